@@ -0,0 +1,71 @@
+// Command airports replaces the airport dataset embedded in the backend with airports read from a local CSV file:
+//
+//	go run ./cmd/airports -input airports.csv
+//
+// The file must have the columns icao,iata,name,country,latitude,longitude,elevation,timezone, where country is an
+// ISO 3166-1 alpha-2 code, elevation is given in feet and timezone is an IANA time zone name. The backend has to be
+// rebuilt for the new dataset to take effect.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/avialog/backend/internal/repository"
+	"github.com/sirupsen/logrus"
+	"os"
+	"time"
+	_ "time/tzdata"
+)
+
+func main() {
+	input := flag.String("input", "", "path of the CSV file with airports")
+	output := flag.String("output", repository.AirportsFile, "path of the embedded dataset")
+	flag.Parse()
+
+	if *input == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	count, err := updateAirports(*input, *output)
+	if err != nil {
+		logrus.Fatal(err)
+	}
+	logrus.Infof("wrote %d airports to %s", count, *output)
+}
+
+func updateAirports(input, output string) (int, error) {
+	file, err := os.Open(input)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	airports, err := repository.ParseAirports(file)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %v", input, err)
+	}
+	for _, airport := range airports {
+		if _, err := time.LoadLocation(airport.Timezone); err != nil {
+			return 0, fmt.Errorf("%s: airport %s: invalid timezone: %v", input, airport.ICAOCode, airport.Timezone)
+		}
+	}
+
+	// the dataset is written to a temporary file first, so a failed update doesn't leave it truncated
+	temporary := output + ".tmp"
+	outputFile, err := os.Create(temporary)
+	if err != nil {
+		return 0, err
+	}
+	if err := repository.WriteAirports(outputFile, airports); err != nil {
+		outputFile.Close()
+		os.Remove(temporary)
+		return 0, err
+	}
+	if err := outputFile.Close(); err != nil {
+		os.Remove(temporary)
+		return 0, err
+	}
+
+	return len(airports), os.Rename(temporary, output)
+}
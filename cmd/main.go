@@ -3,10 +3,15 @@ package main
 import (
 	"context"
 	firebase "firebase.google.com/go/v4"
+	"firebase.google.com/go/v4/messaging"
+	"fmt"
 	_ "github.com/avialog/backend/docs"
 	"github.com/avialog/backend/internal/config"
 	"github.com/avialog/backend/internal/controller"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/notification"
 	"github.com/avialog/backend/internal/repository"
+	"github.com/avialog/backend/internal/scheduler"
 	"github.com/avialog/backend/internal/service"
 	"github.com/avialog/backend/internal/util"
 	"github.com/gin-gonic/gin"
@@ -17,7 +22,9 @@ import (
 	"google.golang.org/api/option"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"net/http"
 	"os"
+	"time"
 )
 
 // @title Avialog API
@@ -54,6 +61,11 @@ func main() {
 		logrus.Panic(err)
 	}
 
+	messagingClient, err := app.Messaging(context.Background())
+	if err != nil {
+		logrus.Panic(err)
+	}
+
 	db, err := gorm.Open(postgres.Open(cfg.DSN), &gorm.Config{})
 	if err != nil {
 		logrus.Panic(err)
@@ -65,10 +77,25 @@ func main() {
 	if err != nil {
 		logrus.Panic(err)
 	}
-	services := service.NewServices(repositories, cfg, util.GetValidator(), authClient)
+	// redirects are not followed, a URL on an allowed host must not lead the server to another one
+	httpClient := &http.Client{Timeout: 10 * time.Second, CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	services := service.NewServices(repositories, cfg, util.GetValidator(), authClient, httpClient,
+		newNotifications(cfg, messagingClient))
 	controllers := controller.NewControllers(services, cfg)
 	controllers.Route(server)
 
+	hostname, err := os.Hostname()
+	if err != nil {
+		logrus.Panic(err)
+	}
+	jobs := scheduler.NewScheduler(repositories.JobRun(), hostname)
+	if err := scheduleJobs(jobs, services); err != nil {
+		logrus.Panic(err)
+	}
+	go jobs.Run(context.Background())
+
 	port := "3000"
 	if os.Getenv("PORT") != "" {
 		port = os.Getenv("PORT")
@@ -82,3 +109,77 @@ func main() {
 		logrus.Panic(err)
 	}
 }
+
+// scheduleJobs registers the background jobs. Every replica runs the scheduler, each run is executed by one of them.
+func scheduleJobs(jobs scheduler.Scheduler, services service.Services) error {
+	for _, job := range []scheduler.Job{
+		{
+			Name:     "purge-trash",
+			Schedule: scheduler.MustParse("0 * * * *"),
+			Run: func(ctx context.Context) (string, error) {
+				purgeResult, err := services.Trash().PurgeTrash(ctx)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("purged %d flights, %d landings, %d passengers, %d aircraft and %d contacts from trash",
+					purgeResult.Flights, purgeResult.Landings, purgeResult.Passengers, purgeResult.Aircraft,
+					purgeResult.Contacts), nil
+			},
+		},
+		{
+			Name:     "purge-idempotency-keys",
+			Schedule: scheduler.MustParse("5 * * * *"),
+			Run: func(ctx context.Context) (string, error) {
+				purgedKeys, err := services.Idempotency().PurgeExpired(ctx)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("purged %d expired idempotency keys", purgedKeys), nil
+			},
+		},
+		{
+			Name:     "send-reminders",
+			Schedule: scheduler.MustParse("30 * * * *"),
+			Run: func(ctx context.Context) (string, error) {
+				reminderResult, err := services.Reminder().SendReminders(ctx)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("sent %d reminders to %d users, %d failed",
+					reminderResult.Sent, reminderResult.Users, reminderResult.Failed), nil
+			},
+		},
+		{
+			Name:     "purge-job-runs",
+			Schedule: scheduler.MustParse("@daily"),
+			Run: func(ctx context.Context) (string, error) {
+				purgedRuns, err := services.Job().PurgeRuns(ctx)
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("purged %d job runs", purgedRuns), nil
+			},
+		},
+	} {
+		if err := jobs.Register(job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newNotifications registers the notification channels. Emails are only sent if an SMTP server is configured. Webhooks
+// get a client of their own, as their URLs are chosen by users.
+func newNotifications(cfg config.Config, messagingClient *messaging.Client) notification.Registry {
+	notifications := notification.NewRegistry()
+	notifications.Register(model.NotificationChannelPush, notification.NewPushChannel(messagingClient))
+	notifications.Register(model.NotificationChannelWebhook,
+		notification.NewWebhookChannel(notification.NewWebhookHTTPClient(10*time.Second)))
+	if cfg.SMTPHost != "" {
+		notifications.Register(model.NotificationChannelEmail, notification.NewEmailChannel(cfg.SMTPHost, cfg.SMTPPort,
+			cfg.SMTPUsername, cfg.SMTPPassword, cfg.SMTPFrom))
+	} else {
+		logrus.Warn("SMTP_HOST is not set, reminders will not be sent by email")
+	}
+	return notifications
+}
@@ -31,6 +31,14 @@ const docTemplate = `{
                     "aircraft"
                 ],
                 "summary": "Get user aircraft (all)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ETag of the aircraft the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -38,6 +46,9 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_avialog_backend_internal_dto.AircraftResponse"
                         }
                     },
+                    "304": {
+                        "description": "Not modified"
+                    },
                     "404": {
                         "description": "Not Found",
                         "schema": {
@@ -58,7 +69,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update aircraft",
+                "description": "Update aircraft, with If-Match only if it was not changed since the client got that version",
                 "consumes": [
                     "application/json"
                 ],
@@ -77,6 +88,12 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "ETag of the aircraft the change is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
                     {
                         "description": "Aircraft",
                         "name": "aircraft",
@@ -106,6 +123,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
                         }
                     },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -225,6 +248,122 @@ const docTemplate = `{
                 }
             }
         },
+        "/aircraft/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a single aircraft of the user, the ETag of the response can be passed as If-Match to update it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "aircraft"
+                ],
+                "summary": "Get aircraft",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Aircraft ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the aircraft the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.AircraftResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/airports": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Search airports by ICAO code, IATA code or name for autocomplete. Exact code matches are returned first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "airports"
+                ],
+                "summary": "Search airports",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ICAO code, IATA code or part of the name",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of airports, 10 by default, at most 50",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_avialog_backend_internal_dto.AirportResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
         "/contacts": {
             "get": {
                 "security": [
@@ -240,6 +379,14 @@ const docTemplate = `{
                     "contacts"
                 ],
                 "summary": "Get user contacts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ETag of the contacts the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -250,6 +397,9 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "304": {
+                        "description": "Not modified"
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -309,13 +459,72 @@ const docTemplate = `{
             }
         },
         "/contacts/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a single contact of a user, the ETag of the response can be passed as If-Match to update it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "contacts"
+                ],
+                "summary": "Get a contact",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Contact ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the contact the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.ContactResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            },
             "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update an existing contact for a user",
+                "description": "Update an existing contact for a user, with If-Match only if it was not changed since the client got that version",
                 "consumes": [
                     "application/json"
                 ],
@@ -334,6 +543,12 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "ETag of the contact the change is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
                     {
                         "description": "Contact information to update",
                         "name": "contactRequest",
@@ -363,6 +578,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
                         }
                     },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -430,6 +651,40 @@ const docTemplate = `{
                 }
             }
         },
+        "/currency": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get passenger, night passenger and instrument currency of a user per aircraft category",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "currency"
+                ],
+                "summary": "Get user recency and currency",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_avialog_backend_internal_dto.CurrencyResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
         "/healthz": {
             "get": {
                 "description": "Returns the health status of the server",
@@ -450,28 +705,42 @@ const docTemplate = `{
                 }
             }
         },
-        "/logbook": {
+        "/jobs/runs": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get a list of logbook entries for a user",
+                "description": "Get the most recently scheduled runs of background jobs with the replica that executed them, the\nnumber of attempts and their outcome, only for admins",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "logbook"
+                    "jobs"
+                ],
+                "summary": "Get recent background job runs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Name of the job, all jobs if empty",
+                        "name": "job",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of runs, 50 by default and at most 200",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get user logbook entries",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LogbookResponse"
+                                "$ref": "#/definitions/github_com_avialog_backend_internal_dto.JobRunResponse"
                             }
                         }
                     },
@@ -481,6 +750,46 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/licences": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get all pilot licences of a user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "licences"
+                ],
+                "summary": "Get user licences",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LicenceResponse"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -495,7 +804,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Insert a new logbook entry for a user",
+                "description": "Insert a new pilot licence for a user",
                 "consumes": [
                     "application/json"
                 ],
@@ -503,17 +812,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "logbook"
+                    "licences"
                 ],
-                "summary": "Insert a new logbook entry",
+                "summary": "Insert a new licence",
                 "parameters": [
                     {
-                        "description": "Logbook entry information to insert",
-                        "name": "logbookRequest",
+                        "description": "Licence to insert",
+                        "name": "licenceRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LogbookRequest"
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LicenceRequest"
                         }
                     }
                 ],
@@ -521,7 +830,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LogbookResponse"
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LicenceResponse"
                         }
                     },
                     "400": {
@@ -539,14 +848,95 @@ const docTemplate = `{
                 }
             }
         },
-        "/logbook/{id}": {
-            "put": {
+        "/licences/summary": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the licences and ratings of a user sorted into valid ones, ones expiring soon and lapsed ones",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "licences"
+                ],
+                "summary": "Get expiry summary of licences and ratings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.ExpirySummaryResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/licences/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a single pilot licence of a user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "licences"
+                ],
+                "summary": "Get a licence",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Licence ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LicenceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update an existing logbook entry for a user",
+                "description": "Update an existing pilot licence of a user",
                 "consumes": [
                     "application/json"
                 ],
@@ -554,24 +944,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "logbook"
+                    "licences"
                 ],
-                "summary": "Update an existing logbook entry",
+                "summary": "Update an existing licence",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Flight ID to update",
+                        "description": "Licence ID to update",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Logbook entry information to update",
-                        "name": "logbookRequest",
+                        "description": "Licence information to update",
+                        "name": "licenceRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LogbookRequest"
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LicenceRequest"
                         }
                     }
                 ],
@@ -579,7 +969,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LogbookResponse"
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LicenceResponse"
                         }
                     },
                     "400": {
@@ -588,6 +978,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -602,18 +998,18 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Delete an existing logbook entry for a user",
+                "description": "Delete a pilot licence of a user, licences with endorsed ratings cannot be deleted",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "logbook"
+                    "licences"
                 ],
-                "summary": "Delete an existing logbook entry",
+                "summary": "Delete an existing licence",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Flight ID to delete",
+                        "description": "Licence ID to delete",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -621,7 +1017,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Logbook entry deleted successfully",
+                        "description": "Licence deleted successfully",
                         "schema": {
                             "type": "object",
                             "properties": {
@@ -643,6 +1039,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -652,26 +1054,103 @@ const docTemplate = `{
                 }
             }
         },
-        "/profile": {
+        "/logbook": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Get a user by userID from the token",
+                "description": "Get a page of logbook entries for a user sorted by takeoff time, optionally filtered. Pass next_cursor of a page as cursor to get the next page.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "profile"
+                    "logbook"
+                ],
+                "summary": "Get user logbook entries",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Start of the date range (unix timestamp)",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End of the date range (unix timestamp)",
+                        "name": "end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Aircraft ID",
+                        "name": "aircraft_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ICAO or IATA code of the takeoff or landing airport",
+                        "name": "airport_code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role of the user during the flight",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Style of the flight",
+                        "name": "style",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Text contained in remarks or personal remarks",
+                        "name": "remarks",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order of takeoff time, asc or desc (default)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of entries per page, 50 by default and at most 200",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the next page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the page the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
                 ],
-                "summary": "Get a user",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.UserResponse"
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LogbookPageResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
                         }
                     },
                     "500": {
@@ -682,13 +1161,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Update user profile information",
+                "description": "Insert a new logbook entry for a user",
                 "consumes": [
                     "application/json"
                 ],
@@ -696,25 +1175,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "profile"
+                    "logbook"
                 ],
-                "summary": "Update user profile",
+                "summary": "Insert a new logbook entry",
                 "parameters": [
                     {
-                        "description": "User profile information to update",
-                        "name": "userRequest",
+                        "description": "Logbook entry information to insert",
+                        "name": "logbookRequest",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.UserRequest"
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LogbookRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.UserResponse"
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LogbookResponse"
                         }
                     },
                     "400": {
@@ -723,6 +1202,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -731,230 +1216,3919 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_avialog_backend_internal_dto.AircraftRequest": {
-            "type": "object",
-            "required": [
-                "aircraft_model",
-                "registration_number"
-            ],
-            "properties": {
-                "aircraft_model": {
-                    "type": "string"
-                },
-                "image_url": {
-                    "type": "string"
-                },
-                "registration_number": {
-                    "type": "string"
-                },
-                "remarks": {
-                    "type": "string"
+        },
+        "/logbook/batch": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Apply up to 100 operations in the order of the request and return the result of each of them. An atomic batch is applied entirely or not at all, otherwise every operation which succeeds is applied.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "logbook"
+                ],
+                "summary": "Create, update and delete logbook entries in a batch",
+                "parameters": [
+                    {
+                        "description": "Operations to apply",
+                        "name": "batchRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LogbookBatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LogbookBatchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
                 }
             }
         },
-        "github_com_avialog_backend_internal_dto.AircraftResponse": {
-            "type": "object",
-            "required": [
-                "aircraft_model",
-                "registration_number"
-            ],
-            "properties": {
-                "aircraft_model": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
-                "image_url": {
-                    "type": "string"
-                },
-                "registration_number": {
-                    "type": "string"
-                },
-                "remarks": {
-                    "type": "string"
+        "/logbook/duplicates": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get groups of logbook entries of the same route taking off within 5 minutes of each other",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "logbook"
+                ],
+                "summary": "Get likely duplicate logbook entries",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_avialog_backend_internal_dto.DuplicateGroupResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/logbook/export": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Export user logbook as a document. PDF export contains the whole logbook in the EASA FCL.050 layout, CSV export contains flights from the optional date range with the selected columns, durations are exported both as HH:MM and decimal hours",
+                "produces": [
+                    "application/pdf",
+                    "text/csv"
+                ],
+                "tags": [
+                    "logbook"
+                ],
+                "summary": "Export user logbook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "pdf",
+                        "description": "Export format (pdf, csv)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Start of the date range (unix timestamp), csv only",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End of the date range (unix timestamp), csv only",
+                        "name": "end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated list of columns, csv only",
+                        "name": "columns",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/logbook/import": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Import flights from ForeFlight (foreflight), LogTen Pro (logten), mccPilotLog (mccpilotlog) or a generic CSV file (csv) in a single transaction. Missing aircraft are created by registration. Generic CSV columns are mapped with a JSON object mapping logbook fields (the column names of the CSV export) to column names of the file.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "logbook"
+                ],
+                "summary": "Import logbook from another application",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "Exported logbook",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Format of the file (foreflight, logten, mccpilotlog, csv)",
+                        "name": "format",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Column mapping of the generic CSV format as JSON object",
+                        "name": "mapping",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.ImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/logbook/night": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Compute night time of a flight from the civil twilight along the great circle route and the split of its landings between day and night, as filled in when a logbook entry is saved without them",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "logbook"
+                ],
+                "summary": "Preview night time of a flight",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ICAO or IATA code of the takeoff airport",
+                        "name": "takeoff_airport_code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Takeoff time (unix timestamp)",
+                        "name": "takeoff_time",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ICAO or IATA code of the landing airport",
+                        "name": "landing_airport_code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Landing time (unix timestamp)",
+                        "name": "landing_time",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of landings, 1 by default",
+                        "name": "landings",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.NightTimeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/logbook/totals": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the sum of every time column and landing counts of user logbook, optionally restricted to a date range, aircraft or role",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "logbook"
+                ],
+                "summary": "Get user logbook totals",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Start of the date range (unix timestamp)",
+                        "name": "start",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "End of the date range (unix timestamp)",
+                        "name": "end",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Aircraft ID",
+                        "name": "aircraft_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role of the user during the flight",
+                        "name": "role",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.TotalsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/logbook/verify": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Recompute the hash chain of the logbook history and compare every entry with its latest version. Keep the returned head to detect removal of the latest versions later.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "logbook"
+                ],
+                "summary": "Verify the integrity of the logbook",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LogbookVerificationResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/logbook/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a single logbook entry of a user with its aircraft, passengers and landings, the ETag of the response can be passed as If-Match to update it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "logbook"
+                ],
+                "summary": "Get a logbook entry",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Flight ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the entry the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LogbookResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update an existing logbook entry for a user, signed entries can only be amended. With If-Match the entry is only updated if it was not changed since the client got that version",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "logbook"
+                ],
+                "summary": "Update an existing logbook entry",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Flight ID to update",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the entry the change is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Logbook entry information to update",
+                        "name": "logbookRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LogbookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LogbookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete an existing logbook entry for a user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "logbook"
+                ],
+                "summary": "Delete an existing logbook entry",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Flight ID to delete",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Logbook entry deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/logbook/{id}/amend": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Change a logbook entry of a user which may be signed, the signature of the entry is invalidated. With If-Match the entry is only amended if it was not changed since the client got that version",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "logbook"
+                ],
+                "summary": "Amend a logbook entry",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Flight ID to amend",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the entry the change is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Amended logbook entry information",
+                        "name": "logbookRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LogbookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LogbookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/logbook/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get every recorded version of a logbook entry from the oldest one, with the fields changed since the previous version. The history of a deleted entry is kept.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "logbook"
+                ],
+                "summary": "Get the history of a logbook entry",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Flight ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_avialog_backend_internal_dto.FlightVersionResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/logbook/{id}/signature": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Ask an instructor, a contact or another user identified by email, to countersign a logbook entry. The token of a request to a contact is emailed to the contact, who signs without an account.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "signatures"
+                ],
+                "summary": "Request a signature of a logbook entry",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Flight ID to sign",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Instructor to ask for the signature",
+                        "name": "signatureRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SignatureRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SignatureResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/medicals": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get all medical certificates of a user with the validity of their privileges, which depends on the\nrule set, the class and the age at the examination. Warnings list the privileges of the most recent\ncertificate of each rule set that have lapsed or expire soon. Validity is only given if the profile\nhas a date of birth.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "medicals"
+                ],
+                "summary": "Get user medical certificates",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.MedicalsResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Insert a new medical certificate for a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "medicals"
+                ],
+                "summary": "Insert a new medical certificate",
+                "parameters": [
+                    {
+                        "description": "Medical certificate to insert",
+                        "name": "medicalRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.MedicalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.MedicalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/medicals/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update an existing medical certificate of a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "medicals"
+                ],
+                "summary": "Update an existing medical certificate",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Medical certificate ID to update",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Medical certificate information to update",
+                        "name": "medicalRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.MedicalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.MedicalResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a medical certificate of a user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "medicals"
+                ],
+                "summary": "Delete an existing medical certificate",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Medical certificate ID to delete",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Medical certificate deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/profile": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a user by userID from the token",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Get a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ETag of the profile the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.UserResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Not modified"
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update user profile information, with If-Match only if it was not changed since the client got that version",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "profile"
+                ],
+                "summary": "Update user profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ETag of the profile the change is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "User profile information to update",
+                        "name": "userRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.UserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.UserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/progress/{licence}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get each minimum experience requirement of an aeroplane licence or the instrument rating with the\namount logged by the user and the remainder. Durations are in nanoseconds, like the logbook totals.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "progress"
+                ],
+                "summary": "Get progress towards a licence",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Licence, one of PPL, CPL, IR and ATPL",
+                        "name": "licence",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "EASA",
+                            "FAA"
+                        ],
+                        "type": "string",
+                        "description": "Rule set, EASA by default",
+                        "name": "rule_set",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.ProgressResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/ratings": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get all class, type, instrument and instructor ratings of a user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ratings"
+                ],
+                "summary": "Get user ratings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_avialog_backend_internal_dto.RatingResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Insert a new class, type, instrument or instructor rating, optionally endorsed on one of the licences, for a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ratings"
+                ],
+                "summary": "Insert a new rating",
+                "parameters": [
+                    {
+                        "description": "Rating to insert",
+                        "name": "ratingRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.RatingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.RatingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/ratings/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a single rating of a user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ratings"
+                ],
+                "summary": "Get a rating",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rating ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.RatingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Update an existing rating of a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ratings"
+                ],
+                "summary": "Update an existing rating",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rating ID to update",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rating information to update",
+                        "name": "ratingRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.RatingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.RatingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a rating of a user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ratings"
+                ],
+                "summary": "Delete an existing rating",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Rating ID to delete",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rating deleted successfully",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "message": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/reminders/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get what the user is reminded of before it lapses, how many days before and over which channels. Users\nwho have not chosen yet get the defaults with all channels disabled.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reminders"
+                ],
+                "summary": "Get reminder preferences",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.ReminderPreferenceResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Choose what the user is reminded of before it lapses, how many days before and over which channels.\nPush notifications need the device token and webhooks an HTTPS URL.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reminders"
+                ],
+                "summary": "Update reminder preferences",
+                "parameters": [
+                    {
+                        "description": "Reminder preferences",
+                        "name": "preferenceRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.ReminderPreferenceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.ReminderPreferenceResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/signatures": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the requests waiting for the signature of the user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "signatures"
+                ],
+                "summary": "Get signature requests",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SignatureResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/signatures/token/{token}": {
+            "get": {
+                "description": "Get the signature request with the token together with the logbook entry to sign, no account is needed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "signatures"
+                ],
+                "summary": "Review a signature request of a contact",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Signature request token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SignatureReviewResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/signatures/token/{token}/reject": {
+            "post": {
+                "description": "Reject the signature request with the token, no account is needed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "signatures"
+                ],
+                "summary": "Reject a signature request as a contact",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Signature request token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Remarks of the instructor",
+                        "name": "decisionRequest",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SignatureDecisionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SignatureResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/signatures/token/{token}/sign": {
+            "post": {
+                "description": "Sign the logbook entry of the signature request with the token, no account is needed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "signatures"
+                ],
+                "summary": "Sign a logbook entry as a contact",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Signature request token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Remarks of the instructor",
+                        "name": "decisionRequest",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SignatureDecisionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SignatureResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/signatures/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a signature request addressed to the user together with the logbook entry to sign",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "signatures"
+                ],
+                "summary": "Review a signature request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Signature request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SignatureReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/signatures/{id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Reject the signature request addressed to the user, the remarks tell the pilot what to correct",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "signatures"
+                ],
+                "summary": "Reject a signature request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Signature request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Remarks of the instructor",
+                        "name": "decisionRequest",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SignatureDecisionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SignatureResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/signatures/{id}/sign": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Sign the logbook entry of a signature request addressed to the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "signatures"
+                ],
+                "summary": "Sign a logbook entry",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Signature request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Remarks of the instructor",
+                        "name": "decisionRequest",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SignatureDecisionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SignatureResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/sync": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the logbook entries, aircraft and contacts created, updated or deleted and the profile if it changed since the token was issued. Without a token, or with a token older than the retention period of the trash, every item is returned with reset set and the local copy has to be replaced. The returned token is passed to the next request.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sync"
+                ],
+                "summary": "Get changes since the last synchronization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Token of the previous synchronization",
+                        "name": "since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SyncResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Apply logbook entries, aircraft, contacts and profile changed on a device. Changes of existing items carry the updated_at of the version they are based on. If the item changed on the server since then, the change is not applied and the server version is returned as a conflict.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sync"
+                ],
+                "summary": "Push changes made offline",
+                "parameters": [
+                    {
+                        "description": "Changes to apply",
+                        "name": "pushRequest",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SyncPushRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SyncPushResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the deleted logbook entries, aircraft and contacts of a user with the time of deletion. They can be restored until they are purged after the retention period.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Get the trash",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_dto.TrashResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/aircraft/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Restore a deleted aircraft of a user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Restore an aircraft",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Aircraft ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/contacts/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Restore a deleted contact of a user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Restore a contact",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Contact ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/trash/flights/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Restore a deleted logbook entry together with its landings and passengers. The aircraft of the entry has to be restored first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "trash"
+                ],
+                "summary": "Restore a logbook entry",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Flight ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_avialog_backend_internal_util.HTTPError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_avialog_backend_internal_dto.AircraftRequest": {
+            "type": "object",
+            "required": [
+                "aircraft_model",
+                "registration_number"
+            ],
+            "properties": {
+                "aircraft_model": {
+                    "type": "string"
+                },
+                "category": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.AircraftCategory"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "registration_number": {
+                    "type": "string"
+                },
+                "remarks": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.AircraftResponse": {
+            "type": "object",
+            "required": [
+                "aircraft_model",
+                "registration_number"
+            ],
+            "properties": {
+                "aircraft_model": {
+                    "type": "string"
+                },
+                "category": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.AircraftCategory"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "registration_number": {
+                    "type": "string"
+                },
+                "remarks": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.AircraftSummary": {
+            "type": "object",
+            "properties": {
+                "aircraft_model": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "registration_number": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.AirportResponse": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "elevation": {
+                    "type": "integer"
+                },
+                "iata_code": {
+                    "type": "string"
+                },
+                "icao_code": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.BatchAction": {
+            "type": "string",
+            "enum": [
+                "create",
+                "update",
+                "delete"
+            ],
+            "x-enum-varnames": [
+                "BatchActionCreate",
+                "BatchActionUpdate",
+                "BatchActionDelete"
+            ]
+        },
+        "github_com_avialog_backend_internal_dto.BatchStatus": {
+            "type": "string",
+            "enum": [
+                "created",
+                "updated",
+                "deleted",
+                "failed",
+                "aborted"
+            ],
+            "x-enum-varnames": [
+                "BatchStatusCreated",
+                "BatchStatusUpdated",
+                "BatchStatusDeleted",
+                "BatchStatusFailed",
+                "BatchStatusAborted"
+            ]
+        },
+        "github_com_avialog_backend_internal_dto.ConflictKind": {
+            "type": "string",
+            "enum": [
+                "duplicate",
+                "overlap"
+            ],
+            "x-enum-varnames": [
+                "ConflictDuplicate",
+                "ConflictOverlap"
+            ]
+        },
+        "github_com_avialog_backend_internal_dto.ContactRequest": {
+            "type": "object",
+            "required": [
+                "first_name"
+            ],
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "company": {
+                    "type": "string"
+                },
+                "email_address": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.ContactResponse": {
+            "type": "object",
+            "required": [
+                "first_name"
+            ],
+            "properties": {
+                "avatar_url": {
+                    "type": "string"
+                },
+                "company": {
+                    "type": "string"
+                },
+                "email_address": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.CurrencyRequirement": {
+            "type": "object",
+            "properties": {
+                "logged": {
+                    "type": "integer"
+                },
+                "missing": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "required": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.CurrencyResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.AircraftCategory"
+                },
+                "instrument": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.CurrencyStatus"
+                },
+                "night_passenger": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.CurrencyStatus"
+                },
+                "passenger": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.CurrencyStatus"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.CurrencyStatus": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "boolean"
+                },
+                "lapses_at": {
+                    "type": "string"
+                },
+                "requirements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.CurrencyRequirement"
+                    }
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.DuplicateGroupResponse": {
+            "type": "object",
+            "properties": {
+                "flights": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.FlightSummary"
+                    }
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.ExpiryItem": {
+            "type": "object",
+            "properties": {
+                "days_left": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.ExpiryItemType"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.ExpiryItemType": {
+            "type": "string",
+            "enum": [
+                "LICENCE",
+                "RATING"
+            ],
+            "x-enum-varnames": [
+                "ExpiryItemTypeLicence",
+                "ExpiryItemTypeRating"
+            ]
+        },
+        "github_com_avialog_backend_internal_dto.ExpirySummaryResponse": {
+            "type": "object",
+            "properties": {
+                "expiring_soon": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.ExpiryItem"
+                    }
+                },
+                "lapsed": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.ExpiryItem"
+                    }
+                },
+                "valid": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.ExpiryItem"
+                    }
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.FieldChange": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "new": {
+                    "type": "object"
+                },
+                "old": {
+                    "type": "object"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.FieldError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.FlightConflict": {
+            "type": "object",
+            "properties": {
+                "flight": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.FlightSummary"
+                },
+                "kind": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.ConflictKind"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.FlightSummary": {
+            "type": "object",
+            "properties": {
+                "aircraft_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "landing_airport_code": {
+                    "type": "string"
+                },
+                "landing_time": {
+                    "type": "string"
+                },
+                "takeoff_airport_code": {
+                    "type": "string"
+                },
+                "takeoff_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.FlightVersionResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.FlightVersionAction"
+                },
+                "changes": {
+                    "description": "Changes lists the fields changed since the previous version of the entry, it is empty for the first version.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.FieldChange"
+                    }
+                },
+                "flight_id": {
+                    "type": "integer"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "previous_hash": {
+                    "type": "string"
+                },
+                "recorded_at": {
+                    "type": "string"
+                },
+                "sequence": {
+                    "type": "integer"
+                },
+                "snapshot": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LogbookSnapshot"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.ImportResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "created_aircraft": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.ImportRowResult"
+                    }
+                },
+                "skipped": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.ImportRowResult": {
+            "type": "object",
+            "properties": {
+                "flight_id": {
+                    "type": "integer"
+                },
+                "line": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.ImportRowStatus"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.ImportRowStatus": {
+            "type": "string",
+            "enum": [
+                "created",
+                "skipped",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "ImportRowCreated",
+                "ImportRowSkipped",
+                "ImportRowFailed"
+            ]
+        },
+        "github_com_avialog_backend_internal_dto.JobRunResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "job": {
+                    "type": "string"
+                },
+                "replica": {
+                    "type": "string"
+                },
+                "scheduled_at": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.JobRunStatus"
+                },
+                "summary": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.LandingEntry": {
+            "type": "object",
+            "properties": {
+                "airport_code": {
+                    "type": "string"
+                },
+                "approach_type": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.ApproachType"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "day_count": {
+                    "type": "integer"
+                },
+                "night_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.LandingResponse": {
+            "type": "object",
+            "properties": {
+                "airport_code": {
+                    "type": "string"
+                },
+                "approach_type": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.ApproachType"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "day_count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "night_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.LicenceRequest": {
+            "type": "object",
+            "required": [
+                "issuing_state",
+                "number",
+                "type"
+            ],
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "issued_at": {
+                    "type": "string"
+                },
+                "issuing_state": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "remarks": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.LicenceType"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.LicenceResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "issued_at": {
+                    "type": "string"
+                },
+                "issuing_state": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "remarks": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.LicenceType"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.LogbookBatchOperation": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.BatchAction"
+                },
+                "entry": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LogbookRequest"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.LogbookBatchRequest": {
+            "type": "object",
+            "properties": {
+                "atomic": {
+                    "type": "boolean"
+                },
+                "operations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LogbookBatchOperation"
+                    }
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.LogbookBatchResponse": {
+            "type": "object",
+            "properties": {
+                "atomic": {
+                    "type": "boolean"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LogbookBatchResult"
+                    }
+                },
+                "succeeded": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.LogbookBatchResult": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.BatchAction"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "flight_id": {
+                    "type": "integer"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.BatchStatus"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.LogbookPageResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LogbookResponse"
+                    }
+                },
+                "paging": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.PagingResponse"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.LogbookRequest": {
+            "type": "object",
+            "properties": {
+                "aircraft_id": {
+                    "type": "integer"
+                },
+                "allow_conflicts": {
+                    "description": "AllowConflicts stores the entry even if it duplicates or overlaps existing entries.",
+                    "type": "boolean"
+                },
+                "cross_country_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "dual_given_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "dual_received_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "holdings": {
+                    "type": "integer"
+                },
+                "ifr_actual_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "ifr_simulated_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "ifr_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "landing_airport_code": {
+                    "type": "string"
+                },
+                "landing_time": {
+                    "type": "string"
+                },
+                "landings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LandingEntry"
+                    }
+                },
+                "multi_pilot_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "my_role": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.Role"
+                },
+                "night_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "passengers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.PassengerEntry"
+                    }
+                },
+                "personal_remarks": {
+                    "type": "string"
+                },
+                "pilot_in_command_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "remarks": {
+                    "type": "string"
+                },
+                "second_in_command_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "simulator_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "style": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.Style"
+                },
+                "takeoff_airport_code": {
+                    "type": "string"
+                },
+                "takeoff_time": {
+                    "type": "string"
+                },
+                "total_block_time": {
+                    "$ref": "#/definitions/time.Duration"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.LogbookResponse": {
+            "type": "object",
+            "properties": {
+                "aircraft": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.AircraftSummary"
+                },
+                "aircraft_id": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "cross_country_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "dual_given_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "dual_received_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "holdings": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ifr_actual_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "ifr_simulated_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "ifr_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "landing_airport_code": {
+                    "type": "string"
+                },
+                "landing_time": {
+                    "type": "string"
+                },
+                "landings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LandingResponse"
+                    }
+                },
+                "multi_pilot_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "my_role": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.Role"
+                },
+                "night_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "passengers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.PassengerResponse"
+                    }
+                },
+                "personal_remarks": {
+                    "type": "string"
+                },
+                "pilot_in_command_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "remarks": {
+                    "type": "string"
+                },
+                "second_in_command_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "signature": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SignatureResponse"
+                },
+                "signature_url": {
+                    "type": "string"
+                },
+                "simulator_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "style": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.Style"
+                },
+                "takeoff_airport_code": {
+                    "type": "string"
+                },
+                "takeoff_time": {
+                    "type": "string"
+                },
+                "total_block_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.LogbookSnapshot": {
+            "type": "object",
+            "properties": {
+                "aircraft_id": {
+                    "type": "integer"
+                },
+                "cross_country_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "dual_given_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "dual_received_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "holdings": {
+                    "type": "integer"
+                },
+                "ifr_actual_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "ifr_simulated_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "ifr_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "landing_airport_code": {
+                    "type": "string"
+                },
+                "landing_time": {
+                    "type": "string"
+                },
+                "landings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LandingEntry"
+                    }
+                },
+                "multi_pilot_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "my_role": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.Role"
+                },
+                "night_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "passengers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.PassengerEntry"
+                    }
+                },
+                "personal_remarks": {
+                    "type": "string"
+                },
+                "pilot_in_command_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "remarks": {
+                    "type": "string"
+                },
+                "second_in_command_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "signature_url": {
+                    "type": "string"
+                },
+                "simulator_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "style": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.Style"
+                },
+                "takeoff_airport_code": {
+                    "type": "string"
+                },
+                "takeoff_time": {
+                    "type": "string"
+                },
+                "total_block_time": {
+                    "$ref": "#/definitions/time.Duration"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.LogbookVerificationResponse": {
+            "type": "object",
+            "properties": {
+                "head": {
+                    "type": "string"
+                },
+                "problems": {
+                    "description": "Problems lists the versions breaking the chain and the entries which differ from their latest version.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.VerificationProblem"
+                    }
+                },
+                "untracked_flight_ids": {
+                    "description": "UntrackedFlightIDs are entries stored before the history was recorded, they have no version to verify.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "valid": {
+                    "type": "boolean"
+                },
+                "versions": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.MedicalPrivilegeResponse": {
+            "type": "object",
+            "properties": {
+                "days_left": {
+                    "type": "integer"
+                },
+                "lapsed": {
+                    "type": "boolean"
+                },
+                "privilege": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.MedicalPrivilege"
+                },
+                "valid_until": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.MedicalRequest": {
+            "type": "object",
+            "required": [
+                "class",
+                "examined_at",
+                "rule_set"
+            ],
+            "properties": {
+                "class": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.MedicalClass"
+                },
+                "examined_at": {
+                    "type": "string"
+                },
+                "limitations": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "remarks": {
+                    "type": "string"
+                },
+                "rule_set": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.RuleSet"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.MedicalResponse": {
+            "type": "object",
+            "properties": {
+                "age_at_examination": {
+                    "type": "integer"
+                },
+                "class": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.MedicalClass"
+                },
+                "examined_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "limitations": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "privileges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.MedicalPrivilegeResponse"
+                    }
+                },
+                "remarks": {
+                    "type": "string"
+                },
+                "rule_set": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.RuleSet"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.MedicalWarning": {
+            "type": "object",
+            "properties": {
+                "certificate_id": {
+                    "type": "integer"
+                },
+                "days_left": {
+                    "type": "integer"
+                },
+                "lapsed": {
+                    "type": "boolean"
+                },
+                "privilege": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.MedicalPrivilege"
+                },
+                "rule_set": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.RuleSet"
+                },
+                "valid_until": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.MedicalsResponse": {
+            "type": "object",
+            "properties": {
+                "certificates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.MedicalResponse"
+                    }
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.MedicalWarning"
+                    }
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.NightTimeResponse": {
+            "type": "object",
+            "properties": {
+                "day_landings": {
+                    "type": "integer"
+                },
+                "landing_airport_code": {
+                    "type": "string"
+                },
+                "night_landing": {
+                    "type": "boolean"
+                },
+                "night_landings": {
+                    "type": "integer"
+                },
+                "night_time": {
+                    "$ref": "#/definitions/time.Duration"
+                },
+                "takeoff_airport_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.PagingResponse": {
+            "type": "object",
+            "properties": {
+                "has_more": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.PassengerEntry": {
+            "type": "object",
+            "properties": {
+                "company": {
+                    "type": "string"
+                },
+                "email_address": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.Role"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.PassengerResponse": {
+            "type": "object",
+            "properties": {
+                "company": {
+                    "type": "string"
+                },
+                "email_address": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.Role"
                 }
             }
         },
-        "github_com_avialog_backend_internal_dto.ContactRequest": {
+        "github_com_avialog_backend_internal_dto.ProgressRequirement": {
+            "type": "object",
+            "properties": {
+                "logged": {
+                    "type": "integer"
+                },
+                "met": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "remaining": {
+                    "type": "integer"
+                },
+                "required": {
+                    "type": "integer"
+                },
+                "unit": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.ProgressUnit"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.ProgressResponse": {
+            "type": "object",
+            "properties": {
+                "licence": {
+                    "type": "string"
+                },
+                "met": {
+                    "type": "boolean"
+                },
+                "requirements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.ProgressRequirement"
+                    }
+                },
+                "rule_set": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.RuleSet"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.ProgressUnit": {
+            "type": "string",
+            "enum": [
+                "DURATION",
+                "COUNT"
+            ],
+            "x-enum-varnames": [
+                "ProgressUnitDuration",
+                "ProgressUnitCount"
+            ]
+        },
+        "github_com_avialog_backend_internal_dto.RatingRequest": {
             "type": "object",
             "required": [
-                "first_name"
+                "kind",
+                "name"
             ],
             "properties": {
-                "avatar_url": {
+                "expires_at": {
                     "type": "string"
                 },
-                "company": {
+                "issued_at": {
                     "type": "string"
                 },
-                "email_address": {
+                "kind": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.RatingKind"
+                },
+                "licence_id": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "first_name": {
+                "remarks": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.RatingResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
                     "type": "string"
                 },
-                "last_name": {
+                "id": {
+                    "type": "integer"
+                },
+                "issued_at": {
                     "type": "string"
                 },
-                "note": {
+                "kind": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.RatingKind"
+                },
+                "licence_id": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
                 },
-                "phone": {
+                "remarks": {
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "github_com_avialog_backend_internal_dto.ContactResponse": {
+        "github_com_avialog_backend_internal_dto.ReminderPreferenceRequest": {
             "type": "object",
             "required": [
-                "first_name"
+                "days_before"
             ],
             "properties": {
-                "avatar_url": {
+                "currency": {
+                    "type": "boolean"
+                },
+                "days_before": {
+                    "type": "integer"
+                },
+                "device_token": {
                     "type": "string"
                 },
-                "company": {
+                "email_enabled": {
+                    "type": "boolean"
+                },
+                "licences": {
+                    "type": "boolean"
+                },
+                "medicals": {
+                    "type": "boolean"
+                },
+                "push_enabled": {
+                    "type": "boolean"
+                },
+                "webhook_enabled": {
+                    "type": "boolean"
+                },
+                "webhook_url": {
                     "type": "string"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.ReminderPreferenceResponse": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "boolean"
                 },
-                "email_address": {
+                "days_before": {
+                    "type": "integer"
+                },
+                "device_token": {
                     "type": "string"
                 },
-                "first_name": {
+                "email_enabled": {
+                    "type": "boolean"
+                },
+                "licences": {
+                    "type": "boolean"
+                },
+                "medicals": {
+                    "type": "boolean"
+                },
+                "push_enabled": {
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "webhook_enabled": {
+                    "type": "boolean"
+                },
+                "webhook_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.ServerInfo": {
+            "type": "object",
+            "properties": {
+                "healthy": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.SignatureDecisionRequest": {
+            "type": "object",
+            "properties": {
+                "remarks": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.SignatureRequest": {
+            "type": "object",
+            "properties": {
+                "contact_id": {
+                    "type": "integer"
+                },
+                "instructor_email": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.SignatureResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "fields_hash": {
                     "type": "string"
                 },
+                "flight_id": {
+                    "type": "integer"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "last_name": {
+                "remarks": {
                     "type": "string"
                 },
-                "note": {
+                "requester_name": {
                     "type": "string"
                 },
-                "phone": {
+                "signed_at": {
+                    "type": "string"
+                },
+                "signer_contact_id": {
+                    "type": "integer"
+                },
+                "signer_email": {
+                    "type": "string"
+                },
+                "signer_name": {
+                    "type": "string"
+                },
+                "signer_user_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.SignatureStatus"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "github_com_avialog_backend_internal_dto.LandingEntry": {
+        "github_com_avialog_backend_internal_dto.SignatureReviewResponse": {
             "type": "object",
             "properties": {
-                "airport_code": {
+                "entry": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LogbookResponse"
+                },
+                "signature": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SignatureResponse"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.SyncAircraftChange": {
+            "type": "object",
+            "properties": {
+                "aircraft": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.AircraftRequest"
+                },
+                "base_updated_at": {
                     "type": "string"
                 },
-                "approach_type": {
-                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.ApproachType"
+                "deleted": {
+                    "type": "boolean"
                 },
-                "count": {
+                "id": {
                     "type": "integer"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.SyncAircraftResult": {
+            "type": "object",
+            "properties": {
+                "aircraft": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.AircraftResponse"
                 },
-                "day_count": {
+                "error": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "night_count": {
+                "index": {
                     "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SyncStatus"
                 }
             }
         },
-        "github_com_avialog_backend_internal_dto.LogbookRequest": {
+        "github_com_avialog_backend_internal_dto.SyncContactChange": {
             "type": "object",
             "properties": {
-                "aircraft_id": {
+                "base_updated_at": {
+                    "type": "string"
+                },
+                "contact": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.ContactRequest"
+                },
+                "deleted": {
+                    "type": "boolean"
+                },
+                "id": {
                     "type": "integer"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.SyncContactResult": {
+            "type": "object",
+            "properties": {
+                "contact": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.ContactResponse"
                 },
-                "cross_country_time": {
-                    "$ref": "#/definitions/time.Duration"
+                "error": {
+                    "type": "string"
                 },
-                "dual_given_time": {
-                    "$ref": "#/definitions/time.Duration"
+                "id": {
+                    "type": "integer"
                 },
-                "dual_received_time": {
-                    "$ref": "#/definitions/time.Duration"
+                "index": {
+                    "type": "integer"
                 },
-                "ifr_actual_time": {
-                    "$ref": "#/definitions/time.Duration"
+                "status": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SyncStatus"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.SyncFlightChange": {
+            "type": "object",
+            "properties": {
+                "base_updated_at": {
+                    "type": "string"
                 },
-                "ifr_simulated_time": {
-                    "$ref": "#/definitions/time.Duration"
+                "deleted": {
+                    "type": "boolean"
                 },
-                "ifr_time": {
-                    "$ref": "#/definitions/time.Duration"
+                "entry": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LogbookRequest"
                 },
-                "landing_airport_code": {
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.SyncFlightResult": {
+            "type": "object",
+            "properties": {
+                "entry": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LogbookResponse"
+                },
+                "error": {
                     "type": "string"
                 },
-                "landing_time": {
+                "id": {
+                    "type": "integer"
+                },
+                "index": {
+                    "type": "integer"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SyncStatus"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.SyncProfileChange": {
+            "type": "object",
+            "properties": {
+                "base_updated_at": {
+                    "type": "string"
+                },
+                "profile": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.UserRequest"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.SyncProfileResult": {
+            "type": "object",
+            "properties": {
+                "error": {
                     "type": "string"
                 },
-                "landings": {
+                "profile": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.UserResponse"
+                },
+                "status": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SyncStatus"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.SyncPushRequest": {
+            "type": "object",
+            "properties": {
+                "aircraft": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SyncAircraftChange"
+                    }
+                },
+                "contacts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SyncContactChange"
+                    }
+                },
+                "flights": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SyncFlightChange"
+                    }
+                },
+                "profile": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SyncProfileChange"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.SyncPushResponse": {
+            "type": "object",
+            "properties": {
+                "aircraft": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LandingEntry"
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SyncAircraftResult"
                     }
                 },
-                "multi_pilot_time": {
-                    "$ref": "#/definitions/time.Duration"
-                },
-                "night_time": {
-                    "$ref": "#/definitions/time.Duration"
+                "contacts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SyncContactResult"
+                    }
                 },
-                "passengers": {
+                "flights": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.PassengerEntry"
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SyncFlightResult"
                     }
                 },
-                "personal_remarks": {
-                    "type": "string"
+                "profile": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.SyncProfileResult"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.SyncResponse": {
+            "type": "object",
+            "properties": {
+                "aircraft": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.AircraftResponse"
+                    }
                 },
-                "pilot_in_command_time": {
-                    "$ref": "#/definitions/time.Duration"
+                "contacts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.ContactResponse"
+                    }
                 },
-                "remarks": {
-                    "type": "string"
+                "deleted_aircraft": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "second_in_command_time": {
-                    "$ref": "#/definitions/time.Duration"
+                "deleted_contacts": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "signature_url": {
-                    "type": "string"
+                "deleted_flights": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "simulator_time": {
-                    "$ref": "#/definitions/time.Duration"
+                "flights": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LogbookResponse"
+                    }
                 },
-                "style": {
-                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.Style"
+                "profile": {
+                    "$ref": "#/definitions/github_com_avialog_backend_internal_dto.UserResponse"
                 },
-                "takeoff_airport_code": {
-                    "type": "string"
+                "reset": {
+                    "type": "boolean"
                 },
-                "takeoff_time": {
+                "token": {
                     "type": "string"
-                },
-                "total_block_time": {
-                    "$ref": "#/definitions/time.Duration"
                 }
             }
         },
-        "github_com_avialog_backend_internal_dto.LogbookResponse": {
+        "github_com_avialog_backend_internal_dto.SyncStatus": {
+            "type": "string",
+            "enum": [
+                "applied",
+                "conflict",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "SyncStatusApplied",
+                "SyncStatusConflict",
+                "SyncStatusFailed"
+            ]
+        },
+        "github_com_avialog_backend_internal_dto.TotalsResponse": {
             "type": "object",
             "properties": {
-                "aircraft_id": {
-                    "type": "integer"
-                },
                 "cross_country_time": {
                     "$ref": "#/definitions/time.Duration"
                 },
+                "day_landing_count": {
+                    "type": "integer"
+                },
                 "dual_given_time": {
                     "$ref": "#/definitions/time.Duration"
                 },
                 "dual_received_time": {
                     "$ref": "#/definitions/time.Duration"
                 },
+                "flight_count": {
+                    "type": "integer"
+                },
                 "ifr_actual_time": {
                     "$ref": "#/definitions/time.Duration"
                 },
@@ -964,99 +5138,133 @@ const docTemplate = `{
                 "ifr_time": {
                     "$ref": "#/definitions/time.Duration"
                 },
-                "landing_airport_code": {
-                    "type": "string"
-                },
-                "landing_time": {
-                    "type": "string"
-                },
-                "landings": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.LandingEntry"
-                    }
+                "landing_count": {
+                    "type": "integer"
                 },
                 "multi_pilot_time": {
                     "$ref": "#/definitions/time.Duration"
                 },
+                "night_landing_count": {
+                    "type": "integer"
+                },
                 "night_time": {
                     "$ref": "#/definitions/time.Duration"
                 },
-                "passengers": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.PassengerEntry"
-                    }
-                },
-                "personal_remarks": {
-                    "type": "string"
-                },
                 "pilot_in_command_time": {
                     "$ref": "#/definitions/time.Duration"
                 },
-                "remarks": {
-                    "type": "string"
-                },
                 "second_in_command_time": {
                     "$ref": "#/definitions/time.Duration"
                 },
-                "signature_url": {
-                    "type": "string"
-                },
                 "simulator_time": {
                     "$ref": "#/definitions/time.Duration"
                 },
-                "style": {
-                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.Style"
+                "total_block_time": {
+                    "$ref": "#/definitions/time.Duration"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.TrashAircraftResponse": {
+            "type": "object",
+            "properties": {
+                "aircraft_model": {
+                    "type": "string"
                 },
-                "takeoff_airport_code": {
+                "deleted_at": {
                     "type": "string"
                 },
-                "takeoff_time": {
+                "id": {
+                    "type": "integer"
+                },
+                "purge_at": {
                     "type": "string"
                 },
-                "total_block_time": {
-                    "$ref": "#/definitions/time.Duration"
+                "registration_number": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_avialog_backend_internal_dto.PassengerEntry": {
+        "github_com_avialog_backend_internal_dto.TrashContactResponse": {
             "type": "object",
             "properties": {
                 "company": {
                     "type": "string"
                 },
-                "email_address": {
+                "deleted_at": {
                     "type": "string"
                 },
                 "first_name": {
                     "type": "string"
                 },
+                "id": {
+                    "type": "integer"
+                },
                 "last_name": {
                     "type": "string"
                 },
-                "note": {
+                "purge_at": {
                     "type": "string"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.TrashFlightResponse": {
+            "type": "object",
+            "properties": {
+                "aircraft_id": {
+                    "type": "integer"
                 },
-                "phone": {
+                "deleted_at": {
                     "type": "string"
                 },
-                "role": {
-                    "$ref": "#/definitions/github_com_avialog_backend_internal_model.Role"
+                "id": {
+                    "type": "integer"
+                },
+                "landing_airport_code": {
+                    "type": "string"
+                },
+                "landing_time": {
+                    "type": "string"
+                },
+                "purge_at": {
+                    "type": "string"
+                },
+                "takeoff_airport_code": {
+                    "type": "string"
+                },
+                "takeoff_time": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_avialog_backend_internal_dto.ServerInfo": {
+        "github_com_avialog_backend_internal_dto.TrashResponse": {
             "type": "object",
             "properties": {
-                "healthy": {
-                    "type": "boolean"
+                "aircraft": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.TrashAircraftResponse"
+                    }
+                },
+                "contacts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.TrashContactResponse"
+                    }
+                },
+                "flights": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.TrashFlightResponse"
+                    }
                 }
             }
         },
         "github_com_avialog_backend_internal_dto.UserRequest": {
             "type": "object",
             "properties": {
+                "auto_fill_times": {
+                    "type": "boolean"
+                },
                 "avatar_url": {
                     "type": "string"
                 },
@@ -1069,6 +5277,9 @@ const docTemplate = `{
                 "country": {
                     "type": "string"
                 },
+                "date_of_birth": {
+                    "type": "string"
+                },
                 "first_name": {
                     "type": "string"
                 },
@@ -1095,6 +5306,9 @@ const docTemplate = `{
                 "email"
             ],
             "properties": {
+                "auto_fill_times": {
+                    "type": "boolean"
+                },
                 "avatar_url": {
                     "type": "string"
                 },
@@ -1107,6 +5321,9 @@ const docTemplate = `{
                 "country": {
                     "type": "string"
                 },
+                "date_of_birth": {
+                    "type": "string"
+                },
                 "email": {
                     "type": "string"
                 },
@@ -1127,16 +5344,170 @@ const docTemplate = `{
                 },
                 "timezone": {
                     "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_avialog_backend_internal_dto.VerificationProblem": {
+            "type": "object",
+            "properties": {
+                "flight_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "sequence": {
+                    "type": "integer"
                 }
             }
         },
+        "github_com_avialog_backend_internal_model.AircraftCategory": {
+            "type": "string",
+            "enum": [
+                "AIRPLANE",
+                "ROTORCRAFT",
+                "GLIDER",
+                "POWERED_LIFT",
+                "LIGHTER_THAN_AIR"
+            ],
+            "x-enum-varnames": [
+                "AircraftCategoryAirplane",
+                "AircraftCategoryRotorcraft",
+                "AircraftCategoryGlider",
+                "AircraftCategoryPoweredLift",
+                "AircraftCategoryLighterThanAir"
+            ]
+        },
         "github_com_avialog_backend_internal_model.ApproachType": {
             "type": "string",
             "enum": [
-                "VISUAL"
+                "VISUAL",
+                "ILS",
+                "LOC",
+                "VOR",
+                "NDB",
+                "RNAV",
+                "RNP",
+                "GLS",
+                "PAR"
+            ],
+            "x-enum-varnames": [
+                "ApproachTypeVisual",
+                "ApproachTypeILS",
+                "ApproachTypeLOC",
+                "ApproachTypeVOR",
+                "ApproachTypeNDB",
+                "ApproachTypeRNAV",
+                "ApproachTypeRNP",
+                "ApproachTypeGLS",
+                "ApproachTypePAR"
+            ]
+        },
+        "github_com_avialog_backend_internal_model.FlightVersionAction": {
+            "type": "string",
+            "enum": [
+                "CREATED",
+                "IMPORTED",
+                "UPDATED",
+                "AMENDED",
+                "DELETED",
+                "RESTORED"
+            ],
+            "x-enum-varnames": [
+                "FlightVersionActionCreated",
+                "FlightVersionActionImported",
+                "FlightVersionActionUpdated",
+                "FlightVersionActionAmended",
+                "FlightVersionActionDeleted",
+                "FlightVersionActionRestored"
+            ]
+        },
+        "github_com_avialog_backend_internal_model.JobRunStatus": {
+            "type": "string",
+            "enum": [
+                "RUNNING",
+                "SUCCEEDED",
+                "FAILED"
+            ],
+            "x-enum-varnames": [
+                "JobRunStatusRunning",
+                "JobRunStatusSucceeded",
+                "JobRunStatusFailed"
+            ]
+        },
+        "github_com_avialog_backend_internal_model.LicenceType": {
+            "type": "string",
+            "enum": [
+                "LAPL",
+                "PPL",
+                "SPL",
+                "CPL",
+                "MPL",
+                "ATPL"
+            ],
+            "x-enum-varnames": [
+                "LicenceTypeLAPL",
+                "LicenceTypePPL",
+                "LicenceTypeSPL",
+                "LicenceTypeCPL",
+                "LicenceTypeMPL",
+                "LicenceTypeATPL"
+            ]
+        },
+        "github_com_avialog_backend_internal_model.MedicalClass": {
+            "type": "string",
+            "enum": [
+                "CLASS_1",
+                "CLASS_2",
+                "CLASS_3",
+                "LAPL"
+            ],
+            "x-enum-varnames": [
+                "MedicalClass1",
+                "MedicalClass2",
+                "MedicalClass3",
+                "MedicalClassLAPL"
+            ]
+        },
+        "github_com_avialog_backend_internal_model.MedicalPrivilege": {
+            "type": "string",
+            "enum": [
+                "CLASS_1",
+                "CLASS_2",
+                "LAPL",
+                "ATP",
+                "COMMERCIAL",
+                "PRIVATE"
+            ],
+            "x-enum-varnames": [
+                "MedicalPrivilegeClass1",
+                "MedicalPrivilegeClass2",
+                "MedicalPrivilegeLAPL",
+                "MedicalPrivilegeATP",
+                "MedicalPrivilegeCommercial",
+                "MedicalPrivilegePrivate"
+            ]
+        },
+        "github_com_avialog_backend_internal_model.RatingKind": {
+            "type": "string",
+            "enum": [
+                "CLASS",
+                "TYPE",
+                "INSTRUMENT",
+                "INSTRUCTOR",
+                "EXAMINER",
+                "OTHER"
             ],
             "x-enum-varnames": [
-                "ApproachTypeVisual"
+                "RatingKindClass",
+                "RatingKindType",
+                "RatingKindInstrument",
+                "RatingKindInstructor",
+                "RatingKindExaminer",
+                "RatingKindOther"
             ]
         },
         "github_com_avialog_backend_internal_model.Role": {
@@ -1164,6 +5535,32 @@ const docTemplate = `{
                 "RoleOther"
             ]
         },
+        "github_com_avialog_backend_internal_model.RuleSet": {
+            "type": "string",
+            "enum": [
+                "EASA",
+                "FAA"
+            ],
+            "x-enum-varnames": [
+                "RuleSetEASA",
+                "RuleSetFAA"
+            ]
+        },
+        "github_com_avialog_backend_internal_model.SignatureStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "SIGNED",
+                "REJECTED",
+                "INVALIDATED"
+            ],
+            "x-enum-varnames": [
+                "SignatureStatusPending",
+                "SignatureStatusSigned",
+                "SignatureStatusRejected",
+                "SignatureStatusInvalidated"
+            ]
+        },
         "github_com_avialog_backend_internal_model.Style": {
             "type": "string",
             "enum": [
@@ -1191,6 +5588,18 @@ const docTemplate = `{
                 "code": {
                     "type": "integer"
                 },
+                "conflicts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.FlightConflict"
+                    }
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_avialog_backend_internal_dto.FieldError"
+                    }
+                },
                 "message": {
                     "type": "string"
                 }
@@ -1199,6 +5608,8 @@ const docTemplate = `{
         "time.Duration": {
             "type": "integer",
             "enum": [
+                -9223372036854775808,
+                9223372036854775807,
                 1,
                 1000,
                 1000000,
@@ -1208,9 +5619,13 @@ const docTemplate = `{
                 1,
                 1000,
                 1000000,
-                1000000000
+                1000000000,
+                60000000000,
+                3600000000000
             ],
             "x-enum-varnames": [
+                "minDuration",
+                "maxDuration",
                 "Nanosecond",
                 "Microsecond",
                 "Millisecond",
@@ -1220,7 +5635,9 @@ const docTemplate = `{
                 "Nanosecond",
                 "Microsecond",
                 "Millisecond",
-                "Second"
+                "Second",
+                "Minute",
+                "Hour"
             ]
         }
     },
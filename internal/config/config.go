@@ -3,18 +3,52 @@ package config
 import (
 	"encoding/base64"
 	"errors"
+	"github.com/sirupsen/logrus"
 	"os"
+	"strings"
+	"time"
+)
+
+const (
+	defaultTrashRetention = 30 * 24 * time.Hour
+	defaultExpiryWarning  = 90 * 24 * time.Hour
+	defaultSMTPPort       = "587"
+	// defaultSignatureHosts is where the app uploads signature images.
+	defaultSignatureHosts = "firebasestorage.googleapis.com"
 )
 
 type Config struct {
 	DSN         string `json:"DSN"`
 	FirebaseKey string `json:"firebase_key"`
+	// TrashRetention is how long deleted flights, aircraft and contacts can be restored before they are purged.
+	TrashRetention time.Duration `json:"trash_retention"`
+	// ExpiryWarning is how long before their expiry licences and ratings are reported as expiring soon.
+	ExpiryWarning time.Duration `json:"expiry_warning"`
+	// SMTPHost is the server sending reminder emails, emails are disabled if it is empty.
+	SMTPHost     string `json:"smtp_host"`
+	SMTPPort     string `json:"smtp_port"`
+	SMTPUsername string `json:"smtp_username"`
+	SMTPPassword string `json:"smtp_password"`
+	SMTPFrom     string `json:"smtp_from"`
+	// SignatureHosts are the only hosts signature images are downloaded from for the PDF export.
+	SignatureHosts []string `json:"signature_hosts"`
+	// AdminUserIDs are the users allowed to see the operational endpoints, such as the runs of background jobs.
+	AdminUserIDs []string `json:"admin_user_ids"`
 }
 
 func NewConfig() Config {
 	return Config{
-		DSN:         os.Getenv("DSN"),
-		FirebaseKey: os.Getenv("FIREBASE_KEY"),
+		DSN:            os.Getenv("DSN"),
+		FirebaseKey:    os.Getenv("FIREBASE_KEY"),
+		TrashRetention: durationFromEnv("TRASH_RETENTION", defaultTrashRetention),
+		ExpiryWarning:  durationFromEnv("EXPIRY_WARNING", defaultExpiryWarning),
+		SMTPHost:       os.Getenv("SMTP_HOST"),
+		SMTPPort:       stringFromEnv("SMTP_PORT", defaultSMTPPort),
+		SMTPUsername:   os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:   os.Getenv("SMTP_PASSWORD"),
+		SMTPFrom:       os.Getenv("SMTP_FROM"),
+		SignatureHosts: listFromEnv("SIGNATURE_HOSTS", defaultSignatureHosts),
+		AdminUserIDs:   listFromEnv("ADMIN_USER_IDS", ""),
 	}
 }
 
@@ -26,3 +60,38 @@ func (c Config) DecodeFirebaseKey() ([]byte, error) {
 
 	return key, nil
 }
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		logrus.Warnf("invalid %s %q, using %s", key, value, fallback)
+		return fallback
+	}
+
+	return duration
+}
+
+func stringFromEnv(key string, fallback string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	return value
+}
+
+func listFromEnv(key string, fallback string) []string {
+	var list []string
+	for _, value := range strings.Split(stringFromEnv(key, fallback), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			list = append(list, value)
+		}
+	}
+
+	return list
+}
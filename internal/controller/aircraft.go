@@ -13,6 +13,7 @@ import (
 
 type AircraftController interface {
 	GetAircraft(*gin.Context)
+	GetAircraftByID(*gin.Context)
 	InsertAircraft(*gin.Context)
 	UpdateAircraft(*gin.Context)
 	DeleteAircraft(*gin.Context)
@@ -33,7 +34,9 @@ func newAircraftController(aircraftService service.AircraftService) AircraftCont
 // @Produce json
 // @Security ApiKeyAuth
 // @Success 200 {object} dto.AircraftResponse
+// @Success 304 "Not modified"
 // @Router /aircraft [get]
+// @Param If-None-Match header string false "ETag of the aircraft the client has"
 // @Failure 404 {object} util.HTTPError
 // @Failure 500 {object} util.HTTPError
 func (a *aircraftController) GetAircraft(ctx *gin.Context) {
@@ -48,7 +51,45 @@ func (a *aircraftController) GetAircraft(ctx *gin.Context) {
 
 	aircraftResponse := a.adaptAircraftSlice(aircraft)
 
-	ctx.JSON(http.StatusOK, aircraftResponse)
+	respondWithContentETag(ctx, http.StatusOK, aircraftResponse)
+}
+
+// GetAircraftByID godoc
+// @Summary Get aircraft
+// @Description Get a single aircraft of the user, the ETag of the response can be passed as If-Match to update it
+// @Tags aircraft
+// @Produce json
+// @Security ApiKeyAuth
+// @Success 200 {object} dto.AircraftResponse
+// @Success 304 "Not modified"
+// @Router /aircraft/{id} [get]
+// @Param id path string true "Aircraft ID"
+// @Param If-None-Match header string false "ETag of the aircraft the client has"
+// @Failure 400 {object} util.HTTPError
+// @Failure 404 {object} util.HTTPError
+// @Failure 500 {object} util.HTTPError
+func (a *aircraftController) GetAircraftByID(ctx *gin.Context) {
+	aircraftID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := ctx.GetString("userID")
+
+	aircraft, err := a.aircraftService.GetAircraftByID(userID, uint(aircraftID))
+	if err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			util.NewError(ctx, http.StatusNotFound, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	aircraftResponse := a.adaptAircraft(aircraft)
+
+	respondWithETag(ctx, http.StatusOK, versionETag(aircraft.UpdatedAt), aircraftResponse)
 }
 
 // InsertAircraft godoc
@@ -89,7 +130,7 @@ func (a *aircraftController) InsertAircraft(ctx *gin.Context) {
 
 // UpdateAircraft godoc
 // @Summary Update aircraft
-// @Description Update aircraft
+// @Description Update aircraft, with If-Match only if it was not changed since the client got that version
 // @Tags aircraft
 // @Accept json
 // @Produce json
@@ -97,9 +138,11 @@ func (a *aircraftController) InsertAircraft(ctx *gin.Context) {
 // @Success 200 {object} dto.AircraftResponse
 // @Router /aircraft [put]
 // @Param id path string true "Aircraft ID"
+// @Param If-Match header string false "ETag of the aircraft the change is based on"
 // @Param aircraft body dto.AircraftRequest true "Aircraft"
 // @Failure 400 {object} util.HTTPError
 // @Failure 404 {object} util.HTTPError
+// @Failure 412 {object} util.HTTPError
 // @Failure 500 {object} util.HTTPError
 func (a *aircraftController) UpdateAircraft(ctx *gin.Context) {
 	aircraftID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
@@ -116,11 +159,20 @@ func (a *aircraftController) UpdateAircraft(ctx *gin.Context) {
 		return
 	}
 
-	aircraft, err := a.aircraftService.UpdateAircraft(userID, uint(aircraftID), aircraftRequest)
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		util.NewError(ctx, http.StatusPreconditionFailed, err)
+		return
+	}
+
+	aircraft, err := a.aircraftService.UpdateAircraft(userID, uint(aircraftID), aircraftRequest, version)
 	if err != nil {
 		if errors.Is(err, dto.ErrBadRequest) {
 			util.NewError(ctx, http.StatusBadRequest, err)
 			return
+		} else if errors.Is(err, dto.ErrPreconditionFailed) {
+			util.NewError(ctx, http.StatusPreconditionFailed, err)
+			return
 		}
 		util.NewError(ctx, http.StatusInternalServerError, err)
 		return
@@ -128,7 +180,7 @@ func (a *aircraftController) UpdateAircraft(ctx *gin.Context) {
 
 	aircraftResponse := a.adaptAircraft(aircraft)
 
-	ctx.JSON(http.StatusOK, aircraftResponse)
+	respondWithETag(ctx, http.StatusOK, versionETag(aircraft.UpdatedAt), aircraftResponse)
 }
 
 // DeleteAircraft godoc
@@ -152,7 +204,7 @@ func (a *aircraftController) DeleteAircraft(ctx *gin.Context) {
 	}
 	userID := ctx.GetString("userID")
 
-	err = a.aircraftService.DeleteAircraft(userID, uint(aircraftID))
+	err = a.aircraftService.DeleteAircraft(userID, uint(aircraftID), nil)
 	if err != nil {
 		if errors.Is(err, dto.ErrBadRequest) {
 			util.NewError(ctx, http.StatusBadRequest, err)
@@ -176,8 +228,10 @@ func (a *aircraftController) adaptAircraft(aircraft model.Aircraft) dto.Aircraft
 		ID:                 aircraft.ID,
 		AircraftModel:      aircraft.AircraftModel,
 		RegistrationNumber: aircraft.RegistrationNumber,
+		Category:           aircraft.Category,
 		ImageURL:           aircraft.ImageURL,
 		Remarks:            aircraft.Remarks,
+		UpdatedAt:          aircraft.UpdatedAt,
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/avialog/backend/internal/dto"
 	"github.com/avialog/backend/internal/model"
 	"github.com/avialog/backend/internal/service"
@@ -15,6 +16,7 @@ import (
 	"gorm.io/gorm"
 	"net/http"
 	"net/http/httptest"
+	"time"
 )
 
 var _ = Describe("AircraftController", func() {
@@ -88,6 +90,7 @@ var _ = Describe("AircraftController", func() {
 				expectedServerResponseJSON, err := json.Marshal(expectedServerResponse)
 				Expect(err).NotTo(HaveOccurred())
 
+				ctx.Request = httptest.NewRequest("GET", "/aircraft", nil)
 				ctx.Set("userID", "1")
 
 				aircraftServiceMock.EXPECT().GetUserAircraft("1").Return(aircraftArr, nil)
@@ -202,7 +205,134 @@ var _ = Describe("AircraftController", func() {
 		})
 	})
 
+	Describe("GetAircraftByID", func() {
+		Context("When the aircraft exists", func() {
+			It("Should return 200 and the aircraft with its ETag", func() {
+				// given
+				updatedAt := time.Date(2024, 4, 19, 8, 0, 0, 123456000, time.UTC)
+				aircraftArr[0].UpdatedAt = updatedAt
+				expectedServerResponse[0].UpdatedAt = updatedAt
+				expectedServerResponseJSON, err := json.Marshal(expectedServerResponse[0])
+				Expect(err).NotTo(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/aircraft/1", nil)
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
+
+				aircraftServiceMock.EXPECT().GetAircraftByID("1", uint(1)).Return(aircraftArr[0], nil)
+
+				// when
+				aircraftController.GetAircraftByID(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Header().Get("ETag")).To(Equal(`"1713513600123456"`))
+				Expect(w.Body).To(MatchJSON(expectedServerResponseJSON))
+			})
+		})
+		Context("When the client already has the current version", func() {
+			It("Should return 304 without a body", func() {
+				// given
+				aircraftArr[0].UpdatedAt = time.Date(2024, 4, 19, 8, 0, 0, 123456000, time.UTC)
+
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/aircraft/1", nil)
+				ctx.Request.Header.Set("If-None-Match", `"1713513600123456"`)
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
+
+				aircraftServiceMock.EXPECT().GetAircraftByID("1", uint(1)).Return(aircraftArr[0], nil)
+
+				// when
+				aircraftController.GetAircraftByID(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusNotModified))
+				Expect(w.Body.Len()).To(BeZero())
+			})
+		})
+		Context("When the aircraft does not exist", func() {
+			It("Should return 404 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/aircraft/1", nil)
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
+
+				aircraftServiceMock.EXPECT().GetAircraftByID("1", uint(1)).
+					Return(model.Aircraft{}, fmt.Errorf("%w: %v", dto.ErrNotFound, "record not found"))
+
+				// when
+				aircraftController.GetAircraftByID(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body).To(MatchJSON(`{"code":404,"message":"not found: record not found"}`))
+			})
+		})
+	})
+
 	Describe("UpdateAircraft", func() {
+		Context("When If-Match is given", func() {
+			It("Should update the aircraft only at that version", func() {
+				// given
+				expectedRequestJSON, err := json.Marshal(aircraftRequest)
+				Expect(err).NotTo(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest(http.MethodPut, "/aircraft/1", bytes.NewBuffer(expectedRequestJSON))
+				ctx.Request.Header.Set("If-Match", `"1713513600123456"`)
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
+
+				version := time.UnixMicro(1713513600123456)
+				aircraftServiceMock.EXPECT().UpdateAircraft("1", uint(1), aircraftRequest, &version).Return(aircraftArr[0], nil)
+
+				// when
+				aircraftController.UpdateAircraft(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Header().Get("ETag")).To(Equal(versionETag(aircraftArr[0].UpdatedAt)))
+			})
+		})
+		Context("When the aircraft changed since the version of If-Match", func() {
+			It("Should return 412 and error message", func() {
+				// given
+				expectedRequestJSON, err := json.Marshal(aircraftRequest)
+				Expect(err).NotTo(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest(http.MethodPut, "/aircraft/1", bytes.NewBuffer(expectedRequestJSON))
+				ctx.Request.Header.Set("If-Match", `"1713513600123456"`)
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
+
+				aircraftServiceMock.EXPECT().UpdateAircraft("1", uint(1), aircraftRequest, gomock.Any()).
+					Return(model.Aircraft{}, fmt.Errorf("%w: aircraft changed since the given version", dto.ErrPreconditionFailed))
+
+				// when
+				aircraftController.UpdateAircraft(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusPreconditionFailed))
+				Expect(w.Body).To(MatchJSON(`{"code":412,"message":"precondition failed: aircraft changed since the given version"}`))
+			})
+		})
+		Context("When If-Match is not an ETag of this API", func() {
+			It("Should return 412 without updating the aircraft", func() {
+				// given
+				expectedRequestJSON, err := json.Marshal(aircraftRequest)
+				Expect(err).NotTo(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest(http.MethodPut, "/aircraft/1", bytes.NewBuffer(expectedRequestJSON))
+				ctx.Request.Header.Set("If-Match", `W/"abc"`)
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
+
+				// when
+				aircraftController.UpdateAircraft(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusPreconditionFailed))
+			})
+		})
 		Context("When aircraft exists and everything goes well", func() {
 			It("Should return 200 and the updated aircraft", func() {
 				// given
@@ -217,7 +347,7 @@ var _ = Describe("AircraftController", func() {
 				ctx.Set("userID", "1")
 				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
 
-				aircraftServiceMock.EXPECT().UpdateAircraft("1", uint(1), aircraftRequest).Return(aircraftArr[0], nil)
+				aircraftServiceMock.EXPECT().UpdateAircraft("1", uint(1), aircraftRequest, nil).Return(aircraftArr[0], nil)
 
 				// when
 				aircraftController.UpdateAircraft(ctx)
@@ -274,7 +404,7 @@ var _ = Describe("AircraftController", func() {
 				ctx.Set("userID", "1")
 				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
 
-				aircraftServiceMock.EXPECT().UpdateAircraft("1", uint(1), aircraftRequest).Return(model.Aircraft{}, dto.ErrBadRequest)
+				aircraftServiceMock.EXPECT().UpdateAircraft("1", uint(1), aircraftRequest, nil).Return(model.Aircraft{}, dto.ErrBadRequest)
 
 				// when
 				aircraftController.UpdateAircraft(ctx)
@@ -296,7 +426,7 @@ var _ = Describe("AircraftController", func() {
 				ctx.Set("userID", "1")
 				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
 
-				aircraftServiceMock.EXPECT().UpdateAircraft("1", uint(1), aircraftRequest).Return(model.Aircraft{}, dto.ErrInternalFailure)
+				aircraftServiceMock.EXPECT().UpdateAircraft("1", uint(1), aircraftRequest, nil).Return(model.Aircraft{}, dto.ErrInternalFailure)
 
 				// when
 				aircraftController.UpdateAircraft(ctx)
@@ -318,7 +448,7 @@ var _ = Describe("AircraftController", func() {
 				ctx.Set("userID", "1")
 				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
 
-				aircraftServiceMock.EXPECT().DeleteAircraft("1", uint(1)).Return(nil)
+				aircraftServiceMock.EXPECT().DeleteAircraft("1", uint(1), nil).Return(nil)
 
 				// when
 				aircraftController.DeleteAircraft(ctx)
@@ -347,7 +477,7 @@ var _ = Describe("AircraftController", func() {
 				ctx.Set("userID", "1")
 				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
 
-				aircraftServiceMock.EXPECT().DeleteAircraft("1", uint(1)).Return(dto.ErrBadRequest)
+				aircraftServiceMock.EXPECT().DeleteAircraft("1", uint(1), nil).Return(dto.ErrBadRequest)
 
 				// when
 				aircraftController.DeleteAircraft(ctx)
@@ -364,7 +494,7 @@ var _ = Describe("AircraftController", func() {
 				ctx.Set("userID", "1")
 				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
 
-				aircraftServiceMock.EXPECT().DeleteAircraft("1", uint(1)).Return(dto.ErrConflict)
+				aircraftServiceMock.EXPECT().DeleteAircraft("1", uint(1), nil).Return(dto.ErrConflict)
 
 				// when
 				aircraftController.DeleteAircraft(ctx)
@@ -380,7 +510,7 @@ var _ = Describe("AircraftController", func() {
 				ctx.Set("userID", "1")
 				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
 
-				aircraftServiceMock.EXPECT().DeleteAircraft("1", uint(1)).Return(dto.ErrNotFound)
+				aircraftServiceMock.EXPECT().DeleteAircraft("1", uint(1), nil).Return(dto.ErrNotFound)
 
 				// when
 				aircraftController.DeleteAircraft(ctx)
@@ -396,7 +526,7 @@ var _ = Describe("AircraftController", func() {
 				ctx.Set("userID", "1")
 				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
 
-				aircraftServiceMock.EXPECT().DeleteAircraft("1", uint(1)).Return(dto.ErrInternalFailure)
+				aircraftServiceMock.EXPECT().DeleteAircraft("1", uint(1), nil).Return(dto.ErrInternalFailure)
 
 				// when
 				aircraftController.DeleteAircraft(ctx)
@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"errors"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/service"
+	"github.com/avialog/backend/internal/util"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type AirportController interface {
+	SearchAirports(*gin.Context)
+}
+
+type airportController struct {
+	airportService service.AirportService
+}
+
+func newAirportController(airportService service.AirportService) AirportController {
+	return &airportController{airportService: airportService}
+}
+
+// SearchAirports godoc
+//
+// @Summary Search airports
+// @Description Search airports by ICAO code, IATA code or name for autocomplete. Exact code matches are returned first
+// @Tags airports
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   q                 query    string     true        "ICAO code, IATA code or part of the name"
+// @Param   limit             query    int        false       "Maximum number of airports, 10 by default, at most 50"
+// @Success 200 {array}       dto.AirportResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /airports [get]
+func (c *airportController) SearchAirports(ctx *gin.Context) {
+	var airportRequest dto.AirportRequest
+	if err := ctx.ShouldBindQuery(&airportRequest); err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	airports, err := c.airportService.SearchAirports(airportRequest.Query, airportRequest.Limit)
+	if err != nil {
+		if errors.Is(err, dto.ErrBadRequest) {
+			util.NewError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, airports)
+}
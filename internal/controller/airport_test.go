@@ -0,0 +1,109 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/service"
+	"github.com/avialog/backend/internal/util"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("AirportController", func() {
+	var (
+		airportController  AirportController
+		airportServiceCtrl *gomock.Controller
+		airportServiceMock *service.MockAirportService
+		w                  *httptest.ResponseRecorder
+		ctx                *gin.Context
+		airportsMock       []dto.AirportResponse
+	)
+
+	BeforeEach(func() {
+		airportServiceCtrl = gomock.NewController(GinkgoT())
+		airportServiceMock = service.NewMockAirportService(airportServiceCtrl)
+		airportController = newAirportController(airportServiceMock)
+		w = httptest.NewRecorder()
+		ctx, _ = gin.CreateTestContext(w)
+		airportsMock = []dto.AirportResponse{
+			{
+				ICAOCode:  "EPWA",
+				IATACode:  util.String("WAW"),
+				Name:      "Warsaw Chopin Airport",
+				Country:   "PL",
+				Latitude:  52.1657,
+				Longitude: 20.9671,
+				Elevation: 362,
+				Timezone:  "Europe/Warsaw",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		airportServiceCtrl.Finish()
+	})
+
+	Describe("SearchAirports", func() {
+		Context("When no error occurs", func() {
+			It("should return 200 and matching airports", func() {
+				// given
+				expectedAirportsJSON, err := json.Marshal(airportsMock)
+				Expect(err).ToNot(HaveOccurred())
+				ctx.Request = httptest.NewRequest("GET", "/airports?q=waw&limit=5", nil)
+				airportServiceMock.EXPECT().SearchAirports("waw", util.Int(5)).Return(airportsMock, nil)
+
+				// when
+				airportController.SearchAirports(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body).To(MatchJSON(expectedAirportsJSON))
+			})
+		})
+		Context("When limit is not a number", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/airports?q=waw&limit=abc", nil)
+
+				// when
+				airportController.SearchAirports(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("When query is empty", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/airports", nil)
+				airportServiceMock.EXPECT().SearchAirports("", nil).Return(nil, fmt.Errorf("%w: %v", dto.ErrBadRequest, "query must not be empty"))
+
+				// when
+				airportController.SearchAirports(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body).To(MatchJSON(`{"code":400,"message":"bad request: query must not be empty"}`))
+			})
+		})
+		Context("When searching fails", func() {
+			It("should return 500 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/airports?q=waw", nil)
+				airportServiceMock.EXPECT().SearchAirports("waw", nil).Return(nil, errors.New("internal failure"))
+
+				// when
+				airportController.SearchAirports(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+})
@@ -14,6 +14,7 @@ import (
 
 type ContactController interface {
 	GetContacts(*gin.Context)
+	GetContact(*gin.Context)
 	InsertContact(*gin.Context)
 	UpdateContact(*gin.Context)
 	DeleteContact(*gin.Context)
@@ -34,7 +35,9 @@ func newContactController(contactService service.ContactService) ContactControll
 // @Tags contacts
 // @Produce  json
 // @Security ApiKeyAuth
+// @Param   If-None-Match     header   string     false       "ETag of the contacts the client has"
 // @Success 200 {array}       dto.ContactResponse
+// @Success 304 "Not modified"
 // @Failure 500 {object}      util.HTTPError
 // @Router  /contacts [get]
 func (c *contactController) GetContacts(ctx *gin.Context) {
@@ -50,7 +53,46 @@ func (c *contactController) GetContacts(ctx *gin.Context) {
 	if len(contactsResponse) == 0 {
 		contactsResponse = []dto.ContactResponse{}
 	}
-	ctx.JSON(http.StatusOK, contactsResponse)
+	respondWithContentETag(ctx, http.StatusOK, contactsResponse)
+}
+
+// GetContact godoc
+//
+// @Summary Get a contact
+// @Description Get a single contact of a user, the ETag of the response can be passed as If-Match to update it
+// @Tags contacts
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   id                path     int        true        "Contact ID"
+// @Param   If-None-Match     header   string     false       "ETag of the contact the client has"
+// @Success 200 {object}      dto.ContactResponse
+// @Success 304 "Not modified"
+// @Failure 400 {object}      util.HTTPError
+// @Failure 404 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /contacts/{id} [get]
+func (c *contactController) GetContact(ctx *gin.Context) {
+	contactID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := ctx.GetString(common.UserID)
+
+	contact, err := c.contactService.GetContact(userID, uint(contactID))
+	if err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			util.NewError(ctx, http.StatusNotFound, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	contactResponse := c.adaptContact(contact)
+
+	respondWithETag(ctx, http.StatusOK, versionETag(contact.UpdatedAt), contactResponse)
 }
 
 // InsertContact godoc
@@ -93,16 +135,18 @@ func (c *contactController) InsertContact(ctx *gin.Context) {
 // UpdateContact godoc
 //
 // @Summary Update an existing contact
-// @Description Update an existing contact for a user
+// @Description Update an existing contact for a user, with If-Match only if it was not changed since the client got that version
 // @Tags contacts
 // @Accept  json
 // @Produce  json
 // @Security ApiKeyAuth
 // @Param   id                path     int        true        "Contact ID to update"
+// @Param   If-Match          header   string     false       "ETag of the contact the change is based on"
 // @Param   contactRequest    body     dto.ContactRequest true    "Contact information to update"
 // @Success 200 {object}      dto.ContactResponse
 // @Failure 400 {object}      util.HTTPError
 // @Failure 404 {object}      util.HTTPError
+// @Failure 412 {object}      util.HTTPError
 // @Failure 500 {object}      util.HTTPError
 // @Router  /contacts/{id} [put]
 func (c *contactController) UpdateContact(ctx *gin.Context) {
@@ -120,7 +164,13 @@ func (c *contactController) UpdateContact(ctx *gin.Context) {
 		return
 	}
 
-	contact, err := c.contactService.UpdateContact(userID, uint(contactID), contactRequest)
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		util.NewError(ctx, http.StatusPreconditionFailed, err)
+		return
+	}
+
+	contact, err := c.contactService.UpdateContact(userID, uint(contactID), contactRequest, version)
 	if err != nil {
 		if errors.Is(err, dto.ErrNotFound) {
 			util.NewError(ctx, http.StatusNotFound, err)
@@ -128,6 +178,9 @@ func (c *contactController) UpdateContact(ctx *gin.Context) {
 		} else if errors.Is(err, dto.ErrBadRequest) {
 			util.NewError(ctx, http.StatusBadRequest, err)
 			return
+		} else if errors.Is(err, dto.ErrPreconditionFailed) {
+			util.NewError(ctx, http.StatusPreconditionFailed, err)
+			return
 		}
 		util.NewError(ctx, http.StatusInternalServerError, err)
 		return
@@ -135,7 +188,7 @@ func (c *contactController) UpdateContact(ctx *gin.Context) {
 
 	contactResponse := c.adaptContact(contact)
 
-	ctx.JSON(http.StatusOK, contactResponse)
+	respondWithETag(ctx, http.StatusOK, versionETag(contact.UpdatedAt), contactResponse)
 }
 
 // DeleteContact godoc
@@ -161,7 +214,7 @@ func (c *contactController) DeleteContact(ctx *gin.Context) {
 
 	userID := ctx.GetString(common.UserID)
 
-	err = c.contactService.DeleteContact(userID, uint(contactID))
+	err = c.contactService.DeleteContact(userID, uint(contactID), nil)
 	if err != nil {
 		if errors.Is(err, dto.ErrNotFound) {
 			util.NewError(ctx, http.StatusNotFound, err)
@@ -184,6 +237,7 @@ func (c *contactController) adaptContact(contact model.Contact) dto.ContactRespo
 		Phone:        contact.Phone,
 		EmailAddress: contact.EmailAddress,
 		Note:         contact.Note,
+		UpdatedAt:    contact.UpdatedAt,
 	}
 }
 
@@ -15,6 +15,7 @@ import (
 	"gorm.io/gorm"
 	"net/http"
 	"net/http/httptest"
+	"time"
 )
 
 var _ = Describe("UserController", func() {
@@ -236,7 +237,75 @@ var _ = Describe("UserController", func() {
 			})
 		})
 	})
+	Describe("GetContact", func() {
+		Context("when contact is fetched successfully", func() {
+			It("should return status 200, contact and its ETag", func() {
+				// given
+				ctx.Params = gin.Params{gin.Param{Key: "id", Value: "1"}}
+				mockContacts[0].UpdatedAt = time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC)
+				expectedContacts[0].UpdatedAt = mockContacts[0].UpdatedAt
+				contactResponseJSON, err := json.Marshal(expectedContacts[0])
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/api/contacts/1", nil)
+				ctx.Set("userID", "1")
+				contactServiceMock.EXPECT().GetContact("1", uint(1)).Return(mockContacts[0], nil)
+
+				// when
+				contactController.GetContact(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Header().Get("ETag")).To(Equal(`"1713513600000000"`))
+				Expect(w.Body).To(MatchJSON(contactResponseJSON))
+			})
+		})
+		Context("when contact is not found", func() {
+			It("should return status 404", func() {
+				// given
+				ctx.Params = gin.Params{gin.Param{Key: "id", Value: "3"}}
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/api/contacts/3", nil)
+				ctx.Set("userID", "1")
+				contactServiceMock.EXPECT().GetContact("1", uint(3)).
+					Return(model.Contact{}, fmt.Errorf("%w: %v", dto.ErrNotFound, gorm.ErrRecordNotFound))
+
+				// when
+				contactController.GetContact(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body.String()).To(Equal(`{"code":404,"message":"not found: record not found"}`))
+			})
+		})
+	})
+
 	Describe("UpdateContact", func() {
+		Context("when contact changed since the version of If-Match", func() {
+			It("should return status 412", func() {
+				// given
+				ctx.Params = gin.Params{gin.Param{Key: "id", Value: "3"}}
+
+				contactRequestJSON, err := json.Marshal(contactRequest)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest(http.MethodPut, "/api/contacts/3", bytes.NewBuffer(contactRequestJSON))
+				ctx.Request.Header.Set("If-Match", `"1713513600000000"`)
+				ctx.Set("userID", "1")
+				version := time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC)
+				contactServiceMock.EXPECT().UpdateContact("1", uint(3), contactRequest, gomock.Any()).
+					DoAndReturn(func(_ string, _ uint, _ dto.ContactRequest, given *time.Time) (model.Contact, error) {
+						Expect(given.Equal(version)).To(BeTrue())
+						return model.Contact{}, fmt.Errorf("%w: contact changed since the given version", dto.ErrPreconditionFailed)
+					})
+
+				// when
+				contactController.UpdateContact(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusPreconditionFailed))
+				Expect(w.Body.String()).To(Equal(`{"code":412,"message":"precondition failed: contact changed since the given version"}`))
+			})
+		})
 		Context("when contact is updated successfully", func() {
 			It("should return status 200 and contact", func() {
 				// given
@@ -254,7 +323,7 @@ var _ = Describe("UserController", func() {
 				ctx.Set("Content-Type", "application/json")
 				ctx.Set("Accept", "application/json")
 				ctx.Set("userID", "1")
-				contactServiceMock.EXPECT().UpdateContact("1", uint(3), contactRequest).Return(contactBeforeUpdate, nil)
+				contactServiceMock.EXPECT().UpdateContact("1", uint(3), contactRequest, nil).Return(contactBeforeUpdate, nil)
 				// when
 				contactController.UpdateContact(ctx)
 
@@ -316,7 +385,7 @@ var _ = Describe("UserController", func() {
 				ctx.Set("Content-Type", "application/json")
 				ctx.Set("Accept", "application/json")
 				ctx.Set("userID", "1")
-				contactServiceMock.EXPECT().UpdateContact("1", uint(3), contactRequest).Return(model.Contact{}, fmt.Errorf("%w: %v", dto.ErrNotFound, gorm.ErrRecordNotFound))
+				contactServiceMock.EXPECT().UpdateContact("1", uint(3), contactRequest, nil).Return(model.Contact{}, fmt.Errorf("%w: %v", dto.ErrNotFound, gorm.ErrRecordNotFound))
 
 				// when
 				contactController.UpdateContact(ctx)
@@ -341,7 +410,7 @@ var _ = Describe("UserController", func() {
 				ctx.Set("Content-Type", "application/json")
 				ctx.Set("Accept", "application/json")
 				ctx.Set("userID", "1")
-				contactServiceMock.EXPECT().UpdateContact("1", uint(3), contactRequest).Return(model.Contact{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, gorm.ErrInvalidDB))
+				contactServiceMock.EXPECT().UpdateContact("1", uint(3), contactRequest, nil).Return(model.Contact{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, gorm.ErrInvalidDB))
 
 				// when
 				contactController.UpdateContact(ctx)
@@ -359,7 +428,7 @@ var _ = Describe("UserController", func() {
 				ctx.Set("Accept", "application/json")
 				ctx.Set("userID", "1")
 				ctx.Params = gin.Params{gin.Param{Key: "id", Value: "1"}}
-				contactServiceMock.EXPECT().DeleteContact("1", uint(1)).Return(nil)
+				contactServiceMock.EXPECT().DeleteContact("1", uint(1), nil).Return(nil)
 
 				// when
 				contactController.DeleteContact(ctx)
@@ -390,7 +459,7 @@ var _ = Describe("UserController", func() {
 				ctx.Set("Accept", "application/json")
 				ctx.Set("userID", "1")
 				ctx.Params = gin.Params{gin.Param{Key: "id", Value: "1"}}
-				contactServiceMock.EXPECT().DeleteContact("1", uint(1)).Return(fmt.Errorf("%w: %v", dto.ErrNotFound, gorm.ErrRecordNotFound))
+				contactServiceMock.EXPECT().DeleteContact("1", uint(1), nil).Return(fmt.Errorf("%w: %v", dto.ErrNotFound, gorm.ErrRecordNotFound))
 
 				// when
 				contactController.DeleteContact(ctx)
@@ -406,7 +475,7 @@ var _ = Describe("UserController", func() {
 				ctx.Set("Accept", "application/json")
 				ctx.Set("userID", "1")
 				ctx.Params = gin.Params{gin.Param{Key: "id", Value: "1"}}
-				contactServiceMock.EXPECT().DeleteContact("1", uint(1)).Return(fmt.Errorf("%w: %v", dto.ErrInternalFailure, gorm.ErrInvalidDB))
+				contactServiceMock.EXPECT().DeleteContact("1", uint(1), nil).Return(fmt.Errorf("%w: %v", dto.ErrInternalFailure, gorm.ErrInvalidDB))
 
 				// when
 				contactController.DeleteContact(ctx)
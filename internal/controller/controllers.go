@@ -14,16 +14,44 @@ type Controllers interface {
 	Info() InfoController
 	Route(server *gin.Engine)
 	Aircraft() AircraftController
+	Currency() CurrencyController
+	Import() ImportController
+	Airport() AirportController
+	Signature() SignatureController
+	History() HistoryController
+	Trash() TrashController
+	Sync() SyncController
+	Licence() LicenceController
+	Rating() RatingController
+	Medical() MedicalController
+	Reminder() ReminderController
+	Job() JobController
+	Progress() ProgressController
 }
 
 type controllers struct {
-	userController     UserController
-	infoController     InfoController
-	config             config.Config
-	contactController  ContactController
-	authMiddleware     gin.HandlerFunc
-	aircraftController AircraftController
-	logbookController  LogbookController
+	userController        UserController
+	infoController        InfoController
+	config                config.Config
+	contactController     ContactController
+	authMiddleware        gin.HandlerFunc
+	idempotencyMiddleware gin.HandlerFunc
+	adminMiddleware       gin.HandlerFunc
+	aircraftController    AircraftController
+	logbookController     LogbookController
+	currencyController    CurrencyController
+	importController      ImportController
+	airportController     AirportController
+	signatureController   SignatureController
+	historyController     HistoryController
+	trashController       TrashController
+	syncController        SyncController
+	licenceController     LicenceController
+	ratingController      RatingController
+	medicalController     MedicalController
+	reminderController    ReminderController
+	jobController         JobController
+	progressController    ProgressController
 }
 
 func NewControllers(services service.Services, config config.Config) Controllers {
@@ -32,15 +60,45 @@ func NewControllers(services service.Services, config config.Config) Controllers
 	aircraftController := newAircraftController(services.Aircraft())
 	infoController := newInfoController()
 	authMiddleware := middleware.AuthJWT(services.Auth())
+	idempotencyMiddleware := middleware.Idempotency(services.Idempotency())
+	adminMiddleware := middleware.RequireAdmin(config.AdminUserIDs)
 	flightController := newLogbookController(services.Logbook())
+	currencyController := newCurrencyController(services.Currency())
+	importController := newImportController(services.Import())
+	airportController := newAirportController(services.Airport())
+	signatureController := newSignatureController(services.Signature())
+	historyController := newHistoryController(services.History())
+	trashController := newTrashController(services.Trash())
+	syncController := newSyncController(services.Sync())
+	licenceController := newLicenceController(services.Licence())
+	ratingController := newRatingController(services.Rating())
+	medicalController := newMedicalController(services.Medical())
+	reminderController := newReminderController(services.Reminder())
+	jobController := newJobController(services.Job())
+	progressController := newProgressController(services.Progress())
 	return &controllers{
-		userController:     userController,
-		contactController:  contactController,
-		infoController:     infoController,
-		config:             config,
-		authMiddleware:     authMiddleware,
-		aircraftController: aircraftController,
-		logbookController:  flightController,
+		userController:        userController,
+		contactController:     contactController,
+		infoController:        infoController,
+		config:                config,
+		authMiddleware:        authMiddleware,
+		idempotencyMiddleware: idempotencyMiddleware,
+		adminMiddleware:       adminMiddleware,
+		aircraftController:    aircraftController,
+		logbookController:     flightController,
+		currencyController:    currencyController,
+		importController:      importController,
+		airportController:     airportController,
+		signatureController:   signatureController,
+		historyController:     historyController,
+		trashController:       trashController,
+		syncController:        syncController,
+		licenceController:     licenceController,
+		ratingController:      ratingController,
+		medicalController:     medicalController,
+		reminderController:    reminderController,
+		jobController:         jobController,
+		progressController:    progressController,
 	}
 }
 
@@ -58,16 +116,52 @@ func (c *controllers) Contact() ContactController { return c.contactController }
 
 func (c *controllers) Logbook() LogbookController { return c.logbookController }
 
+func (c *controllers) Currency() CurrencyController { return c.currencyController }
+
+func (c *controllers) Import() ImportController { return c.importController }
+
+func (c *controllers) Airport() AirportController { return c.airportController }
+
+func (c *controllers) Signature() SignatureController { return c.signatureController }
+
+func (c *controllers) History() HistoryController { return c.historyController }
+
+func (c *controllers) Trash() TrashController { return c.trashController }
+
+func (c *controllers) Sync() SyncController { return c.syncController }
+
+func (c *controllers) Licence() LicenceController { return c.licenceController }
+
+func (c *controllers) Rating() RatingController { return c.ratingController }
+
+func (c *controllers) Medical() MedicalController { return c.medicalController }
+
+func (c *controllers) Reminder() ReminderController { return c.reminderController }
+
+func (c *controllers) Job() JobController { return c.jobController }
+
+func (c *controllers) Progress() ProgressController { return c.progressController }
+
 func (c *controllers) Route(server *gin.Engine) {
 
 	server.GET("/healthz", c.infoController.Info)
 
 	api := server.Group("/api")
 	{
+		// instructors signing as a contact of the pilot have no account, the token of the request authorizes them
+		signatureTokens := api.Group("/signatures/token")
+		{
+			signatureTokens.Use(c.idempotencyMiddleware)
+			signatureTokens.GET(":token", c.signatureController.GetSignatureRequestByToken)
+			signatureTokens.POST(":token/sign", c.signatureController.SignEntryByToken)
+			signatureTokens.POST(":token/reject", c.signatureController.RejectEntryByToken)
+		}
 
 		authenticated := api.Group("/")
 		{
 			authenticated.Use(c.authMiddleware)
+			// retried requests are recognized per user, so keys are checked after authentication
+			authenticated.Use(c.idempotencyMiddleware)
 
 			profile := authenticated.Group("/profile")
 			{
@@ -78,6 +172,7 @@ func (c *controllers) Route(server *gin.Engine) {
 			contacts := authenticated.Group("/contacts")
 			{
 				contacts.GET("", c.contactController.GetContacts)
+				contacts.GET(":id", c.contactController.GetContact)
 				contacts.POST("", c.contactController.InsertContact)
 				contacts.PUT(":id", c.contactController.UpdateContact)
 				contacts.DELETE(":id", c.contactController.DeleteContact)
@@ -86,17 +181,87 @@ func (c *controllers) Route(server *gin.Engine) {
 			flights := authenticated.Group("/logbook")
 			{
 				flights.GET("", c.logbookController.GetLogbookEntries)
+				flights.GET("totals", c.logbookController.GetLogbookTotals)
+				flights.GET("export", c.logbookController.ExportLogbook)
+				flights.GET("night", c.logbookController.PreviewNightTime)
+				flights.GET("duplicates", c.logbookController.GetDuplicateEntries)
+				flights.GET("verify", c.historyController.VerifyLogbook)
+				flights.GET(":id", c.logbookController.GetLogbookEntry)
+				flights.GET(":id/history", c.historyController.GetLogbookEntryHistory)
 				flights.POST("", c.logbookController.InsertLogbookEntry)
+				flights.POST("import", c.importController.ImportLogbook)
+				flights.POST("batch", c.logbookController.BatchLogbookEntries)
 				flights.PUT(":id", c.logbookController.UpdateLogbookEntry)
+				flights.POST(":id/amend", c.logbookController.AmendLogbookEntry)
+				flights.POST(":id/signature", c.signatureController.RequestSignature)
 				flights.DELETE(":id", c.logbookController.DeleteLogbookEntry)
 			}
+			signatures := authenticated.Group("/signatures")
+			{
+				signatures.GET("", c.signatureController.GetSignatureRequests)
+				signatures.GET(":id", c.signatureController.GetSignatureRequest)
+				signatures.POST(":id/sign", c.signatureController.SignEntry)
+				signatures.POST(":id/reject", c.signatureController.RejectEntry)
+			}
 			aircraft := authenticated.Group("/aircraft")
 			{
 				aircraft.GET("", c.aircraftController.GetAircraft)
+				aircraft.GET(":id", c.aircraftController.GetAircraftByID)
 				aircraft.POST("", c.aircraftController.InsertAircraft)
 				aircraft.PUT(":id", c.aircraftController.UpdateAircraft)
 				aircraft.DELETE(":id", c.aircraftController.DeleteAircraft)
 			}
+			trash := authenticated.Group("/trash")
+			{
+				trash.GET("", c.trashController.GetTrash)
+				trash.POST("flights/:id/restore", c.trashController.RestoreLogbookEntry)
+				trash.POST("aircraft/:id/restore", c.trashController.RestoreAircraft)
+				trash.POST("contacts/:id/restore", c.trashController.RestoreContact)
+			}
+			sync := authenticated.Group("/sync")
+			{
+				sync.GET("", c.syncController.GetChanges)
+				sync.POST("", c.syncController.PushChanges)
+			}
+			licences := authenticated.Group("/licences")
+			{
+				licences.GET("", c.licenceController.GetLicences)
+				licences.GET("summary", c.licenceController.GetExpirySummary)
+				licences.GET(":id", c.licenceController.GetLicence)
+				licences.POST("", c.licenceController.InsertLicence)
+				licences.PUT(":id", c.licenceController.UpdateLicence)
+				licences.DELETE(":id", c.licenceController.DeleteLicence)
+			}
+			ratings := authenticated.Group("/ratings")
+			{
+				ratings.GET("", c.ratingController.GetRatings)
+				ratings.GET(":id", c.ratingController.GetRating)
+				ratings.POST("", c.ratingController.InsertRating)
+				ratings.PUT(":id", c.ratingController.UpdateRating)
+				ratings.DELETE(":id", c.ratingController.DeleteRating)
+			}
+			medicals := authenticated.Group("/medicals")
+			{
+				medicals.GET("", c.medicalController.GetMedicals)
+				medicals.POST("", c.medicalController.InsertMedical)
+				medicals.PUT(":id", c.medicalController.UpdateMedical)
+				medicals.DELETE(":id", c.medicalController.DeleteMedical)
+			}
+			reminders := authenticated.Group("/reminders")
+			{
+				reminders.GET("preferences", c.reminderController.GetPreference)
+				reminders.PUT("preferences", c.reminderController.UpdatePreference)
+			}
+			// job runs name the replicas and carry the errors of jobs across all users
+			jobs := authenticated.Group("/jobs")
+			{
+				jobs.Use(c.adminMiddleware)
+				jobs.GET("runs", c.jobController.GetRuns)
+			}
+			authenticated.GET("/progress/:licence", c.progressController.GetProgress)
+
+			authenticated.GET("/currency", c.currencyController.GetCurrency)
+			authenticated.GET("/airports", c.airportController.SearchAirports)
 
 		}
 
@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"github.com/avialog/backend/internal/common"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/service"
+	"github.com/avialog/backend/internal/util"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type CurrencyController interface {
+	GetCurrency(*gin.Context)
+}
+
+type currencyController struct {
+	currencyService service.CurrencyService
+}
+
+func newCurrencyController(currencyService service.CurrencyService) CurrencyController {
+	return &currencyController{currencyService: currencyService}
+}
+
+// GetCurrency godoc
+//
+// @Summary Get user recency and currency
+// @Description Get passenger, night passenger and instrument currency of a user per aircraft category
+// @Tags currency
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {array}       dto.CurrencyResponse
+// @Failure 500 {object}      util.HTTPError
+// @Router  /currency [get]
+func (c *currencyController) GetCurrency(ctx *gin.Context) {
+	userID := ctx.GetString(common.UserID)
+
+	currency, err := c.currencyService.GetCurrency(userID)
+	if err != nil {
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	if len(currency) == 0 {
+		currency = []dto.CurrencyResponse{}
+	}
+	ctx.JSON(http.StatusOK, currency)
+}
@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"encoding/json"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/service"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("CurrencyController", func() {
+	var (
+		currencyController  CurrencyController
+		currencyServiceCtrl *gomock.Controller
+		currencyServiceMock *service.MockCurrencyService
+		w                   *httptest.ResponseRecorder
+		ctx                 *gin.Context
+		currencyMock        []dto.CurrencyResponse
+	)
+
+	BeforeEach(func() {
+		currencyServiceCtrl = gomock.NewController(GinkgoT())
+		currencyServiceMock = service.NewMockCurrencyService(currencyServiceCtrl)
+		currencyController = newCurrencyController(currencyServiceMock)
+		w = httptest.NewRecorder()
+		ctx, _ = gin.CreateTestContext(w)
+		airplane := model.AircraftCategoryAirplane
+		lapsesAt := time.Date(2024, 7, 30, 12, 0, 0, 0, time.UTC)
+		currencyMock = []dto.CurrencyResponse{
+			{
+				Category: &airplane,
+				Passenger: dto.CurrencyStatus{
+					Current:      true,
+					LapsesAt:     &lapsesAt,
+					Requirements: []dto.CurrencyRequirement{{Name: "takeoffs_and_landings", Required: 3, Logged: 4}},
+				},
+				NightPassenger: dto.CurrencyStatus{
+					Requirements: []dto.CurrencyRequirement{{Name: "night_takeoffs_and_landings", Required: 3, Missing: 3}},
+				},
+				Instrument: dto.CurrencyStatus{
+					Requirements: []dto.CurrencyRequirement{
+						{Name: "instrument_approaches", Required: 6, Missing: 6},
+						{Name: "holding_procedures", Required: 1, Missing: 1},
+					},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		currencyServiceCtrl.Finish()
+	})
+
+	Describe("GetCurrency", func() {
+		Context("When no error occurs", func() {
+			It("should return 200 and currency per category", func() {
+				// given
+				expectedCurrencyJSON, err := json.Marshal(currencyMock)
+				Expect(err).ToNot(HaveOccurred())
+				ctx.Set("userID", "1")
+				currencyServiceMock.EXPECT().GetCurrency("1").Return(currencyMock, nil)
+
+				// when
+				currencyController.GetCurrency(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body).To(MatchJSON(expectedCurrencyJSON))
+			})
+		})
+		Context("When getting currency fails", func() {
+			It("should return 500 and error message", func() {
+				// given
+				ctx.Set("userID", "1")
+				currencyServiceMock.EXPECT().GetCurrency("1").Return(nil, dto.ErrInternalFailure)
+
+				// when
+				currencyController.GetCurrency(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+				Expect(w.Body).To(MatchJSON(`{"code": 500, "message":"internal failure"}`))
+			})
+		})
+	})
+})
@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// versionETag identifies the version of a single item by the time it was last updated. Postgres stores times with
+// microsecond precision, so finer parts would not survive a round trip through If-Match.
+func versionETag(updatedAt time.Time) string {
+	return fmt.Sprintf("%q", strconv.FormatInt(updatedAt.UnixMicro(), 10))
+}
+
+// logbookEntryETag identifies the version of a logbook entry, which also changes when its signature request changes.
+func logbookEntryETag(logbookResponse dto.LogbookResponse) string {
+	updatedAt := logbookResponse.UpdatedAt
+	if logbookResponse.Signature != nil && logbookResponse.Signature.UpdatedAt.After(updatedAt) {
+		updatedAt = logbookResponse.Signature.UpdatedAt
+	}
+	return versionETag(updatedAt)
+}
+
+// respondWithETag writes the body with the given ETag, or only 304 Not Modified if the client already has this version.
+func respondWithETag(ctx *gin.Context, status int, etag string, body any) {
+	ctx.Header("ETag", etag)
+	if ctx.Request.Method == http.MethodGet && matchesETag(ctx.GetHeader("If-None-Match"), etag) {
+		ctx.AbortWithStatus(http.StatusNotModified)
+		return
+	}
+	ctx.JSON(status, body)
+}
+
+// respondWithContentETag writes the body with a weak ETag derived from its content. It is used for lists, which have
+// no version of their own.
+func respondWithContentETag(ctx *gin.Context, status int, body any) {
+	content, err := json.Marshal(body)
+	if err != nil {
+		ctx.JSON(status, body)
+		return
+	}
+
+	hash := sha256.Sum256(content)
+	etag := fmt.Sprintf("W/%q", hex.EncodeToString(hash[:16]))
+	ctx.Header("ETag", etag)
+	if ctx.Request.Method == http.MethodGet && matchesETag(ctx.GetHeader("If-None-Match"), etag) {
+		ctx.AbortWithStatus(http.StatusNotModified)
+		return
+	}
+	ctx.Data(status, "application/json; charset=utf-8", content)
+}
+
+// matchesETag reports whether any of the ETags of an If-None-Match header is the given ETag, using weak comparison.
+func matchesETag(header string, etag string) bool {
+	if header == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// ifMatchVersion returns the version the client wants to change taken from the If-Match header, or nil if the header
+// is missing or matches any version.
+func ifMatchVersion(ctx *gin.Context) (*time.Time, error) {
+	header := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return nil, nil
+	}
+
+	unquoted, err := strconv.Unquote(header)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid If-Match header", dto.ErrPreconditionFailed)
+	}
+	micro, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: If-Match does not match the current version", dto.ErrPreconditionFailed)
+	}
+
+	version := time.UnixMicro(micro)
+	return &version, nil
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"errors"
+	"github.com/avialog/backend/internal/common"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/service"
+	"github.com/avialog/backend/internal/util"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type HistoryController interface {
+	GetLogbookEntryHistory(*gin.Context)
+	VerifyLogbook(*gin.Context)
+}
+
+type historyController struct {
+	historyService service.HistoryService
+}
+
+func newHistoryController(historyService service.HistoryService) HistoryController {
+	return &historyController{historyService: historyService}
+}
+
+// GetLogbookEntryHistory godoc
+//
+// @Summary Get the history of a logbook entry
+// @Description Get every recorded version of a logbook entry from the oldest one, with the fields changed since the previous version. The history of a deleted entry is kept.
+// @Tags logbook
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   id                path     int        true        "Flight ID"
+// @Success 200 {object}      []dto.FlightVersionResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 404 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /logbook/{id}/history [get]
+func (c *historyController) GetLogbookEntryHistory(ctx *gin.Context) {
+	flightID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := ctx.GetString(common.UserID)
+
+	versionResponses, err := c.historyService.GetLogbookEntryHistory(userID, uint(flightID))
+	if err != nil {
+		if errors.Is(err, dto.ErrBadRequest) {
+			util.NewError(ctx, http.StatusBadRequest, err)
+			return
+		} else if errors.Is(err, dto.ErrNotFound) {
+			util.NewError(ctx, http.StatusNotFound, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, versionResponses)
+}
+
+// VerifyLogbook godoc
+//
+// @Summary Verify the integrity of the logbook
+// @Description Recompute the hash chain of the logbook history and compare every entry with its latest version. Keep the returned head to detect removal of the latest versions later.
+// @Tags logbook
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {object}      dto.LogbookVerificationResponse
+// @Failure 500 {object}      util.HTTPError
+// @Router  /logbook/verify [get]
+func (c *historyController) VerifyLogbook(ctx *gin.Context) {
+	userID := ctx.GetString(common.UserID)
+
+	verificationResponse, err := c.historyService.VerifyLogbook(userID)
+	if err != nil {
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, verificationResponse)
+}
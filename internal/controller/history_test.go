@@ -0,0 +1,139 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/service"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("HistoryController", func() {
+	var (
+		historyController  HistoryController
+		historyServiceCtrl *gomock.Controller
+		historyServiceMock *service.MockHistoryService
+		w                  *httptest.ResponseRecorder
+		ctx                *gin.Context
+	)
+
+	BeforeEach(func() {
+		historyServiceCtrl = gomock.NewController(GinkgoT())
+		historyServiceMock = service.NewMockHistoryService(historyServiceCtrl)
+		w = httptest.NewRecorder()
+		ctx, _ = gin.CreateTestContext(w)
+		historyController = newHistoryController(historyServiceMock)
+	})
+
+	AfterEach(func() {
+		historyServiceCtrl.Finish()
+	})
+
+	Describe("GetLogbookEntryHistory", func() {
+		Context("When the user sends a request and no error occurs.", func() {
+			It("should return 200 and the versions", func() {
+				// given
+				versionResponses := []dto.FlightVersionResponse{{
+					Sequence:     1,
+					FlightID:     3,
+					Action:       model.FlightVersionActionCreated,
+					RecordedAt:   time.Date(2024, 3, 26, 9, 30, 0, 0, time.UTC),
+					PreviousHash: "0000",
+					Hash:         "abcd",
+					Changes:      []dto.FieldChange{{Field: "remarks", Old: json.RawMessage(`null`), New: json.RawMessage(`"Diverted"`)}},
+				}}
+				expectedResponseJSON, err := json.Marshal(versionResponses)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest("GET", "/logbook/3/history", nil)
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "3"})
+				historyServiceMock.EXPECT().GetLogbookEntryHistory("1", uint(3)).Return(versionResponses, nil)
+
+				// when
+				historyController.GetLogbookEntryHistory(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(200))
+				Expect(w.Body).To(MatchJSON(expectedResponseJSON))
+			})
+		})
+		Context("When the user sends a request and fails to fetch id from params", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/logbook/history", nil)
+				ctx.Set("userID", "1")
+
+				// when
+				historyController.GetLogbookEntryHistory(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(400))
+				Expect(w.Body).To(MatchJSON(`{"code": 400, "message":"strconv.ParseUint: parsing \"\": invalid syntax"}`))
+			})
+		})
+		Context("When the entry does not exist", func() {
+			It("should return 404 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/logbook/3/history", nil)
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "3"})
+				historyServiceMock.EXPECT().GetLogbookEntryHistory("1", uint(3)).
+					Return(nil, fmt.Errorf("%w: %v", dto.ErrNotFound, "flight not found"))
+
+				// when
+				historyController.GetLogbookEntryHistory(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(404))
+				Expect(w.Body).To(MatchJSON(`{"code": 404, "message":"not found: flight not found"}`))
+			})
+		})
+	})
+
+	Describe("VerifyLogbook", func() {
+		Context("When the user sends a request and no error occurs.", func() {
+			It("should return 200 and the verification result", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/logbook/verify", nil)
+				ctx.Set("userID", "1")
+				historyServiceMock.EXPECT().VerifyLogbook("1").Return(dto.LogbookVerificationResponse{
+					Valid:              false,
+					Versions:           2,
+					Problems:           []dto.VerificationProblem{{FlightID: 3, Reason: "entry differs from its latest version"}},
+					UntrackedFlightIDs: []uint{},
+				}, nil)
+
+				// when
+				historyController.VerifyLogbook(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(200))
+				Expect(w.Body).To(MatchJSON(`{"valid": false, "versions": 2, "head": null,
+					"problems": [{"sequence": null, "flight_id": 3, "reason": "entry differs from its latest version"}],
+					"untracked_flight_ids": []}`))
+			})
+		})
+		Context("When the user sends a request and the verification fails", func() {
+			It("should return 500 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/logbook/verify", nil)
+				ctx.Set("userID", "1")
+				historyServiceMock.EXPECT().VerifyLogbook("1").Return(dto.LogbookVerificationResponse{}, dto.ErrInternalFailure)
+
+				// when
+				historyController.VerifyLogbook(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(500))
+				Expect(w.Body).To(MatchJSON(`{"code": 500, "message":"internal failure"}`))
+			})
+		})
+	})
+})
@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/common"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/importer"
+	"github.com/avialog/backend/internal/service"
+	"github.com/avialog/backend/internal/util"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type ImportController interface {
+	ImportLogbook(*gin.Context)
+}
+
+type importController struct {
+	importService service.ImportService
+}
+
+func newImportController(importService service.ImportService) ImportController {
+	return &importController{importService: importService}
+}
+
+// ImportLogbook godoc
+//
+// @Summary Import logbook from another application
+// @Description Import flights from ForeFlight (foreflight), LogTen Pro (logten), mccPilotLog (mccpilotlog) or a generic CSV file (csv) in a single transaction. Missing aircraft are created by registration. Generic CSV columns are mapped with a JSON object mapping logbook fields (the column names of the CSV export) to column names of the file.
+// @Tags logbook
+// @Accept  multipart/form-data
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   file              formData file       true        "Exported logbook"
+// @Param   format            formData string     true        "Format of the file (foreflight, logten, mccpilotlog, csv)"
+// @Param   mapping           formData string     false       "Column mapping of the generic CSV format as JSON object"
+// @Success 200 {object}      dto.ImportResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /logbook/import [post]
+func (c *importController) ImportLogbook(ctx *gin.Context) {
+	userID := ctx.GetString(common.UserID)
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	var options importer.Options
+	if mapping := ctx.PostForm("mapping"); mapping != "" {
+		if err := json.Unmarshal([]byte(mapping), &options.Mapping); err != nil {
+			util.NewError(ctx, http.StatusBadRequest, fmt.Errorf("%w: invalid mapping: %v", dto.ErrBadRequest, err))
+			return
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer file.Close()
+
+	importResponse, err := c.importService.ImportLogbook(userID, ctx.PostForm("format"), file, options)
+	if err != nil {
+		if errors.Is(err, dto.ErrBadRequest) {
+			util.NewError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, importResponse)
+}
@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/importer"
+	"github.com/avialog/backend/internal/service"
+	"github.com/avialog/backend/internal/util"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("ImportController", func() {
+	var (
+		importController  ImportController
+		importServiceCtrl *gomock.Controller
+		importServiceMock *service.MockImportService
+		w                 *httptest.ResponseRecorder
+		ctx               *gin.Context
+		newImportRequest  func(fields map[string]string, file string) *http.Request
+	)
+
+	BeforeEach(func() {
+		importServiceCtrl = gomock.NewController(GinkgoT())
+		importServiceMock = service.NewMockImportService(importServiceCtrl)
+		importController = newImportController(importServiceMock)
+		w = httptest.NewRecorder()
+		ctx, _ = gin.CreateTestContext(w)
+		newImportRequest = func(fields map[string]string, file string) *http.Request {
+			var body bytes.Buffer
+			writer := multipart.NewWriter(&body)
+			for name, value := range fields {
+				Expect(writer.WriteField(name, value)).To(Succeed())
+			}
+			if file != "" {
+				part, err := writer.CreateFormFile("file", "logbook.csv")
+				Expect(err).ToNot(HaveOccurred())
+				_, err = part.Write([]byte(file))
+				Expect(err).ToNot(HaveOccurred())
+			}
+			Expect(writer.Close()).To(Succeed())
+
+			request := httptest.NewRequest("POST", "/logbook/import", &body)
+			request.Header.Set("Content-Type", writer.FormDataContentType())
+			return request
+		}
+	})
+
+	AfterEach(func() {
+		importServiceCtrl.Finish()
+	})
+
+	Describe("ImportLogbook", func() {
+		Context("When the user uploads a file and no error occurs", func() {
+			It("should return 200 and import report", func() {
+				// given
+				importResponse := dto.ImportResponse{
+					Created:         1,
+					CreatedAircraft: []string{"SP-ABC"},
+					Rows:            []dto.ImportRowResult{{Line: 2, Status: dto.ImportRowCreated, FlightID: util.Uint(7)}},
+				}
+				expectedImportResponseJSON, err := json.Marshal(importResponse)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = newImportRequest(map[string]string{"format": "csv", "mapping": `{"date":"Flight Date"}`}, "Flight Date\n2024-03-01\n")
+				ctx.Set("userID", "1")
+				importServiceMock.EXPECT().ImportLogbook("1", "csv", gomock.Any(), importer.Options{Mapping: map[string]string{"date": "Flight Date"}}).
+					DoAndReturn(func(userID string, format string, reader io.Reader, options importer.Options) (dto.ImportResponse, error) {
+						content, err := io.ReadAll(reader)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(string(content)).To(Equal("Flight Date\n2024-03-01\n"))
+						return importResponse, nil
+					})
+
+				// when
+				importController.ImportLogbook(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(200))
+				Expect(w.Body).To(MatchJSON(expectedImportResponseJSON))
+			})
+		})
+		Context("When the file is missing", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Request = newImportRequest(map[string]string{"format": "csv"}, "")
+				ctx.Set("userID", "1")
+
+				// when
+				importController.ImportLogbook(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(400))
+			})
+		})
+		Context("When the mapping is not valid JSON", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Request = newImportRequest(map[string]string{"format": "csv", "mapping": "date"}, "date\n")
+				ctx.Set("userID", "1")
+
+				// when
+				importController.ImportLogbook(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(400))
+			})
+		})
+		Context("When the service returns bad request", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Request = newImportRequest(map[string]string{"format": "xls"}, "date\n")
+				ctx.Set("userID", "1")
+				importServiceMock.EXPECT().ImportLogbook("1", "xls", gomock.Any(), importer.Options{}).
+					Return(dto.ImportResponse{}, fmt.Errorf("%w: unsupported import format: xls", dto.ErrBadRequest))
+
+				// when
+				importController.ImportLogbook(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(400))
+				Expect(w.Body).To(MatchJSON(`{"code": 400, "message":"bad request: unsupported import format: xls"}`))
+			})
+		})
+		Context("When the import fails", func() {
+			It("should return 500 and error message", func() {
+				// given
+				ctx.Request = newImportRequest(map[string]string{"format": "csv"}, "date\n")
+				ctx.Set("userID", "1")
+				importServiceMock.EXPECT().ImportLogbook("1", "csv", gomock.Any(), importer.Options{}).Return(dto.ImportResponse{}, dto.ErrInternalFailure)
+
+				// when
+				importController.ImportLogbook(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(500))
+				Expect(w.Body).To(MatchJSON(`{"code": 500, "message":"internal failure"}`))
+			})
+		})
+	})
+})
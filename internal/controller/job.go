@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"errors"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/service"
+	"github.com/avialog/backend/internal/util"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type JobController interface {
+	GetRuns(*gin.Context)
+}
+
+type jobController struct {
+	jobService service.JobService
+}
+
+func newJobController(jobService service.JobService) JobController {
+	return &jobController{jobService: jobService}
+}
+
+// GetRuns godoc
+//
+// @Summary Get recent background job runs
+// @Description Get the most recently scheduled runs of background jobs with the replica that executed them, the
+// @Description number of attempts and their outcome, only for admins
+// @Tags jobs
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   job               query    string     false       "Name of the job, all jobs if empty"
+// @Param   limit             query    int        false       "Number of runs, 50 by default and at most 200"
+// @Success 200 {array}       dto.JobRunResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 403 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /jobs/runs [get]
+func (c *jobController) GetRuns(ctx *gin.Context) {
+	var jobRunsRequest dto.JobRunsRequest
+	if err := ctx.ShouldBindQuery(&jobRunsRequest); err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	runs, err := c.jobService.GetRecentRuns(jobRunsRequest.Job, jobRunsRequest.Limit)
+	if err != nil {
+		if errors.Is(err, dto.ErrBadRequest) {
+			util.NewError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.adaptRuns(runs))
+}
+
+func (c *jobController) adaptRuns(runs []model.JobRun) []dto.JobRunResponse {
+	runResponses := make([]dto.JobRunResponse, 0, len(runs))
+	for _, run := range runs {
+		runResponses = append(runResponses, dto.JobRunResponse{
+			ID:          run.ID,
+			Job:         run.Job,
+			ScheduledAt: run.ScheduledAt,
+			Status:      run.Status,
+			Replica:     run.Replica,
+			Attempts:    run.Attempts,
+			Summary:     run.Summary,
+			Error:       run.Error,
+			StartedAt:   run.StartedAt,
+			FinishedAt:  run.FinishedAt,
+		})
+	}
+	return runResponses
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/service"
+	"github.com/avialog/backend/internal/util"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("JobController", func() {
+	var (
+		jobController  JobController
+		jobServiceCtrl *gomock.Controller
+		jobServiceMock *service.MockJobService
+		w              *httptest.ResponseRecorder
+		ctx            *gin.Context
+	)
+
+	BeforeEach(func() {
+		jobServiceCtrl = gomock.NewController(GinkgoT())
+		jobServiceMock = service.NewMockJobService(jobServiceCtrl)
+		w = httptest.NewRecorder()
+		ctx, _ = gin.CreateTestContext(w)
+		jobController = newJobController(jobServiceMock)
+	})
+
+	AfterEach(func() {
+		jobServiceCtrl.Finish()
+	})
+
+	Describe("GetRuns", func() {
+		Context("when runs of the job exist", func() {
+			It("should return 200 and the runs", func() {
+				// given
+				scheduledAt := time.Date(2024, 4, 20, 13, 0, 0, 0, time.UTC)
+				finishedAt := scheduledAt.Add(2 * time.Second)
+				summary := "purged 2 flights, 0 aircraft and 0 contacts from trash"
+				run := model.JobRun{ID: 7, Job: "purge-trash", ScheduledAt: scheduledAt,
+					Status: model.JobRunStatusSucceeded, Replica: "avialog-1", Attempts: 1, Summary: &summary,
+					StartedAt: scheduledAt, FinishedAt: &finishedAt, LockedUntil: scheduledAt.Add(time.Hour)}
+				expectedJSON, err := json.Marshal([]dto.JobRunResponse{{ID: 7, Job: "purge-trash",
+					ScheduledAt: scheduledAt, Status: model.JobRunStatusSucceeded, Replica: "avialog-1", Attempts: 1,
+					Summary: &summary, StartedAt: scheduledAt, FinishedAt: &finishedAt}})
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/api/jobs/runs?job=purge-trash&limit=10", nil)
+				jobServiceMock.EXPECT().GetRecentRuns("purge-trash", util.Int(10)).Return([]model.JobRun{run}, nil)
+
+				// when
+				jobController.GetRuns(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body).To(MatchJSON(expectedJSON))
+			})
+		})
+		Context("when the limit is out of range", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/api/jobs/runs?limit=500", nil)
+				jobServiceMock.EXPECT().GetRecentRuns("", util.Int(500)).
+					Return(nil, fmt.Errorf("%w: limit must be between 1 and 200", dto.ErrBadRequest))
+
+				// when
+				jobController.GetRuns(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body).To(MatchJSON(`{"code":400,"message":"bad request: limit must be between 1 and 200"}`))
+			})
+		})
+	})
+})
@@ -0,0 +1,250 @@
+package controller
+
+import (
+	"errors"
+	"github.com/avialog/backend/internal/common"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/service"
+	"github.com/avialog/backend/internal/util"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type LicenceController interface {
+	GetLicences(*gin.Context)
+	GetLicence(*gin.Context)
+	InsertLicence(*gin.Context)
+	UpdateLicence(*gin.Context)
+	DeleteLicence(*gin.Context)
+	GetExpirySummary(*gin.Context)
+}
+
+type licenceController struct {
+	licenceService service.LicenceService
+}
+
+func newLicenceController(licenceService service.LicenceService) LicenceController {
+	return &licenceController{licenceService: licenceService}
+}
+
+// GetLicences godoc
+//
+// @Summary Get user licences
+// @Description Get all pilot licences of a user
+// @Tags licences
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {array}       dto.LicenceResponse
+// @Failure 500 {object}      util.HTTPError
+// @Router  /licences [get]
+func (c *licenceController) GetLicences(ctx *gin.Context) {
+	userID := ctx.GetString(common.UserID)
+
+	licences, err := c.licenceService.GetUserLicences(userID)
+	if err != nil {
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.adaptLicences(licences))
+}
+
+// GetLicence godoc
+//
+// @Summary Get a licence
+// @Description Get a single pilot licence of a user
+// @Tags licences
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   id                path     int        true        "Licence ID"
+// @Success 200 {object}      dto.LicenceResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 404 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /licences/{id} [get]
+func (c *licenceController) GetLicence(ctx *gin.Context) {
+	licenceID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := ctx.GetString(common.UserID)
+
+	licence, err := c.licenceService.GetLicence(userID, uint(licenceID))
+	if err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			util.NewError(ctx, http.StatusNotFound, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.adaptLicence(licence))
+}
+
+// InsertLicence godoc
+//
+// @Summary Insert a new licence
+// @Description Insert a new pilot licence for a user
+// @Tags licences
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   licenceRequest    body     dto.LicenceRequest true    "Licence to insert"
+// @Success 201 {object}      dto.LicenceResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /licences [post]
+func (c *licenceController) InsertLicence(ctx *gin.Context) {
+	userID := ctx.GetString(common.UserID)
+
+	var licenceRequest dto.LicenceRequest
+	if err := ctx.ShouldBindJSON(&licenceRequest); err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	licence, err := c.licenceService.InsertLicence(userID, licenceRequest)
+	if err != nil {
+		if errors.Is(err, dto.ErrBadRequest) {
+			util.NewError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, c.adaptLicence(licence))
+}
+
+// UpdateLicence godoc
+//
+// @Summary Update an existing licence
+// @Description Update an existing pilot licence of a user
+// @Tags licences
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   id                path     int        true        "Licence ID to update"
+// @Param   licenceRequest    body     dto.LicenceRequest true    "Licence information to update"
+// @Success 200 {object}      dto.LicenceResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 404 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /licences/{id} [put]
+func (c *licenceController) UpdateLicence(ctx *gin.Context) {
+	licenceID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := ctx.GetString(common.UserID)
+
+	var licenceRequest dto.LicenceRequest
+	if err := ctx.ShouldBindJSON(&licenceRequest); err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	licence, err := c.licenceService.UpdateLicence(userID, uint(licenceID), licenceRequest)
+	if err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			util.NewError(ctx, http.StatusNotFound, err)
+			return
+		} else if errors.Is(err, dto.ErrBadRequest) {
+			util.NewError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.adaptLicence(licence))
+}
+
+// DeleteLicence godoc
+//
+// @Summary Delete an existing licence
+// @Description Delete a pilot licence of a user, licences with endorsed ratings cannot be deleted
+// @Tags licences
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   id                path     int        true        "Licence ID to delete"
+// @Success 200 {object}      object{message=string} "Licence deleted successfully"
+// @Failure 400 {object}      util.HTTPError
+// @Failure 404 {object}      util.HTTPError
+// @Failure 409 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /licences/{id} [delete]
+func (c *licenceController) DeleteLicence(ctx *gin.Context) {
+	licenceID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := ctx.GetString(common.UserID)
+
+	err = c.licenceService.DeleteLicence(userID, uint(licenceID))
+	if err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			util.NewError(ctx, http.StatusNotFound, err)
+			return
+		} else if errors.Is(err, dto.ErrConflict) {
+			util.NewError(ctx, http.StatusConflict, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Licence deleted successfully"})
+}
+
+// GetExpirySummary godoc
+//
+// @Summary Get expiry summary of licences and ratings
+// @Description Get the licences and ratings of a user sorted into valid ones, ones expiring soon and lapsed ones
+// @Tags licences
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {object}      dto.ExpirySummaryResponse
+// @Failure 500 {object}      util.HTTPError
+// @Router  /licences/summary [get]
+func (c *licenceController) GetExpirySummary(ctx *gin.Context) {
+	userID := ctx.GetString(common.UserID)
+
+	summary, err := c.licenceService.GetExpirySummary(userID)
+	if err != nil {
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, summary)
+}
+
+func (c *licenceController) adaptLicence(licence model.Licence) dto.LicenceResponse {
+	return dto.LicenceResponse{
+		ID:           licence.ID,
+		Type:         licence.Type,
+		Number:       licence.Number,
+		IssuingState: licence.IssuingState,
+		IssuedAt:     licence.IssuedAt,
+		ExpiresAt:    licence.ExpiresAt,
+		Remarks:      licence.Remarks,
+		UpdatedAt:    licence.UpdatedAt,
+	}
+}
+
+func (c *licenceController) adaptLicences(licences []model.Licence) []dto.LicenceResponse {
+	licenceResponses := make([]dto.LicenceResponse, 0, len(licences))
+	for _, licence := range licences {
+		licenceResponses = append(licenceResponses, c.adaptLicence(licence))
+	}
+	return licenceResponses
+}
@@ -0,0 +1,280 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/service"
+	"github.com/avialog/backend/internal/util"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("LicenceController", func() {
+	var (
+		licenceController  LicenceController
+		licenceServiceCtrl *gomock.Controller
+		licenceServiceMock *service.MockLicenceService
+		w                  *httptest.ResponseRecorder
+		ctx                *gin.Context
+		licenceRequest     dto.LicenceRequest
+		mockLicence        model.Licence
+		expectedLicence    dto.LicenceResponse
+	)
+
+	BeforeEach(func() {
+		licenceServiceCtrl = gomock.NewController(GinkgoT())
+		licenceServiceMock = service.NewMockLicenceService(licenceServiceCtrl)
+		w = httptest.NewRecorder()
+		ctx, _ = gin.CreateTestContext(w)
+		licenceController = newLicenceController(licenceServiceMock)
+
+		issuedAt := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+		licenceRequest = dto.LicenceRequest{
+			Type:         model.LicenceTypeCPL,
+			Number:       "PL.FCL.12345",
+			IssuingState: "PL",
+			IssuedAt:     &issuedAt,
+		}
+		mockLicence = model.Licence{
+			Model:        gorm.Model{ID: 2},
+			UserID:       "1",
+			Type:         model.LicenceTypeCPL,
+			Number:       "PL.FCL.12345",
+			IssuingState: "PL",
+			IssuedAt:     &issuedAt,
+		}
+		expectedLicence = dto.LicenceResponse{
+			ID:           2,
+			Type:         model.LicenceTypeCPL,
+			Number:       "PL.FCL.12345",
+			IssuingState: "PL",
+			IssuedAt:     &issuedAt,
+		}
+	})
+
+	AfterEach(func() {
+		licenceServiceCtrl.Finish()
+	})
+
+	Describe("GetLicences", func() {
+		Context("when the user has licences", func() {
+			It("should return 200 and the licences", func() {
+				// given
+				expectedJSON, err := json.Marshal([]dto.LicenceResponse{expectedLicence})
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/api/licences", nil)
+				ctx.Set("userID", "1")
+				licenceServiceMock.EXPECT().GetUserLicences("1").Return([]model.Licence{mockLicence}, nil)
+
+				// when
+				licenceController.GetLicences(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body).To(MatchJSON(expectedJSON))
+			})
+		})
+		Context("when the user has no licences", func() {
+			It("should return 200 and an empty array", func() {
+				// given
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/api/licences", nil)
+				ctx.Set("userID", "1")
+				licenceServiceMock.EXPECT().GetUserLicences("1").Return(nil, nil)
+
+				// when
+				licenceController.GetLicences(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body).To(MatchJSON(`[]`))
+			})
+		})
+	})
+
+	Describe("GetLicence", func() {
+		Context("when the licence does not exist", func() {
+			It("should return 404 and error message", func() {
+				// given
+				ctx.Params = gin.Params{gin.Param{Key: "id", Value: "2"}}
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/api/licences/2", nil)
+				ctx.Set("userID", "1")
+				licenceServiceMock.EXPECT().GetLicence("1", uint(2)).
+					Return(model.Licence{}, fmt.Errorf("%w: %v", dto.ErrNotFound, gorm.ErrRecordNotFound))
+
+				// when
+				licenceController.GetLicence(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body).To(MatchJSON(`{"code":404,"message":"not found: record not found"}`))
+			})
+		})
+	})
+
+	Describe("InsertLicence", func() {
+		Context("when the licence is valid", func() {
+			It("should return 201 and the licence", func() {
+				// given
+				requestJSON, err := json.Marshal(licenceRequest)
+				Expect(err).ToNot(HaveOccurred())
+				expectedJSON, err := json.Marshal(expectedLicence)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest(http.MethodPost, "/api/licences", bytes.NewBuffer(requestJSON))
+				ctx.Set("userID", "1")
+				licenceServiceMock.EXPECT().InsertLicence("1", licenceRequest).Return(mockLicence, nil)
+
+				// when
+				licenceController.InsertLicence(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusCreated))
+				Expect(w.Body).To(MatchJSON(expectedJSON))
+			})
+		})
+		Context("when the licence expires before it was issued", func() {
+			It("should return 400 and the invalid fields", func() {
+				// given
+				requestJSON, err := json.Marshal(licenceRequest)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest(http.MethodPost, "/api/licences", bytes.NewBuffer(requestJSON))
+				ctx.Set("userID", "1")
+				validationError := &dto.ValidationError{Errors: []dto.FieldError{{Field: "expires_at", Code: dto.CodeBeforeIssueDate}}}
+				licenceServiceMock.EXPECT().InsertLicence("1", licenceRequest).
+					Return(model.Licence{}, fmt.Errorf("%w: %w", dto.ErrBadRequest, validationError))
+
+				// when
+				licenceController.InsertLicence(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body).To(MatchJSON(`{"code":400,"message":"bad request: invalid data in field: expires_at",
+					"errors":[{"field":"expires_at","code":"before_issue_date"}]}`))
+			})
+		})
+	})
+
+	Describe("UpdateLicence", func() {
+		Context("when the licence is updated", func() {
+			It("should return 200 and the licence", func() {
+				// given
+				requestJSON, err := json.Marshal(licenceRequest)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Params = gin.Params{gin.Param{Key: "id", Value: "2"}}
+				ctx.Request = httptest.NewRequest(http.MethodPut, "/api/licences/2", bytes.NewBuffer(requestJSON))
+				ctx.Set("userID", "1")
+				licenceServiceMock.EXPECT().UpdateLicence("1", uint(2), licenceRequest).Return(mockLicence, nil)
+
+				// when
+				licenceController.UpdateLicence(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusOK))
+			})
+		})
+		Context("when the id is not a number", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Params = gin.Params{gin.Param{Key: "id", Value: "a"}}
+
+				// when
+				licenceController.UpdateLicence(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body.String()).To(Equal(`{"code":400,"message":"strconv.ParseUint: parsing \"a\": invalid syntax"}`))
+			})
+		})
+	})
+
+	Describe("DeleteLicence", func() {
+		Context("when ratings are endorsed on the licence", func() {
+			It("should return 409 and error message", func() {
+				// given
+				ctx.Params = gin.Params{gin.Param{Key: "id", Value: "2"}}
+				ctx.Request = httptest.NewRequest(http.MethodDelete, "/api/licences/2", nil)
+				ctx.Set("userID", "1")
+				licenceServiceMock.EXPECT().DeleteLicence("1", uint(2)).
+					Return(fmt.Errorf("%w: licence has endorsed ratings", dto.ErrConflict))
+
+				// when
+				licenceController.DeleteLicence(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusConflict))
+				Expect(w.Body).To(MatchJSON(`{"code":409,"message":"conflict: licence has endorsed ratings"}`))
+			})
+		})
+		Context("when the licence is deleted", func() {
+			It("should return 200 and message", func() {
+				// given
+				ctx.Params = gin.Params{gin.Param{Key: "id", Value: "2"}}
+				ctx.Request = httptest.NewRequest(http.MethodDelete, "/api/licences/2", nil)
+				ctx.Set("userID", "1")
+				licenceServiceMock.EXPECT().DeleteLicence("1", uint(2)).Return(nil)
+
+				// when
+				licenceController.DeleteLicence(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body).To(MatchJSON(`{"message":"Licence deleted successfully"}`))
+			})
+		})
+	})
+
+	Describe("GetExpirySummary", func() {
+		Context("when no error occurs", func() {
+			It("should return 200 and the summary", func() {
+				// given
+				expiresAt := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+				summary := dto.ExpirySummaryResponse{
+					Valid: []dto.ExpiryItem{{Type: dto.ExpiryItemTypeLicence, ID: 2, Name: "CPL PL.FCL.12345"}},
+					ExpiringSoon: []dto.ExpiryItem{{Type: dto.ExpiryItemTypeRating, ID: 3, Name: "SEP",
+						ExpiresAt: &expiresAt, DaysLeft: util.Int(71)}},
+					Lapsed: []dto.ExpiryItem{},
+				}
+				expectedJSON, err := json.Marshal(summary)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/api/licences/summary", nil)
+				ctx.Set("userID", "1")
+				licenceServiceMock.EXPECT().GetExpirySummary("1").Return(summary, nil)
+
+				// when
+				licenceController.GetExpirySummary(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body).To(MatchJSON(expectedJSON))
+			})
+		})
+		Context("when the service fails", func() {
+			It("should return 500 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/api/licences/summary", nil)
+				ctx.Set("userID", "1")
+				licenceServiceMock.EXPECT().GetExpirySummary("1").
+					Return(dto.ExpirySummaryResponse{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, "db error"))
+
+				// when
+				licenceController.GetExpirySummary(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusInternalServerError))
+			})
+		})
+	})
+})
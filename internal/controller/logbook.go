@@ -2,6 +2,7 @@ package controller
 
 import (
 	"errors"
+	"fmt"
 	"github.com/avialog/backend/internal/common"
 	"github.com/avialog/backend/internal/dto"
 	"github.com/avialog/backend/internal/service"
@@ -9,14 +10,22 @@ import (
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 )
 
 type LogbookController interface {
 	GetLogbookEntries(*gin.Context)
+	GetLogbookEntry(*gin.Context)
 	InsertLogbookEntry(*gin.Context)
 	UpdateLogbookEntry(*gin.Context)
+	AmendLogbookEntry(*gin.Context)
 	DeleteLogbookEntry(*gin.Context)
+	BatchLogbookEntries(*gin.Context)
+	GetLogbookTotals(*gin.Context)
+	ExportLogbook(*gin.Context)
+	PreviewNightTime(*gin.Context)
+	GetDuplicateEntries(*gin.Context)
 }
 
 type logbookController struct {
@@ -30,42 +39,105 @@ func newLogbookController(logbookService service.LogbookService) LogbookControll
 // GetLogbookEntries godoc
 //
 // @Summary Get user logbook entries
-// @Description Get a list of logbook entries for a user
+// @Description Get a page of logbook entries for a user sorted by takeoff time, optionally filtered. Pass next_cursor of a page as cursor to get the next page.
 // @Tags logbook
 // @Produce  json
 // @Security ApiKeyAuth
-// @Success 200 {object} []dto.LogbookResponse
+// @Param   start             query    int        false       "Start of the date range (unix timestamp)"
+// @Param   end               query    int        false       "End of the date range (unix timestamp)"
+// @Param   aircraft_id       query    int        false       "Aircraft ID"
+// @Param   airport_code      query    string     false       "ICAO or IATA code of the takeoff or landing airport"
+// @Param   role              query    string     false       "Role of the user during the flight"
+// @Param   style             query    string     false       "Style of the flight"
+// @Param   remarks           query    string     false       "Text contained in remarks or personal remarks"
+// @Param   order             query    string     false       "Sort order of takeoff time, asc or desc (default)"
+// @Param   limit             query    int        false       "Number of entries per page, 50 by default and at most 200"
+// @Param   cursor            query    string     false       "Cursor of the next page"
+// @Param   If-None-Match     header   string     false       "ETag of the page the client has"
+// @Success 200 {object}      dto.LogbookPageResponse
+// @Success 304 "Not modified"
 // @Failure 500 {object}      util.HTTPError
 // @Failure 400 {object}      util.HTTPError
 // @Router  /logbook [get]
 func (c *logbookController) GetLogbookEntries(ctx *gin.Context) {
 	userID := ctx.GetString(common.UserID)
-	var start time.Time
-	var end time.Time
 
 	var getLogbookRequest dto.GetLogbookRequest
-	if err := ctx.ShouldBindJSON(&getLogbookRequest); err != nil {
+	if err := ctx.ShouldBindQuery(&getLogbookRequest); err != nil {
 		util.NewError(ctx, http.StatusBadRequest, err)
 		return
 	}
 
-	if getLogbookRequest.Start == nil && getLogbookRequest.End != nil || getLogbookRequest.Start != nil && getLogbookRequest.End == nil {
-		util.NewError(ctx, http.StatusBadRequest, errors.New("both start and end time must be provided or neither"))
+	filter := dto.LogbookFilter{
+		AircraftID:  getLogbookRequest.AircraftID,
+		AirportCode: getLogbookRequest.AirportCode,
+		Role:        getLogbookRequest.Role,
+		Style:       getLogbookRequest.Style,
+		Remarks:     getLogbookRequest.Remarks,
+	}
+	if getLogbookRequest.Start != nil {
+		start := time.Unix(*getLogbookRequest.Start, 0)
+		filter.Start = &start
+	}
+	if getLogbookRequest.End != nil {
+		end := time.Unix(*getLogbookRequest.End, 0)
+		filter.End = &end
+	}
+	if getLogbookRequest.Order != nil {
+		filter.Order = *getLogbookRequest.Order
+	}
+
+	page, err := c.logbookService.GetLogbookEntries(userID, filter, getLogbookRequest.Limit, getLogbookRequest.Cursor)
+	if err != nil {
+		if errors.Is(err, dto.ErrBadRequest) {
+			util.NewError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	respondWithContentETag(ctx, http.StatusOK, page)
+}
+
+// GetLogbookEntry godoc
+//
+// @Summary Get a logbook entry
+// @Description Get a single logbook entry of a user with its aircraft, passengers and landings, the ETag of the response can be passed as If-Match to update it
+// @Tags logbook
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   id                path     int        true        "Flight ID"
+// @Param   If-None-Match     header   string     false       "ETag of the entry the client has"
+// @Success 200 {object}      dto.LogbookResponse
+// @Success 304 "Not modified"
+// @Failure 400 {object}      util.HTTPError
+// @Failure 404 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /logbook/{id} [get]
+func (c *logbookController) GetLogbookEntry(ctx *gin.Context) {
+	flightID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
 		return
-	} else if getLogbookRequest.Start == nil && getLogbookRequest.End == nil {
-		start = time.Now().AddDate(0, 0, -90)
-		end = time.Now()
-	} else {
-		start = time.Unix(*getLogbookRequest.Start, 0)
-		end = time.Unix(*getLogbookRequest.End, 0)
 	}
-	flights, err := c.logbookService.GetLogbookEntries(userID, start, end)
+
+	userID := ctx.GetString(common.UserID)
+
+	logbookResponse, err := c.logbookService.GetLogbookEntry(userID, uint(flightID))
 	if err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			util.NewError(ctx, http.StatusNotFound, err)
+			return
+		} else if errors.Is(err, dto.ErrBadRequest) {
+			util.NewError(ctx, http.StatusBadRequest, err)
+			return
+		}
 		util.NewError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, flights)
+	respondWithETag(ctx, http.StatusOK, logbookEntryETag(logbookResponse), logbookResponse)
 }
 
 // InsertLogbookEntry godoc
@@ -79,6 +151,7 @@ func (c *logbookController) GetLogbookEntries(ctx *gin.Context) {
 // @Param   logbookRequest     body     dto.LogbookRequest true    "Logbook entry information to insert"
 // @Success 201 {object}      dto.LogbookResponse
 // @Failure 400 {object}      util.HTTPError
+// @Failure 409 {object}      util.HTTPError
 // @Failure 500 {object}      util.HTTPError
 // @Router  /logbook [post]
 func (c *logbookController) InsertLogbookEntry(ctx *gin.Context) {
@@ -95,6 +168,9 @@ func (c *logbookController) InsertLogbookEntry(ctx *gin.Context) {
 		if errors.Is(err, dto.ErrBadRequest) {
 			util.NewError(ctx, http.StatusBadRequest, err)
 			return
+		} else if errors.Is(err, dto.ErrConflict) {
+			util.NewError(ctx, http.StatusConflict, err)
+			return
 		}
 		util.NewError(ctx, http.StatusInternalServerError, err)
 		return
@@ -105,15 +181,18 @@ func (c *logbookController) InsertLogbookEntry(ctx *gin.Context) {
 // UpdateLogbookEntry godoc
 //
 // @Summary Update an existing logbook entry
-// @Description Update an existing logbook entry for a user
+// @Description Update an existing logbook entry for a user, signed entries can only be amended. With If-Match the entry is only updated if it was not changed since the client got that version
 // @Tags logbook
 // @Accept  json
 // @Produce  json
 // @Security ApiKeyAuth
 // @Param   id                path     int        true        "Flight ID to update"
+// @Param   If-Match          header   string     false       "ETag of the entry the change is based on"
 // @Param   logbookRequest     body     dto.LogbookRequest true    "Logbook entry information to update"
 // @Success 200 {object}      dto.LogbookResponse
 // @Failure 400 {object}      util.HTTPError
+// @Failure 409 {object}      util.HTTPError
+// @Failure 412 {object}      util.HTTPError
 // @Failure 500 {object}      util.HTTPError
 // @Router /logbook/{id} [put]
 func (c *logbookController) UpdateLogbookEntry(ctx *gin.Context) {
@@ -131,17 +210,90 @@ func (c *logbookController) UpdateLogbookEntry(ctx *gin.Context) {
 		return
 	}
 
-	logbookResponse, err := c.logbookService.UpdateLogbookEntry(userID, uint(flightID), logbookRequest)
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		util.NewError(ctx, http.StatusPreconditionFailed, err)
+		return
+	}
+
+	logbookResponse, err := c.logbookService.UpdateLogbookEntry(userID, uint(flightID), logbookRequest, version)
 	if err != nil {
 		if errors.Is(err, dto.ErrBadRequest) {
 			util.NewError(ctx, http.StatusBadRequest, err)
 			return
+		} else if errors.Is(err, dto.ErrConflict) {
+			util.NewError(ctx, http.StatusConflict, err)
+			return
+		} else if errors.Is(err, dto.ErrPreconditionFailed) {
+			util.NewError(ctx, http.StatusPreconditionFailed, err)
+			return
 		}
 		util.NewError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
-	ctx.JSON(http.StatusOK, logbookResponse)
+	respondWithETag(ctx, http.StatusOK, logbookEntryETag(logbookResponse), logbookResponse)
+}
+
+// AmendLogbookEntry godoc
+//
+// @Summary Amend a logbook entry
+// @Description Change a logbook entry of a user which may be signed, the signature of the entry is invalidated. With If-Match the entry is only amended if it was not changed since the client got that version
+// @Tags logbook
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   id                path     int        true        "Flight ID to amend"
+// @Param   If-Match          header   string     false       "ETag of the entry the change is based on"
+// @Param   logbookRequest     body     dto.LogbookRequest true    "Amended logbook entry information"
+// @Success 200 {object}      dto.LogbookResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 404 {object}      util.HTTPError
+// @Failure 409 {object}      util.HTTPError
+// @Failure 412 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router /logbook/{id}/amend [post]
+func (c *logbookController) AmendLogbookEntry(ctx *gin.Context) {
+	flightID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := ctx.GetString(common.UserID)
+
+	var logbookRequest dto.LogbookRequest
+	if err := ctx.ShouldBindJSON(&logbookRequest); err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	version, err := ifMatchVersion(ctx)
+	if err != nil {
+		util.NewError(ctx, http.StatusPreconditionFailed, err)
+		return
+	}
+
+	logbookResponse, err := c.logbookService.AmendLogbookEntry(userID, uint(flightID), logbookRequest, version)
+	if err != nil {
+		if errors.Is(err, dto.ErrBadRequest) {
+			util.NewError(ctx, http.StatusBadRequest, err)
+			return
+		} else if errors.Is(err, dto.ErrNotFound) {
+			util.NewError(ctx, http.StatusNotFound, err)
+			return
+		} else if errors.Is(err, dto.ErrConflict) {
+			util.NewError(ctx, http.StatusConflict, err)
+			return
+		} else if errors.Is(err, dto.ErrPreconditionFailed) {
+			util.NewError(ctx, http.StatusPreconditionFailed, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	respondWithETag(ctx, http.StatusOK, logbookEntryETag(logbookResponse), logbookResponse)
 }
 
 // DeleteLogbookEntry godoc
@@ -155,6 +307,7 @@ func (c *logbookController) UpdateLogbookEntry(ctx *gin.Context) {
 // @Success 200 {object}      object{message=string} "Logbook entry deleted successfully"
 // @Failure 400 {object}      util.HTTPError
 // @Failure 404 {object}      util.HTTPError
+// @Failure 409 {object}      util.HTTPError
 // @Failure 500 {object}      util.HTTPError
 // @Router  /logbook/{id} [delete]
 func (c *logbookController) DeleteLogbookEntry(ctx *gin.Context) {
@@ -166,7 +319,7 @@ func (c *logbookController) DeleteLogbookEntry(ctx *gin.Context) {
 
 	userID := ctx.GetString(common.UserID)
 
-	err = c.logbookService.DeleteLogbookEntry(userID, uint(flightID))
+	err = c.logbookService.DeleteLogbookEntry(userID, uint(flightID), nil)
 	if err != nil {
 		if errors.Is(err, dto.ErrNotFound) {
 			util.NewError(ctx, http.StatusNotFound, err)
@@ -174,6 +327,9 @@ func (c *logbookController) DeleteLogbookEntry(ctx *gin.Context) {
 		} else if errors.Is(err, dto.ErrBadRequest) {
 			util.NewError(ctx, http.StatusBadRequest, err)
 			return
+		} else if errors.Is(err, dto.ErrConflict) {
+			util.NewError(ctx, http.StatusConflict, err)
+			return
 		}
 		util.NewError(ctx, http.StatusInternalServerError, err)
 		return
@@ -181,3 +337,254 @@ func (c *logbookController) DeleteLogbookEntry(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "Logbook entry deleted successfully"})
 }
+
+// BatchLogbookEntries godoc
+//
+// @Summary Create, update and delete logbook entries in a batch
+// @Description Apply up to 100 operations in the order of the request and return the result of each of them. An atomic batch is applied entirely or not at all, otherwise every operation which succeeds is applied.
+// @Tags logbook
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   batchRequest      body     dto.LogbookBatchRequest true    "Operations to apply"
+// @Success 200 {object}      dto.LogbookBatchResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /logbook/batch [post]
+func (c *logbookController) BatchLogbookEntries(ctx *gin.Context) {
+	userID := ctx.GetString(common.UserID)
+
+	var batchRequest dto.LogbookBatchRequest
+	if err := ctx.ShouldBindJSON(&batchRequest); err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	batchResponse, err := c.logbookService.BatchLogbookEntries(userID, batchRequest)
+	if err != nil {
+		if errors.Is(err, dto.ErrBadRequest) {
+			util.NewError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, batchResponse)
+}
+
+// GetDuplicateEntries godoc
+//
+// @Summary Get likely duplicate logbook entries
+// @Description Get groups of logbook entries of the same route taking off within 5 minutes of each other
+// @Tags logbook
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {object}      []dto.DuplicateGroupResponse
+// @Failure 500 {object}      util.HTTPError
+// @Router  /logbook/duplicates [get]
+func (c *logbookController) GetDuplicateEntries(ctx *gin.Context) {
+	userID := ctx.GetString(common.UserID)
+
+	duplicates, err := c.logbookService.GetDuplicateEntries(userID)
+	if err != nil {
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, duplicates)
+}
+
+// GetLogbookTotals godoc
+//
+// @Summary Get user logbook totals
+// @Description Get the sum of every time column and landing counts of user logbook, optionally restricted to a date range, aircraft or role
+// @Tags logbook
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   start             query    int        false       "Start of the date range (unix timestamp)"
+// @Param   end               query    int        false       "End of the date range (unix timestamp)"
+// @Param   aircraft_id       query    int        false       "Aircraft ID"
+// @Param   role              query    string     false       "Role of the user during the flight"
+// @Success 200 {object}      dto.TotalsResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /logbook/totals [get]
+func (c *logbookController) GetLogbookTotals(ctx *gin.Context) {
+	userID := ctx.GetString(common.UserID)
+
+	var totalsRequest dto.TotalsRequest
+	if err := ctx.ShouldBindQuery(&totalsRequest); err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	filter := dto.TotalsFilter{
+		AircraftID: totalsRequest.AircraftID,
+		Role:       totalsRequest.Role,
+	}
+	if totalsRequest.Start != nil {
+		start := time.Unix(*totalsRequest.Start, 0)
+		filter.Start = &start
+	}
+	if totalsRequest.End != nil {
+		end := time.Unix(*totalsRequest.End, 0)
+		filter.End = &end
+	}
+
+	totals, err := c.logbookService.GetLogbookTotals(userID, filter)
+	if err != nil {
+		if errors.Is(err, dto.ErrBadRequest) {
+			util.NewError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, totals)
+}
+
+// ExportLogbook godoc
+//
+// @Summary Export user logbook
+// @Description Export user logbook as a document. PDF export contains the whole logbook in the EASA FCL.050 layout, CSV export contains flights from the optional date range with the selected columns, durations are exported both as HH:MM and decimal hours
+// @Tags logbook
+// @Produce  application/pdf
+// @Produce  text/csv
+// @Security ApiKeyAuth
+// @Param   format            query    string     false       "Export format (pdf, csv)" default(pdf)
+// @Param   start             query    int        false       "Start of the date range (unix timestamp), csv only"
+// @Param   end               query    int        false       "End of the date range (unix timestamp), csv only"
+// @Param   columns           query    string     false       "Comma separated list of columns, csv only"
+// @Success 200 {file}        file
+// @Failure 400 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /logbook/export [get]
+func (c *logbookController) ExportLogbook(ctx *gin.Context) {
+	userID := ctx.GetString(common.UserID)
+
+	var exportRequest dto.ExportRequest
+	if err := ctx.ShouldBindQuery(&exportRequest); err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	format := "pdf"
+	if exportRequest.Format != nil {
+		format = *exportRequest.Format
+	}
+
+	switch format {
+	case "pdf":
+		c.exportLogbookPDF(ctx, userID)
+	case "csv":
+		c.exportLogbookCSV(ctx, userID, exportRequest)
+	default:
+		util.NewError(ctx, http.StatusBadRequest, fmt.Errorf("%w: unsupported export format: %v", dto.ErrBadRequest, format))
+	}
+}
+
+func (c *logbookController) exportLogbookPDF(ctx *gin.Context, userID string) {
+	document, err := c.logbookService.ExportLogbookPDF(userID)
+	if err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			util.NewError(ctx, http.StatusNotFound, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Header("Content-Disposition", `attachment; filename="logbook.pdf"`)
+	ctx.Data(http.StatusOK, "application/pdf", document)
+}
+
+func (c *logbookController) exportLogbookCSV(ctx *gin.Context, userID string, exportRequest dto.ExportRequest) {
+	var filter dto.ExportFilter
+	if exportRequest.Start != nil {
+		start := time.Unix(*exportRequest.Start, 0)
+		filter.Start = &start
+	}
+	if exportRequest.End != nil {
+		end := time.Unix(*exportRequest.End, 0)
+		filter.End = &end
+	}
+	if exportRequest.Columns != nil {
+		for _, column := range strings.Split(*exportRequest.Columns, ",") {
+			if column = strings.TrimSpace(column); column != "" {
+				filter.Columns = append(filter.Columns, column)
+			}
+		}
+	}
+
+	ctx.Header("Content-Type", "text/csv; charset=utf-8")
+	ctx.Header("Content-Disposition", `attachment; filename="logbook.csv"`)
+
+	err := c.logbookService.ExportLogbookCSV(userID, filter, ctx.Writer)
+	if err != nil {
+		if ctx.Writer.Written() {
+			// the response is already partially sent, the status code can't be changed anymore
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.Writer.Header().Del("Content-Type")
+		ctx.Writer.Header().Del("Content-Disposition")
+		if errors.Is(err, dto.ErrBadRequest) {
+			util.NewError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+	}
+}
+
+// PreviewNightTime godoc
+//
+// @Summary Preview night time of a flight
+// @Description Compute night time of a flight from the civil twilight along the great circle route and the split of its landings between day and night, as filled in when a logbook entry is saved without them
+// @Tags logbook
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   takeoff_airport_code  query    string     true        "ICAO or IATA code of the takeoff airport"
+// @Param   takeoff_time          query    int        true        "Takeoff time (unix timestamp)"
+// @Param   landing_airport_code  query    string     true        "ICAO or IATA code of the landing airport"
+// @Param   landing_time          query    int        true        "Landing time (unix timestamp)"
+// @Param   landings              query    int        false       "Number of landings, 1 by default"
+// @Success 200 {object}      dto.NightTimeResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /logbook/night [get]
+func (c *logbookController) PreviewNightTime(ctx *gin.Context) {
+	var nightTimeRequest dto.NightTimeRequest
+	if err := ctx.ShouldBindQuery(&nightTimeRequest); err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	route := dto.FlightRoute{
+		TakeoffAirportCode: nightTimeRequest.TakeoffAirportCode,
+		LandingAirportCode: nightTimeRequest.LandingAirportCode,
+		Landings:           1,
+	}
+	if nightTimeRequest.TakeoffTime != nil {
+		route.TakeoffTime = time.Unix(*nightTimeRequest.TakeoffTime, 0).UTC()
+	}
+	if nightTimeRequest.LandingTime != nil {
+		route.LandingTime = time.Unix(*nightTimeRequest.LandingTime, 0).UTC()
+	}
+	if nightTimeRequest.Landings != nil {
+		route.Landings = *nightTimeRequest.Landings
+	}
+
+	nightTime, err := c.logbookService.PreviewNightTime(route)
+	if err != nil {
+		if errors.Is(err, dto.ErrBadRequest) {
+			util.NewError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, nightTime)
+}
@@ -3,6 +3,7 @@ package controller
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"github.com/avialog/backend/internal/dto"
 	"github.com/avialog/backend/internal/model"
 	"github.com/avialog/backend/internal/service"
@@ -11,6 +12,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
+	"io"
 	"net/http/httptest"
 	"time"
 )
@@ -34,12 +36,15 @@ var _ = Describe("LogbookController", func() {
 		logbookController = newLogbookController(logbookServiceMock)
 		logbookEntriesMock = []dto.LogbookResponse{
 			{
+				ID:                  1,
 				AircraftID:          1,
+				Aircraft:            dto.AircraftSummary{ID: 1, RegistrationNumber: "SP-ABC", AircraftModel: "C152"},
 				TakeoffTime:         time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
 				TakeoffAirportCode:  "JFK",
 				LandingTime:         time.Date(2024, 3, 1, 1, 0, 0, 0, time.UTC),
 				LandingAirportCode:  "LAX",
 				Style:               model.StyleY,
+				MyRole:              model.RolePilotInCommand,
 				Remarks:             util.String("Remarks"),
 				PersonalRemarks:     util.String("PersonalRemarks"),
 				TotalBlockTime:      util.Duration(1 * time.Hour),
@@ -55,8 +60,9 @@ var _ = Describe("LogbookController", func() {
 				CrossCountryTime:    util.Duration(11 * time.Hour),
 				SimulatorTime:       util.Duration(12 * time.Hour),
 				SignatureURL:        util.String("SignatureURL"),
-				Passengers: []dto.PassengerEntry{
+				Passengers: []dto.PassengerResponse{
 					{
+						ID:           1,
 						Role:         model.RolePilotInCommand,
 						FirstName:    "John",
 						LastName:     util.String("Doe"),
@@ -66,6 +72,7 @@ var _ = Describe("LogbookController", func() {
 						Note:         util.String("Note"),
 					},
 					{
+						ID:           2,
 						Role:         model.RoleSecondInCommand,
 						FirstName:    "Jane",
 						LastName:     util.String("Smiths"),
@@ -75,7 +82,7 @@ var _ = Describe("LogbookController", func() {
 						Note:         util.String("Notes"),
 					},
 				},
-				Landings: []dto.LandingEntry{
+				Landings: []dto.LandingResponse{
 					{
 						ApproachType: model.ApproachTypeVisual,
 						Count:        util.Uint(1),
@@ -113,7 +120,7 @@ var _ = Describe("LogbookController", func() {
 				CrossCountryTime:    util.Duration(12 * time.Hour),
 				SimulatorTime:       util.Duration(13 * time.Hour),
 				SignatureURL:        util.String("SignatureURL2"),
-				Passengers: []dto.PassengerEntry{
+				Passengers: []dto.PassengerResponse{
 					{
 						Role:         model.RolePilotInCommand,
 						FirstName:    "John2",
@@ -133,7 +140,7 @@ var _ = Describe("LogbookController", func() {
 						Note:         util.String("Notes2"),
 					},
 				},
-				Landings: []dto.LandingEntry{
+				Landings: []dto.LandingResponse{
 					{
 						ApproachType: model.ApproachTypeVisual,
 						Count:        util.Uint(2),
@@ -172,7 +179,6 @@ var _ = Describe("LogbookController", func() {
 			IFRSimulatedTime:    util.Duration(10 * time.Hour),
 			CrossCountryTime:    util.Duration(11 * time.Hour),
 			SimulatorTime:       util.Duration(12 * time.Hour),
-			SignatureURL:        util.String("SignatureURL"),
 			Passengers: []dto.PassengerEntry{
 				{
 					Role:         model.RolePilotInCommand,
@@ -218,52 +224,60 @@ var _ = Describe("LogbookController", func() {
 	})
 
 	Describe("GetLogbookEntries", func() {
-		Context("When the user doesn't send a request and no error occurs.", func() {
-			It("should return 200 and only those entries that match the date", func() {
+		Context("When the user doesn't send query parameters and no error occurs.", func() {
+			It("should return 200 and the first page", func() {
 				// given
-				expectedLogbookEntriesJSON, err := json.Marshal(logbookEntriesMock)
+				page := dto.LogbookPageResponse{Entries: logbookEntriesMock, Paging: dto.PagingResponse{Limit: 50}}
+				expectedPageJSON, err := json.Marshal(page)
 				Expect(err).ToNot(HaveOccurred())
 
-				ctx.Request = httptest.NewRequest("GET", "/logbook", bytes.NewBuffer([]byte("{}")))
+				ctx.Request = httptest.NewRequest("GET", "/logbook", nil)
 				ctx.Set("userID", "1")
-				logbookServiceMock.EXPECT().GetLogbookEntries("1", gomock.Any(), gomock.Any()).DoAndReturn(
-					func(userID string, start time.Time, end time.Time) ([]dto.LogbookResponse, error) {
-						Expect(start).To(BeTemporally("~", time.Now().AddDate(0, 0, -90), time.Second))
-						Expect(end).To(BeTemporally("~", time.Now(), time.Second))
-						return logbookEntriesMock, nil
-					})
+				logbookServiceMock.EXPECT().GetLogbookEntries("1", dto.LogbookFilter{}, nil, nil).Return(page, nil)
 
 				// when
 				logbookController.GetLogbookEntries(ctx)
 
 				// then
 				Expect(w.Code).To(Equal(200))
-				Expect(w.Body).To(MatchJSON(expectedLogbookEntriesJSON))
+				Expect(w.Body).To(MatchJSON(expectedPageJSON))
 			})
 		})
-		Context("When user request fail to bind", func() {
-			It("should return 400 and error message", func() {
+		Context("When the user sends filters, order and a cursor", func() {
+			It("should pass them to the service", func() {
 				// given
-				ctx.Request = httptest.NewRequest("GET", "/logbook", bytes.NewBuffer([]byte("")))
+				start := time.Date(2024, time.April, 12, 12, 0, 0, 0, time.Local)
+				end := time.Date(2024, time.April, 14, 12, 0, 0, 0, time.Local)
+				role := model.RolePilotInCommand
+				style := model.StyleVFR
+				page := dto.LogbookPageResponse{Entries: []dto.LogbookResponse{}, Paging: dto.PagingResponse{Limit: 10, HasMore: true, NextCursor: util.String("next")}}
+
+				ctx.Request = httptest.NewRequest("GET", fmt.Sprintf("/logbook?start=%d&end=%d&aircraft_id=2&airport_code=EPWA&role=PIC&style=%v&remarks=check&order=asc&limit=10&cursor=abc",
+					start.Unix(), end.Unix(), style), nil)
 				ctx.Set("userID", "1")
+				logbookServiceMock.EXPECT().GetLogbookEntries("1", dto.LogbookFilter{
+					Start:       &start,
+					End:         &end,
+					AircraftID:  util.Uint(2),
+					AirportCode: util.String("EPWA"),
+					Role:        &role,
+					Style:       &style,
+					Remarks:     util.String("check"),
+					Order:       dto.SortAscending,
+				}, util.Int(10), util.String("abc")).Return(page, nil)
+
 				// when
 				logbookController.GetLogbookEntries(ctx)
 
 				// then
-				Expect(w.Code).To(Equal(400))
-				Expect(w.Body).To(MatchJSON(`{"code": 400, "message":"EOF"}`))
+				Expect(w.Code).To(Equal(200))
+				Expect(w.Body).To(MatchJSON(`{"entries": [], "paging": {"limit": 10, "has_more": true, "next_cursor": "next"}}`))
 			})
 		})
-		Context("When the user doesn't provide a start date but provide an end date.", func() {
+		Context("When query parameters fail to bind", func() {
 			It("should return 400 and error message", func() {
 				// given
-				getLogbookRequestJSON, err := json.Marshal(dto.GetLogbookRequest{
-					Start: nil,
-					End:   util.Int64(time.Now().AddDate(0, 0, -90).Unix()),
-				})
-				Expect(err).ToNot(HaveOccurred())
-
-				ctx.Request = httptest.NewRequest("GET", "/logbook", bytes.NewBuffer(getLogbookRequestJSON))
+				ctx.Request = httptest.NewRequest("GET", "/logbook?limit=abc", nil)
 				ctx.Set("userID", "1")
 
 				// when
@@ -271,43 +285,30 @@ var _ = Describe("LogbookController", func() {
 
 				// then
 				Expect(w.Code).To(Equal(400))
-				Expect(w.Body).To(MatchJSON(`{"code": 400, "message":"both start and end time must be provided or neither"}`))
 			})
 		})
-		Context("When the user doesn't provide an end date but provide a start date.", func() {
+		Context("When the service rejects the query", func() {
 			It("should return 400 and error message", func() {
 				// given
-				getLogbookRequestJSON, err := json.Marshal(dto.GetLogbookRequest{
-					Start: util.Int64(time.Now().AddDate(0, 0, -90).Unix()),
-					End:   nil,
-				})
-				Expect(err).ToNot(HaveOccurred())
-
-				ctx.Request = httptest.NewRequest("GET", "/logbook", bytes.NewBuffer(getLogbookRequestJSON))
+				ctx.Request = httptest.NewRequest("GET", "/logbook?cursor=abc", nil)
 				ctx.Set("userID", "1")
+				logbookServiceMock.EXPECT().GetLogbookEntries("1", dto.LogbookFilter{}, nil, util.String("abc")).
+					Return(dto.LogbookPageResponse{}, fmt.Errorf("%w: invalid cursor", dto.ErrBadRequest))
 
 				// when
 				logbookController.GetLogbookEntries(ctx)
 
 				// then
 				Expect(w.Code).To(Equal(400))
-				Expect(w.Body).To(MatchJSON(`{"code": 400, "message":"both start and end time must be provided or neither"}`))
+				Expect(w.Body).To(MatchJSON(`{"code": 400, "message":"bad request: invalid cursor"}`))
 			})
 		})
 		Context("when getting user logbook entries fails", func() {
 			It("should return 500 and error message", func() {
 				// given
-				start := time.Date(2024, time.April, 12, 12, 0, 0, 0, time.Local)
-				end := time.Date(2024, time.April, 14, 12, 0, 0, 0, time.Local)
-				getLogbookRequestJSON, err := json.Marshal(dto.GetLogbookRequest{
-					Start: util.Int64(start.Unix()),
-					End:   util.Int64(end.Unix()),
-				})
-				Expect(err).ToNot(HaveOccurred())
-
-				ctx.Request = httptest.NewRequest("GET", "/logbook", bytes.NewBuffer(getLogbookRequestJSON))
+				ctx.Request = httptest.NewRequest("GET", "/logbook", nil)
 				ctx.Set("userID", "1")
-				logbookServiceMock.EXPECT().GetLogbookEntries("1", start, end).Return(nil, dto.ErrInternalFailure)
+				logbookServiceMock.EXPECT().GetLogbookEntries("1", dto.LogbookFilter{}, nil, nil).Return(dto.LogbookPageResponse{}, dto.ErrInternalFailure)
 
 				// when
 				logbookController.GetLogbookEntries(ctx)
@@ -317,7 +318,136 @@ var _ = Describe("LogbookController", func() {
 				Expect(w.Body).To(MatchJSON(`{"code": 500, "message":"internal failure"}`))
 			})
 		})
+	})
+	Describe("GetLogbookEntry", func() {
+		Context("When the entry is found", func() {
+			It("should return 200 and the entry", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/logbook/1", nil)
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
+				logbookServiceMock.EXPECT().GetLogbookEntry("1", uint(1)).Return(dto.LogbookResponse{
+					ID:                 1,
+					AircraftID:         2,
+					Aircraft:           dto.AircraftSummary{ID: 2, RegistrationNumber: "SP-ABC", AircraftModel: "C152"},
+					TakeoffTime:        time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+					TakeoffAirportCode: "EPKK",
+					LandingTime:        time.Date(2024, 3, 1, 1, 0, 0, 0, time.UTC),
+					LandingAirportCode: "EPWA",
+					Style:              model.StyleY,
+					MyRole:             model.RolePilotInCommand,
+					Passengers:         []dto.PassengerResponse{{ID: 3, Role: model.RoleSecondInCommand, FirstName: "John"}},
+					Landings:           []dto.LandingResponse{{ID: 4, ApproachType: model.ApproachTypeVisual, Count: util.Uint(1)}},
+					CreatedAt:          time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC),
+					UpdatedAt:          time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC),
+				}, nil)
+
+				// when
+				logbookController.GetLogbookEntry(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(200))
+				Expect(w.Body).To(MatchJSON(`{"id": 1, "aircraft_id": 2,
+					"aircraft": {"id": 2, "registration_number": "SP-ABC", "aircraft_model": "C152"},
+					"takeoff_time": "2024-03-01T00:00:00Z", "takeoff_airport_code": "EPKK",
+					"landing_time": "2024-03-01T01:00:00Z", "landing_airport_code": "EPWA", "style": "Y", "my_role": "PIC",
+					"remarks": null, "personal_remarks": null, "total_block_time": null, "pilot_in_command_time": null,
+					"second_in_command_time": null, "dual_received_time": null, "dual_given_time": null,
+					"multi_pilot_time": null, "night_time": null, "ifr_time": null, "ifr_actual_time": null,
+					"ifr_simulated_time": null, "cross_country_time": null, "simulator_time": null, "holdings": null,
+					"signature_url": null,
+					"passengers": [{"id": 3, "role": "SIC", "first_name": "John", "last_name": null, "company": null,
+						"phone": null, "email_address": null, "note": null}],
+					"landings": [{"id": 4, "approach_type": "VISUAL", "count": 1, "night_count": null, "day_count": null,
+						"airport_code": null}], "signature": null,
+					"created_at": "2024-03-02T00:00:00Z", "updated_at": "2024-03-03T00:00:00Z"}`))
+				Expect(w.Header().Get("ETag")).To(Equal(`"1709424000000000"`))
+			})
+		})
+		Context("When the signature request changed after the entry", func() {
+			It("should derive the ETag from the signature request", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/logbook/1", nil)
+				ctx.Request.Header.Set("If-None-Match", `"1709424000000000"`)
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
+				logbookServiceMock.EXPECT().GetLogbookEntry("1", uint(1)).Return(dto.LogbookResponse{
+					ID:        1,
+					UpdatedAt: time.Date(2024, 3, 3, 0, 0, 0, 0, time.UTC),
+					Signature: &dto.SignatureResponse{ID: 5, UpdatedAt: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)},
+				}, nil)
+
+				// when
+				logbookController.GetLogbookEntry(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(200))
+				Expect(w.Header().Get("ETag")).To(Equal(`"1709510400000000"`))
+			})
+		})
+		Context("When the id is not a number", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/logbook/abc", nil)
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "abc"})
+
+				// when
+				logbookController.GetLogbookEntry(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(400))
+				Expect(w.Body).To(MatchJSON(`{"code": 400, "message":"strconv.ParseUint: parsing \"abc\": invalid syntax"}`))
+			})
+		})
+		Context("When the entry is not found", func() {
+			It("should return 404 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/logbook/1", nil)
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
+				logbookServiceMock.EXPECT().GetLogbookEntry("1", uint(1)).Return(dto.LogbookResponse{}, dto.ErrNotFound)
+
+				// when
+				logbookController.GetLogbookEntry(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(404))
+				Expect(w.Body).To(MatchJSON(`{"code": 404, "message":"not found"}`))
+			})
+		})
+		Context("When the entry belongs to another user", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/logbook/1", nil)
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
+				logbookServiceMock.EXPECT().GetLogbookEntry("1", uint(1)).Return(dto.LogbookResponse{}, dto.ErrBadRequest)
+
+				// when
+				logbookController.GetLogbookEntry(ctx)
 
+				// then
+				Expect(w.Code).To(Equal(400))
+				Expect(w.Body).To(MatchJSON(`{"code": 400, "message":"bad request"}`))
+			})
+		})
+		Context("When fetching the entry fails", func() {
+			It("should return 500 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/logbook/1", nil)
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
+				logbookServiceMock.EXPECT().GetLogbookEntry("1", uint(1)).Return(dto.LogbookResponse{}, dto.ErrInternalFailure)
+
+				// when
+				logbookController.GetLogbookEntry(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(500))
+				Expect(w.Body).To(MatchJSON(`{"code": 500, "message":"internal failure"}`))
+			})
+		})
 	})
 	Describe("InsertLogbookEntry", func() {
 		Context("When the user sends a request and no error occurs.", func() {
@@ -390,6 +520,55 @@ var _ = Describe("LogbookController", func() {
 				Expect(w.Body).To(MatchJSON(`{"code": 400, "message":"bad request"}`))
 			})
 		})
+		Context("When the user sends an entry with invalid fields", func() {
+			It("should return 400 and all invalid fields", func() {
+				// given
+				expectedLogbookInsertRequestJSON, err := json.Marshal(expectedLogbookRequest)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest("POST", "/logbook", bytes.NewBuffer(expectedLogbookInsertRequestJSON))
+				ctx.Set("userID", "1")
+				validationError := &dto.ValidationError{Errors: []dto.FieldError{
+					{Field: "landing_time", Code: dto.CodeBeforeTakeoffTime},
+					{Field: "night_time", Code: dto.CodeExceedsTotalBlockTime},
+				}}
+				logbookServiceMock.EXPECT().InsertLogbookEntry("1", expectedLogbookRequest).
+					Return(dto.LogbookResponse{}, fmt.Errorf("%w: %w", dto.ErrBadRequest, validationError))
+
+				// when
+				logbookController.InsertLogbookEntry(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(400))
+				Expect(w.Body).To(MatchJSON(`{"code": 400, "message":"bad request: invalid data in fields: landing_time, night_time",
+					"errors": [{"field": "landing_time", "code": "before_takeoff_time"}, {"field": "night_time", "code": "exceeds_total_block_time"}]}`))
+			})
+		})
+		Context("When the entry conflicts with existing entries", func() {
+			It("should return 409 and the conflicting entries", func() {
+				// given
+				expectedLogbookInsertRequestJSON, err := json.Marshal(expectedLogbookRequest)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest("POST", "/logbook", bytes.NewBuffer(expectedLogbookInsertRequestJSON))
+				ctx.Set("userID", "1")
+				takeoffTime := time.Date(2024, time.April, 12, 12, 0, 0, 0, time.UTC)
+				conflictError := &dto.ConflictError{Conflicts: []dto.FlightConflict{{Kind: dto.ConflictOverlap, Flight: dto.FlightSummary{
+					ID: 7, AircraftID: 2, TakeoffTime: takeoffTime, TakeoffAirportCode: "EPWA", LandingTime: takeoffTime.Add(time.Hour), LandingAirportCode: "EPKK",
+				}}}}
+				logbookServiceMock.EXPECT().InsertLogbookEntry("1", expectedLogbookRequest).
+					Return(dto.LogbookResponse{}, fmt.Errorf("%w: %w", dto.ErrConflict, conflictError))
+
+				// when
+				logbookController.InsertLogbookEntry(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(409))
+				Expect(w.Body).To(MatchJSON(`{"code": 409, "message": "conflict: entry conflicts with logbook entries: 7",
+					"conflicts": [{"kind": "overlap", "flight": {"id": 7, "aircraft_id": 2, "takeoff_time": "2024-04-12T12:00:00Z",
+					"takeoff_airport_code": "EPWA", "landing_time": "2024-04-12T13:00:00Z", "landing_airport_code": "EPKK"}}]}`))
+			})
+		})
 	})
 	Describe("UpdateLogbookEntry", func() {
 		Context("When the user sends a request and no error occurs.", func() {
@@ -403,7 +582,7 @@ var _ = Describe("LogbookController", func() {
 				ctx.Request = httptest.NewRequest("PUT", "/logbook", bytes.NewBuffer(expectedLogbookUpdateRequestJSON))
 				ctx.Set("userID", "1")
 				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
-				logbookServiceMock.EXPECT().UpdateLogbookEntry("1", uint(1), expectedLogbookRequest).Return(logbookEntriesMock[0], nil)
+				logbookServiceMock.EXPECT().UpdateLogbookEntry("1", uint(1), expectedLogbookRequest, nil).Return(logbookEntriesMock[0], nil)
 
 				// when
 				logbookController.UpdateLogbookEntry(ctx)
@@ -413,6 +592,28 @@ var _ = Describe("LogbookController", func() {
 				Expect(w.Body).To(MatchJSON(expectedLogbookResponseJSON))
 			})
 		})
+		Context("When the entry or its signature changed since the version of If-Match", func() {
+			It("should return 412 and error message", func() {
+				// given
+				expectedLogbookUpdateRequestJSON, err := json.Marshal(expectedLogbookRequest)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest("PUT", "/logbook", bytes.NewBuffer(expectedLogbookUpdateRequestJSON))
+				ctx.Request.Header.Set("If-Match", `"1713513600000000"`)
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
+				version := time.UnixMicro(1713513600000000)
+				logbookServiceMock.EXPECT().UpdateLogbookEntry("1", uint(1), expectedLogbookRequest, &version).
+					Return(dto.LogbookResponse{}, fmt.Errorf("%w: flight changed since the given version", dto.ErrPreconditionFailed))
+
+				// when
+				logbookController.UpdateLogbookEntry(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(412))
+				Expect(w.Body).To(MatchJSON(`{"code": 412, "message":"precondition failed: flight changed since the given version"}`))
+			})
+		})
 		Context("When the user sends a request and fails to bind", func() {
 			It("should return 400 and error message", func() {
 				// given
@@ -437,7 +638,7 @@ var _ = Describe("LogbookController", func() {
 				ctx.Request = httptest.NewRequest("PUT", "/logbook", bytes.NewBuffer(expectedLogbookUpdateRequestJSON))
 				ctx.Set("userID", "1")
 				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
-				logbookServiceMock.EXPECT().UpdateLogbookEntry("1", uint(1), expectedLogbookRequest).Return(dto.LogbookResponse{}, dto.ErrInternalFailure)
+				logbookServiceMock.EXPECT().UpdateLogbookEntry("1", uint(1), expectedLogbookRequest, nil).Return(dto.LogbookResponse{}, dto.ErrInternalFailure)
 
 				// when
 				logbookController.UpdateLogbookEntry(ctx)
@@ -474,7 +675,7 @@ var _ = Describe("LogbookController", func() {
 				ctx.Request = httptest.NewRequest("PUT", "/logbook", bytes.NewBuffer(expectedLogbookUpdateRequestJSON))
 				ctx.Set("userID", "1")
 				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
-				logbookServiceMock.EXPECT().UpdateLogbookEntry("1", uint(1), expectedLogbookRequest).Return(dto.LogbookResponse{}, dto.ErrBadRequest)
+				logbookServiceMock.EXPECT().UpdateLogbookEntry("1", uint(1), expectedLogbookRequest, nil).Return(dto.LogbookResponse{}, dto.ErrBadRequest)
 
 				// when
 				logbookController.UpdateLogbookEntry(ctx)
@@ -485,6 +686,167 @@ var _ = Describe("LogbookController", func() {
 			})
 		})
 	})
+	Describe("AmendLogbookEntry", func() {
+		Context("When the user sends a request and no error occurs.", func() {
+			It("should return 200 and the amended entry", func() {
+				// given
+				expectedLogbookResponseJSON, err := json.Marshal(logbookEntriesMock[0])
+				Expect(err).ToNot(HaveOccurred())
+				expectedLogbookAmendRequestJSON, err := json.Marshal(expectedLogbookRequest)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest("POST", "/logbook", bytes.NewBuffer(expectedLogbookAmendRequestJSON))
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
+				logbookServiceMock.EXPECT().AmendLogbookEntry("1", uint(1), expectedLogbookRequest, nil).Return(logbookEntriesMock[0], nil)
+
+				// when
+				logbookController.AmendLogbookEntry(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(200))
+				Expect(w.Body).To(MatchJSON(expectedLogbookResponseJSON))
+				Expect(w.Header().Get("ETag")).To(Equal(logbookEntryETag(logbookEntriesMock[0])))
+			})
+		})
+		Context("When the entry changed since the version of If-Match", func() {
+			It("should return 412 and error message", func() {
+				// given
+				expectedLogbookAmendRequestJSON, err := json.Marshal(expectedLogbookRequest)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest("POST", "/logbook", bytes.NewBuffer(expectedLogbookAmendRequestJSON))
+				ctx.Request.Header.Set("If-Match", `"1713513600000000"`)
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
+				version := time.UnixMicro(1713513600000000)
+				logbookServiceMock.EXPECT().AmendLogbookEntry("1", uint(1), expectedLogbookRequest, &version).
+					Return(dto.LogbookResponse{}, fmt.Errorf("%w: flight changed since the given version", dto.ErrPreconditionFailed))
+
+				// when
+				logbookController.AmendLogbookEntry(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(412))
+				Expect(w.Body).To(MatchJSON(`{"code": 412, "message":"precondition failed: flight changed since the given version"}`))
+			})
+		})
+		Context("When the user sends a request and fails to fetch id from params", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("POST", "/logbook", nil)
+				ctx.Set("userID", "1")
+
+				// when
+				logbookController.AmendLogbookEntry(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(400))
+				Expect(w.Body).To(MatchJSON(`{"code": 400, "message":"strconv.ParseUint: parsing \"\": invalid syntax"}`))
+			})
+		})
+		Context("When the user sends a request and fails (not found) to amend", func() {
+			It("should return 404 and error message", func() {
+				// given
+				expectedLogbookAmendRequestJSON, err := json.Marshal(expectedLogbookRequest)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest("POST", "/logbook", bytes.NewBuffer(expectedLogbookAmendRequestJSON))
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
+				logbookServiceMock.EXPECT().AmendLogbookEntry("1", uint(1), expectedLogbookRequest, nil).Return(dto.LogbookResponse{}, dto.ErrNotFound)
+
+				// when
+				logbookController.AmendLogbookEntry(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(404))
+				Expect(w.Body).To(MatchJSON(`{"code": 404, "message":"not found"}`))
+			})
+		})
+		Context("When the user sends a request and fails (internal failure) to amend", func() {
+			It("should return 500 and error message", func() {
+				// given
+				expectedLogbookAmendRequestJSON, err := json.Marshal(expectedLogbookRequest)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest("POST", "/logbook", bytes.NewBuffer(expectedLogbookAmendRequestJSON))
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
+				logbookServiceMock.EXPECT().AmendLogbookEntry("1", uint(1), expectedLogbookRequest, nil).Return(dto.LogbookResponse{}, dto.ErrInternalFailure)
+
+				// when
+				logbookController.AmendLogbookEntry(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(500))
+				Expect(w.Body).To(MatchJSON(`{"code": 500, "message":"internal failure"}`))
+			})
+		})
+	})
+
+	Describe("BatchLogbookEntries", func() {
+		Context("When the user sends a batch and no error occurs.", func() {
+			It("should return 200 and the results", func() {
+				// given
+				batchRequest := dto.LogbookBatchRequest{
+					Atomic:     true,
+					Operations: []dto.LogbookBatchOperation{{Action: dto.BatchActionDelete, ID: util.Uint(5)}},
+				}
+				batchRequestJSON, err := json.Marshal(batchRequest)
+				Expect(err).ToNot(HaveOccurred())
+				batchResponse := dto.LogbookBatchResponse{
+					Atomic:    true,
+					Succeeded: 1,
+					Results: []dto.LogbookBatchResult{
+						{Index: 0, Action: dto.BatchActionDelete, Status: dto.BatchStatusDeleted, FlightID: util.Uint(5)},
+					},
+				}
+
+				ctx.Request = httptest.NewRequest("POST", "/logbook/batch", bytes.NewBuffer(batchRequestJSON))
+				ctx.Set("userID", "1")
+				logbookServiceMock.EXPECT().BatchLogbookEntries("1", batchRequest).Return(batchResponse, nil)
+
+				// when
+				logbookController.BatchLogbookEntries(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(200))
+				Expect(w.Body).To(MatchJSON(`{"atomic":true,"succeeded":1,"failed":0,"results":[{"index":0,"action":"delete","status":"deleted","flight_id":5,"error":null}]}`))
+			})
+		})
+		Context("When the user sends a batch without operations", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("POST", "/logbook/batch", bytes.NewBuffer([]byte(`{"operations":[]}`)))
+				ctx.Set("userID", "1")
+				logbookServiceMock.EXPECT().BatchLogbookEntries("1", dto.LogbookBatchRequest{Operations: []dto.LogbookBatchOperation{}}).
+					Return(dto.LogbookBatchResponse{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "batch has no operations"))
+
+				// when
+				logbookController.BatchLogbookEntries(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(400))
+				Expect(w.Body).To(MatchJSON(`{"code": 400, "message":"bad request: batch has no operations"}`))
+			})
+		})
+		Context("When the user sends a request and fails to bind", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("POST", "/logbook/batch", bytes.NewBuffer([]byte("")))
+				ctx.Set("userID", "1")
+
+				// when
+				logbookController.BatchLogbookEntries(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(400))
+				Expect(w.Body).To(MatchJSON(`{"code": 400, "message":"EOF"}`))
+			})
+		})
+	})
+
 	Describe("DeleteLogbookEntry", func() {
 		Context("When the user sends a request and no error occurs.", func() {
 			It("should return 200 and message", func() {
@@ -493,7 +855,7 @@ var _ = Describe("LogbookController", func() {
 				ctx.Request = httptest.NewRequest("DELETE", "/logbook", nil)
 				ctx.Set("userID", "1")
 				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
-				logbookServiceMock.EXPECT().DeleteLogbookEntry("1", uint(1)).Return(nil)
+				logbookServiceMock.EXPECT().DeleteLogbookEntry("1", uint(1), nil).Return(nil)
 				// when
 				logbookController.DeleteLogbookEntry(ctx)
 
@@ -524,7 +886,7 @@ var _ = Describe("LogbookController", func() {
 				ctx.Request = httptest.NewRequest("DELETE", "/logbook", nil)
 				ctx.Set("userID", "1")
 				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
-				logbookServiceMock.EXPECT().DeleteLogbookEntry("1", uint(1)).Return(dto.ErrInternalFailure)
+				logbookServiceMock.EXPECT().DeleteLogbookEntry("1", uint(1), nil).Return(dto.ErrInternalFailure)
 
 				// when
 				logbookController.DeleteLogbookEntry(ctx)
@@ -541,7 +903,7 @@ var _ = Describe("LogbookController", func() {
 				ctx.Request = httptest.NewRequest("DELETE", "/logbook", nil)
 				ctx.Set("userID", "1")
 				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
-				logbookServiceMock.EXPECT().DeleteLogbookEntry("1", uint(1)).Return(dto.ErrNotFound)
+				logbookServiceMock.EXPECT().DeleteLogbookEntry("1", uint(1), nil).Return(dto.ErrNotFound)
 
 				// when
 				logbookController.DeleteLogbookEntry(ctx)
@@ -551,6 +913,23 @@ var _ = Describe("LogbookController", func() {
 				Expect(w.Body).To(MatchJSON(`{"code": 404, "message":"not found"}`))
 			})
 		})
+		Context("When the user sends a request and the entry is signed", func() {
+			It("should return 409 and error message", func() {
+				// given
+
+				ctx.Request = httptest.NewRequest("DELETE", "/logbook", nil)
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
+				logbookServiceMock.EXPECT().DeleteLogbookEntry("1", uint(1), nil).Return(fmt.Errorf("%w: %v", dto.ErrConflict, "flight is signed and cannot be deleted"))
+
+				// when
+				logbookController.DeleteLogbookEntry(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(409))
+				Expect(w.Body).To(MatchJSON(`{"code": 409, "message":"conflict: flight is signed and cannot be deleted"}`))
+			})
+		})
 		Context("When the user sends a request and fails (bad request) to delete", func() {
 			It("should return 400 and error message", func() {
 				// given
@@ -558,7 +937,7 @@ var _ = Describe("LogbookController", func() {
 				ctx.Request = httptest.NewRequest("DELETE", "/logbook", nil)
 				ctx.Set("userID", "1")
 				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
-				logbookServiceMock.EXPECT().DeleteLogbookEntry("1", uint(1)).Return(dto.ErrBadRequest)
+				logbookServiceMock.EXPECT().DeleteLogbookEntry("1", uint(1), nil).Return(dto.ErrBadRequest)
 
 				// when
 				logbookController.DeleteLogbookEntry(ctx)
@@ -575,7 +954,7 @@ var _ = Describe("LogbookController", func() {
 				ctx.Request = httptest.NewRequest("DELETE", "/logbook", nil)
 				ctx.Set("userID", "1")
 				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
-				logbookServiceMock.EXPECT().DeleteLogbookEntry("1", uint(1)).Return(dto.ErrInternalFailure)
+				logbookServiceMock.EXPECT().DeleteLogbookEntry("1", uint(1), nil).Return(dto.ErrInternalFailure)
 
 				// when
 				logbookController.DeleteLogbookEntry(ctx)
@@ -586,4 +965,288 @@ var _ = Describe("LogbookController", func() {
 			})
 		})
 	})
+	Describe("GetDuplicateEntries", func() {
+		Context("When no error occurs", func() {
+			It("should return 200 and groups of duplicates", func() {
+				// given
+				takeoffTime := time.Date(2024, time.April, 12, 12, 0, 0, 0, time.UTC)
+				duplicates := []dto.DuplicateGroupResponse{{Flights: []dto.FlightSummary{
+					{ID: 1, TakeoffTime: takeoffTime, TakeoffAirportCode: "EPWA", LandingTime: takeoffTime.Add(time.Hour), LandingAirportCode: "EPKK"},
+					{ID: 2, TakeoffTime: takeoffTime, TakeoffAirportCode: "EPWA", LandingTime: takeoffTime.Add(time.Hour), LandingAirportCode: "EPKK"},
+				}}}
+				expectedDuplicatesJSON, err := json.Marshal(duplicates)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest("GET", "/logbook/duplicates", nil)
+				ctx.Set("userID", "1")
+				logbookServiceMock.EXPECT().GetDuplicateEntries("1").Return(duplicates, nil)
+
+				// when
+				logbookController.GetDuplicateEntries(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(200))
+				Expect(w.Body).To(MatchJSON(expectedDuplicatesJSON))
+			})
+		})
+		Context("When the service fails", func() {
+			It("should return 500 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/logbook/duplicates", nil)
+				ctx.Set("userID", "1")
+				logbookServiceMock.EXPECT().GetDuplicateEntries("1").Return(nil, dto.ErrInternalFailure)
+
+				// when
+				logbookController.GetDuplicateEntries(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(500))
+				Expect(w.Body).To(MatchJSON(`{"code": 500, "message":"internal failure"}`))
+			})
+		})
+	})
+	Describe("GetLogbookTotals", func() {
+		Context("When the user sends filters and no error occurs", func() {
+			It("should return 200 and totals", func() {
+				// given
+				start := time.Date(2024, time.April, 12, 12, 0, 0, 0, time.Local)
+				end := time.Date(2024, time.April, 14, 12, 0, 0, 0, time.Local)
+				role := model.RolePilotInCommand
+				totals := dto.TotalsResponse{FlightCount: 3, TotalBlockTime: 4 * time.Hour, LandingCount: 5}
+				expectedTotalsJSON, err := json.Marshal(totals)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest("GET", fmt.Sprintf("/logbook/totals?start=%d&end=%d&aircraft_id=2&role=PIC", start.Unix(), end.Unix()), nil)
+				ctx.Set("userID", "1")
+				logbookServiceMock.EXPECT().GetLogbookTotals("1", dto.TotalsFilter{
+					Start:      &start,
+					End:        &end,
+					AircraftID: util.Uint(2),
+					Role:       &role,
+				}).Return(totals, nil)
+
+				// when
+				logbookController.GetLogbookTotals(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(200))
+				Expect(w.Body).To(MatchJSON(expectedTotalsJSON))
+			})
+		})
+		Context("When query parameters fail to bind", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/logbook/totals?aircraft_id=abc", nil)
+				ctx.Set("userID", "1")
+
+				// when
+				logbookController.GetLogbookTotals(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(400))
+			})
+		})
+		Context("When the service returns bad request", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/logbook/totals?role=XYZ", nil)
+				ctx.Set("userID", "1")
+				logbookServiceMock.EXPECT().GetLogbookTotals("1", gomock.Any()).Return(dto.TotalsResponse{}, dto.ErrBadRequest)
+
+				// when
+				logbookController.GetLogbookTotals(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(400))
+				Expect(w.Body).To(MatchJSON(`{"code": 400, "message":"bad request"}`))
+			})
+		})
+		Context("When getting totals fails", func() {
+			It("should return 500 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/logbook/totals", nil)
+				ctx.Set("userID", "1")
+				logbookServiceMock.EXPECT().GetLogbookTotals("1", dto.TotalsFilter{}).Return(dto.TotalsResponse{}, dto.ErrInternalFailure)
+
+				// when
+				logbookController.GetLogbookTotals(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(500))
+				Expect(w.Body).To(MatchJSON(`{"code": 500, "message":"internal failure"}`))
+			})
+		})
+	})
+
+	Describe("ExportLogbook", func() {
+		Context("When the user exports logbook and no error occurs", func() {
+			It("should return 200 and PDF document", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/logbook/export", nil)
+				ctx.Set("userID", "1")
+				logbookServiceMock.EXPECT().ExportLogbookPDF("1").Return([]byte("%PDF-1.3"), nil)
+
+				// when
+				logbookController.ExportLogbook(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(200))
+				Expect(w.Header().Get("Content-Type")).To(Equal("application/pdf"))
+				Expect(w.Header().Get("Content-Disposition")).To(Equal(`attachment; filename="logbook.pdf"`))
+				Expect(w.Body.String()).To(Equal("%PDF-1.3"))
+			})
+		})
+		Context("When the user requests unsupported format", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/logbook/export?format=docx", nil)
+				ctx.Set("userID", "1")
+
+				// when
+				logbookController.ExportLogbook(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(400))
+				Expect(w.Body).To(MatchJSON(`{"code": 400, "message":"bad request: unsupported export format: docx"}`))
+			})
+		})
+		Context("When exporting logbook fails", func() {
+			It("should return 500 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/logbook/export?format=pdf", nil)
+				ctx.Set("userID", "1")
+				logbookServiceMock.EXPECT().ExportLogbookPDF("1").Return(nil, dto.ErrInternalFailure)
+
+				// when
+				logbookController.ExportLogbook(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(500))
+				Expect(w.Body).To(MatchJSON(`{"code": 500, "message":"internal failure"}`))
+			})
+		})
+		Context("When the user exports logbook as CSV", func() {
+			It("should return 200 and CSV document", func() {
+				// given
+				start := time.Date(2024, time.April, 12, 12, 0, 0, 0, time.Local)
+				end := time.Date(2024, time.April, 14, 12, 0, 0, 0, time.Local)
+				ctx.Request = httptest.NewRequest("GET", fmt.Sprintf("/logbook/export?format=csv&start=%d&end=%d&columns=date,%%20total_block_time,,", start.Unix(), end.Unix()), nil)
+				ctx.Set("userID", "1")
+				logbookServiceMock.EXPECT().ExportLogbookCSV("1", dto.ExportFilter{
+					Start:   &start,
+					End:     &end,
+					Columns: []string{"date", "total_block_time"},
+				}, gomock.Any()).DoAndReturn(func(userID string, filter dto.ExportFilter, writer io.Writer) error {
+					_, err := writer.Write([]byte("date,total_block_time\n2024-04-12,1:15\n"))
+					return err
+				})
+
+				// when
+				logbookController.ExportLogbook(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(200))
+				Expect(w.Header().Get("Content-Type")).To(Equal("text/csv; charset=utf-8"))
+				Expect(w.Header().Get("Content-Disposition")).To(Equal(`attachment; filename="logbook.csv"`))
+				Expect(w.Body.String()).To(Equal("date,total_block_time\n2024-04-12,1:15\n"))
+			})
+		})
+		Context("When CSV export returns bad request", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/logbook/export?format=csv&columns=color", nil)
+				ctx.Set("userID", "1")
+				logbookServiceMock.EXPECT().ExportLogbookCSV("1", dto.ExportFilter{Columns: []string{"color"}}, gomock.Any()).
+					Return(fmt.Errorf("%w: unknown column: color", dto.ErrBadRequest))
+
+				// when
+				logbookController.ExportLogbook(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(400))
+				Expect(w.Header().Get("Content-Disposition")).To(BeEmpty())
+				Expect(w.Body).To(MatchJSON(`{"code": 400, "message":"bad request: unknown column: color"}`))
+			})
+		})
+		Context("When query parameters fail to bind", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/logbook/export?format=csv&start=abc", nil)
+				ctx.Set("userID", "1")
+
+				// when
+				logbookController.ExportLogbook(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(400))
+			})
+		})
+	})
+	Describe("PreviewNightTime", func() {
+		Context("When the route is valid", func() {
+			It("should return 200 and night time", func() {
+				// given
+				takeoffTime := time.Date(2024, time.June, 21, 20, 0, 0, 0, time.UTC)
+				landingTime := takeoffTime.Add(time.Hour)
+				nightTime := dto.NightTimeResponse{
+					TakeoffAirportCode: "EPWA",
+					LandingAirportCode: "EPKK",
+					NightTime:          time.Hour,
+					NightLanding:       true,
+					NightLandings:      1,
+				}
+				expectedNightTimeJSON, err := json.Marshal(nightTime)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest("GET", fmt.Sprintf("/logbook/night?takeoff_airport_code=WAW&takeoff_time=%d&landing_airport_code=EPKK&landing_time=%d",
+					takeoffTime.Unix(), landingTime.Unix()), nil)
+				logbookServiceMock.EXPECT().PreviewNightTime(dto.FlightRoute{
+					TakeoffAirportCode: "WAW",
+					TakeoffTime:        takeoffTime,
+					LandingAirportCode: "EPKK",
+					LandingTime:        landingTime,
+					Landings:           1,
+				}).Return(nightTime, nil)
+
+				// when
+				logbookController.PreviewNightTime(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(200))
+				Expect(w.Body).To(MatchJSON(expectedNightTimeJSON))
+			})
+		})
+		Context("When query parameters fail to bind", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/logbook/night?landings=-1", nil)
+
+				// when
+				logbookController.PreviewNightTime(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(400))
+			})
+		})
+		Context("When the airport is unknown", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/logbook/night?takeoff_airport_code=XXX&takeoff_time=0&landing_airport_code=EPKK&landing_time=3600&landings=2", nil)
+				logbookServiceMock.EXPECT().PreviewNightTime(dto.FlightRoute{
+					TakeoffAirportCode: "XXX",
+					TakeoffTime:        time.Unix(0, 0).UTC(),
+					LandingAirportCode: "EPKK",
+					LandingTime:        time.Unix(3600, 0).UTC(),
+					Landings:           2,
+				}).Return(dto.NightTimeResponse{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "unknown airport code: XXX"))
+
+				// when
+				logbookController.PreviewNightTime(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(400))
+				Expect(w.Body).To(MatchJSON(`{"code":400,"message":"bad request: unknown airport code: XXX"}`))
+			})
+		})
+	})
 })
@@ -0,0 +1,177 @@
+package controller
+
+import (
+	"errors"
+	"github.com/avialog/backend/internal/common"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/service"
+	"github.com/avialog/backend/internal/util"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type MedicalController interface {
+	GetMedicals(*gin.Context)
+	InsertMedical(*gin.Context)
+	UpdateMedical(*gin.Context)
+	DeleteMedical(*gin.Context)
+}
+
+type medicalController struct {
+	medicalService service.MedicalService
+}
+
+func newMedicalController(medicalService service.MedicalService) MedicalController {
+	return &medicalController{medicalService: medicalService}
+}
+
+// GetMedicals godoc
+//
+// @Summary Get user medical certificates
+// @Description Get all medical certificates of a user with the validity of their privileges, which depends on the
+// @Description rule set, the class and the age at the examination. Warnings list the privileges of the most recent
+// @Description certificate of each rule set that have lapsed or expire soon. Validity is only given if the profile
+// @Description has a date of birth.
+// @Tags medicals
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {object}      dto.MedicalsResponse
+// @Failure 404 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /medicals [get]
+func (c *medicalController) GetMedicals(ctx *gin.Context) {
+	userID := ctx.GetString(common.UserID)
+
+	medicals, err := c.medicalService.GetMedicals(userID)
+	if err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			util.NewError(ctx, http.StatusNotFound, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, medicals)
+}
+
+// InsertMedical godoc
+//
+// @Summary Insert a new medical certificate
+// @Description Insert a new medical certificate for a user
+// @Tags medicals
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   medicalRequest    body     dto.MedicalRequest true    "Medical certificate to insert"
+// @Success 201 {object}      dto.MedicalResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 404 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /medicals [post]
+func (c *medicalController) InsertMedical(ctx *gin.Context) {
+	userID := ctx.GetString(common.UserID)
+
+	var medicalRequest dto.MedicalRequest
+	if err := ctx.ShouldBindJSON(&medicalRequest); err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	medical, err := c.medicalService.InsertMedical(userID, medicalRequest)
+	if err != nil {
+		if errors.Is(err, dto.ErrBadRequest) {
+			util.NewError(ctx, http.StatusBadRequest, err)
+			return
+		} else if errors.Is(err, dto.ErrNotFound) {
+			util.NewError(ctx, http.StatusNotFound, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, medical)
+}
+
+// UpdateMedical godoc
+//
+// @Summary Update an existing medical certificate
+// @Description Update an existing medical certificate of a user
+// @Tags medicals
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   id                path     int        true        "Medical certificate ID to update"
+// @Param   medicalRequest    body     dto.MedicalRequest true    "Medical certificate information to update"
+// @Success 200 {object}      dto.MedicalResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 404 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /medicals/{id} [put]
+func (c *medicalController) UpdateMedical(ctx *gin.Context) {
+	medicalID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := ctx.GetString(common.UserID)
+
+	var medicalRequest dto.MedicalRequest
+	if err := ctx.ShouldBindJSON(&medicalRequest); err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	medical, err := c.medicalService.UpdateMedical(userID, uint(medicalID), medicalRequest)
+	if err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			util.NewError(ctx, http.StatusNotFound, err)
+			return
+		} else if errors.Is(err, dto.ErrBadRequest) {
+			util.NewError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, medical)
+}
+
+// DeleteMedical godoc
+//
+// @Summary Delete an existing medical certificate
+// @Description Delete a medical certificate of a user
+// @Tags medicals
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   id                path     int        true        "Medical certificate ID to delete"
+// @Success 200 {object}      object{message=string} "Medical certificate deleted successfully"
+// @Failure 400 {object}      util.HTTPError
+// @Failure 404 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /medicals/{id} [delete]
+func (c *medicalController) DeleteMedical(ctx *gin.Context) {
+	medicalID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := ctx.GetString(common.UserID)
+
+	err = c.medicalService.DeleteMedical(userID, uint(medicalID))
+	if err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			util.NewError(ctx, http.StatusNotFound, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Medical certificate deleted successfully"})
+}
@@ -0,0 +1,147 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/service"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("MedicalController", func() {
+	var (
+		medicalController  MedicalController
+		medicalServiceCtrl *gomock.Controller
+		medicalServiceMock *service.MockMedicalService
+		w                  *httptest.ResponseRecorder
+		ctx                *gin.Context
+		medicalRequest     dto.MedicalRequest
+		expectedMedical    dto.MedicalResponse
+	)
+
+	BeforeEach(func() {
+		medicalServiceCtrl = gomock.NewController(GinkgoT())
+		medicalServiceMock = service.NewMockMedicalService(medicalServiceCtrl)
+		w = httptest.NewRecorder()
+		ctx, _ = gin.CreateTestContext(w)
+		medicalController = newMedicalController(medicalServiceMock)
+
+		examinedAt := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+		medicalRequest = dto.MedicalRequest{
+			RuleSet:    model.RuleSetEASA,
+			Class:      model.MedicalClassLAPL,
+			ExaminedAt: examinedAt,
+		}
+		age := 37
+		expectedMedical = dto.MedicalResponse{
+			ID:               2,
+			RuleSet:          model.RuleSetEASA,
+			Class:            model.MedicalClassLAPL,
+			ExaminedAt:       examinedAt,
+			AgeAtExamination: &age,
+			Privileges: []dto.MedicalPrivilegeResponse{
+				{Privilege: model.MedicalPrivilegeLAPL, ValidUntil: time.Date(2028, 5, 10, 0, 0, 0, 0, time.UTC), DaysLeft: 1481},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		medicalServiceCtrl.Finish()
+	})
+
+	Describe("GetMedicals", func() {
+		Context("when the user has certificates", func() {
+			It("should return 200 with the certificates and warnings", func() {
+				// given
+				medicals := dto.MedicalsResponse{
+					Certificates: []dto.MedicalResponse{expectedMedical},
+					Warnings:     []dto.MedicalWarning{},
+				}
+				expectedJSON, err := json.Marshal(medicals)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/api/medicals", nil)
+				ctx.Set("userID", "1")
+				medicalServiceMock.EXPECT().GetMedicals("1").Return(medicals, nil)
+
+				// when
+				medicalController.GetMedicals(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body).To(MatchJSON(expectedJSON))
+			})
+		})
+	})
+
+	Describe("InsertMedical", func() {
+		Context("when the certificate is valid", func() {
+			It("should return 201 and the certificate", func() {
+				// given
+				requestJSON, err := json.Marshal(medicalRequest)
+				Expect(err).ToNot(HaveOccurred())
+				expectedJSON, err := json.Marshal(expectedMedical)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest(http.MethodPost, "/api/medicals", bytes.NewBuffer(requestJSON))
+				ctx.Set("userID", "1")
+				medicalServiceMock.EXPECT().InsertMedical("1", medicalRequest).Return(expectedMedical, nil)
+
+				// when
+				medicalController.InsertMedical(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusCreated))
+				Expect(w.Body).To(MatchJSON(expectedJSON))
+			})
+		})
+		Context("when the class is not in the rule set", func() {
+			It("should return 400 and error message", func() {
+				// given
+				requestJSON, err := json.Marshal(medicalRequest)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest(http.MethodPost, "/api/medicals", bytes.NewBuffer(requestJSON))
+				ctx.Set("userID", "1")
+				medicalServiceMock.EXPECT().InsertMedical("1", medicalRequest).
+					Return(dto.MedicalResponse{}, fmt.Errorf("%w: %w", dto.ErrBadRequest,
+						&dto.ValidationError{Errors: []dto.FieldError{{Field: "class", Code: dto.CodeNotInRuleSet}}}))
+
+				// when
+				medicalController.InsertMedical(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Describe("DeleteMedical", func() {
+		Context("when the certificate does not exist", func() {
+			It("should return 404 and error message", func() {
+				// given
+				ctx.Params = gin.Params{gin.Param{Key: "id", Value: "2"}}
+				ctx.Request = httptest.NewRequest(http.MethodDelete, "/api/medicals/2", nil)
+				ctx.Set("userID", "1")
+				medicalServiceMock.EXPECT().DeleteMedical("1", uint(2)).
+					Return(fmt.Errorf("%w: %v", dto.ErrNotFound, gorm.ErrRecordNotFound))
+
+				// when
+				medicalController.DeleteMedical(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body).To(MatchJSON(`{"code":404,"message":"not found: record not found"}`))
+			})
+		})
+	})
+})
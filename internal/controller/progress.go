@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"errors"
+	"github.com/avialog/backend/internal/common"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/service"
+	"github.com/avialog/backend/internal/util"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type ProgressController interface {
+	GetProgress(*gin.Context)
+}
+
+type progressController struct {
+	progressService service.ProgressService
+}
+
+func newProgressController(progressService service.ProgressService) ProgressController {
+	return &progressController{progressService: progressService}
+}
+
+// GetProgress godoc
+//
+// @Summary Get progress towards a licence
+// @Description Get each minimum experience requirement of an aeroplane licence or the instrument rating with the
+// @Description amount logged by the user and the remainder. Durations are in nanoseconds, like the logbook totals.
+// @Tags progress
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   licence           path     string     true        "Licence, one of PPL, CPL, IR and ATPL"
+// @Param   rule_set          query    string     false       "Rule set, EASA by default" Enums(EASA, FAA)
+// @Success 200 {object}      dto.ProgressResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 404 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /progress/{licence} [get]
+func (c *progressController) GetProgress(ctx *gin.Context) {
+	var progressRequest dto.ProgressRequest
+	if err := ctx.ShouldBindQuery(&progressRequest); err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	ruleSet := model.RuleSetEASA
+	if progressRequest.RuleSet != nil {
+		ruleSet = *progressRequest.RuleSet
+	}
+
+	userID := ctx.GetString(common.UserID)
+
+	progress, err := c.progressService.GetProgress(userID, ctx.Param("licence"), ruleSet)
+	if err != nil {
+		if errors.Is(err, dto.ErrBadRequest) {
+			util.NewError(ctx, http.StatusBadRequest, err)
+			return
+		} else if errors.Is(err, dto.ErrNotFound) {
+			util.NewError(ctx, http.StatusNotFound, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, progress)
+}
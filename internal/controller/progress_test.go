@@ -0,0 +1,122 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/service"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("ProgressController", func() {
+	var (
+		progressController  ProgressController
+		progressServiceCtrl *gomock.Controller
+		progressServiceMock *service.MockProgressService
+		w                   *httptest.ResponseRecorder
+		ctx                 *gin.Context
+		expectedProgress    dto.ProgressResponse
+	)
+
+	BeforeEach(func() {
+		progressServiceCtrl = gomock.NewController(GinkgoT())
+		progressServiceMock = service.NewMockProgressService(progressServiceCtrl)
+		w = httptest.NewRecorder()
+		ctx, _ = gin.CreateTestContext(w)
+		progressController = newProgressController(progressServiceMock)
+
+		expectedProgress = dto.ProgressResponse{
+			RuleSet: model.RuleSetFAA,
+			Licence: "PPL",
+			Met:     false,
+			Requirements: []dto.ProgressRequirement{
+				{Name: "total_time", Unit: dto.ProgressUnitDuration, Required: int64(40 * time.Hour),
+					Logged: int64(32 * time.Hour), Remaining: int64(8 * time.Hour), Met: false},
+				{Name: "dual_night_landings", Unit: dto.ProgressUnitCount, Required: 10, Logged: 12, Remaining: 0,
+					Met: true},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		progressServiceCtrl.Finish()
+	})
+
+	Describe("GetProgress", func() {
+		Context("when the rule set is given", func() {
+			It("should return 200 and the progress", func() {
+				// given
+				expectedJSON, err := json.Marshal(expectedProgress)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Params = gin.Params{gin.Param{Key: "licence", Value: "ppl"}}
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/api/progress/ppl?rule_set=FAA", nil)
+				ctx.Set("userID", "1")
+				progressServiceMock.EXPECT().GetProgress("1", "ppl", model.RuleSetFAA).Return(expectedProgress, nil)
+
+				// when
+				progressController.GetProgress(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body).To(MatchJSON(expectedJSON))
+			})
+		})
+		Context("when the rule set is not given", func() {
+			It("should use the EASA rule set", func() {
+				// given
+				ctx.Params = gin.Params{gin.Param{Key: "licence", Value: "CPL"}}
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/api/progress/CPL", nil)
+				ctx.Set("userID", "1")
+				progressServiceMock.EXPECT().GetProgress("1", "CPL", model.RuleSetEASA).Return(dto.ProgressResponse{}, nil)
+
+				// when
+				progressController.GetProgress(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusOK))
+			})
+		})
+		Context("when the licence is unknown", func() {
+			It("should return 404 and error message", func() {
+				// given
+				ctx.Params = gin.Params{gin.Param{Key: "licence", Value: "MPL"}}
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/api/progress/MPL", nil)
+				ctx.Set("userID", "1")
+				progressServiceMock.EXPECT().GetProgress("1", "MPL", model.RuleSetEASA).
+					Return(dto.ProgressResponse{}, fmt.Errorf("%w: no requirements for MPL under EASA", dto.ErrNotFound))
+
+				// when
+				progressController.GetProgress(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+				Expect(w.Body).To(MatchJSON(`{"code":404,"message":"not found: no requirements for MPL under EASA"}`))
+			})
+		})
+		Context("when the rule set is unknown", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Params = gin.Params{gin.Param{Key: "licence", Value: "PPL"}}
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/api/progress/PPL?rule_set=CAA", nil)
+				ctx.Set("userID", "1")
+				progressServiceMock.EXPECT().GetProgress("1", "PPL", model.RuleSet("CAA")).
+					Return(dto.ProgressResponse{}, fmt.Errorf("%w: unknown rule set CAA", dto.ErrBadRequest))
+
+				// when
+				progressController.GetProgress(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body).To(MatchJSON(`{"code":400,"message":"bad request: unknown rule set CAA"}`))
+			})
+		})
+	})
+})
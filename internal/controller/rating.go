@@ -0,0 +1,223 @@
+package controller
+
+import (
+	"errors"
+	"github.com/avialog/backend/internal/common"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/service"
+	"github.com/avialog/backend/internal/util"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type RatingController interface {
+	GetRatings(*gin.Context)
+	GetRating(*gin.Context)
+	InsertRating(*gin.Context)
+	UpdateRating(*gin.Context)
+	DeleteRating(*gin.Context)
+}
+
+type ratingController struct {
+	ratingService service.RatingService
+}
+
+func newRatingController(ratingService service.RatingService) RatingController {
+	return &ratingController{ratingService: ratingService}
+}
+
+// GetRatings godoc
+//
+// @Summary Get user ratings
+// @Description Get all class, type, instrument and instructor ratings of a user
+// @Tags ratings
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {array}       dto.RatingResponse
+// @Failure 500 {object}      util.HTTPError
+// @Router  /ratings [get]
+func (c *ratingController) GetRatings(ctx *gin.Context) {
+	userID := ctx.GetString(common.UserID)
+
+	ratings, err := c.ratingService.GetUserRatings(userID)
+	if err != nil {
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.adaptRatings(ratings))
+}
+
+// GetRating godoc
+//
+// @Summary Get a rating
+// @Description Get a single rating of a user
+// @Tags ratings
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   id                path     int        true        "Rating ID"
+// @Success 200 {object}      dto.RatingResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 404 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /ratings/{id} [get]
+func (c *ratingController) GetRating(ctx *gin.Context) {
+	ratingID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := ctx.GetString(common.UserID)
+
+	rating, err := c.ratingService.GetRating(userID, uint(ratingID))
+	if err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			util.NewError(ctx, http.StatusNotFound, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.adaptRating(rating))
+}
+
+// InsertRating godoc
+//
+// @Summary Insert a new rating
+// @Description Insert a new class, type, instrument or instructor rating, optionally endorsed on one of the licences, for a user
+// @Tags ratings
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   ratingRequest     body     dto.RatingRequest true    "Rating to insert"
+// @Success 201 {object}      dto.RatingResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /ratings [post]
+func (c *ratingController) InsertRating(ctx *gin.Context) {
+	userID := ctx.GetString(common.UserID)
+
+	var ratingRequest dto.RatingRequest
+	if err := ctx.ShouldBindJSON(&ratingRequest); err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	rating, err := c.ratingService.InsertRating(userID, ratingRequest)
+	if err != nil {
+		if errors.Is(err, dto.ErrBadRequest) {
+			util.NewError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, c.adaptRating(rating))
+}
+
+// UpdateRating godoc
+//
+// @Summary Update an existing rating
+// @Description Update an existing rating of a user
+// @Tags ratings
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   id                path     int        true        "Rating ID to update"
+// @Param   ratingRequest     body     dto.RatingRequest true    "Rating information to update"
+// @Success 200 {object}      dto.RatingResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 404 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /ratings/{id} [put]
+func (c *ratingController) UpdateRating(ctx *gin.Context) {
+	ratingID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := ctx.GetString(common.UserID)
+
+	var ratingRequest dto.RatingRequest
+	if err := ctx.ShouldBindJSON(&ratingRequest); err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	rating, err := c.ratingService.UpdateRating(userID, uint(ratingID), ratingRequest)
+	if err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			util.NewError(ctx, http.StatusNotFound, err)
+			return
+		} else if errors.Is(err, dto.ErrBadRequest) {
+			util.NewError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.adaptRating(rating))
+}
+
+// DeleteRating godoc
+//
+// @Summary Delete an existing rating
+// @Description Delete a rating of a user
+// @Tags ratings
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   id                path     int        true        "Rating ID to delete"
+// @Success 200 {object}      object{message=string} "Rating deleted successfully"
+// @Failure 400 {object}      util.HTTPError
+// @Failure 404 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /ratings/{id} [delete]
+func (c *ratingController) DeleteRating(ctx *gin.Context) {
+	ratingID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := ctx.GetString(common.UserID)
+
+	err = c.ratingService.DeleteRating(userID, uint(ratingID))
+	if err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			util.NewError(ctx, http.StatusNotFound, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Rating deleted successfully"})
+}
+
+func (c *ratingController) adaptRating(rating model.Rating) dto.RatingResponse {
+	return dto.RatingResponse{
+		ID:        rating.ID,
+		LicenceID: rating.LicenceID,
+		Kind:      rating.Kind,
+		Name:      rating.Name,
+		IssuedAt:  rating.IssuedAt,
+		ExpiresAt: rating.ExpiresAt,
+		Remarks:   rating.Remarks,
+		UpdatedAt: rating.UpdatedAt,
+	}
+}
+
+func (c *ratingController) adaptRatings(ratings []model.Rating) []dto.RatingResponse {
+	ratingResponses := make([]dto.RatingResponse, 0, len(ratings))
+	for _, rating := range ratings {
+		ratingResponses = append(ratingResponses, c.adaptRating(rating))
+	}
+	return ratingResponses
+}
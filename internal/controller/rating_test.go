@@ -0,0 +1,171 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/service"
+	"github.com/avialog/backend/internal/util"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"net/http"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("RatingController", func() {
+	var (
+		ratingController  RatingController
+		ratingServiceCtrl *gomock.Controller
+		ratingServiceMock *service.MockRatingService
+		w                 *httptest.ResponseRecorder
+		ctx               *gin.Context
+		ratingRequest     dto.RatingRequest
+		mockRating        model.Rating
+	)
+
+	BeforeEach(func() {
+		ratingServiceCtrl = gomock.NewController(GinkgoT())
+		ratingServiceMock = service.NewMockRatingService(ratingServiceCtrl)
+		w = httptest.NewRecorder()
+		ctx, _ = gin.CreateTestContext(w)
+		ratingController = newRatingController(ratingServiceMock)
+
+		expiresAt := time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+		ratingRequest = dto.RatingRequest{
+			LicenceID: util.Uint(2),
+			Kind:      model.RatingKindClass,
+			Name:      "SEP",
+			ExpiresAt: &expiresAt,
+		}
+		mockRating = model.Rating{
+			Model:     gorm.Model{ID: 3},
+			UserID:    "1",
+			LicenceID: util.Uint(2),
+			Kind:      model.RatingKindClass,
+			Name:      "SEP",
+			ExpiresAt: &expiresAt,
+		}
+	})
+
+	AfterEach(func() {
+		ratingServiceCtrl.Finish()
+	})
+
+	Describe("GetRatings", func() {
+		Context("when the user has ratings", func() {
+			It("should return 200 and the ratings", func() {
+				// given
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/api/ratings", nil)
+				ctx.Set("userID", "1")
+				ratingServiceMock.EXPECT().GetUserRatings("1").Return([]model.Rating{mockRating}, nil)
+
+				// when
+				ratingController.GetRatings(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body).To(MatchJSON(`[{"id":3,"licence_id":2,"kind":"CLASS","name":"SEP","issued_at":null,
+					"expires_at":"2024-06-30T00:00:00Z","remarks":null,"updated_at":"0001-01-01T00:00:00Z"}]`))
+			})
+		})
+	})
+
+	Describe("InsertRating", func() {
+		Context("when the rating is valid", func() {
+			It("should return 201 and the rating", func() {
+				// given
+				requestJSON, err := json.Marshal(ratingRequest)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest(http.MethodPost, "/api/ratings", bytes.NewBuffer(requestJSON))
+				ctx.Set("userID", "1")
+				ratingServiceMock.EXPECT().InsertRating("1", ratingRequest).Return(mockRating, nil)
+
+				// when
+				ratingController.InsertRating(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusCreated))
+			})
+		})
+		Context("when the licence does not belong to the user", func() {
+			It("should return 400 and error message", func() {
+				// given
+				requestJSON, err := json.Marshal(ratingRequest)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest(http.MethodPost, "/api/ratings", bytes.NewBuffer(requestJSON))
+				ctx.Set("userID", "1")
+				ratingServiceMock.EXPECT().InsertRating("1", ratingRequest).
+					Return(model.Rating{}, fmt.Errorf("%w: licence 2 not found", dto.ErrBadRequest))
+
+				// when
+				ratingController.InsertRating(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+				Expect(w.Body).To(MatchJSON(`{"code":400,"message":"bad request: licence 2 not found"}`))
+			})
+		})
+		Context("when the name is missing", func() {
+			It("should return 400 without calling the service", func() {
+				// given
+				ctx.Request = httptest.NewRequest(http.MethodPost, "/api/ratings", bytes.NewBufferString(`{"kind":"CLASS"}`))
+				ctx.Set("userID", "1")
+
+				// when
+				ratingController.InsertRating(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Describe("UpdateRating", func() {
+		Context("when the rating does not exist", func() {
+			It("should return 404 and error message", func() {
+				// given
+				requestJSON, err := json.Marshal(ratingRequest)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Params = gin.Params{gin.Param{Key: "id", Value: "3"}}
+				ctx.Request = httptest.NewRequest(http.MethodPut, "/api/ratings/3", bytes.NewBuffer(requestJSON))
+				ctx.Set("userID", "1")
+				ratingServiceMock.EXPECT().UpdateRating("1", uint(3), ratingRequest).
+					Return(model.Rating{}, fmt.Errorf("%w: %v", dto.ErrNotFound, gorm.ErrRecordNotFound))
+
+				// when
+				ratingController.UpdateRating(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("DeleteRating", func() {
+		Context("when the rating is deleted", func() {
+			It("should return 200 and message", func() {
+				// given
+				ctx.Params = gin.Params{gin.Param{Key: "id", Value: "3"}}
+				ctx.Request = httptest.NewRequest(http.MethodDelete, "/api/ratings/3", nil)
+				ctx.Set("userID", "1")
+				ratingServiceMock.EXPECT().DeleteRating("1", uint(3)).Return(nil)
+
+				// when
+				ratingController.DeleteRating(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusOK))
+				Expect(w.Body).To(MatchJSON(`{"message":"Rating deleted successfully"}`))
+			})
+		})
+	})
+})
@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"errors"
+	"github.com/avialog/backend/internal/common"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/service"
+	"github.com/avialog/backend/internal/util"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type ReminderController interface {
+	GetPreference(*gin.Context)
+	UpdatePreference(*gin.Context)
+}
+
+type reminderController struct {
+	reminderService service.ReminderService
+}
+
+func newReminderController(reminderService service.ReminderService) ReminderController {
+	return &reminderController{reminderService: reminderService}
+}
+
+// GetPreference godoc
+//
+// @Summary Get reminder preferences
+// @Description Get what the user is reminded of before it lapses, how many days before and over which channels. Users
+// @Description who have not chosen yet get the defaults with all channels disabled.
+// @Tags reminders
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {object}      dto.ReminderPreferenceResponse
+// @Failure 500 {object}      util.HTTPError
+// @Router  /reminders/preferences [get]
+func (c *reminderController) GetPreference(ctx *gin.Context) {
+	userID := ctx.GetString(common.UserID)
+
+	preference, err := c.reminderService.GetPreference(userID)
+	if err != nil {
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.adaptPreference(preference))
+}
+
+// UpdatePreference godoc
+//
+// @Summary Update reminder preferences
+// @Description Choose what the user is reminded of before it lapses, how many days before and over which channels.
+// @Description Push notifications need the device token and webhooks an HTTPS URL.
+// @Tags reminders
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   preferenceRequest body     dto.ReminderPreferenceRequest true "Reminder preferences"
+// @Success 200 {object}      dto.ReminderPreferenceResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /reminders/preferences [put]
+func (c *reminderController) UpdatePreference(ctx *gin.Context) {
+	userID := ctx.GetString(common.UserID)
+
+	var preferenceRequest dto.ReminderPreferenceRequest
+	if err := ctx.ShouldBindJSON(&preferenceRequest); err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	preference, err := c.reminderService.UpdatePreference(userID, preferenceRequest)
+	if err != nil {
+		if errors.Is(err, dto.ErrBadRequest) {
+			util.NewError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, c.adaptPreference(preference))
+}
+
+func (c *reminderController) adaptPreference(preference model.NotificationPreference) dto.ReminderPreferenceResponse {
+	return dto.ReminderPreferenceResponse{
+		DaysBefore:     preference.DaysBefore,
+		Licences:       preference.Licences,
+		Medicals:       preference.Medicals,
+		Currency:       preference.Currency,
+		EmailEnabled:   preference.EmailEnabled,
+		PushEnabled:    preference.PushEnabled,
+		WebhookEnabled: preference.WebhookEnabled,
+		DeviceToken:    preference.DeviceToken,
+		WebhookURL:     preference.WebhookURL,
+		UpdatedAt:      preference.UpdatedAt,
+	}
+}
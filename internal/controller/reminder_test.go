@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/service"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("ReminderController", func() {
+	var (
+		reminderController  ReminderController
+		reminderServiceCtrl *gomock.Controller
+		reminderServiceMock *service.MockReminderService
+		w                   *httptest.ResponseRecorder
+		ctx                 *gin.Context
+		mockPreference      model.NotificationPreference
+	)
+
+	BeforeEach(func() {
+		reminderServiceCtrl = gomock.NewController(GinkgoT())
+		reminderServiceMock = service.NewMockReminderService(reminderServiceCtrl)
+		w = httptest.NewRecorder()
+		ctx, _ = gin.CreateTestContext(w)
+		reminderController = newReminderController(reminderServiceMock)
+
+		mockPreference = model.NotificationPreference{UserID: "1", DaysBefore: 30, Licences: true, Medicals: true,
+			Currency: true}
+	})
+
+	AfterEach(func() {
+		reminderServiceCtrl.Finish()
+	})
+
+	Describe("GetPreference", func() {
+		It("should return 200 and the preference", func() {
+			// given
+			expectedJSON, err := json.Marshal(dto.ReminderPreferenceResponse{DaysBefore: 30, Licences: true,
+				Medicals: true, Currency: true})
+			Expect(err).ToNot(HaveOccurred())
+
+			ctx.Request = httptest.NewRequest(http.MethodGet, "/api/reminders/preferences", nil)
+			ctx.Set("userID", "1")
+			reminderServiceMock.EXPECT().GetPreference("1").Return(mockPreference, nil)
+
+			// when
+			reminderController.GetPreference(ctx)
+
+			// then
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body).To(MatchJSON(expectedJSON))
+		})
+	})
+
+	Describe("UpdatePreference", func() {
+		var preferenceRequest dto.ReminderPreferenceRequest
+
+		BeforeEach(func() {
+			preferenceRequest = dto.ReminderPreferenceRequest{DaysBefore: 30, Licences: true, PushEnabled: true}
+		})
+
+		Context("when the device token is missing", func() {
+			It("should return 400 and error message", func() {
+				// given
+				requestJSON, err := json.Marshal(preferenceRequest)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest(http.MethodPut, "/api/reminders/preferences", bytes.NewBuffer(requestJSON))
+				ctx.Set("userID", "1")
+				reminderServiceMock.EXPECT().UpdatePreference("1", preferenceRequest).
+					Return(model.NotificationPreference{}, fmt.Errorf("%w: %w", dto.ErrBadRequest,
+						&dto.ValidationError{Errors: []dto.FieldError{{Field: "device_token", Code: dto.CodeRequired}}}))
+
+				// when
+				reminderController.UpdatePreference(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+		Context("when days before are missing", func() {
+			It("should return 400 without calling the service", func() {
+				// given
+				ctx.Request = httptest.NewRequest(http.MethodPut, "/api/reminders/preferences",
+					bytes.NewBufferString(`{"licences":true}`))
+				ctx.Set("userID", "1")
+
+				// when
+				reminderController.UpdatePreference(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+})
@@ -0,0 +1,291 @@
+package controller
+
+import (
+	"errors"
+	"github.com/avialog/backend/internal/common"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/service"
+	"github.com/avialog/backend/internal/util"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type SignatureController interface {
+	RequestSignature(*gin.Context)
+	GetSignatureRequests(*gin.Context)
+	GetSignatureRequest(*gin.Context)
+	SignEntry(*gin.Context)
+	RejectEntry(*gin.Context)
+	GetSignatureRequestByToken(*gin.Context)
+	SignEntryByToken(*gin.Context)
+	RejectEntryByToken(*gin.Context)
+}
+
+type signatureController struct {
+	signatureService service.SignatureService
+}
+
+func newSignatureController(signatureService service.SignatureService) SignatureController {
+	return &signatureController{signatureService: signatureService}
+}
+
+// RequestSignature godoc
+//
+// @Summary Request a signature of a logbook entry
+// @Description Ask an instructor, a contact or another user identified by email, to countersign a logbook entry. The token of a request to a contact is emailed to the contact, who signs without an account.
+// @Tags signatures
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   id                path     int        true        "Flight ID to sign"
+// @Param   signatureRequest  body     dto.SignatureRequest true "Instructor to ask for the signature"
+// @Success 201 {object}      dto.SignatureResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 404 {object}      util.HTTPError
+// @Failure 409 {object}      util.HTTPError
+// @Failure 422 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /logbook/{id}/signature [post]
+func (c *signatureController) RequestSignature(ctx *gin.Context) {
+	flightID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := ctx.GetString(common.UserID)
+
+	var signatureRequest dto.SignatureRequest
+	if err := ctx.ShouldBindJSON(&signatureRequest); err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	signatureResponse, err := c.signatureService.RequestSignature(userID, uint(flightID), signatureRequest)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, signatureResponse)
+}
+
+// GetSignatureRequests godoc
+//
+// @Summary Get signature requests
+// @Description Get the requests waiting for the signature of the user
+// @Tags signatures
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {array}       dto.SignatureResponse
+// @Failure 500 {object}      util.HTTPError
+// @Router  /signatures [get]
+func (c *signatureController) GetSignatureRequests(ctx *gin.Context) {
+	userID := ctx.GetString(common.UserID)
+
+	signatureResponses, err := c.signatureService.GetSignatureRequests(userID)
+	if err != nil {
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, signatureResponses)
+}
+
+// GetSignatureRequest godoc
+//
+// @Summary Review a signature request
+// @Description Get a signature request addressed to the user together with the logbook entry to sign
+// @Tags signatures
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   id                path     int        true        "Signature request ID"
+// @Success 200 {object}      dto.SignatureReviewResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 404 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /signatures/{id} [get]
+func (c *signatureController) GetSignatureRequest(ctx *gin.Context) {
+	signatureID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := ctx.GetString(common.UserID)
+
+	reviewResponse, err := c.signatureService.GetSignatureRequest(userID, uint(signatureID))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reviewResponse)
+}
+
+// SignEntry godoc
+//
+// @Summary Sign a logbook entry
+// @Description Sign the logbook entry of a signature request addressed to the user
+// @Tags signatures
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   id                path     int        true        "Signature request ID"
+// @Param   decisionRequest   body     dto.SignatureDecisionRequest false "Remarks of the instructor"
+// @Success 200 {object}      dto.SignatureResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 404 {object}      util.HTTPError
+// @Failure 409 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /signatures/{id}/sign [post]
+func (c *signatureController) SignEntry(ctx *gin.Context) {
+	c.decide(ctx, c.signatureService.SignEntry)
+}
+
+// RejectEntry godoc
+//
+// @Summary Reject a signature request
+// @Description Reject the signature request addressed to the user, the remarks tell the pilot what to correct
+// @Tags signatures
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   id                path     int        true        "Signature request ID"
+// @Param   decisionRequest   body     dto.SignatureDecisionRequest false "Remarks of the instructor"
+// @Success 200 {object}      dto.SignatureResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 404 {object}      util.HTTPError
+// @Failure 409 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /signatures/{id}/reject [post]
+func (c *signatureController) RejectEntry(ctx *gin.Context) {
+	c.decide(ctx, c.signatureService.RejectEntry)
+}
+
+// GetSignatureRequestByToken godoc
+//
+// @Summary Review a signature request of a contact
+// @Description Get the signature request with the token together with the logbook entry to sign, no account is needed
+// @Tags signatures
+// @Produce  json
+// @Param   token             path     string     true        "Signature request token"
+// @Success 200 {object}      dto.SignatureReviewResponse
+// @Failure 404 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /signatures/token/{token} [get]
+func (c *signatureController) GetSignatureRequestByToken(ctx *gin.Context) {
+	reviewResponse, err := c.signatureService.GetSignatureRequestByToken(ctx.Param("token"))
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reviewResponse)
+}
+
+// SignEntryByToken godoc
+//
+// @Summary Sign a logbook entry as a contact
+// @Description Sign the logbook entry of the signature request with the token, no account is needed
+// @Tags signatures
+// @Accept  json
+// @Produce  json
+// @Param   token             path     string     true        "Signature request token"
+// @Param   decisionRequest   body     dto.SignatureDecisionRequest false "Remarks of the instructor"
+// @Success 200 {object}      dto.SignatureResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 404 {object}      util.HTTPError
+// @Failure 409 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /signatures/token/{token}/sign [post]
+func (c *signatureController) SignEntryByToken(ctx *gin.Context) {
+	c.decideByToken(ctx, c.signatureService.SignEntryByToken)
+}
+
+// RejectEntryByToken godoc
+//
+// @Summary Reject a signature request as a contact
+// @Description Reject the signature request with the token, no account is needed
+// @Tags signatures
+// @Accept  json
+// @Produce  json
+// @Param   token             path     string     true        "Signature request token"
+// @Param   decisionRequest   body     dto.SignatureDecisionRequest false "Remarks of the instructor"
+// @Success 200 {object}      dto.SignatureResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 404 {object}      util.HTTPError
+// @Failure 409 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /signatures/token/{token}/reject [post]
+func (c *signatureController) RejectEntryByToken(ctx *gin.Context) {
+	c.decideByToken(ctx, c.signatureService.RejectEntryByToken)
+}
+
+func (c *signatureController) decide(ctx *gin.Context,
+	decide func(string, uint, dto.SignatureDecisionRequest) (dto.SignatureResponse, error)) {
+	signatureID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := ctx.GetString(common.UserID)
+
+	decisionRequest, ok := bindDecisionRequest(ctx)
+	if !ok {
+		return
+	}
+
+	signatureResponse, err := decide(userID, uint(signatureID), decisionRequest)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, signatureResponse)
+}
+
+func (c *signatureController) decideByToken(ctx *gin.Context,
+	decide func(string, dto.SignatureDecisionRequest) (dto.SignatureResponse, error)) {
+	decisionRequest, ok := bindDecisionRequest(ctx)
+	if !ok {
+		return
+	}
+
+	signatureResponse, err := decide(ctx.Param("token"), decisionRequest)
+	if err != nil {
+		c.handleError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, signatureResponse)
+}
+
+// bindDecisionRequest binds the optional body of a decision, an empty body means no remarks.
+func bindDecisionRequest(ctx *gin.Context) (dto.SignatureDecisionRequest, bool) {
+	var decisionRequest dto.SignatureDecisionRequest
+	if ctx.Request.ContentLength == 0 {
+		return decisionRequest, true
+	}
+	if err := ctx.ShouldBindJSON(&decisionRequest); err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return dto.SignatureDecisionRequest{}, false
+	}
+	return decisionRequest, true
+}
+
+func (c *signatureController) handleError(ctx *gin.Context, err error) {
+	if errors.Is(err, dto.ErrBadRequest) {
+		util.NewError(ctx, http.StatusBadRequest, err)
+	} else if errors.Is(err, dto.ErrNotFound) {
+		util.NewError(ctx, http.StatusNotFound, err)
+	} else if errors.Is(err, dto.ErrConflict) {
+		util.NewError(ctx, http.StatusConflict, err)
+	} else if errors.Is(err, dto.ErrUnprocessable) {
+		util.NewError(ctx, http.StatusUnprocessableEntity, err)
+	} else {
+		util.NewError(ctx, http.StatusInternalServerError, err)
+	}
+}
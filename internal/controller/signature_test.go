@@ -0,0 +1,284 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/service"
+	"github.com/avialog/backend/internal/util"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http/httptest"
+)
+
+var _ = Describe("SignatureController", func() {
+	var (
+		signatureController  SignatureController
+		signatureServiceCtrl *gomock.Controller
+		signatureServiceMock *service.MockSignatureService
+		w                    *httptest.ResponseRecorder
+		ctx                  *gin.Context
+		signatureResponse    dto.SignatureResponse
+		decisionRequest      dto.SignatureDecisionRequest
+	)
+
+	BeforeEach(func() {
+		signatureServiceCtrl = gomock.NewController(GinkgoT())
+		signatureServiceMock = service.NewMockSignatureService(signatureServiceCtrl)
+		w = httptest.NewRecorder()
+		ctx, _ = gin.CreateTestContext(w)
+		signatureController = newSignatureController(signatureServiceMock)
+		signatureResponse = dto.SignatureResponse{
+			ID:            5,
+			FlightID:      3,
+			Status:        model.SignatureStatusPending,
+			RequesterName: "Anna Nowak",
+			SignerName:    "Jan Kowalski",
+			SignerEmail:   util.String("jan@example.com"),
+			SignerUserID:  util.String("2"),
+		}
+		decisionRequest = dto.SignatureDecisionRequest{Remarks: util.String("Good landings")}
+	})
+
+	AfterEach(func() {
+		signatureServiceCtrl.Finish()
+	})
+
+	Describe("RequestSignature", func() {
+		Context("When the user sends a request and no error occurs.", func() {
+			It("should return 201 and the signature request", func() {
+				// given
+				signatureRequest := dto.SignatureRequest{InstructorEmail: util.String("jan@example.com")}
+				signatureRequestJSON, err := json.Marshal(signatureRequest)
+				Expect(err).ToNot(HaveOccurred())
+				expectedResponseJSON, err := json.Marshal(signatureResponse)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest("POST", "/logbook/3/signature", bytes.NewBuffer(signatureRequestJSON))
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "3"})
+				signatureServiceMock.EXPECT().RequestSignature("1", uint(3), signatureRequest).Return(signatureResponse, nil)
+
+				// when
+				signatureController.RequestSignature(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(201))
+				Expect(w.Body).To(MatchJSON(expectedResponseJSON))
+			})
+		})
+		Context("When the user sends a request for a flight that is already signed", func() {
+			It("should return 409 and error message", func() {
+				// given
+				signatureRequest := dto.SignatureRequest{InstructorEmail: util.String("jan@example.com")}
+				signatureRequestJSON, err := json.Marshal(signatureRequest)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest("POST", "/logbook/3/signature", bytes.NewBuffer(signatureRequestJSON))
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "3"})
+				signatureServiceMock.EXPECT().RequestSignature("1", uint(3), signatureRequest).
+					Return(dto.SignatureResponse{}, fmt.Errorf("%w: %v", dto.ErrConflict, "flight is already signed"))
+
+				// when
+				signatureController.RequestSignature(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(409))
+				Expect(w.Body).To(MatchJSON(`{"code": 409, "message":"conflict: flight is already signed"}`))
+			})
+		})
+		Context("When the user asks a contact while emails are disabled", func() {
+			It("should return 422 and error message", func() {
+				// given
+				signatureRequest := dto.SignatureRequest{ContactID: util.Uint(7)}
+				signatureRequestJSON, err := json.Marshal(signatureRequest)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest("POST", "/logbook/3/signature", bytes.NewBuffer(signatureRequestJSON))
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "3"})
+				signatureServiceMock.EXPECT().RequestSignature("1", uint(3), signatureRequest).
+					Return(dto.SignatureResponse{}, fmt.Errorf("%w: %v", dto.ErrUnprocessable, "emails are disabled, contacts cannot be asked to sign"))
+
+				// when
+				signatureController.RequestSignature(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(422))
+				Expect(w.Body).To(MatchJSON(`{"code": 422, "message":"unprocessable: emails are disabled, contacts cannot be asked to sign"}`))
+			})
+		})
+		Context("When the user sends a request and fails to bind", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("POST", "/logbook/3/signature", bytes.NewBuffer([]byte("")))
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "3"})
+
+				// when
+				signatureController.RequestSignature(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(400))
+				Expect(w.Body).To(MatchJSON(`{"code": 400, "message":"EOF"}`))
+			})
+		})
+	})
+
+	Describe("GetSignatureRequests", func() {
+		Context("When the user sends a request and no error occurs.", func() {
+			It("should return 200 and the pending requests", func() {
+				// given
+				expectedResponseJSON, err := json.Marshal([]dto.SignatureResponse{signatureResponse})
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest("GET", "/signatures", nil)
+				ctx.Set("userID", "2")
+				signatureServiceMock.EXPECT().GetSignatureRequests("2").Return([]dto.SignatureResponse{signatureResponse}, nil)
+
+				// when
+				signatureController.GetSignatureRequests(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(200))
+				Expect(w.Body).To(MatchJSON(expectedResponseJSON))
+			})
+		})
+	})
+
+	Describe("GetSignatureRequest", func() {
+		Context("When the request is addressed to another user", func() {
+			It("should return 404 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/signatures/5", nil)
+				ctx.Set("userID", "3")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "5"})
+				signatureServiceMock.EXPECT().GetSignatureRequest("3", uint(5)).
+					Return(dto.SignatureReviewResponse{}, fmt.Errorf("%w: %v", dto.ErrNotFound, "signature request not found"))
+
+				// when
+				signatureController.GetSignatureRequest(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(404))
+				Expect(w.Body).To(MatchJSON(`{"code": 404, "message":"not found: signature request not found"}`))
+			})
+		})
+	})
+
+	Describe("SignEntry", func() {
+		Context("When the user sends a request with remarks and no error occurs.", func() {
+			It("should return 200 and the signed request", func() {
+				// given
+				decisionRequestJSON, err := json.Marshal(decisionRequest)
+				Expect(err).ToNot(HaveOccurred())
+				signatureResponse.Status = model.SignatureStatusSigned
+				expectedResponseJSON, err := json.Marshal(signatureResponse)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest("POST", "/signatures/5/sign", bytes.NewBuffer(decisionRequestJSON))
+				ctx.Set("userID", "2")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "5"})
+				signatureServiceMock.EXPECT().SignEntry("2", uint(5), decisionRequest).Return(signatureResponse, nil)
+
+				// when
+				signatureController.SignEntry(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(200))
+				Expect(w.Body).To(MatchJSON(expectedResponseJSON))
+			})
+		})
+		Context("When the user sends a request without a body", func() {
+			It("should sign without remarks", func() {
+				// given
+				ctx.Request = httptest.NewRequest("POST", "/signatures/5/sign", nil)
+				ctx.Set("userID", "2")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "5"})
+				signatureServiceMock.EXPECT().SignEntry("2", uint(5), dto.SignatureDecisionRequest{}).Return(signatureResponse, nil)
+
+				// when
+				signatureController.SignEntry(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(200))
+			})
+		})
+		Context("When the request is no longer pending", func() {
+			It("should return 409 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("POST", "/signatures/5/sign", nil)
+				ctx.Set("userID", "2")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "5"})
+				signatureServiceMock.EXPECT().SignEntry("2", uint(5), dto.SignatureDecisionRequest{}).
+					Return(dto.SignatureResponse{}, fmt.Errorf("%w: signature request is invalidated", dto.ErrConflict))
+
+				// when
+				signatureController.SignEntry(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(409))
+				Expect(w.Body).To(MatchJSON(`{"code": 409, "message":"conflict: signature request is invalidated"}`))
+			})
+		})
+		Context("When the user sends a request and fails to fetch id from params", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("POST", "/signatures/sign", nil)
+				ctx.Set("userID", "2")
+
+				// when
+				signatureController.SignEntry(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(400))
+				Expect(w.Body).To(MatchJSON(`{"code": 400, "message":"strconv.ParseUint: parsing \"\": invalid syntax"}`))
+			})
+		})
+	})
+
+	Describe("RejectEntryByToken", func() {
+		Context("When the contact sends a request and no error occurs.", func() {
+			It("should return 200 and the rejected request", func() {
+				// given
+				decisionRequestJSON, err := json.Marshal(decisionRequest)
+				Expect(err).ToNot(HaveOccurred())
+				signatureResponse.Status = model.SignatureStatusRejected
+				expectedResponseJSON, err := json.Marshal(signatureResponse)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest("POST", "/signatures/token/abc/reject", bytes.NewBuffer(decisionRequestJSON))
+				ctx.Params = append(ctx.Params, gin.Param{Key: "token", Value: "abc"})
+				signatureServiceMock.EXPECT().RejectEntryByToken("abc", decisionRequest).Return(signatureResponse, nil)
+
+				// when
+				signatureController.RejectEntryByToken(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(200))
+				Expect(w.Body).To(MatchJSON(expectedResponseJSON))
+			})
+		})
+		Context("When the token is unknown", func() {
+			It("should return 404 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("POST", "/signatures/token/abc/reject", nil)
+				ctx.Params = append(ctx.Params, gin.Param{Key: "token", Value: "abc"})
+				signatureServiceMock.EXPECT().RejectEntryByToken("abc", dto.SignatureDecisionRequest{}).
+					Return(dto.SignatureResponse{}, fmt.Errorf("%w: %v", dto.ErrNotFound, "signature request not found"))
+
+				// when
+				signatureController.RejectEntryByToken(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(404))
+				Expect(w.Body).To(MatchJSON(`{"code": 404, "message":"not found: signature request not found"}`))
+			})
+		})
+	})
+})
@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"errors"
+	"github.com/avialog/backend/internal/common"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/service"
+	"github.com/avialog/backend/internal/util"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type SyncController interface {
+	GetChanges(*gin.Context)
+	PushChanges(*gin.Context)
+}
+
+type syncController struct {
+	syncService service.SyncService
+}
+
+func newSyncController(syncService service.SyncService) SyncController {
+	return &syncController{syncService: syncService}
+}
+
+// GetChanges godoc
+//
+// @Summary Get changes since the last synchronization
+// @Description Get the logbook entries, aircraft and contacts created, updated or deleted and the profile if it changed since the token was issued. Without a token, or with a token older than the retention period of the trash, every item is returned with reset set and the local copy has to be replaced. The returned token is passed to the next request.
+// @Tags sync
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   since             query    string     false       "Token of the previous synchronization"
+// @Success 200 {object}      dto.SyncResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /sync [get]
+func (c *syncController) GetChanges(ctx *gin.Context) {
+	userID := ctx.GetString(common.UserID)
+
+	var token *string
+	if since := ctx.Query("since"); since != "" {
+		token = &since
+	}
+
+	syncResponse, err := c.syncService.GetChanges(userID, token)
+	if err != nil {
+		if errors.Is(err, dto.ErrBadRequest) {
+			util.NewError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, syncResponse)
+}
+
+// PushChanges godoc
+//
+// @Summary Push changes made offline
+// @Description Apply logbook entries, aircraft, contacts and profile changed on a device. Changes of existing items carry the updated_at of the version they are based on. If the item changed on the server since then, the change is not applied and the server version is returned as a conflict.
+// @Tags sync
+// @Accept  json
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   pushRequest       body     dto.SyncPushRequest true   "Changes to apply"
+// @Success 200 {object}      dto.SyncPushResponse
+// @Failure 400 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /sync [post]
+func (c *syncController) PushChanges(ctx *gin.Context) {
+	userID := ctx.GetString(common.UserID)
+
+	var pushRequest dto.SyncPushRequest
+	if err := ctx.ShouldBindJSON(&pushRequest); err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	pushResponse, err := c.syncService.PushChanges(userID, pushRequest)
+	if err != nil {
+		if errors.Is(err, dto.ErrBadRequest) {
+			util.NewError(ctx, http.StatusBadRequest, err)
+			return
+		}
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, pushResponse)
+}
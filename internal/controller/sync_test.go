@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/service"
+	"github.com/avialog/backend/internal/util"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("SyncController", func() {
+	var (
+		syncController  SyncController
+		syncServiceCtrl *gomock.Controller
+		syncServiceMock *service.MockSyncService
+		w               *httptest.ResponseRecorder
+		ctx             *gin.Context
+	)
+
+	BeforeEach(func() {
+		syncServiceCtrl = gomock.NewController(GinkgoT())
+		syncServiceMock = service.NewMockSyncService(syncServiceCtrl)
+		w = httptest.NewRecorder()
+		ctx, _ = gin.CreateTestContext(w)
+		syncController = newSyncController(syncServiceMock)
+	})
+
+	AfterEach(func() {
+		syncServiceCtrl.Finish()
+	})
+
+	Describe("GetChanges", func() {
+		Context("When the user sends a request with a token and no error occurs.", func() {
+			It("should return 200 and the changes", func() {
+				// given
+				syncResponse := dto.SyncResponse{
+					Token:           "next",
+					Flights:         []dto.LogbookResponse{},
+					DeletedFlights:  []uint{3},
+					Aircraft:        []dto.AircraftResponse{},
+					DeletedAircraft: []uint{},
+					Contacts:        []dto.ContactResponse{},
+					DeletedContacts: []uint{},
+				}
+				expectedResponseJSON, err := json.Marshal(syncResponse)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest("GET", "/sync?since=previous", nil)
+				ctx.Set("userID", "1")
+				syncServiceMock.EXPECT().GetChanges("1", util.String("previous")).Return(syncResponse, nil)
+
+				// when
+				syncController.GetChanges(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(200))
+				Expect(w.Body).To(MatchJSON(expectedResponseJSON))
+			})
+		})
+		Context("When the user sends a request without a token", func() {
+			It("should request every item", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/sync", nil)
+				ctx.Set("userID", "1")
+				syncServiceMock.EXPECT().GetChanges("1", nil).Return(dto.SyncResponse{Reset: true}, nil)
+
+				// when
+				syncController.GetChanges(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(200))
+			})
+		})
+		Context("When the token is invalid", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/sync?since=invalid", nil)
+				ctx.Set("userID", "1")
+				syncServiceMock.EXPECT().GetChanges("1", util.String("invalid")).
+					Return(dto.SyncResponse{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "invalid sync token"))
+
+				// when
+				syncController.GetChanges(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(400))
+				Expect(w.Body).To(MatchJSON(`{"code": 400, "message":"bad request: invalid sync token"}`))
+			})
+		})
+	})
+
+	Describe("PushChanges", func() {
+		Context("When the user pushes changes and no error occurs.", func() {
+			It("should return 200 and the result of every change", func() {
+				// given
+				baseUpdatedAt := time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC)
+				pushRequest := dto.SyncPushRequest{
+					Contacts: []dto.SyncContactChange{{ID: util.Uint(4), BaseUpdatedAt: &baseUpdatedAt, Deleted: true}},
+				}
+				pushRequestJSON, err := json.Marshal(pushRequest)
+				Expect(err).ToNot(HaveOccurred())
+				pushResponse := dto.SyncPushResponse{
+					Flights:  []dto.SyncFlightResult{},
+					Aircraft: []dto.SyncAircraftResult{},
+					Contacts: []dto.SyncContactResult{{Index: 0, Status: dto.SyncStatusApplied, ID: util.Uint(4)}},
+				}
+				expectedResponseJSON, err := json.Marshal(pushResponse)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest("POST", "/sync", bytes.NewBuffer(pushRequestJSON))
+				ctx.Set("userID", "1")
+				syncServiceMock.EXPECT().PushChanges("1", pushRequest).Return(pushResponse, nil)
+
+				// when
+				syncController.PushChanges(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(200))
+				Expect(w.Body).To(MatchJSON(expectedResponseJSON))
+			})
+		})
+		Context("When the body is invalid", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("POST", "/sync", bytes.NewBufferString("invalid"))
+				ctx.Set("userID", "1")
+
+				// when
+				syncController.PushChanges(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(400))
+			})
+		})
+		Context("When the service fails", func() {
+			It("should return 500 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("POST", "/sync", bytes.NewBufferString("{}"))
+				ctx.Set("userID", "1")
+				syncServiceMock.EXPECT().PushChanges("1", dto.SyncPushRequest{}).
+					Return(dto.SyncPushResponse{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, "db error"))
+
+				// when
+				syncController.PushChanges(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(500))
+				Expect(w.Body).To(MatchJSON(`{"code": 500, "message":"internal failure: db error"}`))
+			})
+		})
+	})
+})
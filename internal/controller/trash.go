@@ -0,0 +1,150 @@
+package controller
+
+import (
+	"errors"
+	"github.com/avialog/backend/internal/common"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/service"
+	"github.com/avialog/backend/internal/util"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type TrashController interface {
+	GetTrash(*gin.Context)
+	RestoreLogbookEntry(*gin.Context)
+	RestoreAircraft(*gin.Context)
+	RestoreContact(*gin.Context)
+}
+
+type trashController struct {
+	trashService service.TrashService
+}
+
+func newTrashController(trashService service.TrashService) TrashController {
+	return &trashController{trashService: trashService}
+}
+
+// GetTrash godoc
+//
+// @Summary Get the trash
+// @Description Get the deleted logbook entries, aircraft and contacts of a user with the time of deletion. They can be restored until they are purged after the retention period.
+// @Tags trash
+// @Produce  json
+// @Security ApiKeyAuth
+// @Success 200 {object}      dto.TrashResponse
+// @Failure 500 {object}      util.HTTPError
+// @Router  /trash [get]
+func (c *trashController) GetTrash(ctx *gin.Context) {
+	userID := ctx.GetString(common.UserID)
+
+	trashResponse, err := c.trashService.GetTrash(userID)
+	if err != nil {
+		util.NewError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, trashResponse)
+}
+
+// RestoreLogbookEntry godoc
+//
+// @Summary Restore a logbook entry
+// @Description Restore a deleted logbook entry together with its landings and passengers. The aircraft of the entry has to be restored first.
+// @Tags trash
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   id                path     int        true        "Flight ID"
+// @Success 200 {object}      map[string]string
+// @Failure 400 {object}      util.HTTPError
+// @Failure 404 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /trash/flights/{id}/restore [post]
+func (c *trashController) RestoreLogbookEntry(ctx *gin.Context) {
+	flightID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := ctx.GetString(common.UserID)
+
+	if err := c.trashService.RestoreLogbookEntry(userID, uint(flightID)); err != nil {
+		c.handleRestoreError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Logbook entry restored successfully"})
+}
+
+// RestoreAircraft godoc
+//
+// @Summary Restore an aircraft
+// @Description Restore a deleted aircraft of a user
+// @Tags trash
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   id                path     int        true        "Aircraft ID"
+// @Success 200 {object}      map[string]string
+// @Failure 400 {object}      util.HTTPError
+// @Failure 404 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /trash/aircraft/{id}/restore [post]
+func (c *trashController) RestoreAircraft(ctx *gin.Context) {
+	aircraftID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := ctx.GetString(common.UserID)
+
+	if err := c.trashService.RestoreAircraft(userID, uint(aircraftID)); err != nil {
+		c.handleRestoreError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Aircraft restored successfully"})
+}
+
+// RestoreContact godoc
+//
+// @Summary Restore a contact
+// @Description Restore a deleted contact of a user
+// @Tags trash
+// @Produce  json
+// @Security ApiKeyAuth
+// @Param   id                path     int        true        "Contact ID"
+// @Success 200 {object}      map[string]string
+// @Failure 400 {object}      util.HTTPError
+// @Failure 404 {object}      util.HTTPError
+// @Failure 500 {object}      util.HTTPError
+// @Router  /trash/contacts/{id}/restore [post]
+func (c *trashController) RestoreContact(ctx *gin.Context) {
+	contactID, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	userID := ctx.GetString(common.UserID)
+
+	if err := c.trashService.RestoreContact(userID, uint(contactID)); err != nil {
+		c.handleRestoreError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Contact restored successfully"})
+}
+
+func (c *trashController) handleRestoreError(ctx *gin.Context, err error) {
+	if errors.Is(err, dto.ErrBadRequest) {
+		util.NewError(ctx, http.StatusBadRequest, err)
+		return
+	} else if errors.Is(err, dto.ErrNotFound) {
+		util.NewError(ctx, http.StatusNotFound, err)
+		return
+	}
+	util.NewError(ctx, http.StatusInternalServerError, err)
+}
@@ -0,0 +1,190 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/service"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"net/http/httptest"
+	"time"
+)
+
+var _ = Describe("TrashController", func() {
+	var (
+		trashController  TrashController
+		trashServiceCtrl *gomock.Controller
+		trashServiceMock *service.MockTrashService
+		w                *httptest.ResponseRecorder
+		ctx              *gin.Context
+	)
+
+	BeforeEach(func() {
+		trashServiceCtrl = gomock.NewController(GinkgoT())
+		trashServiceMock = service.NewMockTrashService(trashServiceCtrl)
+		w = httptest.NewRecorder()
+		ctx, _ = gin.CreateTestContext(w)
+		trashController = newTrashController(trashServiceMock)
+	})
+
+	AfterEach(func() {
+		trashServiceCtrl.Finish()
+	})
+
+	Describe("GetTrash", func() {
+		Context("When the user sends a request and no error occurs.", func() {
+			It("should return 200 and the deleted items", func() {
+				// given
+				deletedAt := time.Date(2024, 4, 1, 8, 0, 0, 0, time.UTC)
+				trashResponse := dto.TrashResponse{
+					Flights: []dto.TrashFlightResponse{},
+					Aircraft: []dto.TrashAircraftResponse{{
+						ID:                 1,
+						RegistrationNumber: "SP-ABC",
+						AircraftModel:      "C152",
+						DeletedAt:          deletedAt,
+						PurgeAt:            deletedAt.Add(30 * 24 * time.Hour),
+					}},
+					Contacts: []dto.TrashContactResponse{},
+				}
+				expectedResponseJSON, err := json.Marshal(trashResponse)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest("GET", "/trash", nil)
+				ctx.Set("userID", "1")
+				trashServiceMock.EXPECT().GetTrash("1").Return(trashResponse, nil)
+
+				// when
+				trashController.GetTrash(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(200))
+				Expect(w.Body).To(MatchJSON(expectedResponseJSON))
+			})
+		})
+		Context("When the user sends a request and the service fails", func() {
+			It("should return 500 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("GET", "/trash", nil)
+				ctx.Set("userID", "1")
+				trashServiceMock.EXPECT().GetTrash("1").Return(dto.TrashResponse{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, "db error"))
+
+				// when
+				trashController.GetTrash(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(500))
+				Expect(w.Body).To(MatchJSON(`{"code": 500, "message":"internal failure: db error"}`))
+			})
+		})
+	})
+
+	Describe("RestoreLogbookEntry", func() {
+		Context("When the user sends a request and no error occurs.", func() {
+			It("should return 200 and message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("POST", "/trash/flights/3/restore", nil)
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "3"})
+				trashServiceMock.EXPECT().RestoreLogbookEntry("1", uint(3)).Return(nil)
+
+				// when
+				trashController.RestoreLogbookEntry(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(200))
+				Expect(w.Body).To(MatchJSON(`{"message":"Logbook entry restored successfully"}`))
+			})
+		})
+		Context("When the aircraft of the flight is deleted", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("POST", "/trash/flights/3/restore", nil)
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "3"})
+				trashServiceMock.EXPECT().RestoreLogbookEntry("1", uint(3)).
+					Return(fmt.Errorf("%w: %v", dto.ErrBadRequest, "aircraft of the flight is deleted, restore it first"))
+
+				// when
+				trashController.RestoreLogbookEntry(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(400))
+				Expect(w.Body).To(MatchJSON(`{"code": 400, "message":"bad request: aircraft of the flight is deleted, restore it first"}`))
+			})
+		})
+		Context("When the flight is not in the trash", func() {
+			It("should return 404 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("POST", "/trash/flights/3/restore", nil)
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "3"})
+				trashServiceMock.EXPECT().RestoreLogbookEntry("1", uint(3)).
+					Return(fmt.Errorf("%w: %v", dto.ErrNotFound, "deleted flight not found"))
+
+				// when
+				trashController.RestoreLogbookEntry(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(404))
+				Expect(w.Body).To(MatchJSON(`{"code": 404, "message":"not found: deleted flight not found"}`))
+			})
+		})
+		Context("When the user sends a request and fails to fetch id from params", func() {
+			It("should return 400 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("POST", "/trash/flights/restore", nil)
+				ctx.Set("userID", "1")
+
+				// when
+				trashController.RestoreLogbookEntry(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(400))
+				Expect(w.Body).To(MatchJSON(`{"code": 400, "message":"strconv.ParseUint: parsing \"\": invalid syntax"}`))
+			})
+		})
+	})
+
+	Describe("RestoreAircraft", func() {
+		Context("When the user sends a request and no error occurs.", func() {
+			It("should return 200 and message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("POST", "/trash/aircraft/1/restore", nil)
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "1"})
+				trashServiceMock.EXPECT().RestoreAircraft("1", uint(1)).Return(nil)
+
+				// when
+				trashController.RestoreAircraft(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(200))
+				Expect(w.Body).To(MatchJSON(`{"message":"Aircraft restored successfully"}`))
+			})
+		})
+	})
+
+	Describe("RestoreContact", func() {
+		Context("When the contact is not in the trash", func() {
+			It("should return 404 and error message", func() {
+				// given
+				ctx.Request = httptest.NewRequest("POST", "/trash/contacts/4/restore", nil)
+				ctx.Set("userID", "1")
+				ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "4"})
+				trashServiceMock.EXPECT().RestoreContact("1", uint(4)).
+					Return(fmt.Errorf("%w: %v", dto.ErrNotFound, "deleted contact not found"))
+
+				// when
+				trashController.RestoreContact(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(404))
+				Expect(w.Body).To(MatchJSON(`{"code": 404, "message":"not found: deleted contact not found"}`))
+			})
+		})
+	})
+})
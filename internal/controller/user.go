@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"github.com/avialog/backend/internal/common"
 	"github.com/avialog/backend/internal/dto"
 	"github.com/avialog/backend/internal/model"
@@ -30,7 +31,9 @@ func newUserController(userService service.UserService) UserController {
 // @Tags profile
 // @Produce  json
 // @Security ApiKeyAuth
+// @Param   If-None-Match     header   string     false       "ETag of the profile the client has"
 // @Success 200 {object}      dto.UserResponse
+// @Success 304 "Not modified"
 // @Failure 500 {object}      util.HTTPError
 // @Router  /profile [get]
 func (u *userController) GetUser(ctx *gin.Context) {
@@ -44,20 +47,22 @@ func (u *userController) GetUser(ctx *gin.Context) {
 
 	userResponse := u.adaptUser(user)
 
-	ctx.JSON(http.StatusOK, userResponse)
+	respondWithETag(ctx, http.StatusOK, versionETag(user.UpdatedAt), userResponse)
 }
 
 // UpdateProfile godoc
 //
 // @Summary Update user profile
-// @Description Update user profile information
+// @Description Update user profile information, with If-Match only if it was not changed since the client got that version
 // @Tags profile
 // @Accept  json
 // @Produce  json
 // @Security ApiKeyAuth
+// @Param   If-Match          header   string     false       "ETag of the profile the change is based on"
 // @Param   userRequest       body     dto.UserRequest true       "User profile information to update"
 // @Success 200 {object}      dto.UserResponse
 // @Failure 400 {object}      util.HTTPError
+// @Failure 412 {object}      util.HTTPError
 // @Failure 500 {object}      util.HTTPError
 // @Router  /profile [put]
 func (u *userController) UpdateProfile(ctx *gin.Context) {
@@ -69,29 +74,42 @@ func (u *userController) UpdateProfile(ctx *gin.Context) {
 		return
 	}
 
-	user, err := u.userService.UpdateProfile(userID, userRequest)
+	version, err := ifMatchVersion(ctx)
 	if err != nil {
+		util.NewError(ctx, http.StatusPreconditionFailed, err)
+		return
+	}
+
+	user, err := u.userService.UpdateProfile(userID, userRequest, version)
+	if err != nil {
+		if errors.Is(err, dto.ErrPreconditionFailed) {
+			util.NewError(ctx, http.StatusPreconditionFailed, err)
+			return
+		}
 		util.NewError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
 	userResponse := u.adaptUser(user)
 
-	ctx.JSON(http.StatusOK, userResponse)
+	respondWithETag(ctx, http.StatusOK, versionETag(user.UpdatedAt), userResponse)
 }
 
 func (u *userController) adaptUser(user model.User) dto.UserResponse {
 	return dto.UserResponse{
-		FirstName:    user.FirstName,
-		LastName:     user.LastName,
-		Email:        user.Email,
-		AvatarURL:    user.AvatarURL,
-		SignatureURL: user.SignatureURL,
-		Country:      user.Country,
-		Phone:        user.Phone,
-		Street:       user.Street,
-		City:         user.City,
-		Company:      user.Company,
-		Timezone:     user.Timezone,
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		Email:         user.Email,
+		AvatarURL:     user.AvatarURL,
+		SignatureURL:  user.SignatureURL,
+		Country:       user.Country,
+		Phone:         user.Phone,
+		Street:        user.Street,
+		City:          user.City,
+		Company:       user.Company,
+		Timezone:      user.Timezone,
+		AutoFillTimes: user.AutoFillTimes,
+		DateOfBirth:   user.DateOfBirth,
+		UpdatedAt:     user.UpdatedAt,
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"github.com/avialog/backend/internal/dto"
 	"github.com/avialog/backend/internal/model"
 	"github.com/avialog/backend/internal/service"
@@ -14,6 +15,7 @@ import (
 	"go.uber.org/mock/gomock"
 	"net/http"
 	"net/http/httptest"
+	"time"
 )
 
 var _ = Describe("UserController", func() {
@@ -107,6 +109,25 @@ var _ = Describe("UserController", func() {
 				Expect(w.Body.String()).To(MatchJSON(expectedJSON))
 			})
 		})
+		Context("when the client already has the current profile", func() {
+			It("should return 304 Not Modified", func() {
+				// given
+				userMock.UpdatedAt = time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC)
+
+				ctx.Request = httptest.NewRequest(http.MethodGet, "/api/profile", nil)
+				ctx.Request.Header.Set("If-None-Match", `"1713513600000000"`)
+				ctx.Set("userID", "1")
+				userServiceMock.EXPECT().GetUser("1").Return(userMock, nil)
+
+				// when
+				userController.GetUser(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusNotModified))
+				Expect(w.Header().Get("ETag")).To(Equal(`"1713513600000000"`))
+				Expect(w.Body.Len()).To(BeZero())
+			})
+		})
 		Context("on failed get profile", func() {
 			It("should return 500 Internal Server Error", func() {
 				// given
@@ -143,7 +164,7 @@ var _ = Describe("UserController", func() {
 				ctx.Set("Content-Type", "application/json")
 				ctx.Set("Accept", "application/json")
 				ctx.Set("userID", "1")
-				userServiceMock.EXPECT().UpdateProfile("1", userRequest).Return(userMock, nil)
+				userServiceMock.EXPECT().UpdateProfile("1", userRequest, nil).Return(userMock, nil)
 				// when
 				userController.UpdateProfile(ctx)
 
@@ -170,6 +191,26 @@ var _ = Describe("UserController", func() {
 				Expect(w.Body.String()).To(MatchJSON(`{"code":400,"message":"invalid character 'i' looking for beginning of value"}`))
 			})
 		})
+		Context("when the profile changed since the version of If-Match", func() {
+			It("should return 412 Precondition Failed", func() {
+				// given
+				userRequestJSON, err := json.Marshal(userRequest)
+				Expect(err).ToNot(HaveOccurred())
+
+				ctx.Request = httptest.NewRequest(http.MethodPut, "/api/profile", bytes.NewBuffer(userRequestJSON))
+				ctx.Request.Header.Set("If-Match", `"1713513600000000"`)
+				ctx.Set("userID", "1")
+				userServiceMock.EXPECT().UpdateProfile("1", userRequest, gomock.Any()).
+					Return(model.User{}, fmt.Errorf("%w: profile changed since the given version", dto.ErrPreconditionFailed))
+
+				// when
+				userController.UpdateProfile(ctx)
+
+				// then
+				Expect(w.Code).To(Equal(http.StatusPreconditionFailed))
+				Expect(w.Body.String()).To(MatchJSON(`{"code":412,"message":"precondition failed: profile changed since the given version"}`))
+			})
+		})
 		Context("on failed update profile", func() {
 			It("should return 500 Internal Server Error", func() {
 				// given
@@ -183,7 +224,7 @@ var _ = Describe("UserController", func() {
 				ctx.Set("Content-Type", "application/json")
 				ctx.Set("Accept", "application/json")
 				ctx.Set("userID", "1")
-				userServiceMock.EXPECT().UpdateProfile("1", userRequest).Return(model.User{}, errors.New("failed to update profile"))
+				userServiceMock.EXPECT().UpdateProfile("1", userRequest, nil).Return(model.User{}, errors.New("failed to update profile"))
 
 				// when
 				userController.UpdateProfile(ctx)
@@ -1,8 +1,11 @@
 package dto
 
+import "github.com/avialog/backend/internal/model"
+
 type AircraftRequest struct {
-	RegistrationNumber string  `json:"registration_number" binding:"required"`
-	AircraftModel      string  `json:"aircraft_model" binding:"required"`
-	Remarks            *string `json:"remarks"`
-	ImageURL           *string `json:"image_url"`
+	RegistrationNumber string                  `json:"registration_number" binding:"required"`
+	AircraftModel      string                  `json:"aircraft_model" binding:"required"`
+	Category           *model.AircraftCategory `json:"category"`
+	Remarks            *string                 `json:"remarks"`
+	ImageURL           *string                 `json:"image_url"`
 }
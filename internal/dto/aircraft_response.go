@@ -1,9 +1,16 @@
 package dto
 
+import (
+	"github.com/avialog/backend/internal/model"
+	"time"
+)
+
 type AircraftResponse struct {
-	ID                 uint    `json:"id"`
-	RegistrationNumber string  `json:"registration_number" binding:"required"`
-	AircraftModel      string  `json:"aircraft_model" binding:"required"`
-	Remarks            *string `json:"remarks"`
-	ImageURL           *string `json:"image_url"`
+	ID                 uint                    `json:"id"`
+	RegistrationNumber string                  `json:"registration_number" binding:"required"`
+	AircraftModel      string                  `json:"aircraft_model" binding:"required"`
+	Category           *model.AircraftCategory `json:"category"`
+	Remarks            *string                 `json:"remarks"`
+	ImageURL           *string                 `json:"image_url"`
+	UpdatedAt          time.Time               `json:"updated_at"`
 }
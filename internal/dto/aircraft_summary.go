@@ -0,0 +1,7 @@
+package dto
+
+type AircraftSummary struct {
+	ID                 uint   `json:"id"`
+	RegistrationNumber string `json:"registration_number"`
+	AircraftModel      string `json:"aircraft_model"`
+}
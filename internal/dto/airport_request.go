@@ -0,0 +1,6 @@
+package dto
+
+type AirportRequest struct {
+	Query string `form:"q"`
+	Limit *int   `form:"limit"`
+}
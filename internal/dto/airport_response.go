@@ -0,0 +1,14 @@
+package dto
+
+import "github.com/avialog/backend/internal/model"
+
+type AirportResponse struct {
+	ICAOCode  string        `json:"icao_code"`
+	IATACode  *string       `json:"iata_code"`
+	Name      string        `json:"name"`
+	Country   model.Country `json:"country"`
+	Latitude  float64       `json:"latitude"`
+	Longitude float64       `json:"longitude"`
+	Elevation int           `json:"elevation"`
+	Timezone  string        `json:"timezone"`
+}
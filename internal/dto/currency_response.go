@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"github.com/avialog/backend/internal/model"
+	"time"
+)
+
+type CurrencyResponse struct {
+	Category       *model.AircraftCategory `json:"category"`
+	Passenger      CurrencyStatus          `json:"passenger"`
+	NightPassenger CurrencyStatus          `json:"night_passenger"`
+	Instrument     CurrencyStatus          `json:"instrument"`
+}
+
+type CurrencyStatus struct {
+	Current      bool                  `json:"current"`
+	LapsesAt     *time.Time            `json:"lapses_at"`
+	Requirements []CurrencyRequirement `json:"requirements"`
+}
+
+type CurrencyRequirement struct {
+	Name     string `json:"name"`
+	Required uint   `json:"required"`
+	Logged   uint   `json:"logged"`
+	Missing  uint   `json:"missing"`
+}
@@ -3,9 +3,12 @@ package dto
 import "errors"
 
 var (
-	ErrNotFound        = errors.New("not found")
-	ErrInternalFailure = errors.New("internal failure")
-	ErrBadRequest      = errors.New("bad request")
-	ErrNotAuthorized   = errors.New("not authorized")
-	ErrConflict        = errors.New("conflict")
+	ErrNotFound           = errors.New("not found")
+	ErrInternalFailure    = errors.New("internal failure")
+	ErrBadRequest         = errors.New("bad request")
+	ErrNotAuthorized      = errors.New("not authorized")
+	ErrForbidden          = errors.New("forbidden")
+	ErrConflict           = errors.New("conflict")
+	ErrUnprocessable      = errors.New("unprocessable")
+	ErrPreconditionFailed = errors.New("precondition failed")
 )
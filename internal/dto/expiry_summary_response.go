@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+type ExpiryItemType string
+
+const (
+	ExpiryItemTypeLicence ExpiryItemType = "LICENCE"
+	ExpiryItemTypeRating  ExpiryItemType = "RATING"
+)
+
+// ExpirySummaryResponse sorts the licences and ratings of the user by their validity. Items without an expiry date
+// are valid, items are sorted by expiry date with the earliest first.
+type ExpirySummaryResponse struct {
+	Valid        []ExpiryItem `json:"valid"`
+	ExpiringSoon []ExpiryItem `json:"expiring_soon"`
+	Lapsed       []ExpiryItem `json:"lapsed"`
+}
+
+// ExpiryItem is a licence or rating of the user. DaysLeft is negative for lapsed items and nil for items without an
+// expiry date.
+type ExpiryItem struct {
+	Type      ExpiryItemType `json:"type"`
+	ID        uint           `json:"id"`
+	Name      string         `json:"name"`
+	ExpiresAt *time.Time     `json:"expires_at"`
+	DaysLeft  *int           `json:"days_left"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+import "time"
+
+type ExportFilter struct {
+	Start   *time.Time
+	End     *time.Time
+	Columns []string
+}
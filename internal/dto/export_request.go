@@ -0,0 +1,8 @@
+package dto
+
+type ExportRequest struct {
+	Format  *string `form:"format"`
+	Start   *int64  `form:"start"`
+	End     *int64  `form:"end"`
+	Columns *string `form:"columns"`
+}
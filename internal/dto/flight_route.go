@@ -0,0 +1,11 @@
+package dto
+
+import "time"
+
+type FlightRoute struct {
+	TakeoffAirportCode string
+	TakeoffTime        time.Time
+	LandingAirportCode string
+	LandingTime        time.Time
+	Landings           uint
+}
@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"encoding/json"
+	"github.com/avialog/backend/internal/model"
+	"time"
+)
+
+type FlightVersionResponse struct {
+	Sequence     uint                      `json:"sequence"`
+	FlightID     uint                      `json:"flight_id"`
+	Action       model.FlightVersionAction `json:"action"`
+	RecordedAt   time.Time                 `json:"recorded_at"`
+	PreviousHash string                    `json:"previous_hash"`
+	Hash         string                    `json:"hash"`
+	Snapshot     LogbookSnapshot           `json:"snapshot"`
+	// Changes lists the fields changed since the previous version of the entry, it is empty for the first version.
+	Changes []FieldChange `json:"changes"`
+}
+
+type FieldChange struct {
+	Field string          `json:"field"`
+	Old   json.RawMessage `json:"old" swaggertype:"object"`
+	New   json.RawMessage `json:"new" swaggertype:"object"`
+}
@@ -1,6 +1,16 @@
 package dto
 
+import "github.com/avialog/backend/internal/model"
+
 type GetLogbookRequest struct {
-	Start *int64 `json:"start"`
-	End   *int64 `json:"end"`
+	Start       *int64       `form:"start"`
+	End         *int64       `form:"end"`
+	AircraftID  *uint        `form:"aircraft_id"`
+	AirportCode *string      `form:"airport_code"`
+	Role        *model.Role  `form:"role"`
+	Style       *model.Style `form:"style"`
+	Remarks     *string      `form:"remarks"`
+	Order       *SortOrder   `form:"order"`
+	Limit       *int         `form:"limit"`
+	Cursor      *string      `form:"cursor"`
 }
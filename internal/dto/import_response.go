@@ -0,0 +1,24 @@
+package dto
+
+type ImportRowStatus string
+
+const (
+	ImportRowCreated ImportRowStatus = "created"
+	ImportRowSkipped ImportRowStatus = "skipped"
+	ImportRowFailed  ImportRowStatus = "failed"
+)
+
+type ImportResponse struct {
+	Created         int               `json:"created"`
+	Skipped         int               `json:"skipped"`
+	Failed          int               `json:"failed"`
+	CreatedAircraft []string          `json:"created_aircraft"`
+	Rows            []ImportRowResult `json:"rows"`
+}
+
+type ImportRowResult struct {
+	Line     int             `json:"line"`
+	Status   ImportRowStatus `json:"status"`
+	FlightID *uint           `json:"flight_id"`
+	Message  *string         `json:"message"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+import (
+	"github.com/avialog/backend/internal/model"
+	"time"
+)
+
+type JobRunResponse struct {
+	ID          uint               `json:"id"`
+	Job         string             `json:"job"`
+	ScheduledAt time.Time          `json:"scheduled_at"`
+	Status      model.JobRunStatus `json:"status"`
+	Replica     string             `json:"replica"`
+	Attempts    int                `json:"attempts"`
+	Summary     *string            `json:"summary"`
+	Error       *string            `json:"error"`
+	StartedAt   time.Time          `json:"started_at"`
+	FinishedAt  *time.Time         `json:"finished_at"`
+}
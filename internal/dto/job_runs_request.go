@@ -0,0 +1,6 @@
+package dto
+
+type JobRunsRequest struct {
+	Job   string `form:"job"`
+	Limit *int   `form:"limit"`
+}
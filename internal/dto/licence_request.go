@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"github.com/avialog/backend/internal/model"
+	"time"
+)
+
+type LicenceRequest struct {
+	Type         model.LicenceType `json:"type" binding:"required"`
+	Number       string            `json:"number" binding:"required"`
+	IssuingState string            `json:"issuing_state" binding:"required"`
+	IssuedAt     *time.Time        `json:"issued_at"`
+	ExpiresAt    *time.Time        `json:"expires_at"`
+	Remarks      *string           `json:"remarks"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+import (
+	"github.com/avialog/backend/internal/model"
+	"time"
+)
+
+type LicenceResponse struct {
+	ID           uint              `json:"id"`
+	Type         model.LicenceType `json:"type"`
+	Number       string            `json:"number"`
+	IssuingState string            `json:"issuing_state"`
+	IssuedAt     *time.Time        `json:"issued_at"`
+	ExpiresAt    *time.Time        `json:"expires_at"`
+	Remarks      *string           `json:"remarks"`
+	UpdatedAt    time.Time         `json:"updated_at"`
+}
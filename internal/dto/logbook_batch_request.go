@@ -0,0 +1,24 @@
+package dto
+
+type BatchAction string
+
+const (
+	BatchActionCreate BatchAction = "create"
+	BatchActionUpdate BatchAction = "update"
+	BatchActionDelete BatchAction = "delete"
+)
+
+// LogbookBatchRequest is a list of changes of the logbook. Atomic batches are applied entirely or not at all, other
+// batches apply every operation which succeeds.
+type LogbookBatchRequest struct {
+	Atomic     bool                    `json:"atomic"`
+	Operations []LogbookBatchOperation `json:"operations"`
+}
+
+// LogbookBatchOperation creates an entry, or updates or deletes the entry with the ID. Entry is required to create and
+// update.
+type LogbookBatchOperation struct {
+	Action BatchAction     `json:"action"`
+	ID     *uint           `json:"id"`
+	Entry  *LogbookRequest `json:"entry"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+type BatchStatus string
+
+const (
+	BatchStatusCreated BatchStatus = "created"
+	BatchStatusUpdated BatchStatus = "updated"
+	BatchStatusDeleted BatchStatus = "deleted"
+	BatchStatusFailed  BatchStatus = "failed"
+	// BatchStatusAborted is an operation of an atomic batch which was not applied because another operation failed.
+	BatchStatusAborted BatchStatus = "aborted"
+)
+
+type LogbookBatchResponse struct {
+	Atomic    bool                 `json:"atomic"`
+	Succeeded int                  `json:"succeeded"`
+	Failed    int                  `json:"failed"`
+	Results   []LogbookBatchResult `json:"results"`
+}
+
+// LogbookBatchResult is the result of the operation at the index in the request.
+type LogbookBatchResult struct {
+	Index    int         `json:"index"`
+	Action   BatchAction `json:"action"`
+	Status   BatchStatus `json:"status"`
+	FlightID *uint       `json:"flight_id"`
+	Error    *string     `json:"error"`
+}
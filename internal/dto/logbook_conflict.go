@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type ConflictKind string
+
+const (
+	// ConflictDuplicate is an entry of the same route taking off at almost the same time.
+	ConflictDuplicate ConflictKind = "duplicate"
+	// ConflictOverlap is an entry in the air at the same time on a different route.
+	ConflictOverlap ConflictKind = "overlap"
+)
+
+type FlightSummary struct {
+	ID                 uint      `json:"id"`
+	AircraftID         uint      `json:"aircraft_id"`
+	TakeoffTime        time.Time `json:"takeoff_time"`
+	TakeoffAirportCode string    `json:"takeoff_airport_code"`
+	LandingTime        time.Time `json:"landing_time"`
+	LandingAirportCode string    `json:"landing_airport_code"`
+}
+
+type FlightConflict struct {
+	Kind   ConflictKind  `json:"kind"`
+	Flight FlightSummary `json:"flight"`
+}
+
+// ConflictError lists the existing logbook entries clashing with the entry.
+type ConflictError struct {
+	Conflicts []FlightConflict
+}
+
+func (e *ConflictError) Error() string {
+	ids := make([]string, 0, len(e.Conflicts))
+	for _, conflict := range e.Conflicts {
+		ids = append(ids, fmt.Sprint(conflict.Flight.ID))
+	}
+	return fmt.Sprintf("entry conflicts with logbook entries: %v", strings.Join(ids, ", "))
+}
+
+type DuplicateGroupResponse struct {
+	Flights []FlightSummary `json:"flights"`
+}
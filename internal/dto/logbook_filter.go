@@ -0,0 +1,31 @@
+package dto
+
+import (
+	"github.com/avialog/backend/internal/model"
+	"time"
+)
+
+type SortOrder string
+
+const (
+	SortAscending  SortOrder = "asc"
+	SortDescending SortOrder = "desc"
+)
+
+// LogbookFilter selects logbook entries, nil fields are not filtered on. Entries are sorted by takeoff time.
+type LogbookFilter struct {
+	Start       *time.Time
+	End         *time.Time
+	AircraftID  *uint
+	AirportCode *string
+	Role        *model.Role
+	Style       *model.Style
+	Remarks     *string
+	Order       SortOrder
+}
+
+// LogbookCursor is the position of the last entry of a page, the next page starts right after it.
+type LogbookCursor struct {
+	TakeoffTime time.Time
+	ID          uint
+}
@@ -0,0 +1,12 @@
+package dto
+
+type LogbookPageResponse struct {
+	Entries []LogbookResponse `json:"entries"`
+	Paging  PagingResponse    `json:"paging"`
+}
+
+type PagingResponse struct {
+	Limit      int     `json:"limit"`
+	HasMore    bool    `json:"has_more"`
+	NextCursor *string `json:"next_cursor"`
+}
@@ -26,8 +26,10 @@ type LogbookRequest struct {
 	IFRSimulatedTime    *time.Duration   `json:"ifr_simulated_time"`
 	CrossCountryTime    *time.Duration   `json:"cross_country_time"`
 	SimulatorTime       *time.Duration   `json:"simulator_time"`
-	SignatureURL        *string          `json:"signature_url"`
+	Holdings            *uint            `json:"holdings"`
 	MyRole              model.Role       `json:"my_role"`	// moja rola
 	Passengers          []PassengerEntry `json:"passengers"`
 	Landings            []LandingEntry   `json:"landings"`
+	// AllowConflicts stores the entry even if it duplicates or overlaps existing entries.
+	AllowConflicts bool `json:"allow_conflicts"`
 }
@@ -0,0 +1,17 @@
+package dto
+
+type LogbookVerificationResponse struct {
+	Valid    bool    `json:"valid"`
+	Versions int     `json:"versions"`
+	Head     *string `json:"head"`
+	// Problems lists the versions breaking the chain and the entries which differ from their latest version.
+	Problems []VerificationProblem `json:"problems"`
+	// UntrackedFlightIDs are entries stored before the history was recorded, they have no version to verify.
+	UntrackedFlightIDs []uint `json:"untracked_flight_ids"`
+}
+
+type VerificationProblem struct {
+	Sequence *uint  `json:"sequence"`
+	FlightID uint   `json:"flight_id"`
+	Reason   string `json:"reason"`
+}
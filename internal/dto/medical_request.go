@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"github.com/avialog/backend/internal/model"
+	"time"
+)
+
+type MedicalRequest struct {
+	RuleSet     model.RuleSet      `json:"rule_set" binding:"required"`
+	Class       model.MedicalClass `json:"class" binding:"required"`
+	ExaminedAt  time.Time          `json:"examined_at" binding:"required"`
+	Number      *string            `json:"number"`
+	Limitations *string            `json:"limitations"`
+	Remarks     *string            `json:"remarks"`
+}
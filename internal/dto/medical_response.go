@@ -0,0 +1,45 @@
+package dto
+
+import (
+	"github.com/avialog/backend/internal/model"
+	"time"
+)
+
+// MedicalsResponse lists the medical certificates of the user, the most recent first, with warnings about privileges
+// of the most recent certificate of each rule set which lapsed or expire soon.
+type MedicalsResponse struct {
+	Certificates []MedicalResponse `json:"certificates"`
+	Warnings     []MedicalWarning  `json:"warnings"`
+}
+
+// MedicalResponse holds the validity of every privilege of the certificate. Privileges are empty if the date of birth
+// of the user is unknown, since the validity depends on the age at the examination.
+type MedicalResponse struct {
+	ID               uint                       `json:"id"`
+	RuleSet          model.RuleSet              `json:"rule_set"`
+	Class            model.MedicalClass         `json:"class"`
+	ExaminedAt       time.Time                  `json:"examined_at"`
+	Number           *string                    `json:"number"`
+	Limitations      *string                    `json:"limitations"`
+	Remarks          *string                    `json:"remarks"`
+	AgeAtExamination *int                       `json:"age_at_examination"`
+	Privileges       []MedicalPrivilegeResponse `json:"privileges"`
+	UpdatedAt        time.Time                  `json:"updated_at"`
+}
+
+// MedicalPrivilegeResponse is valid until the end of the ValidUntil day. DaysLeft is negative once it lapsed.
+type MedicalPrivilegeResponse struct {
+	Privilege  model.MedicalPrivilege `json:"privilege"`
+	ValidUntil time.Time              `json:"valid_until"`
+	DaysLeft   int                    `json:"days_left"`
+	Lapsed     bool                   `json:"lapsed"`
+}
+
+type MedicalWarning struct {
+	RuleSet       model.RuleSet          `json:"rule_set"`
+	CertificateID uint                   `json:"certificate_id"`
+	Privilege     model.MedicalPrivilege `json:"privilege"`
+	ValidUntil    time.Time              `json:"valid_until"`
+	DaysLeft      int                    `json:"days_left"`
+	Lapsed        bool                   `json:"lapsed"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+type NightTimeRequest struct {
+	TakeoffAirportCode string `form:"takeoff_airport_code"`
+	TakeoffTime        *int64 `form:"takeoff_time"`
+	LandingAirportCode string `form:"landing_airport_code"`
+	LandingTime        *int64 `form:"landing_time"`
+	Landings           *uint  `form:"landings"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+type NightTimeResponse struct {
+	TakeoffAirportCode string        `json:"takeoff_airport_code"`
+	LandingAirportCode string        `json:"landing_airport_code"`
+	NightTime          time.Duration `json:"night_time"`
+	NightLanding       bool          `json:"night_landing"`
+	DayLandings        uint          `json:"day_landings"`
+	NightLandings      uint          `json:"night_landings"`
+}
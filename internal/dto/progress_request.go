@@ -0,0 +1,7 @@
+package dto
+
+import "github.com/avialog/backend/internal/model"
+
+type ProgressRequest struct {
+	RuleSet *model.RuleSet `form:"rule_set"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "github.com/avialog/backend/internal/model"
+
+// ProgressUnit tells how the amounts of a requirement are measured.
+type ProgressUnit string
+
+const (
+	// ProgressUnitDuration amounts are durations in nanoseconds, like the logbook totals
+	ProgressUnitDuration ProgressUnit = "DURATION"
+	ProgressUnitCount    ProgressUnit = "COUNT"
+)
+
+type ProgressResponse struct {
+	RuleSet      model.RuleSet         `json:"rule_set"`
+	Licence      string                `json:"licence"`
+	Met          bool                  `json:"met"`
+	Requirements []ProgressRequirement `json:"requirements"`
+}
+
+type ProgressRequirement struct {
+	Name      string       `json:"name"`
+	Unit      ProgressUnit `json:"unit"`
+	Required  int64        `json:"required"`
+	Logged    int64        `json:"logged"`
+	Remaining int64        `json:"remaining"`
+	Met       bool         `json:"met"`
+}
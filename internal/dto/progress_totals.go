@@ -0,0 +1,21 @@
+package dto
+
+import (
+	"github.com/avialog/backend/internal/model"
+	"time"
+)
+
+// ProgressTotals are the totals of the flights of a user flown in a role and style.
+type ProgressTotals struct {
+	Role                model.Role
+	Style               model.Style
+	TotalBlockTime      time.Duration
+	PilotInCommandTime  time.Duration
+	SecondInCommandTime time.Duration
+	DualReceivedTime    time.Duration
+	MultiPilotTime      time.Duration
+	NightTime           time.Duration
+	IFRTime             time.Duration
+	CrossCountryTime    time.Duration
+	NightLandingCount   int64
+}
@@ -0,0 +1,10 @@
+package dto
+
+type PurgeResult struct {
+	Flights  int64
+	Aircraft int64
+	Contacts int64
+	// Landings and Passengers also count the rows replaced by updates of flights that are kept.
+	Landings   int64
+	Passengers int64
+}
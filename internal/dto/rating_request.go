@@ -0,0 +1,15 @@
+package dto
+
+import (
+	"github.com/avialog/backend/internal/model"
+	"time"
+)
+
+type RatingRequest struct {
+	LicenceID *uint            `json:"licence_id"`
+	Kind      model.RatingKind `json:"kind" binding:"required"`
+	Name      string           `json:"name" binding:"required"`
+	IssuedAt  *time.Time       `json:"issued_at"`
+	ExpiresAt *time.Time       `json:"expires_at"`
+	Remarks   *string          `json:"remarks"`
+}
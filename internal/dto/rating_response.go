@@ -0,0 +1,17 @@
+package dto
+
+import (
+	"github.com/avialog/backend/internal/model"
+	"time"
+)
+
+type RatingResponse struct {
+	ID        uint             `json:"id"`
+	LicenceID *uint            `json:"licence_id"`
+	Kind      model.RatingKind `json:"kind"`
+	Name      string           `json:"name"`
+	IssuedAt  *time.Time       `json:"issued_at"`
+	ExpiresAt *time.Time       `json:"expires_at"`
+	Remarks   *string          `json:"remarks"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+type ReminderPreferenceRequest struct {
+	DaysBefore     int     `json:"days_before" binding:"required"`
+	Licences       bool    `json:"licences"`
+	Medicals       bool    `json:"medicals"`
+	Currency       bool    `json:"currency"`
+	EmailEnabled   bool    `json:"email_enabled"`
+	PushEnabled    bool    `json:"push_enabled"`
+	WebhookEnabled bool    `json:"webhook_enabled"`
+	DeviceToken    *string `json:"device_token"`
+	WebhookURL     *string `json:"webhook_url"`
+}
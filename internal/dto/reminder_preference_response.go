@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+type ReminderPreferenceResponse struct {
+	DaysBefore     int       `json:"days_before"`
+	Licences       bool      `json:"licences"`
+	Medicals       bool      `json:"medicals"`
+	Currency       bool      `json:"currency"`
+	EmailEnabled   bool      `json:"email_enabled"`
+	PushEnabled    bool      `json:"push_enabled"`
+	WebhookEnabled bool      `json:"webhook_enabled"`
+	DeviceToken    *string   `json:"device_token"`
+	WebhookURL     *string   `json:"webhook_url"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+type ReminderResult struct {
+	Users  int
+	Sent   int
+	Failed int
+}
@@ -0,0 +1,5 @@
+package dto
+
+type SignatureDecisionRequest struct {
+	Remarks *string `json:"remarks"`
+}
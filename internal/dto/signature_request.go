@@ -0,0 +1,8 @@
+package dto
+
+// SignatureRequest names the instructor asked to countersign a flight, either a contact of the pilot or another user
+// identified by the email address of the account.
+type SignatureRequest struct {
+	ContactID       *uint   `json:"contact_id"`
+	InstructorEmail *string `json:"instructor_email"`
+}
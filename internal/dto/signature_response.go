@@ -0,0 +1,28 @@
+package dto
+
+import (
+	"github.com/avialog/backend/internal/model"
+	"time"
+)
+
+type SignatureResponse struct {
+	ID              uint                  `json:"id"`
+	FlightID        uint                  `json:"flight_id"`
+	Status          model.SignatureStatus `json:"status"`
+	RequesterName   string                `json:"requester_name"`
+	SignerName      string                `json:"signer_name"`
+	SignerEmail     *string               `json:"signer_email"`
+	SignerUserID    *string               `json:"signer_user_id"`
+	SignerContactID *uint                 `json:"signer_contact_id"`
+	Remarks         *string               `json:"remarks"`
+	SignedAt        *time.Time            `json:"signed_at"`
+	FieldsHash      *string               `json:"fields_hash"`
+	CreatedAt       time.Time             `json:"created_at"`
+	UpdatedAt       time.Time             `json:"updated_at"`
+}
+
+// SignatureReviewResponse is the signature request together with the logbook entry the instructor is asked to sign.
+type SignatureReviewResponse struct {
+	Signature SignatureResponse `json:"signature"`
+	Entry     LogbookResponse   `json:"entry"`
+}
@@ -0,0 +1,8 @@
+package dto
+
+// StoredResponse is the response to a request, stored to be returned again when the request is retried.
+type StoredResponse struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
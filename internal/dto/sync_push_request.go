@@ -0,0 +1,40 @@
+package dto
+
+import "time"
+
+// SyncPushRequest is a list of changes made on a device while it was offline.
+type SyncPushRequest struct {
+	Flights  []SyncFlightChange   `json:"flights"`
+	Aircraft []SyncAircraftChange `json:"aircraft"`
+	Contacts []SyncContactChange  `json:"contacts"`
+	Profile  *SyncProfileChange   `json:"profile"`
+}
+
+// SyncFlightChange creates an entry when ID is empty, otherwise it updates the entry or deletes it. BaseUpdatedAt is the
+// update time of the entry the change is based on, the later of updated_at of the entry and of its signature request as
+// in the ETag of the entry. The change is a conflict when the entry or its signature request changed on the server since.
+type SyncFlightChange struct {
+	ID            *uint           `json:"id"`
+	BaseUpdatedAt *time.Time      `json:"base_updated_at"`
+	Deleted       bool            `json:"deleted"`
+	Entry         *LogbookRequest `json:"entry"`
+}
+
+type SyncAircraftChange struct {
+	ID            *uint            `json:"id"`
+	BaseUpdatedAt *time.Time       `json:"base_updated_at"`
+	Deleted       bool             `json:"deleted"`
+	Aircraft      *AircraftRequest `json:"aircraft"`
+}
+
+type SyncContactChange struct {
+	ID            *uint           `json:"id"`
+	BaseUpdatedAt *time.Time      `json:"base_updated_at"`
+	Deleted       bool            `json:"deleted"`
+	Contact       *ContactRequest `json:"contact"`
+}
+
+type SyncProfileChange struct {
+	BaseUpdatedAt *time.Time  `json:"base_updated_at"`
+	Profile       UserRequest `json:"profile"`
+}
@@ -0,0 +1,50 @@
+package dto
+
+type SyncStatus string
+
+const (
+	SyncStatusApplied SyncStatus = "applied"
+	// SyncStatusConflict is a change of an item which changed on the server in the meantime, it was not applied.
+	SyncStatusConflict SyncStatus = "conflict"
+	SyncStatusFailed   SyncStatus = "failed"
+)
+
+// SyncPushResponse holds the result of every change at the index in the request.
+type SyncPushResponse struct {
+	Flights  []SyncFlightResult   `json:"flights"`
+	Aircraft []SyncAircraftResult `json:"aircraft"`
+	Contacts []SyncContactResult  `json:"contacts"`
+	Profile  *SyncProfileResult   `json:"profile"`
+}
+
+// SyncFlightResult holds the server version of the entry, which is the stored entry of an applied change or the
+// conflicting entry of a conflict. It is nil when the entry is deleted.
+type SyncFlightResult struct {
+	Index  int              `json:"index"`
+	Status SyncStatus       `json:"status"`
+	ID     *uint            `json:"id"`
+	Error  *string          `json:"error"`
+	Entry  *LogbookResponse `json:"entry"`
+}
+
+type SyncAircraftResult struct {
+	Index    int               `json:"index"`
+	Status   SyncStatus        `json:"status"`
+	ID       *uint             `json:"id"`
+	Error    *string           `json:"error"`
+	Aircraft *AircraftResponse `json:"aircraft"`
+}
+
+type SyncContactResult struct {
+	Index   int              `json:"index"`
+	Status  SyncStatus       `json:"status"`
+	ID      *uint            `json:"id"`
+	Error   *string          `json:"error"`
+	Contact *ContactResponse `json:"contact"`
+}
+
+type SyncProfileResult struct {
+	Status  SyncStatus    `json:"status"`
+	Error   *string       `json:"error"`
+	Profile *UserResponse `json:"profile"`
+}
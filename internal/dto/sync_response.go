@@ -0,0 +1,16 @@
+package dto
+
+// SyncResponse holds the changes of the user since the token of the request. Token is passed to the next request to
+// receive the changes made after this one. Reset is set when the changes cannot be computed from the token, the client
+// then has to replace its local copy with the returned items. Profile is nil when it did not change.
+type SyncResponse struct {
+	Token           string             `json:"token"`
+	Reset           bool               `json:"reset"`
+	Flights         []LogbookResponse  `json:"flights"`
+	DeletedFlights  []uint             `json:"deleted_flights"`
+	Aircraft        []AircraftResponse `json:"aircraft"`
+	DeletedAircraft []uint             `json:"deleted_aircraft"`
+	Contacts        []ContactResponse  `json:"contacts"`
+	DeletedContacts []uint             `json:"deleted_contacts"`
+	Profile         *UserResponse      `json:"profile"`
+}
@@ -0,0 +1,13 @@
+package dto
+
+import (
+	"github.com/avialog/backend/internal/model"
+	"time"
+)
+
+type TotalsFilter struct {
+	Start      *time.Time
+	End        *time.Time
+	AircraftID *uint
+	Role       *model.Role
+}
@@ -0,0 +1,10 @@
+package dto
+
+import "github.com/avialog/backend/internal/model"
+
+type TotalsRequest struct {
+	Start      *int64      `form:"start"`
+	End        *int64      `form:"end"`
+	AircraftID *uint       `form:"aircraft_id"`
+	Role       *model.Role `form:"role"`
+}
@@ -0,0 +1,22 @@
+package dto
+
+import "time"
+
+type TotalsResponse struct {
+	FlightCount         int64         `json:"flight_count"`
+	TotalBlockTime      time.Duration `json:"total_block_time"`
+	PilotInCommandTime  time.Duration `json:"pilot_in_command_time"`
+	SecondInCommandTime time.Duration `json:"second_in_command_time"`
+	DualReceivedTime    time.Duration `json:"dual_received_time"`
+	DualGivenTime       time.Duration `json:"dual_given_time"`
+	MultiPilotTime      time.Duration `json:"multi_pilot_time"`
+	NightTime           time.Duration `json:"night_time"`
+	IFRTime             time.Duration `json:"ifr_time"`
+	IFRActualTime       time.Duration `json:"ifr_actual_time"`
+	IFRSimulatedTime    time.Duration `json:"ifr_simulated_time"`
+	CrossCountryTime    time.Duration `json:"cross_country_time"`
+	SimulatorTime       time.Duration `json:"simulator_time"`
+	LandingCount        int64         `json:"landing_count"`
+	DayLandingCount     int64         `json:"day_landing_count"`
+	NightLandingCount   int64         `json:"night_landing_count"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+import "time"
+
+type TrashResponse struct {
+	Flights  []TrashFlightResponse   `json:"flights"`
+	Aircraft []TrashAircraftResponse `json:"aircraft"`
+	Contacts []TrashContactResponse  `json:"contacts"`
+}
+
+// TrashFlightResponse is a deleted logbook entry. PurgeAt is the time after which it is removed permanently.
+type TrashFlightResponse struct {
+	ID                 uint      `json:"id"`
+	AircraftID         uint      `json:"aircraft_id"`
+	TakeoffTime        time.Time `json:"takeoff_time"`
+	TakeoffAirportCode string    `json:"takeoff_airport_code"`
+	LandingTime        time.Time `json:"landing_time"`
+	LandingAirportCode string    `json:"landing_airport_code"`
+	DeletedAt          time.Time `json:"deleted_at"`
+	PurgeAt            time.Time `json:"purge_at"`
+}
+
+type TrashAircraftResponse struct {
+	ID                 uint      `json:"id"`
+	RegistrationNumber string    `json:"registration_number"`
+	AircraftModel      string    `json:"aircraft_model"`
+	DeletedAt          time.Time `json:"deleted_at"`
+	PurgeAt            time.Time `json:"purge_at"`
+}
+
+type TrashContactResponse struct {
+	ID        uint      `json:"id"`
+	FirstName string    `json:"first_name"`
+	LastName  *string   `json:"last_name"`
+	Company   *string   `json:"company"`
+	DeletedAt time.Time `json:"deleted_at"`
+	PurgeAt   time.Time `json:"purge_at"`
+}
@@ -1,17 +1,23 @@
 package dto
 
-import "github.com/avialog/backend/internal/model"
+import (
+	"github.com/avialog/backend/internal/model"
+	"time"
+)
 
 type UserResponse struct {
-	FirstName    *string        `json:"first_name"`
-	LastName     *string        `json:"last_name"`
-	Email        string         `json:"email" binding:"required"`
-	AvatarURL    *string        `json:"avatar_url"`
-	SignatureURL *string        `json:"signature_url"`
-	Country      *model.Country `json:"country"`
-	Phone        *string        `json:"phone"`
-	Street       *string        `json:"street"`
-	City         *string        `json:"city"`
-	Company      *string        `json:"company"`
-	Timezone     *string        `json:"timezone"`
+	FirstName     *string        `json:"first_name"`
+	LastName      *string        `json:"last_name"`
+	Email         string         `json:"email" binding:"required"`
+	AvatarURL     *string        `json:"avatar_url"`
+	SignatureURL  *string        `json:"signature_url"`
+	Country       *model.Country `json:"country"`
+	Phone         *string        `json:"phone"`
+	Street        *string        `json:"street"`
+	City          *string        `json:"city"`
+	Company       *string        `json:"company"`
+	Timezone      *string        `json:"timezone"`
+	AutoFillTimes bool           `json:"auto_fill_times"`
+	DateOfBirth   *time.Time     `json:"date_of_birth"`
+	UpdatedAt     time.Time      `json:"updated_at"`
 }
@@ -0,0 +1,59 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Codes of FieldError describing why the value of the field is invalid.
+const (
+	CodeRequired                          = "required"
+	CodeInvalidValue                      = "invalid_value"
+	CodeNegative                          = "negative"
+	CodeBeforeTakeoffTime                 = "before_takeoff_time"
+	CodeExceedsFlightDuration             = "exceeds_flight_duration"
+	CodeExceedsTotalBlockTime             = "exceeds_total_block_time"
+	CodeLessThanIFRActualAndSimulatedTime = "less_than_ifr_actual_and_simulated_time"
+	CodeLessThanDayAndNightCount          = "less_than_day_and_night_count"
+	CodeLandingsInSimulator               = "landings_in_simulator"
+	CodeBeforeIssueDate                   = "before_issue_date"
+	CodeInFuture                          = "in_future"
+	CodeBeforeDateOfBirth                 = "before_date_of_birth"
+	CodeNotInRuleSet                      = "not_in_rule_set"
+)
+
+type FieldError struct {
+	Field string `json:"field"`
+	Code  string `json:"code"`
+}
+
+// ValidationError lists all invalid fields of a request.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Add(field, code string) {
+	e.Errors = append(e.Errors, FieldError{Field: field, Code: code})
+}
+
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Errors))
+	for _, fieldError := range e.Errors {
+		if !containsString(fields, fieldError.Field) {
+			fields = append(fields, fieldError.Field)
+		}
+	}
+	if len(fields) == 1 {
+		return fmt.Sprintf("invalid data in field: %v", fields[0])
+	}
+	return fmt.Sprintf("invalid data in fields: %v", strings.Join(fields, ", "))
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,241 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	foreFlightAircraftTable = "aircraft table"
+	foreFlightFlightsTable  = "flights table"
+	foreFlightApproaches    = 6
+	foreFlightPeople        = 6
+)
+
+var foreFlightAliases = map[string][]string{
+	fieldDate:                 {"Date"},
+	fieldAircraftRegistration: {"AircraftID"},
+	fieldTakeoffAirport:       {"From"},
+	fieldLandingAirport:       {"To"},
+	fieldTakeoffTime:          {"TimeOut", "TimeOff"},
+	fieldLandingTime:          {"TimeIn", "TimeOn"},
+	fieldTotalBlockTime:       {"TotalTime"},
+	fieldPilotInCommandTime:   {"PIC"},
+	fieldSecondInCommandTime:  {"SIC"},
+	fieldDualReceivedTime:     {"DualReceived"},
+	fieldDualGivenTime:        {"DualGiven"},
+	fieldNightTime:            {"Night"},
+	fieldIFRActualTime:        {"ActualInstrument"},
+	fieldIFRSimulatedTime:     {"SimulatedInstrument"},
+	fieldCrossCountryTime:     {"CrossCountry"},
+	fieldSimulatorTime:        {"SimulatedFlight"},
+	fieldLandings:             {"AllLandings"},
+	fieldDayLandings:          {"DayLandingsFullStop"},
+	fieldNightLandings:        {"NightLandingsFullStop"},
+	fieldHoldings:             {"Holds"},
+	fieldRemarks:              {"PilotComments"},
+}
+
+var foreFlightCategories = map[string]model.AircraftCategory{
+	"airplane":         model.AircraftCategoryAirplane,
+	"rotorcraft":       model.AircraftCategoryRotorcraft,
+	"glider":           model.AircraftCategoryGlider,
+	"powered lift":     model.AircraftCategoryPoweredLift,
+	"lighter than air": model.AircraftCategoryLighterThanAir,
+}
+
+var foreFlightRoles = map[string]model.Role{
+	"pic":              model.RolePilotInCommand,
+	"sic":              model.RoleSecondInCommand,
+	"instructor":       model.RoleInstructor,
+	"student":          model.RoleDual,
+	"examiner":         model.RoleExaminer,
+	"flight attendant": model.RoleFlightAttendant,
+}
+
+type foreFlightAircraft struct {
+	model    string
+	category *model.AircraftCategory
+}
+
+type foreFlightFormat struct{}
+
+// newForeFlightFormat parses the CSV logbook export of ForeFlight, which consists of an aircraft table followed by
+// a flights table. Approaches and people of the flight are imported as landings and passengers.
+func newForeFlightFormat() Format {
+	return &foreFlightFormat{}
+}
+
+func (f *foreFlightFormat) Parse(reader io.Reader, options Options) ([]Row, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.LazyQuotes = true
+
+	aircraft := make(map[string]foreFlightAircraft)
+	rows := make([]Row, 0)
+
+	var table string
+	var header []string
+	var indexes, aircraftIndexes map[string][]int
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if isEmptyRecord(record) {
+			continue
+		}
+
+		if name := strings.ToLower(strings.TrimSpace(record[0])); name == foreFlightAircraftTable || name == foreFlightFlightsTable {
+			table = name
+			header = nil
+			continue
+		}
+		if table == "" {
+			// lines before the first table describe the export itself
+			continue
+		}
+		if header == nil {
+			header = record
+			indexes = columnIndexes(header, foreFlightAliases)
+			aircraftIndexes = columnIndexes(header, map[string][]string{
+				"AircraftID": {"AircraftID"}, "Make": {"Make"}, "Model": {"Model"}, "TypeCode": {"TypeCode"}, "Category": {"Category"},
+			})
+			continue
+		}
+
+		if table == foreFlightAircraftTable {
+			value := recordValue(record, aircraftIndexes)
+			parsed := foreFlightAircraft{model: strings.TrimSpace(value("Make") + " " + value("Model"))}
+			if parsed.model == "" {
+				parsed.model = strings.TrimSpace(value("TypeCode"))
+			}
+			if category, ok := foreFlightCategories[strings.ToLower(strings.TrimSpace(value("Category")))]; ok {
+				parsed.category = &category
+			}
+			aircraft[strings.ToUpper(strings.TrimSpace(value("AircraftID")))] = parsed
+			continue
+		}
+
+		line, _ := csvReader.FieldPos(0)
+		parser := rowParser{value: recordValue(record, indexes)}
+		row := parser.parse(line)
+		if row.Err == nil {
+			row.Err = addForeFlightDetails(&row.LogbookRequest, header, record)
+		}
+		if details, ok := aircraft[row.AircraftRegistration]; ok {
+			row.AircraftModel = details.model
+			row.AircraftCategory = details.category
+		}
+		rows = append(rows, row)
+	}
+
+	if table != foreFlightFlightsTable {
+		return nil, errors.New("missing flights table")
+	}
+
+	return rows, nil
+}
+
+// addForeFlightDetails adds approaches and people of the flight, approaches are stored as "count;type;runway;airport"
+// and people as "name;role;email".
+func addForeFlightDetails(request *dto.LogbookRequest, header, record []string) error {
+	aliases := make(map[string][]string)
+	for i := 1; i <= max(foreFlightApproaches, foreFlightPeople); i++ {
+		aliases[fmt.Sprintf("Approach%d", i)] = []string{fmt.Sprintf("Approach%d", i)}
+		aliases[fmt.Sprintf("Person%d", i)] = []string{fmt.Sprintf("Person%d", i)}
+	}
+	value := recordValue(record, columnIndexes(header, aliases))
+
+	var approachCount uint
+	for i := 1; i <= foreFlightApproaches; i++ {
+		approach := value(fmt.Sprintf("Approach%d", i))
+		if strings.TrimSpace(approach) == "" {
+			continue
+		}
+
+		parts := strings.Split(approach, ";")
+		count, err := strconv.ParseUint(strings.TrimSpace(parts[0]), 10, 32)
+		if err != nil || len(parts) < 2 {
+			return fmt.Errorf("invalid value in column Approach%d: %v", i, approach)
+		}
+		approachType, ok := parseApproachType(parts[1])
+		if !ok {
+			continue
+		}
+
+		landing := dto.LandingEntry{ApproachType: approachType, Count: uintPointer(uint(count))}
+		if len(parts) > 3 && strings.TrimSpace(parts[3]) != "" {
+			airportCode := strings.ToUpper(strings.TrimSpace(parts[3]))
+			landing.AirportCode = &airportCode
+		}
+		request.Landings = append(request.Landings, landing)
+		approachCount += uint(count)
+	}
+
+	// landings flown as an approach are already counted by the approach entries
+	if approachCount > 0 && len(request.Landings) > 0 && request.Landings[0].ApproachType == model.ApproachTypeVisual {
+		visual := uint(0)
+		if count := uintValue(request.Landings[0].Count); count > approachCount {
+			visual = count - approachCount
+		}
+		request.Landings[0].Count = &visual
+	}
+
+	for i := 1; i <= foreFlightPeople; i++ {
+		person := strings.Split(value(fmt.Sprintf("Person%d", i)), ";")
+		name := strings.TrimSpace(person[0])
+		if name == "" {
+			continue
+		}
+
+		passenger := dto.PassengerEntry{Role: model.RoleOther}
+		firstName, lastName, found := strings.Cut(name, " ")
+		passenger.FirstName = firstName
+		if found {
+			lastName = strings.TrimSpace(lastName)
+			passenger.LastName = &lastName
+		}
+		if len(person) > 1 {
+			if role, ok := foreFlightRoles[strings.ToLower(strings.TrimSpace(person[1]))]; ok {
+				passenger.Role = role
+			}
+		}
+		if len(person) > 2 && strings.TrimSpace(person[2]) != "" {
+			email := strings.TrimSpace(person[2])
+			passenger.EmailAddress = &email
+		}
+		request.Passengers = append(request.Passengers, passenger)
+	}
+
+	return nil
+}
+
+func parseApproachType(value string) (model.ApproachType, bool) {
+	value = strings.ToUpper(value)
+	for _, approachType := range model.InstrumentApproachTypes {
+		if strings.Contains(value, string(approachType)) {
+			return approachType, true
+		}
+	}
+	switch {
+	case strings.Contains(value, "GPS"), strings.Contains(value, "LPV"):
+		return model.ApproachTypeRNAV, true
+	case strings.Contains(value, "LDA"), strings.Contains(value, "SDF"):
+		return model.ApproachTypeLOC, true
+	}
+	return "", false
+}
+
+func uintPointer(value uint) *uint {
+	return &value
+}
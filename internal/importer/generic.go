@@ -0,0 +1,31 @@
+package importer
+
+import (
+	"fmt"
+	"io"
+	"slices"
+)
+
+type genericCSVFormat struct{}
+
+// newGenericCSVFormat parses comma separated files with a header row. Columns are matched using the mapping from
+// options, without mapping the column names have to be equal to the names of logbook fields.
+func newGenericCSVFormat() Format {
+	return &genericCSVFormat{}
+}
+
+func (g *genericCSVFormat) Parse(reader io.Reader, options Options) ([]Row, error) {
+	aliases := make(map[string][]string, len(fields))
+	for _, field := range fields {
+		aliases[field] = []string{field}
+	}
+
+	for field, column := range options.Mapping {
+		if !slices.Contains(fields, field) {
+			return nil, fmt.Errorf("unknown field in mapping: %v", field)
+		}
+		aliases[field] = []string{column}
+	}
+
+	return parseTable(reader, ',', aliases, false)
+}
@@ -0,0 +1,75 @@
+package importer
+
+import (
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"io"
+	"sort"
+)
+
+const (
+	FormatForeFlight  = "foreflight"
+	FormatLogTen      = "logten"
+	FormatMccPilotLog = "mccpilotlog"
+	FormatGenericCSV  = "csv"
+)
+
+// Format parses a logbook exported from another application. Errors returned from Parse concern the whole file,
+// problems with a single row are reported in Row.Err so the remaining rows can still be imported.
+type Format interface {
+	Parse(reader io.Reader, options Options) ([]Row, error)
+}
+
+type Options struct {
+	// Mapping maps logbook fields (the column names of the CSV export) to column names of the imported file. It is
+	// used by the generic CSV format only.
+	Mapping map[string]string
+}
+
+type Row struct {
+	Line                 int
+	AircraftRegistration string
+	AircraftModel        string
+	AircraftCategory     *model.AircraftCategory
+	LogbookRequest       dto.LogbookRequest
+	Err                  error
+}
+
+//go:generate mockgen -source=importer.go -destination=importer_mock.go -package importer
+type Registry interface {
+	Register(name string, format Format)
+	Get(name string) (Format, bool)
+	Names() []string
+}
+
+type registry struct {
+	formats map[string]Format
+}
+
+// NewRegistry returns a registry with all built-in formats registered.
+func NewRegistry() Registry {
+	r := &registry{formats: make(map[string]Format)}
+	r.Register(FormatForeFlight, newForeFlightFormat())
+	r.Register(FormatLogTen, newLogTenFormat())
+	r.Register(FormatMccPilotLog, newMccPilotLogFormat())
+	r.Register(FormatGenericCSV, newGenericCSVFormat())
+	return r
+}
+
+func (r *registry) Register(name string, format Format) {
+	r.formats[name] = format
+}
+
+func (r *registry) Get(name string) (Format, bool) {
+	format, ok := r.formats[name]
+	return format, ok
+}
+
+func (r *registry) Names() []string {
+	names := make([]string, 0, len(r.formats))
+	for name := range r.formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
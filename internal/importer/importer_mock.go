@@ -0,0 +1,119 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: importer.go
+//
+// Generated by this command:
+//
+//	mockgen -source=importer.go -destination=importer_mock.go -package importer
+//
+
+// Package importer is a generated GoMock package.
+package importer
+
+import (
+	io "io"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFormat is a mock of Format interface.
+type MockFormat struct {
+	ctrl     *gomock.Controller
+	recorder *MockFormatMockRecorder
+}
+
+// MockFormatMockRecorder is the mock recorder for MockFormat.
+type MockFormatMockRecorder struct {
+	mock *MockFormat
+}
+
+// NewMockFormat creates a new mock instance.
+func NewMockFormat(ctrl *gomock.Controller) *MockFormat {
+	mock := &MockFormat{ctrl: ctrl}
+	mock.recorder = &MockFormatMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFormat) EXPECT() *MockFormatMockRecorder {
+	return m.recorder
+}
+
+// Parse mocks base method.
+func (m *MockFormat) Parse(reader io.Reader, options Options) ([]Row, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Parse", reader, options)
+	ret0, _ := ret[0].([]Row)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Parse indicates an expected call of Parse.
+func (mr *MockFormatMockRecorder) Parse(reader, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Parse", reflect.TypeOf((*MockFormat)(nil).Parse), reader, options)
+}
+
+// MockRegistry is a mock of Registry interface.
+type MockRegistry struct {
+	ctrl     *gomock.Controller
+	recorder *MockRegistryMockRecorder
+}
+
+// MockRegistryMockRecorder is the mock recorder for MockRegistry.
+type MockRegistryMockRecorder struct {
+	mock *MockRegistry
+}
+
+// NewMockRegistry creates a new mock instance.
+func NewMockRegistry(ctrl *gomock.Controller) *MockRegistry {
+	mock := &MockRegistry{ctrl: ctrl}
+	mock.recorder = &MockRegistryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRegistry) EXPECT() *MockRegistryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockRegistry) Get(name string) (Format, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", name)
+	ret0, _ := ret[0].(Format)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockRegistryMockRecorder) Get(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRegistry)(nil).Get), name)
+}
+
+// Names mocks base method.
+func (m *MockRegistry) Names() []string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Names")
+	ret0, _ := ret[0].([]string)
+	return ret0
+}
+
+// Names indicates an expected call of Names.
+func (mr *MockRegistryMockRecorder) Names() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Names", reflect.TypeOf((*MockRegistry)(nil).Names))
+}
+
+// Register mocks base method.
+func (m *MockRegistry) Register(name string, format Format) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Register", name, format)
+}
+
+// Register indicates an expected call of Register.
+func (mr *MockRegistryMockRecorder) Register(name, format any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Register", reflect.TypeOf((*MockRegistry)(nil).Register), name, format)
+}
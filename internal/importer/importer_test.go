@@ -0,0 +1,237 @@
+package importer
+
+import (
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"strings"
+	"time"
+)
+
+var _ = Describe("Importer", func() {
+	var registry Registry
+
+	BeforeEach(func() {
+		registry = NewRegistry()
+	})
+
+	Describe("Registry", func() {
+		Context("when built-in formats are registered", func() {
+			It("should return all of them", func() {
+				// when
+				names := registry.Names()
+
+				// then
+				Expect(names).To(Equal([]string{FormatGenericCSV, FormatForeFlight, FormatLogTen, FormatMccPilotLog}))
+			})
+		})
+		Context("when format is not registered", func() {
+			It("should not be found", func() {
+				// when
+				_, ok := registry.Get("unknown")
+
+				// then
+				Expect(ok).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("ForeFlight", func() {
+		Context("when file contains aircraft and flights tables", func() {
+			It("should parse flights with approaches and people", func() {
+				// given
+				file := "ForeFlight Logbook Import,This row is required for importing into ForeFlight. Do not delete or modify.\n" +
+					"\n" +
+					"Aircraft Table,,,,,,\n" +
+					"AircraftID,TypeCode,Year,Make,Model,Category,Class\n" +
+					"N12345,C172,1998,Cessna,172S,airplane,airplane_single_engine_land\n" +
+					"\n" +
+					"Flights Table,,,,,,,,,,,,,,,,,,,,,,,,,\n" +
+					"Date,AircraftID,From,To,Route,TimeOut,TimeOff,TimeOn,TimeIn,TotalTime,PIC,SIC,Night,CrossCountry,DayLandingsFullStop,NightLandingsFullStop,AllLandings,ActualInstrument,SimulatedInstrument,Holds,Approach1,Approach2,DualGiven,DualReceived,SimulatedFlight,Person1,PilotComments\n" +
+					"2024-03-01,n12345,KSFO,KLAX,,23:30,,,01:00,1.5,1.5,,0.5,1.5,2,1,3,0.3,0.2,1,1;ILS OR LOC RWY 25L;25L;KLAX;,,,,,John Doe;Instructor;john@doe.com,Night flight\n"
+
+				format, _ := registry.Get(FormatForeFlight)
+
+				// when
+				rows, err := format.Parse(strings.NewReader(file), Options{})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(rows).To(HaveLen(1))
+				Expect(rows[0].Err).To(BeNil())
+				Expect(rows[0].Line).To(Equal(9))
+				Expect(rows[0].AircraftRegistration).To(Equal("N12345"))
+				Expect(rows[0].AircraftModel).To(Equal("Cessna 172S"))
+				Expect(*rows[0].AircraftCategory).To(Equal(model.AircraftCategoryAirplane))
+				Expect(rows[0].LogbookRequest).To(Equal(dto.LogbookRequest{
+					TakeoffTime:        time.Date(2024, 3, 1, 23, 30, 0, 0, time.UTC),
+					TakeoffAirportCode: "KSFO",
+					LandingTime:        time.Date(2024, 3, 2, 1, 0, 0, 0, time.UTC),
+					LandingAirportCode: "KLAX",
+					Style:              model.StyleIFR,
+					MyRole:             model.RolePilotInCommand,
+					Remarks:            util.String("Night flight"),
+					TotalBlockTime:     util.Duration(90 * time.Minute),
+					PilotInCommandTime: util.Duration(90 * time.Minute),
+					NightTime:          util.Duration(30 * time.Minute),
+					IFRTime:            util.Duration(30 * time.Minute),
+					IFRActualTime:      util.Duration(18 * time.Minute),
+					IFRSimulatedTime:   util.Duration(12 * time.Minute),
+					CrossCountryTime:   util.Duration(90 * time.Minute),
+					Holdings:           util.Uint(1),
+					Passengers: []dto.PassengerEntry{
+						{Role: model.RoleInstructor, FirstName: "John", LastName: util.String("Doe"), EmailAddress: util.String("john@doe.com")},
+					},
+					Landings: []dto.LandingEntry{
+						{ApproachType: model.ApproachTypeVisual, Count: util.Uint(2), DayCount: util.Uint(2), NightCount: util.Uint(1), AirportCode: util.String("KLAX")},
+						{ApproachType: model.ApproachTypeILS, Count: util.Uint(1), AirportCode: util.String("KLAX")},
+					},
+				}))
+			})
+		})
+		Context("when flights table is missing", func() {
+			It("should return error", func() {
+				// given
+				format, _ := registry.Get(FormatForeFlight)
+
+				// when
+				rows, err := format.Parse(strings.NewReader("Date,AircraftID\n2024-03-01,N12345\n"), Options{})
+
+				// then
+				Expect(err.Error()).To(Equal("missing flights table"))
+				Expect(rows).To(BeNil())
+			})
+		})
+	})
+
+	Describe("LogTen", func() {
+		Context("when file is tab separated", func() {
+			It("should parse flights", func() {
+				// given
+				file := "flight_flightDate\taircraft_aircraftID\taircraftType_type\tflight_from\tflight_to\tflight_takeoffTime\tflight_landingTime\tflight_totalTime\tflight_sic\tflight_multiPilot\tflight_dayLandings\tflight_remarks\n" +
+					"2024-03-01\tSP-LRA\tB738\tEPWA\tEGLL\t06:00\t08:30\t2:30\t2:30\t2:30\t1\tLine flight\n"
+				format, _ := registry.Get(FormatLogTen)
+
+				// when
+				rows, err := format.Parse(strings.NewReader(file), Options{})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(rows).To(HaveLen(1))
+				Expect(rows[0].Err).To(BeNil())
+				Expect(rows[0].AircraftRegistration).To(Equal("SP-LRA"))
+				Expect(rows[0].AircraftModel).To(Equal("B738"))
+				Expect(rows[0].LogbookRequest.TakeoffTime).To(Equal(time.Date(2024, 3, 1, 6, 0, 0, 0, time.UTC)))
+				Expect(rows[0].LogbookRequest.LandingTime).To(Equal(time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)))
+				Expect(*rows[0].LogbookRequest.TotalBlockTime).To(Equal(150 * time.Minute))
+				Expect(rows[0].LogbookRequest.MyRole).To(Equal(model.RoleSecondInCommand))
+				Expect(rows[0].LogbookRequest.Style).To(Equal(model.StyleVFR))
+				Expect(rows[0].LogbookRequest.Landings).To(Equal([]dto.LandingEntry{
+					{ApproachType: model.ApproachTypeVisual, Count: util.Uint(1), DayCount: util.Uint(1), AirportCode: util.String("EGLL")},
+				}))
+			})
+		})
+	})
+
+	Describe("mccPilotLog", func() {
+		Context("when durations are stored as minutes", func() {
+			It("should parse flights", func() {
+				// given
+				file := "mcc_DATE,AC_REG,AC_MODEL,AF_DEP,AF_ARR,TIME_DEP,TIME_ARR,TIME_TOTAL,TIME_PIC,TIME_NIGHT,LDG_DAY,LDG_NIGHT\n" +
+					"2024-03-01,SP-ABC,C152,EPKK,EPKT,1200,1315,75,75,0,3,0\n" +
+					"2024-03-02,SP-ABC,C152,EPKK,EPKT,1200,1315,abc,75,0,3,0\n"
+				format, _ := registry.Get(FormatMccPilotLog)
+
+				// when
+				rows, err := format.Parse(strings.NewReader(file), Options{})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(rows).To(HaveLen(2))
+				Expect(rows[0].Err).To(BeNil())
+				Expect(*rows[0].LogbookRequest.TotalBlockTime).To(Equal(75 * time.Minute))
+				Expect(*rows[0].LogbookRequest.NightTime).To(Equal(time.Duration(0)))
+				Expect(rows[0].LogbookRequest.MyRole).To(Equal(model.RolePilotInCommand))
+				Expect(*rows[0].LogbookRequest.Landings[0].Count).To(Equal(uint(3)))
+				Expect(rows[1].Line).To(Equal(3))
+				Expect(rows[1].Err.Error()).To(Equal("invalid value in column total_block_time: abc"))
+			})
+		})
+	})
+
+	Describe("Generic CSV", func() {
+		Context("when mapping is provided", func() {
+			It("should parse flights using mapped columns", func() {
+				// given
+				file := "Flight Date,Reg,Type,Dep,Arr,Block,Role\n" +
+					"01/03/2024,SP-ABC,C152,EPKK,EPKT,1:15,pic\n"
+				format, _ := registry.Get(FormatGenericCSV)
+
+				// when
+				rows, err := format.Parse(strings.NewReader(file), Options{Mapping: map[string]string{
+					"date":                  "Flight Date",
+					"aircraft_registration": "Reg",
+					"aircraft_model":        "Type",
+					"takeoff_airport":       "Dep",
+					"landing_airport":       "Arr",
+					"total_block_time":      "Block",
+					"my_role":               "Role",
+				}})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(rows).To(HaveLen(1))
+				Expect(rows[0].Err).To(BeNil())
+				Expect(rows[0].LogbookRequest.TakeoffTime).To(Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)))
+				Expect(rows[0].LogbookRequest.LandingTime).To(Equal(time.Date(2024, 3, 1, 1, 15, 0, 0, time.UTC)))
+				Expect(rows[0].LogbookRequest.MyRole).To(Equal(model.RolePilotInCommand))
+			})
+		})
+		Context("when file is the CSV export of the logbook", func() {
+			It("should parse flights without mapping", func() {
+				// given
+				file := "date,takeoff_airport,takeoff_time,landing_airport,landing_time,aircraft_registration,aircraft_model,style,my_role,total_block_time,total_block_time_decimal\n" +
+					"2024-03-25,EPWA,10:00,EPKK,11:15,SP-ABC,Cessna 172,Y,PIC,1:15,1.25\n"
+				format, _ := registry.Get(FormatGenericCSV)
+
+				// when
+				rows, err := format.Parse(strings.NewReader(file), Options{})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(rows).To(HaveLen(1))
+				Expect(rows[0].Err).To(BeNil())
+				Expect(rows[0].LogbookRequest.Style).To(Equal(model.StyleY))
+				Expect(*rows[0].LogbookRequest.TotalBlockTime).To(Equal(75 * time.Minute))
+			})
+		})
+		Context("when mapping contains unknown field", func() {
+			It("should return error", func() {
+				// given
+				format, _ := registry.Get(FormatGenericCSV)
+
+				// when
+				rows, err := format.Parse(strings.NewReader("date\n"), Options{Mapping: map[string]string{"color": "Color"}})
+
+				// then
+				Expect(err.Error()).To(Equal("unknown field in mapping: color"))
+				Expect(rows).To(BeNil())
+			})
+		})
+		Context("when date column is missing", func() {
+			It("should return error", func() {
+				// given
+				format, _ := registry.Get(FormatGenericCSV)
+
+				// when
+				rows, err := format.Parse(strings.NewReader("takeoff_airport\nEPWA\n"), Options{})
+
+				// then
+				Expect(err.Error()).To(Equal("missing column for field: date"))
+				Expect(rows).To(BeNil())
+			})
+		})
+	})
+})
@@ -0,0 +1,33 @@
+package importer
+
+// newLogTenFormat parses tab separated exports of LogTen Pro. Both the raw field names and the column titles of
+// the default export template are recognised.
+func newLogTenFormat() Format {
+	return &tableFormat{
+		comma: '\t',
+		aliases: map[string][]string{
+			fieldDate:                 {"flight_flightDate", "Date"},
+			fieldAircraftRegistration: {"aircraft_aircraftID", "Aircraft ID"},
+			fieldAircraftModel:        {"aircraftType_type", "aircraftType_model", "Aircraft Type", "Type"},
+			fieldTakeoffAirport:       {"flight_from", "From"},
+			fieldLandingAirport:       {"flight_to", "To"},
+			fieldTakeoffTime:          {"flight_actualDepartureTime", "flight_takeoffTime", "Out", "Takeoff"},
+			fieldLandingTime:          {"flight_actualArrivalTime", "flight_landingTime", "In", "Landing"},
+			fieldTotalBlockTime:       {"flight_totalTime", "Total Time"},
+			fieldPilotInCommandTime:   {"flight_pic", "PIC"},
+			fieldSecondInCommandTime:  {"flight_sic", "SIC"},
+			fieldDualReceivedTime:     {"flight_dualReceived", "Dual Received"},
+			fieldDualGivenTime:        {"flight_dualGiven", "Dual Given"},
+			fieldMultiPilotTime:       {"flight_multiPilot", "Multi-Pilot"},
+			fieldNightTime:            {"flight_night", "Night"},
+			fieldIFRActualTime:        {"flight_actualInstrument", "Actual Instrument"},
+			fieldIFRSimulatedTime:     {"flight_simulatedInstrument", "Simulated Instrument"},
+			fieldCrossCountryTime:     {"flight_crossCountry", "Cross Country"},
+			fieldSimulatorTime:        {"flight_simulator", "Simulator"},
+			fieldDayLandings:          {"flight_dayLandings", "Day Landings"},
+			fieldNightLandings:        {"flight_nightLandings", "Night Landings"},
+			fieldHoldings:             {"flight_holds", "Holds"},
+			fieldRemarks:              {"flight_remarks", "Remarks"},
+		},
+	}
+}
@@ -0,0 +1,34 @@
+package importer
+
+// newMccPilotLogFormat parses CSV exports of mccPilotLog, which store durations as minutes.
+func newMccPilotLogFormat() Format {
+	return &tableFormat{
+		comma: ',',
+		aliases: map[string][]string{
+			fieldDate:                 {"mcc_DATE", "DATE"},
+			fieldAircraftRegistration: {"AC_REG"},
+			fieldAircraftModel:        {"AC_MODEL"},
+			fieldTakeoffAirport:       {"AF_DEP"},
+			fieldLandingAirport:       {"AF_ARR"},
+			fieldTakeoffTime:          {"TIME_DEP"},
+			fieldLandingTime:          {"TIME_ARR"},
+			fieldTotalBlockTime:       {"TIME_TOTAL"},
+			fieldPilotInCommandTime:   {"TIME_PIC"},
+			fieldSecondInCommandTime:  {"TIME_SIC", "TIME_COPILOT"},
+			fieldDualReceivedTime:     {"TIME_DUAL"},
+			fieldDualGivenTime:        {"TIME_INSTRUCTOR"},
+			fieldMultiPilotTime:       {"TIME_MP"},
+			fieldNightTime:            {"TIME_NIGHT"},
+			fieldIFRTime:              {"TIME_IFR"},
+			fieldIFRActualTime:        {"TIME_ACTUAL"},
+			fieldIFRSimulatedTime:     {"TIME_HOOD"},
+			fieldCrossCountryTime:     {"TIME_XC"},
+			fieldSimulatorTime:        {"TIME_SIM"},
+			fieldDayLandings:          {"LDG_DAY"},
+			fieldNightLandings:        {"LDG_NIGHT"},
+			fieldHoldings:             {"HOLDING"},
+			fieldRemarks:              {"REMARKS"},
+		},
+		integerMinutes: true,
+	}
+}
@@ -0,0 +1,351 @@
+package importer
+
+import (
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Logbook fields that can be read from imported files, the names match the columns of the CSV export so the export
+// can be imported back with the generic CSV format without any mapping.
+const (
+	fieldDate                 = "date"
+	fieldTakeoffAirport       = "takeoff_airport"
+	fieldTakeoffTime          = "takeoff_time"
+	fieldLandingAirport       = "landing_airport"
+	fieldLandingTime          = "landing_time"
+	fieldAircraftRegistration = "aircraft_registration"
+	fieldAircraftModel        = "aircraft_model"
+	fieldStyle                = "style"
+	fieldMyRole               = "my_role"
+	fieldTotalBlockTime       = "total_block_time"
+	fieldPilotInCommandTime   = "pilot_in_command_time"
+	fieldSecondInCommandTime  = "second_in_command_time"
+	fieldDualReceivedTime     = "dual_received_time"
+	fieldDualGivenTime        = "dual_given_time"
+	fieldMultiPilotTime       = "multi_pilot_time"
+	fieldNightTime            = "night_time"
+	fieldIFRTime              = "ifr_time"
+	fieldIFRActualTime        = "ifr_actual_time"
+	fieldIFRSimulatedTime     = "ifr_simulated_time"
+	fieldCrossCountryTime     = "cross_country_time"
+	fieldSimulatorTime        = "simulator_time"
+	fieldHoldings             = "holdings"
+	fieldLandings             = "landings"
+	fieldDayLandings          = "day_landings"
+	fieldNightLandings        = "night_landings"
+	fieldRemarks              = "remarks"
+	fieldPersonalRemarks      = "personal_remarks"
+)
+
+var fields = []string{
+	fieldDate,
+	fieldTakeoffAirport,
+	fieldTakeoffTime,
+	fieldLandingAirport,
+	fieldLandingTime,
+	fieldAircraftRegistration,
+	fieldAircraftModel,
+	fieldStyle,
+	fieldMyRole,
+	fieldTotalBlockTime,
+	fieldPilotInCommandTime,
+	fieldSecondInCommandTime,
+	fieldDualReceivedTime,
+	fieldDualGivenTime,
+	fieldMultiPilotTime,
+	fieldNightTime,
+	fieldIFRTime,
+	fieldIFRActualTime,
+	fieldIFRSimulatedTime,
+	fieldCrossCountryTime,
+	fieldSimulatorTime,
+	fieldHoldings,
+	fieldLandings,
+	fieldDayLandings,
+	fieldNightLandings,
+	fieldRemarks,
+	fieldPersonalRemarks,
+}
+
+var dateLayouts = []string{"2006-01-02", "2006/01/02", "02.01.2006", "02/01/2006", "Jan 2, 2006", "2 Jan 2006"}
+
+var timeLayouts = []string{"15:04", "1504", "15:04:05"}
+
+// rowParser converts textual values of a single record into a logbook request. The first parsing error is kept and
+// later reported for the whole row.
+type rowParser struct {
+	value func(field string) string
+	// integerMinutes tells whether durations without a separator are minutes (mccPilotLog) or hours
+	integerMinutes bool
+	err            error
+}
+
+func (p *rowParser) string(field string) string {
+	return strings.TrimSpace(p.value(field))
+}
+
+func (p *rowParser) optionalString(field string) *string {
+	value := p.string(field)
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+func (p *rowParser) duration(field string) *time.Duration {
+	value := p.string(field)
+	if value == "" || p.err != nil {
+		return nil
+	}
+
+	duration, err := parseDuration(value, p.integerMinutes)
+	if err != nil {
+		p.err = fmt.Errorf("invalid value in column %s: %v", field, value)
+		return nil
+	}
+	return &duration
+}
+
+func (p *rowParser) count(field string) *uint {
+	value := p.string(field)
+	if value == "" || p.err != nil {
+		return nil
+	}
+
+	count, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		p.err = fmt.Errorf("invalid value in column %s: %v", field, value)
+		return nil
+	}
+	result := uint(count)
+	return &result
+}
+
+func (p *rowParser) date(field string) time.Time {
+	value := p.string(field)
+	if p.err != nil {
+		return time.Time{}
+	}
+	if value == "" {
+		p.err = fmt.Errorf("missing value in column %s", field)
+		return time.Time{}
+	}
+
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date
+		}
+	}
+	p.err = fmt.Errorf("invalid value in column %s: %v", field, value)
+	return time.Time{}
+}
+
+func (p *rowParser) clock(field string) *time.Duration {
+	value := p.string(field)
+	if value == "" || p.err != nil {
+		return nil
+	}
+
+	for _, layout := range timeLayouts {
+		if clock, err := time.Parse(layout, value); err == nil {
+			sinceMidnight := clock.Sub(time.Date(0, 1, 1, 0, 0, 0, 0, time.UTC))
+			return &sinceMidnight
+		}
+	}
+	p.err = fmt.Errorf("invalid value in column %s: %v", field, value)
+	return nil
+}
+
+// parse builds a row from the values of the record, times are interpreted as UTC.
+func (p *rowParser) parse(line int) Row {
+	row := Row{
+		Line:                 line,
+		AircraftRegistration: strings.ToUpper(p.string(fieldAircraftRegistration)),
+		AircraftModel:        p.string(fieldAircraftModel),
+	}
+
+	date := p.date(fieldDate)
+	takeoffClock := p.clock(fieldTakeoffTime)
+	landingClock := p.clock(fieldLandingTime)
+
+	request := dto.LogbookRequest{
+		TakeoffAirportCode:  strings.ToUpper(p.string(fieldTakeoffAirport)),
+		LandingAirportCode:  strings.ToUpper(p.string(fieldLandingAirport)),
+		Style:               model.Style(strings.ToUpper(p.string(fieldStyle))),
+		MyRole:              model.Role(strings.ToUpper(p.string(fieldMyRole))),
+		Remarks:             p.optionalString(fieldRemarks),
+		PersonalRemarks:     p.optionalString(fieldPersonalRemarks),
+		TotalBlockTime:      p.duration(fieldTotalBlockTime),
+		PilotInCommandTime:  p.duration(fieldPilotInCommandTime),
+		SecondInCommandTime: p.duration(fieldSecondInCommandTime),
+		DualReceivedTime:    p.duration(fieldDualReceivedTime),
+		DualGivenTime:       p.duration(fieldDualGivenTime),
+		MultiPilotTime:      p.duration(fieldMultiPilotTime),
+		NightTime:           p.duration(fieldNightTime),
+		IFRTime:             p.duration(fieldIFRTime),
+		IFRActualTime:       p.duration(fieldIFRActualTime),
+		IFRSimulatedTime:    p.duration(fieldIFRSimulatedTime),
+		CrossCountryTime:    p.duration(fieldCrossCountryTime),
+		SimulatorTime:       p.duration(fieldSimulatorTime),
+		Holdings:            p.count(fieldHoldings),
+	}
+	landings := p.count(fieldLandings)
+	dayLandings := p.count(fieldDayLandings)
+	nightLandings := p.count(fieldNightLandings)
+
+	if p.err != nil {
+		row.Err = p.err
+		return row
+	}
+
+	request.TakeoffTime = date
+	if takeoffClock != nil {
+		request.TakeoffTime = date.Add(*takeoffClock)
+	}
+	request.LandingTime = request.TakeoffTime
+	if landingClock != nil {
+		request.LandingTime = date.Add(*landingClock)
+		if request.LandingTime.Before(request.TakeoffTime) {
+			request.LandingTime = request.LandingTime.AddDate(0, 0, 1)
+		}
+	} else if request.TotalBlockTime != nil {
+		request.LandingTime = request.TakeoffTime.Add(*request.TotalBlockTime)
+	}
+
+	if request.IFRTime == nil && (request.IFRActualTime != nil || request.IFRSimulatedTime != nil) {
+		ifrTime := durationValue(request.IFRActualTime) + durationValue(request.IFRSimulatedTime)
+		request.IFRTime = &ifrTime
+	}
+	if request.Style == "" {
+		request.Style = inferStyle(request)
+	}
+	if request.MyRole == "" {
+		request.MyRole = inferRole(request)
+	}
+
+	if landings == nil && (dayLandings != nil || nightLandings != nil) {
+		total := uintValue(dayLandings) + uintValue(nightLandings)
+		landings = &total
+	}
+	if uintValue(landings) > 0 || uintValue(dayLandings) > 0 || uintValue(nightLandings) > 0 {
+		airportCode := request.LandingAirportCode
+		request.Landings = append(request.Landings, dto.LandingEntry{
+			ApproachType: model.ApproachTypeVisual,
+			Count:        landings,
+			DayCount:     dayLandings,
+			NightCount:   nightLandings,
+			AirportCode:  &airportCode,
+		})
+	}
+
+	row.LogbookRequest = request
+	return row
+}
+
+func inferStyle(request dto.LogbookRequest) model.Style {
+	if durationValue(request.IFRTime) > 0 {
+		return model.StyleIFR
+	}
+	return model.StyleVFR
+}
+
+func inferRole(request dto.LogbookRequest) model.Role {
+	switch {
+	case durationValue(request.DualGivenTime) > 0:
+		return model.RoleInstructor
+	case durationValue(request.PilotInCommandTime) > 0:
+		return model.RolePilotInCommand
+	case durationValue(request.SecondInCommandTime) > 0:
+		return model.RoleSecondInCommand
+	case durationValue(request.DualReceivedTime) > 0:
+		return model.RoleDual
+	default:
+		return model.RoleOther
+	}
+}
+
+// parseDuration accepts H:MM and decimal hours. Integer values are hours unless integerMinutes is set.
+func parseDuration(value string, integerMinutes bool) (time.Duration, error) {
+	if hours, minutes, found := strings.Cut(value, ":"); found {
+		h, err := strconv.ParseUint(hours, 10, 32)
+		if err != nil {
+			return 0, err
+		}
+		m, err := strconv.ParseUint(minutes, 10, 32)
+		if err != nil || m >= 60 {
+			return 0, fmt.Errorf("invalid minutes: %v", minutes)
+		}
+		return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute, nil
+	}
+
+	if integerMinutes && !strings.ContainsAny(value, ".,") {
+		minutes, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return 0, err
+		}
+		return time.Duration(minutes) * time.Minute, nil
+	}
+
+	hours, err := strconv.ParseFloat(strings.Replace(value, ",", ".", 1), 64)
+	if err != nil || hours < 0 {
+		return 0, fmt.Errorf("invalid hours: %v", value)
+	}
+	return (time.Duration(hours * float64(time.Hour))).Round(time.Minute), nil
+}
+
+// columnIndexes returns positions of the header columns of every field. aliases lists header names used for each
+// field in the order of preference, names are compared case-insensitively.
+func columnIndexes(header []string, aliases map[string][]string) map[string][]int {
+	positions := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := positions[name]; !ok {
+			positions[name] = i
+		}
+	}
+
+	indexes := make(map[string][]int)
+	for field, names := range aliases {
+		for _, name := range names {
+			if i, ok := positions[strings.ToLower(name)]; ok {
+				indexes[field] = append(indexes[field], i)
+			}
+		}
+	}
+	return indexes
+}
+
+// recordValue returns a function reading the first non-empty column of a field from the record.
+func recordValue(record []string, indexes map[string][]int) func(field string) string {
+	return func(field string) string {
+		for _, i := range indexes[field] {
+			if i < len(record) && strings.TrimSpace(record[i]) != "" {
+				return record[i]
+			}
+		}
+		return ""
+	}
+}
+
+func isEmptyRecord(record []string) bool {
+	return !slices.ContainsFunc(record, func(value string) bool { return strings.TrimSpace(value) != "" })
+}
+
+func durationValue(duration *time.Duration) time.Duration {
+	if duration == nil {
+		return 0
+	}
+	return *duration
+}
+
+func uintValue(value *uint) uint {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
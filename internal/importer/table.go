@@ -0,0 +1,59 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// tableFormat parses delimited files with a header row, which is how most logbook applications export data.
+type tableFormat struct {
+	comma          rune
+	aliases        map[string][]string
+	integerMinutes bool
+}
+
+func (t *tableFormat) Parse(reader io.Reader, options Options) ([]Row, error) {
+	return parseTable(reader, t.comma, t.aliases, t.integerMinutes)
+}
+
+func parseTable(reader io.Reader, comma rune, aliases map[string][]string, integerMinutes bool) ([]Row, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.Comma = comma
+	csvReader.FieldsPerRecord = -1
+	csvReader.LazyQuotes = true
+
+	header, err := csvReader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("file is empty")
+		}
+		return nil, err
+	}
+
+	indexes := columnIndexes(header, aliases)
+	if _, ok := indexes[fieldDate]; !ok {
+		return nil, fmt.Errorf("missing column for field: %v", fieldDate)
+	}
+
+	rows := make([]Row, 0)
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if isEmptyRecord(record) {
+			continue
+		}
+
+		line, _ := csvReader.FieldPos(0)
+		parser := rowParser{value: recordValue(record, indexes), integerMinutes: integerMinutes}
+		rows = append(rows, parser.parse(line))
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,8 @@
+package infrastructure
+
+import "net/http"
+
+//go:generate mockgen -source=http_client.go -destination=http_client_mock.go -package infrastructure
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
@@ -0,0 +1,11 @@
+package infrastructure
+
+import (
+	"context"
+	"firebase.google.com/go/v4/messaging"
+)
+
+//go:generate mockgen -source=messaging_client.go -destination=messaging_client_mock.go -package infrastructure
+type MessagingClient interface {
+	Send(ctx context.Context, message *messaging.Message) (string, error)
+}
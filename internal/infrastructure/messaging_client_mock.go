@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: messaging_client.go
+//
+// Generated by this command:
+//
+//	mockgen -source=messaging_client.go -destination=messaging_client_mock.go -package infrastructure
+//
+
+// Package infrastructure is a generated GoMock package.
+package infrastructure
+
+import (
+	context "context"
+	reflect "reflect"
+
+	messaging "firebase.google.com/go/v4/messaging"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMessagingClient is a mock of MessagingClient interface.
+type MockMessagingClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessagingClientMockRecorder
+}
+
+// MockMessagingClientMockRecorder is the mock recorder for MockMessagingClient.
+type MockMessagingClientMockRecorder struct {
+	mock *MockMessagingClient
+}
+
+// NewMockMessagingClient creates a new mock instance.
+func NewMockMessagingClient(ctrl *gomock.Controller) *MockMessagingClient {
+	mock := &MockMessagingClient{ctrl: ctrl}
+	mock.recorder = &MockMessagingClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessagingClient) EXPECT() *MockMessagingClientMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockMessagingClient) Send(ctx context.Context, message *messaging.Message) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, message)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockMessagingClientMockRecorder) Send(ctx, message any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockMessagingClient)(nil).Send), ctx, message)
+}
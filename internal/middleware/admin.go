@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"github.com/avialog/backend/internal/common"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/util"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// RequireAdmin lets only the given users through, it runs after AuthJWT which identifies the user. Without any admins
+// configured every request is refused.
+func RequireAdmin(adminUserIDs []string) gin.HandlerFunc {
+	admins := make(map[string]struct{}, len(adminUserIDs))
+	for _, userID := range adminUserIDs {
+		admins[userID] = struct{}{}
+	}
+
+	return func(c *gin.Context) {
+		if _, ok := admins[c.GetString(common.UserID)]; !ok {
+			util.NewError(c, http.StatusForbidden, dto.ErrForbidden)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"github.com/avialog/backend/internal/common"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("RequireAdmin Middleware", func() {
+	var (
+		w   *httptest.ResponseRecorder
+		ctx *gin.Context
+	)
+
+	BeforeEach(func() {
+		w = httptest.NewRecorder()
+		ctx, _ = gin.CreateTestContext(w)
+		ctx.Request, _ = http.NewRequest(http.MethodGet, "/", nil)
+	})
+
+	Context("when the user is an admin", func() {
+		It("should pass the request to the next handler", func() {
+			// given
+			ctx.Set(common.UserID, "admin")
+
+			// when
+			RequireAdmin([]string{"other", "admin"})(ctx)
+
+			// then
+			Expect(ctx.IsAborted()).To(BeFalse())
+			Expect(w.Code).To(Equal(http.StatusOK))
+		})
+	})
+
+	Context("when the user is not an admin", func() {
+		It("should return forbidden", func() {
+			// given
+			ctx.Set(common.UserID, "user")
+
+			// when
+			RequireAdmin([]string{"admin"})(ctx)
+
+			// then
+			Expect(ctx.IsAborted()).To(BeTrue())
+			Expect(w.Code).To(Equal(http.StatusForbidden))
+			Expect(w.Body.String()).To(ContainSubstring("forbidden"))
+		})
+	})
+
+	Context("when no admins are configured", func() {
+		It("should return forbidden", func() {
+			// given
+			ctx.Set(common.UserID, "")
+
+			// when
+			RequireAdmin(nil)(ctx)
+
+			// then
+			Expect(ctx.IsAborted()).To(BeTrue())
+			Expect(w.Code).To(Equal(http.StatusForbidden))
+		})
+	})
+})
@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/avialog/backend/internal/common"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/service"
+	"github.com/avialog/backend/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+)
+
+const (
+	idempotencyKeyHeader      = "Idempotency-Key"
+	idempotentReplayedHeader  = "Idempotent-Replayed"
+	contentTypeResponseHeader = "Content-Type"
+)
+
+// Idempotency executes a POST, PUT or DELETE request sent with an Idempotency-Key header once per user and key. A
+// retried request gets the stored response of the first one, unless the first one failed with a server error. Requests
+// without the header are executed as they are.
+func Idempotency(idempotencyService service.IdempotencyService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(idempotencyKeyHeader)
+		if key == "" || !isMutatingMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		requestHash, err := hashRequest(c.Request)
+		if err != nil {
+			util.NewError(c, http.StatusBadRequest, err)
+			c.Abort()
+			return
+		}
+
+		userID := c.GetString(common.UserID)
+		storedResponse, lockToken, err := idempotencyService.Acquire(userID, key, requestHash)
+		if err != nil {
+			if errors.Is(err, dto.ErrBadRequest) {
+				util.NewError(c, http.StatusBadRequest, err)
+			} else if errors.Is(err, dto.ErrConflict) {
+				util.NewError(c, http.StatusConflict, err)
+			} else if errors.Is(err, dto.ErrUnprocessable) {
+				util.NewError(c, http.StatusUnprocessableEntity, err)
+			} else {
+				util.NewError(c, http.StatusInternalServerError, err)
+			}
+			c.Abort()
+			return
+		}
+
+		if storedResponse != nil {
+			c.Header(idempotentReplayedHeader, "true")
+			c.Data(storedResponse.StatusCode, storedResponse.ContentType, storedResponse.Body)
+			c.Abort()
+			return
+		}
+
+		writer := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		if c.Writer.Status() >= http.StatusInternalServerError {
+			if err := idempotencyService.Release(userID, key, lockToken); err != nil {
+				logrus.Errorf("failed to release idempotency key of user %s: %v", userID, err)
+			}
+			return
+		}
+
+		if err := idempotencyService.Complete(userID, key, lockToken, dto.StoredResponse{
+			StatusCode:  c.Writer.Status(),
+			ContentType: c.Writer.Header().Get(contentTypeResponseHeader),
+			Body:        writer.body.Bytes(),
+		}); err != nil {
+			logrus.Errorf("failed to store response of idempotency key of user %s: %v", userID, err)
+			if err := idempotencyService.Release(userID, key, lockToken); err != nil {
+				logrus.Errorf("failed to release idempotency key of user %s: %v", userID, err)
+			}
+		}
+	}
+}
+
+func isMutatingMethod(method string) bool {
+	return method == http.MethodPost || method == http.MethodPut || method == http.MethodDelete
+}
+
+// hashRequest hashes the method, URI and body of the request, so a key sent again with a different request is
+// detected. The body is read into memory and restored for the handler.
+func hashRequest(request *http.Request) (string, error) {
+	var body []byte
+	if request.Body != nil {
+		var err error
+		body, err = io.ReadAll(request.Body)
+		if err != nil {
+			return "", err
+		}
+		request.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(request.Method + "\n" + request.URL.RequestURI() + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// recordingWriter keeps a copy of the response body written to the client.
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,172 @@
+package middleware
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/service"
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+)
+
+var _ = Describe("Idempotency Middleware", func() {
+	var (
+		idempotencyServiceCtrl *gomock.Controller
+		idempotencyService     *service.MockIdempotencyService
+		server                 *gin.Engine
+		w                      *httptest.ResponseRecorder
+		executions             int
+		handlerStatus          int
+	)
+
+	BeforeEach(func() {
+		idempotencyServiceCtrl = gomock.NewController(GinkgoT())
+		idempotencyService = service.NewMockIdempotencyService(idempotencyServiceCtrl)
+		w = httptest.NewRecorder()
+		executions = 0
+		handlerStatus = http.StatusCreated
+
+		server = gin.New()
+		server.Use(func(c *gin.Context) {
+			c.Set("userID", "1")
+		}, Idempotency(idempotencyService))
+		handler := func(c *gin.Context) {
+			executions++
+			body, _ := io.ReadAll(c.Request.Body)
+			c.JSON(handlerStatus, gin.H{"received": string(body)})
+		}
+		server.POST("/contacts", handler)
+		server.GET("/contacts", handler)
+	})
+
+	AfterEach(func() {
+		idempotencyServiceCtrl.Finish()
+	})
+
+	newRequest := func(method, key string) *http.Request {
+		request := httptest.NewRequest(method, "/contacts", bytes.NewBufferString(`{"first_name":"Jan"}`))
+		if key != "" {
+			request.Header.Set("Idempotency-Key", key)
+		}
+		return request
+	}
+
+	Context("when the request has no idempotency key", func() {
+		It("should execute the request", func() {
+			// when
+			server.ServeHTTP(w, newRequest(http.MethodPost, ""))
+
+			// then
+			Expect(executions).To(Equal(1))
+			Expect(w.Code).To(Equal(http.StatusCreated))
+		})
+	})
+
+	Context("when the request does not change data", func() {
+		It("should execute the request without checking the key", func() {
+			// when
+			server.ServeHTTP(w, newRequest(http.MethodGet, "abc"))
+
+			// then
+			Expect(executions).To(Equal(1))
+		})
+	})
+
+	Context("when the key is sent for the first time", func() {
+		It("should execute the request and store the response", func() {
+			// given
+			idempotencyService.EXPECT().Acquire("1", "abc", gomock.Any()).Return(nil, "lock", nil)
+			idempotencyService.EXPECT().Complete("1", "abc", "lock", dto.StoredResponse{
+				StatusCode:  http.StatusCreated,
+				ContentType: "application/json; charset=utf-8",
+				Body:        []byte(`{"received":"{\"first_name\":\"Jan\"}"}`),
+			}).Return(nil)
+
+			// when
+			server.ServeHTTP(w, newRequest(http.MethodPost, "abc"))
+
+			// then
+			Expect(executions).To(Equal(1))
+			Expect(w.Code).To(Equal(http.StatusCreated))
+			Expect(w.Body).To(MatchJSON(`{"received":"{\"first_name\":\"Jan\"}"}`))
+		})
+	})
+
+	Context("when the request is retried", func() {
+		It("should return the stored response without executing the request", func() {
+			// given
+			idempotencyService.EXPECT().Acquire("1", "abc", gomock.Any()).Return(&dto.StoredResponse{
+				StatusCode:  http.StatusCreated,
+				ContentType: "application/json; charset=utf-8",
+				Body:        []byte(`{"id":7}`),
+			}, "", nil)
+
+			// when
+			server.ServeHTTP(w, newRequest(http.MethodPost, "abc"))
+
+			// then
+			Expect(executions).To(Equal(0))
+			Expect(w.Code).To(Equal(http.StatusCreated))
+			Expect(w.Header().Get("Idempotent-Replayed")).To(Equal("true"))
+			Expect(w.Body).To(MatchJSON(`{"id":7}`))
+		})
+	})
+
+	Context("when the same request is sent twice", func() {
+		It("should hash both the same way", func() {
+			// given
+			var hashes []string
+			idempotencyService.EXPECT().Acquire("1", "abc", gomock.Any()).DoAndReturn(func(_, _, requestHash string) (*dto.StoredResponse, string, error) {
+				hashes = append(hashes, requestHash)
+				return nil, "", fmt.Errorf("%w: %v", dto.ErrConflict, "a request with the idempotency key is in progress")
+			}).Times(2)
+
+			// when
+			server.ServeHTTP(w, newRequest(http.MethodPost, "abc"))
+			server.ServeHTTP(httptest.NewRecorder(), newRequest(http.MethodPost, "abc"))
+
+			// then
+			Expect(hashes).To(HaveLen(2))
+			Expect(hashes[0]).To(Equal(hashes[1]))
+			Expect(executions).To(Equal(0))
+			Expect(w.Code).To(Equal(http.StatusConflict))
+		})
+	})
+
+	Context("when the key was used for a different request", func() {
+		It("should return 422", func() {
+			// given
+			idempotencyService.EXPECT().Acquire("1", "abc", gomock.Any()).
+				Return(nil, "", fmt.Errorf("%w: %v", dto.ErrUnprocessable, "idempotency key was already used for a different request"))
+
+			// when
+			server.ServeHTTP(w, newRequest(http.MethodPost, "abc"))
+
+			// then
+			Expect(executions).To(Equal(0))
+			Expect(w.Code).To(Equal(http.StatusUnprocessableEntity))
+			Expect(w.Body).To(MatchJSON(`{"code":422,"message":"unprocessable: idempotency key was already used for a different request"}`))
+		})
+	})
+
+	Context("when the request fails with a server error", func() {
+		It("should release the key so the request can be retried", func() {
+			// given
+			handlerStatus = http.StatusInternalServerError
+			idempotencyService.EXPECT().Acquire("1", "abc", gomock.Any()).Return(nil, "lock", nil)
+			idempotencyService.EXPECT().Release("1", "abc", "lock").Return(nil)
+
+			// when
+			server.ServeHTTP(w, newRequest(http.MethodPost, "abc"))
+
+			// then
+			Expect(executions).To(Equal(1))
+			Expect(w.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
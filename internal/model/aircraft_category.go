@@ -0,0 +1,19 @@
+package model
+
+type AircraftCategory string
+
+const (
+	AircraftCategoryAirplane       AircraftCategory = "AIRPLANE"
+	AircraftCategoryRotorcraft     AircraftCategory = "ROTORCRAFT"
+	AircraftCategoryGlider         AircraftCategory = "GLIDER"
+	AircraftCategoryPoweredLift    AircraftCategory = "POWERED_LIFT"
+	AircraftCategoryLighterThanAir AircraftCategory = "LIGHTER_THAN_AIR"
+)
+
+var AvailableAircraftCategories = []AircraftCategory{
+	AircraftCategoryAirplane,
+	AircraftCategoryRotorcraft,
+	AircraftCategoryGlider,
+	AircraftCategoryPoweredLift,
+	AircraftCategoryLighterThanAir,
+}
@@ -0,0 +1,14 @@
+package model
+
+// Airport is read from the airport dataset embedded in the binary, it is not stored in the database.
+type Airport struct {
+	ICAOCode  string
+	IATACode  *string
+	Name      string
+	Country   Country
+	Latitude  float64
+	Longitude float64
+	// Elevation is given in feet above mean sea level
+	Elevation int
+	Timezone  string
+}
@@ -4,8 +4,35 @@ type ApproachType string
 
 const (
 	ApproachTypeVisual ApproachType = "VISUAL"
+	ApproachTypeILS    ApproachType = "ILS"
+	ApproachTypeLOC    ApproachType = "LOC"
+	ApproachTypeVOR    ApproachType = "VOR"
+	ApproachTypeNDB    ApproachType = "NDB"
+	ApproachTypeRNAV   ApproachType = "RNAV"
+	ApproachTypeRNP    ApproachType = "RNP"
+	ApproachTypeGLS    ApproachType = "GLS"
+	ApproachTypePAR    ApproachType = "PAR"
 )
 
 var AvailableApproachTypes = []ApproachType{
 	ApproachTypeVisual,
+	ApproachTypeILS,
+	ApproachTypeLOC,
+	ApproachTypeVOR,
+	ApproachTypeNDB,
+	ApproachTypeRNAV,
+	ApproachTypeRNP,
+	ApproachTypeGLS,
+	ApproachTypePAR,
+}
+
+var InstrumentApproachTypes = []ApproachType{
+	ApproachTypeILS,
+	ApproachTypeLOC,
+	ApproachTypeVOR,
+	ApproachTypeNDB,
+	ApproachTypeRNAV,
+	ApproachTypeRNP,
+	ApproachTypeGLS,
+	ApproachTypePAR,
 }
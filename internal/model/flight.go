@@ -7,13 +7,13 @@ import (
 
 type Flight struct {
 	gorm.Model
-	UserID              string      `gorm:"required; not null; default:null" validate:"required"`
+	UserID              string      `gorm:"required; not null; default:null; index:idx_flights_user_id_takeoff_time,priority:1" validate:"required"`
 	User                User        `validate:"-"`
 	AircraftID          uint        `gorm:"required; not null; default:null" validate:"required"`
 	Aircraft            Aircraft    `validate:"-"`
 	Passengers          []Passenger `gorm:"foreignKey:FlightID" validate:"-"`
 	Landings            []Landing   `gorm:"foreignKey:FlightID" validate:"-"`
-	TakeoffTime         time.Time   `gorm:"required; not null; default:null" validate:"required"`
+	TakeoffTime         time.Time   `gorm:"required; not null; default:null; index:idx_flights_user_id_takeoff_time,priority:2" validate:"required"`
 	TakeoffAirportCode  string      `gorm:"required; not null; default:null" validate:"required"`
 	LandingTime         time.Time   `gorm:"required; not null; default:null" validate:"required"`
 	LandingAirportCode  string      `gorm:"required; not null; default:null" validate:"required"`
@@ -33,5 +33,6 @@ type Flight struct {
 	IFRSimulatedTime    *time.Duration
 	CrossCountryTime    *time.Duration
 	SimulatorTime       *time.Duration
+	Holdings            *uint
 	SignatureURL        *string
 }
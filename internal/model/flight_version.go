@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// FlightVersion is an immutable record of a change of a flight and its landings and passengers. The versions of a user
+// form a hash chain: the hash of every version covers the hash of the previous one, so a version changed or removed in
+// the database breaks the chain from that point on.
+type FlightVersion struct {
+	ID           uint                `gorm:"primaryKey"`
+	UserID       string              `gorm:"required; not null; default:null; uniqueIndex:idx_flight_versions_user_id_sequence,priority:1"`
+	Sequence     uint                `gorm:"required; not null; default:null; uniqueIndex:idx_flight_versions_user_id_sequence,priority:2"`
+	FlightID     uint                `gorm:"required; not null; default:null; index"`
+	Action       FlightVersionAction `gorm:"required; not null; default:null"`
+	Snapshot     string              `gorm:"required; not null; default:null; type:text"`
+	RecordedAt   time.Time           `gorm:"required; not null; default:null"`
+	PreviousHash string              `gorm:"required; not null; default:null"`
+	Hash         string              `gorm:"required; not null; default:null; uniqueIndex"`
+}
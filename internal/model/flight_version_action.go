@@ -0,0 +1,12 @@
+package model
+
+type FlightVersionAction string
+
+const (
+	FlightVersionActionCreated  FlightVersionAction = "CREATED"
+	FlightVersionActionImported FlightVersionAction = "IMPORTED"
+	FlightVersionActionUpdated  FlightVersionAction = "UPDATED"
+	FlightVersionActionAmended  FlightVersionAction = "AMENDED"
+	FlightVersionActionDeleted  FlightVersionAction = "DELETED"
+	FlightVersionActionRestored FlightVersionAction = "RESTORED"
+)
@@ -0,0 +1,23 @@
+package model
+
+import "time"
+
+// IdempotencyKey is a key sent by a client with a request changing data, with the response to return when the request
+// is retried. The response is empty while the request is in progress. UserID is empty for requests authorized by a
+// token of a signature request.
+type IdempotencyKey struct {
+	ID          uint   `gorm:"primaryKey"`
+	UserID      string `gorm:"not null; uniqueIndex:idx_idempotency_keys_user_id_key,priority:1"`
+	Key         string `gorm:"required; not null; default:null; uniqueIndex:idx_idempotency_keys_user_id_key,priority:2"`
+	RequestHash string `gorm:"required; not null; default:null"`
+	StatusCode  *int
+	ContentType *string
+	Body        []byte
+	// LockedUntil is the time after which a request in progress is considered abandoned and can be executed again.
+	LockedUntil time.Time `gorm:"required; not null; default:null"`
+	// LockToken identifies the request holding the key. A retry taking over an abandoned key sets a new one, so the
+	// abandoned request can no longer store its response or release the key.
+	LockToken string    `gorm:"not null; default:''"`
+	ExpiresAt time.Time `gorm:"required; not null; default:null; index"`
+	CreatedAt time.Time
+}
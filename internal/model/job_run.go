@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// JobRun is a run of a background job at its scheduled time. The unique index on the job and the scheduled time lets
+// a single replica claim the run. A run is retried within its lock, after the lock expires a run still in progress is
+// considered abandoned, e.g. by a replica that was stopped, and can be taken over.
+type JobRun struct {
+	ID          uint         `gorm:"primaryKey"`
+	Job         string       `gorm:"not null; uniqueIndex:idx_job_runs_job_scheduled_at,priority:1"`
+	ScheduledAt time.Time    `gorm:"not null; uniqueIndex:idx_job_runs_job_scheduled_at,priority:2; index"`
+	Status      JobRunStatus `gorm:"not null"`
+	// Replica is the host name of the replica executing the run
+	Replica     string `gorm:"not null"`
+	Attempts    int    `gorm:"not null"`
+	Summary     *string
+	Error       *string
+	StartedAt   time.Time `gorm:"not null"`
+	FinishedAt  *time.Time
+	LockedUntil time.Time `gorm:"not null"`
+}
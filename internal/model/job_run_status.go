@@ -0,0 +1,9 @@
+package model
+
+type JobRunStatus string
+
+const (
+	JobRunStatusRunning   JobRunStatus = "RUNNING"
+	JobRunStatusSucceeded JobRunStatus = "SUCCEEDED"
+	JobRunStatusFailed    JobRunStatus = "FAILED"
+)
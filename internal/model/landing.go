@@ -4,7 +4,7 @@ import "gorm.io/gorm"
 
 type Landing struct {
 	gorm.Model
-	FlightID     uint         `gorm:"required; not null; default:null" validate:"required"`
+	FlightID     uint         `gorm:"required; not null; default:null; index" validate:"required"`
 	ApproachType ApproachType `gorm:"required; not null; default:null" validate:"required,approach_type"`
 	Count        *uint
 	NightCount   *uint
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+// Licence is a pilot licence of the user. Licences without an expiry date, like EASA Part-FCL or FAA certificates,
+// stay valid as long as their ratings and the medical are.
+type Licence struct {
+	gorm.Model
+	UserID       string      `gorm:"required; not null; default:null" validate:"required"`
+	User         User        `validate:"-"`
+	Type         LicenceType `gorm:"required; not null; default:null" validate:"required,licence_type"`
+	Number       string      `gorm:"required; not null; default:null" validate:"required"`
+	IssuingState string      `gorm:"required; not null; default:null" validate:"required"`
+	IssuedAt     *time.Time
+	ExpiresAt    *time.Time
+	Remarks      *string
+	Ratings      []Rating `gorm:"foreignKey:LicenceID" validate:"-"`
+}
@@ -0,0 +1,21 @@
+package model
+
+type LicenceType string
+
+const (
+	LicenceTypeLAPL LicenceType = "LAPL"
+	LicenceTypePPL  LicenceType = "PPL"
+	LicenceTypeSPL  LicenceType = "SPL"
+	LicenceTypeCPL  LicenceType = "CPL"
+	LicenceTypeMPL  LicenceType = "MPL"
+	LicenceTypeATPL LicenceType = "ATPL"
+)
+
+var AvailableLicenceTypes = []LicenceType{
+	LicenceTypeLAPL,
+	LicenceTypePPL,
+	LicenceTypeSPL,
+	LicenceTypeCPL,
+	LicenceTypeMPL,
+	LicenceTypeATPL,
+}
@@ -0,0 +1,18 @@
+package model
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+type MedicalCertificate struct {
+	gorm.Model
+	UserID      string       `gorm:"required; not null; default:null" validate:"required"`
+	User        User         `validate:"-"`
+	RuleSet     RuleSet      `gorm:"required; not null; default:null" validate:"required,rule_set"`
+	Class       MedicalClass `gorm:"required; not null; default:null" validate:"required,medical_class"`
+	ExaminedAt  time.Time    `gorm:"required; not null; default:null" validate:"required"`
+	Number      *string
+	Limitations *string
+	Remarks     *string
+}
@@ -0,0 +1,17 @@
+package model
+
+type MedicalClass string
+
+const (
+	MedicalClass1    MedicalClass = "CLASS_1"
+	MedicalClass2    MedicalClass = "CLASS_2"
+	MedicalClass3    MedicalClass = "CLASS_3"
+	MedicalClassLAPL MedicalClass = "LAPL"
+)
+
+var AvailableMedicalClasses = []MedicalClass{
+	MedicalClass1,
+	MedicalClass2,
+	MedicalClass3,
+	MedicalClassLAPL,
+}
@@ -0,0 +1,14 @@
+package model
+
+// MedicalPrivilege is a privilege exercised with a medical certificate. A certificate of a higher class also covers the
+// privileges of the lower classes, each with its own validity.
+type MedicalPrivilege string
+
+const (
+	MedicalPrivilegeClass1     MedicalPrivilege = "CLASS_1"
+	MedicalPrivilegeClass2     MedicalPrivilege = "CLASS_2"
+	MedicalPrivilegeLAPL       MedicalPrivilege = "LAPL"
+	MedicalPrivilegeATP        MedicalPrivilege = "ATP"
+	MedicalPrivilegeCommercial MedicalPrivilege = "COMMERCIAL"
+	MedicalPrivilegePrivate    MedicalPrivilege = "PRIVATE"
+)
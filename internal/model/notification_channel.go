@@ -0,0 +1,10 @@
+package model
+
+// NotificationChannel is the medium over which notifications reach a user.
+type NotificationChannel string
+
+const (
+	NotificationChannelEmail   NotificationChannel = "EMAIL"
+	NotificationChannelPush    NotificationChannel = "PUSH"
+	NotificationChannelWebhook NotificationChannel = "WEBHOOK"
+)
@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// NotificationPreference chooses what a user is reminded of, how many days before it lapses and over which channels.
+// Users without preferences get no reminders.
+type NotificationPreference struct {
+	UserID         string `gorm:"primaryKey"`
+	User           User   `validate:"-"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DaysBefore     int  `gorm:"not null" validate:"min=1,max=365"`
+	Licences       bool `gorm:"not null"`
+	Medicals       bool `gorm:"not null"`
+	Currency       bool `gorm:"not null"`
+	EmailEnabled   bool `gorm:"not null"`
+	PushEnabled    bool `gorm:"not null"`
+	WebhookEnabled bool `gorm:"not null"`
+	// DeviceToken is the Firebase Cloud Messaging registration token of the device receiving push notifications
+	DeviceToken *string
+	WebhookURL  *string `validate:"omitempty,url,startswith=https://"`
+}
+
+// Channels returns the channels the user enabled.
+func (p NotificationPreference) Channels() []NotificationChannel {
+	channels := make([]NotificationChannel, 0, 3)
+	if p.EmailEnabled {
+		channels = append(channels, NotificationChannelEmail)
+	}
+	if p.PushEnabled {
+		channels = append(channels, NotificationChannelPush)
+	}
+	if p.WebhookEnabled {
+		channels = append(channels, NotificationChannelWebhook)
+	}
+	return channels
+}
@@ -4,7 +4,7 @@ import "gorm.io/gorm"
 
 type Passenger struct {
 	gorm.Model
-	FlightID     uint   `gorm:"required; not null; default:null" validate:"required"`
+	FlightID     uint   `gorm:"required; not null; default:null; index" validate:"required"`
 	Flight       Flight `validate:"-"`
 	Role         Role   `gorm:"required; not null; default:null" validate:"required,role"`
 	FirstName    string `gorm:"required; not null; default:null" validate:"required"`
@@ -0,0 +1,21 @@
+package model
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+// Rating is a class, type, instrument or instructor rating of the user, e.g. SEP, B737 or FI(A). It is endorsed on
+// one of the licences of the user if LicenceID is set.
+type Rating struct {
+	gorm.Model
+	UserID    string     `gorm:"required; not null; default:null" validate:"required"`
+	User      User       `validate:"-"`
+	LicenceID *uint      `gorm:"index"`
+	Licence   *Licence   `validate:"-"`
+	Kind      RatingKind `gorm:"required; not null; default:null" validate:"required,rating_kind"`
+	Name      string     `gorm:"required; not null; default:null" validate:"required"`
+	IssuedAt  *time.Time
+	ExpiresAt *time.Time
+	Remarks   *string
+}
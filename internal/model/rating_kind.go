@@ -0,0 +1,21 @@
+package model
+
+type RatingKind string
+
+const (
+	RatingKindClass      RatingKind = "CLASS"
+	RatingKindType       RatingKind = "TYPE"
+	RatingKindInstrument RatingKind = "INSTRUMENT"
+	RatingKindInstructor RatingKind = "INSTRUCTOR"
+	RatingKindExaminer   RatingKind = "EXAMINER"
+	RatingKindOther      RatingKind = "OTHER"
+)
+
+var AvailableRatingKinds = []RatingKind{
+	RatingKindClass,
+	RatingKindType,
+	RatingKindInstrument,
+	RatingKindInstructor,
+	RatingKindExaminer,
+	RatingKindOther,
+}
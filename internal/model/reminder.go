@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// Reminder records a reminder sent to a user, so that each lapse of an item is reminded of only once. Item identifies
+// what lapses, e.g. a licence or the passenger currency in a category, and LapsesAt tells renewals apart.
+type Reminder struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    string    `gorm:"not null; uniqueIndex:idx_reminders_user_id_item_lapses_at,priority:1"`
+	Item      string    `gorm:"not null; uniqueIndex:idx_reminders_user_id_item_lapses_at,priority:2"`
+	LapsesAt  time.Time `gorm:"not null; uniqueIndex:idx_reminders_user_id_item_lapses_at,priority:3"`
+	CreatedAt time.Time
+}
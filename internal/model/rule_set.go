@@ -0,0 +1,14 @@
+package model
+
+// RuleSet is the aviation authority whose regulations apply, e.g. to the validity of a medical certificate.
+type RuleSet string
+
+const (
+	RuleSetEASA RuleSet = "EASA"
+	RuleSetFAA  RuleSet = "FAA"
+)
+
+var AvailableRuleSets = []RuleSet{
+	RuleSetEASA,
+	RuleSetFAA,
+}
@@ -0,0 +1,27 @@
+package model
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+// Signature is a request of the pilot for a countersignature of a flight by an instructor, who is either another user
+// or a contact of the pilot signing with the token of the request. Once signed, the flight can only be changed by an
+// amendment, which invalidates the signature.
+type Signature struct {
+	gorm.Model
+	FlightID        uint    `gorm:"required; not null; default:null; index" validate:"required"`
+	Flight          Flight  `validate:"-"`
+	RequesterID     string  `gorm:"required; not null; default:null" validate:"required"`
+	RequesterName   string  `gorm:"required; not null; default:null" validate:"required"`
+	SignerUserID    *string `gorm:"index"`
+	SignerContactID *uint
+	SignerName      string `gorm:"required; not null; default:null" validate:"required"`
+	SignerEmail     *string
+	Token           *string         `gorm:"uniqueIndex"`
+	Status          SignatureStatus `gorm:"required; not null; default:null" validate:"required"`
+	Remarks         *string
+	SignedAt        *time.Time
+	// FieldsHash is the SHA-256 hash of the fields of the flight at the time of signing
+	FieldsHash *string
+}
@@ -0,0 +1,10 @@
+package model
+
+type SignatureStatus string
+
+const (
+	SignatureStatusPending     SignatureStatus = "PENDING"
+	SignatureStatusSigned      SignatureStatus = "SIGNED"
+	SignatureStatusRejected    SignatureStatus = "REJECTED"
+	SignatureStatusInvalidated SignatureStatus = "INVALIDATED"
+)
@@ -21,7 +21,11 @@ type User struct {
 	City         *string
 	Company      *string
 	Timezone     *string
-	Contacts     []Contact  `gorm:"foreignKey:UserID" validate:"-"`
-	Aircraft     []Aircraft `gorm:"foreignKey:UserID" validate:"-"`
-	Flights      []Flight   `gorm:"foreignKey:UserID" validate:"-"`
+	// DateOfBirth determines the validity of medical certificates, which depends on the age at the examination
+	DateOfBirth *time.Time
+	// AutoFillTimes enables filling in block time and time columns of the role of flights saved without them
+	AutoFillTimes bool       `gorm:"not null; default:true"`
+	Contacts      []Contact  `gorm:"foreignKey:UserID" validate:"-"`
+	Aircraft      []Aircraft `gorm:"foreignKey:UserID" validate:"-"`
+	Flights       []Flight   `gorm:"foreignKey:UserID" validate:"-"`
 }
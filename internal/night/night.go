@@ -0,0 +1,100 @@
+// Package night computes night time of flights. Night is the period between the end of evening civil twilight and the
+// beginning of morning civil twilight, that is when the centre of the sun is more than 6° below the horizon.
+package night
+
+import (
+	"math"
+	"time"
+)
+
+// CivilTwilightElevation is the elevation of the sun in degrees at the end of evening civil twilight.
+const CivilTwilightElevation = -6.0
+
+// sampleInterval is the resolution of the night time calculation, logbooks record times with minute precision.
+const sampleInterval = time.Minute
+
+type Position struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// SunElevation returns the elevation of the centre of the sun above the horizon in degrees, without atmospheric
+// refraction. The approximation of the solar position is accurate to about 0.01° for years 1950-2050.
+func SunElevation(t time.Time, position Position) float64 {
+	// days since J2000.0
+	d := float64(t.UnixNano())/float64(24*time.Hour) - 10957.5
+
+	meanAnomaly := radians(357.529 + 0.98560028*d)
+	meanLongitude := 280.459 + 0.98564736*d
+	eclipticLongitude := radians(meanLongitude + 1.915*math.Sin(meanAnomaly) + 0.020*math.Sin(2*meanAnomaly))
+	obliquity := radians(23.439 - 0.00000036*d)
+
+	rightAscension := math.Atan2(math.Cos(obliquity)*math.Sin(eclipticLongitude), math.Cos(eclipticLongitude))
+	declination := math.Asin(math.Sin(obliquity) * math.Sin(eclipticLongitude))
+
+	siderealTime := radians(math.Mod(280.46061837+360.98564736629*d, 360) + position.Longitude)
+	hourAngle := siderealTime - rightAscension
+
+	latitude := radians(position.Latitude)
+	elevation := math.Asin(math.Sin(latitude)*math.Sin(declination) +
+		math.Cos(latitude)*math.Cos(declination)*math.Cos(hourAngle))
+	return degrees(elevation)
+}
+
+// IsNight tells whether it is night at the given time and position.
+func IsNight(t time.Time, position Position) bool {
+	return SunElevation(t, position) < CivilTwilightElevation
+}
+
+// Intermediate returns the position at the given fraction of the great circle route between from and to.
+func Intermediate(from, to Position, fraction float64) Position {
+	lat1, lon1 := radians(from.Latitude), radians(from.Longitude)
+	lat2, lon2 := radians(to.Latitude), radians(to.Longitude)
+
+	distance := 2 * math.Asin(math.Sqrt(math.Pow(math.Sin((lat2-lat1)/2), 2)+
+		math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin((lon2-lon1)/2), 2)))
+	if distance == 0 {
+		return from
+	}
+
+	a := math.Sin((1-fraction)*distance) / math.Sin(distance)
+	b := math.Sin(fraction*distance) / math.Sin(distance)
+	x := a*math.Cos(lat1)*math.Cos(lon1) + b*math.Cos(lat2)*math.Cos(lon2)
+	y := a*math.Cos(lat1)*math.Sin(lon1) + b*math.Cos(lat2)*math.Sin(lon2)
+	z := a*math.Sin(lat1) + b*math.Sin(lat2)
+
+	return Position{
+		Latitude:  degrees(math.Atan2(z, math.Sqrt(x*x+y*y))),
+		Longitude: degrees(math.Atan2(y, x)),
+	}
+}
+
+// Duration returns the night time of a flight from takeoff to landing, assuming the aircraft follows the great circle
+// route at a constant speed. The result is rounded to whole minutes.
+func Duration(from Position, takeoffTime time.Time, to Position, landingTime time.Time) time.Duration {
+	total := landingTime.Sub(takeoffTime)
+	if total <= 0 {
+		return 0
+	}
+
+	var nightTime time.Duration
+	for elapsed := time.Duration(0); elapsed < total; elapsed += sampleInterval {
+		interval := min(sampleInterval, total-elapsed)
+		// every interval is classified by its middle
+		middle := elapsed + interval/2
+		position := Intermediate(from, to, float64(middle)/float64(total))
+		if IsNight(takeoffTime.Add(middle), position) {
+			nightTime += interval
+		}
+	}
+
+	return nightTime.Round(time.Minute)
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+func degrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
@@ -0,0 +1,12 @@
+package night
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"testing"
+)
+
+func TestNight(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Night Suite")
+}
@@ -0,0 +1,112 @@
+package night
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"time"
+)
+
+var _ = Describe("Night", func() {
+	var (
+		warsaw    Position
+		newYork   Position
+		london    Position
+		midsummer time.Time
+	)
+
+	BeforeEach(func() {
+		warsaw = Position{Latitude: 52.1657, Longitude: 20.9671}
+		newYork = Position{Latitude: 40.6398, Longitude: -73.7789}
+		london = Position{Latitude: 51.4706, Longitude: -0.4619}
+		midsummer = time.Date(2024, 6, 21, 0, 0, 0, 0, time.UTC)
+	})
+
+	Describe("SunElevation", func() {
+		Context("at local noon of the summer solstice", func() {
+			It("should return the maximum elevation of the year", func() {
+				// when
+				elevation := SunElevation(midsummer.Add(10*time.Hour+36*time.Minute), warsaw)
+
+				// then
+				Expect(elevation).To(BeNumerically("~", 90-warsaw.Latitude+23.44, 0.1))
+			})
+		})
+	})
+
+	Describe("IsNight", func() {
+		Context("around the end of evening civil twilight", func() {
+			It("should switch from day to night", func() {
+				// when, then
+				Expect(IsNight(midsummer.Add(19*time.Hour+49*time.Minute), warsaw)).To(BeFalse())
+				Expect(IsNight(midsummer.Add(19*time.Hour+53*time.Minute), warsaw)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("Intermediate", func() {
+		Context("when the fraction is at the ends of the route", func() {
+			It("should return the airports", func() {
+				// when
+				start := Intermediate(newYork, london, 0)
+				end := Intermediate(newYork, london, 1)
+
+				// then
+				Expect(start.Latitude).To(BeNumerically("~", newYork.Latitude, 1e-9))
+				Expect(start.Longitude).To(BeNumerically("~", newYork.Longitude, 1e-9))
+				Expect(end.Latitude).To(BeNumerically("~", london.Latitude, 1e-9))
+				Expect(end.Longitude).To(BeNumerically("~", london.Longitude, 1e-9))
+			})
+		})
+		Context("when the route crosses the Atlantic", func() {
+			It("should follow the great circle north of both airports", func() {
+				// when
+				middle := Intermediate(newYork, london, 0.5)
+
+				// then
+				Expect(middle.Latitude).To(BeNumerically(">", london.Latitude))
+				Expect(middle.Longitude).To(BeNumerically("~", -41.3, 0.1))
+			})
+		})
+	})
+
+	Describe("Duration", func() {
+		Context("when the whole flight is flown in the dark", func() {
+			It("should return the whole flight time", func() {
+				// when
+				nightTime := Duration(newYork, time.Date(2024, 1, 15, 23, 0, 0, 0, time.UTC),
+					london, time.Date(2024, 1, 16, 6, 0, 0, 0, time.UTC))
+
+				// then
+				Expect(nightTime).To(Equal(7 * time.Hour))
+			})
+		})
+		Context("when the flight ends after sunrise", func() {
+			It("should return the time flown before the morning civil twilight", func() {
+				// when
+				nightTime := Duration(newYork, time.Date(2024, 6, 15, 23, 0, 0, 0, time.UTC),
+					london, time.Date(2024, 6, 16, 6, 0, 0, 0, time.UTC))
+
+				// then
+				Expect(nightTime).To(Equal(3*time.Hour + 27*time.Minute))
+			})
+		})
+		Context("when the flight is flown at noon", func() {
+			It("should return zero", func() {
+				// when
+				nightTime := Duration(warsaw, midsummer.Add(10*time.Hour), london, midsummer.Add(12*time.Hour+30*time.Minute))
+
+				// then
+				Expect(nightTime).To(BeZero())
+			})
+		})
+		Context("when the landing time is not after the takeoff time", func() {
+			It("should return zero", func() {
+				// when
+				nightTime := Duration(warsaw, midsummer, warsaw, midsummer)
+
+				// then
+				Expect(nightTime).To(BeZero())
+			})
+		})
+	})
+})
@@ -0,0 +1,57 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+)
+
+type sendMailFunc func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+
+type emailChannel struct {
+	addr     string
+	auth     smtp.Auth
+	from     mail.Address
+	sendMail sendMailFunc
+	now      func() time.Time
+}
+
+// NewEmailChannel returns a channel sending plain text emails through the SMTP server. The server has to support
+// STARTTLS, as the credentials are only sent over an encrypted connection.
+func NewEmailChannel(host, port, username, password, from string) Channel {
+	return newEmailChannel(host, port, smtp.PlainAuth("", username, password, host), from, smtp.SendMail, time.Now)
+}
+
+func newEmailChannel(host, port string, auth smtp.Auth, from string, sendMail sendMailFunc, now func() time.Time) Channel {
+	return &emailChannel{addr: net.JoinHostPort(host, port), auth: auth, from: mail.Address{Address: from},
+		sendMail: sendMail, now: now}
+}
+
+// Send ignores the context, as net/smtp does not support cancellation.
+func (e *emailChannel) Send(_ context.Context, recipient Recipient, message Message) error {
+	if recipient.Email == "" {
+		return ErrNoAddress
+	}
+	to := mail.Address{Address: recipient.Email}
+
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", e.from.String())
+	fmt.Fprintf(&msg, "To: %s\r\n", to.String())
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Title))
+	fmt.Fprintf(&msg, "Date: %s\r\n", e.now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(message.Body)
+	msg.WriteString("\r\n")
+
+	if err := e.sendMail(e.addr, e.auth, e.from.Address, []string{to.Address}, msg.Bytes()); err != nil {
+		return fmt.Errorf("error sending email to user %s: %w", recipient.UserID, err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"github.com/avialog/backend/internal/model"
+)
+
+// ErrNoAddress is returned by a channel when the recipient has no address it can deliver to, e.g. no device token.
+var ErrNoAddress = errors.New("recipient has no address for the channel")
+
+type Message struct {
+	Title string
+	Body  string
+	// Data is passed on to clients as is, so they can e.g. open the item the message is about
+	Data map[string]string
+}
+
+type Recipient struct {
+	UserID      string
+	Email       string
+	DeviceToken *string
+	WebhookURL  *string
+}
+
+// Channel delivers messages over a single medium, like email or push notifications.
+type Channel interface {
+	Send(ctx context.Context, recipient Recipient, message Message) error
+}
+
+//go:generate mockgen -source=notification.go -destination=notification_mock.go -package notification
+type Registry interface {
+	Register(name model.NotificationChannel, channel Channel)
+	Get(name model.NotificationChannel) (Channel, bool)
+}
+
+type registry struct {
+	channels map[model.NotificationChannel]Channel
+}
+
+// NewRegistry returns an empty registry. Which channels are available depends on the configuration, so they are
+// registered by the caller.
+func NewRegistry() Registry {
+	return &registry{channels: make(map[model.NotificationChannel]Channel)}
+}
+
+func (r *registry) Register(name model.NotificationChannel, channel Channel) {
+	r.channels[name] = channel
+}
+
+func (r *registry) Get(name model.NotificationChannel) (Channel, bool) {
+	channel, ok := r.channels[name]
+	return channel, ok
+}
@@ -0,0 +1,244 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"firebase.google.com/go/v4/messaging"
+	"github.com/avialog/backend/internal/infrastructure"
+	"github.com/avialog/backend/internal/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+var _ = Describe("Notification", func() {
+	var (
+		recipient Recipient
+		message   Message
+	)
+
+	BeforeEach(func() {
+		deviceToken := "device-token"
+		webhookURL := "https://hooks.example.com/avialog"
+		recipient = Recipient{
+			UserID:      "1",
+			Email:       "pilot@example.com",
+			DeviceToken: &deviceToken,
+			WebhookURL:  &webhookURL,
+		}
+		message = Message{
+			Title: "Medical expires in 14 days",
+			Body:  "Your EASA class 2 medical expires on 4 May 2024.",
+			Data:  map[string]string{"type": "MEDICAL", "id": "3"},
+		}
+	})
+
+	Describe("Registry", func() {
+		Context("when a channel is registered", func() {
+			It("should return it", func() {
+				// given
+				registry := NewRegistry()
+				channel := NewMockChannel(gomock.NewController(GinkgoT()))
+				registry.Register(model.NotificationChannelPush, channel)
+
+				// when
+				registered, ok := registry.Get(model.NotificationChannelPush)
+				_, emailOk := registry.Get(model.NotificationChannelEmail)
+
+				// then
+				Expect(ok).To(BeTrue())
+				Expect(registered).To(BeIdenticalTo(channel))
+				Expect(emailOk).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("emailChannel", func() {
+		It("should send a plain text email to the recipient", func() {
+			// given
+			var sentTo []string
+			var sentMsg string
+			now := time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+			channel := newEmailChannel("smtp.example.com", "587", nil, "noreply@avialog.com",
+				func(addr string, _ smtp.Auth, from string, to []string, msg []byte) error {
+					Expect(addr).To(Equal("smtp.example.com:587"))
+					Expect(from).To(Equal("noreply@avialog.com"))
+					sentTo = to
+					sentMsg = string(msg)
+					return nil
+				}, func() time.Time { return now })
+
+			// when
+			err := channel.Send(context.Background(), recipient, message)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(sentTo).To(Equal([]string{"pilot@example.com"}))
+			Expect(sentMsg).To(ContainSubstring("To: <pilot@example.com>\r\n"))
+			Expect(sentMsg).To(ContainSubstring("Subject: Medical expires in 14 days\r\n"))
+			Expect(sentMsg).To(HaveSuffix("\r\n\r\nYour EASA class 2 medical expires on 4 May 2024.\r\n"))
+		})
+		It("should encode a subject that would break the headers", func() {
+			// given
+			var sentMsg string
+			channel := newEmailChannel("smtp.example.com", "587", nil, "noreply@avialog.com",
+				func(_ string, _ smtp.Auth, _ string, _ []string, msg []byte) error {
+					sentMsg = string(msg)
+					return nil
+				}, time.Now)
+			message.Title = "Expiring\r\nBcc: someone@example.com"
+
+			// when
+			err := channel.Send(context.Background(), recipient, message)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(sentMsg).ToNot(ContainSubstring("\r\nBcc:"))
+		})
+	})
+
+	Describe("pushChannel", func() {
+		var (
+			messagingCtrl *gomock.Controller
+			messagingMock *infrastructure.MockMessagingClient
+			channel       Channel
+		)
+
+		BeforeEach(func() {
+			messagingCtrl = gomock.NewController(GinkgoT())
+			messagingMock = infrastructure.NewMockMessagingClient(messagingCtrl)
+			channel = NewPushChannel(messagingMock)
+		})
+
+		It("should send the message to the device of the recipient", func() {
+			// given
+			messagingMock.EXPECT().Send(gomock.Any(), &messaging.Message{
+				Token:        "device-token",
+				Notification: &messaging.Notification{Title: message.Title, Body: message.Body},
+				Data:         message.Data,
+			}).Return("projects/avialog/messages/1", nil)
+
+			// when
+			err := channel.Send(context.Background(), recipient, message)
+
+			// then
+			Expect(err).To(BeNil())
+		})
+		It("should return an error when the recipient has no device", func() {
+			// given
+			recipient.DeviceToken = nil
+
+			// when
+			err := channel.Send(context.Background(), recipient, message)
+
+			// then
+			Expect(errors.Is(err, ErrNoAddress)).To(BeTrue())
+		})
+	})
+
+	Describe("webhookChannel", func() {
+		var (
+			httpClientCtrl *gomock.Controller
+			httpClientMock *infrastructure.MockHTTPClient
+			channel        Channel
+		)
+
+		BeforeEach(func() {
+			httpClientCtrl = gomock.NewController(GinkgoT())
+			httpClientMock = infrastructure.NewMockHTTPClient(httpClientCtrl)
+			channel = NewWebhookChannel(httpClientMock)
+		})
+
+		It("should post the message as JSON", func() {
+			// given
+			httpClientMock.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				Expect(req.Method).To(Equal(http.MethodPost))
+				Expect(req.URL.String()).To(Equal("https://hooks.example.com/avialog"))
+				Expect(req.Header.Get("Content-Type")).To(Equal("application/json"))
+				body, err := io.ReadAll(req.Body)
+				Expect(err).To(BeNil())
+				var payload map[string]any
+				Expect(json.Unmarshal(body, &payload)).To(Succeed())
+				Expect(payload).To(HaveKeyWithValue("user_id", "1"))
+				Expect(payload).To(HaveKeyWithValue("title", message.Title))
+				return &http.Response{StatusCode: http.StatusNoContent, Body: io.NopCloser(strings.NewReader(""))}, nil
+			})
+
+			// when
+			err := channel.Send(context.Background(), recipient, message)
+
+			// then
+			Expect(err).To(BeNil())
+		})
+		It("should return an error when the webhook fails", func() {
+			// given
+			httpClientMock.EXPECT().Do(gomock.Any()).
+				Return(&http.Response{StatusCode: http.StatusBadGateway, Body: io.NopCloser(strings.NewReader(""))}, nil)
+
+			// when
+			err := channel.Send(context.Background(), recipient, message)
+
+			// then
+			Expect(err).To(MatchError("webhook of user 1 responded with status 502"))
+		})
+	})
+
+	Describe("NewWebhookHTTPClient", func() {
+		It("should not connect to a webhook on an internal address", func() {
+			// given
+			called := false
+			server := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+				called = true
+			}))
+			defer server.Close()
+			webhookURL := server.URL
+			recipient.WebhookURL = &webhookURL
+			channel := NewWebhookChannel(NewWebhookHTTPClient(time.Second))
+
+			// when
+			err := channel.Send(context.Background(), recipient, message)
+
+			// then
+			Expect(errors.Is(err, errInternalAddress)).To(BeTrue())
+			Expect(called).To(BeFalse())
+		})
+		It("should not follow redirects", func() {
+			// given
+			client := NewWebhookHTTPClient(time.Second)
+
+			// when
+			err := client.CheckRedirect(&http.Request{}, nil)
+
+			// then
+			Expect(err).To(Equal(http.ErrUseLastResponse))
+		})
+	})
+
+	DescribeTable("checkWebhookAddress",
+		func(address string, allowed bool) {
+			// when
+			err := checkWebhookAddress("tcp", address, nil)
+
+			// then
+			Expect(err == nil).To(Equal(allowed))
+		},
+		Entry("public IPv4", "93.184.216.34:443", true),
+		Entry("public IPv6", "[2606:2800:220:1:248:1893:25c8:1946]:443", true),
+		Entry("loopback", "127.0.0.1:443", false),
+		Entry("IPv6 loopback", "[::1]:443", false),
+		Entry("private", "10.1.2.3:443", false),
+		Entry("private 192.168", "192.168.1.1:80", false),
+		Entry("link-local metadata service", "169.254.169.254:80", false),
+		Entry("IPv4-mapped loopback", "[::ffff:127.0.0.1]:443", false),
+		Entry("unspecified", "0.0.0.0:443", false),
+		Entry("shared address space", "100.64.0.1:443", false),
+		Entry("IPv6 unique local", "[fd00::1]:443", false),
+	)
+})
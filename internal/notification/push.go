@@ -0,0 +1,36 @@
+package notification
+
+import (
+	"context"
+	"firebase.google.com/go/v4/messaging"
+	"fmt"
+	"github.com/avialog/backend/internal/infrastructure"
+)
+
+type pushChannel struct {
+	client infrastructure.MessagingClient
+}
+
+// NewPushChannel returns a channel sending push notifications through Firebase Cloud Messaging.
+func NewPushChannel(client infrastructure.MessagingClient) Channel {
+	return &pushChannel{client: client}
+}
+
+func (p *pushChannel) Send(ctx context.Context, recipient Recipient, message Message) error {
+	if recipient.DeviceToken == nil || *recipient.DeviceToken == "" {
+		return ErrNoAddress
+	}
+
+	_, err := p.client.Send(ctx, &messaging.Message{
+		Token: *recipient.DeviceToken,
+		Notification: &messaging.Notification{
+			Title: message.Title,
+			Body:  message.Body,
+		},
+		Data: message.Data,
+	})
+	if err != nil {
+		return fmt.Errorf("error sending push notification to user %s: %w", recipient.UserID, err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/infrastructure"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// errInternalAddress is returned when a webhook URL leads to an address the server must not call on behalf of a user.
+var errInternalAddress = errors.New("webhook address is not public")
+
+// nonPublicPrefixes are ranges not covered by the checks of netip.Addr which are not reachable from the internet.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+type webhookChannel struct {
+	httpClient infrastructure.HTTPClient
+}
+
+type webhookPayload struct {
+	UserID string            `json:"user_id"`
+	Title  string            `json:"title"`
+	Body   string            `json:"body"`
+	Data   map[string]string `json:"data"`
+}
+
+// NewWebhookChannel returns a channel posting messages as JSON to the URL chosen by the user. The client should be one
+// returned by NewWebhookHTTPClient.
+func NewWebhookChannel(httpClient infrastructure.HTTPClient) Channel {
+	return &webhookChannel{httpClient: httpClient}
+}
+
+// NewWebhookHTTPClient returns a client for URLs chosen by users. It only connects to public addresses, checked after
+// the host is resolved so a host cannot resolve to an internal service, and it does not follow redirects. Proxies from
+// the environment are not used, as the client would only see the address of the proxy.
+func NewWebhookHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: checkWebhookAddress}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// checkWebhookAddress rejects connections to loopback, private, link-local and other non-public addresses.
+func checkWebhookAddress(_ string, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errInternalAddress, err)
+	}
+
+	addr := addrPort.Addr().Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return fmt.Errorf("%w: %v", errInternalAddress, addr)
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return fmt.Errorf("%w: %v", errInternalAddress, addr)
+		}
+	}
+	return nil
+}
+
+func (w *webhookChannel) Send(ctx context.Context, recipient Recipient, message Message) error {
+	if recipient.WebhookURL == nil || *recipient.WebhookURL == "" {
+		return ErrNoAddress
+	}
+
+	payload, err := json.Marshal(webhookPayload{
+		UserID: recipient.UserID,
+		Title:  message.Title,
+		Body:   message.Body,
+		Data:   message.Data,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, *recipient.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("error creating webhook request for user %s: %w", recipient.UserID, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error calling webhook of user %s: %w", recipient.UserID, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook of user %s responded with status %d", recipient.UserID, resp.StatusCode)
+	}
+	return nil
+}
@@ -4,17 +4,23 @@ import (
 	"errors"
 	"fmt"
 	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/infrastructure"
 	"github.com/avialog/backend/internal/model"
 	"gorm.io/gorm"
+	"time"
 )
 
 //go:generate mockgen -source=aircraft.go -destination=aircraft_mock.go -package repository
 type AircraftRepository interface {
 	Create(aircraft model.Aircraft) (model.Aircraft, error)
+	CreateTx(tx infrastructure.Database, aircraft model.Aircraft) (model.Aircraft, error)
 	GetByUserIDAndID(userID string, id uint) (model.Aircraft, error)
 	GetByUserID(userID string) ([]model.Aircraft, error)
+	GetByIDs(ids []uint) ([]model.Aircraft, error)
 	Save(aircraft model.Aircraft) (model.Aircraft, error)
+	SaveIfUnchanged(aircraft model.Aircraft, updatedAt time.Time) (model.Aircraft, error)
 	DeleteByUserIDAndID(userID string, id uint) error
+	DeleteIfUnchanged(userID string, id uint, updatedAt time.Time) error
 }
 
 type aircraft struct {
@@ -36,6 +42,15 @@ func (a *aircraft) Create(aircraft model.Aircraft) (model.Aircraft, error) {
 	return aircraft, nil
 }
 
+func (a *aircraft) CreateTx(tx infrastructure.Database, aircraft model.Aircraft) (model.Aircraft, error) {
+	result := tx.Create(&aircraft)
+	if result.Error != nil {
+		return model.Aircraft{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return aircraft, nil
+}
+
 func (a *aircraft) GetByUserIDAndID(userID string, id uint) (model.Aircraft, error) {
 	var aircraft model.Aircraft
 	result := a.db.Where("user_id = ? AND id = ?", userID, id).First(&aircraft)
@@ -48,6 +63,21 @@ func (a *aircraft) GetByUserIDAndID(userID string, id uint) (model.Aircraft, err
 	return aircraft, nil
 }
 
+// GetByIDs returns the aircraft with the given IDs, including the ones in the trash, as flights keep referencing a
+// deleted aircraft.
+func (a *aircraft) GetByIDs(ids []uint) ([]model.Aircraft, error) {
+	aircraft := make([]model.Aircraft, 0, len(ids))
+	if len(ids) == 0 {
+		return aircraft, nil
+	}
+
+	result := a.db.Unscoped().Where("id IN ?", ids).Find(&aircraft)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return aircraft, nil
+}
+
 func (a *aircraft) Save(aircraft model.Aircraft) (model.Aircraft, error) {
 	result := a.db.Save(&aircraft)
 	if result.Error != nil {
@@ -57,6 +87,15 @@ func (a *aircraft) Save(aircraft model.Aircraft) (model.Aircraft, error) {
 	return aircraft, nil
 }
 
+// SaveIfUnchanged saves the aircraft only if it is still at the given update time.
+func (a *aircraft) SaveIfUnchanged(aircraft model.Aircraft, updatedAt time.Time) (model.Aircraft, error) {
+	if err := saveIfUnchanged(a.db, &aircraft, updatedAt); err != nil {
+		return model.Aircraft{}, err
+	}
+
+	return aircraft, nil
+}
+
 func (a *aircraft) DeleteByUserIDAndID(userID string, id uint) error {
 	result := a.db.Where("id = ? AND user_id = ?", id, userID).Delete(&model.Aircraft{})
 	if result.Error != nil {
@@ -70,6 +109,11 @@ func (a *aircraft) DeleteByUserIDAndID(userID string, id uint) error {
 	return nil
 }
 
+// DeleteIfUnchanged deletes the aircraft only if it is still at the given update time.
+func (a *aircraft) DeleteIfUnchanged(userID string, id uint, updatedAt time.Time) error {
+	return deleteIfUnchanged(a.db, &model.Aircraft{}, userID, id, updatedAt)
+}
+
 func (a *aircraft) GetByUserID(userID string) ([]model.Aircraft, error) {
 	var aircraft []model.Aircraft
 	result := a.db.Where("user_id = ?", userID).Find(&aircraft)
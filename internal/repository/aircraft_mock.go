@@ -11,7 +11,9 @@ package repository
 
 import (
 	reflect "reflect"
+	time "time"
 
+	infrastructure "github.com/avialog/backend/internal/infrastructure"
 	model "github.com/avialog/backend/internal/model"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -54,6 +56,21 @@ func (mr *MockAircraftRepositoryMockRecorder) Create(aircraft any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAircraftRepository)(nil).Create), aircraft)
 }
 
+// CreateTx mocks base method.
+func (m *MockAircraftRepository) CreateTx(tx infrastructure.Database, aircraft model.Aircraft) (model.Aircraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTx", tx, aircraft)
+	ret0, _ := ret[0].(model.Aircraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTx indicates an expected call of CreateTx.
+func (mr *MockAircraftRepositoryMockRecorder) CreateTx(tx, aircraft any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTx", reflect.TypeOf((*MockAircraftRepository)(nil).CreateTx), tx, aircraft)
+}
+
 // DeleteByUserIDAndID mocks base method.
 func (m *MockAircraftRepository) DeleteByUserIDAndID(userID string, id uint) error {
 	m.ctrl.T.Helper()
@@ -68,6 +85,35 @@ func (mr *MockAircraftRepositoryMockRecorder) DeleteByUserIDAndID(userID, id any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserIDAndID", reflect.TypeOf((*MockAircraftRepository)(nil).DeleteByUserIDAndID), userID, id)
 }
 
+// DeleteIfUnchanged mocks base method.
+func (m *MockAircraftRepository) DeleteIfUnchanged(userID string, id uint, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIfUnchanged", userID, id, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIfUnchanged indicates an expected call of DeleteIfUnchanged.
+func (mr *MockAircraftRepositoryMockRecorder) DeleteIfUnchanged(userID, id, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIfUnchanged", reflect.TypeOf((*MockAircraftRepository)(nil).DeleteIfUnchanged), userID, id, updatedAt)
+}
+
+// GetByIDs mocks base method.
+func (m *MockAircraftRepository) GetByIDs(ids []uint) ([]model.Aircraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDs", ids)
+	ret0, _ := ret[0].([]model.Aircraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDs indicates an expected call of GetByIDs.
+func (mr *MockAircraftRepositoryMockRecorder) GetByIDs(ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDs", reflect.TypeOf((*MockAircraftRepository)(nil).GetByIDs), ids)
+}
+
 // GetByUserID mocks base method.
 func (m *MockAircraftRepository) GetByUserID(userID string) ([]model.Aircraft, error) {
 	m.ctrl.T.Helper()
@@ -112,3 +158,18 @@ func (mr *MockAircraftRepositoryMockRecorder) Save(aircraft any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockAircraftRepository)(nil).Save), aircraft)
 }
+
+// SaveIfUnchanged mocks base method.
+func (m *MockAircraftRepository) SaveIfUnchanged(aircraft model.Aircraft, updatedAt time.Time) (model.Aircraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveIfUnchanged", aircraft, updatedAt)
+	ret0, _ := ret[0].(model.Aircraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveIfUnchanged indicates an expected call of SaveIfUnchanged.
+func (mr *MockAircraftRepositoryMockRecorder) SaveIfUnchanged(aircraft, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveIfUnchanged", reflect.TypeOf((*MockAircraftRepository)(nil).SaveIfUnchanged), aircraft, updatedAt)
+}
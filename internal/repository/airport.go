@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AirportsFile is the path of the embedded dataset relative to the repository root, it is rewritten by cmd/airports.
+const AirportsFile = "internal/repository/data/airports.csv"
+
+var airportsHeader = []string{"icao", "iata", "name", "country", "latitude", "longitude", "elevation", "timezone"}
+
+var (
+	icaoCodePattern = regexp.MustCompile(`^[A-Z0-9]{4}$`)
+	iataCodePattern = regexp.MustCompile(`^[A-Z0-9]{3}$`)
+)
+
+//go:embed data/airports.csv
+var airportsCSV []byte
+
+//go:generate mockgen -source=airport.go -destination=airport_mock.go -package repository
+type AirportRepository interface {
+	GetByCode(code string) (model.Airport, error)
+	Search(query string, limit int) []model.Airport
+}
+
+type airport struct {
+	airports []model.Airport
+	byICAO   map[string]int
+	byIATA   map[string]int
+}
+
+func newAirportRepository(airports []model.Airport) AirportRepository {
+	repository := &airport{
+		airports: airports,
+		byICAO:   make(map[string]int, len(airports)),
+		byIATA:   make(map[string]int, len(airports)),
+	}
+	for i, a := range airports {
+		repository.byICAO[a.ICAOCode] = i
+		if a.IATACode != nil {
+			repository.byIATA[*a.IATACode] = i
+		}
+	}
+	return repository
+}
+
+// GetByCode finds the airport by its ICAO code, or by its IATA code if no airport has such ICAO code. Codes are
+// compared case-insensitively.
+func (a *airport) GetByCode(code string) (model.Airport, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if i, ok := a.byICAO[code]; ok {
+		return a.airports[i], nil
+	}
+	if i, ok := a.byIATA[code]; ok {
+		return a.airports[i], nil
+	}
+	return model.Airport{}, fmt.Errorf("%w: %v", dto.ErrNotFound, code)
+}
+
+// Search returns airports matching the query for autocomplete. Exact code matches come first, followed by airports
+// with a code starting with the query and airports with the query in their name.
+func (a *airport) Search(query string, limit int) []model.Airport {
+	query = strings.ToUpper(strings.TrimSpace(query))
+	if query == "" || limit <= 0 {
+		return []model.Airport{}
+	}
+
+	type match struct {
+		index int
+		rank  int
+	}
+	matches := make([]match, 0)
+	for i, airport := range a.airports {
+		iataCode := ""
+		if airport.IATACode != nil {
+			iataCode = *airport.IATACode
+		}
+
+		rank := -1
+		switch {
+		case airport.ICAOCode == query || iataCode == query:
+			rank = 0
+		case strings.HasPrefix(airport.ICAOCode, query) || (iataCode != "" && strings.HasPrefix(iataCode, query)):
+			rank = 1
+		case strings.Contains(strings.ToUpper(airport.Name), query):
+			rank = 2
+		}
+		if rank >= 0 {
+			matches = append(matches, match{index: i, rank: rank})
+		}
+	}
+
+	// airports are sorted by ICAO code, so a stable sort keeps matches of the same rank in that order
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].rank < matches[j].rank })
+
+	result := make([]model.Airport, 0, min(len(matches), limit))
+	for _, m := range matches {
+		if len(result) == limit {
+			break
+		}
+		result = append(result, a.airports[m.index])
+	}
+	return result
+}
+
+// ParseAirports reads airports in the format of the embedded dataset. The returned airports are sorted by ICAO code.
+func ParseAirports(reader io.Reader) ([]model.Airport, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = len(airportsHeader)
+
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid header: %v", err)
+	}
+	for i, name := range airportsHeader {
+		if strings.ToLower(strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff"))) != name {
+			return nil, fmt.Errorf("invalid header: expected columns %v", strings.Join(airportsHeader, ","))
+		}
+	}
+
+	airports := make([]model.Airport, 0)
+	icaoCodes := make(map[string]bool)
+	iataCodes := make(map[string]bool)
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := csvReader.FieldPos(0)
+		airport, err := parseAirport(record)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		if icaoCodes[airport.ICAOCode] {
+			return nil, fmt.Errorf("line %d: duplicate ICAO code: %v", line, airport.ICAOCode)
+		}
+		icaoCodes[airport.ICAOCode] = true
+		if airport.IATACode != nil {
+			if iataCodes[*airport.IATACode] {
+				return nil, fmt.Errorf("line %d: duplicate IATA code: %v", line, *airport.IATACode)
+			}
+			iataCodes[*airport.IATACode] = true
+		}
+
+		airports = append(airports, airport)
+	}
+
+	sort.Slice(airports, func(i, j int) bool { return airports[i].ICAOCode < airports[j].ICAOCode })
+	return airports, nil
+}
+
+func parseAirport(record []string) (model.Airport, error) {
+	for i := range record {
+		record[i] = strings.TrimSpace(record[i])
+	}
+
+	airport := model.Airport{
+		ICAOCode: strings.ToUpper(record[0]),
+		Name:     record[2],
+		Country:  model.Country(strings.ToUpper(record[3])),
+		Timezone: record[7],
+	}
+	if !icaoCodePattern.MatchString(airport.ICAOCode) {
+		return model.Airport{}, fmt.Errorf("invalid ICAO code: %v", record[0])
+	}
+	if record[1] != "" {
+		iataCode := strings.ToUpper(record[1])
+		if !iataCodePattern.MatchString(iataCode) {
+			return model.Airport{}, fmt.Errorf("invalid IATA code: %v", record[1])
+		}
+		airport.IATACode = &iataCode
+	}
+	if airport.Name == "" {
+		return model.Airport{}, errors.New("missing name")
+	}
+	if len(airport.Country) != 2 {
+		return model.Airport{}, fmt.Errorf("invalid country: %v", record[3])
+	}
+
+	var err error
+	if airport.Latitude, err = strconv.ParseFloat(record[4], 64); err != nil || airport.Latitude < -90 || airport.Latitude > 90 {
+		return model.Airport{}, fmt.Errorf("invalid latitude: %v", record[4])
+	}
+	if airport.Longitude, err = strconv.ParseFloat(record[5], 64); err != nil || airport.Longitude < -180 || airport.Longitude > 180 {
+		return model.Airport{}, fmt.Errorf("invalid longitude: %v", record[5])
+	}
+	if airport.Elevation, err = strconv.Atoi(record[6]); err != nil {
+		return model.Airport{}, fmt.Errorf("invalid elevation: %v", record[6])
+	}
+	if airport.Timezone == "" {
+		return model.Airport{}, errors.New("missing timezone")
+	}
+
+	return airport, nil
+}
+
+// WriteAirports writes airports in the format of the embedded dataset.
+func WriteAirports(writer io.Writer, airports []model.Airport) error {
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.Write(airportsHeader); err != nil {
+		return err
+	}
+	for _, airport := range airports {
+		iataCode := ""
+		if airport.IATACode != nil {
+			iataCode = *airport.IATACode
+		}
+		record := []string{
+			airport.ICAOCode,
+			iataCode,
+			airport.Name,
+			string(airport.Country),
+			strconv.FormatFloat(airport.Latitude, 'f', 4, 64),
+			strconv.FormatFloat(airport.Longitude, 'f', 4, 64),
+			strconv.Itoa(airport.Elevation),
+			airport.Timezone,
+		}
+		if err := csvWriter.Write(record); err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+func embeddedAirports() ([]model.Airport, error) {
+	airports, err := ParseAirports(bytes.NewReader(airportsCSV))
+	if err != nil {
+		return nil, fmt.Errorf("invalid airport dataset: %v", err)
+	}
+	return airports, nil
+}
@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: airport.go
+//
+// Generated by this command:
+//
+//	mockgen -source=airport.go -destination=airport_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/avialog/backend/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAirportRepository is a mock of AirportRepository interface.
+type MockAirportRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAirportRepositoryMockRecorder
+}
+
+// MockAirportRepositoryMockRecorder is the mock recorder for MockAirportRepository.
+type MockAirportRepositoryMockRecorder struct {
+	mock *MockAirportRepository
+}
+
+// NewMockAirportRepository creates a new mock instance.
+func NewMockAirportRepository(ctrl *gomock.Controller) *MockAirportRepository {
+	mock := &MockAirportRepository{ctrl: ctrl}
+	mock.recorder = &MockAirportRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAirportRepository) EXPECT() *MockAirportRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByCode mocks base method.
+func (m *MockAirportRepository) GetByCode(code string) (model.Airport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByCode", code)
+	ret0, _ := ret[0].(model.Airport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByCode indicates an expected call of GetByCode.
+func (mr *MockAirportRepositoryMockRecorder) GetByCode(code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByCode", reflect.TypeOf((*MockAirportRepository)(nil).GetByCode), code)
+}
+
+// Search mocks base method.
+func (m *MockAirportRepository) Search(query string, limit int) []model.Airport {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", query, limit)
+	ret0, _ := ret[0].([]model.Airport)
+	return ret0
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockAirportRepositoryMockRecorder) Search(query, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockAirportRepository)(nil).Search), query, limit)
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"bytes"
+	"errors"
+	"github.com/avialog/backend/internal/dto"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"strings"
+)
+
+var _ = Describe("AirportRepository", func() {
+	var airportRepository AirportRepository
+
+	BeforeEach(func() {
+		airports, err := embeddedAirports()
+		Expect(err).To(BeNil())
+		airportRepository = newAirportRepository(airports)
+	})
+
+	Describe("GetByCode", func() {
+		Context("when the code is an IATA code", func() {
+			It("should return the airport with its ICAO code", func() {
+				// when
+				airport, err := airportRepository.GetByCode(" waw ")
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(airport.ICAOCode).To(Equal("EPWA"))
+				Expect(airport.Timezone).To(Equal("Europe/Warsaw"))
+			})
+		})
+		Context("when the code is unknown", func() {
+			It("should return not found error", func() {
+				// when
+				_, err := airportRepository.GetByCode("ZZZZ")
+
+				// then
+				Expect(errors.Is(err, dto.ErrNotFound)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("Search", func() {
+		Context("when the query matches codes and names", func() {
+			It("should return exact code matches first", func() {
+				// when
+				airports := airportRepository.Search("wa", 3)
+
+				// then
+				Expect(airports).To(HaveLen(3))
+				Expect(airports[0].ICAOCode).To(Equal("EPWA"))
+			})
+		})
+		Context("when the query is part of the name", func() {
+			It("should return airports with matching names", func() {
+				// when
+				airports := airportRepository.Search("kraków", 10)
+
+				// then
+				Expect(airports).To(HaveLen(1))
+				Expect(airports[0].ICAOCode).To(Equal("EPKK"))
+			})
+		})
+	})
+
+	Describe("ParseAirports", func() {
+		Context("when the dataset is written back", func() {
+			It("should produce the embedded dataset", func() {
+				// given
+				airports, err := ParseAirports(bytes.NewReader(airportsCSV))
+				Expect(err).To(BeNil())
+				var buffer bytes.Buffer
+
+				// when
+				err = WriteAirports(&buffer, airports)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(buffer.String()).To(Equal(string(airportsCSV)))
+			})
+		})
+		Context("when an IATA code is duplicated", func() {
+			It("should return an error", func() {
+				// given
+				file := strings.NewReader("icao,iata,name,country,latitude,longitude,elevation,timezone\n" +
+					"EPWA,WAW,Warsaw Chopin Airport,PL,52.1657,20.9671,362,Europe/Warsaw\n" +
+					"EPMO,WAW,Warsaw Modlin Airport,PL,52.4511,20.6518,341,Europe/Warsaw\n")
+
+				// when
+				_, err := ParseAirports(file)
+
+				// then
+				Expect(err.Error()).To(Equal("line 3: duplicate IATA code: WAW"))
+			})
+		})
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"github.com/avialog/backend/internal/dto"
 	"github.com/avialog/backend/internal/model"
 	"gorm.io/gorm"
+	"time"
 )
 
 //go:generate mockgen -source=contact.go -destination=contact_mock.go -package repository
@@ -13,7 +14,9 @@ type ContactRepository interface {
 	GetByUserIDAndID(userID string, id uint) (model.Contact, error)
 	GetByUserID(userID string) ([]model.Contact, error)
 	Save(contact model.Contact) (model.Contact, error)
+	SaveIfUnchanged(contact model.Contact, updatedAt time.Time) (model.Contact, error)
 	DeleteByUserIDAndID(userID string, id uint) error
+	DeleteIfUnchanged(userID string, id uint, updatedAt time.Time) error
 }
 
 type contact struct {
@@ -54,6 +57,15 @@ func (c *contact) Save(contact model.Contact) (model.Contact, error) {
 	return contact, nil
 }
 
+// SaveIfUnchanged saves the contact only if it is still at the given update time.
+func (c *contact) SaveIfUnchanged(contact model.Contact, updatedAt time.Time) (model.Contact, error) {
+	if err := saveIfUnchanged(c.db, &contact, updatedAt); err != nil {
+		return model.Contact{}, err
+	}
+
+	return contact, nil
+}
+
 func (c *contact) DeleteByUserIDAndID(userID string, id uint) error {
 	result := c.db.Where("id = ? AND user_id = ?", id, userID).Delete(&model.Contact{})
 	if result.Error != nil {
@@ -61,12 +73,17 @@ func (c *contact) DeleteByUserIDAndID(userID string, id uint) error {
 	}
 
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("contact %d for user %s not found: %w", id, userID, dto.ErrNotFound)
+		return fmt.Errorf("%w: %v", dto.ErrNotFound, "contact not found")
 	}
 
 	return nil
 }
 
+// DeleteIfUnchanged deletes the contact only if it is still at the given update time.
+func (c *contact) DeleteIfUnchanged(userID string, id uint, updatedAt time.Time) error {
+	return deleteIfUnchanged(c.db, &model.Contact{}, userID, id, updatedAt)
+}
+
 func (c *contact) GetByUserID(userID string) ([]model.Contact, error) {
 	var contact []model.Contact
 
@@ -11,6 +11,7 @@ package repository
 
 import (
 	reflect "reflect"
+	time "time"
 
 	model "github.com/avialog/backend/internal/model"
 	gomock "go.uber.org/mock/gomock"
@@ -68,6 +69,20 @@ func (mr *MockContactRepositoryMockRecorder) DeleteByUserIDAndID(userID, id any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserIDAndID", reflect.TypeOf((*MockContactRepository)(nil).DeleteByUserIDAndID), userID, id)
 }
 
+// DeleteIfUnchanged mocks base method.
+func (m *MockContactRepository) DeleteIfUnchanged(userID string, id uint, updatedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIfUnchanged", userID, id, updatedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIfUnchanged indicates an expected call of DeleteIfUnchanged.
+func (mr *MockContactRepositoryMockRecorder) DeleteIfUnchanged(userID, id, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIfUnchanged", reflect.TypeOf((*MockContactRepository)(nil).DeleteIfUnchanged), userID, id, updatedAt)
+}
+
 // GetByUserID mocks base method.
 func (m *MockContactRepository) GetByUserID(userID string) ([]model.Contact, error) {
 	m.ctrl.T.Helper()
@@ -112,3 +127,18 @@ func (mr *MockContactRepositoryMockRecorder) Save(contact any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockContactRepository)(nil).Save), contact)
 }
+
+// SaveIfUnchanged mocks base method.
+func (m *MockContactRepository) SaveIfUnchanged(contact model.Contact, updatedAt time.Time) (model.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveIfUnchanged", contact, updatedAt)
+	ret0, _ := ret[0].(model.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveIfUnchanged indicates an expected call of SaveIfUnchanged.
+func (mr *MockContactRepositoryMockRecorder) SaveIfUnchanged(contact, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveIfUnchanged", reflect.TypeOf((*MockContactRepository)(nil).SaveIfUnchanged), contact, updatedAt)
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/avialog/backend/internal/infrastructure"
 	"github.com/avialog/backend/internal/model"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"strings"
 	"time"
 )
 
@@ -15,18 +17,27 @@ type FlightRepository interface {
 	Create(flight model.Flight) (model.Flight, error)
 	GetByID(id uint) (model.Flight, error)
 	GetByUserID(userID string) ([]model.Flight, error)
+	GetByUserIDOrderedByTakeoffTime(userID string, start, end *time.Time) ([]model.Flight, error)
 	GetByAircraftID(aircraftID uint) ([]model.Flight, error)
 	Save(flight model.Flight) (model.Flight, error)
 	DeleteByID(id uint) error
 	CountByUserIDAndAircraftID(userID string, aircraftID uint) (int64, error)
 	GetByUserIDAndDate(userID string, start, end time.Time) ([]model.Flight, error)
+	GetByUserIDAndTimeWindow(userID string, start, end time.Time) ([]model.Flight, error)
+	GetByUserIDAndTimeWindowTx(tx infrastructure.Database, userID string, start, end time.Time) ([]model.Flight, error)
+	GetPageByUserID(userID string, filter dto.LogbookFilter, after *dto.LogbookCursor, limit int) ([]model.Flight, error)
 	Begin() infrastructure.Database
 	CreateTx(tx infrastructure.Database, flight model.Flight) (model.Flight, error)
-	DeleteByIDTx(tx infrastructure.Database, id uint) error
 	GetByIDTx(tx infrastructure.Database, id uint) (model.Flight, error)
+	GetByIDForUpdateTx(tx infrastructure.Database, id uint) (model.Flight, error)
 	SaveTx(tx infrastructure.Database, flight model.Flight) (model.Flight, error)
+	GetTotalsByUserID(userID string, filter dto.TotalsFilter) (dto.TotalsResponse, error)
+	GetAirplaneTotalsByRoleAndStyle(userID string) ([]dto.ProgressTotals, error)
 }
 
+// likeEscaper escapes wildcards of LIKE patterns, so the text is matched literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 type flight struct {
 	db *gorm.DB
 }
@@ -116,6 +127,25 @@ func (f *flight) GetByUserID(userID string) ([]model.Flight, error) {
 	return flights, nil
 }
 
+func (f *flight) GetByUserIDOrderedByTakeoffTime(userID string, start, end *time.Time) ([]model.Flight, error) {
+	var flights []model.Flight
+
+	query := f.db.Where("user_id = ?", userID)
+	if start != nil {
+		query = query.Where("takeoff_time >= ?", *start)
+	}
+	if end != nil {
+		query = query.Where("takeoff_time <= ?", *end)
+	}
+
+	result := query.Order("takeoff_time asc").Find(&flights)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return flights, nil
+}
+
 func (f *flight) GetByAircraftID(aircraftID uint) ([]model.Flight, error) {
 	var flights []model.Flight
 
@@ -147,15 +177,80 @@ func (f *flight) GetByUserIDAndDate(userID string, start, end time.Time) ([]mode
 	return flights, nil
 }
 
-func (f *flight) DeleteByIDTx(tx infrastructure.Database, id uint) error {
-	result := tx.Delete(&model.Flight{}, id)
+// GetByUserIDAndTimeWindow returns flights of the user which are in the air at any time between start and end.
+func (f *flight) GetByUserIDAndTimeWindow(userID string, start, end time.Time) ([]model.Flight, error) {
+	var flights []model.Flight
+
+	result := f.db.Where("user_id = ? AND takeoff_time <= ? AND landing_time >= ?", userID, end, start).
+		Order("takeoff_time asc").Find(&flights)
 	if result.Error != nil {
-		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
 	}
-	if result.RowsAffected == 0 {
-		return fmt.Errorf("%w: flight cannot be deleted", dto.ErrNotFound)
+
+	return flights, nil
+}
+
+// GetByUserIDAndTimeWindowTx returns flights of the user which are in the air at any time between start and end, with
+// the changes of the transaction.
+func (f *flight) GetByUserIDAndTimeWindowTx(tx infrastructure.Database, userID string, start, end time.Time) ([]model.Flight, error) {
+	var flights []model.Flight
+
+	result := tx.Where("user_id = ? AND takeoff_time <= ? AND landing_time >= ?", userID, end, start).
+		Order("takeoff_time asc").Find(&flights)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
 	}
-	return nil
+
+	return flights, nil
+}
+
+// GetPageByUserID returns at most limit flights of the user matching the filter, sorted by takeoff time and ID. If
+// after is set, the page starts with the first flight following it in the sort order.
+func (f *flight) GetPageByUserID(userID string, filter dto.LogbookFilter, after *dto.LogbookCursor, limit int) ([]model.Flight, error) {
+	var flights []model.Flight
+
+	query := f.db.Where("user_id = ?", userID)
+	if filter.Start != nil {
+		query = query.Where("takeoff_time >= ?", *filter.Start)
+	}
+	if filter.End != nil {
+		query = query.Where("takeoff_time <= ?", *filter.End)
+	}
+	if filter.AircraftID != nil {
+		query = query.Where("aircraft_id = ?", *filter.AircraftID)
+	}
+	if filter.AirportCode != nil {
+		query = query.Where("(takeoff_airport_code = ? OR landing_airport_code = ?)", *filter.AirportCode, *filter.AirportCode)
+	}
+	if filter.Role != nil {
+		query = query.Where("my_role = ?", *filter.Role)
+	}
+	if filter.Style != nil {
+		query = query.Where("style = ?", *filter.Style)
+	}
+	if filter.Remarks != nil {
+		pattern := "%" + likeEscaper.Replace(*filter.Remarks) + "%"
+		query = query.Where("(remarks ILIKE ? OR personal_remarks ILIKE ?)", pattern, pattern)
+	}
+
+	if filter.Order == dto.SortAscending {
+		if after != nil {
+			query = query.Where("(takeoff_time, id) > (?, ?)", after.TakeoffTime, after.ID)
+		}
+		query = query.Order("takeoff_time asc, id asc")
+	} else {
+		if after != nil {
+			query = query.Where("(takeoff_time, id) < (?, ?)", after.TakeoffTime, after.ID)
+		}
+		query = query.Order("takeoff_time desc, id desc")
+	}
+
+	result := query.Limit(limit).Find(&flights)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return flights, nil
 }
 
 func (f *flight) GetByIDTx(tx infrastructure.Database, id uint) (model.Flight, error) {
@@ -167,3 +262,130 @@ func (f *flight) GetByIDTx(tx infrastructure.Database, id uint) (model.Flight, e
 
 	return flight, nil
 }
+
+// GetByIDForUpdateTx returns the flight and locks its row until the end of the transaction, so writes that check the
+// flight before changing it are applied one after another.
+func (f *flight) GetByIDForUpdateTx(tx infrastructure.Database, id uint) (model.Flight, error) {
+	var flight model.Flight
+	result := tx.Where("id = ?", id).Clauses(clause.Locking{Strength: "UPDATE"}).First(&flight)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return model.Flight{}, fmt.Errorf("%w: %v", dto.ErrNotFound, result.Error)
+		}
+		return model.Flight{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return flight, nil
+}
+
+func (f *flight) GetTotalsByUserID(userID string, filter dto.TotalsFilter) (dto.TotalsResponse, error) {
+	var totals dto.TotalsResponse
+
+	query := f.filterTotals(f.db.Model(&model.Flight{}), userID, filter)
+	result := query.Select(`COUNT(*) AS flight_count,
+		COALESCE(SUM(flights.total_block_time), 0)::bigint AS total_block_time,
+		COALESCE(SUM(flights.pilot_in_command_time), 0)::bigint AS pilot_in_command_time,
+		COALESCE(SUM(flights.second_in_command_time), 0)::bigint AS second_in_command_time,
+		COALESCE(SUM(flights.dual_received_time), 0)::bigint AS dual_received_time,
+		COALESCE(SUM(flights.dual_given_time), 0)::bigint AS dual_given_time,
+		COALESCE(SUM(flights.multi_pilot_time), 0)::bigint AS multi_pilot_time,
+		COALESCE(SUM(flights.night_time), 0)::bigint AS night_time,
+		COALESCE(SUM(flights.ifr_time), 0)::bigint AS ifr_time,
+		COALESCE(SUM(flights.ifr_actual_time), 0)::bigint AS ifr_actual_time,
+		COALESCE(SUM(flights.ifr_simulated_time), 0)::bigint AS ifr_simulated_time,
+		COALESCE(SUM(flights.cross_country_time), 0)::bigint AS cross_country_time,
+		COALESCE(SUM(flights.simulator_time), 0)::bigint AS simulator_time`).Scan(&totals)
+	if result.Error != nil {
+		return dto.TotalsResponse{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	var landingTotals struct {
+		LandingCount      int64
+		DayLandingCount   int64
+		NightLandingCount int64
+	}
+	query = f.filterTotals(f.db.Model(&model.Landing{}).
+		Joins("JOIN flights ON flights.id = landings.flight_id AND flights.deleted_at IS NULL"), userID, filter)
+	result = query.Select(`COALESCE(SUM(landings.count), 0)::bigint AS landing_count,
+		COALESCE(SUM(landings.day_count), 0)::bigint AS day_landing_count,
+		COALESCE(SUM(landings.night_count), 0)::bigint AS night_landing_count`).Scan(&landingTotals)
+	if result.Error != nil {
+		return dto.TotalsResponse{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	totals.LandingCount = landingTotals.LandingCount
+	totals.DayLandingCount = landingTotals.DayLandingCount
+	totals.NightLandingCount = landingTotals.NightLandingCount
+
+	return totals, nil
+}
+
+// GetAirplaneTotalsByRoleAndStyle returns the totals of the flights of the user in each role and style, counting only
+// flights in aeroplanes or in aircraft without a category.
+func (f *flight) GetAirplaneTotalsByRoleAndStyle(userID string) ([]dto.ProgressTotals, error) {
+	var totals []dto.ProgressTotals
+	result := f.filterAirplanes(f.db.Model(&model.Flight{}), userID).
+		Select(`flights.my_role AS role, flights.style AS style,
+		COALESCE(SUM(flights.total_block_time), 0)::bigint AS total_block_time,
+		COALESCE(SUM(flights.pilot_in_command_time), 0)::bigint AS pilot_in_command_time,
+		COALESCE(SUM(flights.second_in_command_time), 0)::bigint AS second_in_command_time,
+		COALESCE(SUM(flights.dual_received_time), 0)::bigint AS dual_received_time,
+		COALESCE(SUM(flights.multi_pilot_time), 0)::bigint AS multi_pilot_time,
+		COALESCE(SUM(flights.night_time), 0)::bigint AS night_time,
+		COALESCE(SUM(flights.ifr_time), 0)::bigint AS ifr_time,
+		COALESCE(SUM(flights.cross_country_time), 0)::bigint AS cross_country_time`).
+		Group("flights.my_role, flights.style").
+		Order("flights.my_role, flights.style").
+		Scan(&totals)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	var landingTotals []struct {
+		Role              model.Role
+		Style             model.Style
+		NightLandingCount int64
+	}
+	result = f.filterAirplanes(f.db.Model(&model.Landing{}).
+		Joins("JOIN flights ON flights.id = landings.flight_id AND flights.deleted_at IS NULL"), userID).
+		Select(`flights.my_role AS role, flights.style AS style,
+		COALESCE(SUM(landings.night_count), 0)::bigint AS night_landing_count`).
+		Group("flights.my_role, flights.style").
+		Scan(&landingTotals)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	for _, landingTotal := range landingTotals {
+		for i := range totals {
+			if totals[i].Role == landingTotal.Role && totals[i].Style == landingTotal.Style {
+				totals[i].NightLandingCount = landingTotal.NightLandingCount
+			}
+		}
+	}
+
+	return totals, nil
+}
+
+func (f *flight) filterAirplanes(query *gorm.DB, userID string) *gorm.DB {
+	return query.Joins("LEFT JOIN aircrafts ON aircrafts.id = flights.aircraft_id").
+		Where("flights.user_id = ?", userID).
+		Where("(aircrafts.category IS NULL OR aircrafts.category = ?)", model.AircraftCategoryAirplane)
+}
+
+func (f *flight) filterTotals(query *gorm.DB, userID string, filter dto.TotalsFilter) *gorm.DB {
+	query = query.Where("flights.user_id = ?", userID)
+	if filter.Start != nil {
+		query = query.Where("flights.takeoff_time >= ?", *filter.Start)
+	}
+	if filter.End != nil {
+		query = query.Where("flights.takeoff_time <= ?", *filter.End)
+	}
+	if filter.AircraftID != nil {
+		query = query.Where("flights.aircraft_id = ?", *filter.AircraftID)
+	}
+	if filter.Role != nil {
+		query = query.Where("flights.my_role = ?", *filter.Role)
+	}
+	return query
+}
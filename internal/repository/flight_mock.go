@@ -13,6 +13,7 @@ import (
 	reflect "reflect"
 	time "time"
 
+	dto "github.com/avialog/backend/internal/dto"
 	infrastructure "github.com/avialog/backend/internal/infrastructure"
 	model "github.com/avialog/backend/internal/model"
 	gomock "go.uber.org/mock/gomock"
@@ -114,18 +115,19 @@ func (mr *MockFlightRepositoryMockRecorder) DeleteByID(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockFlightRepository)(nil).DeleteByID), id)
 }
 
-// DeleteByIDTx mocks base method.
-func (m *MockFlightRepository) DeleteByIDTx(tx infrastructure.Database, id uint) error {
+// GetAirplaneTotalsByRoleAndStyle mocks base method.
+func (m *MockFlightRepository) GetAirplaneTotalsByRoleAndStyle(userID string) ([]dto.ProgressTotals, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteByIDTx", tx, id)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "GetAirplaneTotalsByRoleAndStyle", userID)
+	ret0, _ := ret[0].([]dto.ProgressTotals)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// DeleteByIDTx indicates an expected call of DeleteByIDTx.
-func (mr *MockFlightRepositoryMockRecorder) DeleteByIDTx(tx, id any) *gomock.Call {
+// GetAirplaneTotalsByRoleAndStyle indicates an expected call of GetAirplaneTotalsByRoleAndStyle.
+func (mr *MockFlightRepositoryMockRecorder) GetAirplaneTotalsByRoleAndStyle(userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByIDTx", reflect.TypeOf((*MockFlightRepository)(nil).DeleteByIDTx), tx, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAirplaneTotalsByRoleAndStyle", reflect.TypeOf((*MockFlightRepository)(nil).GetAirplaneTotalsByRoleAndStyle), userID)
 }
 
 // GetByAircraftID mocks base method.
@@ -158,6 +160,21 @@ func (mr *MockFlightRepositoryMockRecorder) GetByID(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockFlightRepository)(nil).GetByID), id)
 }
 
+// GetByIDForUpdateTx mocks base method.
+func (m *MockFlightRepository) GetByIDForUpdateTx(tx infrastructure.Database, id uint) (model.Flight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdateTx", tx, id)
+	ret0, _ := ret[0].(model.Flight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdateTx indicates an expected call of GetByIDForUpdateTx.
+func (mr *MockFlightRepositoryMockRecorder) GetByIDForUpdateTx(tx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdateTx", reflect.TypeOf((*MockFlightRepository)(nil).GetByIDForUpdateTx), tx, id)
+}
+
 // GetByIDTx mocks base method.
 func (m *MockFlightRepository) GetByIDTx(tx infrastructure.Database, id uint) (model.Flight, error) {
 	m.ctrl.T.Helper()
@@ -203,6 +220,81 @@ func (mr *MockFlightRepositoryMockRecorder) GetByUserIDAndDate(userID, start, en
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserIDAndDate", reflect.TypeOf((*MockFlightRepository)(nil).GetByUserIDAndDate), userID, start, end)
 }
 
+// GetByUserIDAndTimeWindow mocks base method.
+func (m *MockFlightRepository) GetByUserIDAndTimeWindow(userID string, start, end time.Time) ([]model.Flight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserIDAndTimeWindow", userID, start, end)
+	ret0, _ := ret[0].([]model.Flight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserIDAndTimeWindow indicates an expected call of GetByUserIDAndTimeWindow.
+func (mr *MockFlightRepositoryMockRecorder) GetByUserIDAndTimeWindow(userID, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserIDAndTimeWindow", reflect.TypeOf((*MockFlightRepository)(nil).GetByUserIDAndTimeWindow), userID, start, end)
+}
+
+// GetByUserIDAndTimeWindowTx mocks base method.
+func (m *MockFlightRepository) GetByUserIDAndTimeWindowTx(tx infrastructure.Database, userID string, start, end time.Time) ([]model.Flight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserIDAndTimeWindowTx", tx, userID, start, end)
+	ret0, _ := ret[0].([]model.Flight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserIDAndTimeWindowTx indicates an expected call of GetByUserIDAndTimeWindowTx.
+func (mr *MockFlightRepositoryMockRecorder) GetByUserIDAndTimeWindowTx(tx, userID, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserIDAndTimeWindowTx", reflect.TypeOf((*MockFlightRepository)(nil).GetByUserIDAndTimeWindowTx), tx, userID, start, end)
+}
+
+// GetByUserIDOrderedByTakeoffTime mocks base method.
+func (m *MockFlightRepository) GetByUserIDOrderedByTakeoffTime(userID string, start, end *time.Time) ([]model.Flight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserIDOrderedByTakeoffTime", userID, start, end)
+	ret0, _ := ret[0].([]model.Flight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserIDOrderedByTakeoffTime indicates an expected call of GetByUserIDOrderedByTakeoffTime.
+func (mr *MockFlightRepositoryMockRecorder) GetByUserIDOrderedByTakeoffTime(userID, start, end any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserIDOrderedByTakeoffTime", reflect.TypeOf((*MockFlightRepository)(nil).GetByUserIDOrderedByTakeoffTime), userID, start, end)
+}
+
+// GetPageByUserID mocks base method.
+func (m *MockFlightRepository) GetPageByUserID(userID string, filter dto.LogbookFilter, after *dto.LogbookCursor, limit int) ([]model.Flight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPageByUserID", userID, filter, after, limit)
+	ret0, _ := ret[0].([]model.Flight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPageByUserID indicates an expected call of GetPageByUserID.
+func (mr *MockFlightRepositoryMockRecorder) GetPageByUserID(userID, filter, after, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPageByUserID", reflect.TypeOf((*MockFlightRepository)(nil).GetPageByUserID), userID, filter, after, limit)
+}
+
+// GetTotalsByUserID mocks base method.
+func (m *MockFlightRepository) GetTotalsByUserID(userID string, filter dto.TotalsFilter) (dto.TotalsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTotalsByUserID", userID, filter)
+	ret0, _ := ret[0].(dto.TotalsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTotalsByUserID indicates an expected call of GetTotalsByUserID.
+func (mr *MockFlightRepositoryMockRecorder) GetTotalsByUserID(userID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTotalsByUserID", reflect.TypeOf((*MockFlightRepository)(nil).GetTotalsByUserID), userID, filter)
+}
+
 // Save mocks base method.
 func (m *MockFlightRepository) Save(flight model.Flight) (model.Flight, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/infrastructure"
+	"github.com/avialog/backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -source=flight_version.go -destination=flight_version_mock.go -package repository
+type FlightVersionRepository interface {
+	LockUserTx(tx infrastructure.Database, userID string) error
+	GetHeadTx(tx infrastructure.Database, userID string) (model.FlightVersion, error)
+	CreateTx(tx infrastructure.Database, version model.FlightVersion) (model.FlightVersion, error)
+	GetByUserID(userID string) ([]model.FlightVersion, error)
+	GetByUserIDAndFlightID(userID string, flightID uint) ([]model.FlightVersion, error)
+}
+
+type flightVersion struct {
+	db *gorm.DB
+}
+
+func newFlightVersionRepository(db *gorm.DB) FlightVersionRepository {
+	return &flightVersion{
+		db: db,
+	}
+}
+
+// LockUserTx locks the user until the end of the transaction, so concurrent transactions append their versions to the
+// chain one after another. Transactions changing the logbook lock the user before anything else they lock.
+func (f *flightVersion) LockUserTx(tx infrastructure.Database, userID string) error {
+	result := tx.Where("id = ?", userID).Clauses(clause.Locking{Strength: "UPDATE"}).First(&model.User{})
+	if result.Error != nil {
+		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return nil
+}
+
+// GetHeadTx returns the latest version of the user. The user has to be locked with LockUserTx, otherwise a concurrent
+// transaction can append a version after the head was read.
+func (f *flightVersion) GetHeadTx(tx infrastructure.Database, userID string) (model.FlightVersion, error) {
+	var version model.FlightVersion
+	result := tx.Where("user_id = ?", userID).Order("sequence desc").First(&version)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return model.FlightVersion{}, fmt.Errorf("%w: %v", dto.ErrNotFound, result.Error)
+		}
+		return model.FlightVersion{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return version, nil
+}
+
+func (f *flightVersion) CreateTx(tx infrastructure.Database, version model.FlightVersion) (model.FlightVersion, error) {
+	result := tx.Create(&version)
+	if result.Error != nil {
+		return model.FlightVersion{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return version, nil
+}
+
+func (f *flightVersion) GetByUserID(userID string) ([]model.FlightVersion, error) {
+	versions := make([]model.FlightVersion, 0)
+	result := f.db.Where("user_id = ?", userID).Order("sequence asc").Find(&versions)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return versions, nil
+}
+
+func (f *flightVersion) GetByUserIDAndFlightID(userID string, flightID uint) ([]model.FlightVersion, error) {
+	versions := make([]model.FlightVersion, 0)
+	result := f.db.Where("user_id = ? AND flight_id = ?", userID, flightID).Order("sequence asc").Find(&versions)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return versions, nil
+}
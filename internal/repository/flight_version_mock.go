@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: flight_version.go
+//
+// Generated by this command:
+//
+//	mockgen -source=flight_version.go -destination=flight_version_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	reflect "reflect"
+
+	infrastructure "github.com/avialog/backend/internal/infrastructure"
+	model "github.com/avialog/backend/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFlightVersionRepository is a mock of FlightVersionRepository interface.
+type MockFlightVersionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFlightVersionRepositoryMockRecorder
+}
+
+// MockFlightVersionRepositoryMockRecorder is the mock recorder for MockFlightVersionRepository.
+type MockFlightVersionRepositoryMockRecorder struct {
+	mock *MockFlightVersionRepository
+}
+
+// NewMockFlightVersionRepository creates a new mock instance.
+func NewMockFlightVersionRepository(ctrl *gomock.Controller) *MockFlightVersionRepository {
+	mock := &MockFlightVersionRepository{ctrl: ctrl}
+	mock.recorder = &MockFlightVersionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFlightVersionRepository) EXPECT() *MockFlightVersionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateTx mocks base method.
+func (m *MockFlightVersionRepository) CreateTx(tx infrastructure.Database, version model.FlightVersion) (model.FlightVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTx", tx, version)
+	ret0, _ := ret[0].(model.FlightVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTx indicates an expected call of CreateTx.
+func (mr *MockFlightVersionRepositoryMockRecorder) CreateTx(tx, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTx", reflect.TypeOf((*MockFlightVersionRepository)(nil).CreateTx), tx, version)
+}
+
+// GetByUserID mocks base method.
+func (m *MockFlightVersionRepository) GetByUserID(userID string) ([]model.FlightVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", userID)
+	ret0, _ := ret[0].([]model.FlightVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockFlightVersionRepositoryMockRecorder) GetByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockFlightVersionRepository)(nil).GetByUserID), userID)
+}
+
+// GetByUserIDAndFlightID mocks base method.
+func (m *MockFlightVersionRepository) GetByUserIDAndFlightID(userID string, flightID uint) ([]model.FlightVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserIDAndFlightID", userID, flightID)
+	ret0, _ := ret[0].([]model.FlightVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserIDAndFlightID indicates an expected call of GetByUserIDAndFlightID.
+func (mr *MockFlightVersionRepositoryMockRecorder) GetByUserIDAndFlightID(userID, flightID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserIDAndFlightID", reflect.TypeOf((*MockFlightVersionRepository)(nil).GetByUserIDAndFlightID), userID, flightID)
+}
+
+// GetHeadTx mocks base method.
+func (m *MockFlightVersionRepository) GetHeadTx(tx infrastructure.Database, userID string) (model.FlightVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHeadTx", tx, userID)
+	ret0, _ := ret[0].(model.FlightVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeadTx indicates an expected call of GetHeadTx.
+func (mr *MockFlightVersionRepositoryMockRecorder) GetHeadTx(tx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeadTx", reflect.TypeOf((*MockFlightVersionRepository)(nil).GetHeadTx), tx, userID)
+}
+
+// LockUserTx mocks base method.
+func (m *MockFlightVersionRepository) LockUserTx(tx infrastructure.Database, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockUserTx", tx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockUserTx indicates an expected call of LockUserTx.
+func (mr *MockFlightVersionRepositoryMockRecorder) LockUserTx(tx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUserTx", reflect.TypeOf((*MockFlightVersionRepository)(nil).LockUserTx), tx, userID)
+}
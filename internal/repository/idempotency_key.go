@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+//go:generate mockgen -source=idempotency_key.go -destination=idempotency_key_mock.go -package repository
+type IdempotencyKeyRepository interface {
+	Acquire(key model.IdempotencyKey, now time.Time) (model.IdempotencyKey, bool, error)
+	Complete(userID, key, lockToken string, statusCode int, contentType string, body []byte) error
+	Release(userID, key, lockToken string) error
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+type idempotencyKey struct {
+	db *gorm.DB
+}
+
+func newIdempotencyKeyRepository(db *gorm.DB) IdempotencyKeyRepository {
+	return &idempotencyKey{
+		db: db,
+	}
+}
+
+// Acquire stores the key unless the user already sent it and returns whether the caller owns it and has to execute the
+// request. An expired key, or a key of the same request abandoned in progress, is taken over. The unique index on the
+// user and the key lets a single one of concurrent requests own the key, whichever replica it reaches. Otherwise the
+// stored key is returned.
+func (i *idempotencyKey) Acquire(key model.IdempotencyKey, now time.Time) (model.IdempotencyKey, bool, error) {
+	result := i.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "key"}},
+		DoNothing: true,
+	}).Create(&key)
+	if result.Error != nil {
+		return model.IdempotencyKey{}, false, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	if result.RowsAffected == 1 {
+		return key, true, nil
+	}
+
+	result = i.db.Model(&model.IdempotencyKey{}).
+		Where("user_id = ? AND key = ? AND (expires_at < ? OR (status_code IS NULL AND locked_until < ? AND request_hash = ?))",
+			key.UserID, key.Key, now, now, key.RequestHash).
+		Updates(map[string]interface{}{
+			"request_hash": key.RequestHash,
+			"status_code":  nil,
+			"content_type": nil,
+			"body":         nil,
+			"locked_until": key.LockedUntil,
+			"lock_token":   key.LockToken,
+			"expires_at":   key.ExpiresAt,
+		})
+	if result.Error != nil {
+		return model.IdempotencyKey{}, false, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	if result.RowsAffected == 1 {
+		return key, true, nil
+	}
+
+	var storedKey model.IdempotencyKey
+	result = i.db.Where("user_id = ? AND key = ?", key.UserID, key.Key).First(&storedKey)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			// the key expired and was deleted in the meantime
+			return model.IdempotencyKey{}, false, fmt.Errorf("%w: %v", dto.ErrConflict, "idempotency key changed, retry the request")
+		}
+		return model.IdempotencyKey{}, false, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return storedKey, false, nil
+}
+
+// Complete stores the response of the request holding the key. A request whose key was taken over by a retry does not
+// hold it anymore, so its response is not stored.
+func (i *idempotencyKey) Complete(userID, key, lockToken string, statusCode int, contentType string, body []byte) error {
+	result := i.db.Model(&model.IdempotencyKey{}).
+		Where("user_id = ? AND key = ? AND lock_token = ? AND status_code IS NULL", userID, key, lockToken).
+		Updates(map[string]interface{}{
+			"status_code":  statusCode,
+			"content_type": contentType,
+			"body":         body,
+		})
+	if result.Error != nil {
+		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %v", dto.ErrNotFound, "idempotency key is not held by the request")
+	}
+
+	return nil
+}
+
+// Release deletes a key in progress held by the request, so the request can be executed again.
+func (i *idempotencyKey) Release(userID, key, lockToken string) error {
+	result := i.db.Where("user_id = ? AND key = ? AND lock_token = ? AND status_code IS NULL", userID, key, lockToken).
+		Delete(&model.IdempotencyKey{})
+	if result.Error != nil {
+		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return nil
+}
+
+func (i *idempotencyKey) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result := i.db.WithContext(ctx).Where("expires_at < ?", now).Delete(&model.IdempotencyKey{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"os"
+	"time"
+)
+
+// The specs store keys in Postgres, they are skipped unless TEST_DSN points to a database they may write to.
+var _ = Describe("IdempotencyKeyRepository", func() {
+	var (
+		db                       *gorm.DB
+		idempotencyKeyRepository IdempotencyKeyRepository
+		userID                   string
+		now                      time.Time
+	)
+
+	BeforeEach(func() {
+		dsn := os.Getenv("TEST_DSN")
+		if dsn == "" {
+			Skip("TEST_DSN is not set")
+		}
+
+		var err error
+		db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+		Expect(err).To(BeNil())
+		_, err = NewRepositories(db)
+		Expect(err).To(BeNil())
+		idempotencyKeyRepository = newIdempotencyKeyRepository(db)
+
+		userID = fmt.Sprintf("idempotency-%d", time.Now().UnixNano())
+		now = time.Now().UTC().Truncate(time.Second)
+
+		DeferCleanup(func() {
+			db.Where("user_id = ?", userID).Delete(&model.IdempotencyKey{})
+		})
+	})
+
+	Context("when a retry takes over a key abandoned in progress", func() {
+		It("should keep the abandoned request from completing or releasing it", func() {
+			// given
+			_, acquired, err := idempotencyKeyRepository.Acquire(model.IdempotencyKey{UserID: userID, Key: "abc",
+				RequestHash: "hash", LockedUntil: now.Add(-time.Second), LockToken: "first",
+				ExpiresAt: now.Add(time.Hour)}, now.Add(-time.Minute))
+			Expect(err).To(BeNil())
+			Expect(acquired).To(BeTrue())
+			_, acquired, err = idempotencyKeyRepository.Acquire(model.IdempotencyKey{UserID: userID, Key: "abc",
+				RequestHash: "hash", LockedUntil: now.Add(time.Minute), LockToken: "second",
+				ExpiresAt: now.Add(time.Hour)}, now)
+			Expect(err).To(BeNil())
+			Expect(acquired).To(BeTrue())
+
+			// when
+			completeErr := idempotencyKeyRepository.Complete(userID, "abc", "first", 201, "application/json", []byte(`{"id":1}`))
+			releaseErr := idempotencyKeyRepository.Release(userID, "abc", "first")
+
+			// then
+			Expect(errors.Is(completeErr, dto.ErrNotFound)).To(BeTrue())
+			Expect(releaseErr).To(BeNil())
+			Expect(idempotencyKeyRepository.Complete(userID, "abc", "second", 201, "application/json", []byte(`{"id":2}`))).To(BeNil())
+			var storedKey model.IdempotencyKey
+			Expect(db.Where("user_id = ? AND key = ?", userID, "abc").First(&storedKey).Error).To(BeNil())
+			Expect(storedKey.Body).To(Equal([]byte(`{"id":2}`)))
+		})
+	})
+})
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+//go:generate mockgen -source=job_run.go -destination=job_run_mock.go -package repository
+type JobRunRepository interface {
+	Claim(run model.JobRun, now time.Time) (model.JobRun, bool, error)
+	Save(run model.JobRun) (model.JobRun, error)
+	GetRecent(job string, limit int) ([]model.JobRun, error)
+	DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type jobRun struct {
+	db *gorm.DB
+}
+
+func newJobRunRepository(db *gorm.DB) JobRunRepository {
+	return &jobRun{
+		db: db,
+	}
+}
+
+// Claim stores the run unless another replica already claimed it and returns whether the caller has to execute it. A
+// run whose lock expired while it was still running is taken over.
+func (j *jobRun) Claim(run model.JobRun, now time.Time) (model.JobRun, bool, error) {
+	result := j.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "job"}, {Name: "scheduled_at"}},
+		DoNothing: true,
+	}).Create(&run)
+	if result.Error != nil {
+		return model.JobRun{}, false, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	if result.RowsAffected == 1 {
+		return run, true, nil
+	}
+
+	var claimed model.JobRun
+	result = j.db.Model(&claimed).Clauses(clause.Returning{}).
+		Where("job = ? AND scheduled_at = ? AND status = ? AND locked_until < ?",
+			run.Job, run.ScheduledAt, model.JobRunStatusRunning, now).
+		Updates(map[string]interface{}{
+			"replica":      run.Replica,
+			"attempts":     0,
+			"error":        nil,
+			"started_at":   run.StartedAt,
+			"locked_until": run.LockedUntil,
+		})
+	if result.Error != nil {
+		return model.JobRun{}, false, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	if result.RowsAffected == 1 {
+		return claimed, true, nil
+	}
+
+	return model.JobRun{}, false, nil
+}
+
+// Save stores the progress of a run executed by the replica of the run. A run taken over by another replica in the
+// meantime belongs to the new owner and is not overwritten.
+func (j *jobRun) Save(run model.JobRun) (model.JobRun, error) {
+	result := j.db.Model(&run).Where("replica = ?", run.Replica).Select("*").Updates(&run)
+	if result.Error != nil {
+		return model.JobRun{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return model.JobRun{}, fmt.Errorf("%w: %v", dto.ErrNotFound, "run is executed by another replica")
+	}
+	return run, nil
+}
+
+// GetRecent returns the most recently scheduled runs of the job, or of all jobs if it is empty.
+func (j *jobRun) GetRecent(job string, limit int) ([]model.JobRun, error) {
+	var runs []model.JobRun
+	query := j.db.Order("scheduled_at desc, id desc").Limit(limit)
+	if job != "" {
+		query = query.Where("job = ?", job)
+	}
+	result := query.Find(&runs)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return runs, nil
+}
+
+func (j *jobRun) DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := j.db.WithContext(ctx).Where("scheduled_at < ? AND status <> ?", before, model.JobRunStatusRunning).Delete(&model.JobRun{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,103 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: job_run.go
+//
+// Generated by this command:
+//
+//	mockgen -source=job_run.go -destination=job_run_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/avialog/backend/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockJobRunRepository is a mock of JobRunRepository interface.
+type MockJobRunRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobRunRepositoryMockRecorder
+}
+
+// MockJobRunRepositoryMockRecorder is the mock recorder for MockJobRunRepository.
+type MockJobRunRepositoryMockRecorder struct {
+	mock *MockJobRunRepository
+}
+
+// NewMockJobRunRepository creates a new mock instance.
+func NewMockJobRunRepository(ctrl *gomock.Controller) *MockJobRunRepository {
+	mock := &MockJobRunRepository{ctrl: ctrl}
+	mock.recorder = &MockJobRunRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobRunRepository) EXPECT() *MockJobRunRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockJobRunRepository) Claim(run model.JobRun, now time.Time) (model.JobRun, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", run, now)
+	ret0, _ := ret[0].(model.JobRun)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockJobRunRepositoryMockRecorder) Claim(run, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockJobRunRepository)(nil).Claim), run, now)
+}
+
+// DeleteFinishedBefore mocks base method.
+func (m *MockJobRunRepository) DeleteFinishedBefore(ctx context.Context, before time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFinishedBefore", ctx, before)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteFinishedBefore indicates an expected call of DeleteFinishedBefore.
+func (mr *MockJobRunRepositoryMockRecorder) DeleteFinishedBefore(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFinishedBefore", reflect.TypeOf((*MockJobRunRepository)(nil).DeleteFinishedBefore), ctx, before)
+}
+
+// GetRecent mocks base method.
+func (m *MockJobRunRepository) GetRecent(job string, limit int) ([]model.JobRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecent", job, limit)
+	ret0, _ := ret[0].([]model.JobRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecent indicates an expected call of GetRecent.
+func (mr *MockJobRunRepositoryMockRecorder) GetRecent(job, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecent", reflect.TypeOf((*MockJobRunRepository)(nil).GetRecent), job, limit)
+}
+
+// Save mocks base method.
+func (m *MockJobRunRepository) Save(run model.JobRun) (model.JobRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", run)
+	ret0, _ := ret[0].(model.JobRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockJobRunRepositoryMockRecorder) Save(run any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockJobRunRepository)(nil).Save), run)
+}
@@ -14,6 +14,7 @@ type LandingRepository interface {
 	Create(landing model.Landing) (model.Landing, error)
 	GetByID(id uint) (model.Landing, error)
 	GetByFlightID(flightID uint) ([]model.Landing, error)
+	GetByFlightIDs(flightIDs []uint) ([]model.Landing, error)
 	Save(landing model.Landing) (model.Landing, error)
 	DeleteByID(id uint) error
 	CreateTx(tx infrastructure.Database, landing model.Landing) (model.Landing, error)
@@ -92,6 +93,19 @@ func (l *landing) GetByFlightID(flightID uint) ([]model.Landing, error) {
 	return landings, nil
 }
 
+func (l *landing) GetByFlightIDs(flightIDs []uint) ([]model.Landing, error) {
+	landings := make([]model.Landing, 0)
+	if len(flightIDs) == 0 {
+		return landings, nil
+	}
+
+	result := l.db.Where("flight_id IN ?", flightIDs).Order("id asc").Find(&landings)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return landings, nil
+}
+
 func (l *landing) DeleteByFlightIDTx(tx infrastructure.Database, flightID uint) error {
 	result := tx.Delete(&model.Landing{}, "flight_id = ?", flightID)
 	if result.Error != nil {
@@ -113,6 +113,21 @@ func (mr *MockLandingRepositoryMockRecorder) GetByFlightID(flightID any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFlightID", reflect.TypeOf((*MockLandingRepository)(nil).GetByFlightID), flightID)
 }
 
+// GetByFlightIDs mocks base method.
+func (m *MockLandingRepository) GetByFlightIDs(flightIDs []uint) ([]model.Landing, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByFlightIDs", flightIDs)
+	ret0, _ := ret[0].([]model.Landing)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByFlightIDs indicates an expected call of GetByFlightIDs.
+func (mr *MockLandingRepositoryMockRecorder) GetByFlightIDs(flightIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFlightIDs", reflect.TypeOf((*MockLandingRepository)(nil).GetByFlightIDs), flightIDs)
+}
+
 // GetByID mocks base method.
 func (m *MockLandingRepository) GetByID(id uint) (model.Landing, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=licence.go -destination=licence_mock.go -package repository
+type LicenceRepository interface {
+	Create(licence model.Licence) (model.Licence, error)
+	GetByUserIDAndID(userID string, id uint) (model.Licence, error)
+	GetByUserID(userID string) ([]model.Licence, error)
+	Save(licence model.Licence) (model.Licence, error)
+	DeleteByUserIDAndID(userID string, id uint) error
+}
+
+type licence struct {
+	db *gorm.DB
+}
+
+func newLicenceRepository(db *gorm.DB) LicenceRepository {
+	return &licence{
+		db: db,
+	}
+}
+
+func (l *licence) Create(licence model.Licence) (model.Licence, error) {
+	result := l.db.Create(&licence)
+	if result.Error != nil {
+		return model.Licence{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return licence, nil
+}
+
+func (l *licence) GetByUserIDAndID(userID string, id uint) (model.Licence, error) {
+	var licence model.Licence
+	result := l.db.Where("user_id = ? AND id = ?", userID, id).First(&licence)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return model.Licence{}, fmt.Errorf("%w: %v", dto.ErrNotFound, result.Error)
+		}
+		return model.Licence{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return licence, nil
+}
+
+func (l *licence) GetByUserID(userID string) ([]model.Licence, error) {
+	var licences []model.Licence
+	result := l.db.Where("user_id = ?", userID).Order("id asc").Find(&licences)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return licences, nil
+}
+
+func (l *licence) Save(licence model.Licence) (model.Licence, error) {
+	result := l.db.Save(&licence)
+	if result.Error != nil {
+		return model.Licence{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return licence, nil
+}
+
+func (l *licence) DeleteByUserIDAndID(userID string, id uint) error {
+	result := l.db.Where("id = ? AND user_id = ?", id, userID).Delete(&model.Licence{})
+	if result.Error != nil {
+		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %s", dto.ErrNotFound, "licence not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: licence.go
+//
+// Generated by this command:
+//
+//	mockgen -source=licence.go -destination=licence_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/avialog/backend/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLicenceRepository is a mock of LicenceRepository interface.
+type MockLicenceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockLicenceRepositoryMockRecorder
+}
+
+// MockLicenceRepositoryMockRecorder is the mock recorder for MockLicenceRepository.
+type MockLicenceRepositoryMockRecorder struct {
+	mock *MockLicenceRepository
+}
+
+// NewMockLicenceRepository creates a new mock instance.
+func NewMockLicenceRepository(ctrl *gomock.Controller) *MockLicenceRepository {
+	mock := &MockLicenceRepository{ctrl: ctrl}
+	mock.recorder = &MockLicenceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLicenceRepository) EXPECT() *MockLicenceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockLicenceRepository) Create(licence model.Licence) (model.Licence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", licence)
+	ret0, _ := ret[0].(model.Licence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLicenceRepositoryMockRecorder) Create(licence any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLicenceRepository)(nil).Create), licence)
+}
+
+// DeleteByUserIDAndID mocks base method.
+func (m *MockLicenceRepository) DeleteByUserIDAndID(userID string, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserIDAndID", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserIDAndID indicates an expected call of DeleteByUserIDAndID.
+func (mr *MockLicenceRepositoryMockRecorder) DeleteByUserIDAndID(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserIDAndID", reflect.TypeOf((*MockLicenceRepository)(nil).DeleteByUserIDAndID), userID, id)
+}
+
+// GetByUserID mocks base method.
+func (m *MockLicenceRepository) GetByUserID(userID string) ([]model.Licence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", userID)
+	ret0, _ := ret[0].([]model.Licence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockLicenceRepositoryMockRecorder) GetByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockLicenceRepository)(nil).GetByUserID), userID)
+}
+
+// GetByUserIDAndID mocks base method.
+func (m *MockLicenceRepository) GetByUserIDAndID(userID string, id uint) (model.Licence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserIDAndID", userID, id)
+	ret0, _ := ret[0].(model.Licence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserIDAndID indicates an expected call of GetByUserIDAndID.
+func (mr *MockLicenceRepositoryMockRecorder) GetByUserIDAndID(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserIDAndID", reflect.TypeOf((*MockLicenceRepository)(nil).GetByUserIDAndID), userID, id)
+}
+
+// Save mocks base method.
+func (m *MockLicenceRepository) Save(licence model.Licence) (model.Licence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", licence)
+	ret0, _ := ret[0].(model.Licence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockLicenceRepositoryMockRecorder) Save(licence any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockLicenceRepository)(nil).Save), licence)
+}
@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The benchmarks measure the queries loading a logbook against a Postgres database seeded with benchUserCount users
+// having benchFlightCount flights each. Before timing, each benchmark checks with EXPLAIN that its queries use the
+// index they rely on. They are skipped unless BENCH_DSN points to a database they may write to:
+//
+//	BENCH_DSN="host=localhost user=postgres dbname=avialog_bench sslmode=disable" make bench
+const (
+	benchUserCount   = 20
+	benchFlightCount = 2500
+	benchPageSize    = 50
+)
+
+// benchDB is seeded by the first benchmark and reused by the others, benchmarks run one after another.
+var benchDB *gorm.DB
+
+func openBenchDatabase(b *testing.B) *gorm.DB {
+	dsn := os.Getenv("BENCH_DSN")
+	if dsn == "" {
+		b.Skip("BENCH_DSN is not set")
+	}
+
+	if benchDB != nil {
+		return benchDB
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		b.Fatal(err)
+	}
+	if err := seedBenchDatabase(db); err != nil {
+		b.Fatal(err)
+	}
+	benchDB = db
+	return benchDB
+}
+
+func benchUserID(i int) string {
+	return fmt.Sprintf("bench-%d", i)
+}
+
+// seedBenchDatabase migrates the database and fills it with the logbooks, unless a previous run already did.
+func seedBenchDatabase(db *gorm.DB) error {
+	if _, err := NewRepositories(db); err != nil {
+		return err
+	}
+
+	var count int64
+	if err := db.Model(&model.Flight{}).Where("user_id = ?", benchUserID(benchUserCount-1)).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == benchFlightCount {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, statement := range []string{
+			"DELETE FROM landings WHERE flight_id IN (SELECT id FROM flights WHERE user_id LIKE 'bench-%')",
+			"DELETE FROM passengers WHERE flight_id IN (SELECT id FROM flights WHERE user_id LIKE 'bench-%')",
+			"DELETE FROM flights WHERE user_id LIKE 'bench-%'",
+			"DELETE FROM aircrafts WHERE user_id LIKE 'bench-%'",
+			"DELETE FROM users WHERE id LIKE 'bench-%'",
+		} {
+			if err := tx.Exec(statement).Error; err != nil {
+				return err
+			}
+		}
+
+		blockTime := time.Hour
+		start := time.Date(2010, 1, 1, 8, 0, 0, 0, time.UTC)
+		for u := 0; u < benchUserCount; u++ {
+			userID := benchUserID(u)
+			if err := tx.Create(&model.User{ID: userID, Email: userID + "@example.com"}).Error; err != nil {
+				return err
+			}
+			aircraft := model.Aircraft{UserID: userID, RegistrationNumber: "SP-ABC", AircraftModel: "C152"}
+			if err := tx.Create(&aircraft).Error; err != nil {
+				return err
+			}
+
+			flights := make([]model.Flight, benchFlightCount)
+			for i := range flights {
+				takeoffTime := start.Add(time.Duration(i) * 36 * time.Hour)
+				flights[i] = model.Flight{
+					UserID:             userID,
+					AircraftID:         aircraft.ID,
+					TakeoffTime:        takeoffTime,
+					TakeoffAirportCode: "EPKK",
+					LandingTime:        takeoffTime.Add(blockTime),
+					LandingAirportCode: "EPWA",
+					Style:              model.StyleVFR,
+					MyRole:             model.RolePilotInCommand,
+					TotalBlockTime:     &blockTime,
+					PilotInCommandTime: &blockTime,
+				}
+			}
+			if err := tx.CreateInBatches(&flights, 500).Error; err != nil {
+				return err
+			}
+
+			landings := make([]model.Landing, 0, 2*len(flights))
+			passengers := make([]model.Passenger, 0, len(flights))
+			for _, flight := range flights {
+				landings = append(landings,
+					model.Landing{FlightID: flight.ID, ApproachType: model.ApproachTypeVisual},
+					model.Landing{FlightID: flight.ID, ApproachType: model.ApproachTypeILS})
+				passengers = append(passengers,
+					model.Passenger{FlightID: flight.ID, Role: model.RoleSecondInCommand, FirstName: "John"})
+			}
+			if err := tx.CreateInBatches(&landings, 1000).Error; err != nil {
+				return err
+			}
+			if err := tx.CreateInBatches(&passengers, 1000).Error; err != nil {
+				return err
+			}
+		}
+
+		// the planner only picks the indexes once it knows how selective they are
+		return tx.Exec("ANALYZE flights, landings, passengers").Error
+	})
+}
+
+// queryRecorder keeps the SQL of the queries run through it, with their values inlined.
+type queryRecorder struct {
+	logger.Interface
+	queries []string
+}
+
+func (r *queryRecorder) LogMode(logger.LogLevel) logger.Interface {
+	return r
+}
+
+func (r *queryRecorder) Trace(_ context.Context, _ time.Time, fc func() (string, int64), _ error) {
+	sql, _ := fc()
+	r.queries = append(r.queries, sql)
+}
+
+// expectIndex fails the benchmark unless the plan of every query run by the function uses the index.
+func expectIndex(b *testing.B, db *gorm.DB, index string, run func(db *gorm.DB) error) {
+	recorder := &queryRecorder{Interface: logger.Discard}
+	if err := run(db.Session(&gorm.Session{Logger: recorder})); err != nil {
+		b.Fatal(err)
+	}
+
+	for _, query := range recorder.queries {
+		var plan []string
+		if err := db.Raw("EXPLAIN " + query).Scan(&plan).Error; err != nil {
+			b.Fatal(err)
+		}
+		if !strings.Contains(strings.Join(plan, "\n"), index) {
+			b.Fatalf("query does not use %s:\n%s\n%s", index, query, strings.Join(plan, "\n"))
+		}
+	}
+}
+
+func BenchmarkGetPageByUserID(b *testing.B) {
+	db := openBenchDatabase(b)
+	flightRepository := newFlightRepository(db)
+
+	middle, err := flightRepository.GetPageByUserID(benchUserID(0), dto.LogbookFilter{}, nil, benchFlightCount/2)
+	if err != nil {
+		b.Fatal(err)
+	}
+	last := middle[len(middle)-1]
+
+	for name, after := range map[string]*dto.LogbookCursor{
+		"first":  nil,
+		"middle": {TakeoffTime: last.TakeoffTime, ID: last.ID},
+	} {
+		b.Run(name, func(b *testing.B) {
+			expectIndex(b, db, "idx_flights_user_id_takeoff_time", func(db *gorm.DB) error {
+				_, err := newFlightRepository(db).GetPageByUserID(benchUserID(0), dto.LogbookFilter{}, after, benchPageSize+1)
+				return err
+			})
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := flightRepository.GetPageByUserID(benchUserID(0), dto.LogbookFilter{}, after, benchPageSize+1); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGetByUserIDOrderedByTakeoffTime(b *testing.B) {
+	db := openBenchDatabase(b)
+	flightRepository := newFlightRepository(db)
+
+	expectIndex(b, db, "idx_flights_user_id_takeoff_time", func(db *gorm.DB) error {
+		_, err := newFlightRepository(db).GetByUserIDOrderedByTakeoffTime(benchUserID(0), nil, nil)
+		return err
+	})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := flightRepository.GetByUserIDOrderedByTakeoffTime(benchUserID(0), nil, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetByFlightIDs(b *testing.B) {
+	db := openBenchDatabase(b)
+
+	flights, err := newFlightRepository(db).GetPageByUserID(benchUserID(0), dto.LogbookFilter{}, nil, benchPageSize)
+	if err != nil {
+		b.Fatal(err)
+	}
+	flightIDs := make([]uint, 0, len(flights))
+	for _, flight := range flights {
+		flightIDs = append(flightIDs, flight.ID)
+	}
+
+	b.Run("landings", func(b *testing.B) {
+		expectIndex(b, db, "idx_landings_flight_id", func(db *gorm.DB) error {
+			_, err := newLandingRepository(db).GetByFlightIDs(flightIDs)
+			return err
+		})
+
+		landingRepository := newLandingRepository(db)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := landingRepository.GetByFlightIDs(flightIDs); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("passengers", func(b *testing.B) {
+		expectIndex(b, db, "idx_passengers_flight_id", func(db *gorm.DB) error {
+			_, err := newPassengerRepository(db).GetByFlightIDs(flightIDs)
+			return err
+		})
+
+		passengerRepository := newPassengerRepository(db)
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := passengerRepository.GetByFlightIDs(flightIDs); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=medical.go -destination=medical_mock.go -package repository
+type MedicalRepository interface {
+	Create(certificate model.MedicalCertificate) (model.MedicalCertificate, error)
+	GetByUserIDAndID(userID string, id uint) (model.MedicalCertificate, error)
+	GetByUserID(userID string) ([]model.MedicalCertificate, error)
+	Save(certificate model.MedicalCertificate) (model.MedicalCertificate, error)
+	DeleteByUserIDAndID(userID string, id uint) error
+}
+
+type medical struct {
+	db *gorm.DB
+}
+
+func newMedicalRepository(db *gorm.DB) MedicalRepository {
+	return &medical{
+		db: db,
+	}
+}
+
+func (m *medical) Create(certificate model.MedicalCertificate) (model.MedicalCertificate, error) {
+	result := m.db.Create(&certificate)
+	if result.Error != nil {
+		return model.MedicalCertificate{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return certificate, nil
+}
+
+func (m *medical) GetByUserIDAndID(userID string, id uint) (model.MedicalCertificate, error) {
+	var certificate model.MedicalCertificate
+	result := m.db.Where("user_id = ? AND id = ?", userID, id).First(&certificate)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return model.MedicalCertificate{}, fmt.Errorf("%w: %v", dto.ErrNotFound, result.Error)
+		}
+		return model.MedicalCertificate{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return certificate, nil
+}
+
+func (m *medical) GetByUserID(userID string) ([]model.MedicalCertificate, error) {
+	var certificates []model.MedicalCertificate
+	result := m.db.Where("user_id = ?", userID).Order("examined_at desc, id desc").Find(&certificates)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return certificates, nil
+}
+
+func (m *medical) Save(certificate model.MedicalCertificate) (model.MedicalCertificate, error) {
+	result := m.db.Save(&certificate)
+	if result.Error != nil {
+		return model.MedicalCertificate{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return certificate, nil
+}
+
+func (m *medical) DeleteByUserIDAndID(userID string, id uint) error {
+	result := m.db.Where("id = ? AND user_id = ?", id, userID).Delete(&model.MedicalCertificate{})
+	if result.Error != nil {
+		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %s", dto.ErrNotFound, "medical certificate not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: medical.go
+//
+// Generated by this command:
+//
+//	mockgen -source=medical.go -destination=medical_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/avialog/backend/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMedicalRepository is a mock of MedicalRepository interface.
+type MockMedicalRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockMedicalRepositoryMockRecorder
+}
+
+// MockMedicalRepositoryMockRecorder is the mock recorder for MockMedicalRepository.
+type MockMedicalRepositoryMockRecorder struct {
+	mock *MockMedicalRepository
+}
+
+// NewMockMedicalRepository creates a new mock instance.
+func NewMockMedicalRepository(ctrl *gomock.Controller) *MockMedicalRepository {
+	mock := &MockMedicalRepository{ctrl: ctrl}
+	mock.recorder = &MockMedicalRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMedicalRepository) EXPECT() *MockMedicalRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockMedicalRepository) Create(certificate model.MedicalCertificate) (model.MedicalCertificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", certificate)
+	ret0, _ := ret[0].(model.MedicalCertificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockMedicalRepositoryMockRecorder) Create(certificate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMedicalRepository)(nil).Create), certificate)
+}
+
+// DeleteByUserIDAndID mocks base method.
+func (m *MockMedicalRepository) DeleteByUserIDAndID(userID string, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserIDAndID", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserIDAndID indicates an expected call of DeleteByUserIDAndID.
+func (mr *MockMedicalRepositoryMockRecorder) DeleteByUserIDAndID(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserIDAndID", reflect.TypeOf((*MockMedicalRepository)(nil).DeleteByUserIDAndID), userID, id)
+}
+
+// GetByUserID mocks base method.
+func (m *MockMedicalRepository) GetByUserID(userID string) ([]model.MedicalCertificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", userID)
+	ret0, _ := ret[0].([]model.MedicalCertificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockMedicalRepositoryMockRecorder) GetByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockMedicalRepository)(nil).GetByUserID), userID)
+}
+
+// GetByUserIDAndID mocks base method.
+func (m *MockMedicalRepository) GetByUserIDAndID(userID string, id uint) (model.MedicalCertificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserIDAndID", userID, id)
+	ret0, _ := ret[0].(model.MedicalCertificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserIDAndID indicates an expected call of GetByUserIDAndID.
+func (mr *MockMedicalRepositoryMockRecorder) GetByUserIDAndID(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserIDAndID", reflect.TypeOf((*MockMedicalRepository)(nil).GetByUserIDAndID), userID, id)
+}
+
+// Save mocks base method.
+func (m *MockMedicalRepository) Save(certificate model.MedicalCertificate) (model.MedicalCertificate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", certificate)
+	ret0, _ := ret[0].(model.MedicalCertificate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockMedicalRepositoryMockRecorder) Save(certificate any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockMedicalRepository)(nil).Save), certificate)
+}
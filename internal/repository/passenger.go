@@ -14,6 +14,7 @@ type PassengerRepository interface {
 	Create(passenger model.Passenger) (model.Passenger, error)
 	GetByID(id uint) (model.Passenger, error)
 	GetByFlightID(id uint) ([]model.Passenger, error)
+	GetByFlightIDs(flightIDs []uint) ([]model.Passenger, error)
 	Save(passenger model.Passenger) (model.Passenger, error)
 	DeleteByID(id uint) error
 	CreateTx(tx infrastructure.Database, passenger model.Passenger) (model.Passenger, error)
@@ -94,6 +95,19 @@ func (a *passenger) GetByFlightID(flightID uint) ([]model.Passenger, error) {
 	return passengers, nil
 }
 
+func (a *passenger) GetByFlightIDs(flightIDs []uint) ([]model.Passenger, error) {
+	passengers := make([]model.Passenger, 0)
+	if len(flightIDs) == 0 {
+		return passengers, nil
+	}
+
+	result := a.db.Where("flight_id IN ?", flightIDs).Order("id asc").Find(&passengers)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return passengers, nil
+}
+
 func (a *passenger) DeleteByFlightIDTx(tx infrastructure.Database, flightID uint) error {
 	result := tx.Where("flight_id = ?", flightID).Delete(&model.Passenger{})
 	if result.Error != nil {
@@ -113,6 +113,21 @@ func (mr *MockPassengerRepositoryMockRecorder) GetByFlightID(id any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFlightID", reflect.TypeOf((*MockPassengerRepository)(nil).GetByFlightID), id)
 }
 
+// GetByFlightIDs mocks base method.
+func (m *MockPassengerRepository) GetByFlightIDs(flightIDs []uint) ([]model.Passenger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByFlightIDs", flightIDs)
+	ret0, _ := ret[0].([]model.Passenger)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByFlightIDs indicates an expected call of GetByFlightIDs.
+func (mr *MockPassengerRepositoryMockRecorder) GetByFlightIDs(flightIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFlightIDs", reflect.TypeOf((*MockPassengerRepository)(nil).GetByFlightIDs), flightIDs)
+}
+
 // GetByID mocks base method.
 func (m *MockPassengerRepository) GetByID(id uint) (model.Passenger, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -source=preference.go -destination=preference_mock.go -package repository
+type PreferenceRepository interface {
+	GetByUserID(userID string) (model.NotificationPreference, error)
+	Save(preference model.NotificationPreference) (model.NotificationPreference, error)
+	GetEnabled() ([]model.NotificationPreference, error)
+}
+
+type preference struct {
+	db *gorm.DB
+}
+
+func newPreferenceRepository(db *gorm.DB) PreferenceRepository {
+	return &preference{
+		db: db,
+	}
+}
+
+func (p *preference) GetByUserID(userID string) (model.NotificationPreference, error) {
+	var preference model.NotificationPreference
+	result := p.db.First(&preference, "user_id = ?", userID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return model.NotificationPreference{}, fmt.Errorf("%w: %v", dto.ErrNotFound, result.Error)
+		}
+		return model.NotificationPreference{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return preference, nil
+}
+
+// Save creates the preferences of the user or replaces the existing ones.
+func (p *preference) Save(preference model.NotificationPreference) (model.NotificationPreference, error) {
+	result := p.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"updated_at", "days_before", "licences", "medicals", "currency",
+			"email_enabled", "push_enabled", "webhook_enabled", "device_token", "webhook_url"}),
+	}).Omit("User").Create(&preference)
+	if result.Error != nil {
+		return model.NotificationPreference{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return preference, nil
+}
+
+// GetEnabled returns the preferences with at least one channel enabled, together with their users.
+func (p *preference) GetEnabled() ([]model.NotificationPreference, error) {
+	var preferences []model.NotificationPreference
+	result := p.db.Preload("User").
+		Where("email_enabled OR push_enabled OR webhook_enabled").
+		Order("user_id").
+		Find(&preferences)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return preferences, nil
+}
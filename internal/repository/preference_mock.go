@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: preference.go
+//
+// Generated by this command:
+//
+//	mockgen -source=preference.go -destination=preference_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/avialog/backend/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockPreferenceRepository is a mock of PreferenceRepository interface.
+type MockPreferenceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreferenceRepositoryMockRecorder
+}
+
+// MockPreferenceRepositoryMockRecorder is the mock recorder for MockPreferenceRepository.
+type MockPreferenceRepositoryMockRecorder struct {
+	mock *MockPreferenceRepository
+}
+
+// NewMockPreferenceRepository creates a new mock instance.
+func NewMockPreferenceRepository(ctrl *gomock.Controller) *MockPreferenceRepository {
+	mock := &MockPreferenceRepository{ctrl: ctrl}
+	mock.recorder = &MockPreferenceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreferenceRepository) EXPECT() *MockPreferenceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByUserID mocks base method.
+func (m *MockPreferenceRepository) GetByUserID(userID string) (model.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", userID)
+	ret0, _ := ret[0].(model.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockPreferenceRepositoryMockRecorder) GetByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockPreferenceRepository)(nil).GetByUserID), userID)
+}
+
+// GetEnabled mocks base method.
+func (m *MockPreferenceRepository) GetEnabled() ([]model.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEnabled")
+	ret0, _ := ret[0].([]model.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEnabled indicates an expected call of GetEnabled.
+func (mr *MockPreferenceRepositoryMockRecorder) GetEnabled() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEnabled", reflect.TypeOf((*MockPreferenceRepository)(nil).GetEnabled))
+}
+
+// Save mocks base method.
+func (m *MockPreferenceRepository) Save(preference model.NotificationPreference) (model.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", preference)
+	ret0, _ := ret[0].(model.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockPreferenceRepositoryMockRecorder) Save(preference any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPreferenceRepository)(nil).Save), preference)
+}
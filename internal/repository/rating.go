@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=rating.go -destination=rating_mock.go -package repository
+type RatingRepository interface {
+	Create(rating model.Rating) (model.Rating, error)
+	GetByUserIDAndID(userID string, id uint) (model.Rating, error)
+	GetByUserID(userID string) ([]model.Rating, error)
+	CountByUserIDAndLicenceID(userID string, licenceID uint) (int64, error)
+	Save(rating model.Rating) (model.Rating, error)
+	DeleteByUserIDAndID(userID string, id uint) error
+}
+
+type rating struct {
+	db *gorm.DB
+}
+
+func newRatingRepository(db *gorm.DB) RatingRepository {
+	return &rating{
+		db: db,
+	}
+}
+
+func (r *rating) Create(rating model.Rating) (model.Rating, error) {
+	result := r.db.Create(&rating)
+	if result.Error != nil {
+		return model.Rating{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return rating, nil
+}
+
+func (r *rating) GetByUserIDAndID(userID string, id uint) (model.Rating, error) {
+	var rating model.Rating
+	result := r.db.Where("user_id = ? AND id = ?", userID, id).First(&rating)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return model.Rating{}, fmt.Errorf("%w: %v", dto.ErrNotFound, result.Error)
+		}
+		return model.Rating{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return rating, nil
+}
+
+func (r *rating) GetByUserID(userID string) ([]model.Rating, error) {
+	var ratings []model.Rating
+	result := r.db.Where("user_id = ?", userID).Order("id asc").Find(&ratings)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return ratings, nil
+}
+
+func (r *rating) CountByUserIDAndLicenceID(userID string, licenceID uint) (int64, error) {
+	var count int64
+	result := r.db.Model(&model.Rating{}).Where("user_id = ? AND licence_id = ?", userID, licenceID).Count(&count)
+	if result.Error != nil {
+		return 0, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return count, nil
+}
+
+func (r *rating) Save(rating model.Rating) (model.Rating, error) {
+	result := r.db.Save(&rating)
+	if result.Error != nil {
+		return model.Rating{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return rating, nil
+}
+
+func (r *rating) DeleteByUserIDAndID(userID string, id uint) error {
+	result := r.db.Where("id = ? AND user_id = ?", id, userID).Delete(&model.Rating{})
+	if result.Error != nil {
+		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %s", dto.ErrNotFound, "rating not found")
+	}
+
+	return nil
+}
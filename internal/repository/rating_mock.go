@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: rating.go
+//
+// Generated by this command:
+//
+//	mockgen -source=rating.go -destination=rating_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/avialog/backend/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRatingRepository is a mock of RatingRepository interface.
+type MockRatingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRatingRepositoryMockRecorder
+}
+
+// MockRatingRepositoryMockRecorder is the mock recorder for MockRatingRepository.
+type MockRatingRepositoryMockRecorder struct {
+	mock *MockRatingRepository
+}
+
+// NewMockRatingRepository creates a new mock instance.
+func NewMockRatingRepository(ctrl *gomock.Controller) *MockRatingRepository {
+	mock := &MockRatingRepository{ctrl: ctrl}
+	mock.recorder = &MockRatingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRatingRepository) EXPECT() *MockRatingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountByUserIDAndLicenceID mocks base method.
+func (m *MockRatingRepository) CountByUserIDAndLicenceID(userID string, licenceID uint) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByUserIDAndLicenceID", userID, licenceID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByUserIDAndLicenceID indicates an expected call of CountByUserIDAndLicenceID.
+func (mr *MockRatingRepositoryMockRecorder) CountByUserIDAndLicenceID(userID, licenceID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByUserIDAndLicenceID", reflect.TypeOf((*MockRatingRepository)(nil).CountByUserIDAndLicenceID), userID, licenceID)
+}
+
+// Create mocks base method.
+func (m *MockRatingRepository) Create(rating model.Rating) (model.Rating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", rating)
+	ret0, _ := ret[0].(model.Rating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockRatingRepositoryMockRecorder) Create(rating any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockRatingRepository)(nil).Create), rating)
+}
+
+// DeleteByUserIDAndID mocks base method.
+func (m *MockRatingRepository) DeleteByUserIDAndID(userID string, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByUserIDAndID", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteByUserIDAndID indicates an expected call of DeleteByUserIDAndID.
+func (mr *MockRatingRepositoryMockRecorder) DeleteByUserIDAndID(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByUserIDAndID", reflect.TypeOf((*MockRatingRepository)(nil).DeleteByUserIDAndID), userID, id)
+}
+
+// GetByUserID mocks base method.
+func (m *MockRatingRepository) GetByUserID(userID string) ([]model.Rating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", userID)
+	ret0, _ := ret[0].([]model.Rating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockRatingRepositoryMockRecorder) GetByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockRatingRepository)(nil).GetByUserID), userID)
+}
+
+// GetByUserIDAndID mocks base method.
+func (m *MockRatingRepository) GetByUserIDAndID(userID string, id uint) (model.Rating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserIDAndID", userID, id)
+	ret0, _ := ret[0].(model.Rating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserIDAndID indicates an expected call of GetByUserIDAndID.
+func (mr *MockRatingRepositoryMockRecorder) GetByUserIDAndID(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserIDAndID", reflect.TypeOf((*MockRatingRepository)(nil).GetByUserIDAndID), userID, id)
+}
+
+// Save mocks base method.
+func (m *MockRatingRepository) Save(rating model.Rating) (model.Rating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", rating)
+	ret0, _ := ret[0].(model.Rating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockRatingRepositoryMockRecorder) Save(rating any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRatingRepository)(nil).Save), rating)
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+//go:generate mockgen -source=reminder.go -destination=reminder_mock.go -package repository
+type ReminderRepository interface {
+	Claim(reminder model.Reminder) (bool, error)
+	Release(reminder model.Reminder) error
+}
+
+type reminder struct {
+	db *gorm.DB
+}
+
+func newReminderRepository(db *gorm.DB) ReminderRepository {
+	return &reminder{
+		db: db,
+	}
+}
+
+// Claim stores the reminder unless it was already sent and returns whether the caller has to send it. The unique
+// index on the user, the item and the lapse lets a single replica claim it.
+func (r *reminder) Claim(reminder model.Reminder) (bool, error) {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&reminder)
+	if result.Error != nil {
+		return false, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// Release deletes a claimed reminder that could not be sent, so it is sent again next time.
+func (r *reminder) Release(reminder model.Reminder) error {
+	result := r.db.Where("user_id = ? AND item = ? AND lapses_at = ?", reminder.UserID, reminder.Item, reminder.LapsesAt).
+		Delete(&model.Reminder{})
+	if result.Error != nil {
+		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reminder.go
+//
+// Generated by this command:
+//
+//	mockgen -source=reminder.go -destination=reminder_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	reflect "reflect"
+
+	model "github.com/avialog/backend/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReminderRepository is a mock of ReminderRepository interface.
+type MockReminderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockReminderRepositoryMockRecorder
+}
+
+// MockReminderRepositoryMockRecorder is the mock recorder for MockReminderRepository.
+type MockReminderRepositoryMockRecorder struct {
+	mock *MockReminderRepository
+}
+
+// NewMockReminderRepository creates a new mock instance.
+func NewMockReminderRepository(ctrl *gomock.Controller) *MockReminderRepository {
+	mock := &MockReminderRepository{ctrl: ctrl}
+	mock.recorder = &MockReminderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReminderRepository) EXPECT() *MockReminderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockReminderRepository) Claim(reminder model.Reminder) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", reminder)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockReminderRepositoryMockRecorder) Claim(reminder any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockReminderRepository)(nil).Claim), reminder)
+}
+
+// Release mocks base method.
+func (m *MockReminderRepository) Release(reminder model.Reminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Release", reminder)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockReminderRepositoryMockRecorder) Release(reminder any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockReminderRepository)(nil).Release), reminder)
+}
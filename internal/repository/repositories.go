@@ -13,32 +13,75 @@ type Repositories interface {
 	Passenger() PassengerRepository
 	Aircraft() AircraftRepository
 	Contact() ContactRepository
+	Airport() AirportRepository
+	Signature() SignatureRepository
+	FlightVersion() FlightVersionRepository
+	Trash() TrashRepository
+	IdempotencyKey() IdempotencyKeyRepository
+	Sync() SyncRepository
+	Licence() LicenceRepository
+	Rating() RatingRepository
+	Medical() MedicalRepository
+	Preference() PreferenceRepository
+	Reminder() ReminderRepository
+	JobRun() JobRunRepository
 }
 
 type repositories struct {
-	userRepository      UserRepository
-	passengerRepository PassengerRepository
-	aircraftRepository  AircraftRepository
-	flightRepository    FlightRepository
-	landingRepository   LandingRepository
-	contactRepository   ContactRepository
+	userRepository           UserRepository
+	passengerRepository      PassengerRepository
+	aircraftRepository       AircraftRepository
+	flightRepository         FlightRepository
+	landingRepository        LandingRepository
+	contactRepository        ContactRepository
+	airportRepository        AirportRepository
+	signatureRepository      SignatureRepository
+	flightVersionRepository  FlightVersionRepository
+	trashRepository          TrashRepository
+	idempotencyKeyRepository IdempotencyKeyRepository
+	syncRepository           SyncRepository
+	licenceRepository        LicenceRepository
+	ratingRepository         RatingRepository
+	medicalRepository        MedicalRepository
+	preferenceRepository     PreferenceRepository
+	reminderRepository       ReminderRepository
+	jobRunRepository         JobRunRepository
 }
 
 func NewRepositories(db *gorm.DB) (Repositories, error) {
 	err := db.AutoMigrate(&model.User{}, &model.Aircraft{}, &model.Contact{},
-		&model.Flight{}, &model.Landing{}, &model.Passenger{})
+		&model.Flight{}, &model.Landing{}, &model.Passenger{}, &model.Signature{}, &model.FlightVersion{},
+		&model.IdempotencyKey{}, &model.Licence{}, &model.Rating{}, &model.MedicalCertificate{},
+		&model.NotificationPreference{}, &model.Reminder{}, &model.JobRun{})
 
 	if err != nil {
 		return nil, err
 	}
 
+	airports, err := embeddedAirports()
+	if err != nil {
+		return nil, err
+	}
+
 	return &repositories{
-		userRepository:      newUserRepository(db),
-		aircraftRepository:  newAircraftRepository(db),
-		flightRepository:    newFlightRepository(db),
-		landingRepository:   newLandingRepository(db),
-		passengerRepository: newPassengerRepository(db),
-		contactRepository:   newContactRepository(db),
+		userRepository:           newUserRepository(db),
+		aircraftRepository:       newAircraftRepository(db),
+		flightRepository:         newFlightRepository(db),
+		landingRepository:        newLandingRepository(db),
+		passengerRepository:      newPassengerRepository(db),
+		contactRepository:        newContactRepository(db),
+		airportRepository:        newAirportRepository(airports),
+		signatureRepository:      newSignatureRepository(db),
+		flightVersionRepository:  newFlightVersionRepository(db),
+		trashRepository:          newTrashRepository(db),
+		idempotencyKeyRepository: newIdempotencyKeyRepository(db),
+		syncRepository:           newSyncRepository(db),
+		licenceRepository:        newLicenceRepository(db),
+		ratingRepository:         newRatingRepository(db),
+		medicalRepository:        newMedicalRepository(db),
+		preferenceRepository:     newPreferenceRepository(db),
+		reminderRepository:       newReminderRepository(db),
+		jobRunRepository:         newJobRunRepository(db),
 	}, nil
 }
 
@@ -55,3 +98,27 @@ func (r *repositories) Passenger() PassengerRepository { return r.passengerRepos
 func (r *repositories) Contact() ContactRepository { return r.contactRepository }
 
 func (r *repositories) Landing() LandingRepository { return r.landingRepository }
+
+func (r *repositories) Airport() AirportRepository { return r.airportRepository }
+
+func (r *repositories) Signature() SignatureRepository { return r.signatureRepository }
+
+func (r *repositories) FlightVersion() FlightVersionRepository { return r.flightVersionRepository }
+
+func (r *repositories) Trash() TrashRepository { return r.trashRepository }
+
+func (r *repositories) IdempotencyKey() IdempotencyKeyRepository { return r.idempotencyKeyRepository }
+
+func (r *repositories) Sync() SyncRepository { return r.syncRepository }
+
+func (r *repositories) Licence() LicenceRepository { return r.licenceRepository }
+
+func (r *repositories) Rating() RatingRepository { return r.ratingRepository }
+
+func (r *repositories) Medical() MedicalRepository { return r.medicalRepository }
+
+func (r *repositories) Preference() PreferenceRepository { return r.preferenceRepository }
+
+func (r *repositories) Reminder() ReminderRepository { return r.reminderRepository }
+
+func (r *repositories) JobRun() JobRunRepository { return r.jobRunRepository }
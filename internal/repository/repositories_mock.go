@@ -52,6 +52,20 @@ func (mr *MockRepositoriesMockRecorder) Aircraft() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Aircraft", reflect.TypeOf((*MockRepositories)(nil).Aircraft))
 }
 
+// Airport mocks base method.
+func (m *MockRepositories) Airport() AirportRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Airport")
+	ret0, _ := ret[0].(AirportRepository)
+	return ret0
+}
+
+// Airport indicates an expected call of Airport.
+func (mr *MockRepositoriesMockRecorder) Airport() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Airport", reflect.TypeOf((*MockRepositories)(nil).Airport))
+}
+
 // Contact mocks base method.
 func (m *MockRepositories) Contact() ContactRepository {
 	m.ctrl.T.Helper()
@@ -80,6 +94,48 @@ func (mr *MockRepositoriesMockRecorder) Flight() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Flight", reflect.TypeOf((*MockRepositories)(nil).Flight))
 }
 
+// FlightVersion mocks base method.
+func (m *MockRepositories) FlightVersion() FlightVersionRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlightVersion")
+	ret0, _ := ret[0].(FlightVersionRepository)
+	return ret0
+}
+
+// FlightVersion indicates an expected call of FlightVersion.
+func (mr *MockRepositoriesMockRecorder) FlightVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlightVersion", reflect.TypeOf((*MockRepositories)(nil).FlightVersion))
+}
+
+// IdempotencyKey mocks base method.
+func (m *MockRepositories) IdempotencyKey() IdempotencyKeyRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IdempotencyKey")
+	ret0, _ := ret[0].(IdempotencyKeyRepository)
+	return ret0
+}
+
+// IdempotencyKey indicates an expected call of IdempotencyKey.
+func (mr *MockRepositoriesMockRecorder) IdempotencyKey() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IdempotencyKey", reflect.TypeOf((*MockRepositories)(nil).IdempotencyKey))
+}
+
+// JobRun mocks base method.
+func (m *MockRepositories) JobRun() JobRunRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JobRun")
+	ret0, _ := ret[0].(JobRunRepository)
+	return ret0
+}
+
+// JobRun indicates an expected call of JobRun.
+func (mr *MockRepositoriesMockRecorder) JobRun() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JobRun", reflect.TypeOf((*MockRepositories)(nil).JobRun))
+}
+
 // Landing mocks base method.
 func (m *MockRepositories) Landing() LandingRepository {
 	m.ctrl.T.Helper()
@@ -94,6 +150,34 @@ func (mr *MockRepositoriesMockRecorder) Landing() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Landing", reflect.TypeOf((*MockRepositories)(nil).Landing))
 }
 
+// Licence mocks base method.
+func (m *MockRepositories) Licence() LicenceRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Licence")
+	ret0, _ := ret[0].(LicenceRepository)
+	return ret0
+}
+
+// Licence indicates an expected call of Licence.
+func (mr *MockRepositoriesMockRecorder) Licence() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Licence", reflect.TypeOf((*MockRepositories)(nil).Licence))
+}
+
+// Medical mocks base method.
+func (m *MockRepositories) Medical() MedicalRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Medical")
+	ret0, _ := ret[0].(MedicalRepository)
+	return ret0
+}
+
+// Medical indicates an expected call of Medical.
+func (mr *MockRepositoriesMockRecorder) Medical() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Medical", reflect.TypeOf((*MockRepositories)(nil).Medical))
+}
+
 // Passenger mocks base method.
 func (m *MockRepositories) Passenger() PassengerRepository {
 	m.ctrl.T.Helper()
@@ -108,6 +192,90 @@ func (mr *MockRepositoriesMockRecorder) Passenger() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Passenger", reflect.TypeOf((*MockRepositories)(nil).Passenger))
 }
 
+// Preference mocks base method.
+func (m *MockRepositories) Preference() PreferenceRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Preference")
+	ret0, _ := ret[0].(PreferenceRepository)
+	return ret0
+}
+
+// Preference indicates an expected call of Preference.
+func (mr *MockRepositoriesMockRecorder) Preference() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Preference", reflect.TypeOf((*MockRepositories)(nil).Preference))
+}
+
+// Rating mocks base method.
+func (m *MockRepositories) Rating() RatingRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rating")
+	ret0, _ := ret[0].(RatingRepository)
+	return ret0
+}
+
+// Rating indicates an expected call of Rating.
+func (mr *MockRepositoriesMockRecorder) Rating() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rating", reflect.TypeOf((*MockRepositories)(nil).Rating))
+}
+
+// Reminder mocks base method.
+func (m *MockRepositories) Reminder() ReminderRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reminder")
+	ret0, _ := ret[0].(ReminderRepository)
+	return ret0
+}
+
+// Reminder indicates an expected call of Reminder.
+func (mr *MockRepositoriesMockRecorder) Reminder() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reminder", reflect.TypeOf((*MockRepositories)(nil).Reminder))
+}
+
+// Signature mocks base method.
+func (m *MockRepositories) Signature() SignatureRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Signature")
+	ret0, _ := ret[0].(SignatureRepository)
+	return ret0
+}
+
+// Signature indicates an expected call of Signature.
+func (mr *MockRepositoriesMockRecorder) Signature() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Signature", reflect.TypeOf((*MockRepositories)(nil).Signature))
+}
+
+// Sync mocks base method.
+func (m *MockRepositories) Sync() SyncRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sync")
+	ret0, _ := ret[0].(SyncRepository)
+	return ret0
+}
+
+// Sync indicates an expected call of Sync.
+func (mr *MockRepositoriesMockRecorder) Sync() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockRepositories)(nil).Sync))
+}
+
+// Trash mocks base method.
+func (m *MockRepositories) Trash() TrashRepository {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trash")
+	ret0, _ := ret[0].(TrashRepository)
+	return ret0
+}
+
+// Trash indicates an expected call of Trash.
+func (mr *MockRepositoriesMockRecorder) Trash() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trash", reflect.TypeOf((*MockRepositories)(nil).Trash))
+}
+
 // User mocks base method.
 func (m *MockRepositories) User() UserRepository {
 	m.ctrl.T.Helper()
@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/infrastructure"
+	"github.com/avialog/backend/internal/model"
+	"gorm.io/gorm"
+)
+
+//go:generate mockgen -source=signature.go -destination=signature_mock.go -package repository
+type SignatureRepository interface {
+	CreateTx(tx infrastructure.Database, signature model.Signature) (model.Signature, error)
+	GetByID(id uint) (model.Signature, error)
+	GetByIDTx(tx infrastructure.Database, id uint) (model.Signature, error)
+	GetByToken(token string) (model.Signature, error)
+	GetLatestByFlightID(flightID uint) (model.Signature, error)
+	GetLatestByFlightIDTx(tx infrastructure.Database, flightID uint) (model.Signature, error)
+	GetByFlightIDs(flightIDs []uint) ([]model.Signature, error)
+	GetPendingBySignerUserID(signerUserID string) ([]model.Signature, error)
+	Save(signature model.Signature) (model.Signature, error)
+	SaveTx(tx infrastructure.Database, signature model.Signature) (model.Signature, error)
+	InvalidateByFlightIDTx(tx infrastructure.Database, flightID uint) error
+}
+
+type signature struct {
+	db *gorm.DB
+}
+
+func newSignatureRepository(db *gorm.DB) SignatureRepository {
+	return &signature{
+		db: db,
+	}
+}
+
+func (s *signature) CreateTx(tx infrastructure.Database, signature model.Signature) (model.Signature, error) {
+	result := tx.Create(&signature)
+	if result.Error != nil {
+		return model.Signature{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return signature, nil
+}
+
+func (s *signature) GetByID(id uint) (model.Signature, error) {
+	var signature model.Signature
+	result := s.db.First(&signature, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return model.Signature{}, fmt.Errorf("%w: %v", dto.ErrNotFound, result.Error)
+		}
+		return model.Signature{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return signature, nil
+}
+
+func (s *signature) GetByIDTx(tx infrastructure.Database, id uint) (model.Signature, error) {
+	var signature model.Signature
+	result := tx.First(&signature, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return model.Signature{}, fmt.Errorf("%w: %v", dto.ErrNotFound, result.Error)
+		}
+		return model.Signature{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return signature, nil
+}
+
+func (s *signature) GetByToken(token string) (model.Signature, error) {
+	var signature model.Signature
+	result := s.db.Where("token = ?", token).First(&signature)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return model.Signature{}, fmt.Errorf("%w: %v", dto.ErrNotFound, result.Error)
+		}
+		return model.Signature{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return signature, nil
+}
+
+func (s *signature) GetLatestByFlightID(flightID uint) (model.Signature, error) {
+	var signature model.Signature
+	result := s.db.Where("flight_id = ?", flightID).Order("id desc").First(&signature)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return model.Signature{}, fmt.Errorf("%w: %v", dto.ErrNotFound, result.Error)
+		}
+		return model.Signature{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return signature, nil
+}
+
+func (s *signature) GetLatestByFlightIDTx(tx infrastructure.Database, flightID uint) (model.Signature, error) {
+	var signature model.Signature
+	result := tx.Where("flight_id = ?", flightID).Order("id desc").First(&signature)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return model.Signature{}, fmt.Errorf("%w: %v", dto.ErrNotFound, result.Error)
+		}
+		return model.Signature{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return signature, nil
+}
+
+func (s *signature) GetByFlightIDs(flightIDs []uint) ([]model.Signature, error) {
+	signatures := make([]model.Signature, 0)
+	if len(flightIDs) == 0 {
+		return signatures, nil
+	}
+
+	result := s.db.Where("flight_id IN ?", flightIDs).Order("id asc").Find(&signatures)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return signatures, nil
+}
+
+func (s *signature) GetPendingBySignerUserID(signerUserID string) ([]model.Signature, error) {
+	signatures := make([]model.Signature, 0)
+	result := s.db.Where("signer_user_id = ? AND status = ?", signerUserID, model.SignatureStatusPending).
+		Order("id asc").Find(&signatures)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return signatures, nil
+}
+
+func (s *signature) Save(signature model.Signature) (model.Signature, error) {
+	result := s.db.Save(&signature)
+	if result.Error != nil {
+		return model.Signature{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return signature, nil
+}
+
+func (s *signature) SaveTx(tx infrastructure.Database, signature model.Signature) (model.Signature, error) {
+	result := tx.Save(&signature)
+	if result.Error != nil {
+		return model.Signature{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return signature, nil
+}
+
+// InvalidateByFlightIDTx marks the signatures of the flight which were signed or are waiting to be signed as invalid.
+func (s *signature) InvalidateByFlightIDTx(tx infrastructure.Database, flightID uint) error {
+	result := tx.Where("flight_id = ? AND status IN ?", flightID,
+		[]model.SignatureStatus{model.SignatureStatusPending, model.SignatureStatusSigned}).
+		Model(&model.Signature{}).Update("status", model.SignatureStatusInvalidated)
+	if result.Error != nil {
+		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return nil
+}
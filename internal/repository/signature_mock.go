@@ -0,0 +1,205 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: signature.go
+//
+// Generated by this command:
+//
+//	mockgen -source=signature.go -destination=signature_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	reflect "reflect"
+
+	infrastructure "github.com/avialog/backend/internal/infrastructure"
+	model "github.com/avialog/backend/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSignatureRepository is a mock of SignatureRepository interface.
+type MockSignatureRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSignatureRepositoryMockRecorder
+}
+
+// MockSignatureRepositoryMockRecorder is the mock recorder for MockSignatureRepository.
+type MockSignatureRepositoryMockRecorder struct {
+	mock *MockSignatureRepository
+}
+
+// NewMockSignatureRepository creates a new mock instance.
+func NewMockSignatureRepository(ctrl *gomock.Controller) *MockSignatureRepository {
+	mock := &MockSignatureRepository{ctrl: ctrl}
+	mock.recorder = &MockSignatureRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSignatureRepository) EXPECT() *MockSignatureRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateTx mocks base method.
+func (m *MockSignatureRepository) CreateTx(tx infrastructure.Database, signature model.Signature) (model.Signature, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTx", tx, signature)
+	ret0, _ := ret[0].(model.Signature)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTx indicates an expected call of CreateTx.
+func (mr *MockSignatureRepositoryMockRecorder) CreateTx(tx, signature any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTx", reflect.TypeOf((*MockSignatureRepository)(nil).CreateTx), tx, signature)
+}
+
+// GetByFlightIDs mocks base method.
+func (m *MockSignatureRepository) GetByFlightIDs(flightIDs []uint) ([]model.Signature, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByFlightIDs", flightIDs)
+	ret0, _ := ret[0].([]model.Signature)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByFlightIDs indicates an expected call of GetByFlightIDs.
+func (mr *MockSignatureRepositoryMockRecorder) GetByFlightIDs(flightIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByFlightIDs", reflect.TypeOf((*MockSignatureRepository)(nil).GetByFlightIDs), flightIDs)
+}
+
+// GetByID mocks base method.
+func (m *MockSignatureRepository) GetByID(id uint) (model.Signature, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", id)
+	ret0, _ := ret[0].(model.Signature)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockSignatureRepositoryMockRecorder) GetByID(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockSignatureRepository)(nil).GetByID), id)
+}
+
+// GetByIDTx mocks base method.
+func (m *MockSignatureRepository) GetByIDTx(tx infrastructure.Database, id uint) (model.Signature, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDTx", tx, id)
+	ret0, _ := ret[0].(model.Signature)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDTx indicates an expected call of GetByIDTx.
+func (mr *MockSignatureRepositoryMockRecorder) GetByIDTx(tx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDTx", reflect.TypeOf((*MockSignatureRepository)(nil).GetByIDTx), tx, id)
+}
+
+// GetByToken mocks base method.
+func (m *MockSignatureRepository) GetByToken(token string) (model.Signature, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByToken", token)
+	ret0, _ := ret[0].(model.Signature)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByToken indicates an expected call of GetByToken.
+func (mr *MockSignatureRepositoryMockRecorder) GetByToken(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByToken", reflect.TypeOf((*MockSignatureRepository)(nil).GetByToken), token)
+}
+
+// GetLatestByFlightID mocks base method.
+func (m *MockSignatureRepository) GetLatestByFlightID(flightID uint) (model.Signature, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestByFlightID", flightID)
+	ret0, _ := ret[0].(model.Signature)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestByFlightID indicates an expected call of GetLatestByFlightID.
+func (mr *MockSignatureRepositoryMockRecorder) GetLatestByFlightID(flightID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestByFlightID", reflect.TypeOf((*MockSignatureRepository)(nil).GetLatestByFlightID), flightID)
+}
+
+// GetLatestByFlightIDTx mocks base method.
+func (m *MockSignatureRepository) GetLatestByFlightIDTx(tx infrastructure.Database, flightID uint) (model.Signature, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestByFlightIDTx", tx, flightID)
+	ret0, _ := ret[0].(model.Signature)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestByFlightIDTx indicates an expected call of GetLatestByFlightIDTx.
+func (mr *MockSignatureRepositoryMockRecorder) GetLatestByFlightIDTx(tx, flightID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestByFlightIDTx", reflect.TypeOf((*MockSignatureRepository)(nil).GetLatestByFlightIDTx), tx, flightID)
+}
+
+// GetPendingBySignerUserID mocks base method.
+func (m *MockSignatureRepository) GetPendingBySignerUserID(signerUserID string) ([]model.Signature, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingBySignerUserID", signerUserID)
+	ret0, _ := ret[0].([]model.Signature)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingBySignerUserID indicates an expected call of GetPendingBySignerUserID.
+func (mr *MockSignatureRepositoryMockRecorder) GetPendingBySignerUserID(signerUserID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingBySignerUserID", reflect.TypeOf((*MockSignatureRepository)(nil).GetPendingBySignerUserID), signerUserID)
+}
+
+// InvalidateByFlightIDTx mocks base method.
+func (m *MockSignatureRepository) InvalidateByFlightIDTx(tx infrastructure.Database, flightID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateByFlightIDTx", tx, flightID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InvalidateByFlightIDTx indicates an expected call of InvalidateByFlightIDTx.
+func (mr *MockSignatureRepositoryMockRecorder) InvalidateByFlightIDTx(tx, flightID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateByFlightIDTx", reflect.TypeOf((*MockSignatureRepository)(nil).InvalidateByFlightIDTx), tx, flightID)
+}
+
+// Save mocks base method.
+func (m *MockSignatureRepository) Save(signature model.Signature) (model.Signature, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", signature)
+	ret0, _ := ret[0].(model.Signature)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockSignatureRepositoryMockRecorder) Save(signature any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockSignatureRepository)(nil).Save), signature)
+}
+
+// SaveTx mocks base method.
+func (m *MockSignatureRepository) SaveTx(tx infrastructure.Database, signature model.Signature) (model.Signature, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTx", tx, signature)
+	ret0, _ := ret[0].(model.Signature)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveTx indicates an expected call of SaveTx.
+func (mr *MockSignatureRepositoryMockRecorder) SaveTx(tx, signature any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTx", reflect.TypeOf((*MockSignatureRepository)(nil).SaveTx), tx, signature)
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"gorm.io/gorm"
+	"time"
+)
+
+//go:generate mockgen -source=sync.go -destination=sync_mock.go -package repository
+type SyncRepository interface {
+	GetFlightsChangedSince(userID string, since time.Time) ([]model.Flight, error)
+	GetDeletedFlightIDsSince(userID string, since time.Time) ([]uint, error)
+	GetAircraftChangedSince(userID string, since time.Time) ([]model.Aircraft, error)
+	GetDeletedAircraftIDsSince(userID string, since time.Time) ([]uint, error)
+	GetContactsChangedSince(userID string, since time.Time) ([]model.Contact, error)
+	GetDeletedContactIDsSince(userID string, since time.Time) ([]uint, error)
+}
+
+type sync struct {
+	db *gorm.DB
+}
+
+func newSyncRepository(db *gorm.DB) SyncRepository {
+	return &sync{
+		db: db,
+	}
+}
+
+// GetFlightsChangedSince returns the flights of the user created or updated after the given time. Flights whose
+// signature request changed are returned too, since the signature is a part of the logbook entry.
+func (s *sync) GetFlightsChangedSince(userID string, since time.Time) ([]model.Flight, error) {
+	var flights []model.Flight
+	result := s.db.Where("user_id = ? AND (updated_at > ? OR EXISTS "+
+		"(SELECT 1 FROM signatures WHERE signatures.flight_id = flights.id AND signatures.updated_at > ?))",
+		userID, since, since).Order("id asc").Find(&flights)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return flights, nil
+}
+
+func (s *sync) GetDeletedFlightIDsSince(userID string, since time.Time) ([]uint, error) {
+	var ids []uint
+	result := s.db.Unscoped().Model(&model.Flight{}).Where("user_id = ? AND deleted_at > ?", userID, since).
+		Order("id asc").Pluck("id", &ids)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return ids, nil
+}
+
+func (s *sync) GetAircraftChangedSince(userID string, since time.Time) ([]model.Aircraft, error) {
+	var aircraft []model.Aircraft
+	result := s.db.Where("user_id = ? AND updated_at > ?", userID, since).Order("id asc").Find(&aircraft)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return aircraft, nil
+}
+
+func (s *sync) GetDeletedAircraftIDsSince(userID string, since time.Time) ([]uint, error) {
+	var ids []uint
+	result := s.db.Unscoped().Model(&model.Aircraft{}).Where("user_id = ? AND deleted_at > ?", userID, since).
+		Order("id asc").Pluck("id", &ids)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return ids, nil
+}
+
+func (s *sync) GetContactsChangedSince(userID string, since time.Time) ([]model.Contact, error) {
+	var contacts []model.Contact
+	result := s.db.Where("user_id = ? AND updated_at > ?", userID, since).Order("id asc").Find(&contacts)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return contacts, nil
+}
+
+func (s *sync) GetDeletedContactIDsSince(userID string, since time.Time) ([]uint, error) {
+	var ids []uint
+	result := s.db.Unscoped().Model(&model.Contact{}).Where("user_id = ? AND deleted_at > ?", userID, since).
+		Order("id asc").Pluck("id", &ids)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return ids, nil
+}
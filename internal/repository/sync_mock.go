@@ -0,0 +1,131 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sync.go
+//
+// Generated by this command:
+//
+//	mockgen -source=sync.go -destination=sync_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	reflect "reflect"
+	time "time"
+
+	model "github.com/avialog/backend/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSyncRepository is a mock of SyncRepository interface.
+type MockSyncRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSyncRepositoryMockRecorder
+}
+
+// MockSyncRepositoryMockRecorder is the mock recorder for MockSyncRepository.
+type MockSyncRepositoryMockRecorder struct {
+	mock *MockSyncRepository
+}
+
+// NewMockSyncRepository creates a new mock instance.
+func NewMockSyncRepository(ctrl *gomock.Controller) *MockSyncRepository {
+	mock := &MockSyncRepository{ctrl: ctrl}
+	mock.recorder = &MockSyncRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSyncRepository) EXPECT() *MockSyncRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetAircraftChangedSince mocks base method.
+func (m *MockSyncRepository) GetAircraftChangedSince(userID string, since time.Time) ([]model.Aircraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAircraftChangedSince", userID, since)
+	ret0, _ := ret[0].([]model.Aircraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAircraftChangedSince indicates an expected call of GetAircraftChangedSince.
+func (mr *MockSyncRepositoryMockRecorder) GetAircraftChangedSince(userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAircraftChangedSince", reflect.TypeOf((*MockSyncRepository)(nil).GetAircraftChangedSince), userID, since)
+}
+
+// GetContactsChangedSince mocks base method.
+func (m *MockSyncRepository) GetContactsChangedSince(userID string, since time.Time) ([]model.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContactsChangedSince", userID, since)
+	ret0, _ := ret[0].([]model.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContactsChangedSince indicates an expected call of GetContactsChangedSince.
+func (mr *MockSyncRepositoryMockRecorder) GetContactsChangedSince(userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactsChangedSince", reflect.TypeOf((*MockSyncRepository)(nil).GetContactsChangedSince), userID, since)
+}
+
+// GetDeletedAircraftIDsSince mocks base method.
+func (m *MockSyncRepository) GetDeletedAircraftIDsSince(userID string, since time.Time) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedAircraftIDsSince", userID, since)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedAircraftIDsSince indicates an expected call of GetDeletedAircraftIDsSince.
+func (mr *MockSyncRepositoryMockRecorder) GetDeletedAircraftIDsSince(userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedAircraftIDsSince", reflect.TypeOf((*MockSyncRepository)(nil).GetDeletedAircraftIDsSince), userID, since)
+}
+
+// GetDeletedContactIDsSince mocks base method.
+func (m *MockSyncRepository) GetDeletedContactIDsSince(userID string, since time.Time) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedContactIDsSince", userID, since)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedContactIDsSince indicates an expected call of GetDeletedContactIDsSince.
+func (mr *MockSyncRepositoryMockRecorder) GetDeletedContactIDsSince(userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedContactIDsSince", reflect.TypeOf((*MockSyncRepository)(nil).GetDeletedContactIDsSince), userID, since)
+}
+
+// GetDeletedFlightIDsSince mocks base method.
+func (m *MockSyncRepository) GetDeletedFlightIDsSince(userID string, since time.Time) ([]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedFlightIDsSince", userID, since)
+	ret0, _ := ret[0].([]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedFlightIDsSince indicates an expected call of GetDeletedFlightIDsSince.
+func (mr *MockSyncRepositoryMockRecorder) GetDeletedFlightIDsSince(userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedFlightIDsSince", reflect.TypeOf((*MockSyncRepository)(nil).GetDeletedFlightIDsSince), userID, since)
+}
+
+// GetFlightsChangedSince mocks base method.
+func (m *MockSyncRepository) GetFlightsChangedSince(userID string, since time.Time) ([]model.Flight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlightsChangedSince", userID, since)
+	ret0, _ := ret[0].([]model.Flight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlightsChangedSince indicates an expected call of GetFlightsChangedSince.
+func (mr *MockSyncRepositoryMockRecorder) GetFlightsChangedSince(userID, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlightsChangedSince", reflect.TypeOf((*MockSyncRepository)(nil).GetFlightsChangedSince), userID, since)
+}
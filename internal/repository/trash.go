@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/infrastructure"
+	"github.com/avialog/backend/internal/model"
+	"gorm.io/gorm"
+	"time"
+)
+
+//go:generate mockgen -source=trash.go -destination=trash_mock.go -package repository
+type TrashRepository interface {
+	DeleteFlightTx(tx infrastructure.Database, flightID uint, deletedAt time.Time) error
+	GetDeletedFlightsByUserID(userID string) ([]model.Flight, error)
+	GetDeletedAircraftByUserID(userID string) ([]model.Aircraft, error)
+	GetDeletedContactsByUserID(userID string) ([]model.Contact, error)
+	GetDeletedFlightByUserIDAndID(userID string, id uint) (model.Flight, error)
+	RestoreFlightTx(tx infrastructure.Database, flight model.Flight, restoredAt time.Time) ([]model.Landing, []model.Passenger, error)
+	RestoreAircraft(userID string, id uint, restoredAt time.Time) error
+	RestoreContact(userID string, id uint, restoredAt time.Time) error
+	PurgeDeletedBefore(ctx context.Context, before time.Time) (dto.PurgeResult, error)
+}
+
+type trash struct {
+	db *gorm.DB
+}
+
+func newTrashRepository(db *gorm.DB) TrashRepository {
+	return &trash{
+		db: db,
+	}
+}
+
+// DeleteFlightTx soft deletes the flight with its landings and passengers. They share the deletion time, so the
+// landings and passengers replaced by earlier updates of the flight are not brought back by a restore.
+func (t *trash) DeleteFlightTx(tx infrastructure.Database, flightID uint, deletedAt time.Time) error {
+	result := tx.Where("flight_id = ?", flightID).Model(&model.Landing{}).UpdateColumn("deleted_at", deletedAt)
+	if result.Error != nil {
+		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	result = tx.Where("flight_id = ?", flightID).Model(&model.Passenger{}).UpdateColumn("deleted_at", deletedAt)
+	if result.Error != nil {
+		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	result = tx.Where("id = ?", flightID).Model(&model.Flight{}).UpdateColumn("deleted_at", deletedAt)
+	if result.Error != nil {
+		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: flight cannot be deleted", dto.ErrNotFound)
+	}
+
+	return nil
+}
+
+func (t *trash) GetDeletedFlightsByUserID(userID string) ([]model.Flight, error) {
+	var flights []model.Flight
+	result := t.db.Unscoped().Where("user_id = ? AND deleted_at IS NOT NULL", userID).Order("deleted_at desc").Find(&flights)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return flights, nil
+}
+
+func (t *trash) GetDeletedAircraftByUserID(userID string) ([]model.Aircraft, error) {
+	var aircraft []model.Aircraft
+	result := t.db.Unscoped().Where("user_id = ? AND deleted_at IS NOT NULL", userID).Order("deleted_at desc").Find(&aircraft)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return aircraft, nil
+}
+
+func (t *trash) GetDeletedContactsByUserID(userID string) ([]model.Contact, error) {
+	var contacts []model.Contact
+	result := t.db.Unscoped().Where("user_id = ? AND deleted_at IS NOT NULL", userID).Order("deleted_at desc").Find(&contacts)
+	if result.Error != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return contacts, nil
+}
+
+func (t *trash) GetDeletedFlightByUserIDAndID(userID string, id uint) (model.Flight, error) {
+	var flight model.Flight
+	result := t.db.Unscoped().Where("user_id = ? AND id = ? AND deleted_at IS NOT NULL", userID, id).First(&flight)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return model.Flight{}, fmt.Errorf("%w: %v", dto.ErrNotFound, result.Error)
+		}
+		return model.Flight{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	return flight, nil
+}
+
+// RestoreFlightTx brings back the flight with the landings and passengers deleted together with it and returns them.
+// The flight is marked as updated at the time of the restore, so it is synchronized to the devices again.
+func (t *trash) RestoreFlightTx(tx infrastructure.Database, flight model.Flight,
+	restoredAt time.Time) ([]model.Landing, []model.Passenger, error) {
+	deletedAt := flight.DeletedAt.Time
+
+	result := tx.Where("id = ? AND deleted_at = ?", flight.ID, deletedAt).Unscoped().Model(&model.Flight{}).
+		UpdateColumns(map[string]interface{}{"deleted_at": nil, "updated_at": restoredAt})
+	if result.Error != nil {
+		return nil, nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil, fmt.Errorf("%w: flight cannot be restored", dto.ErrNotFound)
+	}
+
+	var landings []model.Landing
+	result = tx.Where("flight_id = ? AND deleted_at = ?", flight.ID, deletedAt).Unscoped().Order("id asc").Find(&landings)
+	if result.Error != nil {
+		return nil, nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	var passengers []model.Passenger
+	result = tx.Where("flight_id = ? AND deleted_at = ?", flight.ID, deletedAt).Unscoped().Order("id asc").Find(&passengers)
+	if result.Error != nil {
+		return nil, nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	result = tx.Where("flight_id = ? AND deleted_at = ?", flight.ID, deletedAt).Unscoped().Model(&model.Landing{}).
+		UpdateColumn("deleted_at", nil)
+	if result.Error != nil {
+		return nil, nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	result = tx.Where("flight_id = ? AND deleted_at = ?", flight.ID, deletedAt).Unscoped().Model(&model.Passenger{}).
+		UpdateColumn("deleted_at", nil)
+	if result.Error != nil {
+		return nil, nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	for i := range landings {
+		landings[i].DeletedAt = gorm.DeletedAt{}
+	}
+	for i := range passengers {
+		passengers[i].DeletedAt = gorm.DeletedAt{}
+	}
+
+	return landings, passengers, nil
+}
+
+func (t *trash) RestoreAircraft(userID string, id uint, restoredAt time.Time) error {
+	result := t.db.Unscoped().Model(&model.Aircraft{}).Where("user_id = ? AND id = ? AND deleted_at IS NOT NULL", userID, id).
+		UpdateColumns(map[string]interface{}{"deleted_at": nil, "updated_at": restoredAt})
+	if result.Error != nil {
+		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %v", dto.ErrNotFound, "deleted aircraft not found")
+	}
+
+	return nil
+}
+
+func (t *trash) RestoreContact(userID string, id uint, restoredAt time.Time) error {
+	result := t.db.Unscoped().Model(&model.Contact{}).Where("user_id = ? AND id = ? AND deleted_at IS NOT NULL", userID, id).
+		UpdateColumns(map[string]interface{}{"deleted_at": nil, "updated_at": restoredAt})
+	if result.Error != nil {
+		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %v", dto.ErrNotFound, "deleted contact not found")
+	}
+
+	return nil
+}
+
+// PurgeDeletedBefore permanently removes the flights, aircraft and contacts of every user deleted before the given
+// time. The landings, passengers and signatures of the flights are removed with them, the history of the logbook is
+// kept. Landings and passengers replaced by an update before the given time are removed as well, a restore never brings
+// them back. Aircraft still referenced by a flight wait until the flight is purged.
+func (t *trash) PurgeDeletedBefore(ctx context.Context, before time.Time) (dto.PurgeResult, error) {
+	var purgeResult dto.PurgeResult
+
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var flightIDs []uint
+		result := tx.Unscoped().Model(&model.Flight{}).Where("deleted_at < ?", before).Pluck("id", &flightIDs)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		// also removes the landings and passengers deleted together with the purged flights, they share the deletion time
+		result = tx.Unscoped().Where("deleted_at < ?", before).Delete(&model.Landing{})
+		if result.Error != nil {
+			return result.Error
+		}
+		purgeResult.Landings = result.RowsAffected
+
+		result = tx.Unscoped().Where("deleted_at < ?", before).Delete(&model.Passenger{})
+		if result.Error != nil {
+			return result.Error
+		}
+		purgeResult.Passengers = result.RowsAffected
+
+		if len(flightIDs) > 0 {
+			if result := tx.Unscoped().Where("flight_id IN ?", flightIDs).Delete(&model.Signature{}); result.Error != nil {
+				return result.Error
+			}
+			if result := tx.Unscoped().Where("flight_id IN ?", flightIDs).Delete(&model.Landing{}); result.Error != nil {
+				return result.Error
+			}
+			if result := tx.Unscoped().Where("flight_id IN ?", flightIDs).Delete(&model.Passenger{}); result.Error != nil {
+				return result.Error
+			}
+
+			result = tx.Unscoped().Where("id IN ?", flightIDs).Delete(&model.Flight{})
+			if result.Error != nil {
+				return result.Error
+			}
+			purgeResult.Flights = result.RowsAffected
+		}
+
+		result = tx.Unscoped().
+			Where("deleted_at < ? AND NOT EXISTS (SELECT 1 FROM flights WHERE flights.aircraft_id = aircrafts.id)", before).
+			Delete(&model.Aircraft{})
+		if result.Error != nil {
+			return result.Error
+		}
+		purgeResult.Aircraft = result.RowsAffected
+
+		result = tx.Unscoped().Where("deleted_at < ?", before).Delete(&model.Contact{})
+		if result.Error != nil {
+			return result.Error
+		}
+		purgeResult.Contacts = result.RowsAffected
+
+		return nil
+	})
+	if err != nil {
+		return dto.PurgeResult{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+	}
+
+	return purgeResult, nil
+}
@@ -0,0 +1,177 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: trash.go
+//
+// Generated by this command:
+//
+//	mockgen -source=trash.go -destination=trash_mock.go -package repository
+//
+
+// Package repository is a generated GoMock package.
+package repository
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	dto "github.com/avialog/backend/internal/dto"
+	infrastructure "github.com/avialog/backend/internal/infrastructure"
+	model "github.com/avialog/backend/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTrashRepository is a mock of TrashRepository interface.
+type MockTrashRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrashRepositoryMockRecorder
+}
+
+// MockTrashRepositoryMockRecorder is the mock recorder for MockTrashRepository.
+type MockTrashRepositoryMockRecorder struct {
+	mock *MockTrashRepository
+}
+
+// NewMockTrashRepository creates a new mock instance.
+func NewMockTrashRepository(ctrl *gomock.Controller) *MockTrashRepository {
+	mock := &MockTrashRepository{ctrl: ctrl}
+	mock.recorder = &MockTrashRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrashRepository) EXPECT() *MockTrashRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteFlightTx mocks base method.
+func (m *MockTrashRepository) DeleteFlightTx(tx infrastructure.Database, flightID uint, deletedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFlightTx", tx, flightID, deletedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFlightTx indicates an expected call of DeleteFlightTx.
+func (mr *MockTrashRepositoryMockRecorder) DeleteFlightTx(tx, flightID, deletedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFlightTx", reflect.TypeOf((*MockTrashRepository)(nil).DeleteFlightTx), tx, flightID, deletedAt)
+}
+
+// GetDeletedAircraftByUserID mocks base method.
+func (m *MockTrashRepository) GetDeletedAircraftByUserID(userID string) ([]model.Aircraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedAircraftByUserID", userID)
+	ret0, _ := ret[0].([]model.Aircraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedAircraftByUserID indicates an expected call of GetDeletedAircraftByUserID.
+func (mr *MockTrashRepositoryMockRecorder) GetDeletedAircraftByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedAircraftByUserID", reflect.TypeOf((*MockTrashRepository)(nil).GetDeletedAircraftByUserID), userID)
+}
+
+// GetDeletedContactsByUserID mocks base method.
+func (m *MockTrashRepository) GetDeletedContactsByUserID(userID string) ([]model.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedContactsByUserID", userID)
+	ret0, _ := ret[0].([]model.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedContactsByUserID indicates an expected call of GetDeletedContactsByUserID.
+func (mr *MockTrashRepositoryMockRecorder) GetDeletedContactsByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedContactsByUserID", reflect.TypeOf((*MockTrashRepository)(nil).GetDeletedContactsByUserID), userID)
+}
+
+// GetDeletedFlightByUserIDAndID mocks base method.
+func (m *MockTrashRepository) GetDeletedFlightByUserIDAndID(userID string, id uint) (model.Flight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedFlightByUserIDAndID", userID, id)
+	ret0, _ := ret[0].(model.Flight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedFlightByUserIDAndID indicates an expected call of GetDeletedFlightByUserIDAndID.
+func (mr *MockTrashRepositoryMockRecorder) GetDeletedFlightByUserIDAndID(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedFlightByUserIDAndID", reflect.TypeOf((*MockTrashRepository)(nil).GetDeletedFlightByUserIDAndID), userID, id)
+}
+
+// GetDeletedFlightsByUserID mocks base method.
+func (m *MockTrashRepository) GetDeletedFlightsByUserID(userID string) ([]model.Flight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedFlightsByUserID", userID)
+	ret0, _ := ret[0].([]model.Flight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedFlightsByUserID indicates an expected call of GetDeletedFlightsByUserID.
+func (mr *MockTrashRepositoryMockRecorder) GetDeletedFlightsByUserID(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedFlightsByUserID", reflect.TypeOf((*MockTrashRepository)(nil).GetDeletedFlightsByUserID), userID)
+}
+
+// PurgeDeletedBefore mocks base method.
+func (m *MockTrashRepository) PurgeDeletedBefore(ctx context.Context, before time.Time) (dto.PurgeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedBefore", ctx, before)
+	ret0, _ := ret[0].(dto.PurgeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedBefore indicates an expected call of PurgeDeletedBefore.
+func (mr *MockTrashRepositoryMockRecorder) PurgeDeletedBefore(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedBefore", reflect.TypeOf((*MockTrashRepository)(nil).PurgeDeletedBefore), ctx, before)
+}
+
+// RestoreAircraft mocks base method.
+func (m *MockTrashRepository) RestoreAircraft(userID string, id uint, restoredAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreAircraft", userID, id, restoredAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreAircraft indicates an expected call of RestoreAircraft.
+func (mr *MockTrashRepositoryMockRecorder) RestoreAircraft(userID, id, restoredAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreAircraft", reflect.TypeOf((*MockTrashRepository)(nil).RestoreAircraft), userID, id, restoredAt)
+}
+
+// RestoreContact mocks base method.
+func (m *MockTrashRepository) RestoreContact(userID string, id uint, restoredAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreContact", userID, id, restoredAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreContact indicates an expected call of RestoreContact.
+func (mr *MockTrashRepositoryMockRecorder) RestoreContact(userID, id, restoredAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreContact", reflect.TypeOf((*MockTrashRepository)(nil).RestoreContact), userID, id, restoredAt)
+}
+
+// RestoreFlightTx mocks base method.
+func (m *MockTrashRepository) RestoreFlightTx(tx infrastructure.Database, flight model.Flight, restoredAt time.Time) ([]model.Landing, []model.Passenger, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreFlightTx", tx, flight, restoredAt)
+	ret0, _ := ret[0].([]model.Landing)
+	ret1, _ := ret[1].([]model.Passenger)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RestoreFlightTx indicates an expected call of RestoreFlightTx.
+func (mr *MockTrashRepositoryMockRecorder) RestoreFlightTx(tx, flight, restoredAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreFlightTx", reflect.TypeOf((*MockTrashRepository)(nil).RestoreFlightTx), tx, flight, restoredAt)
+}
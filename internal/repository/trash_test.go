@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/avialog/backend/internal/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"os"
+	"time"
+)
+
+// The specs purge a Postgres database, they are skipped unless TEST_DSN points to a database they may write to.
+var _ = Describe("PurgeDeletedBefore", func() {
+	var (
+		db              *gorm.DB
+		trashRepository TrashRepository
+		flight          model.Flight
+		before          time.Time
+	)
+
+	BeforeEach(func() {
+		dsn := os.Getenv("TEST_DSN")
+		if dsn == "" {
+			Skip("TEST_DSN is not set")
+		}
+
+		var err error
+		db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+		Expect(err).To(BeNil())
+		_, err = NewRepositories(db)
+		Expect(err).To(BeNil())
+		trashRepository = newTrashRepository(db)
+
+		userID := fmt.Sprintf("trash-%d", time.Now().UnixNano())
+		Expect(db.Create(&model.User{ID: userID, Email: userID + "@example.com"}).Error).To(BeNil())
+		aircraft := model.Aircraft{UserID: userID, RegistrationNumber: "SP-ABC", AircraftModel: "C152"}
+		Expect(db.Create(&aircraft).Error).To(BeNil())
+		takeoffTime := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+		flight = model.Flight{
+			UserID:             userID,
+			AircraftID:         aircraft.ID,
+			TakeoffTime:        takeoffTime,
+			TakeoffAirportCode: "EPKK",
+			LandingTime:        takeoffTime.Add(time.Hour),
+			LandingAirportCode: "EPWA",
+			Style:              model.StyleVFR,
+			MyRole:             model.RolePilotInCommand,
+		}
+		Expect(db.Create(&flight).Error).To(BeNil())
+		before = time.Now().Add(-30 * 24 * time.Hour)
+
+		DeferCleanup(func() {
+			db.Unscoped().Where("flight_id = ?", flight.ID).Delete(&model.Landing{})
+			db.Unscoped().Where("flight_id = ?", flight.ID).Delete(&model.Passenger{})
+			db.Unscoped().Where("user_id = ?", userID).Delete(&model.Flight{})
+			db.Unscoped().Where("user_id = ?", userID).Delete(&model.Aircraft{})
+			db.Unscoped().Where("id = ?", userID).Delete(&model.User{})
+		})
+	})
+
+	Context("when updates of a kept flight replaced its landings and passengers", func() {
+		It("should remove only those replaced before the given time", func() {
+			// given
+			replacedAt := []gorm.DeletedAt{
+				{Time: before.Add(-24 * time.Hour), Valid: true},
+				{Time: before.Add(time.Hour), Valid: true},
+				{},
+			}
+			for _, deletedAt := range replacedAt {
+				Expect(db.Create(&model.Landing{FlightID: flight.ID, ApproachType: model.ApproachTypeVisual,
+					Model: gorm.Model{DeletedAt: deletedAt}}).Error).To(BeNil())
+				Expect(db.Create(&model.Passenger{FlightID: flight.ID, Role: model.RoleSecondInCommand, FirstName: "John",
+					Model: gorm.Model{DeletedAt: deletedAt}}).Error).To(BeNil())
+			}
+
+			// when
+			purgeResult, err := trashRepository.PurgeDeletedBefore(context.Background(), before)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(purgeResult.Landings).To(BeNumerically(">=", 1))
+			Expect(purgeResult.Passengers).To(BeNumerically(">=", 1))
+			var landings []model.Landing
+			Expect(db.Unscoped().Where("flight_id = ?", flight.ID).Find(&landings).Error).To(BeNil())
+			Expect(landings).To(HaveLen(2))
+			var passengers []model.Passenger
+			Expect(db.Unscoped().Where("flight_id = ?", flight.ID).Find(&passengers).Error).To(BeNil())
+			Expect(passengers).To(HaveLen(2))
+			var flights int64
+			Expect(db.Model(&model.Flight{}).Where("id = ?", flight.ID).Count(&flights).Error).To(BeNil())
+			Expect(flights).To(Equal(int64(1)))
+		})
+	})
+})
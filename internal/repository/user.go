@@ -6,13 +6,16 @@ import (
 	"github.com/avialog/backend/internal/dto"
 	"github.com/avialog/backend/internal/model"
 	"gorm.io/gorm"
+	"time"
 )
 
 //go:generate mockgen -source=user.go -destination=user_mock.go -package repository
 type UserRepository interface {
 	Create(user model.User) (model.User, error)
 	GetByID(id string) (model.User, error)
+	GetByEmail(email string) (model.User, error)
 	Save(user model.User) (model.User, error)
+	SaveIfUnchanged(user model.User, updatedAt time.Time) (model.User, error)
 	DeleteByID(id string) error
 }
 
@@ -47,6 +50,18 @@ func (u *user) GetByID(id string) (model.User, error) {
 	return user, nil
 }
 
+func (u *user) GetByEmail(email string) (model.User, error) {
+	var user model.User
+	result := u.db.First(&user, "LOWER(email) = LOWER(?)", email)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return model.User{}, fmt.Errorf("%w: %v", dto.ErrNotFound, result.Error)
+		}
+		return model.User{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+	return user, nil
+}
+
 func (u *user) Save(user model.User) (model.User, error) {
 	result := u.db.Save(&user)
 	if result.Error != nil {
@@ -56,6 +71,15 @@ func (u *user) Save(user model.User) (model.User, error) {
 	return user, nil
 }
 
+// SaveIfUnchanged saves the user only if it is still at the given update time.
+func (u *user) SaveIfUnchanged(user model.User, updatedAt time.Time) (model.User, error) {
+	if err := saveIfUnchanged(u.db, &user, updatedAt); err != nil {
+		return model.User{}, err
+	}
+
+	return user, nil
+}
+
 func (u *user) DeleteByID(id string) error {
 	result := u.db.Delete(&model.User{}, id)
 	if result.Error != nil {
@@ -11,6 +11,7 @@ package repository
 
 import (
 	reflect "reflect"
+	time "time"
 
 	model "github.com/avialog/backend/internal/model"
 	gomock "go.uber.org/mock/gomock"
@@ -68,6 +69,21 @@ func (mr *MockUserRepositoryMockRecorder) DeleteByID(id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByID", reflect.TypeOf((*MockUserRepository)(nil).DeleteByID), id)
 }
 
+// GetByEmail mocks base method.
+func (m *MockUserRepository) GetByEmail(email string) (model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByEmail", email)
+	ret0, _ := ret[0].(model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByEmail indicates an expected call of GetByEmail.
+func (mr *MockUserRepositoryMockRecorder) GetByEmail(email any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByEmail", reflect.TypeOf((*MockUserRepository)(nil).GetByEmail), email)
+}
+
 // GetByID mocks base method.
 func (m *MockUserRepository) GetByID(id string) (model.User, error) {
 	m.ctrl.T.Helper()
@@ -97,3 +113,18 @@ func (mr *MockUserRepositoryMockRecorder) Save(user any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockUserRepository)(nil).Save), user)
 }
+
+// SaveIfUnchanged mocks base method.
+func (m *MockUserRepository) SaveIfUnchanged(user model.User, updatedAt time.Time) (model.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveIfUnchanged", user, updatedAt)
+	ret0, _ := ret[0].(model.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveIfUnchanged indicates an expected call of SaveIfUnchanged.
+func (mr *MockUserRepositoryMockRecorder) SaveIfUnchanged(user, updatedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveIfUnchanged", reflect.TypeOf((*MockUserRepository)(nil).SaveIfUnchanged), user, updatedAt)
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+// saveIfUnchanged updates all columns of the record in a single statement that only matches the row while it is still
+// at the given update time. A change committed by someone else in between is reported as a failed precondition instead
+// of being overwritten.
+func saveIfUnchanged(db *gorm.DB, record interface{}, updatedAt time.Time) error {
+	result := db.Model(record).Where("updated_at = ?", updatedAt).Select("*").Omit(clause.Associations).Updates(record)
+	if result.Error != nil {
+		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %v", dto.ErrPreconditionFailed, "changed by another request")
+	}
+
+	return nil
+}
+
+// deleteIfUnchanged soft deletes the record of the user in a single statement that only matches the row while it is
+// still at the given update time. A row changed or deleted by someone else in between is reported as a failed
+// precondition.
+func deleteIfUnchanged(db *gorm.DB, record interface{}, userID string, id uint, updatedAt time.Time) error {
+	result := db.Where("id = ? AND user_id = ? AND updated_at = ?", id, userID, updatedAt).Delete(record)
+	if result.Error != nil {
+		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %v", dto.ErrPreconditionFailed, "changed by another request")
+	}
+
+	return nil
+}
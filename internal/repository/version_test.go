@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"os"
+	"time"
+)
+
+// The specs run the conditional update against Postgres, they are skipped unless TEST_DSN points to a database they may
+// write to.
+var _ = Describe("SaveIfUnchanged", func() {
+	var (
+		db                 *gorm.DB
+		aircraftRepository AircraftRepository
+		stored             model.Aircraft
+	)
+
+	BeforeEach(func() {
+		dsn := os.Getenv("TEST_DSN")
+		if dsn == "" {
+			Skip("TEST_DSN is not set")
+		}
+
+		var err error
+		db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Discard})
+		Expect(err).To(BeNil())
+		_, err = NewRepositories(db)
+		Expect(err).To(BeNil())
+		aircraftRepository = newAircraftRepository(db)
+
+		userID := fmt.Sprintf("version-%d", time.Now().UnixNano())
+		Expect(db.Create(&model.User{ID: userID, Email: userID + "@example.com"}).Error).To(BeNil())
+		stored, err = aircraftRepository.Create(model.Aircraft{UserID: userID, RegistrationNumber: "SP-ABC", AircraftModel: "C152"})
+		Expect(err).To(BeNil())
+		stored, err = aircraftRepository.GetByUserIDAndID(userID, stored.ID)
+		Expect(err).To(BeNil())
+
+		DeferCleanup(func() {
+			db.Unscoped().Where("user_id = ?", userID).Delete(&model.Aircraft{})
+			db.Unscoped().Where("id = ?", userID).Delete(&model.User{})
+		})
+	})
+
+	Context("when two requests change the aircraft they read at the same version", func() {
+		It("should save the first change and reject the second", func() {
+			// given
+			first := stored
+			first.AircraftModel = "C172"
+			second := stored
+			second.AircraftModel = "PA-28"
+
+			// when
+			saved, firstErr := aircraftRepository.SaveIfUnchanged(first, stored.UpdatedAt)
+			_, secondErr := aircraftRepository.SaveIfUnchanged(second, stored.UpdatedAt)
+
+			// then
+			Expect(firstErr).To(BeNil())
+			Expect(saved.UpdatedAt.After(stored.UpdatedAt)).To(BeTrue())
+			Expect(errors.Is(secondErr, dto.ErrPreconditionFailed)).To(BeTrue())
+			current, err := aircraftRepository.GetByUserIDAndID(stored.UserID, stored.ID)
+			Expect(err).To(BeNil())
+			Expect(current.AircraftModel).To(Equal("C172"))
+		})
+	})
+	Context("when the requests run concurrently", func() {
+		It("should save exactly one of them", func() {
+			// given
+			models := []string{"C172", "PA-28", "SR22", "DA40"}
+			errs := make(chan error, len(models))
+
+			// when
+			for _, aircraftModel := range models {
+				go func(aircraftModel string) {
+					defer GinkgoRecover()
+					changed := stored
+					changed.AircraftModel = aircraftModel
+					_, err := aircraftRepository.SaveIfUnchanged(changed, stored.UpdatedAt)
+					errs <- err
+				}(aircraftModel)
+			}
+
+			// then
+			saved := 0
+			for range models {
+				err := <-errs
+				if err == nil {
+					saved++
+					continue
+				}
+				Expect(errors.Is(err, dto.ErrPreconditionFailed)).To(BeTrue())
+			}
+			Expect(saved).To(Equal(1))
+		})
+	})
+	Context("when the aircraft is deleted at a version it no longer has", func() {
+		It("should keep the aircraft and reject the deletion", func() {
+			// given
+			changed := stored
+			changed.AircraftModel = "C172"
+			saved, err := aircraftRepository.SaveIfUnchanged(changed, stored.UpdatedAt)
+			Expect(err).To(BeNil())
+
+			// when
+			staleErr := aircraftRepository.DeleteIfUnchanged(stored.UserID, stored.ID, stored.UpdatedAt)
+			currentErr := aircraftRepository.DeleteIfUnchanged(stored.UserID, stored.ID, saved.UpdatedAt)
+
+			// then
+			Expect(errors.Is(staleErr, dto.ErrPreconditionFailed)).To(BeTrue())
+			Expect(currentErr).To(BeNil())
+			_, err = aircraftRepository.GetByUserIDAndID(stored.UserID, stored.ID)
+			Expect(errors.Is(err, dto.ErrNotFound)).To(BeTrue())
+		})
+	})
+})
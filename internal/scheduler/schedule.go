@@ -0,0 +1,182 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxScheduleYears bounds the search for the next time of a schedule that never matches, like 30 February.
+const maxScheduleYears = 5
+
+// Schedule tells when a job runs. Every replica has to compute the same times, as a run is identified by its job and
+// scheduled time, so schedules are evaluated in UTC and intervals are counted from the zero time.
+type Schedule interface {
+	// Next returns the first scheduled time after the given time, or the zero time if there is none.
+	Next(after time.Time) time.Time
+}
+
+type cronSchedule struct {
+	minutes, hours, days, months, weekdays uint64
+	// anyDay and anyWeekday tell whether the fields were *, if both are restricted a day matching either one matches
+	anyDay, anyWeekday bool
+}
+
+type intervalSchedule struct {
+	interval time.Duration
+}
+
+type field struct {
+	min, max int
+}
+
+var (
+	minuteField  = field{0, 59}
+	hourField    = field{0, 23}
+	dayField     = field{1, 31}
+	monthField   = field{1, 12}
+	weekdayField = field{0, 7}
+)
+
+var descriptors = map[string]string{
+	"@hourly":  "0 * * * *",
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// Parse parses a cron expression with the five fields minute, hour, day of month, month and day of week. Fields are *,
+// numbers, ranges like 1-5 and lists of them, each optionally with a step like */15. Sunday is 0 or 7. The descriptors
+// @hourly, @daily, @weekly, @monthly and @every followed by a duration, like @every 10m, are supported as well.
+func Parse(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if interval, ok := strings.CutPrefix(spec, "@every "); ok {
+		duration, err := time.ParseDuration(strings.TrimSpace(interval))
+		if err != nil || duration < time.Second {
+			return nil, fmt.Errorf("invalid interval %q", interval)
+		}
+		return intervalSchedule{interval: duration}, nil
+	}
+	if expression, ok := descriptors[spec]; ok {
+		spec = expression
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields in schedule %q, got %d", spec, len(fields))
+	}
+
+	schedule := cronSchedule{anyDay: fields[2] == "*", anyWeekday: fields[4] == "*"}
+	var err error
+	for i, target := range []struct {
+		bits  *uint64
+		field field
+	}{
+		{&schedule.minutes, minuteField},
+		{&schedule.hours, hourField},
+		{&schedule.days, dayField},
+		{&schedule.months, monthField},
+		{&schedule.weekdays, weekdayField},
+	} {
+		*target.bits, err = parseField(fields[i], target.field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule %q: %w", spec, err)
+		}
+	}
+	if schedule.weekdays&(1<<7) != 0 {
+		schedule.weekdays |= 1
+	}
+
+	return schedule, nil
+}
+
+// MustParse is like Parse but panics if the schedule is invalid, for schedules fixed in the code.
+func MustParse(spec string) Schedule {
+	schedule, err := Parse(spec)
+	if err != nil {
+		panic(err)
+	}
+	return schedule
+}
+
+func parseField(value string, field field) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(value, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step < 1 {
+				return 0, fmt.Errorf("invalid step %q", stepPart)
+			}
+		}
+
+		start, end := field.min, field.max
+		if rangePart != "*" {
+			first, last, isRange := strings.Cut(rangePart, "-")
+			var err error
+			start, err = strconv.Atoi(first)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", first)
+			}
+			end = start
+			if isRange {
+				end, err = strconv.Atoi(last)
+				if err != nil {
+					return 0, fmt.Errorf("invalid value %q", last)
+				}
+			} else if hasStep {
+				end = field.max
+			}
+		}
+		if start < field.min || end > field.max || start > end {
+			return 0, fmt.Errorf("%q out of range %d-%d", part, field.min, field.max)
+		}
+
+		for i := start; i <= end; i += step {
+			bits |= 1 << i
+		}
+	}
+	return bits, nil
+}
+
+func (c cronSchedule) Next(after time.Time) time.Time {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxScheduleYears, 0, 0)
+
+	for t.Before(limit) {
+		if c.months&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !c.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if c.hours&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if c.minutes&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (c cronSchedule) matchesDay(t time.Time) bool {
+	day := c.days&(1<<uint(t.Day())) != 0
+	weekday := c.weekdays&(1<<uint(t.Weekday())) != 0
+	if !c.anyDay && !c.anyWeekday {
+		return day || weekday
+	}
+	return day && weekday
+}
+
+func (i intervalSchedule) Next(after time.Time) time.Time {
+	return after.UTC().Truncate(i.interval).Add(i.interval)
+}
@@ -0,0 +1,71 @@
+package scheduler
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"time"
+)
+
+var _ = Describe("Schedule", func() {
+	var after time.Time
+
+	BeforeEach(func() {
+		// Saturday
+		after = time.Date(2024, 4, 20, 12, 34, 56, 0, time.UTC)
+	})
+
+	DescribeTable("Next",
+		func(spec string, expected time.Time) {
+			// given
+			schedule, err := Parse(spec)
+			Expect(err).To(BeNil())
+
+			// when
+			next := schedule.Next(after)
+
+			// then
+			Expect(next).To(Equal(expected))
+		},
+		Entry("every minute", "* * * * *", time.Date(2024, 4, 20, 12, 35, 0, 0, time.UTC)),
+		Entry("hourly", "@hourly", time.Date(2024, 4, 20, 13, 0, 0, 0, time.UTC)),
+		Entry("every quarter of an hour", "*/15 * * * *", time.Date(2024, 4, 20, 12, 45, 0, 0, time.UTC)),
+		Entry("daily at a time already passed", "30 8 * * *", time.Date(2024, 4, 21, 8, 30, 0, 0, time.UTC)),
+		Entry("on weekdays", "0 9 * * 1-5", time.Date(2024, 4, 22, 9, 0, 0, 0, time.UTC)),
+		Entry("on Sundays as 7", "0 9 * * 7", time.Date(2024, 4, 21, 9, 0, 0, 0, time.UTC)),
+		Entry("on a day of month or a weekday", "0 0 1 * 1", time.Date(2024, 4, 22, 0, 0, 0, 0, time.UTC)),
+		Entry("monthly", "@monthly", time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)),
+		Entry("on 29 February", "0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)),
+		Entry("never", "0 0 30 2 *", time.Time{}),
+		Entry("every 10 minutes", "@every 10m", time.Date(2024, 4, 20, 12, 40, 0, 0, time.UTC)),
+	)
+
+	Context("when the time is not in UTC", func() {
+		It("should evaluate the schedule in UTC", func() {
+			// given
+			schedule := MustParse("0 0 * * *")
+			warsaw := time.FixedZone("CEST", 2*60*60)
+
+			// when
+			next := schedule.Next(time.Date(2024, 4, 21, 1, 0, 0, 0, warsaw))
+
+			// then
+			Expect(next).To(Equal(time.Date(2024, 4, 21, 0, 0, 0, 0, time.UTC)))
+		})
+	})
+
+	DescribeTable("Parse with invalid schedule",
+		func(spec string) {
+			// when
+			_, err := Parse(spec)
+
+			// then
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("too few fields", "0 * * *"),
+		Entry("minute out of range", "60 * * * *"),
+		Entry("reversed range", "0 5-1 * * *"),
+		Entry("zero step", "*/0 * * * *"),
+		Entry("not a number", "a * * * *"),
+		Entry("invalid interval", "@every soon"),
+	)
+})
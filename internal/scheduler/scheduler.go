@@ -0,0 +1,191 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	"github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxAttempts = 3
+	defaultBackoff     = time.Minute
+	defaultTimeout     = 10 * time.Minute
+)
+
+// Job is work done periodically in the background. Run returns a short summary of what it did, which is stored with
+// the run.
+type Job struct {
+	Name     string
+	Schedule Schedule
+	Run      func(ctx context.Context) (string, error)
+	// MaxAttempts is how many times a failing run is tried, 3 if not set
+	MaxAttempts int
+	// Backoff is the wait before the second attempt, doubled before each further one, 1 minute if not set
+	Backoff time.Duration
+	// Timeout limits a single attempt, 10 minutes if not set
+	Timeout time.Duration
+}
+
+// Scheduler runs the registered jobs on every replica. Before a run is executed, it is claimed in the database, so a
+// single replica executes it while the others skip it.
+type Scheduler interface {
+	Register(job Job) error
+	Run(ctx context.Context)
+}
+
+type scheduler struct {
+	jobRunRepository repository.JobRunRepository
+	replica          string
+	jobs             []Job
+	now              func() time.Time
+	after            func(d time.Duration) <-chan time.Time
+}
+
+func NewScheduler(jobRunRepository repository.JobRunRepository, replica string) Scheduler {
+	return newScheduler(jobRunRepository, replica, time.Now, time.After)
+}
+
+func newScheduler(jobRunRepository repository.JobRunRepository, replica string, now func() time.Time,
+	after func(d time.Duration) <-chan time.Time) *scheduler {
+	return &scheduler{jobRunRepository: jobRunRepository, replica: replica, now: now, after: after}
+}
+
+func (s *scheduler) Register(job Job) error {
+	if job.Name == "" || job.Schedule == nil || job.Run == nil {
+		return errors.New("job needs a name, a schedule and a function to run")
+	}
+	for _, registered := range s.jobs {
+		if registered.Name == job.Name {
+			return fmt.Errorf("job %s is already registered", job.Name)
+		}
+	}
+
+	if job.MaxAttempts < 1 {
+		job.MaxAttempts = defaultMaxAttempts
+	}
+	if job.Backoff <= 0 {
+		job.Backoff = defaultBackoff
+	}
+	if job.Timeout <= 0 {
+		job.Timeout = defaultTimeout
+	}
+	s.jobs = append(s.jobs, job)
+	return nil
+}
+
+// Run executes the jobs at their scheduled times until the context is done. Runs missed while no replica was running
+// are not caught up on.
+func (s *scheduler) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, job := range s.jobs {
+		wg.Add(1)
+		go func(job Job) {
+			defer wg.Done()
+			s.loop(ctx, job)
+		}(job)
+	}
+	wg.Wait()
+}
+
+func (s *scheduler) loop(ctx context.Context, job Job) {
+	for {
+		scheduledAt := job.Schedule.Next(s.now())
+		if scheduledAt.IsZero() {
+			logrus.Warnf("job %s has no further scheduled runs", job.Name)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.after(scheduledAt.Sub(s.now())):
+		}
+		s.execute(ctx, job, scheduledAt)
+	}
+}
+
+// lockTime is how long a run may take with all its attempts, after which it is considered abandoned.
+func (j Job) lockTime() time.Duration {
+	lockTime := time.Duration(j.MaxAttempts) * j.Timeout
+	for attempt := 1; attempt < j.MaxAttempts; attempt++ {
+		lockTime += j.backoff(attempt)
+	}
+	return lockTime
+}
+
+// backoff returns the wait after the failed attempt.
+func (j Job) backoff(attempt int) time.Duration {
+	return j.Backoff << (attempt - 1)
+}
+
+// execute claims the run and, if this replica owns it, executes it until an attempt succeeds or all attempts failed.
+func (s *scheduler) execute(ctx context.Context, job Job, scheduledAt time.Time) {
+	now := s.now()
+	run, owned, err := s.jobRunRepository.Claim(model.JobRun{
+		Job:         job.Name,
+		ScheduledAt: scheduledAt,
+		Status:      model.JobRunStatusRunning,
+		Replica:     s.replica,
+		StartedAt:   now,
+		LockedUntil: now.Add(job.lockTime()),
+	}, now)
+	if err != nil {
+		logrus.Errorf("error claiming run of job %s scheduled at %s: %v", job.Name, scheduledAt, err)
+		return
+	}
+	if !owned {
+		logrus.Debugf("run of job %s scheduled at %s is executed by another replica", job.Name, scheduledAt)
+		return
+	}
+
+	for {
+		run.Attempts++
+		attemptCtx, cancel := context.WithTimeout(ctx, job.Timeout)
+		summary, err := job.Run(attemptCtx)
+		cancel()
+
+		if err == nil {
+			run.Status = model.JobRunStatusSucceeded
+			run.Summary = &summary
+			run.Error = nil
+			break
+		}
+
+		message := err.Error()
+		run.Error = &message
+		if run.Attempts >= job.MaxAttempts || ctx.Err() != nil {
+			run.Status = model.JobRunStatusFailed
+			break
+		}
+		logrus.Warnf("attempt %d of job %s failed, retrying: %v", run.Attempts, job.Name, err)
+		if _, err := s.jobRunRepository.Save(run); err != nil {
+			if errors.Is(err, dto.ErrNotFound) {
+				logrus.Warnf("run of job %s scheduled at %s was taken over by another replica", job.Name, scheduledAt)
+				return
+			}
+			logrus.Errorf("error saving run of job %s: %v", job.Name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-s.after(job.backoff(run.Attempts)):
+		}
+	}
+
+	finishedAt := s.now()
+	run.FinishedAt = &finishedAt
+	if run.Status == model.JobRunStatusFailed {
+		logrus.Errorf("job %s failed after %d attempts: %s", job.Name, run.Attempts, *run.Error)
+	} else {
+		logrus.Infof("job %s succeeded: %s", job.Name, *run.Summary)
+	}
+	if _, err := s.jobRunRepository.Save(run); err != nil {
+		logrus.Errorf("error saving run of job %s: %v", job.Name, err)
+	}
+}
@@ -0,0 +1,179 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"time"
+)
+
+var _ = Describe("Scheduler", func() {
+	var (
+		jobRunRepoCtrl *gomock.Controller
+		jobRunRepoMock *repository.MockJobRunRepository
+		jobs           *scheduler
+		now            time.Time
+		scheduledAt    time.Time
+		waits          []time.Duration
+		attempts       int
+		job            Job
+	)
+
+	BeforeEach(func() {
+		jobRunRepoCtrl = gomock.NewController(GinkgoT())
+		jobRunRepoMock = repository.NewMockJobRunRepository(jobRunRepoCtrl)
+		now = time.Date(2024, 4, 20, 13, 0, 0, 0, time.UTC)
+		scheduledAt = now
+		waits = nil
+		attempts = 0
+		jobs = newScheduler(jobRunRepoMock, "avialog-1", func() time.Time { return now },
+			func(d time.Duration) <-chan time.Time {
+				waits = append(waits, d)
+				ch := make(chan time.Time, 1)
+				ch <- now
+				return ch
+			})
+		job = Job{
+			Name:     "purge-trash",
+			Schedule: MustParse("@hourly"),
+			Run: func(context.Context) (string, error) {
+				attempts++
+				return "purged 2 flights", nil
+			},
+		}
+		Expect(jobs.Register(job)).To(Succeed())
+		job = jobs.jobs[0]
+	})
+
+	AfterEach(func() {
+		jobRunRepoCtrl.Finish()
+	})
+
+	Describe("Register", func() {
+		It("should apply the defaults", func() {
+			Expect(job.MaxAttempts).To(Equal(3))
+			Expect(job.Backoff).To(Equal(time.Minute))
+			Expect(job.Timeout).To(Equal(10 * time.Minute))
+			Expect(job.lockTime()).To(Equal(33 * time.Minute))
+		})
+		It("should reject a job registered twice", func() {
+			Expect(jobs.Register(job)).To(MatchError("job purge-trash is already registered"))
+		})
+	})
+
+	Describe("execute", func() {
+		Context("when another replica claimed the run", func() {
+			It("should not run the job", func() {
+				// given
+				jobRunRepoMock.EXPECT().Claim(gomock.Any(), now).Return(model.JobRun{}, false, nil)
+
+				// when
+				jobs.execute(context.Background(), job, scheduledAt)
+
+				// then
+				Expect(attempts).To(Equal(0))
+			})
+		})
+		Context("when the replica claims the run", func() {
+			It("should run the job and store the outcome", func() {
+				// given
+				claimedRun := model.JobRun{ID: 7, Job: "purge-trash", ScheduledAt: scheduledAt,
+					Status: model.JobRunStatusRunning, Replica: "avialog-1", StartedAt: now,
+					LockedUntil: now.Add(33 * time.Minute)}
+				jobRunRepoMock.EXPECT().Claim(model.JobRun{Job: "purge-trash", ScheduledAt: scheduledAt,
+					Status: model.JobRunStatusRunning, Replica: "avialog-1", StartedAt: now,
+					LockedUntil: now.Add(33 * time.Minute)}, now).Return(claimedRun, true, nil)
+				jobRunRepoMock.EXPECT().Save(gomock.Any()).DoAndReturn(func(run model.JobRun) (model.JobRun, error) {
+					Expect(run.ID).To(Equal(uint(7)))
+					Expect(run.Status).To(Equal(model.JobRunStatusSucceeded))
+					Expect(run.Attempts).To(Equal(1))
+					Expect(*run.Summary).To(Equal("purged 2 flights"))
+					Expect(*run.FinishedAt).To(Equal(now))
+					return run, nil
+				})
+
+				// when
+				jobs.execute(context.Background(), job, scheduledAt)
+
+				// then
+				Expect(attempts).To(Equal(1))
+			})
+		})
+		Context("when the job fails", func() {
+			It("should retry it with exponential backoff", func() {
+				// given
+				job.Run = func(context.Context) (string, error) {
+					attempts++
+					return "", errors.New("connection refused")
+				}
+				jobRunRepoMock.EXPECT().Claim(gomock.Any(), now).Return(model.JobRun{ID: 7}, true, nil)
+				jobRunRepoMock.EXPECT().Save(gomock.Any()).Times(2).Return(model.JobRun{}, nil)
+				jobRunRepoMock.EXPECT().Save(gomock.Any()).DoAndReturn(func(run model.JobRun) (model.JobRun, error) {
+					Expect(run.Status).To(Equal(model.JobRunStatusFailed))
+					Expect(run.Attempts).To(Equal(3))
+					Expect(*run.Error).To(Equal("connection refused"))
+					return run, nil
+				})
+
+				// when
+				jobs.execute(context.Background(), job, scheduledAt)
+
+				// then
+				Expect(attempts).To(Equal(3))
+				Expect(waits).To(Equal([]time.Duration{time.Minute, 2 * time.Minute}))
+			})
+		})
+		Context("when another replica takes the run over between attempts", func() {
+			It("should stop without retrying", func() {
+				// given
+				job.Run = func(context.Context) (string, error) {
+					attempts++
+					return "", errors.New("connection refused")
+				}
+				jobRunRepoMock.EXPECT().Claim(gomock.Any(), now).
+					Return(model.JobRun{ID: 7, Replica: "avialog-1"}, true, nil)
+				jobRunRepoMock.EXPECT().Save(gomock.Any()).
+					Return(model.JobRun{}, fmt.Errorf("%w: %v", dto.ErrNotFound, "run is executed by another replica"))
+
+				// when
+				jobs.execute(context.Background(), job, scheduledAt)
+
+				// then
+				Expect(attempts).To(Equal(1))
+				Expect(waits).To(BeEmpty())
+			})
+		})
+		Context("when the job succeeds after a failure", func() {
+			It("should clear the error", func() {
+				// given
+				job.Run = func(context.Context) (string, error) {
+					attempts++
+					if attempts == 1 {
+						return "", errors.New("connection refused")
+					}
+					return "done", nil
+				}
+				jobRunRepoMock.EXPECT().Claim(gomock.Any(), now).Return(model.JobRun{ID: 7}, true, nil)
+				jobRunRepoMock.EXPECT().Save(gomock.Any()).Return(model.JobRun{}, nil)
+				jobRunRepoMock.EXPECT().Save(gomock.Any()).DoAndReturn(func(run model.JobRun) (model.JobRun, error) {
+					Expect(run.Status).To(Equal(model.JobRunStatusSucceeded))
+					Expect(run.Attempts).To(Equal(2))
+					Expect(run.Error).To(BeNil())
+					return run, nil
+				})
+
+				// when
+				jobs.execute(context.Background(), job, scheduledAt)
+
+				// then
+				Expect(attempts).To(Equal(2))
+			})
+		})
+	})
+})
@@ -8,14 +8,16 @@ import (
 	"github.com/avialog/backend/internal/model"
 	"github.com/avialog/backend/internal/repository"
 	"github.com/go-playground/validator/v10"
+	"time"
 )
 
 //go:generate mockgen -source=aircraft.go -destination=aircraft_mock.go -package service
 type AircraftService interface {
 	InsertAircraft(userID string, aircraftRequest dto.AircraftRequest) (model.Aircraft, error)
 	GetUserAircraft(userID string) ([]model.Aircraft, error)
-	UpdateAircraft(userID string, id uint, aircraftRequest dto.AircraftRequest) (model.Aircraft, error)
-	DeleteAircraft(userID string, id uint) error
+	GetAircraftByID(userID string, id uint) (model.Aircraft, error)
+	UpdateAircraft(userID string, id uint, aircraftRequest dto.AircraftRequest, version *time.Time) (model.Aircraft, error)
+	DeleteAircraft(userID string, id uint, version *time.Time) error
 }
 
 type aircraftService struct {
@@ -35,6 +37,7 @@ func (a *aircraftService) InsertAircraft(userID string, aircraftRequest dto.Airc
 		UserID:             userID,
 		AircraftModel:      aircraftRequest.AircraftModel,
 		RegistrationNumber: aircraftRequest.RegistrationNumber,
+		Category:           aircraftRequest.Category,
 		ImageURL:           aircraftRequest.ImageURL,
 		Remarks:            aircraftRequest.Remarks,
 	}
@@ -61,7 +64,14 @@ func (a *aircraftService) GetUserAircraft(userID string) ([]model.Aircraft, erro
 	return a.aircraftRepository.GetByUserID(userID)
 }
 
-func (a *aircraftService) UpdateAircraft(userID string, id uint, aircraftRequest dto.AircraftRequest) (model.Aircraft, error) {
+func (a *aircraftService) GetAircraftByID(userID string, id uint) (model.Aircraft, error) {
+	return a.aircraftRepository.GetByUserIDAndID(userID, id)
+}
+
+// UpdateAircraft changes the aircraft if it is still at the given version, or regardless of its version if none is
+// given.
+func (a *aircraftService) UpdateAircraft(userID string, id uint, aircraftRequest dto.AircraftRequest,
+	version *time.Time) (model.Aircraft, error) {
 	aircraft, err := a.aircraftRepository.GetByUserIDAndID(userID, id)
 	if err != nil {
 		if errors.Is(err, dto.ErrNotFound) {
@@ -70,8 +80,13 @@ func (a *aircraftService) UpdateAircraft(userID string, id uint, aircraftRequest
 		return model.Aircraft{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
 	}
 
+	if err := checkVersion(version, aircraft.UpdatedAt, "aircraft"); err != nil {
+		return model.Aircraft{}, err
+	}
+
 	aircraft.AircraftModel = aircraftRequest.AircraftModel
 	aircraft.RegistrationNumber = aircraftRequest.RegistrationNumber
+	aircraft.Category = aircraftRequest.Category
 	aircraft.ImageURL = aircraftRequest.ImageURL
 	aircraft.Remarks = aircraftRequest.Remarks
 
@@ -90,10 +105,14 @@ func (a *aircraftService) UpdateAircraft(userID string, id uint, aircraftRequest
 		}
 	}
 
+	if version != nil {
+		// the version was checked on read, the write fails if the aircraft changed since
+		return a.aircraftRepository.SaveIfUnchanged(aircraft, aircraft.UpdatedAt)
+	}
 	return a.aircraftRepository.Save(aircraft)
 }
 
-func (a *aircraftService) DeleteAircraft(userID string, id uint) error {
+func (a *aircraftService) DeleteAircraft(userID string, id uint, version *time.Time) error {
 	numberOfFlights, err := a.flightRepository.CountByUserIDAndAircraftID(userID, id)
 	if err != nil {
 		return err
@@ -103,10 +122,8 @@ func (a *aircraftService) DeleteAircraft(userID string, id uint) error {
 		return fmt.Errorf("%w: aircraft has assigned flights", dto.ErrConflict)
 	}
 
-	err = a.aircraftRepository.DeleteByUserIDAndID(userID, id)
-	if err != nil {
-		return err
+	if version != nil {
+		return a.aircraftRepository.DeleteIfUnchanged(userID, id, *version)
 	}
-
-	return nil
+	return a.aircraftRepository.DeleteByUserIDAndID(userID, id)
 }
@@ -11,6 +11,7 @@ package service
 
 import (
 	reflect "reflect"
+	time "time"
 
 	dto "github.com/avialog/backend/internal/dto"
 	model "github.com/avialog/backend/internal/model"
@@ -41,17 +42,32 @@ func (m *MockAircraftService) EXPECT() *MockAircraftServiceMockRecorder {
 }
 
 // DeleteAircraft mocks base method.
-func (m *MockAircraftService) DeleteAircraft(userID string, id uint) error {
+func (m *MockAircraftService) DeleteAircraft(userID string, id uint, version *time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteAircraft", userID, id)
+	ret := m.ctrl.Call(m, "DeleteAircraft", userID, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteAircraft indicates an expected call of DeleteAircraft.
-func (mr *MockAircraftServiceMockRecorder) DeleteAircraft(userID, id any) *gomock.Call {
+func (mr *MockAircraftServiceMockRecorder) DeleteAircraft(userID, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAircraft", reflect.TypeOf((*MockAircraftService)(nil).DeleteAircraft), userID, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAircraft", reflect.TypeOf((*MockAircraftService)(nil).DeleteAircraft), userID, id, version)
+}
+
+// GetAircraftByID mocks base method.
+func (m *MockAircraftService) GetAircraftByID(userID string, id uint) (model.Aircraft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAircraftByID", userID, id)
+	ret0, _ := ret[0].(model.Aircraft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAircraftByID indicates an expected call of GetAircraftByID.
+func (mr *MockAircraftServiceMockRecorder) GetAircraftByID(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAircraftByID", reflect.TypeOf((*MockAircraftService)(nil).GetAircraftByID), userID, id)
 }
 
 // GetUserAircraft mocks base method.
@@ -85,16 +101,16 @@ func (mr *MockAircraftServiceMockRecorder) InsertAircraft(userID, aircraftReques
 }
 
 // UpdateAircraft mocks base method.
-func (m *MockAircraftService) UpdateAircraft(userID string, id uint, aircraftRequest dto.AircraftRequest) (model.Aircraft, error) {
+func (m *MockAircraftService) UpdateAircraft(userID string, id uint, aircraftRequest dto.AircraftRequest, version *time.Time) (model.Aircraft, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateAircraft", userID, id, aircraftRequest)
+	ret := m.ctrl.Call(m, "UpdateAircraft", userID, id, aircraftRequest, version)
 	ret0, _ := ret[0].(model.Aircraft)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateAircraft indicates an expected call of UpdateAircraft.
-func (mr *MockAircraftServiceMockRecorder) UpdateAircraft(userID, id, aircraftRequest any) *gomock.Call {
+func (mr *MockAircraftServiceMockRecorder) UpdateAircraft(userID, id, aircraftRequest, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAircraft", reflect.TypeOf((*MockAircraftService)(nil).UpdateAircraft), userID, id, aircraftRequest)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAircraft", reflect.TypeOf((*MockAircraftService)(nil).UpdateAircraft), userID, id, aircraftRequest, version)
 }
@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"github.com/avialog/backend/internal/config"
 	"github.com/avialog/backend/internal/dto"
 	"github.com/avialog/backend/internal/model"
@@ -11,6 +12,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
+	"time"
 )
 
 var _ = Describe("AircraftService", func() {
@@ -119,6 +121,20 @@ var _ = Describe("AircraftService", func() {
 			})
 
 		})
+		Context("when aircraft request has invalid category", func() {
+			It("should return error", func() {
+				// given
+				category := model.AircraftCategory("SPACESHIP")
+				aircraftRequest.Category = &category
+
+				// when
+				insertedAircraft, err := aircraftService.InsertAircraft("1", aircraftRequest)
+
+				// then
+				Expect(err.Error()).To(Equal("bad request: invalid data in field: Category"))
+				Expect(insertedAircraft).To(Equal(model.Aircraft{}))
+			})
+		})
 	})
 
 	Describe("GetUserAircraft", func() {
@@ -174,7 +190,7 @@ var _ = Describe("AircraftService", func() {
 				flightRepoMock.EXPECT().CountByUserIDAndAircraftID("1", uint(1)).Return(int64(0), errors.New("failed to count flights"))
 
 				// when
-				err := aircraftService.DeleteAircraft("1", uint(1))
+				err := aircraftService.DeleteAircraft("1", uint(1), nil)
 
 				// then
 				Expect(err.Error()).To(Equal("failed to count flights"))
@@ -187,7 +203,7 @@ var _ = Describe("AircraftService", func() {
 				aircraftRepoMock.EXPECT().DeleteByUserIDAndID("1", uint(1)).Return(errors.New("failed to delete aircraft"))
 
 				// when
-				err := aircraftService.DeleteAircraft("1", uint(1))
+				err := aircraftService.DeleteAircraft("1", uint(1), nil)
 
 				// then
 				Expect(err.Error()).To(Equal("failed to delete aircraft"))
@@ -200,19 +216,34 @@ var _ = Describe("AircraftService", func() {
 				aircraftRepoMock.EXPECT().DeleteByUserIDAndID("1", uint(1)).Return(nil)
 
 				// when
-				err := aircraftService.DeleteAircraft("1", uint(1))
+				err := aircraftService.DeleteAircraft("1", uint(1), nil)
 
 				// then
 				Expect(err).To(BeNil())
 			})
 		})
+		Context("when aircraft changed since the given version", func() {
+			It("should return precondition failed", func() {
+				// given
+				version := time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC)
+				flightRepoMock.EXPECT().CountByUserIDAndAircraftID("1", uint(1)).Return(int64(0), nil)
+				aircraftRepoMock.EXPECT().DeleteIfUnchanged("1", uint(1), version).
+					Return(fmt.Errorf("%w: %v", dto.ErrPreconditionFailed, "changed by another request"))
+
+				// when
+				err := aircraftService.DeleteAircraft("1", uint(1), &version)
+
+				// then
+				Expect(errors.Is(err, dto.ErrPreconditionFailed)).To(BeTrue())
+			})
+		})
 		Context("when aircraft has assigned flights", func() {
 			It("should return error", func() {
 				//given
 				flightRepoMock.EXPECT().CountByUserIDAndAircraftID("1", uint(1)).Return(int64(1), nil)
 
 				// when
-				err := aircraftService.DeleteAircraft("1", uint(1))
+				err := aircraftService.DeleteAircraft("1", uint(1), nil)
 
 				// then
 				Expect(err.Error()).To(Equal("conflict: aircraft has assigned flights"))
@@ -225,7 +256,7 @@ var _ = Describe("AircraftService", func() {
 				aircraftRepoMock.EXPECT().DeleteByUserIDAndID("1", uint(1)).Return(errors.New("no aircraft to delete or unauthorized to delete aircraft"))
 
 				// when
-				err := aircraftService.DeleteAircraft("1", uint(1))
+				err := aircraftService.DeleteAircraft("1", uint(1), nil)
 
 				// then
 				Expect(err.Error()).To(Equal("no aircraft to delete or unauthorized to delete aircraft"))
@@ -240,13 +271,59 @@ var _ = Describe("AircraftService", func() {
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("1", uint(1)).Return(model.Aircraft{}, errors.New("failed to get aircraft"))
 
 				// when
-				updatedAircraft, err := aircraftService.UpdateAircraft("1", uint(1), aircraftRequest)
+				updatedAircraft, err := aircraftService.UpdateAircraft("1", uint(1), aircraftRequest, nil)
 
 				// then
 				Expect(err.Error()).To(Equal("internal failure: failed to get aircraft"))
 				Expect(updatedAircraft).To(Equal(model.Aircraft{}))
 			})
 		})
+		Context("when aircraft changed since the given version", func() {
+			It("should return precondition failed error", func() {
+				// given
+				mockAircraft.UpdatedAt = time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC)
+				version := time.Date(2024, 4, 18, 8, 0, 0, 0, time.UTC)
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("1", uint(1)).Return(mockAircraft, nil)
+
+				// when
+				updatedAircraft, err := aircraftService.UpdateAircraft("1", uint(1), aircraftRequest, &version)
+
+				// then
+				Expect(errors.Is(err, dto.ErrPreconditionFailed)).To(BeTrue())
+				Expect(updatedAircraft).To(Equal(model.Aircraft{}))
+			})
+		})
+		Context("when aircraft is still at the given version", func() {
+			It("should update the aircraft", func() {
+				// given
+				mockAircraft.UpdatedAt = time.Date(2024, 4, 19, 8, 0, 0, 123456789, time.UTC)
+				version := time.UnixMicro(mockAircraft.UpdatedAt.UnixMicro())
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("1", uint(1)).Return(mockAircraft, nil)
+				aircraftRepoMock.EXPECT().SaveIfUnchanged(gomock.Any(), mockAircraft.UpdatedAt).Return(mockAircraft, nil)
+
+				// when
+				_, err := aircraftService.UpdateAircraft("1", uint(1), aircraftRequest, &version)
+
+				// then
+				Expect(err).To(BeNil())
+			})
+		})
+		Context("when another request changes the aircraft after the version is checked", func() {
+			It("should return precondition failed error", func() {
+				// given
+				version := mockAircraft.UpdatedAt
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("1", uint(1)).Return(mockAircraft, nil)
+				aircraftRepoMock.EXPECT().SaveIfUnchanged(gomock.Any(), mockAircraft.UpdatedAt).
+					Return(model.Aircraft{}, fmt.Errorf("%w: %v", dto.ErrPreconditionFailed, "changed by another request"))
+
+				// when
+				updatedAircraft, err := aircraftService.UpdateAircraft("1", uint(1), aircraftRequest, &version)
+
+				// then
+				Expect(errors.Is(err, dto.ErrPreconditionFailed)).To(BeTrue())
+				Expect(updatedAircraft).To(Equal(model.Aircraft{}))
+			})
+		})
 		Context("when update fails", func() {
 			It("should return error", func() {
 				// given
@@ -254,7 +331,7 @@ var _ = Describe("AircraftService", func() {
 				aircraftRepoMock.EXPECT().Save(mockAircraft).Return(model.Aircraft{}, errors.New("failed to update aircraft"))
 
 				// when
-				updatedAircraft, err := aircraftService.UpdateAircraft("1", uint(1), aircraftRequest)
+				updatedAircraft, err := aircraftService.UpdateAircraft("1", uint(1), aircraftRequest, nil)
 
 				// then
 				Expect(err.Error()).To(Equal("failed to update aircraft"))
@@ -268,7 +345,7 @@ var _ = Describe("AircraftService", func() {
 				aircraftRepoMock.EXPECT().Save(mockAircraft).Return(mockAircraft, nil)
 
 				// when
-				updatedAircraft, err := aircraftService.UpdateAircraft("1", uint(1), aircraftRequest)
+				updatedAircraft, err := aircraftService.UpdateAircraft("1", uint(1), aircraftRequest, nil)
 
 				// then
 				Expect(err).To(BeNil())
@@ -281,7 +358,7 @@ var _ = Describe("AircraftService", func() {
 				aircraftRequest.RegistrationNumber = ""
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("1", uint(1)).Return(mockAircraft, nil)
 				// when
-				updatedAircraft, err := aircraftService.UpdateAircraft("1", uint(1), aircraftRequest)
+				updatedAircraft, err := aircraftService.UpdateAircraft("1", uint(1), aircraftRequest, nil)
 
 				// then
 				Expect(err.Error()).To(Equal("bad request: invalid data in field: RegistrationNumber"))
@@ -296,7 +373,7 @@ var _ = Describe("AircraftService", func() {
 				aircraftRequest.AircraftModel = ""
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("1", uint(1)).Return(mockAircraft, nil)
 				// when
-				updatedAircraft, err := aircraftService.UpdateAircraft("1", uint(1), aircraftRequest)
+				updatedAircraft, err := aircraftService.UpdateAircraft("1", uint(1), aircraftRequest, nil)
 
 				// then
 				Expect(err.Error()).To(Equal("bad request: invalid data in field: AircraftModel"))
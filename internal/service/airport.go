@@ -0,0 +1,141 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	"strings"
+)
+
+const (
+	defaultAirportSearchLimit = 10
+	maxAirportSearchLimit     = 50
+)
+
+//go:generate mockgen -source=airport.go -destination=airport_mock.go -package service
+type AirportService interface {
+	SearchAirports(query string, limit *int) ([]dto.AirportResponse, error)
+}
+
+type airportService struct {
+	airportRepository repository.AirportRepository
+}
+
+func newAirportService(airportRepository repository.AirportRepository) AirportService {
+	return &airportService{airportRepository: airportRepository}
+}
+
+func (a *airportService) SearchAirports(query string, limit *int) ([]dto.AirportResponse, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, fmt.Errorf("%w: %v", dto.ErrBadRequest, "query must not be empty")
+	}
+
+	searchLimit := defaultAirportSearchLimit
+	if limit != nil {
+		if *limit < 1 || *limit > maxAirportSearchLimit {
+			return nil, fmt.Errorf("%w: limit must be between 1 and %d", dto.ErrBadRequest, maxAirportSearchLimit)
+		}
+		searchLimit = *limit
+	}
+
+	airports := a.airportRepository.Search(query, searchLimit)
+	airportResponses := make([]dto.AirportResponse, 0, len(airports))
+	for _, airport := range airports {
+		airportResponses = append(airportResponses, dto.AirportResponse{
+			ICAOCode:  airport.ICAOCode,
+			IATACode:  airport.IATACode,
+			Name:      airport.Name,
+			Country:   airport.Country,
+			Latitude:  airport.Latitude,
+			Longitude: airport.Longitude,
+			Elevation: airport.Elevation,
+			Timezone:  airport.Timezone,
+		})
+	}
+
+	return airportResponses, nil
+}
+
+type unknownAirportCodeError struct {
+	code string
+}
+
+func (e unknownAirportCodeError) Error() string {
+	return fmt.Sprintf("unknown airport code: %v", e.code)
+}
+
+// getAirport returns the airport with the given ICAO or IATA code. Unknown codes are reported as a bad request.
+func getAirport(airportRepository repository.AirportRepository, code string) (model.Airport, error) {
+	airport, err := airportRepository.GetByCode(code)
+	if err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			return model.Airport{}, fmt.Errorf("%w: %w", dto.ErrBadRequest, unknownAirportCodeError{code: code})
+		}
+		return model.Airport{}, err
+	}
+	return airport, nil
+}
+
+// findAirport returns the airport with the given ICAO or IATA code, or false if the code is not in the dataset.
+func findAirport(airportRepository repository.AirportRepository, code string) (model.Airport, bool, error) {
+	airport, err := airportRepository.GetByCode(code)
+	if err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			return model.Airport{}, false, nil
+		}
+		return model.Airport{}, false, err
+	}
+	return airport, true, nil
+}
+
+// normalizeAirportCode returns the ICAO code of the airport with the given ICAO or IATA code. Codes which are not in
+// the dataset, like those of small airfields and strips, are returned as given.
+func normalizeAirportCode(airportRepository repository.AirportRepository, code string) (string, error) {
+	airport, found, err := findAirport(airportRepository, code)
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return code, nil
+	}
+	return airport.ICAOCode, nil
+}
+
+// normalizeAirportCodes replaces all known airport codes of the request with ICAO codes. Empty codes are left for the
+// validator to report.
+func normalizeAirportCodes(airportRepository repository.AirportRepository, request *dto.LogbookRequest) error {
+	var err error
+	if strings.TrimSpace(request.TakeoffAirportCode) != "" {
+		if request.TakeoffAirportCode, err = normalizeAirportCode(airportRepository, request.TakeoffAirportCode); err != nil {
+			return err
+		}
+	}
+	if strings.TrimSpace(request.LandingAirportCode) != "" {
+		if request.LandingAirportCode, err = normalizeAirportCode(airportRepository, request.LandingAirportCode); err != nil {
+			return err
+		}
+	}
+
+	if len(request.Landings) == 0 {
+		return nil
+	}
+
+	// landings are copied so the caller's slice isn't modified
+	landings := make([]dto.LandingEntry, len(request.Landings))
+	copy(landings, request.Landings)
+	for i := range landings {
+		if landings[i].AirportCode == nil || strings.TrimSpace(*landings[i].AirportCode) == "" {
+			continue
+		}
+		airportCode, err := normalizeAirportCode(airportRepository, *landings[i].AirportCode)
+		if err != nil {
+			return err
+		}
+		landings[i].AirportCode = &airportCode
+	}
+	request.Landings = landings
+
+	return nil
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: airport.go
+//
+// Generated by this command:
+//
+//	mockgen -source=airport.go -destination=airport_mock.go -package service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	reflect "reflect"
+
+	dto "github.com/avialog/backend/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAirportService is a mock of AirportService interface.
+type MockAirportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAirportServiceMockRecorder
+}
+
+// MockAirportServiceMockRecorder is the mock recorder for MockAirportService.
+type MockAirportServiceMockRecorder struct {
+	mock *MockAirportService
+}
+
+// NewMockAirportService creates a new mock instance.
+func NewMockAirportService(ctrl *gomock.Controller) *MockAirportService {
+	mock := &MockAirportService{ctrl: ctrl}
+	mock.recorder = &MockAirportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAirportService) EXPECT() *MockAirportServiceMockRecorder {
+	return m.recorder
+}
+
+// SearchAirports mocks base method.
+func (m *MockAirportService) SearchAirports(query string, limit *int) ([]dto.AirportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchAirports", query, limit)
+	ret0, _ := ret[0].([]dto.AirportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchAirports indicates an expected call of SearchAirports.
+func (mr *MockAirportServiceMockRecorder) SearchAirports(query, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchAirports", reflect.TypeOf((*MockAirportService)(nil).SearchAirports), query, limit)
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	"github.com/avialog/backend/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+)
+
+var _ = Describe("AirportService", func() {
+	var (
+		airportService  AirportService
+		airportRepoCtrl *gomock.Controller
+		airportRepoMock *repository.MockAirportRepository
+		mockAirport     model.Airport
+	)
+
+	BeforeEach(func() {
+		airportRepoCtrl = gomock.NewController(GinkgoT())
+		airportRepoMock = repository.NewMockAirportRepository(airportRepoCtrl)
+		airportService = newAirportService(airportRepoMock)
+		mockAirport = model.Airport{
+			ICAOCode:  "EPKK",
+			IATACode:  util.String("KRK"),
+			Name:      "Kraków John Paul II International Airport",
+			Country:   "PL",
+			Latitude:  50.0777,
+			Longitude: 19.7848,
+			Elevation: 791,
+			Timezone:  "Europe/Warsaw",
+		}
+	})
+
+	AfterEach(func() {
+		airportRepoCtrl.Finish()
+	})
+
+	Describe("SearchAirports", func() {
+		Context("when limit is not provided", func() {
+			It("should search with the default limit", func() {
+				// given
+				airportRepoMock.EXPECT().Search("krk", 10).Return([]model.Airport{mockAirport})
+
+				// when
+				airports, err := airportService.SearchAirports("krk", nil)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(airports).To(Equal([]dto.AirportResponse{{
+					ICAOCode:  "EPKK",
+					IATACode:  util.String("KRK"),
+					Name:      "Kraków John Paul II International Airport",
+					Country:   "PL",
+					Latitude:  50.0777,
+					Longitude: 19.7848,
+					Elevation: 791,
+					Timezone:  "Europe/Warsaw",
+				}}))
+			})
+		})
+		Context("when nothing matches", func() {
+			It("should return an empty list", func() {
+				// given
+				airportRepoMock.EXPECT().Search("zzz", 5).Return([]model.Airport{})
+
+				// when
+				airports, err := airportService.SearchAirports("zzz", util.Int(5))
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(airports).To(BeEmpty())
+				Expect(airports).ToNot(BeNil())
+			})
+		})
+		Context("when query is empty", func() {
+			It("should return bad request error", func() {
+				// when
+				airports, err := airportService.SearchAirports("  ", nil)
+
+				// then
+				Expect(err.Error()).To(Equal("bad request: query must not be empty"))
+				Expect(airports).To(BeNil())
+			})
+		})
+		Context("when limit is out of range", func() {
+			It("should return bad request error", func() {
+				// when
+				airports, err := airportService.SearchAirports("krk", util.Int(51))
+
+				// then
+				Expect(err.Error()).To(Equal("bad request: limit must be between 1 and 50"))
+				Expect(airports).To(BeNil())
+			})
+		})
+	})
+})
@@ -8,14 +8,16 @@ import (
 	"github.com/avialog/backend/internal/model"
 	"github.com/avialog/backend/internal/repository"
 	"github.com/go-playground/validator/v10"
+	"time"
 )
 
 //go:generate mockgen -source=contact.go -destination=contact_mock.go -package service
 type ContactService interface {
 	InsertContact(userID string, contactRequest dto.ContactRequest) (model.Contact, error)
 	GetUserContacts(userID string) ([]model.Contact, error)
-	UpdateContact(userID string, id uint, contactRequest dto.ContactRequest) (model.Contact, error)
-	DeleteContact(userID string, id uint) error
+	GetContact(userID string, id uint) (model.Contact, error)
+	UpdateContact(userID string, id uint, contactRequest dto.ContactRequest, version *time.Time) (model.Contact, error)
+	DeleteContact(userID string, id uint, version *time.Time) error
 }
 
 type contactService struct {
@@ -61,21 +63,30 @@ func (c *contactService) GetUserContacts(userID string) ([]model.Contact, error)
 	return c.contactRepository.GetByUserID(userID)
 }
 
-func (c *contactService) DeleteContact(userID string, id uint) error {
-	err := c.contactRepository.DeleteByUserIDAndID(userID, id)
-	if err != nil {
-		return err
+func (c *contactService) DeleteContact(userID string, id uint, version *time.Time) error {
+	if version != nil {
+		return c.contactRepository.DeleteIfUnchanged(userID, id, *version)
 	}
+	return c.contactRepository.DeleteByUserIDAndID(userID, id)
+}
 
-	return nil
+func (c *contactService) GetContact(userID string, id uint) (model.Contact, error) {
+	return c.contactRepository.GetByUserIDAndID(userID, id)
 }
 
-func (c *contactService) UpdateContact(userID string, id uint, contactRequest dto.ContactRequest) (model.Contact, error) {
+// UpdateContact changes the contact if it is still at the given version, or regardless of its version if none is
+// given.
+func (c *contactService) UpdateContact(userID string, id uint, contactRequest dto.ContactRequest,
+	version *time.Time) (model.Contact, error) {
 	contact, err := c.contactRepository.GetByUserIDAndID(userID, id)
 	if err != nil {
 		return model.Contact{}, err
 	}
 
+	if err := checkVersion(version, contact.UpdatedAt, "contact"); err != nil {
+		return model.Contact{}, err
+	}
+
 	contact.FirstName = contactRequest.FirstName
 	contact.LastName = contactRequest.LastName
 	contact.Phone = contactRequest.Phone
@@ -99,5 +110,9 @@ func (c *contactService) UpdateContact(userID string, id uint, contactRequest dt
 		}
 	}
 
+	if version != nil {
+		// the version was checked on read, the write fails if the contact changed since
+		return c.contactRepository.SaveIfUnchanged(contact, contact.UpdatedAt)
+	}
 	return c.contactRepository.Save(contact)
 }
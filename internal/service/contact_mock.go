@@ -11,6 +11,7 @@ package service
 
 import (
 	reflect "reflect"
+	time "time"
 
 	dto "github.com/avialog/backend/internal/dto"
 	model "github.com/avialog/backend/internal/model"
@@ -41,17 +42,32 @@ func (m *MockContactService) EXPECT() *MockContactServiceMockRecorder {
 }
 
 // DeleteContact mocks base method.
-func (m *MockContactService) DeleteContact(userID string, id uint) error {
+func (m *MockContactService) DeleteContact(userID string, id uint, version *time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteContact", userID, id)
+	ret := m.ctrl.Call(m, "DeleteContact", userID, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteContact indicates an expected call of DeleteContact.
-func (mr *MockContactServiceMockRecorder) DeleteContact(userID, id any) *gomock.Call {
+func (mr *MockContactServiceMockRecorder) DeleteContact(userID, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContact", reflect.TypeOf((*MockContactService)(nil).DeleteContact), userID, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContact", reflect.TypeOf((*MockContactService)(nil).DeleteContact), userID, id, version)
+}
+
+// GetContact mocks base method.
+func (m *MockContactService) GetContact(userID string, id uint) (model.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContact", userID, id)
+	ret0, _ := ret[0].(model.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContact indicates an expected call of GetContact.
+func (mr *MockContactServiceMockRecorder) GetContact(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContact", reflect.TypeOf((*MockContactService)(nil).GetContact), userID, id)
 }
 
 // GetUserContacts mocks base method.
@@ -85,16 +101,16 @@ func (mr *MockContactServiceMockRecorder) InsertContact(userID, contactRequest a
 }
 
 // UpdateContact mocks base method.
-func (m *MockContactService) UpdateContact(userID string, id uint, contactRequest dto.ContactRequest) (model.Contact, error) {
+func (m *MockContactService) UpdateContact(userID string, id uint, contactRequest dto.ContactRequest, version *time.Time) (model.Contact, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateContact", userID, id, contactRequest)
+	ret := m.ctrl.Call(m, "UpdateContact", userID, id, contactRequest, version)
 	ret0, _ := ret[0].(model.Contact)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateContact indicates an expected call of UpdateContact.
-func (mr *MockContactServiceMockRecorder) UpdateContact(userID, id, contactRequest any) *gomock.Call {
+func (mr *MockContactServiceMockRecorder) UpdateContact(userID, id, contactRequest, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContact", reflect.TypeOf((*MockContactService)(nil).UpdateContact), userID, id, contactRequest)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContact", reflect.TypeOf((*MockContactService)(nil).UpdateContact), userID, id, contactRequest, version)
 }
@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"github.com/avialog/backend/internal/config"
 	"github.com/avialog/backend/internal/dto"
 	"github.com/avialog/backend/internal/model"
@@ -11,6 +12,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"time"
 )
 
 var _ = Describe("ContactService", func() {
@@ -167,19 +170,33 @@ var _ = Describe("ContactService", func() {
 				contactRepoMock.EXPECT().DeleteByUserIDAndID("1", uint(1)).Return(nil)
 
 				// when
-				err := contactService.DeleteContact("1", uint(1))
+				err := contactService.DeleteContact("1", uint(1), nil)
 
 				// then
 				Expect(err).To(BeNil())
 			})
 		})
+		Context("when contact changed since the given version", func() {
+			It("should return precondition failed", func() {
+				// given
+				version := time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC)
+				contactRepoMock.EXPECT().DeleteIfUnchanged("1", uint(1), version).
+					Return(fmt.Errorf("%w: %v", dto.ErrPreconditionFailed, "changed by another request"))
+
+				// when
+				err := contactService.DeleteContact("1", uint(1), &version)
+
+				// then
+				Expect(errors.Is(err, dto.ErrPreconditionFailed)).To(BeTrue())
+			})
+		})
 		Context("when delete operation fails", func() {
 			It("should return error", func() {
 				// given
 				contactRepoMock.EXPECT().DeleteByUserIDAndID("1", uint(1)).Return(errors.New("failed to delete contact"))
 
 				// when
-				err := contactService.DeleteContact("1", uint(1))
+				err := contactService.DeleteContact("1", uint(1), nil)
 
 				// then
 				Expect(err.Error()).To(Equal("failed to delete contact"))
@@ -191,7 +208,7 @@ var _ = Describe("ContactService", func() {
 				contactRepoMock.EXPECT().DeleteByUserIDAndID("1", uint(1)).Return(errors.New("no contact to delete or unauthorized to delete contact"))
 
 				// when
-				err := contactService.DeleteContact("1", uint(1))
+				err := contactService.DeleteContact("1", uint(1), nil)
 
 				// then
 				Expect(err.Error()).To(Equal("no contact to delete or unauthorized to delete contact"))
@@ -205,13 +222,45 @@ var _ = Describe("ContactService", func() {
 				contactRepoMock.EXPECT().GetByUserIDAndID("1", uint(1)).Return(model.Contact{}, errors.New("failed to get contact"))
 
 				// when
-				updatedContact, err := contactService.UpdateContact("1", uint(1), contactRequest)
+				updatedContact, err := contactService.UpdateContact("1", uint(1), contactRequest, nil)
 
 				// then
 				Expect(err.Error()).To(Equal("failed to get contact"))
 				Expect(updatedContact).To(Equal(model.Contact{}))
 			})
 		})
+		Context("when contact changed since the given version", func() {
+			It("should return precondition failed error", func() {
+				// given
+				version := time.Date(2024, 4, 18, 8, 0, 0, 0, time.UTC)
+				contactRepoMock.EXPECT().GetByUserIDAndID("1", uint(1)).
+					Return(model.Contact{UserID: "1", Model: gorm.Model{UpdatedAt: version.Add(time.Hour)}}, nil)
+
+				// when
+				updatedContact, err := contactService.UpdateContact("1", uint(1), contactRequest, &version)
+
+				// then
+				Expect(errors.Is(err, dto.ErrPreconditionFailed)).To(BeTrue())
+				Expect(updatedContact).To(Equal(model.Contact{}))
+			})
+		})
+		Context("when another request changes the contact after the version is checked", func() {
+			It("should return precondition failed error", func() {
+				// given
+				version := time.Date(2024, 4, 18, 8, 0, 0, 0, time.UTC)
+				contactRepoMock.EXPECT().GetByUserIDAndID("1", uint(1)).
+					Return(model.Contact{UserID: "1", Model: gorm.Model{UpdatedAt: version}}, nil)
+				contactRepoMock.EXPECT().SaveIfUnchanged(gomock.Any(), version).
+					Return(model.Contact{}, fmt.Errorf("%w: %v", dto.ErrPreconditionFailed, "changed by another request"))
+
+				// when
+				updatedContact, err := contactService.UpdateContact("1", uint(1), contactRequest, &version)
+
+				// then
+				Expect(errors.Is(err, dto.ErrPreconditionFailed)).To(BeTrue())
+				Expect(updatedContact).To(Equal(model.Contact{}))
+			})
+		})
 		Context("when update fails", func() {
 			It("should return error", func() {
 				// given
@@ -219,7 +268,7 @@ var _ = Describe("ContactService", func() {
 				contactRepoMock.EXPECT().Save(mockContact).Return(model.Contact{}, errors.New("failed to update contact"))
 
 				// when
-				updatedContact, err := contactService.UpdateContact("1", uint(1), contactRequest)
+				updatedContact, err := contactService.UpdateContact("1", uint(1), contactRequest, nil)
 
 				// then
 				Expect(err.Error()).To(Equal("failed to update contact"))
@@ -233,7 +282,7 @@ var _ = Describe("ContactService", func() {
 				contactRepoMock.EXPECT().Save(mockContact).Return(mockContact, nil)
 
 				// when
-				updatedContact, err := contactService.UpdateContact("1", uint(1), contactRequest)
+				updatedContact, err := contactService.UpdateContact("1", uint(1), contactRequest, nil)
 
 				// then
 				Expect(err).To(BeNil())
@@ -246,7 +295,7 @@ var _ = Describe("ContactService", func() {
 				contactRequest.FirstName = ""
 				contactRepoMock.EXPECT().GetByUserIDAndID("1", uint(1)).Return(mockContact, nil)
 				// when
-				updatedContact, err := contactService.UpdateContact("1", uint(1), contactRequest)
+				updatedContact, err := contactService.UpdateContact("1", uint(1), contactRequest, nil)
 
 				// then
 				Expect(err.Error()).To(Equal("bad request: invalid data in field: FirstName"))
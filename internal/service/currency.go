@@ -0,0 +1,244 @@
+package service
+
+import (
+	"github.com/avialog/backend/internal/config"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	"slices"
+	"sort"
+	"time"
+)
+
+const (
+	requirementTakeoffsAndLandings      = "takeoffs_and_landings"
+	requirementNightTakeoffsAndLandings = "night_takeoffs_and_landings"
+	requirementInstrumentApproaches     = "instrument_approaches"
+	requirementHoldingProcedures        = "holding_procedures"
+
+	passengerRequiredLandings        = 3
+	passengerCurrencyDays            = 90
+	instrumentRequiredApproaches     = 6
+	instrumentRequiredHoldings       = 1
+	instrumentCurrencyCalendarMonths = 6
+)
+
+//go:generate mockgen -source=currency.go -destination=currency_mock.go -package service
+type CurrencyService interface {
+	GetCurrency(userID string) ([]dto.CurrencyResponse, error)
+}
+
+type currencyService struct {
+	flightRepository   repository.FlightRepository
+	landingRepository  repository.LandingRepository
+	aircraftRepository repository.AircraftRepository
+	config             config.Config
+	now                func() time.Time
+}
+
+type currencyEvent struct {
+	time  time.Time
+	count uint
+}
+
+type currencyEvents struct {
+	landings      []currencyEvent
+	nightLandings []currencyEvent
+	approaches    []currencyEvent
+	holdings      []currencyEvent
+}
+
+func newCurrencyService(flightRepository repository.FlightRepository, landingRepository repository.LandingRepository,
+	aircraftRepository repository.AircraftRepository, config config.Config, now func() time.Time) CurrencyService {
+	return &currencyService{flightRepository: flightRepository, landingRepository: landingRepository,
+		aircraftRepository: aircraftRepository, config: config, now: now}
+}
+
+func (c *currencyService) GetCurrency(userID string) ([]dto.CurrencyResponse, error) {
+	now := c.now()
+	instrumentWindowStart := time.Date(now.Year(), now.Month()-instrumentCurrencyCalendarMonths, 1, 0, 0, 0, 0, now.Location())
+	passengerWindowStart := now.AddDate(0, 0, -passengerCurrencyDays)
+
+	aircraft, err := c.aircraftRepository.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	flights, err := c.flightRepository.GetByUserIDAndDate(userID, instrumentWindowStart, now)
+	if err != nil {
+		return nil, err
+	}
+
+	flightIDs := make([]uint, 0, len(flights))
+	for _, flight := range flights {
+		flightIDs = append(flightIDs, flight.ID)
+	}
+
+	landings, err := c.landingRepository.GetByFlightIDs(flightIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	landingsByFlightID := make(map[uint][]model.Landing)
+	for _, landing := range landings {
+		landingsByFlightID[landing.FlightID] = append(landingsByFlightID[landing.FlightID], landing)
+	}
+
+	categoryByAircraftID := make(map[uint]model.AircraftCategory)
+	eventsByCategory := make(map[model.AircraftCategory]*currencyEvents)
+	for _, a := range aircraft {
+		var category model.AircraftCategory
+		if a.Category != nil {
+			category = *a.Category
+		}
+		categoryByAircraftID[a.ID] = category
+		if _, ok := eventsByCategory[category]; !ok {
+			eventsByCategory[category] = &currencyEvents{}
+		}
+	}
+
+	for _, flight := range flights {
+		events, ok := eventsByCategory[categoryByAircraftID[flight.AircraftID]]
+		if !ok {
+			continue
+		}
+
+		for _, landing := range landingsByFlightID[flight.ID] {
+			count := landingCount(landing)
+			events.landings = append(events.landings, currencyEvent{time: flight.LandingTime, count: count})
+			if landing.NightCount != nil {
+				events.nightLandings = append(events.nightLandings, currencyEvent{time: flight.LandingTime, count: *landing.NightCount})
+			}
+			if slices.Contains(model.InstrumentApproachTypes, landing.ApproachType) {
+				events.approaches = append(events.approaches, currencyEvent{time: flight.LandingTime, count: count})
+			}
+		}
+
+		if flight.Holdings != nil {
+			events.holdings = append(events.holdings, currencyEvent{time: flight.LandingTime, count: *flight.Holdings})
+		}
+	}
+
+	categories := make([]model.AircraftCategory, 0, len(eventsByCategory))
+	for category := range eventsByCategory {
+		categories = append(categories, category)
+	}
+	// aircraft without category are reported last
+	sort.Slice(categories, func(i, j int) bool {
+		return categoryOrder(categories[i]) < categoryOrder(categories[j])
+	})
+
+	addDays := func(t time.Time) time.Time { return t.AddDate(0, 0, passengerCurrencyDays) }
+	addCalendarMonths := func(t time.Time) time.Time {
+		return time.Date(t.Year(), t.Month()+instrumentCurrencyCalendarMonths+1, 1, 0, 0, 0, 0, t.Location())
+	}
+
+	currencyResponses := make([]dto.CurrencyResponse, 0, len(categories))
+	for i, category := range categories {
+		events := eventsByCategory[category]
+
+		var responseCategory *model.AircraftCategory
+		if category != "" {
+			responseCategory = &categories[i]
+		}
+
+		passenger, passengerRequirement := evaluateCurrency(events.landings, requirementTakeoffsAndLandings,
+			passengerRequiredLandings, passengerWindowStart, now, addDays)
+		nightPassenger, nightPassengerRequirement := evaluateCurrency(events.nightLandings, requirementNightTakeoffsAndLandings,
+			passengerRequiredLandings, passengerWindowStart, now, addDays)
+		approaches, approachesRequirement := evaluateCurrency(events.approaches, requirementInstrumentApproaches,
+			instrumentRequiredApproaches, instrumentWindowStart, now, addCalendarMonths)
+		holdings, holdingsRequirement := evaluateCurrency(events.holdings, requirementHoldingProcedures,
+			instrumentRequiredHoldings, instrumentWindowStart, now, addCalendarMonths)
+
+		instrumentLapsesAt := approaches
+		if holdings == nil || (approaches != nil && holdings.Before(*approaches)) {
+			instrumentLapsesAt = holdings
+		}
+
+		currencyResponses = append(currencyResponses, dto.CurrencyResponse{
+			Category: responseCategory,
+			Passenger: dto.CurrencyStatus{
+				Current:      passenger != nil && passenger.After(now),
+				LapsesAt:     passenger,
+				Requirements: []dto.CurrencyRequirement{passengerRequirement},
+			},
+			NightPassenger: dto.CurrencyStatus{
+				Current:      nightPassenger != nil && nightPassenger.After(now),
+				LapsesAt:     nightPassenger,
+				Requirements: []dto.CurrencyRequirement{nightPassengerRequirement},
+			},
+			Instrument: dto.CurrencyStatus{
+				Current:      instrumentLapsesAt != nil && instrumentLapsesAt.After(now),
+				LapsesAt:     instrumentLapsesAt,
+				Requirements: []dto.CurrencyRequirement{approachesRequirement, holdingsRequirement},
+			},
+		})
+	}
+
+	return currencyResponses, nil
+}
+
+// evaluateCurrency returns the time when currency based on the most recent events lapses (nil if the required number
+// of events was never logged) and the requirement summary for the window starting at windowStart.
+func evaluateCurrency(events []currencyEvent, name string, required uint, windowStart, now time.Time,
+	lapse func(time.Time) time.Time) (*time.Time, dto.CurrencyRequirement) {
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].time.After(events[j].time)
+	})
+
+	var lapsesAt *time.Time
+	var cumulative uint
+	for _, event := range events {
+		if event.count == 0 {
+			continue
+		}
+		cumulative += event.count
+		if cumulative >= required {
+			lapseTime := lapse(event.time)
+			lapsesAt = &lapseTime
+			break
+		}
+	}
+
+	var logged uint
+	for _, event := range events {
+		if !event.time.Before(windowStart) && !event.time.After(now) {
+			logged += event.count
+		}
+	}
+
+	requirement := dto.CurrencyRequirement{
+		Name:     name,
+		Required: required,
+		Logged:   logged,
+	}
+	if logged < required {
+		requirement.Missing = required - logged
+	}
+
+	return lapsesAt, requirement
+}
+
+func landingCount(landing model.Landing) uint {
+	if landing.Count != nil {
+		return *landing.Count
+	}
+
+	var count uint
+	if landing.DayCount != nil {
+		count += *landing.DayCount
+	}
+	if landing.NightCount != nil {
+		count += *landing.NightCount
+	}
+	return count
+}
+
+func categoryOrder(category model.AircraftCategory) int {
+	index := slices.Index(model.AvailableAircraftCategories, category)
+	if index == -1 {
+		return len(model.AvailableAircraftCategories)
+	}
+	return index
+}
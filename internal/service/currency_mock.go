@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: currency.go
+//
+// Generated by this command:
+//
+//	mockgen -source=currency.go -destination=currency_mock.go -package service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	reflect "reflect"
+
+	dto "github.com/avialog/backend/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCurrencyService is a mock of CurrencyService interface.
+type MockCurrencyService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCurrencyServiceMockRecorder
+}
+
+// MockCurrencyServiceMockRecorder is the mock recorder for MockCurrencyService.
+type MockCurrencyServiceMockRecorder struct {
+	mock *MockCurrencyService
+}
+
+// NewMockCurrencyService creates a new mock instance.
+func NewMockCurrencyService(ctrl *gomock.Controller) *MockCurrencyService {
+	mock := &MockCurrencyService{ctrl: ctrl}
+	mock.recorder = &MockCurrencyServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCurrencyService) EXPECT() *MockCurrencyServiceMockRecorder {
+	return m.recorder
+}
+
+// GetCurrency mocks base method.
+func (m *MockCurrencyService) GetCurrency(userID string) ([]dto.CurrencyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrency", userID)
+	ret0, _ := ret[0].([]dto.CurrencyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrency indicates an expected call of GetCurrency.
+func (mr *MockCurrencyServiceMockRecorder) GetCurrency(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrency", reflect.TypeOf((*MockCurrencyService)(nil).GetCurrency), userID)
+}
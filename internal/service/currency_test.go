@@ -0,0 +1,193 @@
+package service
+
+import (
+	"errors"
+	"github.com/avialog/backend/internal/config"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	"github.com/avialog/backend/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"time"
+)
+
+var _ = Describe("CurrencyService", func() {
+	var (
+		currencyService  CurrencyService
+		flightRepoCtrl   *gomock.Controller
+		flightRepoMock   *repository.MockFlightRepository
+		landingRepoCtrl  *gomock.Controller
+		landingRepoMock  *repository.MockLandingRepository
+		aircraftRepoCtrl *gomock.Controller
+		aircraftRepoMock *repository.MockAircraftRepository
+		fixedTime        time.Time
+		windowStart      time.Time
+		mockAircraft     []model.Aircraft
+		mockFlights      []model.Flight
+		mockLandings     []model.Landing
+	)
+
+	BeforeEach(func() {
+		fixedTime = time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+		windowStart = time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+		flightRepoCtrl = gomock.NewController(GinkgoT())
+		flightRepoMock = repository.NewMockFlightRepository(flightRepoCtrl)
+		landingRepoCtrl = gomock.NewController(GinkgoT())
+		landingRepoMock = repository.NewMockLandingRepository(landingRepoCtrl)
+		aircraftRepoCtrl = gomock.NewController(GinkgoT())
+		aircraftRepoMock = repository.NewMockAircraftRepository(aircraftRepoCtrl)
+		currencyService = newCurrencyService(flightRepoMock, landingRepoMock, aircraftRepoMock, config.Config{},
+			func() time.Time { return fixedTime })
+
+		airplane := model.AircraftCategoryAirplane
+		mockAircraft = []model.Aircraft{
+			{Model: gorm.Model{ID: 1}, UserID: "1", RegistrationNumber: "SP-ABC", AircraftModel: "Cessna 172", Category: &airplane},
+			{Model: gorm.Model{ID: 2}, UserID: "1", RegistrationNumber: "SP-DEF", AircraftModel: "Unknown"},
+		}
+		mockFlights = []model.Flight{
+			{
+				Model:       gorm.Model{ID: 1},
+				UserID:      "1",
+				AircraftID:  1,
+				TakeoffTime: time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC),
+				LandingTime: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+			},
+			{
+				Model:       gorm.Model{ID: 2},
+				UserID:      "1",
+				AircraftID:  1,
+				TakeoffTime: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+				LandingTime: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+				Holdings:    util.Uint(1),
+			},
+			{
+				Model:       gorm.Model{ID: 3},
+				UserID:      "1",
+				AircraftID:  2,
+				TakeoffTime: time.Date(2024, 1, 10, 10, 0, 0, 0, time.UTC),
+				LandingTime: time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC),
+			},
+		}
+		mockLandings = []model.Landing{
+			{FlightID: 1, ApproachType: model.ApproachTypeVisual, Count: util.Uint(2), NightCount: util.Uint(1), DayCount: util.Uint(1)},
+			{FlightID: 2, ApproachType: model.ApproachTypeILS, Count: util.Uint(6)},
+			{FlightID: 3, ApproachType: model.ApproachTypeVisual, DayCount: util.Uint(3)},
+		}
+	})
+
+	AfterEach(func() {
+		flightRepoCtrl.Finish()
+		landingRepoCtrl.Finish()
+		aircraftRepoCtrl.Finish()
+	})
+
+	Describe("GetCurrency", func() {
+		Context("when user has flights in different aircraft categories", func() {
+			It("should evaluate currency per category", func() {
+				// given
+				aircraftRepoMock.EXPECT().GetByUserID("1").Return(mockAircraft, nil)
+				flightRepoMock.EXPECT().GetByUserIDAndDate("1", windowStart, fixedTime).Return(mockFlights, nil)
+				landingRepoMock.EXPECT().GetByFlightIDs([]uint{1, 2, 3}).Return(mockLandings, nil)
+
+				// when
+				currency, err := currencyService.GetCurrency("1")
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(currency).To(HaveLen(2))
+
+				airplane := currency[0]
+				Expect(*airplane.Category).To(Equal(model.AircraftCategoryAirplane))
+				Expect(airplane.Passenger.Current).To(BeTrue())
+				Expect(*airplane.Passenger.LapsesAt).To(Equal(time.Date(2024, 7, 30, 12, 0, 0, 0, time.UTC)))
+				Expect(airplane.Passenger.Requirements).To(Equal([]dto.CurrencyRequirement{
+					{Name: "takeoffs_and_landings", Required: 3, Logged: 8, Missing: 0},
+				}))
+				Expect(airplane.NightPassenger.Current).To(BeFalse())
+				Expect(airplane.NightPassenger.LapsesAt).To(BeNil())
+				Expect(airplane.NightPassenger.Requirements).To(Equal([]dto.CurrencyRequirement{
+					{Name: "night_takeoffs_and_landings", Required: 3, Logged: 1, Missing: 2},
+				}))
+				Expect(airplane.Instrument.Current).To(BeTrue())
+				Expect(*airplane.Instrument.LapsesAt).To(Equal(time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC)))
+				Expect(airplane.Instrument.Requirements).To(Equal([]dto.CurrencyRequirement{
+					{Name: "instrument_approaches", Required: 6, Logged: 6, Missing: 0},
+					{Name: "holding_procedures", Required: 1, Logged: 1, Missing: 0},
+				}))
+
+				unspecified := currency[1]
+				Expect(unspecified.Category).To(BeNil())
+				Expect(unspecified.Passenger.Current).To(BeFalse())
+				Expect(*unspecified.Passenger.LapsesAt).To(Equal(time.Date(2024, 4, 9, 12, 0, 0, 0, time.UTC)))
+				Expect(unspecified.Passenger.Requirements).To(Equal([]dto.CurrencyRequirement{
+					{Name: "takeoffs_and_landings", Required: 3, Logged: 0, Missing: 3},
+				}))
+				Expect(unspecified.Instrument.Current).To(BeFalse())
+				Expect(unspecified.Instrument.LapsesAt).To(BeNil())
+			})
+		})
+		Context("when user has no aircraft", func() {
+			It("should return empty array", func() {
+				// given
+				aircraftRepoMock.EXPECT().GetByUserID("1").Return([]model.Aircraft{}, nil)
+				flightRepoMock.EXPECT().GetByUserIDAndDate("1", windowStart, fixedTime).Return([]model.Flight{}, nil)
+				landingRepoMock.EXPECT().GetByFlightIDs([]uint{}).Return([]model.Landing{}, nil)
+
+				// when
+				currency, err := currencyService.GetCurrency("1")
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(currency).To(Equal([]dto.CurrencyResponse{}))
+			})
+		})
+		Context("when getting aircraft failed", func() {
+			It("should return an error", func() {
+				// given
+				aircraftRepoMock.EXPECT().GetByUserID("1").Return(nil, errors.New("failed to fetch aircraft"))
+
+				// when
+				currency, err := currencyService.GetCurrency("1")
+
+				// then
+				Expect(err).ToNot(BeNil())
+				Expect(currency).To(BeNil())
+				Expect(err.Error()).To(Equal("failed to fetch aircraft"))
+			})
+		})
+		Context("when getting flights failed", func() {
+			It("should return an error", func() {
+				// given
+				aircraftRepoMock.EXPECT().GetByUserID("1").Return(mockAircraft, nil)
+				flightRepoMock.EXPECT().GetByUserIDAndDate("1", windowStart, fixedTime).Return(nil, errors.New("failed to fetch flights"))
+
+				// when
+				currency, err := currencyService.GetCurrency("1")
+
+				// then
+				Expect(err).ToNot(BeNil())
+				Expect(currency).To(BeNil())
+				Expect(err.Error()).To(Equal("failed to fetch flights"))
+			})
+		})
+		Context("when getting landings failed", func() {
+			It("should return an error", func() {
+				// given
+				aircraftRepoMock.EXPECT().GetByUserID("1").Return(mockAircraft, nil)
+				flightRepoMock.EXPECT().GetByUserIDAndDate("1", windowStart, fixedTime).Return(mockFlights, nil)
+				landingRepoMock.EXPECT().GetByFlightIDs([]uint{1, 2, 3}).Return(nil, errors.New("failed to fetch landings"))
+
+				// when
+				currency, err := currencyService.GetCurrency("1")
+
+				// then
+				Expect(err).ToNot(BeNil())
+				Expect(currency).To(BeNil())
+				Expect(err.Error()).To(Equal("failed to fetch landings"))
+			})
+		})
+	})
+})
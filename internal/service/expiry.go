@@ -0,0 +1,65 @@
+package service
+
+import (
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/go-playground/validator/v10"
+	"math"
+	"sort"
+	"time"
+)
+
+// validateQualification checks the tags of a licence or rating and that it does not expire before it was issued.
+func validateQualification(validate *validator.Validate, value interface{}, issuedAt, expiresAt *time.Time) error {
+	validationError := &dto.ValidationError{}
+	if err := addFieldErrors(validate, validationError, "", value); err != nil {
+		return err
+	}
+
+	if issuedAt != nil && expiresAt != nil && expiresAt.Before(*issuedAt) {
+		validationError.Add("expires_at", dto.CodeBeforeIssueDate)
+	}
+
+	if len(validationError.Errors) > 0 {
+		return fmt.Errorf("%w: %w", dto.ErrBadRequest, validationError)
+	}
+	return nil
+}
+
+func newExpiryItem(itemType dto.ExpiryItemType, id uint, name string, expiresAt *time.Time, now time.Time) dto.ExpiryItem {
+	item := dto.ExpiryItem{Type: itemType, ID: id, Name: name, ExpiresAt: expiresAt}
+	if expiresAt != nil {
+		daysLeft := int(math.Floor(expiresAt.Sub(now).Hours() / 24))
+		item.DaysLeft = &daysLeft
+	}
+	return item
+}
+
+// summarizeExpiry splits the items into valid ones, ones expiring within the warning period and lapsed ones.
+func summarizeExpiry(items []dto.ExpiryItem, now time.Time, warning time.Duration) dto.ExpirySummaryResponse {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].ExpiresAt == nil || items[j].ExpiresAt == nil {
+			return items[j].ExpiresAt == nil && items[i].ExpiresAt != nil
+		}
+		return items[i].ExpiresAt.Before(*items[j].ExpiresAt)
+	})
+
+	summary := dto.ExpirySummaryResponse{
+		Valid:        []dto.ExpiryItem{},
+		ExpiringSoon: []dto.ExpiryItem{},
+		Lapsed:       []dto.ExpiryItem{},
+	}
+	for _, item := range items {
+		switch {
+		case item.ExpiresAt == nil:
+			summary.Valid = append(summary.Valid, item)
+		case now.After(*item.ExpiresAt):
+			summary.Lapsed = append(summary.Lapsed, item)
+		case item.ExpiresAt.Sub(now) <= warning:
+			summary.ExpiringSoon = append(summary.ExpiringSoon, item)
+		default:
+			summary.Valid = append(summary.Valid, item)
+		}
+	}
+	return summary
+}
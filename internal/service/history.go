@@ -0,0 +1,357 @@
+package service
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/infrastructure"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// genesisHash is the previous hash of the first version of a logbook.
+var genesisHash = strings.Repeat("0", 2*sha256.Size)
+
+//go:generate mockgen -source=history.go -destination=history_mock.go -package service
+type HistoryService interface {
+	GetLogbookEntryHistory(userID string, flightID uint) ([]dto.FlightVersionResponse, error)
+	VerifyLogbook(userID string) (dto.LogbookVerificationResponse, error)
+}
+
+type historyService struct {
+	flightVersionRepository repository.FlightVersionRepository
+	flightRepository        repository.FlightRepository
+	landingRepository       repository.LandingRepository
+	passengerRepository     repository.PassengerRepository
+}
+
+func newHistoryService(flightVersionRepository repository.FlightVersionRepository, flightRepository repository.FlightRepository,
+	landingRepository repository.LandingRepository, passengerRepository repository.PassengerRepository) HistoryService {
+	return &historyService{flightVersionRepository: flightVersionRepository, flightRepository: flightRepository,
+		landingRepository: landingRepository, passengerRepository: passengerRepository}
+}
+
+// GetLogbookEntryHistory returns the versions of the entry from the oldest one, each with the fields changed since the
+// previous version. The history of a deleted entry is kept.
+func (h *historyService) GetLogbookEntryHistory(userID string, flightID uint) ([]dto.FlightVersionResponse, error) {
+	versions, err := h.flightVersionRepository.GetByUserIDAndFlightID(userID, flightID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(versions) == 0 {
+		// the entry may have been stored before the history was recorded
+		flight, err := h.flightRepository.GetByID(flightID)
+		if err != nil {
+			if errors.Is(err, dto.ErrNotFound) {
+				return nil, fmt.Errorf("%w: %v", dto.ErrNotFound, "flight not found")
+			}
+			return nil, err
+		}
+
+		if flight.UserID != userID {
+			return nil, fmt.Errorf("%w: %v", dto.ErrBadRequest, "flight does not belong to user")
+		}
+		return []dto.FlightVersionResponse{}, nil
+	}
+
+	versionResponses := make([]dto.FlightVersionResponse, 0, len(versions))
+	previousSnapshot := ""
+	for _, version := range versions {
+		var snapshot dto.LogbookSnapshot
+		if err := json.Unmarshal([]byte(version.Snapshot), &snapshot); err != nil {
+			return nil, fmt.Errorf("%w: invalid snapshot of version %d: %v", dto.ErrInternalFailure, version.Sequence, err)
+		}
+
+		changes := make([]dto.FieldChange, 0)
+		if previousSnapshot != "" {
+			changes, err = diffSnapshots(previousSnapshot, version.Snapshot)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+			}
+		}
+
+		versionResponses = append(versionResponses, dto.FlightVersionResponse{
+			Sequence:     version.Sequence,
+			FlightID:     version.FlightID,
+			Action:       version.Action,
+			RecordedAt:   version.RecordedAt,
+			PreviousHash: version.PreviousHash,
+			Hash:         version.Hash,
+			Snapshot:     snapshot,
+			Changes:      changes,
+		})
+		previousSnapshot = version.Snapshot
+	}
+
+	return versionResponses, nil
+}
+
+// VerifyLogbook recomputes the hash chain of the user and compares the entries of the logbook with their latest
+// versions. Removing the latest versions doesn't break the chain, the returned head can be kept to detect it.
+func (h *historyService) VerifyLogbook(userID string) (dto.LogbookVerificationResponse, error) {
+	versions, err := h.flightVersionRepository.GetByUserID(userID)
+	if err != nil {
+		return dto.LogbookVerificationResponse{}, err
+	}
+
+	response := dto.LogbookVerificationResponse{
+		Versions:           len(versions),
+		Problems:           make([]dto.VerificationProblem, 0),
+		UntrackedFlightIDs: make([]uint, 0),
+	}
+	addProblem := func(version *model.FlightVersion, flightID uint, reason string) {
+		var sequence *uint
+		if version != nil {
+			sequence = &version.Sequence
+		}
+		response.Problems = append(response.Problems, dto.VerificationProblem{Sequence: sequence, FlightID: flightID, Reason: reason})
+	}
+
+	previousHash := genesisHash
+	previousSequence := uint(0)
+	latestByFlightID := make(map[uint]*model.FlightVersion)
+	versionedFlightIDs := make([]uint, 0)
+	for i := range versions {
+		version := &versions[i]
+		if version.Sequence != previousSequence+1 {
+			addProblem(version, version.FlightID, "versions before this version are missing")
+		} else if version.PreviousHash != previousHash {
+			addProblem(version, version.FlightID, "version does not follow the previous version")
+		}
+		if hashFlightVersion(*version) != version.Hash {
+			addProblem(version, version.FlightID, "version was modified after it was recorded")
+		}
+
+		if _, ok := latestByFlightID[version.FlightID]; !ok {
+			versionedFlightIDs = append(versionedFlightIDs, version.FlightID)
+		}
+		latestByFlightID[version.FlightID] = version
+		previousHash = version.Hash
+		previousSequence = version.Sequence
+	}
+	if len(versions) > 0 {
+		response.Head = &versions[len(versions)-1].Hash
+	}
+
+	flights, err := h.flightRepository.GetByUserIDOrderedByTakeoffTime(userID, nil, nil)
+	if err != nil {
+		return dto.LogbookVerificationResponse{}, err
+	}
+
+	flightIDs := make([]uint, 0, len(flights))
+	for _, flight := range flights {
+		flightIDs = append(flightIDs, flight.ID)
+	}
+
+	landings, err := h.landingRepository.GetByFlightIDs(flightIDs)
+	if err != nil {
+		return dto.LogbookVerificationResponse{}, err
+	}
+
+	passengers, err := h.passengerRepository.GetByFlightIDs(flightIDs)
+	if err != nil {
+		return dto.LogbookVerificationResponse{}, err
+	}
+
+	landingsByFlightID := make(map[uint][]model.Landing)
+	for _, landing := range landings {
+		landingsByFlightID[landing.FlightID] = append(landingsByFlightID[landing.FlightID], landing)
+	}
+	passengersByFlightID := make(map[uint][]model.Passenger)
+	for _, passenger := range passengers {
+		passengersByFlightID[passenger.FlightID] = append(passengersByFlightID[passenger.FlightID], passenger)
+	}
+
+	storedFlightIDs := make(map[uint]bool, len(flights))
+	for _, flight := range flights {
+		storedFlightIDs[flight.ID] = true
+
+		latest, ok := latestByFlightID[flight.ID]
+		if !ok {
+			response.UntrackedFlightIDs = append(response.UntrackedFlightIDs, flight.ID)
+			continue
+		}
+
+		if latest.Action == model.FlightVersionActionDeleted {
+			addProblem(latest, flight.ID, "entry is in the logbook although it was deleted")
+			continue
+		}
+
+		snapshot, err := encodeSnapshot(newLogbookSnapshot(flight, passengersByFlightID[flight.ID], landingsByFlightID[flight.ID]))
+		if err != nil {
+			return dto.LogbookVerificationResponse{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+		}
+		if snapshot != latest.Snapshot {
+			addProblem(latest, flight.ID, "entry differs from its latest version")
+		}
+	}
+
+	for _, flightID := range versionedFlightIDs {
+		latest := latestByFlightID[flightID]
+		if !storedFlightIDs[flightID] && latest.Action != model.FlightVersionActionDeleted {
+			addProblem(latest, flightID, "entry was removed without a recorded version")
+		}
+	}
+
+	slices.Sort(response.UntrackedFlightIDs)
+	response.Valid = len(response.Problems) == 0
+	return response, nil
+}
+
+// flightChange is a change of a flight to record in the history of the logbook.
+type flightChange struct {
+	flightID uint
+	action   model.FlightVersionAction
+	snapshot dto.LogbookSnapshot
+}
+
+// recordFlightVersions appends the changes to the hash chain of the user within the transaction of the changes. The
+// transaction has to lock the user with LockUserTx first.
+func recordFlightVersions(flightVersionRepository repository.FlightVersionRepository, tx infrastructure.Database,
+	userID string, recordedAt time.Time, changes ...flightChange) error {
+	head, err := flightVersionRepository.GetHeadTx(tx, userID)
+	if err != nil && !errors.Is(err, dto.ErrNotFound) {
+		return err
+	}
+
+	previousHash := genesisHash
+	sequence := uint(0)
+	if err == nil {
+		previousHash = head.Hash
+		sequence = head.Sequence
+	}
+
+	// the database keeps microseconds, the hash has to match the time read back
+	recordedAt = recordedAt.UTC().Round(time.Microsecond)
+	for _, change := range changes {
+		snapshot, err := encodeSnapshot(change.snapshot)
+		if err != nil {
+			return fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+		}
+
+		sequence++
+		version := model.FlightVersion{
+			UserID:       userID,
+			Sequence:     sequence,
+			FlightID:     change.flightID,
+			Action:       change.action,
+			Snapshot:     snapshot,
+			RecordedAt:   recordedAt,
+			PreviousHash: previousHash,
+		}
+		version.Hash = hashFlightVersion(version)
+
+		if _, err := flightVersionRepository.CreateTx(tx, version); err != nil {
+			return err
+		}
+		previousHash = version.Hash
+	}
+
+	return nil
+}
+
+// hashFlightVersion returns the hex encoded SHA-256 hash of the version, covering the hash of the previous version.
+func hashFlightVersion(version model.FlightVersion) string {
+	hash := sha256.New()
+	_, _ = fmt.Fprintf(hash, "%s\n%s\n%d\n%d\n%s\n%d\n%s", version.PreviousHash, version.UserID, version.Sequence,
+		version.FlightID, version.Action, version.RecordedAt.UnixMicro(), version.Snapshot)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func newLogbookSnapshot(flight model.Flight, passengers []model.Passenger, landings []model.Landing) dto.LogbookSnapshot {
+	snapshot := dto.LogbookSnapshot{
+		AircraftID:          flight.AircraftID,
+		TakeoffTime:         flight.TakeoffTime.UTC().Round(time.Microsecond),
+		TakeoffAirportCode:  flight.TakeoffAirportCode,
+		LandingTime:         flight.LandingTime.UTC().Round(time.Microsecond),
+		LandingAirportCode:  flight.LandingAirportCode,
+		Style:               flight.Style,
+		MyRole:              flight.MyRole,
+		Remarks:             flight.Remarks,
+		PersonalRemarks:     flight.PersonalRemarks,
+		TotalBlockTime:      flight.TotalBlockTime,
+		PilotInCommandTime:  flight.PilotInCommandTime,
+		SecondInCommandTime: flight.SecondInCommandTime,
+		DualReceivedTime:    flight.DualReceivedTime,
+		DualGivenTime:       flight.DualGivenTime,
+		MultiPilotTime:      flight.MultiPilotTime,
+		NightTime:           flight.NightTime,
+		IFRTime:             flight.IFRTime,
+		IFRActualTime:       flight.IFRActualTime,
+		IFRSimulatedTime:    flight.IFRSimulatedTime,
+		CrossCountryTime:    flight.CrossCountryTime,
+		SimulatorTime:       flight.SimulatorTime,
+		Holdings:            flight.Holdings,
+		SignatureURL:        flight.SignatureURL,
+		Passengers:          make([]dto.PassengerEntry, 0, len(passengers)),
+		Landings:            make([]dto.LandingEntry, 0, len(landings)),
+	}
+	for _, passenger := range passengers {
+		snapshot.Passengers = append(snapshot.Passengers, dto.PassengerEntry{
+			Role:         passenger.Role,
+			FirstName:    passenger.FirstName,
+			LastName:     passenger.LastName,
+			Company:      passenger.Company,
+			Phone:        passenger.Phone,
+			EmailAddress: passenger.EmailAddress,
+			Note:         passenger.Note,
+		})
+	}
+	for _, landing := range landings {
+		snapshot.Landings = append(snapshot.Landings, dto.LandingEntry{
+			ApproachType: landing.ApproachType,
+			Count:        landing.Count,
+			NightCount:   landing.NightCount,
+			DayCount:     landing.DayCount,
+			AirportCode:  landing.AirportCode,
+		})
+	}
+	return snapshot
+}
+
+func encodeSnapshot(snapshot dto.LogbookSnapshot) (string, error) {
+	encoded, err := json.Marshal(snapshot)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// diffSnapshots returns the fields which differ between two encoded snapshots, sorted by name.
+func diffSnapshots(previous, current string) ([]dto.FieldChange, error) {
+	var previousFields, currentFields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(previous), &previousFields); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(current), &currentFields); err != nil {
+		return nil, err
+	}
+
+	fields := make([]string, 0, len(currentFields))
+	for field := range currentFields {
+		fields = append(fields, field)
+	}
+	for field := range previousFields {
+		if _, ok := currentFields[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+
+	changes := make([]dto.FieldChange, 0)
+	for _, field := range fields {
+		if !bytes.Equal(previousFields[field], currentFields[field]) {
+			changes = append(changes, dto.FieldChange{Field: field, Old: previousFields[field], New: currentFields[field]})
+		}
+	}
+	return changes, nil
+}
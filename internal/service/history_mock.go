@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: history.go
+//
+// Generated by this command:
+//
+//	mockgen -source=history.go -destination=history_mock.go -package service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	reflect "reflect"
+
+	dto "github.com/avialog/backend/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHistoryService is a mock of HistoryService interface.
+type MockHistoryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryServiceMockRecorder
+}
+
+// MockHistoryServiceMockRecorder is the mock recorder for MockHistoryService.
+type MockHistoryServiceMockRecorder struct {
+	mock *MockHistoryService
+}
+
+// NewMockHistoryService creates a new mock instance.
+func NewMockHistoryService(ctrl *gomock.Controller) *MockHistoryService {
+	mock := &MockHistoryService{ctrl: ctrl}
+	mock.recorder = &MockHistoryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistoryService) EXPECT() *MockHistoryServiceMockRecorder {
+	return m.recorder
+}
+
+// GetLogbookEntryHistory mocks base method.
+func (m *MockHistoryService) GetLogbookEntryHistory(userID string, flightID uint) ([]dto.FlightVersionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLogbookEntryHistory", userID, flightID)
+	ret0, _ := ret[0].([]dto.FlightVersionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLogbookEntryHistory indicates an expected call of GetLogbookEntryHistory.
+func (mr *MockHistoryServiceMockRecorder) GetLogbookEntryHistory(userID, flightID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogbookEntryHistory", reflect.TypeOf((*MockHistoryService)(nil).GetLogbookEntryHistory), userID, flightID)
+}
+
+// VerifyLogbook mocks base method.
+func (m *MockHistoryService) VerifyLogbook(userID string) (dto.LogbookVerificationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyLogbook", userID)
+	ret0, _ := ret[0].(dto.LogbookVerificationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyLogbook indicates an expected call of VerifyLogbook.
+func (mr *MockHistoryServiceMockRecorder) VerifyLogbook(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyLogbook", reflect.TypeOf((*MockHistoryService)(nil).VerifyLogbook), userID)
+}
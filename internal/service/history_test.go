@@ -0,0 +1,323 @@
+package service
+
+import (
+	"encoding/json"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/infrastructure"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	"github.com/avialog/backend/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"time"
+)
+
+var _ = Describe("HistoryService", func() {
+	var (
+		historyService        HistoryService
+		flightVersionRepoCtrl *gomock.Controller
+		flightVersionRepoMock *repository.MockFlightVersionRepository
+		flightRepoCtrl        *gomock.Controller
+		flightRepoMock        *repository.MockFlightRepository
+		landingRepoCtrl       *gomock.Controller
+		landingRepoMock       *repository.MockLandingRepository
+		passengerRepoCtrl     *gomock.Controller
+		passengerRepoMock     *repository.MockPassengerRepository
+		recordedAt            time.Time
+		mockFlight            model.Flight
+		mockUpdatedFlight     model.Flight
+		mockLandings          []model.Landing
+		mockPassengers        []model.Passenger
+		mockVersions          []model.FlightVersion
+	)
+
+	// chain links the versions the way recordFlightVersions does
+	chain := func(versions ...model.FlightVersion) []model.FlightVersion {
+		previousHash := genesisHash
+		for i := range versions {
+			versions[i].Sequence = uint(i + 1)
+			versions[i].PreviousHash = previousHash
+			versions[i].Hash = hashFlightVersion(versions[i])
+			previousHash = versions[i].Hash
+		}
+		return versions
+	}
+
+	snapshotOf := func(flight model.Flight, passengers []model.Passenger, landings []model.Landing) string {
+		snapshot, err := encodeSnapshot(newLogbookSnapshot(flight, passengers, landings))
+		Expect(err).To(BeNil())
+		return snapshot
+	}
+
+	BeforeEach(func() {
+		flightVersionRepoCtrl = gomock.NewController(GinkgoT())
+		flightVersionRepoMock = repository.NewMockFlightVersionRepository(flightVersionRepoCtrl)
+		flightRepoCtrl = gomock.NewController(GinkgoT())
+		flightRepoMock = repository.NewMockFlightRepository(flightRepoCtrl)
+		landingRepoCtrl = gomock.NewController(GinkgoT())
+		landingRepoMock = repository.NewMockLandingRepository(landingRepoCtrl)
+		passengerRepoCtrl = gomock.NewController(GinkgoT())
+		passengerRepoMock = repository.NewMockPassengerRepository(passengerRepoCtrl)
+		historyService = newHistoryService(flightVersionRepoMock, flightRepoMock, landingRepoMock, passengerRepoMock)
+
+		recordedAt = time.Date(2024, 3, 26, 9, 30, 0, 0, time.UTC)
+		takeoffTime := time.Date(2024, 3, 25, 10, 0, 0, 0, time.UTC)
+		mockFlight = model.Flight{
+			Model:              gorm.Model{ID: 3},
+			UserID:             "1",
+			AircraftID:         1,
+			TakeoffTime:        takeoffTime,
+			TakeoffAirportCode: "EPKK",
+			LandingTime:        takeoffTime.Add(time.Hour),
+			LandingAirportCode: "EPWA",
+			Style:              model.StyleY,
+			MyRole:             model.RolePilotInCommand,
+			TotalBlockTime:     util.Duration(time.Hour),
+		}
+		mockUpdatedFlight = mockFlight
+		mockUpdatedFlight.LandingAirportCode = "EPKT"
+		mockUpdatedFlight.Remarks = util.String("Diverted")
+		mockLandings = []model.Landing{{Model: gorm.Model{ID: 11}, FlightID: 3, ApproachType: model.ApproachTypeVisual, Count: util.Uint(1)}}
+		mockPassengers = []model.Passenger{{Model: gorm.Model{ID: 21}, FlightID: 3, Role: model.RoleSecondInCommand, FirstName: "John"}}
+		mockVersions = chain(
+			model.FlightVersion{UserID: "1", FlightID: 3, Action: model.FlightVersionActionCreated, RecordedAt: recordedAt,
+				Snapshot: snapshotOf(mockFlight, mockPassengers, mockLandings)},
+			model.FlightVersion{UserID: "1", FlightID: 3, Action: model.FlightVersionActionUpdated, RecordedAt: recordedAt.Add(time.Hour),
+				Snapshot: snapshotOf(mockUpdatedFlight, mockPassengers, mockLandings)},
+		)
+	})
+
+	AfterEach(func() {
+		flightVersionRepoCtrl.Finish()
+		flightRepoCtrl.Finish()
+		landingRepoCtrl.Finish()
+		passengerRepoCtrl.Finish()
+	})
+
+	Describe("GetLogbookEntryHistory", func() {
+		Context("when the entry has versions", func() {
+			It("Should return the versions with the changed fields", func() {
+				// given
+				flightVersionRepoMock.EXPECT().GetByUserIDAndFlightID("1", uint(3)).Return(mockVersions, nil)
+
+				// when
+				versionResponses, err := historyService.GetLogbookEntryHistory("1", uint(3))
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(versionResponses).To(HaveLen(2))
+				Expect(versionResponses[0].Sequence).To(Equal(uint(1)))
+				Expect(versionResponses[0].Action).To(Equal(model.FlightVersionActionCreated))
+				Expect(versionResponses[0].PreviousHash).To(Equal(genesisHash))
+				Expect(versionResponses[0].Snapshot).To(Equal(newLogbookSnapshot(mockFlight, mockPassengers, mockLandings)))
+				Expect(versionResponses[0].Changes).To(BeEmpty())
+				Expect(versionResponses[1].PreviousHash).To(Equal(versionResponses[0].Hash))
+				Expect(versionResponses[1].Changes).To(Equal([]dto.FieldChange{
+					{Field: "landing_airport_code", Old: json.RawMessage(`"EPWA"`), New: json.RawMessage(`"EPKT"`)},
+					{Field: "remarks", Old: json.RawMessage(`null`), New: json.RawMessage(`"Diverted"`)},
+				}))
+			})
+		})
+		Context("when the entry was stored before the history was recorded", func() {
+			It("Should return an empty history", func() {
+				// given
+				flightVersionRepoMock.EXPECT().GetByUserIDAndFlightID("1", uint(3)).Return([]model.FlightVersion{}, nil)
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlight, nil)
+
+				// when
+				versionResponses, err := historyService.GetLogbookEntryHistory("1", uint(3))
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(versionResponses).To(BeEmpty())
+			})
+		})
+		Context("when the entry belongs to another user", func() {
+			It("Should return a bad request", func() {
+				// given
+				flightVersionRepoMock.EXPECT().GetByUserIDAndFlightID("2", uint(3)).Return([]model.FlightVersion{}, nil)
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlight, nil)
+
+				// when
+				versionResponses, err := historyService.GetLogbookEntryHistory("2", uint(3))
+
+				// then
+				Expect(err).To(MatchError("bad request: flight does not belong to user"))
+				Expect(versionResponses).To(BeNil())
+			})
+		})
+		Context("when the entry does not exist", func() {
+			It("Should return not found", func() {
+				// given
+				flightVersionRepoMock.EXPECT().GetByUserIDAndFlightID("1", uint(3)).Return([]model.FlightVersion{}, nil)
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(model.Flight{}, dto.ErrNotFound)
+
+				// when
+				_, err := historyService.GetLogbookEntryHistory("1", uint(3))
+
+				// then
+				Expect(err).To(MatchError("not found: flight not found"))
+			})
+		})
+	})
+
+	Describe("VerifyLogbook", func() {
+		var expectLogbook = func(flights []model.Flight, flightIDs []uint) {
+			flightRepoMock.EXPECT().GetByUserIDOrderedByTakeoffTime("1", nil, nil).Return(flights, nil)
+			landingRepoMock.EXPECT().GetByFlightIDs(flightIDs).Return(mockLandings, nil)
+			passengerRepoMock.EXPECT().GetByFlightIDs(flightIDs).Return(mockPassengers, nil)
+		}
+
+		Context("when the history and the logbook are intact", func() {
+			It("Should report a valid logbook with the head of the chain", func() {
+				// given
+				untracked := model.Flight{Model: gorm.Model{ID: 2}, UserID: "1"}
+				flightVersionRepoMock.EXPECT().GetByUserID("1").Return(mockVersions, nil)
+				expectLogbook([]model.Flight{untracked, mockUpdatedFlight}, []uint{2, 3})
+
+				// when
+				verificationResponse, err := historyService.VerifyLogbook("1")
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(verificationResponse).To(Equal(dto.LogbookVerificationResponse{
+					Valid:              true,
+					Versions:           2,
+					Head:               &mockVersions[1].Hash,
+					Problems:           []dto.VerificationProblem{},
+					UntrackedFlightIDs: []uint{2},
+				}))
+			})
+		})
+		Context("when a version was modified", func() {
+			It("Should report the modified version", func() {
+				// given
+				mockVersions[0].Snapshot = snapshotOf(mockUpdatedFlight, mockPassengers, mockLandings)
+				flightVersionRepoMock.EXPECT().GetByUserID("1").Return(mockVersions, nil)
+				expectLogbook([]model.Flight{mockUpdatedFlight}, []uint{3})
+
+				// when
+				verificationResponse, err := historyService.VerifyLogbook("1")
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(verificationResponse.Valid).To(BeFalse())
+				Expect(verificationResponse.Problems).To(Equal([]dto.VerificationProblem{
+					{Sequence: util.Uint(1), FlightID: 3, Reason: "version was modified after it was recorded"},
+				}))
+			})
+		})
+		Context("when a version was removed", func() {
+			It("Should report the gap in the chain", func() {
+				// given
+				flightVersionRepoMock.EXPECT().GetByUserID("1").Return(mockVersions[1:], nil)
+				expectLogbook([]model.Flight{mockUpdatedFlight}, []uint{3})
+
+				// when
+				verificationResponse, err := historyService.VerifyLogbook("1")
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(verificationResponse.Valid).To(BeFalse())
+				Expect(verificationResponse.Problems).To(Equal([]dto.VerificationProblem{
+					{Sequence: util.Uint(2), FlightID: 3, Reason: "versions before this version are missing"},
+				}))
+			})
+		})
+		Context("when the entry was changed without a recorded version", func() {
+			It("Should report the changed entry", func() {
+				// given
+				tampered := mockUpdatedFlight
+				tampered.TotalBlockTime = util.Duration(2 * time.Hour)
+				flightVersionRepoMock.EXPECT().GetByUserID("1").Return(mockVersions, nil)
+				expectLogbook([]model.Flight{tampered}, []uint{3})
+
+				// when
+				verificationResponse, err := historyService.VerifyLogbook("1")
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(verificationResponse.Valid).To(BeFalse())
+				Expect(verificationResponse.Problems).To(Equal([]dto.VerificationProblem{
+					{Sequence: util.Uint(2), FlightID: 3, Reason: "entry differs from its latest version"},
+				}))
+			})
+		})
+		Context("when the entry was removed without a recorded version", func() {
+			It("Should report the removed entry", func() {
+				// given
+				flightVersionRepoMock.EXPECT().GetByUserID("1").Return(mockVersions, nil)
+				expectLogbook([]model.Flight{}, []uint{})
+
+				// when
+				verificationResponse, err := historyService.VerifyLogbook("1")
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(verificationResponse.Problems).To(Equal([]dto.VerificationProblem{
+					{Sequence: util.Uint(2), FlightID: 3, Reason: "entry was removed without a recorded version"},
+				}))
+			})
+		})
+		Context("when the entry was deleted", func() {
+			It("Should report a valid logbook", func() {
+				// given
+				deleted := mockVersions[1]
+				deleted.Action = model.FlightVersionActionDeleted
+				versions := chain(mockVersions[0], deleted)
+				flightVersionRepoMock.EXPECT().GetByUserID("1").Return(versions, nil)
+				expectLogbook([]model.Flight{}, []uint{})
+
+				// when
+				verificationResponse, err := historyService.VerifyLogbook("1")
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(verificationResponse.Valid).To(BeTrue())
+			})
+		})
+		Context("when loading the history fails", func() {
+			It("Should return an error", func() {
+				// given
+				flightVersionRepoMock.EXPECT().GetByUserID("1").Return(nil, dto.ErrInternalFailure)
+
+				// when
+				verificationResponse, err := historyService.VerifyLogbook("1")
+
+				// then
+				Expect(err).To(Equal(dto.ErrInternalFailure))
+				Expect(verificationResponse).To(Equal(dto.LogbookVerificationResponse{}))
+			})
+		})
+	})
+
+	Describe("recordFlightVersions", func() {
+		It("Should chain the versions after the head of the user", func() {
+			// given
+			created := make([]model.FlightVersion, 0)
+			flightVersionRepoMock.EXPECT().GetHeadTx(gomock.Any(), "1").Return(model.FlightVersion{Sequence: 4, Hash: "head"}, nil)
+			flightVersionRepoMock.EXPECT().CreateTx(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ infrastructure.Database, version model.FlightVersion) (model.FlightVersion, error) {
+					created = append(created, version)
+					return version, nil
+				}).Times(2)
+
+			// when
+			err := recordFlightVersions(flightVersionRepoMock, nil, "1", recordedAt.Add(400*time.Nanosecond),
+				flightChange{flightID: 3, action: model.FlightVersionActionImported, snapshot: newLogbookSnapshot(mockFlight, nil, nil)},
+				flightChange{flightID: 4, action: model.FlightVersionActionImported, snapshot: newLogbookSnapshot(mockFlight, nil, nil)})
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(created).To(HaveLen(2))
+			Expect(created[0].Sequence).To(Equal(uint(5)))
+			Expect(created[0].PreviousHash).To(Equal("head"))
+			Expect(created[0].RecordedAt).To(Equal(recordedAt))
+			Expect(created[1].Sequence).To(Equal(uint(6)))
+			Expect(created[1].PreviousHash).To(Equal(created[0].Hash))
+			Expect(created[1].Hash).To(Equal(hashFlightVersion(created[1])))
+		})
+	})
+})
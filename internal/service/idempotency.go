@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	"time"
+)
+
+const (
+	maxIdempotencyKeyLength = 255
+	// idempotencyKeyLockTime is how long a request is considered in progress before a retry executes it again
+	idempotencyKeyLockTime = time.Minute
+	// idempotencyKeyRetention is how long a retried request returns the stored response
+	idempotencyKeyRetention = 24 * time.Hour
+	// idempotencyLockTokenBytes is the length of the random token identifying the request holding a key
+	idempotencyLockTokenBytes = 16
+)
+
+//go:generate mockgen -source=idempotency.go -destination=idempotency_mock.go -package service
+type IdempotencyService interface {
+	Acquire(userID, key, requestHash string) (*dto.StoredResponse, string, error)
+	Complete(userID, key, lockToken string, response dto.StoredResponse) error
+	Release(userID, key, lockToken string) error
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+type idempotencyService struct {
+	idempotencyKeyRepository repository.IdempotencyKeyRepository
+	now                      func() time.Time
+}
+
+func newIdempotencyService(idempotencyKeyRepository repository.IdempotencyKeyRepository, now func() time.Time) IdempotencyService {
+	return &idempotencyService{idempotencyKeyRepository: idempotencyKeyRepository, now: now}
+}
+
+// Acquire returns the stored response if the request was already executed. Otherwise it returns the lock token the
+// caller has to execute the request with and then complete or release the key. A key in progress is a conflict, a key
+// sent with a different request is unprocessable.
+func (i *idempotencyService) Acquire(userID, key, requestHash string) (*dto.StoredResponse, string, error) {
+	if key == "" || len(key) > maxIdempotencyKeyLength {
+		return nil, "", fmt.Errorf("%w: idempotency key must have between 1 and %d characters", dto.ErrBadRequest,
+			maxIdempotencyKeyLength)
+	}
+
+	lockToken, err := generateLockToken()
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+	}
+
+	now := i.now()
+	storedKey, acquired, err := i.idempotencyKeyRepository.Acquire(model.IdempotencyKey{
+		UserID:      userID,
+		Key:         key,
+		RequestHash: requestHash,
+		LockedUntil: now.Add(idempotencyKeyLockTime),
+		LockToken:   lockToken,
+		ExpiresAt:   now.Add(idempotencyKeyRetention),
+	}, now)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if acquired {
+		return nil, lockToken, nil
+	}
+
+	if storedKey.RequestHash != requestHash {
+		return nil, "", fmt.Errorf("%w: %v", dto.ErrUnprocessable, "idempotency key was already used for a different request")
+	}
+
+	if storedKey.StatusCode == nil {
+		return nil, "", fmt.Errorf("%w: %v", dto.ErrConflict, "a request with the idempotency key is in progress")
+	}
+
+	response := &dto.StoredResponse{StatusCode: *storedKey.StatusCode, Body: storedKey.Body}
+	if storedKey.ContentType != nil {
+		response.ContentType = *storedKey.ContentType
+	}
+	return response, "", nil
+}
+
+func (i *idempotencyService) Complete(userID, key, lockToken string, response dto.StoredResponse) error {
+	return i.idempotencyKeyRepository.Complete(userID, key, lockToken, response.StatusCode, response.ContentType,
+		response.Body)
+}
+
+func (i *idempotencyService) Release(userID, key, lockToken string) error {
+	return i.idempotencyKeyRepository.Release(userID, key, lockToken)
+}
+
+// PurgeExpired deletes the keys kept longer than the retention period.
+func (i *idempotencyService) PurgeExpired(ctx context.Context) (int64, error) {
+	return i.idempotencyKeyRepository.DeleteExpired(ctx, i.now())
+}
+
+func generateLockToken() (string, error) {
+	token := make([]byte, idempotencyLockTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
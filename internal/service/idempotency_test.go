@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	"github.com/avialog/backend/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"strings"
+	"time"
+)
+
+var _ = Describe("IdempotencyService", func() {
+	var (
+		idempotencyService     IdempotencyService
+		idempotencyKeyRepoCtrl *gomock.Controller
+		idempotencyKeyRepoMock *repository.MockIdempotencyKeyRepository
+		now                    time.Time
+	)
+
+	BeforeEach(func() {
+		idempotencyKeyRepoCtrl = gomock.NewController(GinkgoT())
+		idempotencyKeyRepoMock = repository.NewMockIdempotencyKeyRepository(idempotencyKeyRepoCtrl)
+		now = time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+		idempotencyService = newIdempotencyService(idempotencyKeyRepoMock, func() time.Time { return now })
+	})
+
+	AfterEach(func() {
+		idempotencyKeyRepoCtrl.Finish()
+	})
+
+	Describe("Acquire", func() {
+		Context("when the key is new", func() {
+			It("should store it locked for a minute and kept for a day", func() {
+				// given
+				var storedKey model.IdempotencyKey
+				idempotencyKeyRepoMock.EXPECT().Acquire(gomock.Any(), now).
+					DoAndReturn(func(key model.IdempotencyKey, _ time.Time) (model.IdempotencyKey, bool, error) {
+						storedKey = key
+						return key, true, nil
+					})
+
+				// when
+				storedResponse, lockToken, err := idempotencyService.Acquire("1", "abc", "hash")
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(storedResponse).To(BeNil())
+				Expect(lockToken).To(HaveLen(32))
+				Expect(storedKey).To(Equal(model.IdempotencyKey{
+					UserID:      "1",
+					Key:         "abc",
+					RequestHash: "hash",
+					LockedUntil: now.Add(time.Minute),
+					LockToken:   lockToken,
+					ExpiresAt:   now.Add(24 * time.Hour),
+				}))
+			})
+		})
+		Context("when the request was already executed", func() {
+			It("should return the stored response", func() {
+				// given
+				idempotencyKeyRepoMock.EXPECT().Acquire(gomock.Any(), now).Return(model.IdempotencyKey{
+					RequestHash: "hash",
+					StatusCode:  util.Int(201),
+					ContentType: util.String("application/json"),
+					Body:        []byte(`{"id":7}`),
+				}, false, nil)
+
+				// when
+				storedResponse, _, err := idempotencyService.Acquire("1", "abc", "hash")
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(storedResponse).To(Equal(&dto.StoredResponse{StatusCode: 201, ContentType: "application/json", Body: []byte(`{"id":7}`)}))
+			})
+		})
+		Context("when the request is in progress", func() {
+			It("should return a conflict", func() {
+				// given
+				idempotencyKeyRepoMock.EXPECT().Acquire(gomock.Any(), now).Return(model.IdempotencyKey{RequestHash: "hash"}, false, nil)
+
+				// when
+				_, _, err := idempotencyService.Acquire("1", "abc", "hash")
+
+				// then
+				Expect(errors.Is(err, dto.ErrConflict)).To(BeTrue())
+				Expect(err.Error()).To(Equal("conflict: a request with the idempotency key is in progress"))
+			})
+		})
+		Context("when the key was used for a different request", func() {
+			It("should return unprocessable", func() {
+				// given
+				idempotencyKeyRepoMock.EXPECT().Acquire(gomock.Any(), now).Return(model.IdempotencyKey{
+					RequestHash: "other",
+					StatusCode:  util.Int(201),
+				}, false, nil)
+
+				// when
+				_, _, err := idempotencyService.Acquire("1", "abc", "hash")
+
+				// then
+				Expect(errors.Is(err, dto.ErrUnprocessable)).To(BeTrue())
+			})
+		})
+		Context("when the key is too long", func() {
+			It("should return bad request", func() {
+				// when
+				_, _, err := idempotencyService.Acquire("1", strings.Repeat("a", 256), "hash")
+
+				// then
+				Expect(err.Error()).To(Equal("bad request: idempotency key must have between 1 and 255 characters"))
+			})
+		})
+	})
+
+	Describe("Complete", func() {
+		Context("when a retry took over the key", func() {
+			It("should return not found", func() {
+				// given
+				idempotencyKeyRepoMock.EXPECT().Complete("1", "abc", "lock", 201, "application/json", []byte(`{"id":7}`)).
+					Return(fmt.Errorf("%w: %v", dto.ErrNotFound, "idempotency key is not held by the request"))
+
+				// when
+				err := idempotencyService.Complete("1", "abc", "lock",
+					dto.StoredResponse{StatusCode: 201, ContentType: "application/json", Body: []byte(`{"id":7}`)})
+
+				// then
+				Expect(errors.Is(err, dto.ErrNotFound)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("PurgeExpired", func() {
+		It("should delete the keys expired by now", func() {
+			// given
+			idempotencyKeyRepoMock.EXPECT().DeleteExpired(gomock.Any(), now).Return(int64(3), nil)
+
+			// when
+			purged, err := idempotencyService.PurgeExpired(context.Background())
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(purged).To(Equal(int64(3)))
+		})
+	})
+})
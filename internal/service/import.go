@@ -0,0 +1,273 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/config"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/importer"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	"github.com/go-playground/validator/v10"
+	"io"
+	"strings"
+	"time"
+)
+
+//go:generate mockgen -source=import.go -destination=import_mock.go -package service
+type ImportService interface {
+	ImportLogbook(userID string, format string, reader io.Reader, options importer.Options) (dto.ImportResponse, error)
+}
+
+type importService struct {
+	flightRepository        repository.FlightRepository
+	landingRepository       repository.LandingRepository
+	passengerRepository     repository.PassengerRepository
+	aircraftRepository      repository.AircraftRepository
+	airportRepository       repository.AirportRepository
+	flightVersionRepository repository.FlightVersionRepository
+	registry                importer.Registry
+	validator               *validator.Validate
+	config                  config.Config
+}
+
+func newImportService(flightRepository repository.FlightRepository, landingRepository repository.LandingRepository,
+	passengerRepository repository.PassengerRepository, aircraftRepository repository.AircraftRepository,
+	airportRepository repository.AirportRepository, flightVersionRepository repository.FlightVersionRepository,
+	registry importer.Registry, config config.Config, validator *validator.Validate) ImportService {
+	return &importService{flightRepository: flightRepository, landingRepository: landingRepository,
+		passengerRepository: passengerRepository, aircraftRepository: aircraftRepository,
+		airportRepository: airportRepository, flightVersionRepository: flightVersionRepository, registry: registry,
+		validator: validator, config: config}
+}
+
+// ImportLogbook imports all rows of the file in a single transaction. Rows which can't be parsed or are invalid are
+// reported as failed, rows matching a flight already in the logbook are skipped, database errors abort the import.
+func (i *importService) ImportLogbook(userID string, format string, reader io.Reader, options importer.Options) (dto.ImportResponse, error) {
+	parser, ok := i.registry.Get(format)
+	if !ok {
+		return dto.ImportResponse{}, fmt.Errorf("%w: unsupported import format: %v, supported formats: %v", dto.ErrBadRequest,
+			format, strings.Join(i.registry.Names(), ", "))
+	}
+
+	rows, err := parser.Parse(reader, options)
+	if err != nil {
+		return dto.ImportResponse{}, fmt.Errorf("%w: invalid file: %v", dto.ErrBadRequest, err)
+	}
+
+	aircraft, err := i.aircraftRepository.GetByUserID(userID)
+	if err != nil {
+		return dto.ImportResponse{}, err
+	}
+
+	aircraftIDs := make(map[string]uint, len(aircraft))
+	for _, a := range aircraft {
+		aircraftIDs[strings.ToUpper(a.RegistrationNumber)] = a.ID
+	}
+
+	existingFlights, err := i.getExistingFlights(userID, rows)
+	if err != nil {
+		return dto.ImportResponse{}, err
+	}
+
+	response := dto.ImportResponse{
+		CreatedAircraft: make([]string, 0),
+		Rows:            make([]dto.ImportRowResult, 0, len(rows)),
+	}
+	addResult := func(line int, status dto.ImportRowStatus, flightID *uint, message string) {
+		result := dto.ImportRowResult{Line: line, Status: status, FlightID: flightID}
+		if message != "" {
+			result.Message = &message
+		}
+		response.Rows = append(response.Rows, result)
+
+		switch status {
+		case dto.ImportRowCreated:
+			response.Created++
+		case dto.ImportRowSkipped:
+			response.Skipped++
+		case dto.ImportRowFailed:
+			response.Failed++
+		}
+	}
+
+	changes := make([]flightChange, 0, len(rows))
+	tx := i.flightRepository.Begin()
+
+	if err := i.flightVersionRepository.LockUserTx(tx, userID); err != nil {
+		tx.Rollback()
+		return dto.ImportResponse{}, err
+	}
+
+	for _, row := range rows {
+		if row.Err != nil {
+			addResult(row.Line, dto.ImportRowFailed, nil, row.Err.Error())
+			continue
+		}
+		if row.AircraftRegistration == "" {
+			addResult(row.Line, dto.ImportRowFailed, nil, "missing aircraft registration")
+			continue
+		}
+
+		if err := normalizeAirportCodes(i.airportRepository, &row.LogbookRequest); err != nil {
+			tx.Rollback()
+			return dto.ImportResponse{}, err
+		}
+
+		key := flightKey(row.LogbookRequest.TakeoffTime, row.LogbookRequest.TakeoffAirportCode, row.LogbookRequest.LandingAirportCode)
+		if existingFlights[key] {
+			addResult(row.Line, dto.ImportRowSkipped, nil, "flight already exists in logbook")
+			continue
+		}
+
+		aircraftID, aircraftExists := aircraftIDs[row.AircraftRegistration]
+		newAircraft := model.Aircraft{
+			UserID:             userID,
+			RegistrationNumber: row.AircraftRegistration,
+			AircraftModel:      row.AircraftModel,
+			Category:           row.AircraftCategory,
+		}
+		if !aircraftExists {
+			message, err := i.validate(newAircraft)
+			if err != nil {
+				tx.Rollback()
+				return dto.ImportResponse{}, err
+			}
+			if message != "" {
+				addResult(row.Line, dto.ImportRowFailed, nil, message)
+				continue
+			}
+		}
+
+		flight, passengers, landings := newLogbookEntry(userID, aircraftID, row.LogbookRequest)
+		if err := validateLogbookEntry(i.validator, flight, passengers, landings); err != nil {
+			var validationError *dto.ValidationError
+			if !errors.As(err, &validationError) {
+				tx.Rollback()
+				return dto.ImportResponse{}, err
+			}
+			addResult(row.Line, dto.ImportRowFailed, nil, validationError.Error())
+			continue
+		}
+
+		if !aircraftExists {
+			insertedAircraft, err := i.aircraftRepository.CreateTx(tx, newAircraft)
+			if err != nil {
+				tx.Rollback()
+				return dto.ImportResponse{}, err
+			}
+			aircraftIDs[row.AircraftRegistration] = insertedAircraft.ID
+			response.CreatedAircraft = append(response.CreatedAircraft, insertedAircraft.RegistrationNumber)
+			flight.AircraftID = insertedAircraft.ID
+		}
+
+		insertedFlight, err := i.flightRepository.CreateTx(tx, flight)
+		if err != nil {
+			tx.Rollback()
+			return dto.ImportResponse{}, err
+		}
+
+		for _, passenger := range passengers {
+			passenger.FlightID = insertedFlight.ID
+			if _, err := i.passengerRepository.CreateTx(tx, passenger); err != nil {
+				tx.Rollback()
+				return dto.ImportResponse{}, err
+			}
+		}
+
+		for _, landing := range landings {
+			landing.FlightID = insertedFlight.ID
+			if _, err := i.landingRepository.CreateTx(tx, landing); err != nil {
+				tx.Rollback()
+				return dto.ImportResponse{}, err
+			}
+		}
+
+		changes = append(changes, flightChange{
+			flightID: insertedFlight.ID,
+			action:   model.FlightVersionActionImported,
+			snapshot: newLogbookSnapshot(insertedFlight, passengers, landings),
+		})
+		existingFlights[key] = true
+		flightID := insertedFlight.ID
+		addResult(row.Line, dto.ImportRowCreated, &flightID, "")
+	}
+
+	if len(changes) > 0 {
+		if err := recordFlightVersions(i.flightVersionRepository, tx, userID, time.Now(), changes...); err != nil {
+			tx.Rollback()
+			return dto.ImportResponse{}, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return dto.ImportResponse{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+	}
+
+	return response, nil
+}
+
+// getExistingFlights returns keys of flights of the user within the time range of the imported rows.
+func (i *importService) getExistingFlights(userID string, rows []importer.Row) (map[string]bool, error) {
+	existingFlights := make(map[string]bool)
+
+	var start, end *time.Time
+	for j := range rows {
+		if rows[j].Err != nil {
+			continue
+		}
+		takeoffTime := rows[j].LogbookRequest.TakeoffTime
+		if start == nil || takeoffTime.Before(*start) {
+			start = &rows[j].LogbookRequest.TakeoffTime
+		}
+		if end == nil || takeoffTime.After(*end) {
+			end = &rows[j].LogbookRequest.TakeoffTime
+		}
+	}
+	if start == nil {
+		return existingFlights, nil
+	}
+
+	flights, err := i.flightRepository.GetByUserIDOrderedByTakeoffTime(userID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	for _, flight := range flights {
+		existingFlights[flightKey(flight.TakeoffTime, i.icaoCode(flight.TakeoffAirportCode), i.icaoCode(flight.LandingAirportCode))] = true
+	}
+
+	return existingFlights, nil
+}
+
+// icaoCode returns the ICAO code of a stored airport code, flights saved before codes were normalized may contain IATA
+// codes.
+func (i *importService) icaoCode(code string) string {
+	if airport, err := i.airportRepository.GetByCode(code); err == nil {
+		return airport.ICAOCode
+	}
+	return code
+}
+
+// validate returns a message describing the first invalid field of the value, an error is returned only if the
+// validation itself failed.
+func (i *importService) validate(value interface{}, except ...string) (string, error) {
+	err := i.validator.StructExcept(value, except...)
+	if err == nil {
+		return "", nil
+	}
+
+	var invalidValidationError *validator.InvalidValidationError
+	if errors.As(err, &invalidValidationError) {
+		return "", fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+	}
+
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) && len(validationErrors) > 0 {
+		return fmt.Sprintf("invalid data in field: %v", validationErrors[0].Field()), nil
+	}
+	return err.Error(), nil
+}
+
+func flightKey(takeoffTime time.Time, takeoffAirportCode, landingAirportCode string) string {
+	return fmt.Sprintf("%d|%s|%s", takeoffTime.Unix(), strings.ToUpper(takeoffAirportCode), strings.ToUpper(landingAirportCode))
+}
@@ -0,0 +1,57 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: import.go
+//
+// Generated by this command:
+//
+//	mockgen -source=import.go -destination=import_mock.go -package service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	io "io"
+	reflect "reflect"
+
+	dto "github.com/avialog/backend/internal/dto"
+	importer "github.com/avialog/backend/internal/importer"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImportService is a mock of ImportService interface.
+type MockImportService struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportServiceMockRecorder
+}
+
+// MockImportServiceMockRecorder is the mock recorder for MockImportService.
+type MockImportServiceMockRecorder struct {
+	mock *MockImportService
+}
+
+// NewMockImportService creates a new mock instance.
+func NewMockImportService(ctrl *gomock.Controller) *MockImportService {
+	mock := &MockImportService{ctrl: ctrl}
+	mock.recorder = &MockImportServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportService) EXPECT() *MockImportServiceMockRecorder {
+	return m.recorder
+}
+
+// ImportLogbook mocks base method.
+func (m *MockImportService) ImportLogbook(userID, format string, reader io.Reader, options importer.Options) (dto.ImportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportLogbook", userID, format, reader, options)
+	ret0, _ := ret[0].(dto.ImportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportLogbook indicates an expected call of ImportLogbook.
+func (mr *MockImportServiceMockRecorder) ImportLogbook(userID, format, reader, options any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportLogbook", reflect.TypeOf((*MockImportService)(nil).ImportLogbook), userID, format, reader, options)
+}
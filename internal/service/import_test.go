@@ -0,0 +1,309 @@
+package service
+
+import (
+	"errors"
+	"github.com/avialog/backend/internal/config"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/importer"
+	"github.com/avialog/backend/internal/infrastructure"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	"github.com/avialog/backend/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+var _ = Describe("ImportService", func() {
+	var (
+		importService         ImportService
+		flightRepoCtrl        *gomock.Controller
+		flightRepoMock        *repository.MockFlightRepository
+		landingRepoCtrl       *gomock.Controller
+		landingRepoMock       *repository.MockLandingRepository
+		passengerRepoCtrl     *gomock.Controller
+		passengerRepoMock     *repository.MockPassengerRepository
+		aircraftRepoCtrl      *gomock.Controller
+		aircraftRepoMock      *repository.MockAircraftRepository
+		airportRepoCtrl       *gomock.Controller
+		airportRepoMock       *repository.MockAirportRepository
+		flightVersionRepoCtrl *gomock.Controller
+		flightVersionRepoMock *repository.MockFlightVersionRepository
+		registryCtrl          *gomock.Controller
+		registryMock          *importer.MockRegistry
+		formatCtrl            *gomock.Controller
+		formatMock            *importer.MockFormat
+		databaseCtrl          *gomock.Controller
+		databaseMock          *infrastructure.MockDatabase
+		takeoffTime           time.Time
+		mockRow               importer.Row
+		file                  *strings.Reader
+	)
+
+	BeforeEach(func() {
+		flightRepoCtrl = gomock.NewController(GinkgoT())
+		flightRepoMock = repository.NewMockFlightRepository(flightRepoCtrl)
+		landingRepoCtrl = gomock.NewController(GinkgoT())
+		landingRepoMock = repository.NewMockLandingRepository(landingRepoCtrl)
+		passengerRepoCtrl = gomock.NewController(GinkgoT())
+		passengerRepoMock = repository.NewMockPassengerRepository(passengerRepoCtrl)
+		aircraftRepoCtrl = gomock.NewController(GinkgoT())
+		aircraftRepoMock = repository.NewMockAircraftRepository(aircraftRepoCtrl)
+		airportRepoCtrl = gomock.NewController(GinkgoT())
+		airportRepoMock = repository.NewMockAirportRepository(airportRepoCtrl)
+		airportRepoMock.EXPECT().GetByCode(gomock.Any()).DoAndReturn(func(code string) (model.Airport, error) {
+			switch code {
+			case "KRK":
+				return model.Airport{ICAOCode: "EPKK", IATACode: util.String("KRK")}, nil
+			case "XXXX":
+				return model.Airport{}, dto.ErrNotFound
+			}
+			return model.Airport{ICAOCode: code}, nil
+		}).AnyTimes()
+		flightVersionRepoCtrl = gomock.NewController(GinkgoT())
+		flightVersionRepoMock = repository.NewMockFlightVersionRepository(flightVersionRepoCtrl)
+		registryCtrl = gomock.NewController(GinkgoT())
+		registryMock = importer.NewMockRegistry(registryCtrl)
+		formatCtrl = gomock.NewController(GinkgoT())
+		formatMock = importer.NewMockFormat(formatCtrl)
+		databaseCtrl = gomock.NewController(GinkgoT())
+		databaseMock = infrastructure.NewMockDatabase(databaseCtrl)
+		importService = newImportService(flightRepoMock, landingRepoMock, passengerRepoMock, aircraftRepoMock,
+			airportRepoMock, flightVersionRepoMock, registryMock, config.Config{}, util.GetValidator())
+		takeoffTime = time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+		mockRow = importer.Row{
+			Line:                 2,
+			AircraftRegistration: "SP-ABC",
+			AircraftModel:        "Cessna 152",
+			LogbookRequest: dto.LogbookRequest{
+				TakeoffTime:        takeoffTime,
+				TakeoffAirportCode: "EPKK",
+				LandingTime:        takeoffTime.Add(time.Hour),
+				LandingAirportCode: "EPKT",
+				Style:              model.StyleVFR,
+				MyRole:             model.RolePilotInCommand,
+				TotalBlockTime:     util.Duration(time.Hour),
+				Passengers:         []dto.PassengerEntry{{Role: model.RoleInstructor, FirstName: "John"}},
+				Landings:           []dto.LandingEntry{{ApproachType: model.ApproachTypeVisual, Count: util.Uint(1)}},
+			},
+		}
+		file = strings.NewReader("file")
+	})
+
+	AfterEach(func() {
+		flightRepoCtrl.Finish()
+		landingRepoCtrl.Finish()
+		passengerRepoCtrl.Finish()
+		aircraftRepoCtrl.Finish()
+		airportRepoCtrl.Finish()
+		flightVersionRepoCtrl.Finish()
+		registryCtrl.Finish()
+		formatCtrl.Finish()
+		databaseCtrl.Finish()
+	})
+
+	Describe("ImportLogbook", func() {
+		Context("when aircraft of the row doesn't exist", func() {
+			It("should create aircraft and flight and report created row", func() {
+				// given
+				registryMock.EXPECT().Get("foreflight").Return(formatMock, true)
+				formatMock.EXPECT().Parse(file, importer.Options{}).Return([]importer.Row{mockRow}, nil)
+				aircraftRepoMock.EXPECT().GetByUserID("1").Return([]model.Aircraft{}, nil)
+				flightRepoMock.EXPECT().GetByUserIDOrderedByTakeoffTime("1", &takeoffTime, &takeoffTime).Return([]model.Flight{}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "1").Return(nil)
+				aircraftRepoMock.EXPECT().CreateTx(databaseMock, model.Aircraft{UserID: "1", RegistrationNumber: "SP-ABC", AircraftModel: "Cessna 152"}).
+					Return(model.Aircraft{Model: gorm.Model{ID: 5}, UserID: "1", RegistrationNumber: "SP-ABC", AircraftModel: "Cessna 152"}, nil)
+				flightRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(func(tx infrastructure.Database, flight model.Flight) (model.Flight, error) {
+					Expect(flight.AircraftID).To(Equal(uint(5)))
+					Expect(flight.UserID).To(Equal("1"))
+					flight.ID = 7
+					return flight, nil
+				})
+				passengerRepoMock.EXPECT().CreateTx(databaseMock, model.Passenger{FlightID: 7, Role: model.RoleInstructor, FirstName: "John"}).Return(model.Passenger{}, nil)
+				landingRepoMock.EXPECT().CreateTx(databaseMock, model.Landing{FlightID: 7, ApproachType: model.ApproachTypeVisual, Count: util.Uint(1)}).Return(model.Landing{}, nil)
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "1").Return(model.FlightVersion{Sequence: 4, Hash: "head"}, nil)
+				flightVersionRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(func(tx infrastructure.Database, version model.FlightVersion) (model.FlightVersion, error) {
+					Expect(version.Sequence).To(Equal(uint(5)))
+					Expect(version.FlightID).To(Equal(uint(7)))
+					Expect(version.Action).To(Equal(model.FlightVersionActionImported))
+					Expect(version.PreviousHash).To(Equal("head"))
+					return version, nil
+				})
+				databaseMock.EXPECT().Commit().Return(&gorm.DB{})
+
+				// when
+				importResponse, err := importService.ImportLogbook("1", "foreflight", file, importer.Options{})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(importResponse).To(Equal(dto.ImportResponse{
+					Created:         1,
+					CreatedAircraft: []string{"SP-ABC"},
+					Rows:            []dto.ImportRowResult{{Line: 2, Status: dto.ImportRowCreated, FlightID: util.Uint(7)}},
+				}))
+			})
+		})
+		Context("when the airport of the row is not in the dataset", func() {
+			It("should import the flight with the code as given", func() {
+				// given
+				mockRow.LogbookRequest.LandingAirportCode = "XXXX"
+				registryMock.EXPECT().Get("csv").Return(formatMock, true)
+				formatMock.EXPECT().Parse(file, importer.Options{}).Return([]importer.Row{mockRow}, nil)
+				aircraftRepoMock.EXPECT().GetByUserID("1").Return([]model.Aircraft{{Model: gorm.Model{ID: 5}, RegistrationNumber: "SP-ABC"}}, nil)
+				flightRepoMock.EXPECT().GetByUserIDOrderedByTakeoffTime("1", &takeoffTime, &takeoffTime).Return([]model.Flight{}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "1").Return(nil)
+				flightRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(func(tx infrastructure.Database, flight model.Flight) (model.Flight, error) {
+					Expect(flight.LandingAirportCode).To(Equal("XXXX"))
+					Expect(flight.NightTime).To(BeNil())
+					flight.ID = 7
+					return flight, nil
+				})
+				passengerRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).Return(model.Passenger{}, nil)
+				landingRepoMock.EXPECT().CreateTx(databaseMock, model.Landing{FlightID: 7, ApproachType: model.ApproachTypeVisual, Count: util.Uint(1)}).Return(model.Landing{}, nil)
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "1").Return(model.FlightVersion{}, dto.ErrNotFound)
+				flightVersionRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(func(tx infrastructure.Database, version model.FlightVersion) (model.FlightVersion, error) {
+					return version, nil
+				})
+				databaseMock.EXPECT().Commit().Return(&gorm.DB{})
+
+				// when
+				importResponse, err := importService.ImportLogbook("1", "csv", file, importer.Options{})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(importResponse.Created).To(Equal(1))
+				Expect(importResponse.Failed).To(BeZero())
+			})
+		})
+		Context("when rows are invalid or already imported", func() {
+			It("should report failed and skipped rows", func() {
+				// given
+				invalidRow := mockRow
+				invalidRow.Line = 3
+				invalidRow.LogbookRequest.TakeoffTime = takeoffTime.Add(time.Hour)
+				invalidRow.LogbookRequest.LandingTime = takeoffTime.Add(2 * time.Hour)
+				invalidRow.LogbookRequest.TakeoffAirportCode = ""
+				unparsedRow := importer.Row{Line: 4, Err: errors.New("invalid value in column date: abc")}
+				registryMock.EXPECT().Get("csv").Return(formatMock, true)
+				formatMock.EXPECT().Parse(file, importer.Options{}).Return([]importer.Row{mockRow, invalidRow, unparsedRow}, nil)
+				aircraftRepoMock.EXPECT().GetByUserID("1").Return([]model.Aircraft{{Model: gorm.Model{ID: 5}, RegistrationNumber: "sp-abc"}}, nil)
+				end := takeoffTime.Add(time.Hour)
+				flightRepoMock.EXPECT().GetByUserIDOrderedByTakeoffTime("1", &takeoffTime, &end).
+					Return([]model.Flight{{TakeoffTime: takeoffTime, TakeoffAirportCode: "KRK", LandingAirportCode: "EPKT"}}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "1").Return(nil)
+				databaseMock.EXPECT().Commit().Return(&gorm.DB{})
+
+				// when
+				importResponse, err := importService.ImportLogbook("1", "csv", file, importer.Options{})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(importResponse).To(Equal(dto.ImportResponse{
+					Skipped:         1,
+					Failed:          2,
+					CreatedAircraft: []string{},
+					Rows: []dto.ImportRowResult{
+						{Line: 2, Status: dto.ImportRowSkipped, Message: util.String("flight already exists in logbook")},
+						{Line: 3, Status: dto.ImportRowFailed, Message: util.String("invalid data in field: takeoff_airport_code")},
+						{Line: 4, Status: dto.ImportRowFailed, Message: util.String("invalid value in column date: abc")},
+					},
+				}))
+			})
+		})
+		Context("when format is not supported", func() {
+			It("should return bad request error", func() {
+				// given
+				registryMock.EXPECT().Get("xls").Return(nil, false)
+				registryMock.EXPECT().Names().Return([]string{"csv", "foreflight"})
+
+				// when
+				importResponse, err := importService.ImportLogbook("1", "xls", file, importer.Options{})
+
+				// then
+				Expect(err.Error()).To(Equal("bad request: unsupported import format: xls, supported formats: csv, foreflight"))
+				Expect(importResponse).To(Equal(dto.ImportResponse{}))
+			})
+		})
+		Context("when file can't be parsed", func() {
+			It("should return bad request error", func() {
+				// given
+				registryMock.EXPECT().Get("foreflight").Return(formatMock, true)
+				formatMock.EXPECT().Parse(file, importer.Options{}).Return(nil, errors.New("missing flights table"))
+
+				// when
+				importResponse, err := importService.ImportLogbook("1", "foreflight", file, importer.Options{})
+
+				// then
+				Expect(err.Error()).To(Equal("bad request: invalid file: missing flights table"))
+				Expect(importResponse).To(Equal(dto.ImportResponse{}))
+			})
+		})
+		Context("when saving flight fails", func() {
+			It("should rollback the transaction and return error", func() {
+				// given
+				registryMock.EXPECT().Get("foreflight").Return(formatMock, true)
+				formatMock.EXPECT().Parse(file, importer.Options{}).Return([]importer.Row{mockRow}, nil)
+				aircraftRepoMock.EXPECT().GetByUserID("1").Return([]model.Aircraft{{Model: gorm.Model{ID: 5}, RegistrationNumber: "SP-ABC"}}, nil)
+				flightRepoMock.EXPECT().GetByUserIDOrderedByTakeoffTime("1", &takeoffTime, &takeoffTime).Return([]model.Flight{}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "1").Return(nil)
+				flightRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).Return(model.Flight{}, errors.New("internal failure: failed to save flight"))
+				databaseMock.EXPECT().Rollback().Return(&gorm.DB{})
+
+				// when
+				importResponse, err := importService.ImportLogbook("1", "foreflight", file, importer.Options{})
+
+				// then
+				Expect(err.Error()).To(Equal("internal failure: failed to save flight"))
+				Expect(importResponse).To(Equal(dto.ImportResponse{}))
+			})
+		})
+		Context("when recording the history fails", func() {
+			It("should rollback the transaction and return error", func() {
+				// given
+				registryMock.EXPECT().Get("foreflight").Return(formatMock, true)
+				formatMock.EXPECT().Parse(file, importer.Options{}).Return([]importer.Row{mockRow}, nil)
+				aircraftRepoMock.EXPECT().GetByUserID("1").Return([]model.Aircraft{{Model: gorm.Model{ID: 5}, RegistrationNumber: "SP-ABC"}}, nil)
+				flightRepoMock.EXPECT().GetByUserIDOrderedByTakeoffTime("1", &takeoffTime, &takeoffTime).Return([]model.Flight{}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "1").Return(nil)
+				flightRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).Return(model.Flight{Model: gorm.Model{ID: 7}}, nil)
+				passengerRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).Return(model.Passenger{}, nil)
+				landingRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).Return(model.Landing{}, nil)
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "1").Return(model.FlightVersion{}, dto.ErrInternalFailure)
+				databaseMock.EXPECT().Rollback().Return(&gorm.DB{})
+
+				// when
+				importResponse, err := importService.ImportLogbook("1", "foreflight", file, importer.Options{})
+
+				// then
+				Expect(err).To(Equal(dto.ErrInternalFailure))
+				Expect(importResponse).To(Equal(dto.ImportResponse{}))
+			})
+		})
+		Context("when commit fails", func() {
+			It("should return error", func() {
+				// given
+				registryMock.EXPECT().Get("foreflight").Return(formatMock, true)
+				formatMock.EXPECT().Parse(file, importer.Options{}).Return([]importer.Row{}, nil)
+				aircraftRepoMock.EXPECT().GetByUserID("1").Return([]model.Aircraft{}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "1").Return(nil)
+				databaseMock.EXPECT().Commit().Return(&gorm.DB{Error: errors.New("failed to commit")})
+
+				// when
+				importResponse, err := importService.ImportLogbook("1", "foreflight", file, importer.Options{})
+
+				// then
+				Expect(err.Error()).To(Equal("internal failure: failed to commit"))
+				Expect(importResponse).To(Equal(dto.ImportResponse{}))
+			})
+		})
+	})
+})
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	"time"
+)
+
+const (
+	defaultJobRunsLimit = 50
+	maxJobRunsLimit     = 200
+	// jobRunRetention is how long finished job runs are kept for the status endpoint
+	jobRunRetention = 30 * 24 * time.Hour
+)
+
+//go:generate mockgen -source=job.go -destination=job_mock.go -package service
+type JobService interface {
+	GetRecentRuns(job string, limit *int) ([]model.JobRun, error)
+	PurgeRuns(ctx context.Context) (int64, error)
+}
+
+type jobService struct {
+	jobRunRepository repository.JobRunRepository
+	now              func() time.Time
+}
+
+func newJobService(jobRunRepository repository.JobRunRepository, now func() time.Time) JobService {
+	return &jobService{jobRunRepository: jobRunRepository, now: now}
+}
+
+// GetRecentRuns returns the most recently scheduled runs of the job, or of all jobs if it is empty.
+func (j *jobService) GetRecentRuns(job string, limit *int) ([]model.JobRun, error) {
+	runsLimit := defaultJobRunsLimit
+	if limit != nil {
+		if *limit < 1 || *limit > maxJobRunsLimit {
+			return nil, fmt.Errorf("%w: limit must be between 1 and %d", dto.ErrBadRequest, maxJobRunsLimit)
+		}
+		runsLimit = *limit
+	}
+
+	return j.jobRunRepository.GetRecent(job, runsLimit)
+}
+
+// PurgeRuns deletes the finished runs scheduled before the retention period.
+func (j *jobService) PurgeRuns(ctx context.Context) (int64, error) {
+	return j.jobRunRepository.DeleteFinishedBefore(ctx, j.now().Add(-jobRunRetention))
+}
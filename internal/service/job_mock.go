@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: job.go
+//
+// Generated by this command:
+//
+//	mockgen -source=job.go -destination=job_mock.go -package service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/avialog/backend/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockJobService is a mock of JobService interface.
+type MockJobService struct {
+	ctrl     *gomock.Controller
+	recorder *MockJobServiceMockRecorder
+}
+
+// MockJobServiceMockRecorder is the mock recorder for MockJobService.
+type MockJobServiceMockRecorder struct {
+	mock *MockJobService
+}
+
+// NewMockJobService creates a new mock instance.
+func NewMockJobService(ctrl *gomock.Controller) *MockJobService {
+	mock := &MockJobService{ctrl: ctrl}
+	mock.recorder = &MockJobServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJobService) EXPECT() *MockJobServiceMockRecorder {
+	return m.recorder
+}
+
+// GetRecentRuns mocks base method.
+func (m *MockJobService) GetRecentRuns(job string, limit *int) ([]model.JobRun, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentRuns", job, limit)
+	ret0, _ := ret[0].([]model.JobRun)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecentRuns indicates an expected call of GetRecentRuns.
+func (mr *MockJobServiceMockRecorder) GetRecentRuns(job, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentRuns", reflect.TypeOf((*MockJobService)(nil).GetRecentRuns), job, limit)
+}
+
+// PurgeRuns mocks base method.
+func (m *MockJobService) PurgeRuns(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeRuns", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeRuns indicates an expected call of PurgeRuns.
+func (mr *MockJobServiceMockRecorder) PurgeRuns(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeRuns", reflect.TypeOf((*MockJobService)(nil).PurgeRuns), ctx)
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	"github.com/avialog/backend/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"time"
+)
+
+var _ = Describe("JobService", func() {
+	var (
+		jobService     JobService
+		jobRunRepoCtrl *gomock.Controller
+		jobRunRepoMock *repository.MockJobRunRepository
+		now            time.Time
+	)
+
+	BeforeEach(func() {
+		jobRunRepoCtrl = gomock.NewController(GinkgoT())
+		jobRunRepoMock = repository.NewMockJobRunRepository(jobRunRepoCtrl)
+		now = time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+		jobService = newJobService(jobRunRepoMock, func() time.Time { return now })
+	})
+
+	AfterEach(func() {
+		jobRunRepoCtrl.Finish()
+	})
+
+	Describe("GetRecentRuns", func() {
+		Context("when no limit is given", func() {
+			It("should return the default number of runs", func() {
+				// given
+				runs := []model.JobRun{{ID: 1, Job: "purge-trash", Status: model.JobRunStatusSucceeded}}
+				jobRunRepoMock.EXPECT().GetRecent("purge-trash", 50).Return(runs, nil)
+
+				// when
+				result, err := jobService.GetRecentRuns("purge-trash", nil)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(result).To(Equal(runs))
+			})
+		})
+		Context("when the limit is too large", func() {
+			It("should return bad request error", func() {
+				// when
+				_, err := jobService.GetRecentRuns("", util.Int(201))
+
+				// then
+				Expect(errors.Is(err, dto.ErrBadRequest)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("PurgeRuns", func() {
+		It("should delete the runs finished before the retention period", func() {
+			// given
+			jobRunRepoMock.EXPECT().DeleteFinishedBefore(gomock.Any(), time.Date(2024, 3, 21, 12, 0, 0, 0, time.UTC)).Return(int64(4), nil)
+
+			// when
+			purged, err := jobService.PurgeRuns(context.Background())
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(purged).To(Equal(int64(4)))
+		})
+	})
+})
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"fmt"
+	"github.com/avialog/backend/internal/config"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	"github.com/go-playground/validator/v10"
+	"time"
+)
+
+//go:generate mockgen -source=licence.go -destination=licence_mock.go -package service
+type LicenceService interface {
+	InsertLicence(userID string, licenceRequest dto.LicenceRequest) (model.Licence, error)
+	GetUserLicences(userID string) ([]model.Licence, error)
+	GetLicence(userID string, id uint) (model.Licence, error)
+	UpdateLicence(userID string, id uint, licenceRequest dto.LicenceRequest) (model.Licence, error)
+	DeleteLicence(userID string, id uint) error
+	GetExpirySummary(userID string) (dto.ExpirySummaryResponse, error)
+}
+
+type licenceService struct {
+	licenceRepository repository.LicenceRepository
+	ratingRepository  repository.RatingRepository
+	config            config.Config
+	validator         *validator.Validate
+	now               func() time.Time
+}
+
+func newLicenceService(licenceRepository repository.LicenceRepository, ratingRepository repository.RatingRepository,
+	config config.Config, validator *validator.Validate, now func() time.Time) LicenceService {
+	return &licenceService{licenceRepository: licenceRepository, ratingRepository: ratingRepository, config: config,
+		validator: validator, now: now}
+}
+
+func (l *licenceService) InsertLicence(userID string, licenceRequest dto.LicenceRequest) (model.Licence, error) {
+	licence := model.Licence{UserID: userID}
+	applyLicenceRequest(&licence, licenceRequest)
+
+	if err := validateQualification(l.validator, licence, licence.IssuedAt, licence.ExpiresAt); err != nil {
+		return model.Licence{}, err
+	}
+
+	return l.licenceRepository.Create(licence)
+}
+
+func (l *licenceService) GetUserLicences(userID string) ([]model.Licence, error) {
+	return l.licenceRepository.GetByUserID(userID)
+}
+
+func (l *licenceService) GetLicence(userID string, id uint) (model.Licence, error) {
+	return l.licenceRepository.GetByUserIDAndID(userID, id)
+}
+
+func (l *licenceService) UpdateLicence(userID string, id uint, licenceRequest dto.LicenceRequest) (model.Licence, error) {
+	licence, err := l.licenceRepository.GetByUserIDAndID(userID, id)
+	if err != nil {
+		return model.Licence{}, err
+	}
+
+	applyLicenceRequest(&licence, licenceRequest)
+
+	if err := validateQualification(l.validator, licence, licence.IssuedAt, licence.ExpiresAt); err != nil {
+		return model.Licence{}, err
+	}
+
+	return l.licenceRepository.Save(licence)
+}
+
+func (l *licenceService) DeleteLicence(userID string, id uint) error {
+	numberOfRatings, err := l.ratingRepository.CountByUserIDAndLicenceID(userID, id)
+	if err != nil {
+		return err
+	}
+
+	if numberOfRatings > 0 {
+		return fmt.Errorf("%w: licence has endorsed ratings", dto.ErrConflict)
+	}
+
+	return l.licenceRepository.DeleteByUserIDAndID(userID, id)
+}
+
+// GetExpirySummary reports which licences and ratings of the user are valid, expire within the configured warning
+// period or have already lapsed.
+func (l *licenceService) GetExpirySummary(userID string) (dto.ExpirySummaryResponse, error) {
+	licences, err := l.licenceRepository.GetByUserID(userID)
+	if err != nil {
+		return dto.ExpirySummaryResponse{}, err
+	}
+
+	ratings, err := l.ratingRepository.GetByUserID(userID)
+	if err != nil {
+		return dto.ExpirySummaryResponse{}, err
+	}
+
+	now := l.now()
+	items := make([]dto.ExpiryItem, 0, len(licences)+len(ratings))
+	for _, licence := range licences {
+		name := fmt.Sprintf("%s %s", licence.Type, licence.Number)
+		items = append(items, newExpiryItem(dto.ExpiryItemTypeLicence, licence.ID, name, licence.ExpiresAt, now))
+	}
+	for _, rating := range ratings {
+		items = append(items, newExpiryItem(dto.ExpiryItemTypeRating, rating.ID, rating.Name, rating.ExpiresAt, now))
+	}
+
+	return summarizeExpiry(items, now, l.config.ExpiryWarning), nil
+}
+
+func applyLicenceRequest(licence *model.Licence, licenceRequest dto.LicenceRequest) {
+	licence.Type = licenceRequest.Type
+	licence.Number = licenceRequest.Number
+	licence.IssuingState = licenceRequest.IssuingState
+	licence.IssuedAt = licenceRequest.IssuedAt
+	licence.ExpiresAt = licenceRequest.ExpiresAt
+	licence.Remarks = licenceRequest.Remarks
+}
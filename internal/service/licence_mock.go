@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: licence.go
+//
+// Generated by this command:
+//
+//	mockgen -source=licence.go -destination=licence_mock.go -package service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	reflect "reflect"
+
+	dto "github.com/avialog/backend/internal/dto"
+	model "github.com/avialog/backend/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLicenceService is a mock of LicenceService interface.
+type MockLicenceService struct {
+	ctrl     *gomock.Controller
+	recorder *MockLicenceServiceMockRecorder
+}
+
+// MockLicenceServiceMockRecorder is the mock recorder for MockLicenceService.
+type MockLicenceServiceMockRecorder struct {
+	mock *MockLicenceService
+}
+
+// NewMockLicenceService creates a new mock instance.
+func NewMockLicenceService(ctrl *gomock.Controller) *MockLicenceService {
+	mock := &MockLicenceService{ctrl: ctrl}
+	mock.recorder = &MockLicenceServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLicenceService) EXPECT() *MockLicenceServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteLicence mocks base method.
+func (m *MockLicenceService) DeleteLicence(userID string, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLicence", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLicence indicates an expected call of DeleteLicence.
+func (mr *MockLicenceServiceMockRecorder) DeleteLicence(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLicence", reflect.TypeOf((*MockLicenceService)(nil).DeleteLicence), userID, id)
+}
+
+// GetExpirySummary mocks base method.
+func (m *MockLicenceService) GetExpirySummary(userID string) (dto.ExpirySummaryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetExpirySummary", userID)
+	ret0, _ := ret[0].(dto.ExpirySummaryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetExpirySummary indicates an expected call of GetExpirySummary.
+func (mr *MockLicenceServiceMockRecorder) GetExpirySummary(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetExpirySummary", reflect.TypeOf((*MockLicenceService)(nil).GetExpirySummary), userID)
+}
+
+// GetLicence mocks base method.
+func (m *MockLicenceService) GetLicence(userID string, id uint) (model.Licence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLicence", userID, id)
+	ret0, _ := ret[0].(model.Licence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLicence indicates an expected call of GetLicence.
+func (mr *MockLicenceServiceMockRecorder) GetLicence(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLicence", reflect.TypeOf((*MockLicenceService)(nil).GetLicence), userID, id)
+}
+
+// GetUserLicences mocks base method.
+func (m *MockLicenceService) GetUserLicences(userID string) ([]model.Licence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserLicences", userID)
+	ret0, _ := ret[0].([]model.Licence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserLicences indicates an expected call of GetUserLicences.
+func (mr *MockLicenceServiceMockRecorder) GetUserLicences(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserLicences", reflect.TypeOf((*MockLicenceService)(nil).GetUserLicences), userID)
+}
+
+// InsertLicence mocks base method.
+func (m *MockLicenceService) InsertLicence(userID string, licenceRequest dto.LicenceRequest) (model.Licence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertLicence", userID, licenceRequest)
+	ret0, _ := ret[0].(model.Licence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertLicence indicates an expected call of InsertLicence.
+func (mr *MockLicenceServiceMockRecorder) InsertLicence(userID, licenceRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertLicence", reflect.TypeOf((*MockLicenceService)(nil).InsertLicence), userID, licenceRequest)
+}
+
+// UpdateLicence mocks base method.
+func (m *MockLicenceService) UpdateLicence(userID string, id uint, licenceRequest dto.LicenceRequest) (model.Licence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLicence", userID, id, licenceRequest)
+	ret0, _ := ret[0].(model.Licence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateLicence indicates an expected call of UpdateLicence.
+func (mr *MockLicenceServiceMockRecorder) UpdateLicence(userID, id, licenceRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLicence", reflect.TypeOf((*MockLicenceService)(nil).UpdateLicence), userID, id, licenceRequest)
+}
@@ -0,0 +1,215 @@
+package service
+
+import (
+	"errors"
+	"github.com/avialog/backend/internal/config"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	"github.com/avialog/backend/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"time"
+)
+
+var _ = Describe("LicenceService", func() {
+	var (
+		licenceService    LicenceService
+		licenceRepoCtrl   *gomock.Controller
+		licenceRepoMock   *repository.MockLicenceRepository
+		ratingRepoCtrl    *gomock.Controller
+		ratingRepoMock    *repository.MockRatingRepository
+		now               time.Time
+		issuedAt          time.Time
+		licenceRequest    dto.LicenceRequest
+		mockLicence       model.Licence
+		mockInsertLicence model.Licence
+	)
+
+	BeforeEach(func() {
+		licenceRepoCtrl = gomock.NewController(GinkgoT())
+		licenceRepoMock = repository.NewMockLicenceRepository(licenceRepoCtrl)
+		ratingRepoCtrl = gomock.NewController(GinkgoT())
+		ratingRepoMock = repository.NewMockRatingRepository(ratingRepoCtrl)
+		now = time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+		licenceService = newLicenceService(licenceRepoMock, ratingRepoMock, config.Config{ExpiryWarning: 90 * 24 * time.Hour},
+			util.GetValidator(), func() time.Time { return now })
+
+		issuedAt = time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+		licenceRequest = dto.LicenceRequest{
+			Type:         model.LicenceTypePPL,
+			Number:       "PL.FCL.12345",
+			IssuingState: "PL",
+			IssuedAt:     &issuedAt,
+		}
+		mockInsertLicence = model.Licence{
+			UserID:       "1",
+			Type:         model.LicenceTypePPL,
+			Number:       "PL.FCL.12345",
+			IssuingState: "PL",
+			IssuedAt:     &issuedAt,
+		}
+		mockLicence = mockInsertLicence
+		mockLicence.ID = 2
+	})
+
+	AfterEach(func() {
+		licenceRepoCtrl.Finish()
+		ratingRepoCtrl.Finish()
+	})
+
+	Describe("InsertLicence", func() {
+		Context("when the licence is valid", func() {
+			It("should insert the licence", func() {
+				// given
+				licenceRepoMock.EXPECT().Create(mockInsertLicence).Return(mockLicence, nil)
+
+				// when
+				licence, err := licenceService.InsertLicence("1", licenceRequest)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(licence).To(Equal(mockLicence))
+			})
+		})
+		Context("when the licence type is unknown", func() {
+			It("should return bad request error", func() {
+				// given
+				licenceRequest.Type = "PILOT"
+
+				// when
+				_, err := licenceService.InsertLicence("1", licenceRequest)
+
+				// then
+				Expect(errors.Is(err, dto.ErrBadRequest)).To(BeTrue())
+				var validationError *dto.ValidationError
+				Expect(errors.As(err, &validationError)).To(BeTrue())
+				Expect(validationError.Errors).To(Equal([]dto.FieldError{{Field: "type", Code: dto.CodeInvalidValue}}))
+			})
+		})
+		Context("when the licence expires before it was issued", func() {
+			It("should return bad request error", func() {
+				// given
+				expiresAt := issuedAt.AddDate(0, 0, -1)
+				licenceRequest.ExpiresAt = &expiresAt
+
+				// when
+				_, err := licenceService.InsertLicence("1", licenceRequest)
+
+				// then
+				var validationError *dto.ValidationError
+				Expect(errors.As(err, &validationError)).To(BeTrue())
+				Expect(validationError.Errors).To(Equal([]dto.FieldError{{Field: "expires_at", Code: dto.CodeBeforeIssueDate}}))
+			})
+		})
+	})
+
+	Describe("UpdateLicence", func() {
+		Context("when the licence belongs to the user", func() {
+			It("should save the changes", func() {
+				// given
+				licenceRequest.Number = "PL.FCL.54321"
+				updatedLicence := mockLicence
+				updatedLicence.Number = "PL.FCL.54321"
+				licenceRepoMock.EXPECT().GetByUserIDAndID("1", uint(2)).Return(mockLicence, nil)
+				licenceRepoMock.EXPECT().Save(updatedLicence).Return(updatedLicence, nil)
+
+				// when
+				licence, err := licenceService.UpdateLicence("1", uint(2), licenceRequest)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(licence.Number).To(Equal("PL.FCL.54321"))
+			})
+		})
+		Context("when the licence does not exist", func() {
+			It("should return not found error", func() {
+				// given
+				licenceRepoMock.EXPECT().GetByUserIDAndID("1", uint(2)).
+					Return(model.Licence{}, dto.ErrNotFound)
+
+				// when
+				_, err := licenceService.UpdateLicence("1", uint(2), licenceRequest)
+
+				// then
+				Expect(errors.Is(err, dto.ErrNotFound)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("DeleteLicence", func() {
+		Context("when ratings are endorsed on the licence", func() {
+			It("should return conflict error", func() {
+				// given
+				ratingRepoMock.EXPECT().CountByUserIDAndLicenceID("1", uint(2)).Return(int64(1), nil)
+
+				// when
+				err := licenceService.DeleteLicence("1", uint(2))
+
+				// then
+				Expect(errors.Is(err, dto.ErrConflict)).To(BeTrue())
+			})
+		})
+		Context("when the licence has no ratings", func() {
+			It("should delete the licence", func() {
+				// given
+				ratingRepoMock.EXPECT().CountByUserIDAndLicenceID("1", uint(2)).Return(int64(0), nil)
+				licenceRepoMock.EXPECT().DeleteByUserIDAndID("1", uint(2)).Return(nil)
+
+				// when
+				err := licenceService.DeleteLicence("1", uint(2))
+
+				// then
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Describe("GetExpirySummary", func() {
+		Context("when the user has valid, expiring and lapsed items", func() {
+			It("should sort them by validity and expiry date", func() {
+				// given
+				sepExpiresAt := time.Date(2024, 6, 30, 23, 59, 59, 0, time.UTC)
+				irExpiresAt := time.Date(2024, 3, 31, 23, 59, 59, 0, time.UTC)
+				fiExpiresAt := time.Date(2026, 1, 31, 23, 59, 59, 0, time.UTC)
+				licenceRepoMock.EXPECT().GetByUserID("1").Return([]model.Licence{mockLicence}, nil)
+				ratingRepoMock.EXPECT().GetByUserID("1").Return([]model.Rating{
+					{Model: gorm.Model{ID: 3}, Kind: model.RatingKindInstructor, Name: "FI(A)", ExpiresAt: &fiExpiresAt},
+					{Model: gorm.Model{ID: 4}, Kind: model.RatingKindClass, Name: "SEP", ExpiresAt: &sepExpiresAt},
+					{Model: gorm.Model{ID: 5}, Kind: model.RatingKindInstrument, Name: "IR(A)", ExpiresAt: &irExpiresAt},
+				}, nil)
+
+				// when
+				summary, err := licenceService.GetExpirySummary("1")
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(summary.Valid).To(Equal([]dto.ExpiryItem{
+					{Type: dto.ExpiryItemTypeRating, ID: 3, Name: "FI(A)", ExpiresAt: &fiExpiresAt, DaysLeft: util.Int(651)},
+					{Type: dto.ExpiryItemTypeLicence, ID: 2, Name: "PPL PL.FCL.12345"},
+				}))
+				Expect(summary.ExpiringSoon).To(Equal([]dto.ExpiryItem{
+					{Type: dto.ExpiryItemTypeRating, ID: 4, Name: "SEP", ExpiresAt: &sepExpiresAt, DaysLeft: util.Int(71)},
+				}))
+				Expect(summary.Lapsed).To(Equal([]dto.ExpiryItem{
+					{Type: dto.ExpiryItemTypeRating, ID: 5, Name: "IR(A)", ExpiresAt: &irExpiresAt, DaysLeft: util.Int(-20)},
+				}))
+			})
+		})
+		Context("when getting the ratings fails", func() {
+			It("should return the error", func() {
+				// given
+				licenceRepoMock.EXPECT().GetByUserID("1").Return(nil, nil)
+				ratingRepoMock.EXPECT().GetByUserID("1").Return(nil, dto.ErrInternalFailure)
+
+				// when
+				_, err := licenceService.GetExpirySummary("1")
+
+				// then
+				Expect(errors.Is(err, dto.ErrInternalFailure)).To(BeTrue())
+			})
+		})
+	})
+})
@@ -1,356 +1,536 @@
 package service
 
 import (
+	"encoding/csv"
 	"errors"
 	"fmt"
 	"github.com/avialog/backend/internal/config"
 	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/infrastructure"
 	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/night"
 	"github.com/avialog/backend/internal/repository"
 	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
 	"time"
 )
 
+const maxSignatureSize = 5 << 20
+
+// csvExportPageSize is how many flights the CSV export loads at once.
+const csvExportPageSize = 500
+
+const (
+	defaultLogbookPageSize = 50
+	maxLogbookPageSize     = 200
+)
+
 //go:generate mockgen -source=logbook.go -destination=logbook_mock.go -package service
 type LogbookService interface {
 	InsertLogbookEntry(userID string, logbookRequest dto.LogbookRequest) (dto.LogbookResponse, error)
-	DeleteLogbookEntry(userID string, flightID uint) error
-	UpdateLogbookEntry(userID string, flightID uint, logbookRequest dto.LogbookRequest) (dto.LogbookResponse, error)
-	GetLogbookEntries(userID string, start, end time.Time) ([]dto.LogbookResponse, error)
+	DeleteLogbookEntry(userID string, flightID uint, version *time.Time) error
+	BatchLogbookEntries(userID string, batchRequest dto.LogbookBatchRequest) (dto.LogbookBatchResponse, error)
+	UpdateLogbookEntry(userID string, flightID uint, logbookRequest dto.LogbookRequest, version *time.Time) (dto.LogbookResponse, error)
+	AmendLogbookEntry(userID string, flightID uint, logbookRequest dto.LogbookRequest, version *time.Time) (dto.LogbookResponse, error)
+	GetLogbookEntry(userID string, flightID uint) (dto.LogbookResponse, error)
+	GetLogbookEntries(userID string, filter dto.LogbookFilter, limit *int, cursor *string) (dto.LogbookPageResponse, error)
+	GetLogbookTotals(userID string, filter dto.TotalsFilter) (dto.TotalsResponse, error)
+	ExportLogbookPDF(userID string) ([]byte, error)
+	ExportLogbookCSV(userID string, filter dto.ExportFilter, writer io.Writer) error
+	PreviewNightTime(route dto.FlightRoute) (dto.NightTimeResponse, error)
+	GetDuplicateEntries(userID string) ([]dto.DuplicateGroupResponse, error)
 }
 
 type logbookService struct {
-	flightRepository    repository.FlightRepository
-	landingRepository   repository.LandingRepository
-	passengerRepository repository.PassengerRepository
-	aircraftRepository  repository.AircraftRepository
-	validator           *validator.Validate
-	config              config.Config
+	flightRepository        repository.FlightRepository
+	landingRepository       repository.LandingRepository
+	passengerRepository     repository.PassengerRepository
+	aircraftRepository      repository.AircraftRepository
+	userRepository          repository.UserRepository
+	airportRepository       repository.AirportRepository
+	signatureRepository     repository.SignatureRepository
+	flightVersionRepository repository.FlightVersionRepository
+	trashRepository         repository.TrashRepository
+	httpClient              infrastructure.HTTPClient
+	validator               *validator.Validate
+	config                  config.Config
 }
 
 func newLogbookService(flightRepository repository.FlightRepository, landingRepository repository.LandingRepository,
 	passengerRepository repository.PassengerRepository, aircraftRepository repository.AircraftRepository,
-	config config.Config, validator *validator.Validate) LogbookService {
-	return &logbookService{flightRepository, landingRepository,
-		passengerRepository, aircraftRepository, validator, config}
+	userRepository repository.UserRepository, airportRepository repository.AirportRepository,
+	signatureRepository repository.SignatureRepository, flightVersionRepository repository.FlightVersionRepository,
+	trashRepository repository.TrashRepository, httpClient infrastructure.HTTPClient, config config.Config,
+	validator *validator.Validate) LogbookService {
+	return &logbookService{flightRepository, landingRepository, passengerRepository, aircraftRepository, userRepository,
+		airportRepository, signatureRepository, flightVersionRepository, trashRepository, httpClient, validator, config}
 }
 
 func (l *logbookService) InsertLogbookEntry(userID string, logbookRequest dto.LogbookRequest) (dto.LogbookResponse, error) {
-	var logbookResponse dto.LogbookResponse
-	landingEntries := make([]dto.LandingEntry, 0)
-	passengerEntries := make([]dto.PassengerEntry, 0)
+	write, err := l.prepareInsert(userID, logbookRequest)
+	if err != nil {
+		return dto.LogbookResponse{}, err
+	}
 
-	if _, err := l.aircraftRepository.GetByUserIDAndID(userID, logbookRequest.AircraftID); err != nil {
+	if err := l.commitLogbookWrite(userID, &write); err != nil {
+		return dto.LogbookResponse{}, err
+	}
+
+	return adaptLogbookResponse(write.flight, write.aircraft, write.landings, write.passengers, write.signature), nil
+}
+
+// logbookWrite is a checked change of an entry, ready to be applied in a transaction. The version, if any, is checked
+// again once the entry is locked in the transaction, and conflicts with other entries are checked in the transaction.
+type logbookWrite struct {
+	action         model.FlightVersionAction
+	flight         model.Flight
+	aircraft       model.Aircraft
+	passengers     []model.Passenger
+	landings       []model.Landing
+	signature      *model.Signature
+	version        *time.Time
+	allowConflicts bool
+}
+
+func (l *logbookService) prepareInsert(userID string, logbookRequest dto.LogbookRequest) (logbookWrite, error) {
+	aircraft, err := l.aircraftRepository.GetByUserIDAndID(userID, logbookRequest.AircraftID)
+	if err != nil {
 		if errors.Is(err, dto.ErrNotFound) {
-			return dto.LogbookResponse{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "aircraft does not belong to user")
+			return logbookWrite{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "aircraft does not belong to user")
 		}
-		return dto.LogbookResponse{}, err
+		return logbookWrite{}, err
+	}
+
+	if err := normalizeAirportCodes(l.airportRepository, &logbookRequest); err != nil {
+		return logbookWrite{}, err
+	}
+
+	if err := fillNightTime(l.airportRepository, &logbookRequest); err != nil {
+		return logbookWrite{}, err
+	}
+
+	if err := l.autoFillTimes(userID, &logbookRequest); err != nil {
+		return logbookWrite{}, err
+	}
+
+	flight, passengers, landings := newLogbookEntry(userID, logbookRequest.AircraftID, logbookRequest)
+	if err := l.validateLogbookEntry(flight, passengers, landings); err != nil {
+		return logbookWrite{}, err
 	}
 
+	return logbookWrite{action: model.FlightVersionActionCreated, flight: flight, aircraft: aircraft,
+		passengers: passengers, landings: landings, allowConflicts: logbookRequest.AllowConflicts}, nil
+}
+
+// commitLogbookWrite applies the write in its own transaction.
+func (l *logbookService) commitLogbookWrite(userID string, write *logbookWrite) error {
 	tx := l.flightRepository.Begin()
 
-	flight := model.Flight{
-		UserID:              userID,
-		AircraftID:          logbookRequest.AircraftID,
-		TakeoffTime:         logbookRequest.TakeoffTime,
-		TakeoffAirportCode:  logbookRequest.TakeoffAirportCode,
-		LandingTime:         logbookRequest.LandingTime,
-		LandingAirportCode:  logbookRequest.LandingAirportCode,
-		Style:               logbookRequest.Style,
-		Remarks:             logbookRequest.Remarks,
-		PersonalRemarks:     logbookRequest.PersonalRemarks,
-		TotalBlockTime:      logbookRequest.TotalBlockTime,
-		PilotInCommandTime:  logbookRequest.PilotInCommandTime,
-		SecondInCommandTime: logbookRequest.SecondInCommandTime,
-		DualReceivedTime:    logbookRequest.DualReceivedTime,
-		DualGivenTime:       logbookRequest.DualGivenTime,
-		MultiPilotTime:      logbookRequest.MultiPilotTime,
-		NightTime:           logbookRequest.NightTime,
-		IFRTime:             logbookRequest.IFRTime,
-		IFRActualTime:       logbookRequest.IFRActualTime,
-		IFRSimulatedTime:    logbookRequest.IFRSimulatedTime,
-		CrossCountryTime:    logbookRequest.CrossCountryTime,
-		SimulatorTime:       logbookRequest.SimulatorTime,
-		SignatureURL:        logbookRequest.SignatureURL,
-	}
-
-	err := l.validator.Struct(flight)
-	if err != nil {
-		var invalidValidationError *validator.InvalidValidationError
-		if errors.As(err, &invalidValidationError) {
-			return dto.LogbookResponse{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
-		}
-
-		var validationErrors validator.ValidationErrors
-		if errors.As(err, &validationErrors) {
-			if len(validationErrors) > 0 {
-				return dto.LogbookResponse{}, fmt.Errorf("%w: invalid data in field: %v", dto.ErrBadRequest, validationErrors[0].Field())
-			}
-		}
+	if err := l.flightVersionRepository.LockUserTx(tx, userID); err != nil {
+		tx.Rollback()
+		return err
 	}
 
-	insertedFlight, err := l.flightRepository.CreateTx(tx, flight)
-	if err != nil {
+	if err := l.applyLogbookWriteTx(tx, userID, write, time.Now()); err != nil {
 		tx.Rollback()
-		return dto.LogbookResponse{}, err
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
 	}
+	return nil
+}
 
-	for _, passengerEntry := range logbookRequest.Passengers {
-		passenger := model.Passenger{
-			FlightID:     insertedFlight.ID,
-			Role:         passengerEntry.Role,
-			FirstName:    passengerEntry.FirstName,
-			LastName:     passengerEntry.LastName,
-			Company:      passengerEntry.Company,
-			Phone:        passengerEntry.Phone,
-			EmailAddress: passengerEntry.EmailAddress,
-			Note:         passengerEntry.Note,
+// applyLogbookWriteTx stores the write and records it in the history of the logbook. The stored flight, passengers and
+// landings are set on the write. The transaction has to lock the user with LockUserTx first, so writes of the user are
+// checked for conflicts and lock their flights one after another, and cannot deadlock on the flights.
+func (l *logbookService) applyLogbookWriteTx(tx infrastructure.Database, userID string, write *logbookWrite,
+	recordedAt time.Time) error {
+	var err error
+	switch write.action {
+	case model.FlightVersionActionCreated:
+		if err := l.checkConflictsTx(tx, userID, 0, write.flight, write.allowConflicts); err != nil {
+			return err
 		}
 
-		err := l.validator.Struct(passenger)
+		write.flight, err = l.flightRepository.CreateTx(tx, write.flight)
 		if err != nil {
-			tx.Rollback()
-			var invalidValidationError *validator.InvalidValidationError
-			if errors.As(err, &invalidValidationError) {
-				return dto.LogbookResponse{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+			return err
+		}
+
+		for i := range write.passengers {
+			write.passengers[i].FlightID = write.flight.ID
+			write.passengers[i], err = l.passengerRepository.CreateTx(tx, write.passengers[i])
+			if err != nil {
+				return err
 			}
+		}
 
-			var validationErrors validator.ValidationErrors
-			if errors.As(err, &validationErrors) {
-				if len(validationErrors) > 0 {
-					return dto.LogbookResponse{}, fmt.Errorf("%w: invalid data in field: %v", dto.ErrBadRequest, validationErrors[0].Field())
-				}
+		for i := range write.landings {
+			write.landings[i].FlightID = write.flight.ID
+			write.landings[i], err = l.landingRepository.CreateTx(tx, write.landings[i])
+			if err != nil {
+				return err
 			}
 		}
+	case model.FlightVersionActionUpdated, model.FlightVersionActionAmended:
+		if err := l.lockLogbookEntryTx(tx, write); err != nil {
+			return err
+		}
+
+		if err := l.checkConflictsTx(tx, userID, write.flight.ID, write.flight, write.allowConflicts); err != nil {
+			return err
+		}
 
-		passenger, err = l.passengerRepository.CreateTx(tx, passenger)
+		write.flight, err = l.flightRepository.SaveTx(tx, write.flight)
 		if err != nil {
-			tx.Rollback()
-			return dto.LogbookResponse{}, err
+			return err
 		}
-		passengerEntries = append(passengerEntries, dto.PassengerEntry{
-			Role:         passenger.Role,
-			FirstName:    passenger.FirstName,
-			LastName:     passenger.LastName,
-			Company:      passenger.Company,
-			Phone:        passenger.Phone,
-			EmailAddress: passenger.EmailAddress,
-			Note:         passenger.Note,
-		})
-	}
 
-	for _, landingEntry := range logbookRequest.Landings {
-		landing := model.Landing{
-			FlightID:     insertedFlight.ID,
-			ApproachType: landingEntry.ApproachType,
-			Count:        landingEntry.Count,
-			NightCount:   landingEntry.NightCount,
-			DayCount:     landingEntry.DayCount,
-			AirportCode:  landingEntry.AirportCode,
+		if err := l.passengerRepository.DeleteByFlightIDTx(tx, write.flight.ID); err != nil {
+			return err
 		}
 
-		err := l.validator.Struct(landing)
-		if err != nil {
-			tx.Rollback()
-			var invalidValidationError *validator.InvalidValidationError
-			if errors.As(err, &invalidValidationError) {
-				return dto.LogbookResponse{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+		for i := range write.passengers {
+			write.passengers[i].FlightID = write.flight.ID
+			write.passengers[i], err = l.passengerRepository.CreateTx(tx, write.passengers[i])
+			if err != nil {
+				return err
 			}
+		}
+
+		if err := l.landingRepository.DeleteByFlightIDTx(tx, write.flight.ID); err != nil {
+			return err
+		}
 
-			var validationErrors validator.ValidationErrors
-			if errors.As(err, &validationErrors) {
-				if len(validationErrors) > 0 {
-					return dto.LogbookResponse{}, fmt.Errorf("%w: invalid data in field: %v", dto.ErrBadRequest, validationErrors[0].Field())
-				}
+		if write.action == model.FlightVersionActionAmended && write.signature != nil {
+			if err := l.signatureRepository.InvalidateByFlightIDTx(tx, write.flight.ID); err != nil {
+				return err
+			}
+			if write.signature.Status == model.SignatureStatusPending || write.signature.Status == model.SignatureStatusSigned {
+				write.signature.Status = model.SignatureStatusInvalidated
 			}
 		}
 
-		landing, err = l.landingRepository.CreateTx(tx, landing)
-		if err != nil {
-			tx.Rollback()
-			return dto.LogbookResponse{}, err
+		for i := range write.landings {
+			write.landings[i].FlightID = write.flight.ID
+			write.landings[i], err = l.landingRepository.CreateTx(tx, write.landings[i])
+			if err != nil {
+				return err
+			}
+		}
+	case model.FlightVersionActionDeleted:
+		if err := l.lockLogbookEntryTx(tx, write); err != nil {
+			return err
 		}
-		landingEntries = append(landingEntries, dto.LandingEntry{
-			ApproachType: landing.ApproachType,
-			Count:        landing.Count,
-			NightCount:   landing.NightCount,
-			DayCount:     landing.DayCount,
-			AirportCode:  landing.AirportCode,
-		})
-	}
 
-	logbookResponse = dto.LogbookResponse{
-		AircraftID:          insertedFlight.AircraftID,
-		TakeoffTime:         insertedFlight.TakeoffTime,
-		TakeoffAirportCode:  insertedFlight.TakeoffAirportCode,
-		LandingTime:         insertedFlight.LandingTime,
-		LandingAirportCode:  insertedFlight.LandingAirportCode,
-		Style:               insertedFlight.Style,
-		Remarks:             insertedFlight.Remarks,
-		PersonalRemarks:     insertedFlight.PersonalRemarks,
-		TotalBlockTime:      insertedFlight.TotalBlockTime,
-		PilotInCommandTime:  insertedFlight.PilotInCommandTime,
-		SecondInCommandTime: insertedFlight.SecondInCommandTime,
-		DualReceivedTime:    insertedFlight.DualReceivedTime,
-		DualGivenTime:       insertedFlight.DualGivenTime,
-		MultiPilotTime:      insertedFlight.MultiPilotTime,
-		NightTime:           insertedFlight.NightTime,
-		IFRTime:             insertedFlight.IFRTime,
-		IFRActualTime:       insertedFlight.IFRActualTime,
-		IFRSimulatedTime:    insertedFlight.IFRSimulatedTime,
-		CrossCountryTime:    insertedFlight.CrossCountryTime,
-		SimulatorTime:       insertedFlight.SimulatorTime,
-		SignatureURL:        insertedFlight.SignatureURL,
-		Passengers:          passengerEntries,
-		Landings:            landingEntries,
-	}
-	if err := tx.Commit().Error; err != nil {
-		return dto.LogbookResponse{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+		if err := l.trashRepository.DeleteFlightTx(tx, write.flight.ID, recordedAt); err != nil {
+			if errors.Is(err, dto.ErrNotFound) {
+				return fmt.Errorf("%w: %v", dto.ErrNotFound, "flight not found")
+			}
+
+			return fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+		}
+	default:
+		return fmt.Errorf("%w: unknown logbook write %v", dto.ErrInternalFailure, write.action)
 	}
 
-	return logbookResponse, nil
+	return recordFlightVersions(l.flightVersionRepository, tx, userID, recordedAt, flightChange{
+		flightID: write.flight.ID,
+		action:   write.action,
+		snapshot: newLogbookSnapshot(write.flight, write.passengers, write.landings),
+	})
 }
 
-func (l *logbookService) DeleteLogbookEntry(userID string, flightID uint) error {
-	flight, err := l.flightRepository.GetByID(flightID)
+// lockLogbookEntryTx locks the flight of the write until the end of the transaction and checks the write again against
+// the entry as it is now, another request may have changed or signed the entry since the write was prepared. Signing
+// locks the flight too, so the entry cannot be signed until the write is committed.
+func (l *logbookService) lockLogbookEntryTx(tx infrastructure.Database, write *logbookWrite) error {
+	flight, err := l.flightRepository.GetByIDForUpdateTx(tx, write.flight.ID)
 	if err != nil {
 		if errors.Is(err, dto.ErrNotFound) {
-			return fmt.Errorf("%w: %v", dto.ErrBadRequest, "flight not found")
+			return fmt.Errorf("%w: %v", dto.ErrNotFound, "flight not found")
 		}
 		return err
 	}
 
-	if flight.UserID != userID {
-		return fmt.Errorf("%w: %v", dto.ErrBadRequest, "flight does not belong to user")
+	signature, err := l.signatureRepository.GetLatestByFlightIDTx(tx, write.flight.ID)
+	if err != nil && !errors.Is(err, dto.ErrNotFound) {
+		return err
+	}
+	write.signature = nil
+	if err == nil {
+		write.signature = &signature
 	}
 
-	tx := l.flightRepository.Begin()
-
-	if err := l.landingRepository.DeleteByFlightIDTx(tx, flightID); err != nil {
-		tx.Rollback()
+	if err := checkVersion(write.version, logbookEntryVersion(flight, write.signature), "flight"); err != nil {
 		return err
 	}
 
-	if err := l.passengerRepository.DeleteByFlightIDTx(tx, flightID); err != nil {
-		tx.Rollback()
+	if write.signature != nil && write.signature.Status == model.SignatureStatusSigned {
+		switch write.action {
+		case model.FlightVersionActionUpdated:
+			return fmt.Errorf("%w: %v", dto.ErrConflict, "flight is signed, changes must be amended")
+		case model.FlightVersionActionDeleted:
+			return fmt.Errorf("%w: %v", dto.ErrConflict, "flight is signed and cannot be deleted")
+		}
+	}
+	return nil
+}
+
+// validateLogbookEntry reports all invalid fields of the entry as a bad request.
+func (l *logbookService) validateLogbookEntry(flight model.Flight, passengers []model.Passenger, landings []model.Landing) error {
+	err := validateLogbookEntry(l.validator, flight, passengers, landings)
+	var validationError *dto.ValidationError
+	if errors.As(err, &validationError) {
+		return fmt.Errorf("%w: %w", dto.ErrBadRequest, validationError)
+	}
+	return err
+}
+
+// getLatestSignature returns the most recent signature request of the flight, or nil if the flight has none.
+func (l *logbookService) getLatestSignature(flightID uint) (*model.Signature, error) {
+	signature, err := l.signatureRepository.GetLatestByFlightID(flightID)
+	if err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &signature, nil
+}
+
+func (l *logbookService) DeleteLogbookEntry(userID string, flightID uint, version *time.Time) error {
+	write, err := l.prepareDelete(userID, flightID, version)
+	if err != nil {
 		return err
 	}
 
-	if err := l.flightRepository.DeleteByIDTx(tx, flightID); err != nil {
-		tx.Rollback()
+	return l.commitLogbookWrite(userID, &write)
+}
+
+func (l *logbookService) prepareDelete(userID string, flightID uint, version *time.Time) (logbookWrite, error) {
+	flight, err := l.flightRepository.GetByID(flightID)
+	if err != nil {
 		if errors.Is(err, dto.ErrNotFound) {
-			return fmt.Errorf("%w: %v", dto.ErrNotFound, "flight not found")
+			return logbookWrite{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "flight not found")
 		}
+		return logbookWrite{}, err
+	}
 
-		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+	if flight.UserID != userID {
+		return logbookWrite{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "flight does not belong to user")
 	}
-	if err := tx.Commit().Error; err != nil {
-		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+
+	signature, err := l.getLatestSignature(flightID)
+	if err != nil {
+		return logbookWrite{}, err
 	}
-	return nil
+
+	if err := checkVersion(version, logbookEntryVersion(flight, signature), "flight"); err != nil {
+		return logbookWrite{}, err
+	}
+
+	if signature != nil && signature.Status == model.SignatureStatusSigned {
+		return logbookWrite{}, fmt.Errorf("%w: %v", dto.ErrConflict, "flight is signed and cannot be deleted")
+	}
+
+	landings, err := l.landingRepository.GetByFlightID(flightID)
+	if err != nil {
+		return logbookWrite{}, err
+	}
+
+	passengers, err := l.passengerRepository.GetByFlightID(flightID)
+	if err != nil {
+		return logbookWrite{}, err
+	}
+
+	return logbookWrite{action: model.FlightVersionActionDeleted, flight: flight, passengers: passengers,
+		landings: landings, signature: signature, version: version}, nil
 }
 
-func (l *logbookService) GetLogbookEntries(userID string, start, end time.Time) ([]dto.LogbookResponse, error) {
-	logbookResponses := make([]dto.LogbookResponse, 0)
+func (l *logbookService) GetLogbookEntry(userID string, flightID uint) (dto.LogbookResponse, error) {
+	flight, err := l.flightRepository.GetByID(flightID)
+	if err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			return dto.LogbookResponse{}, fmt.Errorf("%w: %v", dto.ErrNotFound, "flight not found")
+		}
+		return dto.LogbookResponse{}, err
+	}
 
-	flights, err := l.flightRepository.GetByUserIDAndDate(userID, start, end)
+	if flight.UserID != userID {
+		return dto.LogbookResponse{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "flight does not belong to user")
+	}
+
+	entries, err := l.loadLogbookEntries([]model.Flight{flight})
 	if err != nil {
-		return logbookResponses, err
+		return dto.LogbookResponse{}, err
 	}
 
-	for _, flight := range flights {
-		landings, err := l.landingRepository.GetByFlightID(flight.ID)
-		if err != nil {
-			return logbookResponses, err
+	return adaptLogbookResponse(flight, entries.aircraftByID[flight.AircraftID], entries.landingsByFlightID[flight.ID],
+		entries.passengersByFlightID[flight.ID], entries.signatureByFlightID[flight.ID]), nil
+}
+
+// GetLogbookEntries returns a page of entries matching the filter. The cursor is the next cursor of the previous page,
+// or nil for the first page.
+func (l *logbookService) GetLogbookEntries(userID string, filter dto.LogbookFilter, limit *int,
+	cursor *string) (dto.LogbookPageResponse, error) {
+	pageSize := defaultLogbookPageSize
+	if limit != nil {
+		if *limit < 1 || *limit > maxLogbookPageSize {
+			return dto.LogbookPageResponse{}, fmt.Errorf("%w: limit must be between 1 and %d", dto.ErrBadRequest, maxLogbookPageSize)
 		}
+		pageSize = *limit
+	}
+
+	if filter.Order == "" {
+		filter.Order = dto.SortDescending
+	} else if filter.Order != dto.SortAscending && filter.Order != dto.SortDescending {
+		return dto.LogbookPageResponse{}, fmt.Errorf("%w: invalid order: %v", dto.ErrBadRequest, filter.Order)
+	}
+
+	if filter.Role != nil && !slices.Contains(model.AvailableRoles, *filter.Role) {
+		return dto.LogbookPageResponse{}, fmt.Errorf("%w: invalid role: %v", dto.ErrBadRequest, *filter.Role)
+	}
+
+	if filter.Style != nil && !slices.Contains(model.AvailableStyles, *filter.Style) {
+		return dto.LogbookPageResponse{}, fmt.Errorf("%w: invalid style: %v", dto.ErrBadRequest, *filter.Style)
+	}
 
-		landingEntries := make([]dto.LandingEntry, 0)
-		for _, landing := range landings {
-			landingEntries = append(landingEntries, dto.LandingEntry{
-				ApproachType: landing.ApproachType,
-				Count:        landing.Count,
-				NightCount:   landing.NightCount,
-				DayCount:     landing.DayCount,
-				AirportCode:  landing.AirportCode,
-			})
+	if filter.Start != nil && filter.End != nil && filter.End.Before(*filter.Start) {
+		return dto.LogbookPageResponse{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "end time must not be before start time")
+	}
+
+	if filter.AirportCode != nil {
+		code := strings.ToUpper(strings.TrimSpace(*filter.AirportCode))
+		// flights store ICAO codes, unknown codes are searched as they are
+		if airport, err := l.airportRepository.GetByCode(code); err == nil {
+			code = airport.ICAOCode
 		}
+		filter.AirportCode = &code
+	}
 
-		passengers, err := l.passengerRepository.GetByFlightID(flight.ID)
+	var after *dto.LogbookCursor
+	if cursor != nil {
+		decodedCursor, err := decodeLogbookCursor(*cursor)
 		if err != nil {
-			return logbookResponses, err
-		}
-
-		passengerEntries := make([]dto.PassengerEntry, 0)
-
-		for _, passenger := range passengers {
-			passengerEntries = append(passengerEntries, dto.PassengerEntry{
-				Role:         passenger.Role,
-				FirstName:    passenger.FirstName,
-				LastName:     passenger.LastName,
-				Company:      passenger.Company,
-				Phone:        passenger.Phone,
-				EmailAddress: passenger.EmailAddress,
-				Note:         passenger.Note,
-			})
-		}
-
-		logbookResponse := dto.LogbookResponse{
-			AircraftID:          flight.AircraftID,
-			TakeoffTime:         flight.TakeoffTime,
-			TakeoffAirportCode:  flight.TakeoffAirportCode,
-			LandingTime:         flight.LandingTime,
-			LandingAirportCode:  flight.LandingAirportCode,
-			Style:               flight.Style,
-			Remarks:             flight.Remarks,
-			PersonalRemarks:     flight.PersonalRemarks,
-			TotalBlockTime:      flight.TotalBlockTime,
-			PilotInCommandTime:  flight.PilotInCommandTime,
-			SecondInCommandTime: flight.SecondInCommandTime,
-			DualReceivedTime:    flight.DualReceivedTime,
-			DualGivenTime:       flight.DualGivenTime,
-			MultiPilotTime:      flight.MultiPilotTime,
-			NightTime:           flight.NightTime,
-			IFRTime:             flight.IFRTime,
-			IFRActualTime:       flight.IFRActualTime,
-			IFRSimulatedTime:    flight.IFRSimulatedTime,
-			CrossCountryTime:    flight.CrossCountryTime,
-			SimulatorTime:       flight.SimulatorTime,
-			SignatureURL:        flight.SignatureURL,
-			Passengers:          passengerEntries,
-			Landings:            landingEntries,
-		}
-
-		logbookResponses = append(logbookResponses, logbookResponse)
-	}
-
-	return logbookResponses, nil
+			return dto.LogbookPageResponse{}, fmt.Errorf("%w: invalid cursor", dto.ErrBadRequest)
+		}
+		after = &decodedCursor
+	}
+
+	// one more flight is fetched to know whether there is a next page
+	flights, err := l.flightRepository.GetPageByUserID(userID, filter, after, pageSize+1)
+	if err != nil {
+		return dto.LogbookPageResponse{}, err
+	}
+
+	page := dto.LogbookPageResponse{
+		Entries: make([]dto.LogbookResponse, 0, min(len(flights), pageSize)),
+		Paging:  dto.PagingResponse{Limit: pageSize},
+	}
+	if len(flights) > pageSize {
+		flights = flights[:pageSize]
+		last := flights[len(flights)-1]
+		nextCursor := encodeLogbookCursor(dto.LogbookCursor{TakeoffTime: last.TakeoffTime, ID: last.ID})
+		page.Paging.HasMore = true
+		page.Paging.NextCursor = &nextCursor
+	}
+
+	entries, err := l.loadLogbookEntries(flights)
+	if err != nil {
+		return dto.LogbookPageResponse{}, err
+	}
+
+	for _, flight := range flights {
+		page.Entries = append(page.Entries, adaptLogbookResponse(flight, entries.aircraftByID[flight.AircraftID],
+			entries.landingsByFlightID[flight.ID], entries.passengersByFlightID[flight.ID],
+			entries.signatureByFlightID[flight.ID]))
+	}
+
+	return page, nil
 }
 
-func (l *logbookService) UpdateLogbookEntry(userID string, flightID uint, logbookRequest dto.LogbookRequest) (dto.LogbookResponse, error) {
-	var logbookResponse dto.LogbookResponse
-	landingEntries := make([]dto.LandingEntry, 0)
-	passengerEntries := make([]dto.PassengerEntry, 0)
+// UpdateLogbookEntry changes the entry if it is still at the given version, or regardless of its version if none is
+// given.
+func (l *logbookService) UpdateLogbookEntry(userID string, flightID uint, logbookRequest dto.LogbookRequest,
+	version *time.Time) (dto.LogbookResponse, error) {
+	return l.updateLogbookEntry(userID, flightID, logbookRequest, version, false)
+}
 
+// AmendLogbookEntry changes an entry regardless of its signature, the signature is invalidated and has to be requested
+// again. Like an update, it can be limited to the given version of the entry.
+func (l *logbookService) AmendLogbookEntry(userID string, flightID uint, logbookRequest dto.LogbookRequest,
+	version *time.Time) (dto.LogbookResponse, error) {
+	return l.updateLogbookEntry(userID, flightID, logbookRequest, version, true)
+}
+
+func (l *logbookService) updateLogbookEntry(userID string, flightID uint, logbookRequest dto.LogbookRequest,
+	version *time.Time, amend bool) (dto.LogbookResponse, error) {
+	write, err := l.prepareUpdate(userID, flightID, logbookRequest, version, amend)
+	if err != nil {
+		return dto.LogbookResponse{}, err
+	}
+
+	if err := l.commitLogbookWrite(userID, &write); err != nil {
+		return dto.LogbookResponse{}, err
+	}
+
+	return adaptLogbookResponse(write.flight, write.aircraft, write.landings, write.passengers, write.signature), nil
+}
+
+func (l *logbookService) prepareUpdate(userID string, flightID uint, logbookRequest dto.LogbookRequest,
+	version *time.Time, amend bool) (logbookWrite, error) {
 	flight, err := l.flightRepository.GetByID(flightID)
 	if err != nil {
 		if errors.Is(err, dto.ErrNotFound) {
-			return dto.LogbookResponse{}, fmt.Errorf("%w: %v", dto.ErrNotFound, "flight not found")
+			return logbookWrite{}, fmt.Errorf("%w: %v", dto.ErrNotFound, "flight not found")
 		}
-		return dto.LogbookResponse{}, err
+		return logbookWrite{}, err
 	}
 
 	if flight.UserID != userID {
-		return dto.LogbookResponse{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "flight does not belong to user")
+		return logbookWrite{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "flight does not belong to user")
+	}
+
+	signature, err := l.getLatestSignature(flightID)
+	if err != nil {
+		return logbookWrite{}, err
 	}
 
-	if _, err := l.aircraftRepository.GetByUserIDAndID(userID, logbookRequest.AircraftID); err != nil {
+	if err := checkVersion(version, logbookEntryVersion(flight, signature), "flight"); err != nil {
+		return logbookWrite{}, err
+	}
+
+	if !amend && signature != nil && signature.Status == model.SignatureStatusSigned {
+		return logbookWrite{}, fmt.Errorf("%w: %v", dto.ErrConflict, "flight is signed, changes must be amended")
+	}
+
+	aircraft, err := l.aircraftRepository.GetByUserIDAndID(userID, logbookRequest.AircraftID)
+	if err != nil {
 		if errors.Is(err, dto.ErrNotFound) {
-			return dto.LogbookResponse{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "aircraft does not belong to user")
+			return logbookWrite{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "aircraft does not belong to user")
 		}
-		return dto.LogbookResponse{}, err
+		return logbookWrite{}, err
 	}
 
-	tx := l.flightRepository.Begin()
+	if err := normalizeAirportCodes(l.airportRepository, &logbookRequest); err != nil {
+		return logbookWrite{}, err
+	}
+
+	if err := fillNightTime(l.airportRepository, &logbookRequest); err != nil {
+		return logbookWrite{}, err
+	}
+
+	if err := l.autoFillTimes(userID, &logbookRequest); err != nil {
+		return logbookWrite{}, err
+	}
 
 	flight.AircraftID = logbookRequest.AircraftID
 	flight.TakeoffTime = logbookRequest.TakeoffTime
@@ -358,6 +538,7 @@ func (l *logbookService) UpdateLogbookEntry(userID string, flightID uint, logboo
 	flight.LandingTime = logbookRequest.LandingTime
 	flight.LandingAirportCode = logbookRequest.LandingAirportCode
 	flight.Style = logbookRequest.Style
+	flight.MyRole = logbookRequest.MyRole
 	flight.Remarks = logbookRequest.Remarks
 	flight.PersonalRemarks = logbookRequest.PersonalRemarks
 	flight.TotalBlockTime = logbookRequest.TotalBlockTime
@@ -372,112 +553,274 @@ func (l *logbookService) UpdateLogbookEntry(userID string, flightID uint, logboo
 	flight.IFRSimulatedTime = logbookRequest.IFRSimulatedTime
 	flight.CrossCountryTime = logbookRequest.CrossCountryTime
 	flight.SimulatorTime = logbookRequest.SimulatorTime
-	flight.SignatureURL = logbookRequest.SignatureURL
+	flight.Holdings = logbookRequest.Holdings
 
-	err = l.validator.Struct(flight)
-	if err != nil {
-		var invalidValidationError *validator.InvalidValidationError
-		if errors.As(err, &invalidValidationError) {
-			return dto.LogbookResponse{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
-		}
+	_, passengers, landings := newLogbookEntry(userID, flight.AircraftID, logbookRequest)
+	if err := l.validateLogbookEntry(flight, passengers, landings); err != nil {
+		return logbookWrite{}, err
+	}
 
-		var validationErrors validator.ValidationErrors
-		if errors.As(err, &validationErrors) {
-			if len(validationErrors) > 0 {
-				return dto.LogbookResponse{}, fmt.Errorf("%w: invalid data in field: %v", dto.ErrBadRequest, validationErrors[0].Field())
+	action := model.FlightVersionActionUpdated
+	if amend {
+		action = model.FlightVersionActionAmended
+	}
+	return logbookWrite{action: action, flight: flight, aircraft: aircraft, passengers: passengers, landings: landings,
+		signature: signature, version: version, allowConflicts: logbookRequest.AllowConflicts}, nil
+}
+
+func (l *logbookService) GetLogbookTotals(userID string, filter dto.TotalsFilter) (dto.TotalsResponse, error) {
+	if filter.Role != nil && !slices.Contains(model.AvailableRoles, *filter.Role) {
+		return dto.TotalsResponse{}, fmt.Errorf("%w: invalid role: %v", dto.ErrBadRequest, *filter.Role)
+	}
+
+	if filter.Start != nil && filter.End != nil && filter.End.Before(*filter.Start) {
+		return dto.TotalsResponse{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "end time must not be before start time")
+	}
+
+	if filter.AircraftID != nil {
+		if _, err := l.aircraftRepository.GetByUserIDAndID(userID, *filter.AircraftID); err != nil {
+			if errors.Is(err, dto.ErrNotFound) {
+				return dto.TotalsResponse{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "aircraft does not belong to user")
 			}
+			return dto.TotalsResponse{}, err
 		}
 	}
 
-	if _, err := l.flightRepository.SaveTx(tx, flight); err != nil {
-		tx.Rollback()
-		return dto.LogbookResponse{}, err
+	return l.flightRepository.GetTotalsByUserID(userID, filter)
+}
+
+// autoFillTimes fills in block time and time columns of the role unless the user disabled it.
+func (l *logbookService) autoFillTimes(userID string, logbookRequest *dto.LogbookRequest) error {
+	if !needsRoleTimes(*logbookRequest) {
+		return nil
 	}
 
-	if err := l.passengerRepository.DeleteByFlightIDTx(tx, flightID); err != nil {
-		tx.Rollback()
-		return dto.LogbookResponse{}, err
+	user, err := l.userRepository.GetByID(userID)
+	if err != nil {
+		return err
+	}
+	if user.AutoFillTimes {
+		fillRoleTimes(logbookRequest)
+	}
+	return nil
+}
+
+// PreviewNightTime computes the night time and the split of landings which would be filled in for a flight saved
+// without them.
+func (l *logbookService) PreviewNightTime(route dto.FlightRoute) (dto.NightTimeResponse, error) {
+	if strings.TrimSpace(route.TakeoffAirportCode) == "" || strings.TrimSpace(route.LandingAirportCode) == "" {
+		return dto.NightTimeResponse{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "takeoff and landing airport code must be provided")
+	}
+	if route.TakeoffTime.IsZero() || route.LandingTime.IsZero() {
+		return dto.NightTimeResponse{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "takeoff and landing time must be provided")
+	}
+	if route.LandingTime.Before(route.TakeoffTime) {
+		return dto.NightTimeResponse{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "landing time must not be before takeoff time")
+	}
+
+	takeoffAirport, err := getAirport(l.airportRepository, route.TakeoffAirportCode)
+	if err != nil {
+		return dto.NightTimeResponse{}, err
+	}
+	landingAirport, err := getAirport(l.airportRepository, route.LandingAirportCode)
+	if err != nil {
+		return dto.NightTimeResponse{}, err
+	}
+
+	dayLandings, nightLandings := splitLandings(landingAirport, route.LandingTime, route.Landings)
+	return dto.NightTimeResponse{
+		TakeoffAirportCode: takeoffAirport.ICAOCode,
+		LandingAirportCode: landingAirport.ICAOCode,
+		NightTime: night.Duration(airportPosition(takeoffAirport), route.TakeoffTime,
+			airportPosition(landingAirport), route.LandingTime),
+		NightLanding:  night.IsNight(route.LandingTime, airportPosition(landingAirport)),
+		DayLandings:   dayLandings,
+		NightLandings: nightLandings,
+	}, nil
+}
+
+func (l *logbookService) ExportLogbookPDF(userID string) ([]byte, error) {
+	user, err := l.userRepository.GetByID(userID)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, passengerEntry := range logbookRequest.Passengers {
-		passenger := model.Passenger{
-			FlightID:     flight.ID,
-			Role:         passengerEntry.Role,
-			FirstName:    passengerEntry.FirstName,
-			LastName:     passengerEntry.LastName,
-			Company:      passengerEntry.Company,
-			Phone:        passengerEntry.Phone,
-			EmailAddress: passengerEntry.EmailAddress,
-			Note:         passengerEntry.Note,
+	export, err := l.loadLogbookExport(userID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]fcl050Entry, 0, len(export.flights))
+	for _, flight := range export.flights {
+		entries = append(entries, newFCL050Entry(flight, export.aircraftByID[flight.AircraftID],
+			export.landingsByFlightID[flight.ID], export.passengersByFlightID[flight.ID]))
+	}
+
+	var signature []byte
+	var signatureType string
+	if user.SignatureURL != nil && *user.SignatureURL != "" {
+		signature, signatureType, err = l.downloadSignature(*user.SignatureURL)
+		if err != nil {
+			// the logbook is still valid without the signature, the pilot can sign the printout by hand
+			logrus.Warnf("failed to download signature of user %s: %v", userID, err)
+			signature = nil
 		}
+	}
+
+	document, err := renderFCL050(pilotName(user), entries, signature, signatureType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+	}
 
-		err = l.validator.Struct(passenger)
+	return document, nil
+}
+
+func (l *logbookService) ExportLogbookCSV(userID string, filter dto.ExportFilter, writer io.Writer) error {
+	if filter.Start != nil && filter.End != nil && filter.End.Before(*filter.Start) {
+		return fmt.Errorf("%w: %v", dto.ErrBadRequest, "end time must not be before start time")
+	}
+
+	columns, err := selectCSVColumns(filter.Columns)
+	if err != nil {
+		return err
+	}
+
+	csvWriter := csv.NewWriter(writer)
+	if err := writeCSVHeader(csvWriter, columns); err != nil {
+		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+	}
+
+	// flights are loaded and written a page at a time, so the export is never held in memory as a whole
+	logbookFilter := dto.LogbookFilter{Start: filter.Start, End: filter.End, Order: dto.SortAscending}
+	var after *dto.LogbookCursor
+	for {
+		flights, err := l.flightRepository.GetPageByUserID(userID, logbookFilter, after, csvExportPageSize)
 		if err != nil {
-			tx.Rollback()
-			var invalidValidationError *validator.InvalidValidationError
-			if errors.As(err, &invalidValidationError) {
-				return dto.LogbookResponse{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+			return err
+		}
+
+		if len(flights) > 0 {
+			export, err := l.loadLogbookEntries(flights)
+			if err != nil {
+				return err
 			}
 
-			var validationErrors validator.ValidationErrors
-			if errors.As(err, &validationErrors) {
-				if len(validationErrors) > 0 {
-					return dto.LogbookResponse{}, fmt.Errorf("%w: invalid data in field: %v", dto.ErrBadRequest, validationErrors[0].Field())
-				}
+			entries := make([]csvEntry, 0, len(flights))
+			for _, flight := range flights {
+				entries = append(entries, csvEntry{
+					flight:     flight,
+					aircraft:   export.aircraftByID[flight.AircraftID],
+					landings:   export.landingsByFlightID[flight.ID],
+					passengers: export.passengersByFlightID[flight.ID],
+				})
+			}
+			if err := writeCSVEntries(csvWriter, columns, entries); err != nil {
+				return fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
 			}
 		}
 
-		if _, err := l.passengerRepository.CreateTx(tx, passenger); err != nil {
-			tx.Rollback()
-			return dto.LogbookResponse{}, err
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			return fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
 		}
-		passengerEntries = append(passengerEntries, dto.PassengerEntry{
-			Role:         passenger.Role,
-			FirstName:    passenger.FirstName,
-			LastName:     passenger.LastName,
-			Company:      passenger.Company,
-			Phone:        passenger.Phone,
-			EmailAddress: passenger.EmailAddress,
-			Note:         passenger.Note,
-		})
+
+		if len(flights) < csvExportPageSize {
+			return nil
+		}
+		last := flights[len(flights)-1]
+		after = &dto.LogbookCursor{TakeoffTime: last.TakeoffTime, ID: last.ID}
 	}
+}
 
-	if err := l.landingRepository.DeleteByFlightIDTx(tx, flightID); err != nil {
-		tx.Rollback()
-		return dto.LogbookResponse{}, err
+// logbookEntries holds flights of a user together with their aircraft, landings and passengers, which are loaded in a
+// constant number of queries regardless of the number of flights.
+type logbookEntries struct {
+	flights              []model.Flight
+	aircraftByID         map[uint]model.Aircraft
+	landingsByFlightID   map[uint][]model.Landing
+	passengersByFlightID map[uint][]model.Passenger
+	// signatureByFlightID holds the most recent signature request of flights which have one
+	signatureByFlightID map[uint]*model.Signature
+}
+
+func (l *logbookService) loadLogbookExport(userID string, start, end *time.Time) (logbookEntries, error) {
+	flights, err := l.flightRepository.GetByUserIDOrderedByTakeoffTime(userID, start, end)
+	if err != nil {
+		return logbookEntries{}, err
 	}
 
-	for _, landingEntry := range logbookRequest.Landings {
-		landing := model.Landing{
-			FlightID:     flight.ID,
-			ApproachType: landingEntry.ApproachType,
-			Count:        landingEntry.Count,
-			NightCount:   landingEntry.NightCount,
-			DayCount:     landingEntry.DayCount,
-			AirportCode:  landingEntry.AirportCode,
-		}
+	return l.loadLogbookEntries(flights)
+}
 
-		err = l.validator.Struct(landing)
-		if err != nil {
-			tx.Rollback()
-			var invalidValidationError *validator.InvalidValidationError
-			if errors.As(err, &invalidValidationError) {
-				return dto.LogbookResponse{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
-			}
+func (l *logbookService) loadLogbookEntries(flights []model.Flight) (logbookEntries, error) {
+	return loadLogbookEntries(l.aircraftRepository, l.landingRepository, l.passengerRepository, l.signatureRepository,
+		flights)
+}
 
-			var validationErrors validator.ValidationErrors
-			if errors.As(err, &validationErrors) {
-				if len(validationErrors) > 0 {
-					return dto.LogbookResponse{}, fmt.Errorf("%w: invalid data in field: %v", dto.ErrBadRequest, validationErrors[0].Field())
-				}
-			}
+func loadLogbookEntries(aircraftRepository repository.AircraftRepository, landingRepository repository.LandingRepository,
+	passengerRepository repository.PassengerRepository, signatureRepository repository.SignatureRepository,
+	flights []model.Flight) (logbookEntries, error) {
+	flightIDs := make([]uint, 0, len(flights))
+	aircraftIDs := make([]uint, 0)
+	seenAircraftIDs := make(map[uint]bool)
+	for _, flight := range flights {
+		flightIDs = append(flightIDs, flight.ID)
+		if !seenAircraftIDs[flight.AircraftID] {
+			seenAircraftIDs[flight.AircraftID] = true
+			aircraftIDs = append(aircraftIDs, flight.AircraftID)
 		}
+	}
 
-		if _, err := l.landingRepository.CreateTx(tx, landing); err != nil {
-			tx.Rollback()
-			return dto.LogbookResponse{}, err
-		}
-		landingEntries = append(landingEntries, dto.LandingEntry{
+	aircraft, err := aircraftRepository.GetByIDs(aircraftIDs)
+	if err != nil {
+		return logbookEntries{}, err
+	}
+
+	landings, err := landingRepository.GetByFlightIDs(flightIDs)
+	if err != nil {
+		return logbookEntries{}, err
+	}
+
+	passengers, err := passengerRepository.GetByFlightIDs(flightIDs)
+	if err != nil {
+		return logbookEntries{}, err
+	}
+
+	signatures, err := signatureRepository.GetByFlightIDs(flightIDs)
+	if err != nil {
+		return logbookEntries{}, err
+	}
+
+	entries := logbookEntries{
+		flights:              flights,
+		aircraftByID:         make(map[uint]model.Aircraft, len(aircraft)),
+		landingsByFlightID:   make(map[uint][]model.Landing),
+		passengersByFlightID: make(map[uint][]model.Passenger),
+		signatureByFlightID:  make(map[uint]*model.Signature),
+	}
+	for _, a := range aircraft {
+		entries.aircraftByID[a.ID] = a
+	}
+	for _, landing := range landings {
+		entries.landingsByFlightID[landing.FlightID] = append(entries.landingsByFlightID[landing.FlightID], landing)
+	}
+	for _, passenger := range passengers {
+		entries.passengersByFlightID[passenger.FlightID] = append(entries.passengersByFlightID[passenger.FlightID], passenger)
+	}
+	// signatures are ordered by ID, so the most recent one of a flight is kept
+	for i := range signatures {
+		entries.signatureByFlightID[signatures[i].FlightID] = &signatures[i]
+	}
+
+	return entries, nil
+}
+
+func adaptLogbookResponse(flight model.Flight, aircraft model.Aircraft, landings []model.Landing,
+	passengers []model.Passenger, signature *model.Signature) dto.LogbookResponse {
+	landingResponses := make([]dto.LandingResponse, 0, len(landings))
+	for _, landing := range landings {
+		landingResponses = append(landingResponses, dto.LandingResponse{
+			ID:           landing.ID,
 			ApproachType: landing.ApproachType,
 			Count:        landing.Count,
 			NightCount:   landing.NightCount,
@@ -486,13 +829,40 @@ func (l *logbookService) UpdateLogbookEntry(userID string, flightID uint, logboo
 		})
 	}
 
-	logbookResponse = dto.LogbookResponse{
-		AircraftID:          flight.AircraftID,
+	passengerResponses := make([]dto.PassengerResponse, 0, len(passengers))
+	for _, passenger := range passengers {
+		passengerResponses = append(passengerResponses, dto.PassengerResponse{
+			ID:           passenger.ID,
+			Role:         passenger.Role,
+			FirstName:    passenger.FirstName,
+			LastName:     passenger.LastName,
+			Company:      passenger.Company,
+			Phone:        passenger.Phone,
+			EmailAddress: passenger.EmailAddress,
+			Note:         passenger.Note,
+		})
+	}
+
+	var signatureResponse *dto.SignatureResponse
+	if signature != nil {
+		response := adaptSignatureResponse(*signature)
+		signatureResponse = &response
+	}
+
+	return dto.LogbookResponse{
+		ID:         flight.ID,
+		AircraftID: flight.AircraftID,
+		Aircraft: dto.AircraftSummary{
+			ID:                 flight.AircraftID,
+			RegistrationNumber: aircraft.RegistrationNumber,
+			AircraftModel:      aircraft.AircraftModel,
+		},
 		TakeoffTime:         flight.TakeoffTime,
 		TakeoffAirportCode:  flight.TakeoffAirportCode,
 		LandingTime:         flight.LandingTime,
 		LandingAirportCode:  flight.LandingAirportCode,
 		Style:               flight.Style,
+		MyRole:              flight.MyRole,
 		Remarks:             flight.Remarks,
 		PersonalRemarks:     flight.PersonalRemarks,
 		TotalBlockTime:      flight.TotalBlockTime,
@@ -507,14 +877,69 @@ func (l *logbookService) UpdateLogbookEntry(userID string, flightID uint, logboo
 		IFRSimulatedTime:    flight.IFRSimulatedTime,
 		CrossCountryTime:    flight.CrossCountryTime,
 		SimulatorTime:       flight.SimulatorTime,
+		Holdings:            flight.Holdings,
 		SignatureURL:        flight.SignatureURL,
-		Passengers:          passengerEntries,
-		Landings:            landingEntries,
+		Passengers:          passengerResponses,
+		Landings:            landingResponses,
+		Signature:           signatureResponse,
+		CreatedAt:           flight.CreatedAt,
+		UpdatedAt:           flight.UpdatedAt,
 	}
+}
 
-	if err := tx.Commit().Error; err != nil {
-		return dto.LogbookResponse{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+// downloadSignature fetches the signature image of the user. The URL is set by the user, so it is only fetched from
+// the storage hosts in the config to keep the server from requesting internal addresses on their behalf.
+func (l *logbookService) downloadSignature(signatureURL string) ([]byte, string, error) {
+	parsedURL, err := url.Parse(signatureURL)
+	if err != nil {
+		return nil, "", err
+	}
+	if parsedURL.Scheme != "https" || !slices.Contains(l.config.SignatureHosts, parsedURL.Hostname()) {
+		return nil, "", fmt.Errorf("signature host %q is not allowed", parsedURL.Hostname())
+	}
+
+	request, err := http.NewRequest(http.MethodGet, parsedURL.String(), nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	response, err := l.httpClient.Do(request)
+	if err != nil {
+		return nil, "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("unexpected status code: %d", response.StatusCode)
+	}
+
+	signature, err := io.ReadAll(io.LimitReader(response.Body, maxSignatureSize))
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch http.DetectContentType(signature) {
+	case "image/png":
+		return signature, "PNG", nil
+	case "image/jpeg":
+		return signature, "JPG", nil
+	case "image/gif":
+		return signature, "GIF", nil
+	default:
+		return nil, "", errors.New("unsupported signature image type")
 	}
+}
 
-	return logbookResponse, nil
+func pilotName(user model.User) string {
+	names := make([]string, 0, 2)
+	if user.FirstName != nil && *user.FirstName != "" {
+		names = append(names, *user.FirstName)
+	}
+	if user.LastName != nil && *user.LastName != "" {
+		names = append(names, *user.LastName)
+	}
+	if len(names) == 0 {
+		return user.Email
+	}
+	return strings.Join(names, " ")
 }
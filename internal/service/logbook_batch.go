@@ -0,0 +1,153 @@
+package service
+
+import (
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"time"
+)
+
+const maxLogbookBatchSize = 100
+
+// BatchLogbookEntries applies the operations in the order of the request. An atomic batch runs in a single transaction
+// and stops at the first failed operation, otherwise every operation is applied in its own transaction. Each entry can
+// be changed by one operation of the batch only.
+func (l *logbookService) BatchLogbookEntries(userID string, batchRequest dto.LogbookBatchRequest) (dto.LogbookBatchResponse, error) {
+	if len(batchRequest.Operations) == 0 {
+		return dto.LogbookBatchResponse{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "batch has no operations")
+	}
+
+	if len(batchRequest.Operations) > maxLogbookBatchSize {
+		return dto.LogbookBatchResponse{}, fmt.Errorf("%w: batch has more than %d operations", dto.ErrBadRequest,
+			maxLogbookBatchSize)
+	}
+
+	flightIDs := make(map[uint]bool)
+	for _, operation := range batchRequest.Operations {
+		if operation.Action == dto.BatchActionCreate || operation.ID == nil {
+			continue
+		}
+		if flightIDs[*operation.ID] {
+			return dto.LogbookBatchResponse{}, fmt.Errorf("%w: flight %d appears more than once in the batch",
+				dto.ErrBadRequest, *operation.ID)
+		}
+		flightIDs[*operation.ID] = true
+	}
+
+	results := make([]dto.LogbookBatchResult, len(batchRequest.Operations))
+	for i, operation := range batchRequest.Operations {
+		results[i] = dto.LogbookBatchResult{Index: i, Action: operation.Action}
+	}
+
+	if batchRequest.Atomic {
+		l.applyAtomicBatch(userID, batchRequest.Operations, results)
+	} else {
+		for i, operation := range batchRequest.Operations {
+			write, err := l.prepareBatchOperation(userID, operation)
+			if err == nil {
+				err = l.commitLogbookWrite(userID, &write)
+			}
+			setBatchResult(&results[i], write, err)
+		}
+	}
+
+	batchResponse := dto.LogbookBatchResponse{Atomic: batchRequest.Atomic, Results: results}
+	for _, result := range results {
+		if result.Error == nil {
+			batchResponse.Succeeded++
+		} else if result.Status == dto.BatchStatusFailed {
+			batchResponse.Failed++
+		}
+	}
+
+	return batchResponse, nil
+}
+
+func (l *logbookService) applyAtomicBatch(userID string, operations []dto.LogbookBatchOperation, results []dto.LogbookBatchResult) {
+	tx := l.flightRepository.Begin()
+	recordedAt := time.Now()
+
+	if err := l.flightVersionRepository.LockUserTx(tx, userID); err != nil {
+		tx.Rollback()
+		for i := range results {
+			setBatchResult(&results[i], logbookWrite{}, err)
+		}
+		return
+	}
+
+	for i, operation := range operations {
+		write, err := l.prepareBatchOperation(userID, operation)
+		if err == nil {
+			err = l.applyLogbookWriteTx(tx, userID, &write, recordedAt)
+		}
+		if err != nil {
+			tx.Rollback()
+			abortBatch(results, i, err)
+			return
+		}
+		setBatchResult(&results[i], write, nil)
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		err = fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+		for i := range results {
+			setBatchResult(&results[i], logbookWrite{}, err)
+		}
+	}
+}
+
+func (l *logbookService) prepareBatchOperation(userID string, operation dto.LogbookBatchOperation) (logbookWrite, error) {
+	switch operation.Action {
+	case dto.BatchActionCreate:
+		if operation.Entry == nil {
+			return logbookWrite{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "entry is required to create")
+		}
+		return l.prepareInsert(userID, *operation.Entry)
+	case dto.BatchActionUpdate:
+		if operation.ID == nil || operation.Entry == nil {
+			return logbookWrite{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "id and entry are required to update")
+		}
+		return l.prepareUpdate(userID, *operation.ID, *operation.Entry, nil, false)
+	case dto.BatchActionDelete:
+		if operation.ID == nil {
+			return logbookWrite{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "id is required to delete")
+		}
+		return l.prepareDelete(userID, *operation.ID, nil)
+	}
+
+	return logbookWrite{}, fmt.Errorf("%w: invalid action: %v", dto.ErrBadRequest, operation.Action)
+}
+
+func setBatchResult(result *dto.LogbookBatchResult, write logbookWrite, err error) {
+	if err != nil {
+		message := err.Error()
+		result.Status = dto.BatchStatusFailed
+		result.FlightID = nil
+		result.Error = &message
+		return
+	}
+
+	flightID := write.flight.ID
+	result.FlightID = &flightID
+	switch result.Action {
+	case dto.BatchActionCreate:
+		result.Status = dto.BatchStatusCreated
+	case dto.BatchActionUpdate:
+		result.Status = dto.BatchStatusUpdated
+	case dto.BatchActionDelete:
+		result.Status = dto.BatchStatusDeleted
+	}
+}
+
+// abortBatch marks the operation at the index as failed and every other operation of the atomic batch as aborted.
+func abortBatch(results []dto.LogbookBatchResult, failedIndex int, err error) {
+	aborted := fmt.Sprintf("not applied, operation %d of the atomic batch failed", failedIndex)
+	for i := range results {
+		if i == failedIndex {
+			setBatchResult(&results[i], logbookWrite{}, err)
+			continue
+		}
+		results[i].Status = dto.BatchStatusAborted
+		results[i].FlightID = nil
+		results[i].Error = &aborted
+	}
+}
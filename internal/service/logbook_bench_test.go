@@ -0,0 +1,154 @@
+package service
+
+import (
+	"fmt"
+	"github.com/avialog/backend/internal/config"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/infrastructure"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	"github.com/avialog/backend/internal/util"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"io"
+	"testing"
+	"time"
+)
+
+// logbookFixture is a logbook of a single user with two landings and one passenger per flight. The repository mocks
+// count the calls, so the benchmarks report the number of queries needed to load the logbook. The time excludes the
+// database, the queries themselves are measured by the benchmarks of the repository package.
+type logbookFixture struct {
+	service LogbookService
+	queries int
+}
+
+func newLogbookFixture(b *testing.B, flightCount int) *logbookFixture {
+	fixture := &logbookFixture{}
+	count := func() { fixture.queries++ }
+
+	aircraft := []model.Aircraft{{Model: gorm.Model{ID: 1}, UserID: "1", RegistrationNumber: "SP-ABC", AircraftModel: "C152"}}
+	flights := make([]model.Flight, 0, flightCount)
+	landingsByFlightID := make(map[uint][]model.Landing, flightCount)
+	passengersByFlightID := make(map[uint][]model.Passenger, flightCount)
+	takeoffTime := time.Date(2024, 3, 25, 10, 0, 0, 0, time.UTC)
+	for i := 0; i < flightCount; i++ {
+		id := uint(i + 1)
+		flights = append(flights, model.Flight{
+			Model:              gorm.Model{ID: id},
+			UserID:             "1",
+			AircraftID:         1,
+			TakeoffTime:        takeoffTime.Add(-time.Duration(i) * 24 * time.Hour),
+			TakeoffAirportCode: "EPKK",
+			LandingTime:        takeoffTime.Add(-time.Duration(i)*24*time.Hour + time.Hour),
+			LandingAirportCode: "EPWA",
+			Style:              model.StyleY,
+			MyRole:             model.RolePilotInCommand,
+			TotalBlockTime:     util.Duration(time.Hour),
+			PilotInCommandTime: util.Duration(time.Hour),
+		})
+		landingsByFlightID[id] = []model.Landing{
+			{FlightID: id, ApproachType: model.ApproachTypeVisual, Count: util.Uint(1), DayCount: util.Uint(1)},
+			{FlightID: id, ApproachType: model.ApproachTypeILS, Count: util.Uint(1), NightCount: util.Uint(1)},
+		}
+		passengersByFlightID[id] = []model.Passenger{{FlightID: id, Role: model.RoleSecondInCommand, FirstName: "John"}}
+	}
+
+	ctrl := gomock.NewController(b)
+	flightRepoMock := repository.NewMockFlightRepository(ctrl)
+	landingRepoMock := repository.NewMockLandingRepository(ctrl)
+	passengerRepoMock := repository.NewMockPassengerRepository(ctrl)
+	aircraftRepoMock := repository.NewMockAircraftRepository(ctrl)
+	signatureRepoMock := repository.NewMockSignatureRepository(ctrl)
+
+	flightRepoMock.EXPECT().GetPageByUserID("1", gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ string, _ dto.LogbookFilter, after *dto.LogbookCursor, limit int) ([]model.Flight, error) {
+			count()
+			// the flight with ID n is at index n-1, so the page after it starts at index n
+			start := 0
+			if after != nil {
+				start = int(after.ID)
+			}
+			return flights[start:min(start+limit, len(flights))], nil
+		}).AnyTimes()
+	flightRepoMock.EXPECT().GetByUserIDOrderedByTakeoffTime("1", gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ string, _, _ *time.Time) ([]model.Flight, error) {
+			count()
+			return flights, nil
+		}).AnyTimes()
+	aircraftRepoMock.EXPECT().GetByIDs(gomock.Any()).DoAndReturn(func(_ []uint) ([]model.Aircraft, error) {
+		count()
+		return aircraft, nil
+	}).AnyTimes()
+	aircraftRepoMock.EXPECT().GetByUserIDAndID("1", gomock.Any()).DoAndReturn(func(_ string, _ uint) (model.Aircraft, error) {
+		count()
+		return aircraft[0], nil
+	}).AnyTimes()
+	landingRepoMock.EXPECT().GetByFlightID(gomock.Any()).DoAndReturn(func(flightID uint) ([]model.Landing, error) {
+		count()
+		return landingsByFlightID[flightID], nil
+	}).AnyTimes()
+	landingRepoMock.EXPECT().GetByFlightIDs(gomock.Any()).DoAndReturn(func(flightIDs []uint) ([]model.Landing, error) {
+		count()
+		landings := make([]model.Landing, 0, 2*len(flightIDs))
+		for _, flightID := range flightIDs {
+			landings = append(landings, landingsByFlightID[flightID]...)
+		}
+		return landings, nil
+	}).AnyTimes()
+	passengerRepoMock.EXPECT().GetByFlightID(gomock.Any()).DoAndReturn(func(flightID uint) ([]model.Passenger, error) {
+		count()
+		return passengersByFlightID[flightID], nil
+	}).AnyTimes()
+	passengerRepoMock.EXPECT().GetByFlightIDs(gomock.Any()).DoAndReturn(func(flightIDs []uint) ([]model.Passenger, error) {
+		count()
+		passengers := make([]model.Passenger, 0, len(flightIDs))
+		for _, flightID := range flightIDs {
+			passengers = append(passengers, passengersByFlightID[flightID]...)
+		}
+		return passengers, nil
+	}).AnyTimes()
+	signatureRepoMock.EXPECT().GetByFlightIDs(gomock.Any()).DoAndReturn(func(flightIDs []uint) ([]model.Signature, error) {
+		count()
+		return []model.Signature{}, nil
+	}).AnyTimes()
+
+	fixture.service = newLogbookService(flightRepoMock, landingRepoMock, passengerRepoMock, aircraftRepoMock,
+		repository.NewMockUserRepository(ctrl), repository.NewMockAirportRepository(ctrl), signatureRepoMock, repository.NewMockFlightVersionRepository(ctrl),
+		repository.NewMockTrashRepository(ctrl), infrastructure.NewMockHTTPClient(ctrl), config.Config{}, util.GetValidator())
+	return fixture
+}
+
+func (f *logbookFixture) reportQueries(b *testing.B) {
+	b.ReportMetric(float64(f.queries)/float64(b.N), "queries/op")
+}
+
+func BenchmarkGetLogbookEntries(b *testing.B) {
+	for _, pageSize := range []int{10, 50, maxLogbookPageSize} {
+		b.Run(fmt.Sprintf("flights=%d", pageSize), func(b *testing.B) {
+			fixture := newLogbookFixture(b, pageSize)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := fixture.service.GetLogbookEntries("1", dto.LogbookFilter{}, util.Int(pageSize), nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+			fixture.reportQueries(b)
+		})
+	}
+}
+
+func BenchmarkExportLogbookCSV(b *testing.B) {
+	for _, flightCount := range []int{100, 1000, 10000} {
+		b.Run(fmt.Sprintf("flights=%d", flightCount), func(b *testing.B) {
+			fixture := newLogbookFixture(b, flightCount)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := fixture.service.ExportLogbookCSV("1", dto.ExportFilter{}, io.Discard); err != nil {
+					b.Fatal(err)
+				}
+			}
+			fixture.reportQueries(b)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/infrastructure"
+	"github.com/avialog/backend/internal/model"
+	"strings"
+	"time"
+)
+
+// duplicateTolerance is the largest difference of takeoff times of two entries of the same route considered to be
+// the same flight, e.g. entered once from the block times and once from the times of the flight computer.
+const duplicateTolerance = 5 * time.Minute
+
+// checkConflictsTx returns dto.ErrConflict listing entries of the user which duplicate or overlap the entry, unless the
+// client allowed conflicts. The entry with flightID is skipped, so an updated entry does not conflict with itself. The
+// entries are read in the transaction, so entries written earlier in an atomic batch are checked as well.
+func (l *logbookService) checkConflictsTx(tx infrastructure.Database, userID string, flightID uint, flight model.Flight,
+	allowConflicts bool) error {
+	if allowConflicts {
+		return nil
+	}
+
+	flights, err := l.flightRepository.GetByUserIDAndTimeWindowTx(tx, userID,
+		flight.TakeoffTime.Add(-duplicateTolerance), flight.LandingTime.Add(duplicateTolerance))
+	if err != nil {
+		return err
+	}
+
+	conflicts := make([]dto.FlightConflict, 0)
+	for _, existing := range flights {
+		if existing.ID == flightID {
+			continue
+		}
+		if isDuplicate(flight, existing) {
+			conflicts = append(conflicts, dto.FlightConflict{Kind: dto.ConflictDuplicate, Flight: adaptFlightSummary(existing)})
+		} else if isOverlap(flight, existing) {
+			conflicts = append(conflicts, dto.FlightConflict{Kind: dto.ConflictOverlap, Flight: adaptFlightSummary(existing)})
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return fmt.Errorf("%w: %w", dto.ErrConflict, &dto.ConflictError{Conflicts: conflicts})
+	}
+	return nil
+}
+
+// GetDuplicateEntries groups entries of the whole logbook which are likely to be the same flight.
+func (l *logbookService) GetDuplicateEntries(userID string) ([]dto.DuplicateGroupResponse, error) {
+	flights, err := l.flightRepository.GetByUserIDOrderedByTakeoffTime(userID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]dto.DuplicateGroupResponse, 0)
+	grouped := make([]bool, len(flights))
+	for i := range flights {
+		if grouped[i] {
+			continue
+		}
+
+		group := []dto.FlightSummary{adaptFlightSummary(flights[i])}
+		// flights are ordered by takeoff time, so only the following flights within the tolerance can be duplicates
+		for j := i + 1; j < len(flights) && flights[j].TakeoffTime.Sub(flights[i].TakeoffTime) <= duplicateTolerance; j++ {
+			if !grouped[j] && isDuplicate(flights[i], flights[j]) {
+				grouped[j] = true
+				group = append(group, adaptFlightSummary(flights[j]))
+			}
+		}
+
+		if len(group) > 1 {
+			groups = append(groups, dto.DuplicateGroupResponse{Flights: group})
+		}
+	}
+
+	return groups, nil
+}
+
+func isDuplicate(a, b model.Flight) bool {
+	difference := a.TakeoffTime.Sub(b.TakeoffTime)
+	if difference < 0 {
+		difference = -difference
+	}
+	return difference <= duplicateTolerance &&
+		strings.EqualFold(a.TakeoffAirportCode, b.TakeoffAirportCode) &&
+		strings.EqualFold(a.LandingAirportCode, b.LandingAirportCode)
+}
+
+// isOverlap reports whether both flights are in the air at the same time, touching block times are not an overlap.
+func isOverlap(a, b model.Flight) bool {
+	return a.TakeoffTime.Before(b.LandingTime) && b.TakeoffTime.Before(a.LandingTime)
+}
+
+func adaptFlightSummary(flight model.Flight) dto.FlightSummary {
+	return dto.FlightSummary{
+		ID:                 flight.ID,
+		AircraftID:         flight.AircraftID,
+		TakeoffTime:        flight.TakeoffTime,
+		TakeoffAirportCode: flight.TakeoffAirportCode,
+		LandingTime:        flight.LandingTime,
+		LandingAirportCode: flight.LandingAirportCode,
+	}
+}
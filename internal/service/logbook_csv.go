@@ -0,0 +1,187 @@
+package service
+
+import (
+	"encoding/csv"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type csvEntry struct {
+	flight     model.Flight
+	aircraft   model.Aircraft
+	landings   []model.Landing
+	passengers []model.Passenger
+}
+
+type csvColumn struct {
+	name  string
+	value func(entry csvEntry) string
+}
+
+// csvColumns lists every column available in the CSV export in the default order. Each duration is exported twice,
+// as HH:MM and as decimal hours in a column with the "_decimal" suffix.
+var csvColumns = withDurationColumns([]csvColumn{
+	{name: "date", value: func(e csvEntry) string { return e.flight.TakeoffTime.UTC().Format(time.DateOnly) }},
+	{name: "takeoff_airport", value: func(e csvEntry) string { return e.flight.TakeoffAirportCode }},
+	{name: "takeoff_time", value: func(e csvEntry) string { return e.flight.TakeoffTime.UTC().Format("15:04") }},
+	{name: "landing_airport", value: func(e csvEntry) string { return e.flight.LandingAirportCode }},
+	{name: "landing_time", value: func(e csvEntry) string { return e.flight.LandingTime.UTC().Format("15:04") }},
+	{name: "aircraft_registration", value: func(e csvEntry) string { return e.aircraft.RegistrationNumber }},
+	{name: "aircraft_model", value: func(e csvEntry) string { return e.aircraft.AircraftModel }},
+	{name: "style", value: func(e csvEntry) string { return string(e.flight.Style) }},
+	{name: "my_role", value: func(e csvEntry) string { return string(e.flight.MyRole) }},
+}, []csvDurationColumn{
+	{name: "total_block_time", value: func(f model.Flight) *time.Duration { return f.TotalBlockTime }},
+	{name: "pilot_in_command_time", value: func(f model.Flight) *time.Duration { return f.PilotInCommandTime }},
+	{name: "second_in_command_time", value: func(f model.Flight) *time.Duration { return f.SecondInCommandTime }},
+	{name: "dual_received_time", value: func(f model.Flight) *time.Duration { return f.DualReceivedTime }},
+	{name: "dual_given_time", value: func(f model.Flight) *time.Duration { return f.DualGivenTime }},
+	{name: "multi_pilot_time", value: func(f model.Flight) *time.Duration { return f.MultiPilotTime }},
+	{name: "night_time", value: func(f model.Flight) *time.Duration { return f.NightTime }},
+	{name: "ifr_time", value: func(f model.Flight) *time.Duration { return f.IFRTime }},
+	{name: "ifr_actual_time", value: func(f model.Flight) *time.Duration { return f.IFRActualTime }},
+	{name: "ifr_simulated_time", value: func(f model.Flight) *time.Duration { return f.IFRSimulatedTime }},
+	{name: "cross_country_time", value: func(f model.Flight) *time.Duration { return f.CrossCountryTime }},
+	{name: "simulator_time", value: func(f model.Flight) *time.Duration { return f.SimulatorTime }},
+}, []csvColumn{
+	{name: "holdings", value: func(e csvEntry) string { return formatCSVUint(e.flight.Holdings) }},
+	{name: "landings", value: func(e csvEntry) string {
+		return csvLandingSum(e.landings, func(l model.Landing) *uint { return l.Count })
+	}},
+	{name: "day_landings", value: func(e csvEntry) string {
+		return csvLandingSum(e.landings, func(l model.Landing) *uint { return l.DayCount })
+	}},
+	{name: "night_landings", value: func(e csvEntry) string {
+		return csvLandingSum(e.landings, func(l model.Landing) *uint { return l.NightCount })
+	}},
+	{name: "approach_types", value: func(e csvEntry) string {
+		approaches := make([]string, 0, len(e.landings))
+		for _, landing := range e.landings {
+			approaches = append(approaches, string(landing.ApproachType))
+		}
+		return strings.Join(approaches, ";")
+	}},
+	{name: "passengers", value: func(e csvEntry) string {
+		passengers := make([]string, 0, len(e.passengers))
+		for _, passenger := range e.passengers {
+			name := passenger.FirstName
+			if passenger.LastName != nil {
+				name += " " + *passenger.LastName
+			}
+			passengers = append(passengers, fmt.Sprintf("%s %s", passenger.Role, name))
+		}
+		return strings.Join(passengers, ";")
+	}},
+	{name: "remarks", value: func(e csvEntry) string { return stringValue(e.flight.Remarks) }},
+	{name: "personal_remarks", value: func(e csvEntry) string { return stringValue(e.flight.PersonalRemarks) }},
+})
+
+type csvDurationColumn struct {
+	name  string
+	value func(flight model.Flight) *time.Duration
+}
+
+func withDurationColumns(leading []csvColumn, durations []csvDurationColumn, trailing []csvColumn) []csvColumn {
+	columns := make([]csvColumn, 0, len(leading)+2*len(durations)+len(trailing))
+	columns = append(columns, leading...)
+	for _, duration := range durations {
+		value := duration.value
+		columns = append(columns,
+			csvColumn{name: duration.name, value: func(e csvEntry) string {
+				if d := value(e.flight); d != nil {
+					return formatLogbookDuration(*d)
+				}
+				return ""
+			}},
+			csvColumn{name: duration.name + "_decimal", value: func(e csvEntry) string {
+				if d := value(e.flight); d != nil {
+					return strconv.FormatFloat(d.Round(time.Minute).Hours(), 'f', 2, 64)
+				}
+				return ""
+			}},
+		)
+	}
+	return append(columns, trailing...)
+}
+
+// selectCSVColumns returns columns in the requested order, all columns are returned if none were requested.
+func selectCSVColumns(names []string) ([]csvColumn, error) {
+	if len(names) == 0 {
+		return csvColumns, nil
+	}
+
+	columns := make([]csvColumn, 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, column := range csvColumns {
+			if column.name == name {
+				columns = append(columns, column)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: unknown column: %v", dto.ErrBadRequest, name)
+		}
+	}
+
+	return columns, nil
+}
+
+func writeCSVHeader(csvWriter *csv.Writer, columns []csvColumn) error {
+	record := make([]string, len(columns))
+	for i, column := range columns {
+		record[i] = column.name
+	}
+	return csvWriter.Write(record)
+}
+
+func writeCSVEntries(csvWriter *csv.Writer, columns []csvColumn, entries []csvEntry) error {
+	record := make([]string, len(columns))
+	for _, entry := range entries {
+		for i, column := range columns {
+			record[i] = neutralizeCSVFormula(column.value(entry))
+		}
+		if err := csvWriter.Write(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// neutralizeCSVFormula prefixes values which spreadsheets would evaluate as a formula, so that remarks and names are
+// shown as entered instead of being executed when the export is opened.
+func neutralizeCSVFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func csvLandingSum(landings []model.Landing, count func(model.Landing) *uint) string {
+	var sum uint
+	for _, landing := range landings {
+		if c := count(landing); c != nil {
+			sum += *c
+		}
+	}
+	return strconv.FormatUint(uint64(sum), 10)
+}
+
+func formatCSVUint(value *uint) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatUint(uint64(*value), 10)
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// encodeLogbookCursor returns an opaque cursor pointing right after the entry, the client must not rely on its format.
+func encodeLogbookCursor(cursor dto.LogbookCursor) string {
+	value := fmt.Sprintf("%d:%d", cursor.TakeoffTime.UnixNano(), cursor.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(value))
+}
+
+func decodeLogbookCursor(cursor string) (dto.LogbookCursor, error) {
+	value, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return dto.LogbookCursor{}, err
+	}
+
+	takeoffTime, id, found := strings.Cut(string(value), ":")
+	if !found {
+		return dto.LogbookCursor{}, errors.New("missing separator")
+	}
+	nanoseconds, err := strconv.ParseInt(takeoffTime, 10, 64)
+	if err != nil {
+		return dto.LogbookCursor{}, err
+	}
+	flightID, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return dto.LogbookCursor{}, err
+	}
+
+	return dto.LogbookCursor{TakeoffTime: time.Unix(0, nanoseconds).UTC(), ID: uint(flightID)}, nil
+}
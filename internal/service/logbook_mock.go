@@ -10,6 +10,7 @@
 package service
 
 import (
+	io "io"
 	reflect "reflect"
 	time "time"
 
@@ -40,33 +41,137 @@ func (m *MockLogbookService) EXPECT() *MockLogbookServiceMockRecorder {
 	return m.recorder
 }
 
+// AmendLogbookEntry mocks base method.
+func (m *MockLogbookService) AmendLogbookEntry(userID string, flightID uint, logbookRequest dto.LogbookRequest, version *time.Time) (dto.LogbookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AmendLogbookEntry", userID, flightID, logbookRequest, version)
+	ret0, _ := ret[0].(dto.LogbookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AmendLogbookEntry indicates an expected call of AmendLogbookEntry.
+func (mr *MockLogbookServiceMockRecorder) AmendLogbookEntry(userID, flightID, logbookRequest, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AmendLogbookEntry", reflect.TypeOf((*MockLogbookService)(nil).AmendLogbookEntry), userID, flightID, logbookRequest, version)
+}
+
+// BatchLogbookEntries mocks base method.
+func (m *MockLogbookService) BatchLogbookEntries(userID string, batchRequest dto.LogbookBatchRequest) (dto.LogbookBatchResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchLogbookEntries", userID, batchRequest)
+	ret0, _ := ret[0].(dto.LogbookBatchResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchLogbookEntries indicates an expected call of BatchLogbookEntries.
+func (mr *MockLogbookServiceMockRecorder) BatchLogbookEntries(userID, batchRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchLogbookEntries", reflect.TypeOf((*MockLogbookService)(nil).BatchLogbookEntries), userID, batchRequest)
+}
+
 // DeleteLogbookEntry mocks base method.
-func (m *MockLogbookService) DeleteLogbookEntry(userID string, flightID uint) error {
+func (m *MockLogbookService) DeleteLogbookEntry(userID string, flightID uint, version *time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteLogbookEntry", userID, flightID)
+	ret := m.ctrl.Call(m, "DeleteLogbookEntry", userID, flightID, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // DeleteLogbookEntry indicates an expected call of DeleteLogbookEntry.
-func (mr *MockLogbookServiceMockRecorder) DeleteLogbookEntry(userID, flightID any) *gomock.Call {
+func (mr *MockLogbookServiceMockRecorder) DeleteLogbookEntry(userID, flightID, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLogbookEntry", reflect.TypeOf((*MockLogbookService)(nil).DeleteLogbookEntry), userID, flightID, version)
+}
+
+// ExportLogbookCSV mocks base method.
+func (m *MockLogbookService) ExportLogbookCSV(userID string, filter dto.ExportFilter, writer io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportLogbookCSV", userID, filter, writer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportLogbookCSV indicates an expected call of ExportLogbookCSV.
+func (mr *MockLogbookServiceMockRecorder) ExportLogbookCSV(userID, filter, writer any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportLogbookCSV", reflect.TypeOf((*MockLogbookService)(nil).ExportLogbookCSV), userID, filter, writer)
+}
+
+// ExportLogbookPDF mocks base method.
+func (m *MockLogbookService) ExportLogbookPDF(userID string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportLogbookPDF", userID)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportLogbookPDF indicates an expected call of ExportLogbookPDF.
+func (mr *MockLogbookServiceMockRecorder) ExportLogbookPDF(userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLogbookEntry", reflect.TypeOf((*MockLogbookService)(nil).DeleteLogbookEntry), userID, flightID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportLogbookPDF", reflect.TypeOf((*MockLogbookService)(nil).ExportLogbookPDF), userID)
+}
+
+// GetDuplicateEntries mocks base method.
+func (m *MockLogbookService) GetDuplicateEntries(userID string) ([]dto.DuplicateGroupResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDuplicateEntries", userID)
+	ret0, _ := ret[0].([]dto.DuplicateGroupResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDuplicateEntries indicates an expected call of GetDuplicateEntries.
+func (mr *MockLogbookServiceMockRecorder) GetDuplicateEntries(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDuplicateEntries", reflect.TypeOf((*MockLogbookService)(nil).GetDuplicateEntries), userID)
 }
 
 // GetLogbookEntries mocks base method.
-func (m *MockLogbookService) GetLogbookEntries(userID string, start, end time.Time) ([]dto.LogbookResponse, error) {
+func (m *MockLogbookService) GetLogbookEntries(userID string, filter dto.LogbookFilter, limit *int, cursor *string) (dto.LogbookPageResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLogbookEntries", userID, start, end)
-	ret0, _ := ret[0].([]dto.LogbookResponse)
+	ret := m.ctrl.Call(m, "GetLogbookEntries", userID, filter, limit, cursor)
+	ret0, _ := ret[0].(dto.LogbookPageResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetLogbookEntries indicates an expected call of GetLogbookEntries.
-func (mr *MockLogbookServiceMockRecorder) GetLogbookEntries(userID, start, end any) *gomock.Call {
+func (mr *MockLogbookServiceMockRecorder) GetLogbookEntries(userID, filter, limit, cursor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogbookEntries", reflect.TypeOf((*MockLogbookService)(nil).GetLogbookEntries), userID, filter, limit, cursor)
+}
+
+// GetLogbookEntry mocks base method.
+func (m *MockLogbookService) GetLogbookEntry(userID string, flightID uint) (dto.LogbookResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLogbookEntry", userID, flightID)
+	ret0, _ := ret[0].(dto.LogbookResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLogbookEntry indicates an expected call of GetLogbookEntry.
+func (mr *MockLogbookServiceMockRecorder) GetLogbookEntry(userID, flightID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogbookEntries", reflect.TypeOf((*MockLogbookService)(nil).GetLogbookEntries), userID, start, end)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogbookEntry", reflect.TypeOf((*MockLogbookService)(nil).GetLogbookEntry), userID, flightID)
+}
+
+// GetLogbookTotals mocks base method.
+func (m *MockLogbookService) GetLogbookTotals(userID string, filter dto.TotalsFilter) (dto.TotalsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLogbookTotals", userID, filter)
+	ret0, _ := ret[0].(dto.TotalsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLogbookTotals indicates an expected call of GetLogbookTotals.
+func (mr *MockLogbookServiceMockRecorder) GetLogbookTotals(userID, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogbookTotals", reflect.TypeOf((*MockLogbookService)(nil).GetLogbookTotals), userID, filter)
 }
 
 // InsertLogbookEntry mocks base method.
@@ -84,17 +189,32 @@ func (mr *MockLogbookServiceMockRecorder) InsertLogbookEntry(userID, logbookRequ
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertLogbookEntry", reflect.TypeOf((*MockLogbookService)(nil).InsertLogbookEntry), userID, logbookRequest)
 }
 
+// PreviewNightTime mocks base method.
+func (m *MockLogbookService) PreviewNightTime(route dto.FlightRoute) (dto.NightTimeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreviewNightTime", route)
+	ret0, _ := ret[0].(dto.NightTimeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewNightTime indicates an expected call of PreviewNightTime.
+func (mr *MockLogbookServiceMockRecorder) PreviewNightTime(route any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewNightTime", reflect.TypeOf((*MockLogbookService)(nil).PreviewNightTime), route)
+}
+
 // UpdateLogbookEntry mocks base method.
-func (m *MockLogbookService) UpdateLogbookEntry(userID string, flightID uint, logbookRequest dto.LogbookRequest) (dto.LogbookResponse, error) {
+func (m *MockLogbookService) UpdateLogbookEntry(userID string, flightID uint, logbookRequest dto.LogbookRequest, version *time.Time) (dto.LogbookResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateLogbookEntry", userID, flightID, logbookRequest)
+	ret := m.ctrl.Call(m, "UpdateLogbookEntry", userID, flightID, logbookRequest, version)
 	ret0, _ := ret[0].(dto.LogbookResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateLogbookEntry indicates an expected call of UpdateLogbookEntry.
-func (mr *MockLogbookServiceMockRecorder) UpdateLogbookEntry(userID, flightID, logbookRequest any) *gomock.Call {
+func (mr *MockLogbookServiceMockRecorder) UpdateLogbookEntry(userID, flightID, logbookRequest, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLogbookEntry", reflect.TypeOf((*MockLogbookService)(nil).UpdateLogbookEntry), userID, flightID, logbookRequest)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLogbookEntry", reflect.TypeOf((*MockLogbookService)(nil).UpdateLogbookEntry), userID, flightID, logbookRequest, version)
 }
@@ -0,0 +1,379 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/avialog/backend/internal/model"
+	"github.com/go-pdf/fpdf"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	fcl050RowsPerPage    = 14
+	fcl050Margin         = 8.0
+	fcl050HeaderHeight   = 6.0
+	fcl050RowHeight      = 7.0
+	fcl050TotalsHeight   = 6.0
+	fcl050FontFamily     = "Helvetica"
+	fcl050SignatureImage = "signature"
+)
+
+// fcl050Column describes a single column of the EASA FCL.050 logbook layout. Columns sharing the same group are
+// rendered under one common header cell.
+type fcl050Column struct {
+	group string
+	title string
+	width float64
+	total func(totals fcl050Totals) string
+}
+
+type fcl050Entry struct {
+	flight        model.Flight
+	aircraft      model.Aircraft
+	picName       string
+	dayLandings   uint
+	nightLandings uint
+}
+
+type fcl050Totals struct {
+	singlePilot   time.Duration
+	multiPilot    time.Duration
+	total         time.Duration
+	dayLandings   uint
+	nightLandings uint
+	night         time.Duration
+	ifr           time.Duration
+	pic           time.Duration
+	coPilot       time.Duration
+	dual          time.Duration
+	instructor    time.Duration
+	simulator     time.Duration
+}
+
+var fcl050Columns = []fcl050Column{
+	{group: "1", title: "DATE\n(dd/mm/yy)", width: 13},
+	{group: "2 DEPARTURE", title: "PLACE", width: 11},
+	{group: "2 DEPARTURE", title: "TIME", width: 9},
+	{group: "3 ARRIVAL", title: "PLACE", width: 11},
+	{group: "3 ARRIVAL", title: "TIME", width: 9},
+	{group: "4 AIRCRAFT", title: "MAKE, MODEL", width: 18},
+	{group: "4 AIRCRAFT", title: "REGISTRATION", width: 14},
+	{group: "5 SINGLE PILOT TIME", title: "SE / ME", width: 16, total: func(t fcl050Totals) string { return formatLogbookDuration(t.singlePilot) }},
+	{group: "6", title: "MULTI-PILOT\nTIME", width: 11, total: func(t fcl050Totals) string { return formatLogbookDuration(t.multiPilot) }},
+	{group: "7", title: "TOTAL TIME\nOF FLIGHT", width: 11, total: func(t fcl050Totals) string { return formatLogbookDuration(t.total) }},
+	{group: "8", title: "NAME PIC", width: 20},
+	{group: "9 LANDINGS", title: "DAY", width: 7, total: func(t fcl050Totals) string { return fmt.Sprint(t.dayLandings) }},
+	{group: "9 LANDINGS", title: "NIGHT", width: 7, total: func(t fcl050Totals) string { return fmt.Sprint(t.nightLandings) }},
+	{group: "10 OPERATIONAL CONDITION TIME", title: "NIGHT", width: 11, total: func(t fcl050Totals) string { return formatLogbookDuration(t.night) }},
+	{group: "10 OPERATIONAL CONDITION TIME", title: "IFR", width: 11, total: func(t fcl050Totals) string { return formatLogbookDuration(t.ifr) }},
+	{group: "11 PILOT FUNCTION TIME", title: "PIC", width: 11, total: func(t fcl050Totals) string { return formatLogbookDuration(t.pic) }},
+	{group: "11 PILOT FUNCTION TIME", title: "CO-PILOT", width: 11, total: func(t fcl050Totals) string { return formatLogbookDuration(t.coPilot) }},
+	{group: "11 PILOT FUNCTION TIME", title: "DUAL", width: 11, total: func(t fcl050Totals) string { return formatLogbookDuration(t.dual) }},
+	{group: "11 PILOT FUNCTION TIME", title: "INSTRUCTOR", width: 11, total: func(t fcl050Totals) string { return formatLogbookDuration(t.instructor) }},
+	{group: "12 FSTD SESSION", title: "DATE", width: 12},
+	{group: "12 FSTD SESSION", title: "TYPE", width: 12},
+	{group: "12 FSTD SESSION", title: "TOTAL TIME", width: 11, total: func(t fcl050Totals) string { return formatLogbookDuration(t.simulator) }},
+	{group: "13", title: "REMARKS AND\nENDORSEMENTS", width: 23},
+}
+
+// pilotInCommandRoles are roles in which the logbook owner is the pilot in command, so "SELF" is written in the
+// "Name PIC" column.
+var pilotInCommandRoles = []model.Role{
+	model.RolePilotInCommand,
+	model.RoleStudentPilotInCommand,
+	model.RolePilotInCommandUnderSupervision,
+	model.RoleInstructor,
+	model.RoleExaminer,
+}
+
+func newFCL050Entry(flight model.Flight, aircraft model.Aircraft, landings []model.Landing, passengers []model.Passenger) fcl050Entry {
+	entry := fcl050Entry{flight: flight, aircraft: aircraft}
+
+	if slices.Contains(pilotInCommandRoles, flight.MyRole) {
+		entry.picName = "SELF"
+	} else {
+		for _, passenger := range passengers {
+			if passenger.Role == model.RolePilotInCommand {
+				entry.picName = passenger.FirstName
+				if passenger.LastName != nil {
+					entry.picName += " " + *passenger.LastName
+				}
+				break
+			}
+		}
+	}
+
+	for _, landing := range landings {
+		if landing.DayCount == nil && landing.NightCount == nil {
+			if landing.Count != nil {
+				entry.dayLandings += *landing.Count
+			}
+			continue
+		}
+		if landing.DayCount != nil {
+			entry.dayLandings += *landing.DayCount
+		}
+		if landing.NightCount != nil {
+			entry.nightLandings += *landing.NightCount
+		}
+	}
+
+	return entry
+}
+
+func (e fcl050Entry) totals() fcl050Totals {
+	total := durationValue(e.flight.TotalBlockTime)
+	multiPilot := durationValue(e.flight.MultiPilotTime)
+
+	var singlePilot time.Duration
+	if total > multiPilot {
+		singlePilot = total - multiPilot
+	}
+
+	return fcl050Totals{
+		singlePilot:   singlePilot,
+		multiPilot:    multiPilot,
+		total:         total,
+		dayLandings:   e.dayLandings,
+		nightLandings: e.nightLandings,
+		night:         durationValue(e.flight.NightTime),
+		ifr:           durationValue(e.flight.IFRTime),
+		pic:           durationValue(e.flight.PilotInCommandTime),
+		coPilot:       durationValue(e.flight.SecondInCommandTime),
+		dual:          durationValue(e.flight.DualReceivedTime),
+		instructor:    durationValue(e.flight.DualGivenTime),
+		simulator:     durationValue(e.flight.SimulatorTime),
+	}
+}
+
+func (e fcl050Entry) cells() []string {
+	t := e.totals()
+	cells := []string{
+		e.flight.TakeoffTime.UTC().Format("02/01/06"),
+		e.flight.TakeoffAirportCode,
+		e.flight.TakeoffTime.UTC().Format("15:04"),
+		e.flight.LandingAirportCode,
+		e.flight.LandingTime.UTC().Format("15:04"),
+		e.aircraft.AircraftModel,
+		e.aircraft.RegistrationNumber,
+		formatOptionalLogbookDuration(t.singlePilot),
+		formatOptionalLogbookDuration(t.multiPilot),
+		formatOptionalLogbookDuration(t.total),
+		e.picName,
+		formatOptionalCount(t.dayLandings),
+		formatOptionalCount(t.nightLandings),
+		formatOptionalLogbookDuration(t.night),
+		formatOptionalLogbookDuration(t.ifr),
+		formatOptionalLogbookDuration(t.pic),
+		formatOptionalLogbookDuration(t.coPilot),
+		formatOptionalLogbookDuration(t.dual),
+		formatOptionalLogbookDuration(t.instructor),
+		"",
+		"",
+		"",
+		"",
+	}
+
+	if t.simulator > 0 {
+		cells[19] = cells[0]
+		cells[20] = e.aircraft.AircraftModel
+		cells[21] = formatLogbookDuration(t.simulator)
+	}
+	if e.flight.Remarks != nil {
+		cells[22] = *e.flight.Remarks
+	}
+
+	return cells
+}
+
+func (t fcl050Totals) add(other fcl050Totals) fcl050Totals {
+	return fcl050Totals{
+		singlePilot:   t.singlePilot + other.singlePilot,
+		multiPilot:    t.multiPilot + other.multiPilot,
+		total:         t.total + other.total,
+		dayLandings:   t.dayLandings + other.dayLandings,
+		nightLandings: t.nightLandings + other.nightLandings,
+		night:         t.night + other.night,
+		ifr:           t.ifr + other.ifr,
+		pic:           t.pic + other.pic,
+		coPilot:       t.coPilot + other.coPilot,
+		dual:          t.dual + other.dual,
+		instructor:    t.instructor + other.instructor,
+		simulator:     t.simulator + other.simulator,
+	}
+}
+
+// renderFCL050 renders logbook entries into a PDF document following the EASA AMC1 FCL.050 column layout. The
+// signature is optional, signatureType must be one of the image types supported by fpdf (JPG, PNG, GIF).
+func renderFCL050(pilotName string, entries []fcl050Entry, signature []byte, signatureType string) ([]byte, error) {
+	pdf := fpdf.New("L", "mm", "A4", "")
+	pdf.SetMargins(fcl050Margin, fcl050Margin, fcl050Margin)
+	pdf.SetAutoPageBreak(false, fcl050Margin)
+	pdf.SetTitle("Pilot logbook", true)
+	pdf.SetAuthor(pilotName, true)
+	translate := pdf.UnicodeTranslatorFromDescriptor("")
+
+	if len(signature) > 0 {
+		pdf.RegisterImageOptionsReader(fcl050SignatureImage, fpdf.ImageOptions{ImageType: signatureType}, bytes.NewReader(signature))
+	}
+
+	pageCount := (len(entries) + fcl050RowsPerPage - 1) / fcl050RowsPerPage
+	if pageCount == 0 {
+		pageCount = 1
+	}
+
+	var broughtForward fcl050Totals
+	for page := 0; page < pageCount; page++ {
+		start := page * fcl050RowsPerPage
+		end := min(start+fcl050RowsPerPage, len(entries))
+
+		pdf.AddPage()
+		pdf.SetFont(fcl050FontFamily, "B", 10)
+		pdf.CellFormat(0, 6, translate("PILOT LOGBOOK - "+pilotName), "", 1, "L", false, 0, "")
+		pdf.Ln(2)
+		renderFCL050Header(pdf)
+
+		var pageTotals fcl050Totals
+		pdf.SetFont(fcl050FontFamily, "", 6)
+		for _, entry := range entries[start:end] {
+			pageTotals = pageTotals.add(entry.totals())
+			renderFCL050Row(pdf, translate, entry.cells(), fcl050RowHeight)
+		}
+		for row := end - start; row < fcl050RowsPerPage; row++ {
+			renderFCL050Row(pdf, translate, make([]string, len(fcl050Columns)), fcl050RowHeight)
+		}
+
+		pdf.SetFont(fcl050FontFamily, "B", 6)
+		renderFCL050Totals(pdf, "TOTAL THIS PAGE", pageTotals)
+		renderFCL050Totals(pdf, "TOTAL FROM PREVIOUS PAGES", broughtForward)
+		broughtForward = broughtForward.add(pageTotals)
+		renderFCL050Totals(pdf, "TOTAL TIME", broughtForward)
+
+		renderFCL050Footer(pdf, translate, pilotName, len(signature) > 0, page+1, pageCount)
+	}
+
+	var buffer bytes.Buffer
+	if err := pdf.Output(&buffer); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func renderFCL050Header(pdf *fpdf.Fpdf) {
+	pdf.SetFont(fcl050FontFamily, "B", 5)
+	x, y := pdf.GetXY()
+
+	for i := 0; i < len(fcl050Columns); {
+		column := fcl050Columns[i]
+		width := column.width
+		j := i + 1
+		for j < len(fcl050Columns) && fcl050Columns[j].group == column.group {
+			width += fcl050Columns[j].width
+			j++
+		}
+
+		pdf.SetXY(x, y)
+		if j-i == 1 {
+			// single column groups span both header rows
+			pdf.Rect(x, y, width, 2*fcl050HeaderHeight, "D")
+			renderFCL050CellText(pdf, column.group+"\n"+column.title, x, y, width, 2*fcl050HeaderHeight)
+		} else {
+			pdf.CellFormat(width, fcl050HeaderHeight, column.group, "1", 0, "C", false, 0, "")
+			subX := x
+			for _, sub := range fcl050Columns[i:j] {
+				pdf.SetXY(subX, y+fcl050HeaderHeight)
+				pdf.CellFormat(sub.width, fcl050HeaderHeight, sub.title, "1", 0, "C", false, 0, "")
+				subX += sub.width
+			}
+		}
+
+		x += width
+		i = j
+	}
+
+	pdf.SetXY(fcl050Margin, y+2*fcl050HeaderHeight)
+}
+
+func renderFCL050CellText(pdf *fpdf.Fpdf, text string, x, y, width, height float64) {
+	lines := strings.Split(text, "\n")
+	lineHeight := 2.5
+	top := y + (height-lineHeight*float64(len(lines)))/2
+	for i, line := range lines {
+		pdf.SetXY(x, top+float64(i)*lineHeight)
+		pdf.CellFormat(width, lineHeight, line, "", 0, "C", false, 0, "")
+	}
+}
+
+func renderFCL050Row(pdf *fpdf.Fpdf, translate func(string) string, cells []string, height float64) {
+	for i, column := range fcl050Columns {
+		text := translate(cells[i])
+		for text != "" && pdf.GetStringWidth(text) > column.width-1 {
+			text = text[:len(text)-1]
+		}
+		pdf.CellFormat(column.width, height, text, "1", 0, "C", false, 0, "")
+	}
+	pdf.Ln(height)
+}
+
+func renderFCL050Totals(pdf *fpdf.Fpdf, label string, totals fcl050Totals) {
+	var labelWidth float64
+	first := 0
+	for first < len(fcl050Columns) && fcl050Columns[first].total == nil {
+		labelWidth += fcl050Columns[first].width
+		first++
+	}
+
+	pdf.CellFormat(labelWidth, fcl050TotalsHeight, label, "1", 0, "R", false, 0, "")
+	for _, column := range fcl050Columns[first:] {
+		text := ""
+		if column.total != nil {
+			text = column.total(totals)
+		}
+		pdf.CellFormat(column.width, fcl050TotalsHeight, text, "1", 0, "C", false, 0, "")
+	}
+	pdf.Ln(fcl050TotalsHeight)
+}
+
+func renderFCL050Footer(pdf *fpdf.Fpdf, translate func(string) string, pilotName string, hasSignature bool, page, pageCount int) {
+	pdf.Ln(3)
+	pdf.SetFont(fcl050FontFamily, "", 7)
+	pdf.CellFormat(120, 5, "I certify that the entries in this log are true.", "", 0, "L", false, 0, "")
+	pdf.CellFormat(0, 5, fmt.Sprintf("Page %d of %d", page, pageCount), "", 1, "R", false, 0, "")
+
+	x, y := pdf.GetXY()
+	pdf.CellFormat(25, 5, "Pilot's signature:", "", 0, "L", false, 0, "")
+	if hasSignature {
+		pdf.ImageOptions(fcl050SignatureImage, x+26, y, 0, 12, false, fpdf.ImageOptions{}, 0, "")
+	}
+	pdf.SetXY(x, y+13)
+	pdf.CellFormat(0, 5, translate(pilotName), "", 1, "L", false, 0, "")
+}
+
+func formatLogbookDuration(duration time.Duration) string {
+	minutes := int64(duration.Round(time.Minute) / time.Minute)
+	return fmt.Sprintf("%d:%02d", minutes/60, minutes%60)
+}
+
+func formatOptionalLogbookDuration(duration time.Duration) string {
+	if duration == 0 {
+		return ""
+	}
+	return formatLogbookDuration(duration)
+}
+
+func formatOptionalCount(count uint) string {
+	if count == 0 {
+		return ""
+	}
+	return fmt.Sprint(count)
+}
+
+func durationValue(duration *time.Duration) time.Duration {
+	if duration == nil {
+		return 0
+	}
+	return *duration
+}
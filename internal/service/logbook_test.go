@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"github.com/avialog/backend/internal/config"
 	"github.com/avialog/backend/internal/dto"
 	"github.com/avialog/backend/internal/infrastructure"
@@ -13,9 +14,19 @@ import (
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 	"gorm.io/gorm"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"strings"
 	"time"
 )
 
+// returnFlightVersion stands in for FlightVersionRepository.CreateTx storing the version as it is.
+func returnFlightVersion(_ infrastructure.Database, version model.FlightVersion) (model.FlightVersion, error) {
+	return version, nil
+}
+
 var _ = Describe("LogbookService", func() {
 	var (
 		logbookService           LogbookService
@@ -27,6 +38,18 @@ var _ = Describe("LogbookService", func() {
 		passengerRepoMock        *repository.MockPassengerRepository
 		aircraftRepoCtrl         *gomock.Controller
 		aircraftRepoMock         *repository.MockAircraftRepository
+		userRepoCtrl             *gomock.Controller
+		userRepoMock             *repository.MockUserRepository
+		airportRepoCtrl          *gomock.Controller
+		airportRepoMock          *repository.MockAirportRepository
+		signatureRepoCtrl        *gomock.Controller
+		signatureRepoMock        *repository.MockSignatureRepository
+		flightVersionRepoCtrl    *gomock.Controller
+		flightVersionRepoMock    *repository.MockFlightVersionRepository
+		trashRepoCtrl            *gomock.Controller
+		trashRepoMock            *repository.MockTrashRepository
+		httpClientCtrl           *gomock.Controller
+		httpClientMock           *infrastructure.MockHTTPClient
 		databaseCtrl             *gomock.Controller
 		databaseMock             *infrastructure.MockDatabase
 		validator                *validator.Validate
@@ -43,6 +66,9 @@ var _ = Describe("LogbookService", func() {
 		mockInsertedLandingTwo   model.Landing
 		mockInsertedFlight       model.Flight
 		mockFlightBeforeUpdate   model.Flight
+		mockAircraft             model.Aircraft
+		expectedPassengers       []dto.PassengerResponse
+		expectedLandings         []dto.LandingResponse
 		startDate                time.Time
 		endDate                  time.Time
 	)
@@ -57,20 +83,44 @@ var _ = Describe("LogbookService", func() {
 		passengerRepoMock = repository.NewMockPassengerRepository(passengerRepoCtrl)
 		aircraftRepoCtrl = gomock.NewController(GinkgoT())
 		aircraftRepoMock = repository.NewMockAircraftRepository(aircraftRepoCtrl)
+		userRepoCtrl = gomock.NewController(GinkgoT())
+		userRepoMock = repository.NewMockUserRepository(userRepoCtrl)
+		airportRepoCtrl = gomock.NewController(GinkgoT())
+		airportRepoMock = repository.NewMockAirportRepository(airportRepoCtrl)
+		airportRepoMock.EXPECT().GetByCode(gomock.Any()).DoAndReturn(func(code string) (model.Airport, error) {
+			switch code {
+			case "WAW", "EPWA":
+				return model.Airport{ICAOCode: "EPWA", IATACode: util.String("WAW"), Latitude: 52.1657, Longitude: 20.9671}, nil
+			case "XXX":
+				return model.Airport{}, dto.ErrNotFound
+			}
+			return model.Airport{ICAOCode: code}, nil
+		}).AnyTimes()
+		signatureRepoCtrl = gomock.NewController(GinkgoT())
+		signatureRepoMock = repository.NewMockSignatureRepository(signatureRepoCtrl)
+		flightVersionRepoCtrl = gomock.NewController(GinkgoT())
+		flightVersionRepoMock = repository.NewMockFlightVersionRepository(flightVersionRepoCtrl)
+		trashRepoCtrl = gomock.NewController(GinkgoT())
+		trashRepoMock = repository.NewMockTrashRepository(trashRepoCtrl)
+		httpClientCtrl = gomock.NewController(GinkgoT())
+		httpClientMock = infrastructure.NewMockHTTPClient(httpClientCtrl)
 		databaseCtrl = gomock.NewController(GinkgoT())
 		databaseMock = infrastructure.NewMockDatabase(databaseCtrl)
 		validator = util.GetValidator()
-		logbookService = newLogbookService(flightRepoMock, landingRepoMock, passengerRepoMock, aircraftRepoMock, config.Config{}, validator)
+		logbookService = newLogbookService(flightRepoMock, landingRepoMock, passengerRepoMock, aircraftRepoMock,
+			userRepoMock, airportRepoMock, signatureRepoMock, flightVersionRepoMock, trashRepoMock, httpClientMock,
+			config.Config{SignatureHosts: []string{"example.com"}}, validator)
 		logbookRequest = dto.LogbookRequest{
 			AircraftID:          uint(1),
 			TakeoffTime:         fixedTime,
 			TakeoffAirportCode:  "SFO",
-			LandingTime:         fixedTime,
+			LandingTime:         fixedTime.Add(12 * time.Hour),
 			LandingAirportCode:  "LAX",
 			Style:               model.StyleY,
+			MyRole:              model.RolePilotInCommand,
 			Remarks:             util.String("Remarks"),
 			PersonalRemarks:     util.String("Personal Remarks"),
-			TotalBlockTime:      util.Duration(1 * time.Hour),
+			TotalBlockTime:      util.Duration(12 * time.Hour),
 			PilotInCommandTime:  util.Duration(2 * time.Hour),
 			SecondInCommandTime: util.Duration(3 * time.Hour),
 			DualReceivedTime:    util.Duration(4 * time.Hour),
@@ -78,11 +128,9 @@ var _ = Describe("LogbookService", func() {
 			MultiPilotTime:      util.Duration(6 * time.Hour),
 			NightTime:           util.Duration(7 * time.Hour),
 			IFRTime:             util.Duration(8 * time.Hour),
-			IFRActualTime:       util.Duration(9 * time.Hour),
-			IFRSimulatedTime:    util.Duration(10 * time.Hour),
+			IFRActualTime:       util.Duration(3 * time.Hour),
+			IFRSimulatedTime:    util.Duration(5 * time.Hour),
 			CrossCountryTime:    util.Duration(11 * time.Hour),
-			SimulatorTime:       util.Duration(12 * time.Hour),
-			SignatureURL:        util.String("https://signature.com"),
 			Passengers: []dto.PassengerEntry{
 				{
 					Role:         model.RolePilotInCommand,
@@ -106,14 +154,14 @@ var _ = Describe("LogbookService", func() {
 			Landings: []dto.LandingEntry{
 				{
 					ApproachType: model.ApproachTypeVisual,
-					Count:        util.Uint(1),
+					Count:        util.Uint(5),
 					NightCount:   util.Uint(2),
 					DayCount:     util.Uint(3),
 					AirportCode:  util.String("SFO"),
 				},
 				{
 					ApproachType: model.ApproachTypeVisual,
-					Count:        util.Uint(4),
+					Count:        util.Uint(11),
 					NightCount:   util.Uint(5),
 					DayCount:     util.Uint(6),
 					AirportCode:  util.String("LAX"),
@@ -125,12 +173,13 @@ var _ = Describe("LogbookService", func() {
 			AircraftID:          uint(1),
 			TakeoffTime:         fixedTime,
 			TakeoffAirportCode:  "SFO",
-			LandingTime:         fixedTime,
+			LandingTime:         fixedTime.Add(12 * time.Hour),
 			LandingAirportCode:  "LAX",
 			Style:               model.StyleY,
+			MyRole:              model.RolePilotInCommand,
 			Remarks:             util.String("Remarks"),
 			PersonalRemarks:     util.String("Personal Remarks"),
-			TotalBlockTime:      util.Duration(1 * time.Hour),
+			TotalBlockTime:      util.Duration(12 * time.Hour),
 			PilotInCommandTime:  util.Duration(2 * time.Hour),
 			SecondInCommandTime: util.Duration(3 * time.Hour),
 			DualReceivedTime:    util.Duration(4 * time.Hour),
@@ -138,11 +187,9 @@ var _ = Describe("LogbookService", func() {
 			MultiPilotTime:      util.Duration(6 * time.Hour),
 			NightTime:           util.Duration(7 * time.Hour),
 			IFRTime:             util.Duration(8 * time.Hour),
-			IFRActualTime:       util.Duration(9 * time.Hour),
-			IFRSimulatedTime:    util.Duration(10 * time.Hour),
+			IFRActualTime:       util.Duration(3 * time.Hour),
+			IFRSimulatedTime:    util.Duration(5 * time.Hour),
 			CrossCountryTime:    util.Duration(11 * time.Hour),
-			SimulatorTime:       util.Duration(12 * time.Hour),
-			SignatureURL:        util.String("https://signature.com"),
 		}
 		mockInsertedFlight = model.Flight{
 			Model:               gorm.Model{ID: uint(3)},
@@ -150,12 +197,13 @@ var _ = Describe("LogbookService", func() {
 			AircraftID:          uint(1),
 			TakeoffTime:         fixedTime,
 			TakeoffAirportCode:  "SFO",
-			LandingTime:         fixedTime,
+			LandingTime:         fixedTime.Add(12 * time.Hour),
 			LandingAirportCode:  "LAX",
 			Style:               model.StyleY,
+			MyRole:              model.RolePilotInCommand,
 			Remarks:             util.String("Remarks"),
 			PersonalRemarks:     util.String("Personal Remarks"),
-			TotalBlockTime:      util.Duration(1 * time.Hour),
+			TotalBlockTime:      util.Duration(12 * time.Hour),
 			PilotInCommandTime:  util.Duration(2 * time.Hour),
 			SecondInCommandTime: util.Duration(3 * time.Hour),
 			DualReceivedTime:    util.Duration(4 * time.Hour),
@@ -163,10 +211,9 @@ var _ = Describe("LogbookService", func() {
 			MultiPilotTime:      util.Duration(6 * time.Hour),
 			NightTime:           util.Duration(7 * time.Hour),
 			IFRTime:             util.Duration(8 * time.Hour),
-			IFRActualTime:       util.Duration(9 * time.Hour),
-			IFRSimulatedTime:    util.Duration(10 * time.Hour),
+			IFRActualTime:       util.Duration(3 * time.Hour),
+			IFRSimulatedTime:    util.Duration(5 * time.Hour),
 			CrossCountryTime:    util.Duration(11 * time.Hour),
-			SimulatorTime:       util.Duration(12 * time.Hour),
 			SignatureURL:        util.String("https://signature.com"),
 		}
 		mockPassengerOne = model.Passenger{
@@ -214,7 +261,7 @@ var _ = Describe("LogbookService", func() {
 		mockLandingOne = model.Landing{
 			FlightID:     uint(3),
 			ApproachType: model.ApproachTypeVisual,
-			Count:        util.Uint(1),
+			Count:        util.Uint(5),
 			NightCount:   util.Uint(2),
 			DayCount:     util.Uint(3),
 			AirportCode:  util.String("SFO"),
@@ -223,7 +270,7 @@ var _ = Describe("LogbookService", func() {
 			Model:        gorm.Model{ID: uint(1)},
 			FlightID:     uint(3),
 			ApproachType: model.ApproachTypeVisual,
-			Count:        util.Uint(1),
+			Count:        util.Uint(5),
 			NightCount:   util.Uint(2),
 			DayCount:     util.Uint(3),
 			AirportCode:  util.String("SFO"),
@@ -231,7 +278,7 @@ var _ = Describe("LogbookService", func() {
 		mockLandingTwo = model.Landing{
 			FlightID:     3,
 			ApproachType: model.ApproachTypeVisual,
-			Count:        util.Uint(4),
+			Count:        util.Uint(11),
 			NightCount:   util.Uint(5),
 			DayCount:     util.Uint(6),
 			AirportCode:  util.String("LAX"),
@@ -240,7 +287,7 @@ var _ = Describe("LogbookService", func() {
 			Model:        gorm.Model{ID: uint(2)},
 			FlightID:     uint(3),
 			ApproachType: model.ApproachTypeVisual,
-			Count:        util.Uint(4),
+			Count:        util.Uint(11),
 			NightCount:   util.Uint(5),
 			DayCount:     util.Uint(6),
 			AirportCode:  util.String("LAX"),
@@ -254,6 +301,7 @@ var _ = Describe("LogbookService", func() {
 			LandingTime:         fixedTime,
 			LandingAirportCode:  "LAX",
 			Style:               model.StyleY,
+			MyRole:              model.RolePilotInCommand,
 			Remarks:             util.String("MRemarks"),
 			PersonalRemarks:     util.String("Personal Remarks"),
 			TotalBlockTime:      util.Duration(6 * time.Hour),
@@ -270,6 +318,52 @@ var _ = Describe("LogbookService", func() {
 			SimulatorTime:       util.Duration(6 * time.Hour),
 			SignatureURL:        util.String("https://signature.com"),
 		}
+		mockAircraft = model.Aircraft{
+			Model:              gorm.Model{ID: uint(1)},
+			UserID:             "2",
+			RegistrationNumber: "SP-ABC",
+			AircraftModel:      "C152",
+		}
+		expectedPassengers = []dto.PassengerResponse{
+			{
+				ID:           uint(1),
+				Role:         model.RolePilotInCommand,
+				FirstName:    "John",
+				LastName:     util.String("Doe"),
+				Company:      util.String("Company"),
+				Phone:        util.String("1234567890"),
+				EmailAddress: util.String("test@test.com"),
+				Note:         util.String("Note"),
+			},
+			{
+				ID:           uint(2),
+				Role:         model.RoleSecondInCommand,
+				FirstName:    "Jane",
+				LastName:     util.String("Doe"),
+				Company:      util.String("Company"),
+				Phone:        util.String("1234567890"),
+				EmailAddress: util.String("testing@test.com"),
+				Note:         util.String("Note"),
+			},
+		}
+		expectedLandings = []dto.LandingResponse{
+			{
+				ID:           uint(1),
+				ApproachType: model.ApproachTypeVisual,
+				Count:        util.Uint(5),
+				NightCount:   util.Uint(2),
+				DayCount:     util.Uint(3),
+				AirportCode:  util.String("SFO"),
+			},
+			{
+				ID:           uint(2),
+				ApproachType: model.ApproachTypeVisual,
+				Count:        util.Uint(11),
+				NightCount:   util.Uint(5),
+				DayCount:     util.Uint(6),
+				AirportCode:  util.String("LAX"),
+			},
+		}
 		startDate = time.Date(2022, time.March, 25, 0, 0, 0, 0, time.UTC)
 		endDate = time.Date(2022, time.March, 28, 0, 0, 0, 0, time.UTC)
 	})
@@ -279,6 +373,12 @@ var _ = Describe("LogbookService", func() {
 		landingRepoCtrl.Finish()
 		passengerRepoCtrl.Finish()
 		aircraftRepoCtrl.Finish()
+		userRepoCtrl.Finish()
+		airportRepoCtrl.Finish()
+		signatureRepoCtrl.Finish()
+		flightVersionRepoCtrl.Finish()
+		trashRepoCtrl.Finish()
+		httpClientCtrl.Finish()
 		databaseCtrl.Finish()
 	})
 
@@ -286,14 +386,18 @@ var _ = Describe("LogbookService", func() {
 		Context("When the logbook entry is valid", func() {
 			It("Should return the logbook entry and no error", func() {
 				// given
-				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(mockAircraft, nil)
 				flightRepoMock.EXPECT().CreateTx(databaseMock, mockFlight).Return(mockInsertedFlight, nil)
 				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerOne).Return(mockInsertedPassengerOne, nil)
 				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerTwo).Return(mockInsertedPassengerTwo, nil)
 				landingRepoMock.EXPECT().CreateTx(databaseMock, mockLandingOne).Return(mockInsertedLandingOne, nil)
 				landingRepoMock.EXPECT().CreateTx(databaseMock, mockLandingTwo).Return(mockInsertedLandingTwo, nil)
 
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).Return([]model.Flight{}, nil)
 				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "2").Return(model.FlightVersion{}, dto.ErrNotFound)
+				flightVersionRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(returnFlightVersion)
 				databaseMock.EXPECT().Commit().Return(&gorm.DB{Error: nil})
 
 				// when
@@ -301,7 +405,10 @@ var _ = Describe("LogbookService", func() {
 
 				// then
 				Expect(err).To(BeNil())
+				Expect(logbookResponse.ID).To(Equal(uint(3)))
 				Expect(logbookResponse.AircraftID).To(Equal(uint(1)))
+				Expect(logbookResponse.Aircraft).To(Equal(dto.AircraftSummary{ID: 1, RegistrationNumber: "SP-ABC", AircraftModel: "C152"}))
+				Expect(logbookResponse.MyRole).To(Equal(model.RolePilotInCommand))
 				Expect(logbookResponse).ToNot(BeNil())
 				Expect(logbookResponse.TakeoffTime).To(Equal(logbookRequest.TakeoffTime))
 				Expect(logbookResponse.TakeoffAirportCode).To(Equal(logbookRequest.TakeoffAirportCode))
@@ -322,11 +429,8 @@ var _ = Describe("LogbookService", func() {
 				Expect(logbookResponse.IFRSimulatedTime).To(Equal(logbookRequest.IFRSimulatedTime))
 				Expect(logbookResponse.CrossCountryTime).To(Equal(logbookRequest.CrossCountryTime))
 				Expect(logbookResponse.SimulatorTime).To(Equal(logbookRequest.SimulatorTime))
-				Expect(logbookResponse.SignatureURL).To(Equal(logbookRequest.SignatureURL))
-				Expect(logbookResponse.Passengers).To(HaveLen(2))
-				Expect(logbookResponse.Passengers).To(Equal(logbookRequest.Passengers))
-				Expect(logbookResponse.Landings).To(HaveLen(2))
-				Expect(logbookResponse.Landings).To(Equal(logbookRequest.Landings))
+				Expect(logbookResponse.Passengers).To(Equal(expectedPassengers))
+				Expect(logbookResponse.Landings).To(Equal(expectedLandings))
 
 			})
 		})
@@ -340,7 +444,11 @@ var _ = Describe("LogbookService", func() {
 				landingRepoMock.EXPECT().CreateTx(databaseMock, mockLandingOne).Return(mockInsertedLandingOne, nil)
 				landingRepoMock.EXPECT().CreateTx(databaseMock, mockLandingTwo).Return(mockInsertedLandingTwo, nil)
 
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).Return([]model.Flight{}, nil)
 				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "2").Return(model.FlightVersion{}, dto.ErrNotFound)
+				flightVersionRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(returnFlightVersion)
 				databaseMock.EXPECT().Commit().Return(&gorm.DB{Error: errors.New("failed to commit")})
 
 				// when
@@ -352,6 +460,293 @@ var _ = Describe("LogbookService", func() {
 				Expect(err.Error()).To(Equal("internal failure: failed to commit"))
 			})
 		})
+		Context("when the entry is stored", func() {
+			It("Should append the created entry to the history", func() {
+				// given
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(mockAircraft, nil)
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).Return([]model.Flight{}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightRepoMock.EXPECT().CreateTx(databaseMock, mockFlight).Return(mockInsertedFlight, nil)
+				passengerRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).Return(mockInsertedPassengerOne, nil).Times(2)
+				landingRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).Return(mockInsertedLandingOne, nil).Times(2)
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "2").Return(model.FlightVersion{Sequence: 8, Hash: "head"}, nil)
+				flightVersionRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(func(_ infrastructure.Database, version model.FlightVersion) (model.FlightVersion, error) {
+					Expect(version.UserID).To(Equal("2"))
+					Expect(version.Sequence).To(Equal(uint(9)))
+					Expect(version.FlightID).To(Equal(uint(3)))
+					Expect(version.Action).To(Equal(model.FlightVersionActionCreated))
+					Expect(version.PreviousHash).To(Equal("head"))
+					Expect(version.Hash).To(Equal(hashFlightVersion(version)))
+					Expect(version.Snapshot).To(ContainSubstring(`"takeoff_airport_code":"SFO"`))
+					return version, nil
+				})
+				databaseMock.EXPECT().Commit().Return(&gorm.DB{Error: nil})
+
+				// when
+				_, err := logbookService.InsertLogbookEntry("2", logbookRequest)
+
+				// then
+				Expect(err).To(BeNil())
+			})
+		})
+		Context("when recording the history fails", func() {
+			It("Should roll back and return an error", func() {
+				// given
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(mockAircraft, nil)
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).Return([]model.Flight{}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightRepoMock.EXPECT().CreateTx(databaseMock, mockFlight).Return(mockInsertedFlight, nil)
+				passengerRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).Return(mockInsertedPassengerOne, nil).Times(2)
+				landingRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).Return(mockInsertedLandingOne, nil).Times(2)
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "2").Return(model.FlightVersion{}, dto.ErrNotFound)
+				flightVersionRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).Return(model.FlightVersion{}, dto.ErrInternalFailure)
+				databaseMock.EXPECT().Rollback().Return(&gorm.DB{})
+
+				// when
+				logbookResponse, err := logbookService.InsertLogbookEntry("2", logbookRequest)
+
+				// then
+				Expect(err).To(Equal(dto.ErrInternalFailure))
+				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
+			})
+		})
+		Context("When airport codes are IATA codes", func() {
+			It("Should store ICAO codes of the airports", func() {
+				// given
+				logbookRequest.TakeoffAirportCode = "WAW"
+				logbookRequest.Landings[0].AirportCode = util.String("WAW")
+				mockFlight.TakeoffAirportCode = "EPWA"
+				mockInsertedFlight.TakeoffAirportCode = "EPWA"
+				mockLandingOne.AirportCode = util.String("EPWA")
+				mockInsertedLandingOne.AirportCode = util.String("EPWA")
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
+				flightRepoMock.EXPECT().CreateTx(databaseMock, mockFlight).Return(mockInsertedFlight, nil)
+				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerOne).Return(mockInsertedPassengerOne, nil)
+				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerTwo).Return(mockInsertedPassengerTwo, nil)
+				landingRepoMock.EXPECT().CreateTx(databaseMock, mockLandingOne).Return(mockInsertedLandingOne, nil)
+				landingRepoMock.EXPECT().CreateTx(databaseMock, mockLandingTwo).Return(mockInsertedLandingTwo, nil)
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).Return([]model.Flight{}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "2").Return(model.FlightVersion{}, dto.ErrNotFound)
+				flightVersionRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(returnFlightVersion)
+				databaseMock.EXPECT().Commit().Return(&gorm.DB{Error: nil})
+
+				// when
+				logbookResponse, err := logbookService.InsertLogbookEntry("2", logbookRequest)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(logbookResponse.TakeoffAirportCode).To(Equal("EPWA"))
+				Expect(logbookResponse.Landings[0].AirportCode).To(Equal(util.String("EPWA")))
+				Expect(logbookRequest.Landings[0].AirportCode).To(Equal(util.String("WAW")))
+			})
+		})
+		Context("When night time and the split of landings are omitted", func() {
+			It("Should fill them in from civil twilight", func() {
+				// given
+				takeoffTime := time.Date(2024, time.June, 21, 20, 0, 0, 0, time.UTC)
+				logbookRequest = dto.LogbookRequest{
+					AircraftID:         uint(1),
+					TakeoffTime:        takeoffTime,
+					TakeoffAirportCode: "EPWA",
+					LandingTime:        takeoffTime.Add(90 * time.Minute),
+					LandingAirportCode: "EPWA",
+					Style:              model.StyleY,
+					MyRole:             model.RolePilotInCommand,
+					TotalBlockTime:     util.Duration(90 * time.Minute),
+					PilotInCommandTime: util.Duration(90 * time.Minute),
+					Landings:           []dto.LandingEntry{{ApproachType: model.ApproachTypeVisual, Count: util.Uint(1)}},
+				}
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).Return([]model.Flight{}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(func(tx infrastructure.Database, flight model.Flight) (model.Flight, error) {
+					Expect(flight.NightTime).To(Equal(util.Duration(90 * time.Minute)))
+					flight.ID = 3
+					return flight, nil
+				})
+				landingRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(func(tx infrastructure.Database, landing model.Landing) (model.Landing, error) {
+					Expect(landing.NightCount).To(Equal(util.Uint(1)))
+					Expect(landing.DayCount).To(Equal(util.Uint(0)))
+					return landing, nil
+				})
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "2").Return(model.FlightVersion{}, dto.ErrNotFound)
+				flightVersionRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(returnFlightVersion)
+				databaseMock.EXPECT().Commit().Return(&gorm.DB{Error: nil})
+
+				// when
+				logbookResponse, err := logbookService.InsertLogbookEntry("2", logbookRequest)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(logbookResponse.NightTime).To(Equal(util.Duration(90 * time.Minute)))
+				Expect(logbookRequest.Landings[0].NightCount).To(BeNil())
+			})
+		})
+		Context("When block time and role times are omitted", func() {
+			It("Should fill them in if the user enabled it", func() {
+				// given
+				logbookRequest.TotalBlockTime = nil
+				logbookRequest.PilotInCommandTime = nil
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
+				userRepoMock.EXPECT().GetByID("2").Return(model.User{ID: "2", AutoFillTimes: true}, nil)
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).Return([]model.Flight{}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(func(tx infrastructure.Database, flight model.Flight) (model.Flight, error) {
+					Expect(flight.TotalBlockTime).To(Equal(util.Duration(12 * time.Hour)))
+					Expect(flight.PilotInCommandTime).To(Equal(util.Duration(12 * time.Hour)))
+					Expect(flight.SecondInCommandTime).To(Equal(util.Duration(3 * time.Hour)))
+					flight.ID = 3
+					return flight, nil
+				})
+				passengerRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).Return(mockInsertedPassengerOne, nil).Times(2)
+				landingRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).Return(mockInsertedLandingOne, nil).Times(2)
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "2").Return(model.FlightVersion{}, dto.ErrNotFound)
+				flightVersionRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(returnFlightVersion)
+				databaseMock.EXPECT().Commit().Return(&gorm.DB{Error: nil})
+
+				// when
+				logbookResponse, err := logbookService.InsertLogbookEntry("2", logbookRequest)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(logbookResponse.TotalBlockTime).To(Equal(util.Duration(12 * time.Hour)))
+				Expect(logbookResponse.PilotInCommandTime).To(Equal(util.Duration(12 * time.Hour)))
+			})
+			It("Should leave them empty if the user disabled it", func() {
+				// given
+				logbookRequest.TotalBlockTime = nil
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
+				userRepoMock.EXPECT().GetByID("2").Return(model.User{ID: "2", AutoFillTimes: false}, nil)
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).Return([]model.Flight{}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(func(tx infrastructure.Database, flight model.Flight) (model.Flight, error) {
+					Expect(flight.TotalBlockTime).To(BeNil())
+					flight.ID = 3
+					return flight, nil
+				})
+				passengerRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).Return(mockInsertedPassengerOne, nil).Times(2)
+				landingRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).Return(mockInsertedLandingOne, nil).Times(2)
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "2").Return(model.FlightVersion{}, dto.ErrNotFound)
+				flightVersionRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(returnFlightVersion)
+				databaseMock.EXPECT().Commit().Return(&gorm.DB{Error: nil})
+
+				// when
+				logbookResponse, err := logbookService.InsertLogbookEntry("2", logbookRequest)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(logbookResponse.TotalBlockTime).To(BeNil())
+			})
+		})
+		Context("When the entry duplicates or overlaps existing entries", func() {
+			It("Should return a conflict with the clashing entries", func() {
+				// given
+				duplicate := model.Flight{Model: gorm.Model{ID: 7}, AircraftID: 1, TakeoffTime: fixedTime.Add(3 * time.Minute),
+					TakeoffAirportCode: "SFO", LandingTime: fixedTime.Add(12 * time.Hour), LandingAirportCode: "LAX"}
+				overlap := model.Flight{Model: gorm.Model{ID: 8}, AircraftID: 2, TakeoffTime: fixedTime.Add(11 * time.Hour),
+					TakeoffAirportCode: "LAX", LandingTime: fixedTime.Add(13 * time.Hour), LandingAirportCode: "SAN"}
+				adjacent := model.Flight{Model: gorm.Model{ID: 9}, AircraftID: 1, TakeoffTime: fixedTime.Add(12 * time.Hour),
+					TakeoffAirportCode: "LAX", LandingTime: fixedTime.Add(14 * time.Hour), LandingAirportCode: "SFO"}
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", fixedTime.Add(-5*time.Minute), fixedTime.Add(12*time.Hour+5*time.Minute)).
+					Return([]model.Flight{duplicate, overlap, adjacent}, nil)
+				databaseMock.EXPECT().Rollback().Return(&gorm.DB{})
+
+				// when
+				logbookResponse, err := logbookService.InsertLogbookEntry("2", logbookRequest)
+
+				// then
+				Expect(errors.Is(err, dto.ErrConflict)).To(BeTrue())
+				Expect(err.Error()).To(Equal("conflict: entry conflicts with logbook entries: 7, 8"))
+				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
+				var conflictError *dto.ConflictError
+				Expect(errors.As(err, &conflictError)).To(BeTrue())
+				Expect(conflictError.Conflicts).To(Equal([]dto.FlightConflict{
+					{Kind: dto.ConflictDuplicate, Flight: dto.FlightSummary{ID: 7, AircraftID: 1, TakeoffTime: fixedTime.Add(3 * time.Minute),
+						TakeoffAirportCode: "SFO", LandingTime: fixedTime.Add(12 * time.Hour), LandingAirportCode: "LAX"}},
+					{Kind: dto.ConflictOverlap, Flight: dto.FlightSummary{ID: 8, AircraftID: 2, TakeoffTime: fixedTime.Add(11 * time.Hour),
+						TakeoffAirportCode: "LAX", LandingTime: fixedTime.Add(13 * time.Hour), LandingAirportCode: "SAN"}},
+				}))
+			})
+			It("Should insert the entry if the client allowed conflicts", func() {
+				// given
+				logbookRequest.AllowConflicts = true
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightRepoMock.EXPECT().CreateTx(databaseMock, mockFlight).Return(mockInsertedFlight, nil)
+				passengerRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).Return(mockInsertedPassengerOne, nil).Times(2)
+				landingRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).Return(mockInsertedLandingOne, nil).Times(2)
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "2").Return(model.FlightVersion{}, dto.ErrNotFound)
+				flightVersionRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(returnFlightVersion)
+				databaseMock.EXPECT().Commit().Return(&gorm.DB{Error: nil})
+
+				// when
+				_, err := logbookService.InsertLogbookEntry("2", logbookRequest)
+
+				// then
+				Expect(err).To(BeNil())
+			})
+		})
+		Context("When checking conflicts failed", func() {
+			It("Should return an error", func() {
+				// given
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).Return(nil, dto.ErrInternalFailure)
+				databaseMock.EXPECT().Rollback().Return(&gorm.DB{})
+
+				// when
+				logbookResponse, err := logbookService.InsertLogbookEntry("2", logbookRequest)
+
+				// then
+				Expect(err).To(Equal(dto.ErrInternalFailure))
+				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
+			})
+		})
+		Context("When the airport code is unknown", func() {
+			It("Should store the code as given without computing the night time", func() {
+				// given
+				logbookRequest.LandingAirportCode = "XXX"
+				logbookRequest.NightTime = nil
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(mockAircraft, nil)
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).Return([]model.Flight{}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(
+					func(_ infrastructure.Database, flight model.Flight) (model.Flight, error) {
+						Expect(flight.LandingAirportCode).To(Equal("XXX"))
+						Expect(flight.NightTime).To(BeNil())
+						flight.ID = 3
+						return flight, nil
+					})
+				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerOne).Return(mockInsertedPassengerOne, nil)
+				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerTwo).Return(mockInsertedPassengerTwo, nil)
+				landingRepoMock.EXPECT().CreateTx(databaseMock, mockLandingOne).Return(mockInsertedLandingOne, nil)
+				landingRepoMock.EXPECT().CreateTx(databaseMock, mockLandingTwo).Return(mockInsertedLandingTwo, nil)
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "2").Return(model.FlightVersion{}, dto.ErrNotFound)
+				flightVersionRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(returnFlightVersion)
+				databaseMock.EXPECT().Commit().Return(&gorm.DB{Error: nil})
+
+				// when
+				logbookResponse, err := logbookService.InsertLogbookEntry("2", logbookRequest)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(logbookResponse.LandingAirportCode).To(Equal("XXX"))
+				Expect(logbookResponse.NightTime).To(BeNil())
+			})
+		})
 		// it also covers the case when the user does not provide the aircraft id in the request
 		Context("When the aircraft does not exist or the user does not own the aircraft", func() {
 			It("Should return an error", func() {
@@ -367,12 +762,36 @@ var _ = Describe("LogbookService", func() {
 				Expect(err.Error()).To(Equal("aircraft not found"))
 			})
 		})
+		Context("When the entry has inconsistent times and landings", func() {
+			It("Should return all invalid fields without starting a transaction", func() {
+				// given
+				logbookRequest.LandingTime = fixedTime.Add(-time.Hour)
+				logbookRequest.NightTime = util.Duration(13 * time.Hour)
+				logbookRequest.Landings[1].Count = util.Uint(1)
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
+
+				// when
+				logbookResponse, err := logbookService.InsertLogbookEntry("2", logbookRequest)
+
+				// then
+				Expect(err).ToNot(BeNil())
+				Expect(errors.Is(err, dto.ErrBadRequest)).To(BeTrue())
+				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
+				Expect(err.Error()).To(Equal("bad request: invalid data in fields: landing_time, night_time, landings[1].count"))
+				var validationError *dto.ValidationError
+				Expect(errors.As(err, &validationError)).To(BeTrue())
+				Expect(validationError.Errors).To(Equal([]dto.FieldError{
+					{Field: "landing_time", Code: dto.CodeBeforeTakeoffTime},
+					{Field: "night_time", Code: dto.CodeExceedsTotalBlockTime},
+					{Field: "landings[1].count", Code: dto.CodeLessThanDayAndNightCount},
+				}))
+			})
+		})
 		Context("When flight to insert missing takeoff time", func() {
 			It("Should return an error", func() {
 				// given
 				logbookRequest.TakeoffTime = time.Time{}
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
-				flightRepoMock.EXPECT().Begin().Return(databaseMock)
 
 				// when
 				logbookResponse, err := logbookService.InsertLogbookEntry("2", logbookRequest)
@@ -380,7 +799,7 @@ var _ = Describe("LogbookService", func() {
 				// then
 				Expect(err).ToNot(BeNil())
 				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
-				Expect(err.Error()).To(Equal("bad request: invalid data in field: TakeoffTime"))
+				Expect(err.Error()).To(Equal("bad request: invalid data in field: takeoff_time"))
 			})
 		})
 		Context("When flight to insert missing takeoff airport code", func() {
@@ -388,7 +807,6 @@ var _ = Describe("LogbookService", func() {
 				// given
 				logbookRequest.TakeoffAirportCode = ""
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
-				flightRepoMock.EXPECT().Begin().Return(databaseMock)
 
 				// when
 				logbookResponse, err := logbookService.InsertLogbookEntry("2", logbookRequest)
@@ -396,7 +814,7 @@ var _ = Describe("LogbookService", func() {
 				// then
 				Expect(err).ToNot(BeNil())
 				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
-				Expect(err.Error()).To(Equal("bad request: invalid data in field: TakeoffAirportCode"))
+				Expect(err.Error()).To(Equal("bad request: invalid data in field: takeoff_airport_code"))
 			})
 		})
 		Context("When flight to insert missing landing time", func() {
@@ -404,7 +822,6 @@ var _ = Describe("LogbookService", func() {
 				// given
 				logbookRequest.LandingTime = time.Time{}
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
-				flightRepoMock.EXPECT().Begin().Return(databaseMock)
 
 				// when
 				logbookResponse, err := logbookService.InsertLogbookEntry("2", logbookRequest)
@@ -412,7 +829,7 @@ var _ = Describe("LogbookService", func() {
 				// then
 				Expect(err).ToNot(BeNil())
 				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
-				Expect(err.Error()).To(Equal("bad request: invalid data in field: LandingTime"))
+				Expect(err.Error()).To(Equal("bad request: invalid data in field: landing_time"))
 			})
 		})
 		Context("When flight to insert missing landing airport code", func() {
@@ -420,7 +837,6 @@ var _ = Describe("LogbookService", func() {
 				// given
 				logbookRequest.LandingAirportCode = ""
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
-				flightRepoMock.EXPECT().Begin().Return(databaseMock)
 
 				// when
 				logbookResponse, err := logbookService.InsertLogbookEntry("2", logbookRequest)
@@ -428,7 +844,7 @@ var _ = Describe("LogbookService", func() {
 				// then
 				Expect(err).ToNot(BeNil())
 				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
-				Expect(err.Error()).To(Equal("bad request: invalid data in field: LandingAirportCode"))
+				Expect(err.Error()).To(Equal("bad request: invalid data in field: landing_airport_code"))
 			})
 		})
 		Context("When flight to insert missing style", func() {
@@ -436,7 +852,6 @@ var _ = Describe("LogbookService", func() {
 				// given
 				logbookRequest.Style = ""
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
-				flightRepoMock.EXPECT().Begin().Return(databaseMock)
 
 				// when
 				logbookResponse, err := logbookService.InsertLogbookEntry("2", logbookRequest)
@@ -444,7 +859,7 @@ var _ = Describe("LogbookService", func() {
 				// then
 				Expect(err).ToNot(BeNil())
 				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
-				Expect(err.Error()).To(Equal("bad request: invalid data in field: Style"))
+				Expect(err.Error()).To(Equal("bad request: invalid data in field: style"))
 
 			})
 		})
@@ -453,7 +868,6 @@ var _ = Describe("LogbookService", func() {
 				// given
 				logbookRequest.Style = "invalidStyle"
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
-				flightRepoMock.EXPECT().Begin().Return(databaseMock)
 
 				// when
 				logbookResponse, err := logbookService.InsertLogbookEntry("2", logbookRequest)
@@ -461,7 +875,7 @@ var _ = Describe("LogbookService", func() {
 				// then
 				Expect(err).ToNot(BeNil())
 				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
-				Expect(err.Error()).To(Equal("bad request: invalid data in field: Style"))
+				Expect(err.Error()).To(Equal("bad request: invalid data in field: style"))
 			})
 		})
 		Context("when creating flight failed", func() {
@@ -469,7 +883,9 @@ var _ = Describe("LogbookService", func() {
 				// given
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
 				flightRepoMock.EXPECT().CreateTx(databaseMock, mockFlight).Return(model.Flight{}, errors.New("failed to create flight"))
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).Return([]model.Flight{}, nil)
 				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
 				databaseMock.EXPECT().Rollback()
 
 				// when
@@ -483,13 +899,10 @@ var _ = Describe("LogbookService", func() {
 		})
 
 		Context("when passenger to insert missing role", func() {
-			It("Should return an error and rollback transaction", func() {
+			It("Should return an error", func() {
 				// given
 				logbookRequest.Passengers[0].Role = ""
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
-				flightRepoMock.EXPECT().CreateTx(databaseMock, mockFlight).Return(mockInsertedFlight, nil)
-				flightRepoMock.EXPECT().Begin().Return(databaseMock)
-				databaseMock.EXPECT().Rollback()
 
 				// when
 				logbookResponse, err := logbookService.InsertLogbookEntry("2", logbookRequest)
@@ -497,17 +910,14 @@ var _ = Describe("LogbookService", func() {
 				// then
 				Expect(err).ToNot(BeNil())
 				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
-				Expect(err.Error()).To(Equal("bad request: invalid data in field: Role"))
+				Expect(err.Error()).To(Equal("bad request: invalid data in field: passengers[0].role"))
 			})
 		})
 		Context("when passenger to insert have invalid role", func() {
-			It("Should return an error and rollback transaction", func() {
+			It("Should return an error", func() {
 				// given
 				logbookRequest.Passengers[0].Role = "invalidRole"
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
-				flightRepoMock.EXPECT().CreateTx(databaseMock, mockFlight).Return(mockInsertedFlight, nil)
-				flightRepoMock.EXPECT().Begin().Return(databaseMock)
-				databaseMock.EXPECT().Rollback()
 
 				// when
 				logbookResponse, err := logbookService.InsertLogbookEntry("2", logbookRequest)
@@ -515,17 +925,14 @@ var _ = Describe("LogbookService", func() {
 				// then
 				Expect(err).ToNot(BeNil())
 				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
-				Expect(err.Error()).To(Equal("bad request: invalid data in field: Role"))
+				Expect(err.Error()).To(Equal("bad request: invalid data in field: passengers[0].role"))
 			})
 		})
 		Context("when passenger to insert missing first name", func() {
-			It("Should return an error and rollback transaction", func() {
+			It("Should return an error", func() {
 				// given
 				logbookRequest.Passengers[0].FirstName = ""
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
-				flightRepoMock.EXPECT().CreateTx(databaseMock, mockFlight).Return(mockInsertedFlight, nil)
-				flightRepoMock.EXPECT().Begin().Return(databaseMock)
-				databaseMock.EXPECT().Rollback()
 
 				// when
 				logbookResponse, err := logbookService.InsertLogbookEntry("2", logbookRequest)
@@ -533,7 +940,7 @@ var _ = Describe("LogbookService", func() {
 				// then
 				Expect(err).ToNot(BeNil())
 				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
-				Expect(err.Error()).To(Equal("bad request: invalid data in field: FirstName"))
+				Expect(err.Error()).To(Equal("bad request: invalid data in field: passengers[0].first_name"))
 			})
 		})
 		Context("when creating passenger failed", func() {
@@ -542,7 +949,9 @@ var _ = Describe("LogbookService", func() {
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
 				flightRepoMock.EXPECT().CreateTx(databaseMock, mockFlight).Return(mockInsertedFlight, nil)
 				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerOne).Return(model.Passenger{}, errors.New("failed to create passenger"))
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).Return([]model.Flight{}, nil)
 				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
 				databaseMock.EXPECT().Rollback()
 
 				// when
@@ -555,15 +964,10 @@ var _ = Describe("LogbookService", func() {
 			})
 		})
 		Context("when landing to insert missing approach type", func() {
-			It("Should return an error and rollback transaction", func() {
+			It("Should return an error", func() {
 				// given
 				logbookRequest.Landings[0].ApproachType = ""
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
-				flightRepoMock.EXPECT().CreateTx(databaseMock, mockFlight).Return(mockInsertedFlight, nil)
-				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerOne).Return(mockInsertedPassengerOne, nil)
-				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerTwo).Return(mockInsertedPassengerTwo, nil)
-				flightRepoMock.EXPECT().Begin().Return(databaseMock)
-				databaseMock.EXPECT().Rollback()
 
 				// when
 				logbookResponse, err := logbookService.InsertLogbookEntry("2", logbookRequest)
@@ -571,26 +975,21 @@ var _ = Describe("LogbookService", func() {
 				// then
 				Expect(err).ToNot(BeNil())
 				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
-				Expect(err.Error()).To(Equal("bad request: invalid data in field: ApproachType"))
+				Expect(err.Error()).To(Equal("bad request: invalid data in field: landings[0].approach_type"))
 			})
 		})
 		Context("when landing to insert have invalid approach type", func() {
-			It("Should return an error and rollback transaction", func() {
+			It("Should return an error", func() {
 				// given
 				logbookRequest.Landings[0].ApproachType = "invalidApproachType"
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
-				flightRepoMock.EXPECT().CreateTx(databaseMock, mockFlight).Return(mockInsertedFlight, nil)
-				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerOne).Return(mockInsertedPassengerOne, nil)
-				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerTwo).Return(mockInsertedPassengerTwo, nil)
-				flightRepoMock.EXPECT().Begin().Return(databaseMock)
-				databaseMock.EXPECT().Rollback()
 
 				// when
 				logbookResponse, err := logbookService.InsertLogbookEntry("2", logbookRequest)
 				// then
 				Expect(err).ToNot(BeNil())
 				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
-				Expect(err.Error()).To(Equal("bad request: invalid data in field: ApproachType"))
+				Expect(err.Error()).To(Equal("bad request: invalid data in field: landings[0].approach_type"))
 			})
 		})
 		Context("when creating landing failed", func() {
@@ -601,7 +1000,9 @@ var _ = Describe("LogbookService", func() {
 				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerOne).Return(mockInsertedPassengerOne, nil)
 				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerTwo).Return(mockInsertedPassengerTwo, nil)
 				landingRepoMock.EXPECT().CreateTx(databaseMock, mockLandingOne).Return(model.Landing{}, errors.New("failed to create landing"))
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).Return([]model.Flight{}, nil)
 				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
 				databaseMock.EXPECT().Rollback()
 
 				// when
@@ -616,36 +1017,137 @@ var _ = Describe("LogbookService", func() {
 	})
 
 	Describe("DeleteLogbookEntry", func() {
+		BeforeEach(func() {
+			mockInsertedFlight.ID = 1
+		})
+
 		Context("when deleting goes well", func() {
 			It("Should return no error", func() {
 				// given
 				flightRepoMock.EXPECT().GetByID(uint(1)).Return(mockInsertedFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(1)).Return(model.Signature{}, dto.ErrNotFound)
+				landingRepoMock.EXPECT().GetByFlightID(uint(1)).Return([]model.Landing{mockInsertedLandingOne}, nil)
+				passengerRepoMock.EXPECT().GetByFlightID(uint(1)).Return([]model.Passenger{mockInsertedPassengerOne}, nil)
 				flightRepoMock.EXPECT().Begin().Return(databaseMock)
-				landingRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(1)).Return(nil)
-				passengerRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(1)).Return(nil)
-				flightRepoMock.EXPECT().DeleteByIDTx(databaseMock, uint(1)).Return(nil)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				var deletedAt time.Time
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(1)).Return(mockInsertedFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(1)).Return(model.Signature{}, dto.ErrNotFound)
+				trashRepoMock.EXPECT().DeleteFlightTx(databaseMock, uint(1), gomock.Any()).DoAndReturn(func(_ infrastructure.Database, _ uint, at time.Time) error {
+					deletedAt = at
+					return nil
+				})
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "2").Return(model.FlightVersion{}, dto.ErrNotFound)
+				flightVersionRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(func(_ infrastructure.Database, version model.FlightVersion) (model.FlightVersion, error) {
+					Expect(version.RecordedAt).To(Equal(deletedAt.UTC().Round(time.Microsecond)))
+					Expect(version.Sequence).To(Equal(uint(1)))
+					Expect(version.FlightID).To(Equal(uint(1)))
+					Expect(version.Action).To(Equal(model.FlightVersionActionDeleted))
+					Expect(version.PreviousHash).To(Equal(genesisHash))
+					Expect(version.Snapshot).To(ContainSubstring(`"passengers":[{`))
+					Expect(version.Snapshot).To(ContainSubstring(`"landings":[{`))
+					return version, nil
+				})
 				databaseMock.EXPECT().Commit().Return(&gorm.DB{Error: nil})
 
 				// when
-				err := logbookService.DeleteLogbookEntry("2", uint(1))
+				err := logbookService.DeleteLogbookEntry("2", uint(1), nil)
 
 				// then
 				Expect(err).To(BeNil())
 
 			})
 		})
+		Context("when the flight is signed", func() {
+			It("Should return a conflict and not delete the flight", func() {
+				// given
+				flightRepoMock.EXPECT().GetByID(uint(1)).Return(mockInsertedFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(1)).Return(model.Signature{FlightID: 1, Status: model.SignatureStatusSigned}, nil)
+
+				// when
+				err := logbookService.DeleteLogbookEntry("2", uint(1), nil)
+
+				// then
+				Expect(errors.Is(err, dto.ErrConflict)).To(BeTrue())
+				Expect(err.Error()).To(Equal("conflict: flight is signed and cannot be deleted"))
+			})
+		})
+		Context("when the flight is signed after the deletion was prepared", func() {
+			It("Should roll back and return a conflict", func() {
+				// given
+				flightRepoMock.EXPECT().GetByID(uint(1)).Return(mockInsertedFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(1)).Return(model.Signature{}, dto.ErrNotFound)
+				landingRepoMock.EXPECT().GetByFlightID(uint(1)).Return([]model.Landing{}, nil)
+				passengerRepoMock.EXPECT().GetByFlightID(uint(1)).Return([]model.Passenger{}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(1)).Return(mockInsertedFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(1)).
+					Return(model.Signature{FlightID: 1, Status: model.SignatureStatusSigned}, nil)
+				databaseMock.EXPECT().Rollback().Return(&gorm.DB{})
+
+				// when
+				err := logbookService.DeleteLogbookEntry("2", uint(1), nil)
+
+				// then
+				Expect(err).To(MatchError("conflict: flight is signed and cannot be deleted"))
+			})
+		})
+		Context("when the flight changed since the given version", func() {
+			It("Should return precondition failed without deleting the flight", func() {
+				// given
+				version := mockInsertedFlight.UpdatedAt.Add(-time.Hour)
+				flightRepoMock.EXPECT().GetByID(uint(1)).Return(mockInsertedFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(1)).Return(model.Signature{}, dto.ErrNotFound)
+
+				// when
+				err := logbookService.DeleteLogbookEntry("2", uint(1), &version)
+
+				// then
+				Expect(errors.Is(err, dto.ErrPreconditionFailed)).To(BeTrue())
+			})
+		})
+		Context("when the flight changes after the deletion was prepared", func() {
+			It("Should roll back and return precondition failed", func() {
+				// given
+				version := mockInsertedFlight.UpdatedAt
+				changedFlight := mockInsertedFlight
+				changedFlight.UpdatedAt = version.Add(time.Minute)
+				flightRepoMock.EXPECT().GetByID(uint(1)).Return(mockInsertedFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(1)).Return(model.Signature{}, dto.ErrNotFound)
+				landingRepoMock.EXPECT().GetByFlightID(uint(1)).Return([]model.Landing{}, nil)
+				passengerRepoMock.EXPECT().GetByFlightID(uint(1)).Return([]model.Passenger{}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(1)).Return(changedFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(1)).Return(model.Signature{}, dto.ErrNotFound)
+				databaseMock.EXPECT().Rollback().Return(&gorm.DB{})
+
+				// when
+				err := logbookService.DeleteLogbookEntry("2", uint(1), &version)
+
+				// then
+				Expect(errors.Is(err, dto.ErrPreconditionFailed)).To(BeTrue())
+			})
+		})
 		Context("when commit fails", func() {
 			It("Should return an error", func() {
 				// given
 				flightRepoMock.EXPECT().GetByID(uint(1)).Return(mockInsertedFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(1)).Return(model.Signature{}, dto.ErrNotFound)
+				landingRepoMock.EXPECT().GetByFlightID(uint(1)).Return([]model.Landing{mockInsertedLandingOne}, nil)
+				passengerRepoMock.EXPECT().GetByFlightID(uint(1)).Return([]model.Passenger{mockInsertedPassengerOne}, nil)
 				flightRepoMock.EXPECT().Begin().Return(databaseMock)
-				landingRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(1)).Return(nil)
-				passengerRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(1)).Return(nil)
-				flightRepoMock.EXPECT().DeleteByIDTx(databaseMock, uint(1)).Return(nil)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(1)).Return(mockInsertedFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(1)).Return(model.Signature{}, dto.ErrNotFound)
+				trashRepoMock.EXPECT().DeleteFlightTx(databaseMock, uint(1), gomock.Any()).Return(nil)
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "2").Return(model.FlightVersion{}, dto.ErrNotFound)
+				flightVersionRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(returnFlightVersion)
 				databaseMock.EXPECT().Commit().Return(&gorm.DB{Error: errors.New("failed to commit")})
 
 				// when
-				err := logbookService.DeleteLogbookEntry("2", uint(1))
+				err := logbookService.DeleteLogbookEntry("2", uint(1), nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
@@ -658,7 +1160,7 @@ var _ = Describe("LogbookService", func() {
 				flightRepoMock.EXPECT().GetByID(uint(1)).Return(model.Flight{}, errors.New("failed to fetch flight"))
 
 				// when
-				err := logbookService.DeleteLogbookEntry("2", uint(1))
+				err := logbookService.DeleteLogbookEntry("2", uint(1), nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
@@ -670,73 +1172,329 @@ var _ = Describe("LogbookService", func() {
 				// given
 				flightRepoMock.EXPECT().GetByID(uint(1)).Return(model.Flight{UserID: "3"}, nil)
 				// when
-				err := logbookService.DeleteLogbookEntry("2", uint(1))
+				err := logbookService.DeleteLogbookEntry("2", uint(1), nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
 				Expect(err.Error()).To(Equal("bad request: flight does not belong to user"))
 			})
 		})
-		Context("when fail to delete landings", func() {
+		Context("when fail to delete flight", func() {
 			It("Should return an error and rollback transaction", func() {
 				// given
 				flightRepoMock.EXPECT().GetByID(uint(1)).Return(mockInsertedFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(1)).Return(model.Signature{}, dto.ErrNotFound)
+				landingRepoMock.EXPECT().GetByFlightID(uint(1)).Return([]model.Landing{mockInsertedLandingOne}, nil)
+				passengerRepoMock.EXPECT().GetByFlightID(uint(1)).Return([]model.Passenger{mockInsertedPassengerOne}, nil)
 				flightRepoMock.EXPECT().Begin().Return(databaseMock)
-				landingRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(1)).Return(errors.New("failed to delete landings"))
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(1)).Return(mockInsertedFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(1)).Return(model.Signature{}, dto.ErrNotFound)
+				trashRepoMock.EXPECT().DeleteFlightTx(databaseMock, uint(1), gomock.Any()).Return(errors.New("failed to delete flight"))
 				databaseMock.EXPECT().Rollback()
+
 				// when
-				err := logbookService.DeleteLogbookEntry("2", uint(1))
+				err := logbookService.DeleteLogbookEntry("2", uint(1), nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
-				Expect(err.Error()).To(Equal("failed to delete landings"))
+				Expect(err.Error()).To(Equal("internal failure: failed to delete flight"))
 			})
 		})
-		Context("when fail to delete passengers", func() {
-			It("Should return an error and rollback transaction", func() {
+		Context("when the flight was deleted in the meantime", func() {
+			It("Should return not found and rollback transaction", func() {
 				// given
 				flightRepoMock.EXPECT().GetByID(uint(1)).Return(mockInsertedFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(1)).Return(model.Signature{}, dto.ErrNotFound)
+				landingRepoMock.EXPECT().GetByFlightID(uint(1)).Return([]model.Landing{mockInsertedLandingOne}, nil)
+				passengerRepoMock.EXPECT().GetByFlightID(uint(1)).Return([]model.Passenger{mockInsertedPassengerOne}, nil)
 				flightRepoMock.EXPECT().Begin().Return(databaseMock)
-				landingRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(1)).Return(nil)
-				passengerRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(1)).Return(errors.New("failed to delete passengers"))
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(1)).Return(mockInsertedFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(1)).Return(model.Signature{}, dto.ErrNotFound)
+				trashRepoMock.EXPECT().DeleteFlightTx(databaseMock, uint(1), gomock.Any()).
+					Return(fmt.Errorf("%w: flight cannot be deleted", dto.ErrNotFound))
 				databaseMock.EXPECT().Rollback()
+
 				// when
-				err := logbookService.DeleteLogbookEntry("2", uint(1))
+				err := logbookService.DeleteLogbookEntry("2", uint(1), nil)
 
 				// then
-				Expect(err).ToNot(BeNil())
-				Expect(err.Error()).To(Equal("failed to delete passengers"))
+				Expect(errors.Is(err, dto.ErrNotFound)).To(BeTrue())
+				Expect(err.Error()).To(Equal("not found: flight not found"))
 			})
 		})
-		Context("when fail to delete flight", func() {
-			It("Should return an error and rollback transaction", func() {
+	})
+
+	Describe("BatchLogbookEntries", func() {
+		var deleteFlight model.Flight
+
+		BeforeEach(func() {
+			deleteFlight = mockInsertedFlight
+			deleteFlight.ID = 5
+		})
+
+		expectCreate := func() {
+			aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(mockAircraft, nil)
+			flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).Return([]model.Flight{}, nil)
+			flightRepoMock.EXPECT().CreateTx(databaseMock, mockFlight).Return(mockInsertedFlight, nil)
+			passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerOne).Return(mockInsertedPassengerOne, nil)
+			passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerTwo).Return(mockInsertedPassengerTwo, nil)
+			landingRepoMock.EXPECT().CreateTx(databaseMock, mockLandingOne).Return(mockInsertedLandingOne, nil)
+			landingRepoMock.EXPECT().CreateTx(databaseMock, mockLandingTwo).Return(mockInsertedLandingTwo, nil)
+		}
+
+		expectDeletePrepared := func() {
+			flightRepoMock.EXPECT().GetByID(uint(5)).Return(deleteFlight, nil)
+			signatureRepoMock.EXPECT().GetLatestByFlightID(uint(5)).Return(model.Signature{}, dto.ErrNotFound)
+			landingRepoMock.EXPECT().GetByFlightID(uint(5)).Return([]model.Landing{}, nil)
+			passengerRepoMock.EXPECT().GetByFlightID(uint(5)).Return([]model.Passenger{}, nil)
+		}
+
+		Context("when the batch is atomic and every operation succeeds", func() {
+			It("should apply the operations in a single transaction", func() {
 				// given
-				flightRepoMock.EXPECT().GetByID(uint(1)).Return(mockInsertedFlight, nil)
+				expectCreate()
+				expectDeletePrepared()
 				flightRepoMock.EXPECT().Begin().Return(databaseMock)
-				landingRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(1)).Return(nil)
-				passengerRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(1)).Return(nil)
-				flightRepoMock.EXPECT().DeleteByIDTx(databaseMock, uint(1)).Return(errors.New("failed to delete flight"))
-				databaseMock.EXPECT().Rollback()
+				// the user is locked once, before any flight, so batches of the user cannot deadlock on their flights
+				lockUser := flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil).Times(1)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(5)).Return(deleteFlight, nil).After(lockUser)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(5)).Return(model.Signature{}, dto.ErrNotFound)
+				trashRepoMock.EXPECT().DeleteFlightTx(databaseMock, uint(5), gomock.Any()).Return(nil)
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "2").Return(model.FlightVersion{}, dto.ErrNotFound)
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "2").Return(model.FlightVersion{Sequence: 1, Hash: "abcd"}, nil)
+				flightVersionRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(returnFlightVersion).Times(2)
+				databaseMock.EXPECT().Commit().Return(&gorm.DB{Error: nil})
 
 				// when
-				err := logbookService.DeleteLogbookEntry("2", uint(1))
+				batchResponse, err := logbookService.BatchLogbookEntries("2", dto.LogbookBatchRequest{
+					Atomic: true,
+					Operations: []dto.LogbookBatchOperation{
+						{Action: dto.BatchActionCreate, Entry: &logbookRequest},
+						{Action: dto.BatchActionDelete, ID: util.Uint(5)},
+					},
+				})
 
 				// then
-				Expect(err).ToNot(BeNil())
-				Expect(err.Error()).To(Equal("internal failure: failed to delete flight"))
+				Expect(err).To(BeNil())
+				Expect(batchResponse).To(Equal(dto.LogbookBatchResponse{
+					Atomic:    true,
+					Succeeded: 2,
+					Results: []dto.LogbookBatchResult{
+						{Index: 0, Action: dto.BatchActionCreate, Status: dto.BatchStatusCreated, FlightID: util.Uint(3)},
+						{Index: 1, Action: dto.BatchActionDelete, Status: dto.BatchStatusDeleted, FlightID: util.Uint(5)},
+					},
+				}))
 			})
 		})
-	})
+		Context("when the batch is atomic and an operation fails", func() {
+			It("should rollback the transaction and abort the other operations", func() {
+				// given
+				expectCreate()
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "2").Return(model.FlightVersion{}, dto.ErrNotFound)
+				flightVersionRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(returnFlightVersion)
+				flightRepoMock.EXPECT().GetByID(uint(5)).Return(model.Flight{}, dto.ErrNotFound)
+				databaseMock.EXPECT().Rollback()
+
+				// when
+				batchResponse, err := logbookService.BatchLogbookEntries("2", dto.LogbookBatchRequest{
+					Atomic: true,
+					Operations: []dto.LogbookBatchOperation{
+						{Action: dto.BatchActionCreate, Entry: &logbookRequest},
+						{Action: dto.BatchActionDelete, ID: util.Uint(5)},
+					},
+				})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(batchResponse.Succeeded).To(Equal(0))
+				Expect(batchResponse.Failed).To(Equal(1))
+				Expect(batchResponse.Results[0].Status).To(Equal(dto.BatchStatusAborted))
+				Expect(batchResponse.Results[0].FlightID).To(BeNil())
+				Expect(*batchResponse.Results[0].Error).To(Equal("not applied, operation 1 of the atomic batch failed"))
+				Expect(batchResponse.Results[1].Status).To(Equal(dto.BatchStatusFailed))
+				Expect(*batchResponse.Results[1].Error).To(Equal("bad request: flight not found"))
+			})
+		})
+		Context("when the batch is atomic and creates overlapping entries", func() {
+			It("should check each entry against the entries created before it in the transaction", func() {
+				// given
+				var created []model.Flight
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(mockAircraft, nil).Times(2)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				lockUser := flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ infrastructure.Database, _ string, _, _ time.Time) ([]model.Flight, error) {
+						return created, nil
+					}).Times(2).After(lockUser)
+				flightRepoMock.EXPECT().CreateTx(databaseMock, mockFlight).
+					DoAndReturn(func(_ infrastructure.Database, flight model.Flight) (model.Flight, error) {
+						created = append(created, mockInsertedFlight)
+						return mockInsertedFlight, nil
+					})
+				passengerRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).Return(mockInsertedPassengerOne, nil).Times(2)
+				landingRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).Return(mockInsertedLandingOne, nil).Times(2)
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "2").Return(model.FlightVersion{}, dto.ErrNotFound)
+				flightVersionRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(returnFlightVersion)
+				databaseMock.EXPECT().Rollback().Return(&gorm.DB{})
+
+				// when
+				batchResponse, err := logbookService.BatchLogbookEntries("2", dto.LogbookBatchRequest{
+					Atomic: true,
+					Operations: []dto.LogbookBatchOperation{
+						{Action: dto.BatchActionCreate, Entry: &logbookRequest},
+						{Action: dto.BatchActionCreate, Entry: &logbookRequest},
+					},
+				})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(batchResponse.Succeeded).To(Equal(0))
+				Expect(batchResponse.Failed).To(Equal(1))
+				Expect(batchResponse.Results[0].Status).To(Equal(dto.BatchStatusAborted))
+				Expect(batchResponse.Results[1].Status).To(Equal(dto.BatchStatusFailed))
+				Expect(*batchResponse.Results[1].Error).To(Equal("conflict: entry conflicts with logbook entries: 3"))
+			})
+		})
+		Context("when the batch is atomic and locking the user fails", func() {
+			It("should fail every operation without applying any", func() {
+				// given
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(fmt.Errorf("%w: %v", dto.ErrInternalFailure, "db error"))
+				databaseMock.EXPECT().Rollback().Return(&gorm.DB{})
+
+				// when
+				batchResponse, err := logbookService.BatchLogbookEntries("2", dto.LogbookBatchRequest{
+					Atomic: true,
+					Operations: []dto.LogbookBatchOperation{
+						{Action: dto.BatchActionCreate, Entry: &logbookRequest},
+						{Action: dto.BatchActionDelete, ID: util.Uint(5)},
+					},
+				})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(batchResponse.Succeeded).To(Equal(0))
+				Expect(batchResponse.Failed).To(Equal(2))
+				Expect(*batchResponse.Results[0].Error).To(Equal("internal failure: db error"))
+				Expect(*batchResponse.Results[1].Error).To(Equal("internal failure: db error"))
+			})
+		})
+		Context("when the batch is not atomic and an operation fails", func() {
+			It("should apply the other operations in their own transactions", func() {
+				// given
+				expectCreate()
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "2").Return(model.FlightVersion{}, dto.ErrNotFound)
+				flightVersionRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(returnFlightVersion)
+				databaseMock.EXPECT().Commit().Return(&gorm.DB{Error: nil})
+
+				// when
+				batchResponse, err := logbookService.BatchLogbookEntries("2", dto.LogbookBatchRequest{
+					Operations: []dto.LogbookBatchOperation{
+						{Action: dto.BatchActionUpdate, ID: util.Uint(5)},
+						{Action: dto.BatchActionCreate, Entry: &logbookRequest},
+						{Action: "move"},
+					},
+				})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(batchResponse.Succeeded).To(Equal(1))
+				Expect(batchResponse.Failed).To(Equal(2))
+				Expect(*batchResponse.Results[0].Error).To(Equal("bad request: id and entry are required to update"))
+				Expect(batchResponse.Results[1]).To(Equal(dto.LogbookBatchResult{Index: 1, Action: dto.BatchActionCreate,
+					Status: dto.BatchStatusCreated, FlightID: util.Uint(3)}))
+				Expect(*batchResponse.Results[2].Error).To(Equal("bad request: invalid action: move"))
+			})
+		})
+		Context("when the batch has no operations", func() {
+			It("should return bad request", func() {
+				// when
+				_, err := logbookService.BatchLogbookEntries("2", dto.LogbookBatchRequest{})
+
+				// then
+				Expect(err.Error()).To(Equal("bad request: batch has no operations"))
+			})
+		})
+		Context("when an entry is changed twice in the batch", func() {
+			It("should return bad request", func() {
+				// when
+				_, err := logbookService.BatchLogbookEntries("2", dto.LogbookBatchRequest{
+					Operations: []dto.LogbookBatchOperation{
+						{Action: dto.BatchActionUpdate, ID: util.Uint(5), Entry: &logbookRequest},
+						{Action: dto.BatchActionDelete, ID: util.Uint(5)},
+					},
+				})
+
+				// then
+				Expect(errors.Is(err, dto.ErrBadRequest)).To(BeTrue())
+				Expect(err.Error()).To(Equal("bad request: flight 5 appears more than once in the batch"))
+			})
+		})
+	})
 
 	Describe("UpdateLogbookEntry", func() {
+		Context("when the signature request changed since the given version", func() {
+			It("should return precondition failed error", func() {
+				// given
+				version := mockFlightBeforeUpdate.UpdatedAt
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).Return(model.Signature{
+					FlightID: 3,
+					Status:   model.SignatureStatusPending,
+					Model:    gorm.Model{UpdatedAt: version.Add(time.Minute)},
+				}, nil)
+
+				// when
+				_, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest, &version)
+
+				// then
+				Expect(errors.Is(err, dto.ErrPreconditionFailed)).To(BeTrue())
+			})
+		})
+		Context("when another request changes the entry after the version is checked", func() {
+			It("should roll back and return precondition failed error", func() {
+				// given
+				version := mockFlightBeforeUpdate.UpdatedAt
+				changedFlight := mockFlightBeforeUpdate
+				changedFlight.UpdatedAt = version.Add(time.Second)
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).Return(model.Signature{}, dto.ErrNotFound)
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(mockAircraft, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(changedFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(3)).Return(model.Signature{}, dto.ErrNotFound)
+				databaseMock.EXPECT().Rollback().Return(&gorm.DB{})
+
+				// when
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest, &version)
+
+				// then
+				Expect(errors.Is(err, dto.ErrPreconditionFailed)).To(BeTrue())
+				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
+			})
+		})
 		Context("when updating goes well", func() {
 			It("Should return no error and updated model response", func() {
 				// given
 				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlightBeforeUpdate, nil)
-				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).Return(model.Signature{}, dto.ErrNotFound)
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(mockAircraft, nil)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(3)).Return(model.Signature{}, dto.ErrNotFound)
 				flightRepoMock.EXPECT().SaveTx(databaseMock, mockInsertedFlight).Return(mockInsertedFlight, nil)
 				passengerRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(3)).Return(nil)
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).Return([]model.Flight{}, nil)
 				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "2").Return(model.FlightVersion{}, dto.ErrNotFound)
+				flightVersionRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(returnFlightVersion)
 				databaseMock.EXPECT().Commit().Return(&gorm.DB{Error: nil})
 				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerOne).Return(mockInsertedPassengerOne, nil)
 				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerTwo).Return(mockInsertedPassengerTwo, nil)
@@ -745,10 +1503,12 @@ var _ = Describe("LogbookService", func() {
 				landingRepoMock.EXPECT().CreateTx(databaseMock, mockLandingTwo).Return(mockInsertedLandingTwo, nil)
 
 				// when
-				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest)
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest, nil)
 				// then
 				Expect(err).To(BeNil())
 				Expect(logbookResponse).ToNot(BeNil())
+				Expect(logbookResponse.ID).To(Equal(uint(3)))
+				Expect(logbookResponse.Aircraft.RegistrationNumber).To(Equal("SP-ABC"))
 				Expect(logbookResponse.TakeoffTime).To(Equal(logbookRequest.TakeoffTime))
 				Expect(logbookResponse.TakeoffAirportCode).To(Equal(logbookRequest.TakeoffAirportCode))
 				Expect(logbookResponse.LandingTime).To(Equal(logbookRequest.LandingTime))
@@ -768,21 +1528,117 @@ var _ = Describe("LogbookService", func() {
 				Expect(logbookResponse.IFRSimulatedTime).To(Equal(logbookRequest.IFRSimulatedTime))
 				Expect(logbookResponse.CrossCountryTime).To(Equal(logbookRequest.CrossCountryTime))
 				Expect(logbookResponse.SimulatorTime).To(Equal(logbookRequest.SimulatorTime))
-				Expect(logbookResponse.SignatureURL).To(Equal(logbookRequest.SignatureURL))
-				Expect(logbookResponse.Passengers).To(HaveLen(2))
-				Expect(logbookResponse.Passengers).To(Equal(logbookRequest.Passengers))
-				Expect(logbookResponse.Landings).To(HaveLen(2))
-				Expect(logbookResponse.Landings).To(Equal(logbookRequest.Landings))
+				Expect(logbookResponse.SignatureURL).To(Equal(mockFlightBeforeUpdate.SignatureURL))
+				Expect(logbookResponse.Passengers).To(Equal(expectedPassengers))
+				Expect(logbookResponse.Landings).To(Equal(expectedLandings))
+			})
+		})
+		Context("when the flight is signed", func() {
+			It("Should return a conflict asking for an amendment", func() {
+				// given
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).Return(model.Signature{FlightID: 3, Status: model.SignatureStatusSigned}, nil)
+
+				// when
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest, nil)
+
+				// then
+				Expect(errors.Is(err, dto.ErrConflict)).To(BeTrue())
+				Expect(err.Error()).To(Equal("conflict: flight is signed, changes must be amended"))
+				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
+			})
+		})
+		Context("when the flight is signed after the update was prepared", func() {
+			It("Should roll back and return a conflict", func() {
+				// given
+				signed := model.Signature{FlightID: 3, Status: model.SignatureStatusSigned}
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).Return(model.Signature{}, dto.ErrNotFound)
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(mockAircraft, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(3)).Return(signed, nil)
+				databaseMock.EXPECT().Rollback().Return(&gorm.DB{})
+
+				// when
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest, nil)
+
+				// then
+				Expect(err).To(MatchError("conflict: flight is signed, changes must be amended"))
+				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
+			})
+		})
+		Context("when a pending signature request exists", func() {
+			It("Should update the entry and keep the request", func() {
+				// given
+				pending := model.Signature{Model: gorm.Model{ID: 5}, FlightID: 3, Status: model.SignatureStatusPending, SignerName: "Jan Kowalski"}
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).Return(pending, nil)
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(mockAircraft, nil)
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).Return([]model.Flight{}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(3)).Return(pending, nil)
+				flightRepoMock.EXPECT().SaveTx(databaseMock, mockInsertedFlight).Return(mockInsertedFlight, nil)
+				passengerRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(3)).Return(nil)
+				passengerRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).Return(mockInsertedPassengerOne, nil).Times(2)
+				landingRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(3)).Return(nil)
+				landingRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).Return(mockInsertedLandingOne, nil).Times(2)
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "2").Return(model.FlightVersion{}, dto.ErrNotFound)
+				flightVersionRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(returnFlightVersion)
+				databaseMock.EXPECT().Commit().Return(&gorm.DB{Error: nil})
+
+				// when
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest, nil)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(logbookResponse.Signature).ToNot(BeNil())
+				Expect(logbookResponse.Signature.Status).To(Equal(model.SignatureStatusPending))
+			})
+		})
+		Context("when the entry conflicts with another entry", func() {
+			It("Should return a conflict ignoring the updated entry itself", func() {
+				// given
+				overlap := model.Flight{Model: gorm.Model{ID: 8}, AircraftID: 1, TakeoffTime: fixedTime.Add(time.Hour),
+					TakeoffAirportCode: "LAX", LandingTime: fixedTime.Add(2 * time.Hour), LandingAirportCode: "SAN"}
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).Return(model.Signature{}, dto.ErrNotFound)
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(3)).Return(model.Signature{}, dto.ErrNotFound)
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).
+					Return([]model.Flight{mockFlightBeforeUpdate, overlap}, nil)
+				databaseMock.EXPECT().Rollback().Return(&gorm.DB{})
+
+				// when
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest, nil)
+
+				// then
+				Expect(errors.Is(err, dto.ErrConflict)).To(BeTrue())
+				Expect(err.Error()).To(Equal("conflict: entry conflicts with logbook entries: 8"))
+				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
 			})
 		})
 		Context("when commit fails", func() {
 			It("Should return an error", func() {
 				// given
 				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).Return(model.Signature{}, dto.ErrNotFound)
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(3)).Return(model.Signature{}, dto.ErrNotFound)
 				flightRepoMock.EXPECT().SaveTx(databaseMock, mockInsertedFlight).Return(mockInsertedFlight, nil)
 				passengerRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(3)).Return(nil)
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).Return([]model.Flight{}, nil)
 				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "2").Return(model.FlightVersion{}, dto.ErrNotFound)
+				flightVersionRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(returnFlightVersion)
 				databaseMock.EXPECT().Commit().Return(&gorm.DB{Error: nil})
 				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerOne).Return(mockInsertedPassengerOne, nil)
 				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerTwo).Return(mockInsertedPassengerTwo, nil)
@@ -791,7 +1647,7 @@ var _ = Describe("LogbookService", func() {
 				landingRepoMock.EXPECT().CreateTx(databaseMock, mockLandingTwo).Return(mockInsertedLandingTwo, nil)
 
 				// when
-				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest)
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest, nil)
 
 				// then
 				Expect(err).To(BeNil())
@@ -799,6 +1655,42 @@ var _ = Describe("LogbookService", func() {
 
 			})
 		})
+		Context("when the landing airport code is unknown", func() {
+			It("Should store the code as given without splitting the landings", func() {
+				// given
+				logbookRequest.Landings = []dto.LandingEntry{
+					{ApproachType: model.ApproachTypeVisual, Count: util.Uint(2), AirportCode: util.String("XXX")},
+				}
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).Return(model.Signature{}, dto.ErrNotFound)
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).Return([]model.Flight{}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(3)).Return(model.Signature{}, dto.ErrNotFound)
+				flightRepoMock.EXPECT().SaveTx(databaseMock, mockInsertedFlight).Return(mockInsertedFlight, nil)
+				passengerRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(3)).Return(nil)
+				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerOne).Return(mockInsertedPassengerOne, nil)
+				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerTwo).Return(mockInsertedPassengerTwo, nil)
+				landingRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(3)).Return(nil)
+				expectedLanding := model.Landing{FlightID: 3, ApproachType: model.ApproachTypeVisual, Count: util.Uint(2),
+					AirportCode: util.String("XXX")}
+				landingRepoMock.EXPECT().CreateTx(databaseMock, expectedLanding).Return(expectedLanding, nil)
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "2").Return(model.FlightVersion{}, dto.ErrNotFound)
+				flightVersionRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(returnFlightVersion)
+				databaseMock.EXPECT().Commit().Return(&gorm.DB{Error: nil})
+
+				// when
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest, nil)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(logbookResponse.Landings).To(HaveLen(1))
+				Expect(logbookResponse.Landings[0].AirportCode).To(Equal(util.String("XXX")))
+				Expect(logbookResponse.Landings[0].DayCount).To(BeNil())
+			})
+		})
 		// this test also covers the case when user does not provide aircraft id in the request
 		Context("when getting flight failed", func() {
 			It("Should return an error", func() {
@@ -806,7 +1698,7 @@ var _ = Describe("LogbookService", func() {
 				flightRepoMock.EXPECT().GetByID(uint(1)).Return(model.Flight{}, errors.New("failed to fetch flight"))
 
 				// when
-				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(1), logbookRequest)
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(1), logbookRequest, nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
@@ -820,7 +1712,7 @@ var _ = Describe("LogbookService", func() {
 				flightRepoMock.EXPECT().GetByID(uint(1)).Return(model.Flight{UserID: "3"}, nil)
 
 				// when
-				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(1), logbookRequest)
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(1), logbookRequest, nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
@@ -832,10 +1724,11 @@ var _ = Describe("LogbookService", func() {
 			It("Should return an error", func() {
 				// given
 				flightRepoMock.EXPECT().GetByID(uint(1)).Return(mockInsertedFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(1)).Return(model.Signature{}, dto.ErrNotFound)
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, errors.New("aircraft does not belong to user"))
 
 				// when
-				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(1), logbookRequest)
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(1), logbookRequest, nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
@@ -848,16 +1741,16 @@ var _ = Describe("LogbookService", func() {
 				// given
 				logbookRequest.TakeoffTime = time.Time{}
 				flightRepoMock.EXPECT().GetByID(uint(1)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(1)).Return(model.Signature{}, dto.ErrNotFound)
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
-				flightRepoMock.EXPECT().Begin().Return(databaseMock)
 
 				// when
-				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(1), logbookRequest)
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(1), logbookRequest, nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
 				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
-				Expect(err.Error()).To(Equal("bad request: invalid data in field: TakeoffTime"))
+				Expect(err.Error()).To(Equal("bad request: invalid data in field: takeoff_time"))
 			})
 		})
 		Context("when flight to update missing TakeoffAirportCode", func() {
@@ -865,16 +1758,16 @@ var _ = Describe("LogbookService", func() {
 				// given
 				logbookRequest.TakeoffTime = time.Time{}
 				flightRepoMock.EXPECT().GetByID(uint(1)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(1)).Return(model.Signature{}, dto.ErrNotFound)
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
-				flightRepoMock.EXPECT().Begin().Return(databaseMock)
 
 				// when
-				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(1), logbookRequest)
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(1), logbookRequest, nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
 				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
-				Expect(err.Error()).To(Equal("bad request: invalid data in field: TakeoffTime"))
+				Expect(err.Error()).To(Equal("bad request: invalid data in field: takeoff_time"))
 			})
 		})
 		Context("when flight to update missing LandingTime", func() {
@@ -882,16 +1775,16 @@ var _ = Describe("LogbookService", func() {
 				// given
 				logbookRequest.LandingTime = time.Time{}
 				flightRepoMock.EXPECT().GetByID(uint(1)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(1)).Return(model.Signature{}, dto.ErrNotFound)
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
-				flightRepoMock.EXPECT().Begin().Return(databaseMock)
 
 				// when
-				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(1), logbookRequest)
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(1), logbookRequest, nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
 				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
-				Expect(err.Error()).To(Equal("bad request: invalid data in field: LandingTime"))
+				Expect(err.Error()).To(Equal("bad request: invalid data in field: landing_time"))
 			})
 		})
 		Context("when flight to update missing LandingAirportCode", func() {
@@ -899,16 +1792,16 @@ var _ = Describe("LogbookService", func() {
 				// given
 				logbookRequest.LandingAirportCode = ""
 				flightRepoMock.EXPECT().GetByID(uint(1)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(1)).Return(model.Signature{}, dto.ErrNotFound)
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
-				flightRepoMock.EXPECT().Begin().Return(databaseMock)
 
 				// when
-				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(1), logbookRequest)
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(1), logbookRequest, nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
 				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
-				Expect(err.Error()).To(Equal("bad request: invalid data in field: LandingAirportCode"))
+				Expect(err.Error()).To(Equal("bad request: invalid data in field: landing_airport_code"))
 
 			})
 		})
@@ -917,16 +1810,16 @@ var _ = Describe("LogbookService", func() {
 				// given
 				logbookRequest.Style = ""
 				flightRepoMock.EXPECT().GetByID(uint(1)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(1)).Return(model.Signature{}, dto.ErrNotFound)
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
-				flightRepoMock.EXPECT().Begin().Return(databaseMock)
 
 				// when
-				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(1), logbookRequest)
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(1), logbookRequest, nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
 				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
-				Expect(err.Error()).To(Equal("bad request: invalid data in field: Style"))
+				Expect(err.Error()).To(Equal("bad request: invalid data in field: style"))
 			})
 		})
 		Context("when flight to update invalid Style", func() {
@@ -934,16 +1827,16 @@ var _ = Describe("LogbookService", func() {
 				// given
 				logbookRequest.Style = "invalidStyle"
 				flightRepoMock.EXPECT().GetByID(uint(1)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(1)).Return(model.Signature{}, dto.ErrNotFound)
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
-				flightRepoMock.EXPECT().Begin().Return(databaseMock)
 
 				// when
-				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(1), logbookRequest)
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(1), logbookRequest, nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
 				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
-				Expect(err.Error()).To(Equal("bad request: invalid data in field: Style"))
+				Expect(err.Error()).To(Equal("bad request: invalid data in field: style"))
 
 			})
 		})
@@ -951,13 +1844,18 @@ var _ = Describe("LogbookService", func() {
 			It("Should return an error and rollback transaction", func() {
 				// given
 				flightRepoMock.EXPECT().GetByID(uint(1)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(1)).Return(model.Signature{}, dto.ErrNotFound)
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(3)).Return(model.Signature{}, dto.ErrNotFound)
 				flightRepoMock.EXPECT().SaveTx(databaseMock, mockInsertedFlight).Return(model.Flight{}, errors.New("failed to update flight"))
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).Return([]model.Flight{}, nil)
 				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
 				databaseMock.EXPECT().Rollback()
 
 				// when
-				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(1), logbookRequest)
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(1), logbookRequest, nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
@@ -969,14 +1867,19 @@ var _ = Describe("LogbookService", func() {
 			It("Should return an error and rollback transaction", func() {
 				// given
 				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).Return(model.Signature{}, dto.ErrNotFound)
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(3)).Return(model.Signature{}, dto.ErrNotFound)
 				flightRepoMock.EXPECT().SaveTx(databaseMock, mockInsertedFlight).Return(mockInsertedFlight, nil)
 				passengerRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(3)).Return(errors.New("failed to delete passengers"))
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).Return([]model.Flight{}, nil)
 				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
 				databaseMock.EXPECT().Rollback()
 
 				// when
-				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest)
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest, nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
@@ -986,79 +1889,75 @@ var _ = Describe("LogbookService", func() {
 			})
 		})
 		Context("when passenger to insert missing role", func() {
-			It("Should return an error and rollback transaction", func() {
+			It("Should return an error", func() {
 				// given
 				logbookRequest.Passengers[0].Role = ""
 				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).Return(model.Signature{}, dto.ErrNotFound)
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
-				flightRepoMock.EXPECT().SaveTx(databaseMock, mockInsertedFlight).Return(mockInsertedFlight, nil)
-				passengerRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(3)).Return(nil)
-				flightRepoMock.EXPECT().Begin().Return(databaseMock)
-				databaseMock.EXPECT().Rollback()
 
 				// when
-				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest)
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest, nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
 				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
-				Expect(err.Error()).To(Equal("bad request: invalid data in field: Role"))
+				Expect(err.Error()).To(Equal("bad request: invalid data in field: passengers[0].role"))
 
 			})
 		})
 		Context("when passenger to insert have invalid role", func() {
-			It("Should return an error and rollback transaction", func() {
+			It("Should return an error", func() {
 				// given
 				logbookRequest.Passengers[0].Role = "invalidRole"
 				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).Return(model.Signature{}, dto.ErrNotFound)
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
-				flightRepoMock.EXPECT().SaveTx(databaseMock, mockInsertedFlight).Return(mockInsertedFlight, nil)
-				passengerRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(3)).Return(nil)
-				flightRepoMock.EXPECT().Begin().Return(databaseMock)
-				databaseMock.EXPECT().Rollback()
 
 				// when
-				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest)
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest, nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
 				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
-				Expect(err.Error()).To(Equal("bad request: invalid data in field: Role"))
+				Expect(err.Error()).To(Equal("bad request: invalid data in field: passengers[0].role"))
 			})
 		})
 		Context("when passenger to insert missing first name", func() {
-			It("Should return an error and rollback transaction", func() {
+			It("Should return an error", func() {
 				// given
 				logbookRequest.Passengers[0].FirstName = ""
 				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).Return(model.Signature{}, dto.ErrNotFound)
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
-				flightRepoMock.EXPECT().SaveTx(databaseMock, mockInsertedFlight).Return(mockInsertedFlight, nil)
-				passengerRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(3)).Return(nil)
-				flightRepoMock.EXPECT().Begin().Return(databaseMock)
-				databaseMock.EXPECT().Rollback()
 
 				// when
-				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest)
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest, nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
 				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
-				Expect(err.Error()).To(Equal("bad request: invalid data in field: FirstName"))
+				Expect(err.Error()).To(Equal("bad request: invalid data in field: passengers[0].first_name"))
 			})
 		})
 		Context("when creating passenger failed", func() {
 			It("Should return an error and rollback transaction", func() {
 				// given
 				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).Return(model.Signature{}, dto.ErrNotFound)
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(3)).Return(model.Signature{}, dto.ErrNotFound)
 				flightRepoMock.EXPECT().SaveTx(databaseMock, mockInsertedFlight).Return(mockInsertedFlight, nil)
 				passengerRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(3)).Return(nil)
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).Return([]model.Flight{}, nil)
 				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
 				databaseMock.EXPECT().Rollback()
 				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerOne).Return(model.Passenger{}, errors.New("failed to create passenger"))
 
 				// when
-				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest)
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest, nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
@@ -1070,17 +1969,22 @@ var _ = Describe("LogbookService", func() {
 			It("Should return an error and rollback transaction", func() {
 				// given
 				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).Return(model.Signature{}, dto.ErrNotFound)
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(3)).Return(model.Signature{}, dto.ErrNotFound)
 				flightRepoMock.EXPECT().SaveTx(databaseMock, mockInsertedFlight).Return(mockInsertedFlight, nil)
 				passengerRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(3)).Return(nil)
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).Return([]model.Flight{}, nil)
 				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
 				databaseMock.EXPECT().Rollback()
 				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerOne).Return(mockInsertedPassengerOne, nil)
 				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerTwo).Return(mockInsertedPassengerTwo, nil)
 				landingRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(3)).Return(errors.New("failed to delete landings"))
 
 				// when
-				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest)
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest, nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
@@ -1089,59 +1993,52 @@ var _ = Describe("LogbookService", func() {
 			})
 		})
 		Context("when landing to insert missing approach type", func() {
-			It("Should return an error and rollback transaction", func() {
+			It("Should return an error", func() {
 				// given
 				logbookRequest.Landings[0].ApproachType = ""
 				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).Return(model.Signature{}, dto.ErrNotFound)
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
-				flightRepoMock.EXPECT().SaveTx(databaseMock, mockInsertedFlight).Return(mockInsertedFlight, nil)
-				passengerRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(3)).Return(nil)
-				flightRepoMock.EXPECT().Begin().Return(databaseMock)
-				databaseMock.EXPECT().Rollback()
-				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerOne).Return(mockInsertedPassengerOne, nil)
-				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerTwo).Return(mockInsertedPassengerTwo, nil)
-				landingRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(3)).Return(nil)
 
 				// when
-				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest)
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest, nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
 				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
-				Expect(err.Error()).To(Equal("bad request: invalid data in field: ApproachType"))
+				Expect(err.Error()).To(Equal("bad request: invalid data in field: landings[0].approach_type"))
 			})
 		})
 		Context("when landing to insert have invalid approach type", func() {
-			It("Should return an error and rollback transaction", func() {
+			It("Should return an error", func() {
 				// given
 				logbookRequest.Landings[0].ApproachType = "invalidApproachType"
 				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).Return(model.Signature{}, dto.ErrNotFound)
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
-				flightRepoMock.EXPECT().SaveTx(databaseMock, mockInsertedFlight).Return(mockInsertedFlight, nil)
-				passengerRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(3)).Return(nil)
-				flightRepoMock.EXPECT().Begin().Return(databaseMock)
-				databaseMock.EXPECT().Rollback()
-				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerOne).Return(mockInsertedPassengerOne, nil)
-				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerTwo).Return(mockInsertedPassengerTwo, nil)
-				landingRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(3)).Return(nil)
 
 				// when
-				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest)
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest, nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
 				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
-				Expect(err.Error()).To(Equal("bad request: invalid data in field: ApproachType"))
+				Expect(err.Error()).To(Equal("bad request: invalid data in field: landings[0].approach_type"))
 			})
 		})
 		Context("when creating landing failed", func() {
 			It("Should return an error and rollback transaction", func() {
 				// given
 				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).Return(model.Signature{}, dto.ErrNotFound)
 				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(model.Aircraft{}, nil)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(3)).Return(model.Signature{}, dto.ErrNotFound)
 				flightRepoMock.EXPECT().SaveTx(databaseMock, mockInsertedFlight).Return(mockInsertedFlight, nil)
 				passengerRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(3)).Return(nil)
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).Return([]model.Flight{}, nil)
 				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
 				databaseMock.EXPECT().Rollback()
 				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerOne).Return(mockInsertedPassengerOne, nil)
 				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerTwo).Return(mockInsertedPassengerTwo, nil)
@@ -1149,7 +2046,7 @@ var _ = Describe("LogbookService", func() {
 				landingRepoMock.EXPECT().CreateTx(databaseMock, mockLandingOne).Return(model.Landing{}, errors.New("failed to create landing"))
 
 				// when
-				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest)
+				logbookResponse, err := logbookService.UpdateLogbookEntry("2", uint(3), logbookRequest, nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
@@ -1159,89 +2056,263 @@ var _ = Describe("LogbookService", func() {
 		})
 	})
 
-	Describe("GetLogbookEntries", func() {
-		Context("when flights found", func() {
-			It("Should return logbook response array with records", func() {
+	Describe("AmendLogbookEntry", func() {
+		Context("when the flight is signed", func() {
+			It("Should update the entry and invalidate the signature", func() {
 				// given
-				mockFlightArr := []model.Flight{
-					{
-						Model:               gorm.Model{ID: 1},
-						UserID:              "1",
-						AircraftID:          1,
-						TakeoffTime:         time.Date(2022, time.March, 25, 0, 0, 0, 0, time.UTC),
-						LandingTime:         time.Date(2022, time.March, 25, 1, 0, 0, 0, time.UTC),
-						TakeoffAirportCode:  "T1",
-						LandingAirportCode:  "L1",
-						Style:               "S1",
-						Remarks:             util.String("R1"),
-						PersonalRemarks:     util.String("PR1"),
-						TotalBlockTime:      util.Duration(1),
-						PilotInCommandTime:  util.Duration(1),
-						SecondInCommandTime: util.Duration(1),
-						DualReceivedTime:    util.Duration(1),
-						DualGivenTime:       util.Duration(1),
-						MultiPilotTime:      util.Duration(1),
-						NightTime:           util.Duration(1),
-						IFRTime:             util.Duration(1),
-						IFRActualTime:       util.Duration(1),
-						IFRSimulatedTime:    util.Duration(1),
-						CrossCountryTime:    util.Duration(1),
-						SimulatorTime:       util.Duration(1),
-						SignatureURL:        util.String("DUAL2"),
-					},
-					{
-						Model:               gorm.Model{ID: 2},
-						UserID:              "1",
-						AircraftID:          3,
-						TakeoffTime:         time.Date(2022, time.March, 26, 5, 0, 0, 0, time.UTC),
-						LandingTime:         time.Date(2022, time.March, 26, 6, 0, 0, 0, time.UTC),
-						TakeoffAirportCode:  "T1",
-						LandingAirportCode:  "L1",
-						Style:               "S1",
-						Remarks:             util.String("R1"),
-						PersonalRemarks:     util.String("PR1"),
-						TotalBlockTime:      util.Duration(2),
-						PilotInCommandTime:  util.Duration(1),
-						SecondInCommandTime: util.Duration(3),
-						DualReceivedTime:    util.Duration(3),
-						DualGivenTime:       util.Duration(2),
-						MultiPilotTime:      util.Duration(5),
-						NightTime:           util.Duration(15),
-						IFRTime:             util.Duration(1),
-						IFRActualTime:       util.Duration(2),
-						IFRSimulatedTime:    util.Duration(21),
-						CrossCountryTime:    util.Duration(2),
-						SimulatorTime:       util.Duration(2),
-						SignatureURL:        util.String("DUAL1"),
-					},
-				}
-				mockPassengerArr := []model.Passenger{
-					{
-						Model:     gorm.Model{ID: 1},
-						FlightID:  1,
-						FirstName: "F1",
-						LastName:  util.String("L1"),
-						Role:      model.RoleSecondInCommand,
-					},
-				}
-				mockPassengerArr2 := []model.Passenger{
-					{
-						Model:     gorm.Model{ID: 2},
-						FlightID:  2,
-						FirstName: "F2",
-						LastName:  util.String("L2"),
-						Role:      model.RoleSecondInCommand,
-					},
-					{
-						Model:     gorm.Model{ID: 3},
-						FlightID:  2,
-						FirstName: "F3",
-						LastName:  util.String("L3"),
-						Role:      model.RoleSecondInCommand,
-					},
-				}
-				mockLandingArr := []model.Landing{
-					{
+				signed := model.Signature{Model: gorm.Model{ID: 5}, FlightID: 3, Status: model.SignatureStatusSigned, SignerName: "Jan Kowalski"}
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).Return(signed, nil)
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(mockAircraft, nil)
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).Return([]model.Flight{}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(3)).Return(signed, nil)
+				flightRepoMock.EXPECT().SaveTx(databaseMock, mockInsertedFlight).Return(mockInsertedFlight, nil)
+				passengerRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(3)).Return(nil)
+				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerOne).Return(mockInsertedPassengerOne, nil)
+				passengerRepoMock.EXPECT().CreateTx(databaseMock, mockPassengerTwo).Return(mockInsertedPassengerTwo, nil)
+				landingRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(3)).Return(nil)
+				landingRepoMock.EXPECT().CreateTx(databaseMock, mockLandingOne).Return(mockInsertedLandingOne, nil)
+				landingRepoMock.EXPECT().CreateTx(databaseMock, mockLandingTwo).Return(mockInsertedLandingTwo, nil)
+				signatureRepoMock.EXPECT().InvalidateByFlightIDTx(databaseMock, uint(3)).Return(nil)
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "2").Return(model.FlightVersion{}, dto.ErrNotFound)
+				flightVersionRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(func(_ infrastructure.Database, version model.FlightVersion) (model.FlightVersion, error) {
+					Expect(version.Action).To(Equal(model.FlightVersionActionAmended))
+					return version, nil
+				})
+				databaseMock.EXPECT().Commit().Return(&gorm.DB{Error: nil})
+
+				// when
+				logbookResponse, err := logbookService.AmendLogbookEntry("2", uint(3), logbookRequest, nil)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(logbookResponse.ID).To(Equal(uint(3)))
+				Expect(logbookResponse.Signature).ToNot(BeNil())
+				Expect(logbookResponse.Signature.ID).To(Equal(uint(5)))
+				Expect(logbookResponse.Signature.Status).To(Equal(model.SignatureStatusInvalidated))
+			})
+		})
+		Context("when the entry changed since the given version", func() {
+			It("Should return precondition failed without amending the entry", func() {
+				// given
+				version := mockFlightBeforeUpdate.UpdatedAt.Add(-time.Hour)
+				signed := model.Signature{Model: gorm.Model{ID: 5}, FlightID: 3, Status: model.SignatureStatusSigned}
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).Return(signed, nil)
+
+				// when
+				logbookResponse, err := logbookService.AmendLogbookEntry("2", uint(3), logbookRequest, &version)
+
+				// then
+				Expect(errors.Is(err, dto.ErrPreconditionFailed)).To(BeTrue())
+				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
+			})
+		})
+		Context("when invalidating the signature fails", func() {
+			It("Should roll back and return an error", func() {
+				// given
+				signed := model.Signature{Model: gorm.Model{ID: 5}, FlightID: 3, Status: model.SignatureStatusSigned}
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).Return(signed, nil)
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("2", uint(1)).Return(mockAircraft, nil)
+				flightRepoMock.EXPECT().GetByUserIDAndTimeWindowTx(databaseMock, "2", gomock.Any(), gomock.Any()).Return([]model.Flight{}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "2").Return(nil)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(mockFlightBeforeUpdate, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(3)).Return(signed, nil)
+				flightRepoMock.EXPECT().SaveTx(databaseMock, mockInsertedFlight).Return(mockInsertedFlight, nil)
+				passengerRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(3)).Return(nil)
+				passengerRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).Return(mockInsertedPassengerOne, nil).Times(2)
+				landingRepoMock.EXPECT().DeleteByFlightIDTx(databaseMock, uint(3)).Return(nil)
+				signatureRepoMock.EXPECT().InvalidateByFlightIDTx(databaseMock, uint(3)).Return(dto.ErrInternalFailure)
+				databaseMock.EXPECT().Rollback().Return(&gorm.DB{})
+
+				// when
+				logbookResponse, err := logbookService.AmendLogbookEntry("2", uint(3), logbookRequest, nil)
+
+				// then
+				Expect(errors.Is(err, dto.ErrInternalFailure)).To(BeTrue())
+				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
+			})
+		})
+	})
+	Describe("GetLogbookEntry", func() {
+		Context("when the flight belongs to the user", func() {
+			It("Should return the entry with its aircraft, passengers and landings", func() {
+				// given
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockInsertedFlight, nil)
+				aircraftRepoMock.EXPECT().GetByIDs([]uint{1}).Return([]model.Aircraft{mockAircraft}, nil)
+				landingRepoMock.EXPECT().GetByFlightIDs([]uint{3}).Return([]model.Landing{mockInsertedLandingOne, mockInsertedLandingTwo}, nil)
+				passengerRepoMock.EXPECT().GetByFlightIDs([]uint{3}).Return([]model.Passenger{mockInsertedPassengerOne, mockInsertedPassengerTwo}, nil)
+				signatureRepoMock.EXPECT().GetByFlightIDs([]uint{3}).Return([]model.Signature{
+					{Model: gorm.Model{ID: 4}, FlightID: 3, Status: model.SignatureStatusRejected},
+					{Model: gorm.Model{ID: 5}, FlightID: 3, Status: model.SignatureStatusPending, Token: util.String("token")},
+				}, nil)
+
+				// when
+				logbookResponse, err := logbookService.GetLogbookEntry("2", uint(3))
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(logbookResponse.ID).To(Equal(uint(3)))
+				Expect(logbookResponse.MyRole).To(Equal(model.RolePilotInCommand))
+				Expect(logbookResponse.Aircraft).To(Equal(dto.AircraftSummary{ID: 1, RegistrationNumber: "SP-ABC", AircraftModel: "C152"}))
+				Expect(logbookResponse.TakeoffAirportCode).To(Equal("SFO"))
+				Expect(logbookResponse.Passengers).To(Equal(expectedPassengers))
+				Expect(logbookResponse.Landings).To(Equal(expectedLandings))
+				Expect(logbookResponse.Signature).To(Equal(&dto.SignatureResponse{ID: 5, FlightID: 3,
+					Status: model.SignatureStatusPending}))
+			})
+		})
+		Context("when the aircraft of the flight is in the trash", func() {
+			It("Should return the entry with the summary of the deleted aircraft", func() {
+				// given
+				trashedAircraft := mockAircraft
+				trashedAircraft.DeletedAt = gorm.DeletedAt{Time: fixedTime, Valid: true}
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockInsertedFlight, nil)
+				aircraftRepoMock.EXPECT().GetByIDs([]uint{1}).Return([]model.Aircraft{trashedAircraft}, nil)
+				landingRepoMock.EXPECT().GetByFlightIDs([]uint{3}).Return([]model.Landing{}, nil)
+				passengerRepoMock.EXPECT().GetByFlightIDs([]uint{3}).Return([]model.Passenger{}, nil)
+				signatureRepoMock.EXPECT().GetByFlightIDs([]uint{3}).Return([]model.Signature{}, nil)
+
+				// when
+				logbookResponse, err := logbookService.GetLogbookEntry("2", uint(3))
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(logbookResponse.Aircraft).To(Equal(dto.AircraftSummary{ID: 1, RegistrationNumber: "SP-ABC", AircraftModel: "C152"}))
+			})
+		})
+		Context("when the flight does not exist", func() {
+			It("Should return not found", func() {
+				// given
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(model.Flight{}, dto.ErrNotFound)
+
+				// when
+				logbookResponse, err := logbookService.GetLogbookEntry("2", uint(3))
+
+				// then
+				Expect(errors.Is(err, dto.ErrNotFound)).To(BeTrue())
+				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
+				Expect(err.Error()).To(Equal("not found: flight not found"))
+			})
+		})
+		Context("when the flight belongs to another user", func() {
+			It("Should return bad request", func() {
+				// given
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockInsertedFlight, nil)
+
+				// when
+				logbookResponse, err := logbookService.GetLogbookEntry("1", uint(3))
+
+				// then
+				Expect(errors.Is(err, dto.ErrBadRequest)).To(BeTrue())
+				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
+				Expect(err.Error()).To(Equal("bad request: flight does not belong to user"))
+			})
+		})
+		Context("when getting landings failed", func() {
+			It("Should return an error", func() {
+				// given
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockInsertedFlight, nil)
+				aircraftRepoMock.EXPECT().GetByIDs([]uint{1}).Return([]model.Aircraft{mockAircraft}, nil)
+				landingRepoMock.EXPECT().GetByFlightIDs([]uint{3}).Return(nil, errors.New("failed to fetch landings"))
+
+				// when
+				logbookResponse, err := logbookService.GetLogbookEntry("2", uint(3))
+
+				// then
+				Expect(err.Error()).To(Equal("failed to fetch landings"))
+				Expect(logbookResponse).To(Equal(dto.LogbookResponse{}))
+			})
+		})
+	})
+	Describe("GetLogbookEntries", func() {
+		Context("when flights found", func() {
+			It("Should return page with records", func() {
+				// given
+				mockFlightArr := []model.Flight{
+					{
+						Model:               gorm.Model{ID: 1},
+						UserID:              "1",
+						AircraftID:          1,
+						TakeoffTime:         time.Date(2022, time.March, 25, 0, 0, 0, 0, time.UTC),
+						LandingTime:         time.Date(2022, time.March, 25, 1, 0, 0, 0, time.UTC),
+						TakeoffAirportCode:  "T1",
+						LandingAirportCode:  "L1",
+						Style:               "S1",
+						Remarks:             util.String("R1"),
+						PersonalRemarks:     util.String("PR1"),
+						TotalBlockTime:      util.Duration(1),
+						PilotInCommandTime:  util.Duration(1),
+						SecondInCommandTime: util.Duration(1),
+						DualReceivedTime:    util.Duration(1),
+						DualGivenTime:       util.Duration(1),
+						MultiPilotTime:      util.Duration(1),
+						NightTime:           util.Duration(1),
+						IFRTime:             util.Duration(1),
+						IFRActualTime:       util.Duration(1),
+						IFRSimulatedTime:    util.Duration(1),
+						CrossCountryTime:    util.Duration(1),
+						SimulatorTime:       util.Duration(1),
+						SignatureURL:        util.String("DUAL2"),
+					},
+					{
+						Model:               gorm.Model{ID: 2},
+						UserID:              "1",
+						AircraftID:          3,
+						TakeoffTime:         time.Date(2022, time.March, 26, 5, 0, 0, 0, time.UTC),
+						LandingTime:         time.Date(2022, time.March, 26, 6, 0, 0, 0, time.UTC),
+						TakeoffAirportCode:  "T1",
+						LandingAirportCode:  "L1",
+						Style:               "S1",
+						Remarks:             util.String("R1"),
+						PersonalRemarks:     util.String("PR1"),
+						TotalBlockTime:      util.Duration(2),
+						PilotInCommandTime:  util.Duration(1),
+						SecondInCommandTime: util.Duration(3),
+						DualReceivedTime:    util.Duration(3),
+						DualGivenTime:       util.Duration(2),
+						MultiPilotTime:      util.Duration(5),
+						NightTime:           util.Duration(15),
+						IFRTime:             util.Duration(1),
+						IFRActualTime:       util.Duration(2),
+						IFRSimulatedTime:    util.Duration(21),
+						CrossCountryTime:    util.Duration(2),
+						SimulatorTime:       util.Duration(2),
+						SignatureURL:        util.String("DUAL1"),
+					},
+				}
+				mockPassengerArr := []model.Passenger{
+					{
+						Model:     gorm.Model{ID: 1},
+						FlightID:  1,
+						FirstName: "F1",
+						LastName:  util.String("L1"),
+						Role:      model.RoleSecondInCommand,
+					},
+				}
+				mockPassengerArr2 := []model.Passenger{
+					{
+						Model:     gorm.Model{ID: 2},
+						FlightID:  2,
+						FirstName: "F2",
+						LastName:  util.String("L2"),
+						Role:      model.RoleSecondInCommand,
+					},
+					{
+						Model:     gorm.Model{ID: 3},
+						FlightID:  2,
+						FirstName: "F3",
+						LastName:  util.String("L3"),
+						Role:      model.RoleSecondInCommand,
+					},
+				}
+				mockLandingArr := []model.Landing{
+					{
 						Model:        gorm.Model{ID: 1},
 						FlightID:     1,
 						ApproachType: model.ApproachTypeVisual,
@@ -1263,7 +2334,9 @@ var _ = Describe("LogbookService", func() {
 				mockLandingArr2 := make([]model.Landing, 0)
 				expectedLogbookResponse := []dto.LogbookResponse{
 					{
+						ID:                  uint(1),
 						AircraftID:          uint(1),
+						Aircraft:            dto.AircraftSummary{ID: 1, RegistrationNumber: "SP-ABC", AircraftModel: "C152"},
 						TakeoffTime:         time.Date(2022, time.March, 25, 0, 0, 0, 0, time.UTC),
 						LandingTime:         time.Date(2022, time.March, 25, 1, 0, 0, 0, time.UTC),
 						TakeoffAirportCode:  "T1",
@@ -1284,15 +2357,17 @@ var _ = Describe("LogbookService", func() {
 						CrossCountryTime:    util.Duration(1),
 						SimulatorTime:       util.Duration(1),
 						SignatureURL:        util.String("DUAL2"),
-						Passengers: []dto.PassengerEntry{
+						Passengers: []dto.PassengerResponse{
 							{
+								ID:        1,
 								FirstName: "F1",
 								LastName:  util.String("L1"),
 								Role:      model.RoleSecondInCommand,
 							},
 						},
-						Landings: []dto.LandingEntry{
+						Landings: []dto.LandingResponse{
 							{
+								ID:           1,
 								ApproachType: model.ApproachTypeVisual,
 								Count:        util.Uint(1),
 								NightCount:   util.Uint(1),
@@ -1300,6 +2375,7 @@ var _ = Describe("LogbookService", func() {
 								AirportCode:  util.String("L1"),
 							},
 							{
+								ID:           2,
 								ApproachType: model.ApproachTypeVisual,
 								Count:        util.Uint(1),
 								NightCount:   util.Uint(1),
@@ -1309,7 +2385,9 @@ var _ = Describe("LogbookService", func() {
 						},
 					},
 					{
+						ID:                  uint(2),
 						AircraftID:          uint(3),
+						Aircraft:            dto.AircraftSummary{ID: 3},
 						TakeoffTime:         time.Date(2022, time.March, 26, 5, 0, 0, 0, time.UTC),
 						LandingTime:         time.Date(2022, time.March, 26, 6, 0, 0, 0, time.UTC),
 						TakeoffAirportCode:  "T1",
@@ -1330,70 +2408,78 @@ var _ = Describe("LogbookService", func() {
 						CrossCountryTime:    util.Duration(2),
 						SimulatorTime:       util.Duration(2),
 						SignatureURL:        util.String("DUAL1"),
-						Passengers: []dto.PassengerEntry{
+						Passengers: []dto.PassengerResponse{
 							{
+								ID:        2,
 								FirstName: "F2",
 								LastName:  util.String("L2"),
 								Role:      model.RoleSecondInCommand,
 							},
 							{
+								ID:        3,
 								FirstName: "F3",
 								LastName:  util.String("L3"),
 								Role:      model.RoleSecondInCommand,
 							},
 						},
-						Landings: []dto.LandingEntry{},
+						Landings: []dto.LandingResponse{},
 					},
 				}
 
-				flightRepoMock.EXPECT().GetByUserIDAndDate("1", startDate, endDate).Return(mockFlightArr, nil)
-				landingRepoMock.EXPECT().GetByFlightID(uint(1)).Return(mockLandingArr, nil)
-				landingRepoMock.EXPECT().GetByFlightID(uint(2)).Return(mockLandingArr2, nil)
-				passengerRepoMock.EXPECT().GetByFlightID(uint(1)).Return(mockPassengerArr, nil)
-				passengerRepoMock.EXPECT().GetByFlightID(uint(2)).Return(mockPassengerArr2, nil)
+				flightRepoMock.EXPECT().GetPageByUserID("1", dto.LogbookFilter{Order: dto.SortDescending}, nil, 51).Return(mockFlightArr, nil)
+				aircraftRepoMock.EXPECT().GetByIDs([]uint{1, 3}).Return([]model.Aircraft{
+					{Model: gorm.Model{ID: 1}, UserID: "1", RegistrationNumber: "SP-ABC", AircraftModel: "C152"},
+				}, nil)
+				landingRepoMock.EXPECT().GetByFlightIDs([]uint{1, 2}).Return(append(mockLandingArr, mockLandingArr2...), nil)
+				passengerRepoMock.EXPECT().GetByFlightIDs([]uint{1, 2}).Return(append(mockPassengerArr, mockPassengerArr2...), nil)
+				signatureRepoMock.EXPECT().GetByFlightIDs([]uint{1, 2}).Return([]model.Signature{}, nil)
 
 				// when
-				logbookResponses, err := logbookService.GetLogbookEntries("1", startDate, endDate)
+				logbookPage, err := logbookService.GetLogbookEntries("1", dto.LogbookFilter{}, nil, nil)
 
 				// then
 				Expect(err).To(BeNil())
-				Expect(logbookResponses).To(Equal(expectedLogbookResponse))
+				Expect(logbookPage.Entries).To(Equal(expectedLogbookResponse))
+				Expect(logbookPage.Paging).To(Equal(dto.PagingResponse{Limit: 50}))
 			})
 		})
 		Context("when flights not found", func() {
-			It("Should return empty logbook response array", func() {
+			It("Should return empty page", func() {
 				// given
 
-				flightRepoMock.EXPECT().GetByUserIDAndDate("1", startDate, endDate).Return([]model.Flight{}, nil)
+				flightRepoMock.EXPECT().GetPageByUserID("1", dto.LogbookFilter{Order: dto.SortDescending}, nil, 51).Return([]model.Flight{}, nil)
+				aircraftRepoMock.EXPECT().GetByIDs([]uint{}).Return([]model.Aircraft{}, nil)
+				landingRepoMock.EXPECT().GetByFlightIDs([]uint{}).Return([]model.Landing{}, nil)
+				passengerRepoMock.EXPECT().GetByFlightIDs([]uint{}).Return([]model.Passenger{}, nil)
+				signatureRepoMock.EXPECT().GetByFlightIDs([]uint{}).Return([]model.Signature{}, nil)
 
 				// when
-				logbookResponses, err := logbookService.GetLogbookEntries("1", startDate, endDate)
+				logbookPage, err := logbookService.GetLogbookEntries("1", dto.LogbookFilter{}, nil, nil)
 
 				// then
 				Expect(err).To(BeNil())
-				Expect(logbookResponses).To(HaveLen(0))
-				Expect(logbookResponses).To(Equal([]dto.LogbookResponse{}))
+				Expect(logbookPage.Entries).To(Equal([]dto.LogbookResponse{}))
+				Expect(logbookPage.Paging).To(Equal(dto.PagingResponse{Limit: 50}))
 			})
 		})
 		Context("when getting flights failed", func() {
-			It("Should return an error and empty array", func() {
+			It("Should return an error and empty page", func() {
 				// given
-				flightRepoMock.EXPECT().GetByUserIDAndDate("1", startDate, endDate).Return([]model.Flight{}, errors.New("failed to fetch flights"))
+				flightRepoMock.EXPECT().GetPageByUserID("1", dto.LogbookFilter{Order: dto.SortDescending}, nil, 51).Return([]model.Flight{}, errors.New("failed to fetch flights"))
 
 				// when
-				logbookResponses, err := logbookService.GetLogbookEntries("1", startDate, endDate)
+				logbookPage, err := logbookService.GetLogbookEntries("1", dto.LogbookFilter{}, nil, nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
-				Expect(logbookResponses).To(HaveLen(0))
-				Expect(logbookResponses).To(Equal([]dto.LogbookResponse{}))
+				Expect(logbookPage).To(Equal(dto.LogbookPageResponse{}))
 				Expect(err.Error()).To(Equal("failed to fetch flights"))
 			})
 		})
 		Context("when getting landings failed", func() {
-			It("Should return an error and empty array", func() {
+			It("Should return an error and empty page", func() {
 				// given
-				flightRepoMock.EXPECT().GetByUserIDAndDate("1", startDate, endDate).Return([]model.Flight{
+				flightRepoMock.EXPECT().GetPageByUserID("1", dto.LogbookFilter{Order: dto.SortDescending}, nil, 51).Return([]model.Flight{
 					{
 						Model:               gorm.Model{ID: 1},
 						UserID:              "1",
@@ -1420,22 +2506,22 @@ var _ = Describe("LogbookService", func() {
 						SignatureURL:        util.String("DUAL2"),
 					},
 				}, nil)
-				landingRepoMock.EXPECT().GetByFlightID(uint(1)).Return([]model.Landing{}, errors.New("failed to fetch landings"))
+				aircraftRepoMock.EXPECT().GetByIDs([]uint{1}).Return([]model.Aircraft{}, nil)
+				landingRepoMock.EXPECT().GetByFlightIDs([]uint{1}).Return([]model.Landing{}, errors.New("failed to fetch landings"))
 
 				// when
-				logbookResponses, err := logbookService.GetLogbookEntries("1", startDate, endDate)
+				logbookPage, err := logbookService.GetLogbookEntries("1", dto.LogbookFilter{}, nil, nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
-				Expect(logbookResponses).To(HaveLen(0))
-				Expect(logbookResponses).To(Equal([]dto.LogbookResponse{}))
+				Expect(logbookPage).To(Equal(dto.LogbookPageResponse{}))
 				Expect(err.Error()).To(Equal("failed to fetch landings"))
 			})
 		})
 		Context("when getting passengers failed", func() {
-			It("Should return an error and empty array", func() {
+			It("Should return an error and empty page", func() {
 				// given
-				flightRepoMock.EXPECT().GetByUserIDAndDate("1", startDate, endDate).Return([]model.Flight{
+				flightRepoMock.EXPECT().GetPageByUserID("1", dto.LogbookFilter{Order: dto.SortDescending}, nil, 51).Return([]model.Flight{
 					{
 						Model:               gorm.Model{ID: 1},
 						UserID:              "1",
@@ -1462,18 +2548,664 @@ var _ = Describe("LogbookService", func() {
 						SignatureURL:        util.String("DUAL2"),
 					},
 				}, nil)
-				landingRepoMock.EXPECT().GetByFlightID(uint(1)).Return([]model.Landing{}, nil)
-				passengerRepoMock.EXPECT().GetByFlightID(uint(1)).Return([]model.Passenger{}, errors.New("failed to fetch passengers"))
+				aircraftRepoMock.EXPECT().GetByIDs([]uint{1}).Return([]model.Aircraft{}, nil)
+				landingRepoMock.EXPECT().GetByFlightIDs([]uint{1}).Return([]model.Landing{}, nil)
+				passengerRepoMock.EXPECT().GetByFlightIDs([]uint{1}).Return([]model.Passenger{}, errors.New("failed to fetch passengers"))
 
 				// when
-				logbookResponses, err := logbookService.GetLogbookEntries("1", startDate, endDate)
+				logbookPage, err := logbookService.GetLogbookEntries("1", dto.LogbookFilter{}, nil, nil)
 
 				// then
 				Expect(err).ToNot(BeNil())
-				Expect(logbookResponses).To(HaveLen(0))
-				Expect(logbookResponses).To(Equal([]dto.LogbookResponse{}))
+				Expect(logbookPage).To(Equal(dto.LogbookPageResponse{}))
 				Expect(err.Error()).To(Equal("failed to fetch passengers"))
 			})
 		})
+		Context("when there are more flights than the limit", func() {
+			It("Should return the next cursor pointing after the last entry", func() {
+				// given
+				firstFlight := model.Flight{Model: gorm.Model{ID: 4}, TakeoffTime: fixedTime}
+				secondFlight := model.Flight{Model: gorm.Model{ID: 2}, TakeoffTime: fixedTime.Add(-time.Hour)}
+				thirdFlight := model.Flight{Model: gorm.Model{ID: 3}, TakeoffTime: fixedTime.Add(-2 * time.Hour)}
+				flightRepoMock.EXPECT().GetPageByUserID("1", dto.LogbookFilter{Order: dto.SortDescending}, nil, 3).
+					Return([]model.Flight{firstFlight, secondFlight, thirdFlight}, nil)
+				aircraftRepoMock.EXPECT().GetByIDs([]uint{0}).Return([]model.Aircraft{}, nil)
+				landingRepoMock.EXPECT().GetByFlightIDs([]uint{4, 2}).Return([]model.Landing{}, nil)
+				passengerRepoMock.EXPECT().GetByFlightIDs([]uint{4, 2}).Return([]model.Passenger{}, nil)
+				signatureRepoMock.EXPECT().GetByFlightIDs([]uint{4, 2}).Return([]model.Signature{}, nil)
+
+				// when
+				logbookPage, err := logbookService.GetLogbookEntries("1", dto.LogbookFilter{}, util.Int(2), nil)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(logbookPage.Entries).To(HaveLen(2))
+				Expect(logbookPage.Paging.Limit).To(Equal(2))
+				Expect(logbookPage.Paging.HasMore).To(BeTrue())
+				Expect(logbookPage.Paging.NextCursor).ToNot(BeNil())
+				cursor, err := decodeLogbookCursor(*logbookPage.Paging.NextCursor)
+				Expect(err).To(BeNil())
+				Expect(cursor).To(Equal(dto.LogbookCursor{TakeoffTime: fixedTime.Add(-time.Hour), ID: 2}))
+			})
+		})
+		Context("when the cursor and filters are given", func() {
+			It("Should get the page after the cursor with normalized filters", func() {
+				// given
+				after := dto.LogbookCursor{TakeoffTime: fixedTime, ID: 4}
+				cursor := encodeLogbookCursor(after)
+				role := model.RolePilotInCommand
+				style := model.StyleY
+				filter := dto.LogbookFilter{
+					Start:       &startDate,
+					End:         &endDate,
+					AircraftID:  util.Uint(1),
+					AirportCode: util.String(" waw"),
+					Role:        &role,
+					Style:       &style,
+					Remarks:     util.String("check"),
+					Order:       dto.SortAscending,
+				}
+				expectedFilter := filter
+				expectedFilter.AirportCode = util.String("EPWA")
+				flightRepoMock.EXPECT().GetPageByUserID("1", expectedFilter, &after, 51).Return([]model.Flight{}, nil)
+				aircraftRepoMock.EXPECT().GetByIDs([]uint{}).Return([]model.Aircraft{}, nil)
+				landingRepoMock.EXPECT().GetByFlightIDs([]uint{}).Return([]model.Landing{}, nil)
+				passengerRepoMock.EXPECT().GetByFlightIDs([]uint{}).Return([]model.Passenger{}, nil)
+				signatureRepoMock.EXPECT().GetByFlightIDs([]uint{}).Return([]model.Signature{}, nil)
+
+				// when
+				logbookPage, err := logbookService.GetLogbookEntries("1", filter, nil, &cursor)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(logbookPage.Entries).To(BeEmpty())
+				Expect(logbookPage.Paging).To(Equal(dto.PagingResponse{Limit: 50}))
+			})
+		})
+		Context("when the query is invalid", func() {
+			It("Should return bad request for an invalid limit", func() {
+				// when
+				_, err := logbookService.GetLogbookEntries("1", dto.LogbookFilter{}, util.Int(201), nil)
+
+				// then
+				Expect(errors.Is(err, dto.ErrBadRequest)).To(BeTrue())
+				Expect(err.Error()).To(Equal("bad request: limit must be between 1 and 200"))
+			})
+			It("Should return bad request for an invalid order", func() {
+				// when
+				_, err := logbookService.GetLogbookEntries("1", dto.LogbookFilter{Order: "sideways"}, nil, nil)
+
+				// then
+				Expect(err.Error()).To(Equal("bad request: invalid order: sideways"))
+			})
+			It("Should return bad request for an invalid style", func() {
+				// given
+				style := model.Style("invalidStyle")
+
+				// when
+				_, err := logbookService.GetLogbookEntries("1", dto.LogbookFilter{Style: &style}, nil, nil)
+
+				// then
+				Expect(err.Error()).To(Equal("bad request: invalid style: invalidStyle"))
+			})
+			It("Should return bad request for an end before start", func() {
+				// when
+				_, err := logbookService.GetLogbookEntries("1", dto.LogbookFilter{Start: &endDate, End: &startDate}, nil, nil)
+
+				// then
+				Expect(err.Error()).To(Equal("bad request: end time must not be before start time"))
+			})
+			It("Should return bad request for an invalid cursor", func() {
+				// when
+				_, err := logbookService.GetLogbookEntries("1", dto.LogbookFilter{}, nil, util.String("not a cursor"))
+
+				// then
+				Expect(err.Error()).To(Equal("bad request: invalid cursor"))
+			})
+		})
+	})
+	Describe("GetDuplicateEntries", func() {
+		Context("when the logbook contains duplicates", func() {
+			It("Should group them", func() {
+				// given
+				flights := []model.Flight{
+					{Model: gorm.Model{ID: 1}, TakeoffTime: fixedTime, TakeoffAirportCode: "EPWA", LandingTime: fixedTime.Add(time.Hour), LandingAirportCode: "EPKK"},
+					{Model: gorm.Model{ID: 2}, TakeoffTime: fixedTime.Add(2 * time.Minute), TakeoffAirportCode: "EPWA", LandingTime: fixedTime.Add(time.Hour), LandingAirportCode: "EPKK"},
+					{Model: gorm.Model{ID: 3}, TakeoffTime: fixedTime.Add(4 * time.Minute), TakeoffAirportCode: "EPWA", LandingTime: fixedTime.Add(time.Hour), LandingAirportCode: "EPGD"},
+					{Model: gorm.Model{ID: 4}, TakeoffTime: fixedTime.Add(5 * time.Minute), TakeoffAirportCode: "epwa", LandingTime: fixedTime.Add(time.Hour), LandingAirportCode: "epkk"},
+					{Model: gorm.Model{ID: 5}, TakeoffTime: fixedTime.Add(3 * time.Hour), TakeoffAirportCode: "EPKK", LandingTime: fixedTime.Add(4 * time.Hour), LandingAirportCode: "EPWA"},
+				}
+				flightRepoMock.EXPECT().GetByUserIDOrderedByTakeoffTime("1", nil, nil).Return(flights, nil)
+
+				// when
+				duplicates, err := logbookService.GetDuplicateEntries("1")
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(duplicates).To(HaveLen(1))
+				Expect(duplicates[0].Flights).To(HaveLen(3))
+				Expect(duplicates[0].Flights[0].ID).To(Equal(uint(1)))
+				Expect(duplicates[0].Flights[1].ID).To(Equal(uint(2)))
+				Expect(duplicates[0].Flights[2].ID).To(Equal(uint(4)))
+			})
+		})
+		Context("when getting flights failed", func() {
+			It("Should return an error", func() {
+				// given
+				flightRepoMock.EXPECT().GetByUserIDOrderedByTakeoffTime("1", nil, nil).Return(nil, dto.ErrInternalFailure)
+
+				// when
+				duplicates, err := logbookService.GetDuplicateEntries("1")
+
+				// then
+				Expect(err).To(Equal(dto.ErrInternalFailure))
+				Expect(duplicates).To(BeNil())
+			})
+		})
+	})
+
+	Describe("PreviewNightTime", func() {
+		Context("When the flight is flown after the end of civil twilight", func() {
+			It("Should return night time and night landings", func() {
+				// given
+				takeoffTime := time.Date(2024, time.June, 21, 20, 0, 0, 0, time.UTC)
+
+				// when
+				nightTime, err := logbookService.PreviewNightTime(dto.FlightRoute{
+					TakeoffAirportCode: "WAW",
+					TakeoffTime:        takeoffTime,
+					LandingAirportCode: "EPWA",
+					LandingTime:        takeoffTime.Add(time.Hour),
+					Landings:           2,
+				})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(nightTime).To(Equal(dto.NightTimeResponse{
+					TakeoffAirportCode: "EPWA",
+					LandingAirportCode: "EPWA",
+					NightTime:          time.Hour,
+					NightLanding:       true,
+					NightLandings:      2,
+				}))
+			})
+		})
+		Context("When the flight crosses the end of civil twilight", func() {
+			It("Should return the part of the flight flown after it as night time", func() {
+				// given
+				takeoffTime := time.Date(2024, time.June, 21, 19, 30, 0, 0, time.UTC)
+
+				// when
+				nightTime, err := logbookService.PreviewNightTime(dto.FlightRoute{
+					TakeoffAirportCode: "EPWA",
+					TakeoffTime:        takeoffTime,
+					LandingAirportCode: "EPWA",
+					LandingTime:        takeoffTime.Add(time.Hour),
+					Landings:           1,
+				})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(nightTime.NightTime).To(Equal(40 * time.Minute))
+				Expect(nightTime.NightLanding).To(BeTrue())
+				Expect(nightTime.NightLandings).To(Equal(uint(1)))
+			})
+		})
+		Context("When the flight is flown before the end of civil twilight", func() {
+			It("Should return no night time and day landings", func() {
+				// given
+				takeoffTime := time.Date(2024, time.June, 21, 18, 0, 0, 0, time.UTC)
+
+				// when
+				nightTime, err := logbookService.PreviewNightTime(dto.FlightRoute{
+					TakeoffAirportCode: "EPWA",
+					TakeoffTime:        takeoffTime,
+					LandingAirportCode: "EPWA",
+					LandingTime:        takeoffTime.Add(time.Hour),
+					Landings:           3,
+				})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(nightTime.NightTime).To(BeZero())
+				Expect(nightTime.NightLanding).To(BeFalse())
+				Expect(nightTime.DayLandings).To(Equal(uint(3)))
+				Expect(nightTime.NightLandings).To(BeZero())
+			})
+		})
+		Context("When the airport is unknown", func() {
+			It("Should return an error", func() {
+				// when
+				_, err := logbookService.PreviewNightTime(dto.FlightRoute{
+					TakeoffAirportCode: "EPWA",
+					TakeoffTime:        fixedTime,
+					LandingAirportCode: "XXX",
+					LandingTime:        fixedTime,
+				})
+
+				// then
+				Expect(err.Error()).To(Equal("bad request: unknown airport code: XXX"))
+			})
+		})
+		Context("When the landing time is before the takeoff time", func() {
+			It("Should return an error", func() {
+				// when
+				_, err := logbookService.PreviewNightTime(dto.FlightRoute{
+					TakeoffAirportCode: "EPWA",
+					TakeoffTime:        fixedTime,
+					LandingAirportCode: "EPWA",
+					LandingTime:        fixedTime.Add(-time.Minute),
+				})
+
+				// then
+				Expect(err.Error()).To(Equal("bad request: landing time must not be before takeoff time"))
+			})
+		})
+	})
+
+	Describe("GetLogbookTotals", func() {
+		Context("when no filter is provided", func() {
+			It("Should return totals from repository and no error", func() {
+				// given
+				expectedTotals := dto.TotalsResponse{
+					FlightCount:        2,
+					TotalBlockTime:     3 * time.Hour,
+					PilotInCommandTime: 2 * time.Hour,
+					NightTime:          30 * time.Minute,
+					LandingCount:       4,
+					DayLandingCount:    3,
+					NightLandingCount:  1,
+				}
+				flightRepoMock.EXPECT().GetTotalsByUserID("1", dto.TotalsFilter{}).Return(expectedTotals, nil)
+
+				// when
+				totals, err := logbookService.GetLogbookTotals("1", dto.TotalsFilter{})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(totals).To(Equal(expectedTotals))
+			})
+		})
+		Context("when filter has aircraft owned by the user", func() {
+			It("Should return totals from repository and no error", func() {
+				// given
+				filter := dto.TotalsFilter{Start: &startDate, End: &endDate, AircraftID: util.Uint(1)}
+				expectedTotals := dto.TotalsResponse{FlightCount: 1, TotalBlockTime: time.Hour}
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("1", uint(1)).Return(model.Aircraft{}, nil)
+				flightRepoMock.EXPECT().GetTotalsByUserID("1", filter).Return(expectedTotals, nil)
+
+				// when
+				totals, err := logbookService.GetLogbookTotals("1", filter)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(totals).To(Equal(expectedTotals))
+			})
+		})
+		Context("when filter has aircraft not owned by the user", func() {
+			It("Should return bad request error", func() {
+				// given
+				filter := dto.TotalsFilter{AircraftID: util.Uint(1)}
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("1", uint(1)).Return(model.Aircraft{}, dto.ErrNotFound)
+
+				// when
+				totals, err := logbookService.GetLogbookTotals("1", filter)
+
+				// then
+				Expect(err).ToNot(BeNil())
+				Expect(totals).To(Equal(dto.TotalsResponse{}))
+				Expect(err.Error()).To(Equal("bad request: aircraft does not belong to user"))
+			})
+		})
+		Context("when filter has invalid role", func() {
+			It("Should return bad request error", func() {
+				// given
+				role := model.Role("invalidRole")
+
+				// when
+				totals, err := logbookService.GetLogbookTotals("1", dto.TotalsFilter{Role: &role})
+
+				// then
+				Expect(err).ToNot(BeNil())
+				Expect(totals).To(Equal(dto.TotalsResponse{}))
+				Expect(err.Error()).To(Equal("bad request: invalid role: invalidRole"))
+			})
+		})
+		Context("when end time is before start time", func() {
+			It("Should return bad request error", func() {
+				// when
+				totals, err := logbookService.GetLogbookTotals("1", dto.TotalsFilter{Start: &endDate, End: &startDate})
+
+				// then
+				Expect(err).ToNot(BeNil())
+				Expect(totals).To(Equal(dto.TotalsResponse{}))
+				Expect(err.Error()).To(Equal("bad request: end time must not be before start time"))
+			})
+		})
+		Context("when getting totals failed", func() {
+			It("Should return an error", func() {
+				// given
+				flightRepoMock.EXPECT().GetTotalsByUserID("1", dto.TotalsFilter{}).Return(dto.TotalsResponse{}, errors.New("failed to fetch totals"))
+
+				// when
+				totals, err := logbookService.GetLogbookTotals("1", dto.TotalsFilter{})
+
+				// then
+				Expect(err).ToNot(BeNil())
+				Expect(totals).To(Equal(dto.TotalsResponse{}))
+				Expect(err.Error()).To(Equal("failed to fetch totals"))
+			})
+		})
+	})
+
+	Describe("ExportLogbookPDF", func() {
+		var mockUser model.User
+
+		BeforeEach(func() {
+			mockUser = model.User{
+				ID:        "1",
+				FirstName: util.String("John"),
+				LastName:  util.String("Doe"),
+				Email:     "john@doe.com",
+			}
+			mockFlight.ID = 1
+		})
+
+		Context("when the logbook is exported successfully", func() {
+			It("Should return a PDF document", func() {
+				// given
+				userRepoMock.EXPECT().GetByID("1").Return(mockUser, nil)
+				flightRepoMock.EXPECT().GetByUserIDOrderedByTakeoffTime("1", nil, nil).Return([]model.Flight{mockFlight}, nil)
+				aircraftRepoMock.EXPECT().GetByIDs([]uint{1}).Return([]model.Aircraft{{Model: gorm.Model{ID: 1}, AircraftModel: "Cessna 172", RegistrationNumber: "SP-ABC"}}, nil)
+				landingRepoMock.EXPECT().GetByFlightIDs([]uint{1}).Return([]model.Landing{mockLandingOne}, nil)
+				passengerRepoMock.EXPECT().GetByFlightIDs([]uint{1}).Return([]model.Passenger{mockPassengerOne}, nil)
+				signatureRepoMock.EXPECT().GetByFlightIDs([]uint{1}).Return([]model.Signature{}, nil)
+
+				// when
+				document, err := logbookService.ExportLogbookPDF("1")
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(string(document)).To(HavePrefix("%PDF-"))
+			})
+		})
+		Context("when the logbook spans multiple pages", func() {
+			It("Should return a PDF document", func() {
+				// given
+				flights := make([]model.Flight, 0, 30)
+				flightIDs := make([]uint, 0, 30)
+				for i := 1; i <= 30; i++ {
+					flight := mockFlight
+					flight.ID = uint(i)
+					flights = append(flights, flight)
+					flightIDs = append(flightIDs, uint(i))
+				}
+				userRepoMock.EXPECT().GetByID("1").Return(mockUser, nil)
+				flightRepoMock.EXPECT().GetByUserIDOrderedByTakeoffTime("1", nil, nil).Return(flights, nil)
+				aircraftRepoMock.EXPECT().GetByIDs([]uint{1}).Return([]model.Aircraft{}, nil)
+				landingRepoMock.EXPECT().GetByFlightIDs(flightIDs).Return([]model.Landing{}, nil)
+				passengerRepoMock.EXPECT().GetByFlightIDs(flightIDs).Return([]model.Passenger{}, nil)
+				signatureRepoMock.EXPECT().GetByFlightIDs(flightIDs).Return([]model.Signature{}, nil)
+
+				// when
+				document, err := logbookService.ExportLogbookPDF("1")
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(string(document)).To(HavePrefix("%PDF-"))
+				Expect(strings.Count(string(document), "/Type /Page\n")).To(Equal(3))
+			})
+		})
+		Context("when user has a signature", func() {
+			It("Should download the signature and return a PDF document", func() {
+				// given
+				var signature strings.Builder
+				Expect(png.Encode(&signature, image.NewGray(image.Rect(0, 0, 10, 5)))).To(Succeed())
+				mockUser.SignatureURL = util.String("https://example.com/signature.png")
+				userRepoMock.EXPECT().GetByID("1").Return(mockUser, nil)
+				flightRepoMock.EXPECT().GetByUserIDOrderedByTakeoffTime("1", nil, nil).Return([]model.Flight{}, nil)
+				aircraftRepoMock.EXPECT().GetByIDs([]uint{}).Return([]model.Aircraft{}, nil)
+				landingRepoMock.EXPECT().GetByFlightIDs([]uint{}).Return([]model.Landing{}, nil)
+				passengerRepoMock.EXPECT().GetByFlightIDs([]uint{}).Return([]model.Passenger{}, nil)
+				signatureRepoMock.EXPECT().GetByFlightIDs([]uint{}).Return([]model.Signature{}, nil)
+				httpClientMock.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+					Expect(req.URL.String()).To(Equal("https://example.com/signature.png"))
+					return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(signature.String()))}, nil
+				})
+
+				// when
+				document, err := logbookService.ExportLogbookPDF("1")
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(string(document)).To(HavePrefix("%PDF-"))
+				Expect(string(document)).To(ContainSubstring("/Subtype /Image"))
+			})
+		})
+		Context("when signature download fails", func() {
+			It("Should return a PDF document without signature", func() {
+				// given
+				mockUser.SignatureURL = util.String("https://example.com/signature.png")
+				userRepoMock.EXPECT().GetByID("1").Return(mockUser, nil)
+				flightRepoMock.EXPECT().GetByUserIDOrderedByTakeoffTime("1", nil, nil).Return([]model.Flight{}, nil)
+				aircraftRepoMock.EXPECT().GetByIDs([]uint{}).Return([]model.Aircraft{}, nil)
+				landingRepoMock.EXPECT().GetByFlightIDs([]uint{}).Return([]model.Landing{}, nil)
+				passengerRepoMock.EXPECT().GetByFlightIDs([]uint{}).Return([]model.Passenger{}, nil)
+				signatureRepoMock.EXPECT().GetByFlightIDs([]uint{}).Return([]model.Signature{}, nil)
+				httpClientMock.EXPECT().Do(gomock.Any()).Return(nil, errors.New("connection refused"))
+
+				// when
+				document, err := logbookService.ExportLogbookPDF("1")
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(string(document)).To(HavePrefix("%PDF-"))
+				Expect(string(document)).ToNot(ContainSubstring("/Subtype /Image"))
+			})
+		})
+		Context("when signature is not on an allowed host", func() {
+			It("Should return a PDF document without requesting the signature", func() {
+				// given
+				mockUser.SignatureURL = util.String("http://10.0.0.1/internal")
+				userRepoMock.EXPECT().GetByID("1").Return(mockUser, nil)
+				flightRepoMock.EXPECT().GetByUserIDOrderedByTakeoffTime("1", nil, nil).Return([]model.Flight{}, nil)
+				aircraftRepoMock.EXPECT().GetByIDs([]uint{}).Return([]model.Aircraft{}, nil)
+				landingRepoMock.EXPECT().GetByFlightIDs([]uint{}).Return([]model.Landing{}, nil)
+				passengerRepoMock.EXPECT().GetByFlightIDs([]uint{}).Return([]model.Passenger{}, nil)
+				signatureRepoMock.EXPECT().GetByFlightIDs([]uint{}).Return([]model.Signature{}, nil)
+				httpClientMock.EXPECT().Do(gomock.Any()).Times(0)
+
+				// when
+				document, err := logbookService.ExportLogbookPDF("1")
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(string(document)).To(HavePrefix("%PDF-"))
+				Expect(string(document)).ToNot(ContainSubstring("/Subtype /Image"))
+			})
+		})
+		Context("when fetching user failed", func() {
+			It("Should return an error", func() {
+				// given
+				userRepoMock.EXPECT().GetByID("1").Return(model.User{}, errors.New("failed to get user"))
+
+				// when
+				document, err := logbookService.ExportLogbookPDF("1")
+
+				// then
+				Expect(err.Error()).To(Equal("failed to get user"))
+				Expect(document).To(BeNil())
+			})
+		})
+		Context("when fetching flights failed", func() {
+			It("Should return an error", func() {
+				// given
+				userRepoMock.EXPECT().GetByID("1").Return(mockUser, nil)
+				flightRepoMock.EXPECT().GetByUserIDOrderedByTakeoffTime("1", nil, nil).Return(nil, errors.New("failed to get flights"))
+
+				// when
+				document, err := logbookService.ExportLogbookPDF("1")
+
+				// then
+				Expect(err.Error()).To(Equal("failed to get flights"))
+				Expect(document).To(BeNil())
+			})
+		})
+		Context("when fetching landings failed", func() {
+			It("Should return an error", func() {
+				// given
+				userRepoMock.EXPECT().GetByID("1").Return(mockUser, nil)
+				flightRepoMock.EXPECT().GetByUserIDOrderedByTakeoffTime("1", nil, nil).Return([]model.Flight{mockFlight}, nil)
+				aircraftRepoMock.EXPECT().GetByIDs([]uint{1}).Return([]model.Aircraft{}, nil)
+				landingRepoMock.EXPECT().GetByFlightIDs([]uint{1}).Return(nil, errors.New("failed to get landings"))
+
+				// when
+				document, err := logbookService.ExportLogbookPDF("1")
+
+				// then
+				Expect(err.Error()).To(Equal("failed to get landings"))
+				Expect(document).To(BeNil())
+			})
+		})
+	})
+
+	Describe("ExportLogbookCSV", func() {
+		BeforeEach(func() {
+			mockFlight.ID = 3
+			mockFlight.TotalBlockTime = util.Duration(75 * time.Minute)
+		})
+
+		Context("when columns are selected", func() {
+			It("Should write selected columns of flights in the date range", func() {
+				// given
+				var output strings.Builder
+				flightRepoMock.EXPECT().GetPageByUserID("1", dto.LogbookFilter{Start: &startDate, End: &endDate, Order: dto.SortAscending},
+					nil, csvExportPageSize).Return([]model.Flight{mockFlight}, nil)
+				aircraftRepoMock.EXPECT().GetByIDs([]uint{1}).Return([]model.Aircraft{{Model: gorm.Model{ID: 1}, AircraftModel: "Cessna 172", RegistrationNumber: "SP-ABC"}}, nil)
+				landingRepoMock.EXPECT().GetByFlightIDs([]uint{3}).Return([]model.Landing{mockLandingOne, mockLandingTwo}, nil)
+				passengerRepoMock.EXPECT().GetByFlightIDs([]uint{3}).Return([]model.Passenger{mockPassengerOne, mockPassengerTwo}, nil)
+				signatureRepoMock.EXPECT().GetByFlightIDs([]uint{3}).Return([]model.Signature{}, nil)
+
+				// when
+				err := logbookService.ExportLogbookCSV("1", dto.ExportFilter{
+					Start:   &startDate,
+					End:     &endDate,
+					Columns: []string{"date", "aircraft_registration", "total_block_time", "total_block_time_decimal", "landings", "night_landings", "passengers", "holdings"},
+				}, &output)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(output.String()).To(Equal("date,aircraft_registration,total_block_time,total_block_time_decimal,landings,night_landings,passengers,holdings\n" +
+					"2024-03-25,SP-ABC,1:15,1.25,16,7,PIC John Doe;SIC Jane Doe,\n"))
+			})
+		})
+		Context("when no columns are selected", func() {
+			It("Should write all columns", func() {
+				// given
+				var output strings.Builder
+				flightRepoMock.EXPECT().GetPageByUserID("1", dto.LogbookFilter{Order: dto.SortAscending}, nil, csvExportPageSize).
+					Return([]model.Flight{}, nil)
+
+				// when
+				err := logbookService.ExportLogbookCSV("1", dto.ExportFilter{}, &output)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(output.String()).To(HavePrefix("date,takeoff_airport,takeoff_time,landing_airport,landing_time,aircraft_registration,aircraft_model,style,my_role,total_block_time,total_block_time_decimal,"))
+				Expect(output.String()).To(HaveSuffix(",remarks,personal_remarks\n"))
+			})
+		})
+		Context("when the logbook has more flights than fit on a page", func() {
+			It("Should write the flights of every page", func() {
+				// given
+				var output strings.Builder
+				flights := make([]model.Flight, csvExportPageSize)
+				for i := range flights {
+					flights[i] = mockFlight
+					flights[i].ID = uint(i + 1)
+				}
+				lastFlight := mockFlight
+				lastFlight.ID = csvExportPageSize + 1
+				after := &dto.LogbookCursor{TakeoffTime: mockFlight.TakeoffTime, ID: csvExportPageSize}
+				flightRepoMock.EXPECT().GetPageByUserID("1", dto.LogbookFilter{Order: dto.SortAscending}, nil, csvExportPageSize).
+					Return(flights, nil)
+				flightRepoMock.EXPECT().GetPageByUserID("1", dto.LogbookFilter{Order: dto.SortAscending}, after, csvExportPageSize).
+					Return([]model.Flight{lastFlight}, nil)
+				aircraftRepoMock.EXPECT().GetByIDs([]uint{1}).Return([]model.Aircraft{}, nil).Times(2)
+				landingRepoMock.EXPECT().GetByFlightIDs(gomock.Any()).Return([]model.Landing{}, nil).Times(2)
+				passengerRepoMock.EXPECT().GetByFlightIDs(gomock.Any()).Return([]model.Passenger{}, nil).Times(2)
+				signatureRepoMock.EXPECT().GetByFlightIDs(gomock.Any()).Return([]model.Signature{}, nil).Times(2)
+
+				// when
+				err := logbookService.ExportLogbookCSV("1", dto.ExportFilter{Columns: []string{"date"}}, &output)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(strings.Count(output.String(), "2024-03-25\n")).To(Equal(csvExportPageSize + 1))
+			})
+		})
+		Context("when a remark starts like a spreadsheet formula", func() {
+			It("Should prefix the remark so it is not evaluated", func() {
+				// given
+				var output strings.Builder
+				mockFlight.Remarks = util.String("=HYPERLINK(\"https://example.com\")")
+				mockFlight.PersonalRemarks = util.String("-5 kt headwind")
+				flightRepoMock.EXPECT().GetPageByUserID("1", dto.LogbookFilter{Order: dto.SortAscending}, nil, csvExportPageSize).
+					Return([]model.Flight{mockFlight}, nil)
+				aircraftRepoMock.EXPECT().GetByIDs([]uint{1}).Return([]model.Aircraft{}, nil)
+				landingRepoMock.EXPECT().GetByFlightIDs([]uint{3}).Return([]model.Landing{}, nil)
+				passengerRepoMock.EXPECT().GetByFlightIDs([]uint{3}).Return([]model.Passenger{}, nil)
+				signatureRepoMock.EXPECT().GetByFlightIDs([]uint{3}).Return([]model.Signature{}, nil)
+
+				// when
+				err := logbookService.ExportLogbookCSV("1", dto.ExportFilter{Columns: []string{"remarks", "personal_remarks"}}, &output)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(output.String()).To(Equal("remarks,personal_remarks\n" +
+					"\"'=HYPERLINK(\"\"https://example.com\"\")\",'-5 kt headwind\n"))
+			})
+		})
+		Context("when unknown column is selected", func() {
+			It("Should return bad request error", func() {
+				// given
+				var output strings.Builder
+
+				// when
+				err := logbookService.ExportLogbookCSV("1", dto.ExportFilter{Columns: []string{"date", "color"}}, &output)
+
+				// then
+				Expect(err.Error()).To(Equal("bad request: unknown column: color"))
+				Expect(output.String()).To(BeEmpty())
+			})
+		})
+		Context("when end time is before start time", func() {
+			It("Should return bad request error", func() {
+				// given
+				var output strings.Builder
+
+				// when
+				err := logbookService.ExportLogbookCSV("1", dto.ExportFilter{Start: &endDate, End: &startDate}, &output)
+
+				// then
+				Expect(err.Error()).To(Equal("bad request: end time must not be before start time"))
+				Expect(output.String()).To(BeEmpty())
+			})
+		})
+		Context("when fetching flights failed", func() {
+			It("Should return an error", func() {
+				// given
+				var output strings.Builder
+				flightRepoMock.EXPECT().GetPageByUserID("1", dto.LogbookFilter{Order: dto.SortAscending}, nil, csvExportPageSize).
+					Return(nil, errors.New("failed to get flights"))
+
+				// when
+				err := logbookService.ExportLogbookCSV("1", dto.ExportFilter{}, &output)
+
+				// then
+				Expect(err.Error()).To(Equal("failed to get flights"))
+				Expect(output.String()).To(BeEmpty())
+			})
+		})
 	})
 })
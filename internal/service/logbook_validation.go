@@ -0,0 +1,219 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/go-playground/validator/v10"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// blockTimeTolerance allows the total block time to exceed the difference between landing and takeoff time by the
+// rounding of both times to the minute.
+const blockTimeTolerance = time.Minute
+
+type durationField struct {
+	name     string
+	duration *time.Duration
+}
+
+// validateLogbookEntry checks the tags of the models and the consistency of times and landings of the entry. It
+// returns *dto.ValidationError listing all violations, any other error means that the validation itself failed.
+// AircraftID and FlightID are not validated, as the referenced records may not exist yet.
+func validateLogbookEntry(validate *validator.Validate, flight model.Flight, passengers []model.Passenger,
+	landings []model.Landing) error {
+	validationError := &dto.ValidationError{}
+
+	if err := addFieldErrors(validate, validationError, "", flight, "AircraftID"); err != nil {
+		return err
+	}
+	for i, passenger := range passengers {
+		if err := addFieldErrors(validate, validationError, fmt.Sprintf("passengers[%d].", i), passenger, "FlightID"); err != nil {
+			return err
+		}
+	}
+	for i, landing := range landings {
+		if err := addFieldErrors(validate, validationError, fmt.Sprintf("landings[%d].", i), landing, "FlightID"); err != nil {
+			return err
+		}
+	}
+
+	checkFlightTimes(validationError, flight)
+	checkLandings(validationError, flight, landings)
+
+	if len(validationError.Errors) > 0 {
+		return validationError
+	}
+	return nil
+}
+
+func addFieldErrors(validate *validator.Validate, validationError *dto.ValidationError, prefix string,
+	value interface{}, except ...string) error {
+	err := validate.StructExcept(value, except...)
+	if err == nil {
+		return nil
+	}
+
+	var invalidValidationError *validator.InvalidValidationError
+	if errors.As(err, &invalidValidationError) {
+		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+	}
+	for _, fieldError := range validationErrors {
+		code := dto.CodeInvalidValue
+		if fieldError.Tag() == "required" {
+			code = dto.CodeRequired
+		}
+		validationError.Add(prefix+jsonFieldName(fieldError.Field()), code)
+	}
+	return nil
+}
+
+// jsonFieldName returns the name clients send a field with. Models have no JSON tags, but the fields of the requests
+// they are built from are named in snake case after the same Go fields, so "IFRActualTime" becomes "ifr_actual_time".
+func jsonFieldName(name string) string {
+	runes := []rune(name)
+	var builder strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+			unicode.IsUpper(runes[i-1]) && i+1 < len(runes) && unicode.IsLower(runes[i+1])) {
+			builder.WriteRune('_')
+		}
+		builder.WriteRune(unicode.ToLower(r))
+	}
+	return builder.String()
+}
+
+func checkFlightTimes(validationError *dto.ValidationError, flight model.Flight) {
+	timesOrdered := !flight.TakeoffTime.IsZero() && !flight.LandingTime.IsZero()
+	if timesOrdered && flight.LandingTime.Before(flight.TakeoffTime) {
+		validationError.Add("landing_time", dto.CodeBeforeTakeoffTime)
+		timesOrdered = false
+	}
+
+	subTimes := []durationField{
+		{"pilot_in_command_time", flight.PilotInCommandTime},
+		{"second_in_command_time", flight.SecondInCommandTime},
+		{"dual_received_time", flight.DualReceivedTime},
+		{"dual_given_time", flight.DualGivenTime},
+		{"multi_pilot_time", flight.MultiPilotTime},
+		{"night_time", flight.NightTime},
+		{"ifr_time", flight.IFRTime},
+		{"ifr_actual_time", flight.IFRActualTime},
+		{"ifr_simulated_time", flight.IFRSimulatedTime},
+		{"cross_country_time", flight.CrossCountryTime},
+	}
+
+	for _, field := range append(subTimes,
+		durationField{"total_block_time", flight.TotalBlockTime},
+		durationField{"simulator_time", flight.SimulatorTime}) {
+		if field.duration != nil && *field.duration < 0 {
+			validationError.Add(field.name, dto.CodeNegative)
+		}
+	}
+
+	if flight.TotalBlockTime != nil && *flight.TotalBlockTime >= 0 {
+		if timesOrdered && *flight.TotalBlockTime > flight.LandingTime.Sub(flight.TakeoffTime)+blockTimeTolerance {
+			validationError.Add("total_block_time", dto.CodeExceedsFlightDuration)
+		}
+		for _, field := range subTimes {
+			if field.duration != nil && *field.duration > *flight.TotalBlockTime {
+				validationError.Add(field.name, dto.CodeExceedsTotalBlockTime)
+			}
+		}
+	}
+
+	if flight.IFRTime != nil && *flight.IFRTime >= 0 &&
+		durationValue(flight.IFRActualTime)+durationValue(flight.IFRSimulatedTime) > *flight.IFRTime {
+		validationError.Add("ifr_time", dto.CodeLessThanIFRActualAndSimulatedTime)
+	}
+}
+
+func checkLandings(validationError *dto.ValidationError, flight model.Flight, landings []model.Landing) {
+	var total uint
+	for i, landing := range landings {
+		dayAndNightCount := uintValue(landing.DayCount) + uintValue(landing.NightCount)
+		if landing.Count != nil {
+			if *landing.Count < dayAndNightCount {
+				validationError.Add(fmt.Sprintf("landings[%d].count", i), dto.CodeLessThanDayAndNightCount)
+			}
+			total += *landing.Count
+		} else {
+			total += dayAndNightCount
+		}
+	}
+
+	if durationValue(flight.SimulatorTime) > 0 && total > 0 {
+		validationError.Add("landings", dto.CodeLandingsInSimulator)
+	}
+}
+
+func uintValue(u *uint) uint {
+	if u == nil {
+		return 0
+	}
+	return *u
+}
+
+// newLogbookEntry maps the request into models, FlightID of passengers and landings is set once the flight is
+// created.
+func newLogbookEntry(userID string, aircraftID uint, request dto.LogbookRequest) (model.Flight, []model.Passenger, []model.Landing) {
+	flight := model.Flight{
+		UserID:              userID,
+		AircraftID:          aircraftID,
+		TakeoffTime:         request.TakeoffTime,
+		TakeoffAirportCode:  request.TakeoffAirportCode,
+		LandingTime:         request.LandingTime,
+		LandingAirportCode:  request.LandingAirportCode,
+		Style:               request.Style,
+		MyRole:              request.MyRole,
+		Remarks:             request.Remarks,
+		PersonalRemarks:     request.PersonalRemarks,
+		TotalBlockTime:      request.TotalBlockTime,
+		PilotInCommandTime:  request.PilotInCommandTime,
+		SecondInCommandTime: request.SecondInCommandTime,
+		DualReceivedTime:    request.DualReceivedTime,
+		DualGivenTime:       request.DualGivenTime,
+		MultiPilotTime:      request.MultiPilotTime,
+		NightTime:           request.NightTime,
+		IFRTime:             request.IFRTime,
+		IFRActualTime:       request.IFRActualTime,
+		IFRSimulatedTime:    request.IFRSimulatedTime,
+		CrossCountryTime:    request.CrossCountryTime,
+		SimulatorTime:       request.SimulatorTime,
+		Holdings:            request.Holdings,
+	}
+
+	passengers := make([]model.Passenger, 0, len(request.Passengers))
+	for _, passengerEntry := range request.Passengers {
+		passengers = append(passengers, model.Passenger{
+			Role:         passengerEntry.Role,
+			FirstName:    passengerEntry.FirstName,
+			LastName:     passengerEntry.LastName,
+			Company:      passengerEntry.Company,
+			Phone:        passengerEntry.Phone,
+			EmailAddress: passengerEntry.EmailAddress,
+			Note:         passengerEntry.Note,
+		})
+	}
+
+	landings := make([]model.Landing, 0, len(request.Landings))
+	for _, landingEntry := range request.Landings {
+		landings = append(landings, model.Landing{
+			ApproachType: landingEntry.ApproachType,
+			Count:        landingEntry.Count,
+			NightCount:   landingEntry.NightCount,
+			DayCount:     landingEntry.DayCount,
+			AirportCode:  landingEntry.AirportCode,
+		})
+	}
+
+	return flight, passengers, landings
+}
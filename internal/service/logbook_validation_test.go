@@ -0,0 +1,205 @@
+package service
+
+import (
+	"errors"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"time"
+)
+
+var _ = Describe("LogbookValidation", func() {
+	var (
+		takeoffTime time.Time
+		flight      model.Flight
+		passengers  []model.Passenger
+		landings    []model.Landing
+	)
+
+	BeforeEach(func() {
+		takeoffTime = time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+		flight = model.Flight{
+			UserID:             "1",
+			TakeoffTime:        takeoffTime,
+			TakeoffAirportCode: "EPKK",
+			LandingTime:        takeoffTime.Add(2 * time.Hour),
+			LandingAirportCode: "EPWA",
+			Style:              model.StyleY,
+			MyRole:             model.RolePilotInCommand,
+			TotalBlockTime:     util.Duration(2 * time.Hour),
+			PilotInCommandTime: util.Duration(2 * time.Hour),
+			NightTime:          util.Duration(30 * time.Minute),
+			IFRTime:            util.Duration(time.Hour),
+			IFRActualTime:      util.Duration(20 * time.Minute),
+			IFRSimulatedTime:   util.Duration(40 * time.Minute),
+		}
+		passengers = []model.Passenger{{Role: model.RoleSecondInCommand, FirstName: "John"}}
+		landings = []model.Landing{{ApproachType: model.ApproachTypeVisual, Count: util.Uint(3), DayCount: util.Uint(2), NightCount: util.Uint(1)}}
+	})
+
+	validationErrors := func(err error) []dto.FieldError {
+		var validationError *dto.ValidationError
+		Expect(errors.As(err, &validationError)).To(BeTrue())
+		return validationError.Errors
+	}
+
+	Describe("validateLogbookEntry", func() {
+		Context("when the entry is consistent", func() {
+			It("should return no error", func() {
+				// when
+				err := validateLogbookEntry(util.GetValidator(), flight, passengers, landings)
+
+				// then
+				Expect(err).To(BeNil())
+			})
+		})
+		Context("when block time is rounded up to the minute", func() {
+			It("should return no error", func() {
+				// given
+				flight.LandingTime = takeoffTime.Add(2*time.Hour - 30*time.Second)
+
+				// when
+				err := validateLogbookEntry(util.GetValidator(), flight, passengers, landings)
+
+				// then
+				Expect(err).To(BeNil())
+			})
+		})
+		Context("when several fields are invalid", func() {
+			It("should return all of them", func() {
+				// given
+				flight.TakeoffAirportCode = ""
+				flight.Style = "invalidStyle"
+				passengers[0].FirstName = ""
+				landings[0].ApproachType = ""
+
+				// when
+				err := validateLogbookEntry(util.GetValidator(), flight, passengers, landings)
+
+				// then
+				Expect(validationErrors(err)).To(Equal([]dto.FieldError{
+					{Field: "takeoff_airport_code", Code: dto.CodeRequired},
+					{Field: "style", Code: dto.CodeInvalidValue},
+					{Field: "passengers[0].first_name", Code: dto.CodeRequired},
+					{Field: "landings[0].approach_type", Code: dto.CodeRequired},
+				}))
+				Expect(err.Error()).To(Equal("invalid data in fields: takeoff_airport_code, style, passengers[0].first_name, landings[0].approach_type"))
+			})
+		})
+		Context("when landing time is before takeoff time", func() {
+			It("should return an error for landing time only", func() {
+				// given
+				flight.LandingTime = takeoffTime.Add(-time.Hour)
+
+				// when
+				err := validateLogbookEntry(util.GetValidator(), flight, passengers, landings)
+
+				// then
+				Expect(validationErrors(err)).To(Equal([]dto.FieldError{{Field: "landing_time", Code: dto.CodeBeforeTakeoffTime}}))
+			})
+		})
+		Context("when block time exceeds the flight duration", func() {
+			It("should return an error", func() {
+				// given
+				flight.TotalBlockTime = util.Duration(2*time.Hour + 2*time.Minute)
+
+				// when
+				err := validateLogbookEntry(util.GetValidator(), flight, passengers, landings)
+
+				// then
+				Expect(validationErrors(err)).To(Equal([]dto.FieldError{{Field: "total_block_time", Code: dto.CodeExceedsFlightDuration}}))
+			})
+		})
+		Context("when times exceed block time", func() {
+			It("should return an error for each of them", func() {
+				// given
+				flight.TotalBlockTime = util.Duration(time.Hour)
+				flight.NightTime = util.Duration(90 * time.Minute)
+				flight.IFRTime = nil
+				flight.IFRActualTime = nil
+				flight.IFRSimulatedTime = nil
+
+				// when
+				err := validateLogbookEntry(util.GetValidator(), flight, passengers, landings)
+
+				// then
+				Expect(validationErrors(err)).To(Equal([]dto.FieldError{
+					{Field: "pilot_in_command_time", Code: dto.CodeExceedsTotalBlockTime},
+					{Field: "night_time", Code: dto.CodeExceedsTotalBlockTime},
+				}))
+			})
+		})
+		Context("when a time is negative", func() {
+			It("should return an error", func() {
+				// given
+				flight.NightTime = util.Duration(-time.Minute)
+
+				// when
+				err := validateLogbookEntry(util.GetValidator(), flight, passengers, landings)
+
+				// then
+				Expect(validationErrors(err)).To(Equal([]dto.FieldError{{Field: "night_time", Code: dto.CodeNegative}}))
+			})
+		})
+		Context("when actual and simulated IFR time exceed IFR time", func() {
+			It("should return an error", func() {
+				// given
+				flight.IFRSimulatedTime = util.Duration(41 * time.Minute)
+
+				// when
+				err := validateLogbookEntry(util.GetValidator(), flight, passengers, landings)
+
+				// then
+				Expect(validationErrors(err)).To(Equal([]dto.FieldError{{Field: "ifr_time", Code: dto.CodeLessThanIFRActualAndSimulatedTime}}))
+			})
+		})
+		Context("when day and night landings exceed the landing count", func() {
+			It("should return an error", func() {
+				// given
+				landings = append(landings, model.Landing{ApproachType: model.ApproachTypeVisual, Count: util.Uint(1), DayCount: util.Uint(2)})
+
+				// when
+				err := validateLogbookEntry(util.GetValidator(), flight, passengers, landings)
+
+				// then
+				Expect(validationErrors(err)).To(Equal([]dto.FieldError{{Field: "landings[1].count", Code: dto.CodeLessThanDayAndNightCount}}))
+			})
+		})
+		Context("when a simulator session has landings", func() {
+			It("should return an error", func() {
+				// given
+				flight.SimulatorTime = util.Duration(time.Hour)
+
+				// when
+				err := validateLogbookEntry(util.GetValidator(), flight, passengers, landings)
+
+				// then
+				Expect(validationErrors(err)).To(Equal([]dto.FieldError{{Field: "landings", Code: dto.CodeLandingsInSimulator}}))
+			})
+		})
+		Context("when a simulator session has no landings", func() {
+			It("should return no error", func() {
+				// given
+				flight.SimulatorTime = util.Duration(time.Hour)
+				landings = []model.Landing{{ApproachType: model.ApproachTypeVisual, Count: util.Uint(0)}}
+
+				// when
+				err := validateLogbookEntry(util.GetValidator(), flight, passengers, landings)
+
+				// then
+				Expect(err).To(BeNil())
+			})
+		})
+	})
+
+	Describe("jsonFieldName", func() {
+		It("should return the snake case names of the request fields", func() {
+			Expect(jsonFieldName("TakeoffAirportCode")).To(Equal("takeoff_airport_code"))
+			Expect(jsonFieldName("IFRActualTime")).To(Equal("ifr_actual_time"))
+			Expect(jsonFieldName("WebhookURL")).To(Equal("webhook_url"))
+			Expect(jsonFieldName("Style")).To(Equal("style"))
+		})
+	})
+})
@@ -0,0 +1,194 @@
+package service
+
+import (
+	"fmt"
+	"github.com/avialog/backend/internal/config"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	"github.com/go-playground/validator/v10"
+	"time"
+)
+
+//go:generate mockgen -source=medical.go -destination=medical_mock.go -package service
+type MedicalService interface {
+	GetMedicals(userID string) (dto.MedicalsResponse, error)
+	InsertMedical(userID string, medicalRequest dto.MedicalRequest) (dto.MedicalResponse, error)
+	UpdateMedical(userID string, id uint, medicalRequest dto.MedicalRequest) (dto.MedicalResponse, error)
+	DeleteMedical(userID string, id uint) error
+}
+
+type medicalService struct {
+	medicalRepository repository.MedicalRepository
+	userRepository    repository.UserRepository
+	config            config.Config
+	validator         *validator.Validate
+	now               func() time.Time
+}
+
+func newMedicalService(medicalRepository repository.MedicalRepository, userRepository repository.UserRepository,
+	config config.Config, validator *validator.Validate, now func() time.Time) MedicalService {
+	return &medicalService{medicalRepository: medicalRepository, userRepository: userRepository, config: config,
+		validator: validator, now: now}
+}
+
+// GetMedicals returns the certificates of the user with the validity of their privileges under the rule set of each
+// certificate. Warnings are given only for the most recent certificate of each rule set, as older ones are superseded.
+func (m *medicalService) GetMedicals(userID string) (dto.MedicalsResponse, error) {
+	user, err := m.userRepository.GetByID(userID)
+	if err != nil {
+		return dto.MedicalsResponse{}, err
+	}
+
+	certificates, err := m.medicalRepository.GetByUserID(userID)
+	if err != nil {
+		return dto.MedicalsResponse{}, err
+	}
+
+	medicalsResponse := dto.MedicalsResponse{
+		Certificates: make([]dto.MedicalResponse, 0, len(certificates)),
+		Warnings:     []dto.MedicalWarning{},
+	}
+	warned := make(map[model.RuleSet]bool)
+	for _, certificate := range certificates {
+		medicalResponse := m.adaptMedical(certificate, user.DateOfBirth)
+		medicalsResponse.Certificates = append(medicalsResponse.Certificates, medicalResponse)
+
+		if warned[certificate.RuleSet] {
+			continue
+		}
+		warned[certificate.RuleSet] = true
+		for _, privilege := range medicalResponse.Privileges {
+			if privilege.Lapsed || time.Duration(privilege.DaysLeft)*24*time.Hour <= m.config.ExpiryWarning {
+				medicalsResponse.Warnings = append(medicalsResponse.Warnings, dto.MedicalWarning{
+					RuleSet:       certificate.RuleSet,
+					CertificateID: certificate.ID,
+					Privilege:     privilege.Privilege,
+					ValidUntil:    privilege.ValidUntil,
+					DaysLeft:      privilege.DaysLeft,
+					Lapsed:        privilege.Lapsed,
+				})
+			}
+		}
+	}
+
+	return medicalsResponse, nil
+}
+
+func (m *medicalService) InsertMedical(userID string, medicalRequest dto.MedicalRequest) (dto.MedicalResponse, error) {
+	user, err := m.userRepository.GetByID(userID)
+	if err != nil {
+		return dto.MedicalResponse{}, err
+	}
+
+	certificate := model.MedicalCertificate{UserID: userID}
+	applyMedicalRequest(&certificate, medicalRequest)
+
+	if err := m.validateMedical(certificate, user.DateOfBirth); err != nil {
+		return dto.MedicalResponse{}, err
+	}
+
+	certificate, err = m.medicalRepository.Create(certificate)
+	if err != nil {
+		return dto.MedicalResponse{}, err
+	}
+
+	return m.adaptMedical(certificate, user.DateOfBirth), nil
+}
+
+func (m *medicalService) UpdateMedical(userID string, id uint, medicalRequest dto.MedicalRequest) (dto.MedicalResponse, error) {
+	user, err := m.userRepository.GetByID(userID)
+	if err != nil {
+		return dto.MedicalResponse{}, err
+	}
+
+	certificate, err := m.medicalRepository.GetByUserIDAndID(userID, id)
+	if err != nil {
+		return dto.MedicalResponse{}, err
+	}
+
+	applyMedicalRequest(&certificate, medicalRequest)
+
+	if err := m.validateMedical(certificate, user.DateOfBirth); err != nil {
+		return dto.MedicalResponse{}, err
+	}
+
+	certificate, err = m.medicalRepository.Save(certificate)
+	if err != nil {
+		return dto.MedicalResponse{}, err
+	}
+
+	return m.adaptMedical(certificate, user.DateOfBirth), nil
+}
+
+func (m *medicalService) DeleteMedical(userID string, id uint) error {
+	return m.medicalRepository.DeleteByUserIDAndID(userID, id)
+}
+
+// validateMedical checks the tags of the certificate, that its class exists in its rule set and that the examination
+// took place in the lifetime of the pilot.
+func (m *medicalService) validateMedical(certificate model.MedicalCertificate, dateOfBirth *time.Time) error {
+	validationError := &dto.ValidationError{}
+	if err := addFieldErrors(m.validator, validationError, "", certificate); err != nil {
+		return err
+	}
+
+	if ruleSet, ok := medicalRuleSets[certificate.RuleSet]; ok {
+		if _, ok := ruleSet.privileges[certificate.Class]; !ok {
+			validationError.Add("class", dto.CodeNotInRuleSet)
+		}
+	}
+	if certificate.ExaminedAt.After(m.now()) {
+		validationError.Add("examined_at", dto.CodeInFuture)
+	} else if dateOfBirth != nil && certificate.ExaminedAt.Before(*dateOfBirth) {
+		validationError.Add("examined_at", dto.CodeBeforeDateOfBirth)
+	}
+
+	if len(validationError.Errors) > 0 {
+		return fmt.Errorf("%w: %w", dto.ErrBadRequest, validationError)
+	}
+	return nil
+}
+
+func (m *medicalService) adaptMedical(certificate model.MedicalCertificate, dateOfBirth *time.Time) dto.MedicalResponse {
+	medicalResponse := dto.MedicalResponse{
+		ID:          certificate.ID,
+		RuleSet:     certificate.RuleSet,
+		Class:       certificate.Class,
+		ExaminedAt:  certificate.ExaminedAt,
+		Number:      certificate.Number,
+		Limitations: certificate.Limitations,
+		Remarks:     certificate.Remarks,
+		Privileges:  []dto.MedicalPrivilegeResponse{},
+		UpdatedAt:   certificate.UpdatedAt,
+	}
+	if dateOfBirth == nil {
+		return medicalResponse
+	}
+
+	age := ageAt(*dateOfBirth, certificate.ExaminedAt)
+	medicalResponse.AgeAtExamination = &age
+
+	today := dateOf(m.now())
+	ruleSet := medicalRuleSets[certificate.RuleSet]
+	for _, privilege := range ruleSet.privileges[certificate.Class] {
+		validUntil := ruleSet.validUntil(privilege, certificate.ExaminedAt, *dateOfBirth)
+		daysLeft := int(validUntil.Sub(today).Hours() / 24)
+		medicalResponse.Privileges = append(medicalResponse.Privileges, dto.MedicalPrivilegeResponse{
+			Privilege:  privilege,
+			ValidUntil: validUntil,
+			DaysLeft:   daysLeft,
+			Lapsed:     daysLeft < 0,
+		})
+	}
+	return medicalResponse
+}
+
+func applyMedicalRequest(certificate *model.MedicalCertificate, medicalRequest dto.MedicalRequest) {
+	certificate.RuleSet = medicalRequest.RuleSet
+	certificate.Class = medicalRequest.Class
+	certificate.ExaminedAt = medicalRequest.ExaminedAt
+	certificate.Number = medicalRequest.Number
+	certificate.Limitations = medicalRequest.Limitations
+	certificate.Remarks = medicalRequest.Remarks
+}
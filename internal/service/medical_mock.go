@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: medical.go
+//
+// Generated by this command:
+//
+//	mockgen -source=medical.go -destination=medical_mock.go -package service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	reflect "reflect"
+
+	dto "github.com/avialog/backend/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMedicalService is a mock of MedicalService interface.
+type MockMedicalService struct {
+	ctrl     *gomock.Controller
+	recorder *MockMedicalServiceMockRecorder
+}
+
+// MockMedicalServiceMockRecorder is the mock recorder for MockMedicalService.
+type MockMedicalServiceMockRecorder struct {
+	mock *MockMedicalService
+}
+
+// NewMockMedicalService creates a new mock instance.
+func NewMockMedicalService(ctrl *gomock.Controller) *MockMedicalService {
+	mock := &MockMedicalService{ctrl: ctrl}
+	mock.recorder = &MockMedicalServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMedicalService) EXPECT() *MockMedicalServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteMedical mocks base method.
+func (m *MockMedicalService) DeleteMedical(userID string, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMedical", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteMedical indicates an expected call of DeleteMedical.
+func (mr *MockMedicalServiceMockRecorder) DeleteMedical(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMedical", reflect.TypeOf((*MockMedicalService)(nil).DeleteMedical), userID, id)
+}
+
+// GetMedicals mocks base method.
+func (m *MockMedicalService) GetMedicals(userID string) (dto.MedicalsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMedicals", userID)
+	ret0, _ := ret[0].(dto.MedicalsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMedicals indicates an expected call of GetMedicals.
+func (mr *MockMedicalServiceMockRecorder) GetMedicals(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMedicals", reflect.TypeOf((*MockMedicalService)(nil).GetMedicals), userID)
+}
+
+// InsertMedical mocks base method.
+func (m *MockMedicalService) InsertMedical(userID string, medicalRequest dto.MedicalRequest) (dto.MedicalResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertMedical", userID, medicalRequest)
+	ret0, _ := ret[0].(dto.MedicalResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertMedical indicates an expected call of InsertMedical.
+func (mr *MockMedicalServiceMockRecorder) InsertMedical(userID, medicalRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertMedical", reflect.TypeOf((*MockMedicalService)(nil).InsertMedical), userID, medicalRequest)
+}
+
+// UpdateMedical mocks base method.
+func (m *MockMedicalService) UpdateMedical(userID string, id uint, medicalRequest dto.MedicalRequest) (dto.MedicalResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMedical", userID, id, medicalRequest)
+	ret0, _ := ret[0].(dto.MedicalResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateMedical indicates an expected call of UpdateMedical.
+func (mr *MockMedicalServiceMockRecorder) UpdateMedical(userID, id, medicalRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMedical", reflect.TypeOf((*MockMedicalService)(nil).UpdateMedical), userID, id, medicalRequest)
+}
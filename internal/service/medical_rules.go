@@ -0,0 +1,122 @@
+package service
+
+import (
+	"github.com/avialog/backend/internal/model"
+	"time"
+)
+
+// medicalValidity is the validity of a privilege for pilots examined before reaching belowAge, or at any age if
+// belowAge is 0. If ceasesAtAge is set, the privilege also lapses when the pilot reaches that age.
+type medicalValidity struct {
+	belowAge    int
+	months      int
+	ceasesAtAge int
+}
+
+type medicalRuleSet struct {
+	// privileges of a certificate of each class, a higher class also covers the privileges of the lower classes
+	privileges map[model.MedicalClass][]model.MedicalPrivilege
+	// validity of each privilege, the first one matching the age at the examination applies
+	validity map[model.MedicalPrivilege][]medicalValidity
+	// calendarMonths extends the validity to the end of its last month
+	calendarMonths bool
+}
+
+var medicalRuleSets = map[model.RuleSet]medicalRuleSet{
+	// Part-MED, MED.A.045
+	model.RuleSetEASA: {
+		privileges: map[model.MedicalClass][]model.MedicalPrivilege{
+			model.MedicalClass1:    {model.MedicalPrivilegeClass1, model.MedicalPrivilegeClass2, model.MedicalPrivilegeLAPL},
+			model.MedicalClass2:    {model.MedicalPrivilegeClass2, model.MedicalPrivilegeLAPL},
+			model.MedicalClassLAPL: {model.MedicalPrivilegeLAPL},
+		},
+		validity: map[model.MedicalPrivilege][]medicalValidity{
+			model.MedicalPrivilegeClass1: {
+				{belowAge: 60, months: 12},
+				{months: 6},
+			},
+			model.MedicalPrivilegeClass2: {
+				{belowAge: 40, months: 60, ceasesAtAge: 42},
+				{belowAge: 50, months: 24, ceasesAtAge: 51},
+				{months: 12},
+			},
+			model.MedicalPrivilegeLAPL: {
+				{belowAge: 40, months: 60, ceasesAtAge: 42},
+				{months: 24},
+			},
+		},
+	},
+	// 14 CFR 61.23(d)
+	model.RuleSetFAA: {
+		privileges: map[model.MedicalClass][]model.MedicalPrivilege{
+			model.MedicalClass1: {model.MedicalPrivilegeATP, model.MedicalPrivilegeCommercial, model.MedicalPrivilegePrivate},
+			model.MedicalClass2: {model.MedicalPrivilegeCommercial, model.MedicalPrivilegePrivate},
+			model.MedicalClass3: {model.MedicalPrivilegePrivate},
+		},
+		validity: map[model.MedicalPrivilege][]medicalValidity{
+			model.MedicalPrivilegeATP: {
+				{belowAge: 40, months: 12},
+				{months: 6},
+			},
+			model.MedicalPrivilegeCommercial: {
+				{months: 12},
+			},
+			model.MedicalPrivilegePrivate: {
+				{belowAge: 40, months: 60},
+				{months: 24},
+			},
+		},
+		calendarMonths: true,
+	},
+}
+
+// validUntil returns the last day on which the privilege of a certificate from the examination is valid.
+func (r medicalRuleSet) validUntil(privilege model.MedicalPrivilege, examinedAt, dateOfBirth time.Time) time.Time {
+	examinedOn := dateOf(examinedAt)
+	age := ageAt(dateOfBirth, examinedOn)
+	for _, validity := range r.validity[privilege] {
+		if validity.belowAge != 0 && age >= validity.belowAge {
+			continue
+		}
+
+		validUntil := addMonths(examinedOn, validity.months)
+		if r.calendarMonths {
+			validUntil = time.Date(validUntil.Year(), validUntil.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+		}
+		if validity.ceasesAtAge != 0 {
+			ceasesOn := dateOf(dateOfBirth).AddDate(validity.ceasesAtAge, 0, 0)
+			if ceasesOn.Before(validUntil) {
+				validUntil = ceasesOn
+			}
+		}
+		return validUntil
+	}
+	return examinedOn
+}
+
+func dateOf(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// ageAt returns the age in full years of a person born on dateOfBirth.
+func ageAt(dateOfBirth, date time.Time) int {
+	dateOfBirth, date = dateOf(dateOfBirth), dateOf(date)
+	age := date.Year() - dateOfBirth.Year()
+	if date.Month() < dateOfBirth.Month() || (date.Month() == dateOfBirth.Month() && date.Day() < dateOfBirth.Day()) {
+		age--
+	}
+	return age
+}
+
+// addMonths adds the months to the date, ending on the last day of the month if it is shorter, so that 31 August
+// plus 6 months is the end of February.
+func addMonths(date time.Time, months int) time.Time {
+	firstOfMonth := time.Date(date.Year(), date.Month()+time.Month(months), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+	day := date.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(firstOfMonth.Year(), firstOfMonth.Month(), day, 0, 0, 0, 0, time.UTC)
+}
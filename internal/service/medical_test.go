@@ -0,0 +1,274 @@
+package service
+
+import (
+	"errors"
+	"github.com/avialog/backend/internal/config"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	"github.com/avialog/backend/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"time"
+)
+
+var _ = Describe("MedicalService", func() {
+	var (
+		medicalService     MedicalService
+		medicalRepoCtrl    *gomock.Controller
+		medicalRepoMock    *repository.MockMedicalRepository
+		userRepoCtrl       *gomock.Controller
+		userRepoMock       *repository.MockUserRepository
+		now                time.Time
+		dateOfBirth        time.Time
+		mockUser           model.User
+		medicalRequest     dto.MedicalRequest
+		mockInsertMedical  model.MedicalCertificate
+		mockMedical        model.MedicalCertificate
+		expectedPrivileges []dto.MedicalPrivilegeResponse
+	)
+
+	BeforeEach(func() {
+		medicalRepoCtrl = gomock.NewController(GinkgoT())
+		medicalRepoMock = repository.NewMockMedicalRepository(medicalRepoCtrl)
+		userRepoCtrl = gomock.NewController(GinkgoT())
+		userRepoMock = repository.NewMockUserRepository(userRepoCtrl)
+		now = time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+		medicalService = newMedicalService(medicalRepoMock, userRepoMock, config.Config{ExpiryWarning: 90 * 24 * time.Hour},
+			util.GetValidator(), func() time.Time { return now })
+
+		dateOfBirth = time.Date(1986, 5, 10, 0, 0, 0, 0, time.UTC)
+		mockUser = model.User{ID: "1", DateOfBirth: &dateOfBirth}
+		examinedAt := time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)
+		medicalRequest = dto.MedicalRequest{
+			RuleSet:    model.RuleSetEASA,
+			Class:      model.MedicalClass2,
+			ExaminedAt: examinedAt,
+		}
+		mockInsertMedical = model.MedicalCertificate{
+			UserID:     "1",
+			RuleSet:    model.RuleSetEASA,
+			Class:      model.MedicalClass2,
+			ExaminedAt: examinedAt,
+		}
+		mockMedical = mockInsertMedical
+		mockMedical.ID = 2
+
+		// examined at 37, the 60 months of validity are cut short by the 42nd birthday
+		ceasesAt := time.Date(2028, 5, 10, 0, 0, 0, 0, time.UTC)
+		expectedPrivileges = []dto.MedicalPrivilegeResponse{
+			{Privilege: model.MedicalPrivilegeClass2, ValidUntil: ceasesAt, DaysLeft: 1481},
+			{Privilege: model.MedicalPrivilegeLAPL, ValidUntil: ceasesAt, DaysLeft: 1481},
+		}
+	})
+
+	AfterEach(func() {
+		medicalRepoCtrl.Finish()
+		userRepoCtrl.Finish()
+	})
+
+	Describe("InsertMedical", func() {
+		Context("when the certificate is valid", func() {
+			It("should insert it and return the validity of its privileges", func() {
+				// given
+				userRepoMock.EXPECT().GetByID("1").Return(mockUser, nil)
+				medicalRepoMock.EXPECT().Create(mockInsertMedical).Return(mockMedical, nil)
+
+				// when
+				medical, err := medicalService.InsertMedical("1", medicalRequest)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(medical.ID).To(Equal(uint(2)))
+				Expect(medical.AgeAtExamination).To(Equal(util.Int(37)))
+				Expect(medical.Privileges).To(Equal(expectedPrivileges))
+			})
+		})
+		Context("when the user has no date of birth", func() {
+			It("should insert it without privileges", func() {
+				// given
+				mockUser.DateOfBirth = nil
+				userRepoMock.EXPECT().GetByID("1").Return(mockUser, nil)
+				medicalRepoMock.EXPECT().Create(mockInsertMedical).Return(mockMedical, nil)
+
+				// when
+				medical, err := medicalService.InsertMedical("1", medicalRequest)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(medical.AgeAtExamination).To(BeNil())
+				Expect(medical.Privileges).To(BeEmpty())
+			})
+		})
+		Context("when the class does not exist in the rule set", func() {
+			It("should return bad request error", func() {
+				// given
+				medicalRequest.Class = model.MedicalClass3
+				userRepoMock.EXPECT().GetByID("1").Return(mockUser, nil)
+
+				// when
+				_, err := medicalService.InsertMedical("1", medicalRequest)
+
+				// then
+				Expect(errors.Is(err, dto.ErrBadRequest)).To(BeTrue())
+				var validationError *dto.ValidationError
+				Expect(errors.As(err, &validationError)).To(BeTrue())
+				Expect(validationError.Errors).To(Equal([]dto.FieldError{{Field: "class", Code: dto.CodeNotInRuleSet}}))
+			})
+		})
+		Context("when the examination is in the future", func() {
+			It("should return bad request error", func() {
+				// given
+				medicalRequest.ExaminedAt = now.AddDate(0, 0, 1)
+				userRepoMock.EXPECT().GetByID("1").Return(mockUser, nil)
+
+				// when
+				_, err := medicalService.InsertMedical("1", medicalRequest)
+
+				// then
+				var validationError *dto.ValidationError
+				Expect(errors.As(err, &validationError)).To(BeTrue())
+				Expect(validationError.Errors).To(Equal([]dto.FieldError{{Field: "examined_at", Code: dto.CodeInFuture}}))
+			})
+		})
+		Context("when the examination is before the date of birth", func() {
+			It("should return bad request error", func() {
+				// given
+				medicalRequest.ExaminedAt = dateOfBirth.AddDate(0, 0, -1)
+				userRepoMock.EXPECT().GetByID("1").Return(mockUser, nil)
+
+				// when
+				_, err := medicalService.InsertMedical("1", medicalRequest)
+
+				// then
+				var validationError *dto.ValidationError
+				Expect(errors.As(err, &validationError)).To(BeTrue())
+				Expect(validationError.Errors).To(Equal([]dto.FieldError{{Field: "examined_at", Code: dto.CodeBeforeDateOfBirth}}))
+			})
+		})
+	})
+
+	Describe("UpdateMedical", func() {
+		Context("when the certificate does not exist", func() {
+			It("should return not found error", func() {
+				// given
+				userRepoMock.EXPECT().GetByID("1").Return(mockUser, nil)
+				medicalRepoMock.EXPECT().GetByUserIDAndID("1", uint(2)).
+					Return(model.MedicalCertificate{}, dto.ErrNotFound)
+
+				// when
+				_, err := medicalService.UpdateMedical("1", uint(2), medicalRequest)
+
+				// then
+				Expect(errors.Is(err, dto.ErrNotFound)).To(BeTrue())
+			})
+		})
+		Context("when the certificate belongs to the user", func() {
+			It("should save the changes", func() {
+				// given
+				number := "PL-MED-1234"
+				medicalRequest.Number = &number
+				updatedMedical := mockMedical
+				updatedMedical.Number = &number
+				userRepoMock.EXPECT().GetByID("1").Return(mockUser, nil)
+				medicalRepoMock.EXPECT().GetByUserIDAndID("1", uint(2)).Return(mockMedical, nil)
+				medicalRepoMock.EXPECT().Save(updatedMedical).Return(updatedMedical, nil)
+
+				// when
+				medical, err := medicalService.UpdateMedical("1", uint(2), medicalRequest)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(medical.Number).To(Equal(&number))
+			})
+		})
+	})
+
+	Describe("GetMedicals", func() {
+		Context("when the user has certificates under both rule sets", func() {
+			It("should warn only about the most recent certificate of each rule set", func() {
+				// given
+				dateOfBirth = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+				userRepoMock.EXPECT().GetByID("1").Return(mockUser, nil)
+				medicalRepoMock.EXPECT().GetByUserID("1").Return([]model.MedicalCertificate{
+					{Model: gorm.Model{ID: 3}, RuleSet: model.RuleSetFAA, Class: model.MedicalClass1,
+						ExaminedAt: time.Date(2023, 11, 14, 0, 0, 0, 0, time.UTC)},
+					{Model: gorm.Model{ID: 4}, RuleSet: model.RuleSetEASA, Class: model.MedicalClass2,
+						ExaminedAt: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)},
+					{Model: gorm.Model{ID: 5}, RuleSet: model.RuleSetEASA, Class: model.MedicalClass2,
+						ExaminedAt: time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)},
+				}, nil)
+
+				// when
+				medicals, err := medicalService.GetMedicals("1")
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(medicals.Certificates).To(HaveLen(3))
+				Expect(medicals.Certificates[0].Privileges).To(Equal([]dto.MedicalPrivilegeResponse{
+					{Privilege: model.MedicalPrivilegeATP, ValidUntil: time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC), DaysLeft: 41},
+					{Privilege: model.MedicalPrivilegeCommercial, ValidUntil: time.Date(2024, 11, 30, 0, 0, 0, 0, time.UTC), DaysLeft: 224},
+					{Privilege: model.MedicalPrivilegePrivate, ValidUntil: time.Date(2025, 11, 30, 0, 0, 0, 0, time.UTC), DaysLeft: 589},
+				}))
+				Expect(medicals.Certificates[2].Privileges[0].Lapsed).To(BeTrue())
+				Expect(medicals.Warnings).To(Equal([]dto.MedicalWarning{
+					{RuleSet: model.RuleSetFAA, CertificateID: 3, Privilege: model.MedicalPrivilegeATP,
+						ValidUntil: time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC), DaysLeft: 41},
+				}))
+			})
+		})
+	})
+})
+
+var _ = Describe("medicalRuleSets", func() {
+	Describe("validUntil", func() {
+		Context("when an EASA class 1 holder is examined after 60", func() {
+			It("should be valid for 6 months", func() {
+				// given
+				dateOfBirth := time.Date(1963, 1, 1, 0, 0, 0, 0, time.UTC)
+				examinedAt := time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)
+
+				// when
+				validUntil := medicalRuleSets[model.RuleSetEASA].validUntil(model.MedicalPrivilegeClass1, examinedAt, dateOfBirth)
+
+				// then
+				Expect(validUntil).To(Equal(time.Date(2024, 9, 30, 0, 0, 0, 0, time.UTC)))
+			})
+		})
+		Context("when an EASA class 2 holder is examined between 40 and 50", func() {
+			It("should be valid for 24 months until the 51st birthday", func() {
+				// given
+				dateOfBirth := time.Date(1975, 2, 1, 0, 0, 0, 0, time.UTC)
+				examinedAt := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+				// when
+				validUntil := medicalRuleSets[model.RuleSetEASA].validUntil(model.MedicalPrivilegeClass2, examinedAt, dateOfBirth)
+
+				// then
+				Expect(validUntil).To(Equal(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)))
+			})
+		})
+		Context("when an FAA third class holder is examined before 40", func() {
+			It("should be valid until the end of the 60th calendar month", func() {
+				// given
+				dateOfBirth := time.Date(1990, 7, 4, 0, 0, 0, 0, time.UTC)
+				examinedAt := time.Date(2024, 2, 3, 0, 0, 0, 0, time.UTC)
+
+				// when
+				validUntil := medicalRuleSets[model.RuleSetFAA].validUntil(model.MedicalPrivilegePrivate, examinedAt, dateOfBirth)
+
+				// then
+				Expect(validUntil).To(Equal(time.Date(2029, 2, 28, 0, 0, 0, 0, time.UTC)))
+			})
+		})
+	})
+
+	Describe("addMonths", func() {
+		It("should end on the last day of a shorter month", func() {
+			Expect(addMonths(time.Date(2023, 8, 31, 0, 0, 0, 0, time.UTC), 6)).
+				To(Equal(time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)))
+		})
+	})
+})
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/night"
+	"github.com/avialog/backend/internal/repository"
+	"strings"
+	"time"
+)
+
+// fillNightTime sets night time and the split of landings between day and night when the request doesn't contain
+// them. Airport codes of the request must already be normalized.
+func fillNightTime(airportRepository repository.AirportRepository, request *dto.LogbookRequest) error {
+	if request.TakeoffAirportCode == "" || request.LandingAirportCode == "" ||
+		request.TakeoffTime.IsZero() || request.LandingTime.IsZero() {
+		// the validator reports the missing values
+		return nil
+	}
+
+	takeoffAirport, takeoffFound, err := findAirport(airportRepository, request.TakeoffAirportCode)
+	if err != nil {
+		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+	}
+	landingAirport, landingFound, err := findAirport(airportRepository, request.LandingAirportCode)
+	if err != nil {
+		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+	}
+	if !takeoffFound || !landingFound {
+		// without the position of both airports the night time is left as entered by the pilot
+		return nil
+	}
+
+	if request.NightTime == nil {
+		nightTime := night.Duration(airportPosition(takeoffAirport), request.TakeoffTime,
+			airportPosition(landingAirport), request.LandingTime)
+		request.NightTime = &nightTime
+	}
+
+	if len(request.Landings) == 0 {
+		return nil
+	}
+
+	// landings are copied so the caller's slice isn't modified
+	landings := make([]dto.LandingEntry, len(request.Landings))
+	copy(landings, request.Landings)
+	for i := range landings {
+		if landings[i].Count == nil || landings[i].DayCount != nil || landings[i].NightCount != nil {
+			continue
+		}
+
+		airport := landingAirport
+		if landings[i].AirportCode != nil && strings.TrimSpace(*landings[i].AirportCode) != "" {
+			var found bool
+			if airport, found, err = findAirport(airportRepository, *landings[i].AirportCode); err != nil {
+				return fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+			}
+			if !found {
+				continue
+			}
+		}
+
+		dayCount, nightCount := splitLandings(airport, request.LandingTime, *landings[i].Count)
+		landings[i].DayCount = &dayCount
+		landings[i].NightCount = &nightCount
+	}
+	request.Landings = landings
+
+	return nil
+}
+
+// splitLandings returns the number of day and night landings made at the airport at the landing time of the flight.
+func splitLandings(airport model.Airport, landingTime time.Time, count uint) (uint, uint) {
+	if night.IsNight(landingTime, airportPosition(airport)) {
+		return 0, count
+	}
+	return count, 0
+}
+
+func airportPosition(airport model.Airport) night.Position {
+	return night.Position{Latitude: airport.Latitude, Longitude: airport.Longitude}
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	"strings"
+)
+
+//go:generate mockgen -source=progress.go -destination=progress_mock.go -package service
+type ProgressService interface {
+	GetProgress(userID string, licence string, ruleSet model.RuleSet) (dto.ProgressResponse, error)
+}
+
+type progressService struct {
+	flightRepository repository.FlightRepository
+}
+
+func newProgressService(flightRepository repository.FlightRepository) ProgressService {
+	return &progressService{flightRepository: flightRepository}
+}
+
+// GetProgress evaluates the minimum experience required for the licence under the rule set against the flights of
+// the user in aeroplanes.
+func (p *progressService) GetProgress(userID string, licence string, ruleSet model.RuleSet) (dto.ProgressResponse, error) {
+	licences, ok := licenceRequirements[ruleSet]
+	if !ok {
+		return dto.ProgressResponse{}, fmt.Errorf("%w: unknown rule set %s", dto.ErrBadRequest, ruleSet)
+	}
+	licence = strings.ToUpper(licence)
+	requirements, ok := licences[licence]
+	if !ok {
+		return dto.ProgressResponse{}, fmt.Errorf("%w: no requirements for %s under %s", dto.ErrNotFound, licence, ruleSet)
+	}
+
+	totals, err := p.flightRepository.GetAirplaneTotalsByRoleAndStyle(userID)
+	if err != nil {
+		return dto.ProgressResponse{}, err
+	}
+
+	progressResponse := dto.ProgressResponse{
+		RuleSet:      ruleSet,
+		Licence:      licence,
+		Met:          true,
+		Requirements: make([]dto.ProgressRequirement, 0, len(requirements)),
+	}
+	for _, requirement := range requirements {
+		logged := requirement.logged(totals)
+		remaining := max(requirement.required-logged, 0)
+		progressResponse.Requirements = append(progressResponse.Requirements, dto.ProgressRequirement{
+			Name:      requirement.name,
+			Unit:      requirement.measure.unit,
+			Required:  requirement.required,
+			Logged:    logged,
+			Remaining: remaining,
+			Met:       remaining == 0,
+		})
+		progressResponse.Met = progressResponse.Met && remaining == 0
+	}
+
+	return progressResponse, nil
+}
@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: progress.go
+//
+// Generated by this command:
+//
+//	mockgen -source=progress.go -destination=progress_mock.go -package service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	reflect "reflect"
+
+	dto "github.com/avialog/backend/internal/dto"
+	model "github.com/avialog/backend/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProgressService is a mock of ProgressService interface.
+type MockProgressService struct {
+	ctrl     *gomock.Controller
+	recorder *MockProgressServiceMockRecorder
+}
+
+// MockProgressServiceMockRecorder is the mock recorder for MockProgressService.
+type MockProgressServiceMockRecorder struct {
+	mock *MockProgressService
+}
+
+// NewMockProgressService creates a new mock instance.
+func NewMockProgressService(ctrl *gomock.Controller) *MockProgressService {
+	mock := &MockProgressService{ctrl: ctrl}
+	mock.recorder = &MockProgressServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProgressService) EXPECT() *MockProgressServiceMockRecorder {
+	return m.recorder
+}
+
+// GetProgress mocks base method.
+func (m *MockProgressService) GetProgress(userID, licence string, ruleSet model.RuleSet) (dto.ProgressResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProgress", userID, licence, ruleSet)
+	ret0, _ := ret[0].(dto.ProgressResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProgress indicates an expected call of GetProgress.
+func (mr *MockProgressServiceMockRecorder) GetProgress(userID, licence, ruleSet any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProgress", reflect.TypeOf((*MockProgressService)(nil).GetProgress), userID, licence, ruleSet)
+}
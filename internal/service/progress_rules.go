@@ -0,0 +1,148 @@
+package service
+
+import (
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"slices"
+	"time"
+)
+
+// progressMeasure is the amount of a requirement taken from the totals of flights.
+type progressMeasure struct {
+	unit  dto.ProgressUnit
+	value func(totals dto.ProgressTotals) int64
+}
+
+var (
+	measureTotalTime = progressMeasure{dto.ProgressUnitDuration,
+		func(totals dto.ProgressTotals) int64 { return int64(totals.TotalBlockTime) }}
+	measurePilotInCommandTime = progressMeasure{dto.ProgressUnitDuration,
+		func(totals dto.ProgressTotals) int64 { return int64(totals.PilotInCommandTime) }}
+	measureDualReceivedTime = progressMeasure{dto.ProgressUnitDuration,
+		func(totals dto.ProgressTotals) int64 { return int64(totals.DualReceivedTime) }}
+	measureMultiPilotTime = progressMeasure{dto.ProgressUnitDuration,
+		func(totals dto.ProgressTotals) int64 { return int64(totals.MultiPilotTime) }}
+	measureNightTime = progressMeasure{dto.ProgressUnitDuration,
+		func(totals dto.ProgressTotals) int64 { return int64(totals.NightTime) }}
+	measureInstrumentTime = progressMeasure{dto.ProgressUnitDuration,
+		func(totals dto.ProgressTotals) int64 { return int64(totals.IFRTime) }}
+	measureCrossCountryTime = progressMeasure{dto.ProgressUnitDuration,
+		func(totals dto.ProgressTotals) int64 { return int64(totals.CrossCountryTime) }}
+	measureNightLandings = progressMeasure{dto.ProgressUnitCount,
+		func(totals dto.ProgressTotals) int64 { return totals.NightLandingCount }}
+)
+
+var (
+	soloRoles = []model.Role{model.RoleStudentPilotInCommand}
+	dualRoles = []model.Role{model.RoleDual}
+)
+
+// licenceRequirement is a minimum amount of experience required for a licence. Only flights in one of the roles and
+// styles count, all flights count if they are empty.
+type licenceRequirement struct {
+	name     string
+	measure  progressMeasure
+	roles    []model.Role
+	styles   []model.Style
+	required int64
+}
+
+func hours(hours int) int64 {
+	return int64(time.Duration(hours) * time.Hour)
+}
+
+// licenceRequirements are the minimum experience of aeroplane licences and the instrument rating. Flights in
+// simulators, and the reductions for integrated courses and holders of other licences, are not taken into account.
+var licenceRequirements = map[model.RuleSet]map[string][]licenceRequirement{
+	model.RuleSetEASA: {
+		// FCL.210.A
+		"PPL": {
+			{name: "total_time", measure: measureTotalTime, required: hours(45)},
+			{name: "dual_instruction", measure: measureDualReceivedTime, required: hours(25)},
+			{name: "supervised_solo", measure: measureTotalTime, roles: soloRoles, required: hours(10)},
+			{name: "solo_cross_country", measure: measureCrossCountryTime, roles: soloRoles, required: hours(5)},
+		},
+		// FCL.325.A and Appendix 3
+		"CPL": {
+			{name: "total_time", measure: measureTotalTime, required: hours(200)},
+			{name: "pilot_in_command", measure: measurePilotInCommandTime, required: hours(100)},
+			{name: "pilot_in_command_vfr_cross_country", measure: measureCrossCountryTime, roles: pilotInCommandRoles,
+				styles: []model.Style{model.StyleVFR}, required: hours(20)},
+			{name: "night", measure: measureNightTime, required: hours(5)},
+			{name: "night_landings", measure: measureNightLandings, roles: pilotInCommandRoles, required: 5},
+		},
+		// FCL.610 and Appendix 6
+		"IR": {
+			{name: "pilot_in_command_cross_country", measure: measureCrossCountryTime, roles: pilotInCommandRoles,
+				required: hours(50)},
+			{name: "instrument_instruction", measure: measureInstrumentTime, roles: dualRoles, required: hours(50)},
+		},
+		// FCL.510.A
+		"ATPL": {
+			{name: "total_time", measure: measureTotalTime, required: hours(1500)},
+			{name: "multi_pilot", measure: measureMultiPilotTime, required: hours(500)},
+			{name: "pilot_in_command", measure: measurePilotInCommandTime, required: hours(250)},
+			{name: "cross_country", measure: measureCrossCountryTime, required: hours(200)},
+			{name: "pilot_in_command_cross_country", measure: measureCrossCountryTime, roles: pilotInCommandRoles,
+				required: hours(100)},
+			{name: "instrument", measure: measureInstrumentTime, required: hours(75)},
+			{name: "night", measure: measureNightTime,
+				roles: append([]model.Role{model.RoleSecondInCommand}, pilotInCommandRoles...), required: hours(100)},
+		},
+	},
+	model.RuleSetFAA: {
+		// 14 CFR 61.109(a)
+		"PPL": {
+			{name: "total_time", measure: measureTotalTime, required: hours(40)},
+			{name: "dual_instruction", measure: measureDualReceivedTime, required: hours(20)},
+			{name: "solo", measure: measureTotalTime, roles: soloRoles, required: hours(10)},
+			{name: "dual_cross_country", measure: measureCrossCountryTime, roles: dualRoles, required: hours(3)},
+			{name: "dual_night", measure: measureNightTime, roles: dualRoles, required: hours(3)},
+			{name: "dual_night_landings", measure: measureNightLandings, roles: dualRoles, required: 10},
+			{name: "dual_instrument", measure: measureInstrumentTime, roles: dualRoles, required: hours(3)},
+			{name: "solo_cross_country", measure: measureCrossCountryTime, roles: soloRoles, required: hours(5)},
+		},
+		// 14 CFR 61.129(a)
+		"CPL": {
+			{name: "total_time", measure: measureTotalTime, required: hours(250)},
+			{name: "pilot_in_command", measure: measurePilotInCommandTime, required: hours(100)},
+			{name: "pilot_in_command_cross_country", measure: measureCrossCountryTime, roles: pilotInCommandRoles,
+				required: hours(50)},
+			{name: "dual_instruction", measure: measureDualReceivedTime, required: hours(20)},
+			{name: "dual_instrument", measure: measureInstrumentTime, roles: dualRoles, required: hours(10)},
+		},
+		// 14 CFR 61.65(d)
+		"IR": {
+			{name: "pilot_in_command_cross_country", measure: measureCrossCountryTime, roles: pilotInCommandRoles,
+				required: hours(50)},
+			{name: "instrument", measure: measureInstrumentTime, required: hours(40)},
+			{name: "dual_instrument", measure: measureInstrumentTime, roles: dualRoles, required: hours(15)},
+		},
+		// 14 CFR 61.159(a)
+		"ATPL": {
+			{name: "total_time", measure: measureTotalTime, required: hours(1500)},
+			{name: "cross_country", measure: measureCrossCountryTime, required: hours(500)},
+			{name: "night", measure: measureNightTime, required: hours(100)},
+			{name: "instrument", measure: measureInstrumentTime, required: hours(75)},
+			{name: "pilot_in_command", measure: measurePilotInCommandTime, required: hours(250)},
+			{name: "pilot_in_command_cross_country", measure: measureCrossCountryTime, roles: pilotInCommandRoles,
+				required: hours(100)},
+			{name: "pilot_in_command_night", measure: measureNightTime, roles: pilotInCommandRoles, required: hours(25)},
+		},
+	},
+}
+
+// logged sums the measure over the totals of the roles and styles the requirement counts.
+func (r licenceRequirement) logged(totals []dto.ProgressTotals) int64 {
+	var logged int64
+	for _, total := range totals {
+		if len(r.roles) > 0 && !slices.Contains(r.roles, total.Role) {
+			continue
+		}
+		if len(r.styles) > 0 && !slices.Contains(r.styles, total.Style) {
+			continue
+		}
+		logged += r.measure.value(total)
+	}
+	return logged
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"errors"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"time"
+)
+
+var _ = Describe("ProgressService", func() {
+	var (
+		progressService ProgressService
+		flightRepoCtrl  *gomock.Controller
+		flightRepoMock  *repository.MockFlightRepository
+		mockTotals      []dto.ProgressTotals
+	)
+
+	BeforeEach(func() {
+		flightRepoCtrl = gomock.NewController(GinkgoT())
+		flightRepoMock = repository.NewMockFlightRepository(flightRepoCtrl)
+		progressService = newProgressService(flightRepoMock)
+
+		mockTotals = []dto.ProgressTotals{
+			{
+				Role:             model.RoleDual,
+				Style:            model.StyleVFR,
+				TotalBlockTime:   30 * time.Hour,
+				DualReceivedTime: 30 * time.Hour,
+				CrossCountryTime: 4 * time.Hour,
+			},
+			{
+				Role:             model.RoleStudentPilotInCommand,
+				Style:            model.StyleVFR,
+				TotalBlockTime:   12 * time.Hour,
+				CrossCountryTime: 3 * time.Hour,
+			},
+		}
+	})
+
+	AfterEach(func() {
+		flightRepoCtrl.Finish()
+	})
+
+	Describe("GetProgress", func() {
+		Context("when the user has flown some of the required experience", func() {
+			It("should return each requirement with the logged amount and the remainder", func() {
+				// given
+				flightRepoMock.EXPECT().GetAirplaneTotalsByRoleAndStyle("1").Return(mockTotals, nil)
+
+				// when
+				progress, err := progressService.GetProgress("1", "ppl", model.RuleSetEASA)
+
+				// then
+				Expect(err).ToNot(HaveOccurred())
+				Expect(progress).To(Equal(dto.ProgressResponse{
+					RuleSet: model.RuleSetEASA,
+					Licence: "PPL",
+					Met:     false,
+					Requirements: []dto.ProgressRequirement{
+						{Name: "total_time", Unit: dto.ProgressUnitDuration, Required: hours(45),
+							Logged: hours(42), Remaining: hours(3), Met: false},
+						{Name: "dual_instruction", Unit: dto.ProgressUnitDuration, Required: hours(25),
+							Logged: hours(30), Remaining: 0, Met: true},
+						{Name: "supervised_solo", Unit: dto.ProgressUnitDuration, Required: hours(10),
+							Logged: hours(12), Remaining: 0, Met: true},
+						{Name: "solo_cross_country", Unit: dto.ProgressUnitDuration, Required: hours(5),
+							Logged: hours(3), Remaining: hours(2), Met: false},
+					},
+				}))
+			})
+		})
+		Context("when the user has flown all the required experience", func() {
+			It("should return the licence as met", func() {
+				// given
+				mockTotals = append(mockTotals, dto.ProgressTotals{
+					Role:               model.RolePilotInCommand,
+					Style:              model.StyleVFR,
+					TotalBlockTime:     110 * time.Hour,
+					PilotInCommandTime: 110 * time.Hour,
+					NightTime:          6 * time.Hour,
+					CrossCountryTime:   25 * time.Hour,
+					NightLandingCount:  5,
+				}, dto.ProgressTotals{
+					Role:               model.RolePilotInCommand,
+					Style:              model.StyleIFR,
+					TotalBlockTime:     50 * time.Hour,
+					PilotInCommandTime: 50 * time.Hour,
+					CrossCountryTime:   50 * time.Hour,
+				})
+				flightRepoMock.EXPECT().GetAirplaneTotalsByRoleAndStyle("1").Return(mockTotals, nil)
+
+				// when
+				progress, err := progressService.GetProgress("1", "CPL", model.RuleSetEASA)
+
+				// then
+				Expect(err).ToNot(HaveOccurred())
+				Expect(progress.Met).To(BeTrue())
+				Expect(progress.Requirements).To(HaveLen(5))
+				Expect(progress.Requirements[0].Logged).To(Equal(hours(202)))
+				// solo cross-country flights of the student pilot count as pilot in command
+				Expect(progress.Requirements[2].Logged).To(Equal(hours(28)))
+				Expect(progress.Requirements[4].Unit).To(Equal(dto.ProgressUnitCount))
+				Expect(progress.Requirements[4].Logged).To(Equal(int64(5)))
+			})
+		})
+		Context("when the licence is unknown", func() {
+			It("should return not found error", func() {
+				// when
+				progress, err := progressService.GetProgress("1", "MPL", model.RuleSetFAA)
+
+				// then
+				Expect(errors.Is(err, dto.ErrNotFound)).To(BeTrue())
+				Expect(progress).To(Equal(dto.ProgressResponse{}))
+			})
+		})
+		Context("when the rule set is unknown", func() {
+			It("should return bad request error", func() {
+				// when
+				progress, err := progressService.GetProgress("1", "PPL", model.RuleSet("CAA"))
+
+				// then
+				Expect(errors.Is(err, dto.ErrBadRequest)).To(BeTrue())
+				Expect(progress).To(Equal(dto.ProgressResponse{}))
+			})
+		})
+		Context("when the repository fails to get the totals", func() {
+			It("should return error", func() {
+				// given
+				flightRepoMock.EXPECT().GetAirplaneTotalsByRoleAndStyle("1").Return(nil, errors.New("database error"))
+
+				// when
+				progress, err := progressService.GetProgress("1", "IR", model.RuleSetFAA)
+
+				// then
+				Expect(err).To(MatchError("database error"))
+				Expect(progress).To(Equal(dto.ProgressResponse{}))
+			})
+		})
+	})
+})
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	"github.com/go-playground/validator/v10"
+)
+
+//go:generate mockgen -source=rating.go -destination=rating_mock.go -package service
+type RatingService interface {
+	InsertRating(userID string, ratingRequest dto.RatingRequest) (model.Rating, error)
+	GetUserRatings(userID string) ([]model.Rating, error)
+	GetRating(userID string, id uint) (model.Rating, error)
+	UpdateRating(userID string, id uint, ratingRequest dto.RatingRequest) (model.Rating, error)
+	DeleteRating(userID string, id uint) error
+}
+
+type ratingService struct {
+	ratingRepository  repository.RatingRepository
+	licenceRepository repository.LicenceRepository
+	validator         *validator.Validate
+}
+
+func newRatingService(ratingRepository repository.RatingRepository, licenceRepository repository.LicenceRepository,
+	validator *validator.Validate) RatingService {
+	return &ratingService{ratingRepository: ratingRepository, licenceRepository: licenceRepository, validator: validator}
+}
+
+func (r *ratingService) InsertRating(userID string, ratingRequest dto.RatingRequest) (model.Rating, error) {
+	rating := model.Rating{UserID: userID}
+	applyRatingRequest(&rating, ratingRequest)
+
+	if err := r.validateRating(userID, rating); err != nil {
+		return model.Rating{}, err
+	}
+
+	return r.ratingRepository.Create(rating)
+}
+
+func (r *ratingService) GetUserRatings(userID string) ([]model.Rating, error) {
+	return r.ratingRepository.GetByUserID(userID)
+}
+
+func (r *ratingService) GetRating(userID string, id uint) (model.Rating, error) {
+	return r.ratingRepository.GetByUserIDAndID(userID, id)
+}
+
+func (r *ratingService) UpdateRating(userID string, id uint, ratingRequest dto.RatingRequest) (model.Rating, error) {
+	rating, err := r.ratingRepository.GetByUserIDAndID(userID, id)
+	if err != nil {
+		return model.Rating{}, err
+	}
+
+	applyRatingRequest(&rating, ratingRequest)
+
+	if err := r.validateRating(userID, rating); err != nil {
+		return model.Rating{}, err
+	}
+
+	return r.ratingRepository.Save(rating)
+}
+
+func (r *ratingService) DeleteRating(userID string, id uint) error {
+	return r.ratingRepository.DeleteByUserIDAndID(userID, id)
+}
+
+// validateRating checks the rating and that the licence it is endorsed on belongs to the user.
+func (r *ratingService) validateRating(userID string, rating model.Rating) error {
+	if err := validateQualification(r.validator, rating, rating.IssuedAt, rating.ExpiresAt); err != nil {
+		return err
+	}
+
+	if rating.LicenceID == nil {
+		return nil
+	}
+	if _, err := r.licenceRepository.GetByUserIDAndID(userID, *rating.LicenceID); err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			return fmt.Errorf("%w: licence %d not found", dto.ErrBadRequest, *rating.LicenceID)
+		}
+		return err
+	}
+	return nil
+}
+
+func applyRatingRequest(rating *model.Rating, ratingRequest dto.RatingRequest) {
+	rating.LicenceID = ratingRequest.LicenceID
+	rating.Kind = ratingRequest.Kind
+	rating.Name = ratingRequest.Name
+	rating.IssuedAt = ratingRequest.IssuedAt
+	rating.ExpiresAt = ratingRequest.ExpiresAt
+	rating.Remarks = ratingRequest.Remarks
+}
@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: rating.go
+//
+// Generated by this command:
+//
+//	mockgen -source=rating.go -destination=rating_mock.go -package service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	reflect "reflect"
+
+	dto "github.com/avialog/backend/internal/dto"
+	model "github.com/avialog/backend/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRatingService is a mock of RatingService interface.
+type MockRatingService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRatingServiceMockRecorder
+}
+
+// MockRatingServiceMockRecorder is the mock recorder for MockRatingService.
+type MockRatingServiceMockRecorder struct {
+	mock *MockRatingService
+}
+
+// NewMockRatingService creates a new mock instance.
+func NewMockRatingService(ctrl *gomock.Controller) *MockRatingService {
+	mock := &MockRatingService{ctrl: ctrl}
+	mock.recorder = &MockRatingServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRatingService) EXPECT() *MockRatingServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteRating mocks base method.
+func (m *MockRatingService) DeleteRating(userID string, id uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRating", userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRating indicates an expected call of DeleteRating.
+func (mr *MockRatingServiceMockRecorder) DeleteRating(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRating", reflect.TypeOf((*MockRatingService)(nil).DeleteRating), userID, id)
+}
+
+// GetRating mocks base method.
+func (m *MockRatingService) GetRating(userID string, id uint) (model.Rating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRating", userID, id)
+	ret0, _ := ret[0].(model.Rating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRating indicates an expected call of GetRating.
+func (mr *MockRatingServiceMockRecorder) GetRating(userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRating", reflect.TypeOf((*MockRatingService)(nil).GetRating), userID, id)
+}
+
+// GetUserRatings mocks base method.
+func (m *MockRatingService) GetUserRatings(userID string) ([]model.Rating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserRatings", userID)
+	ret0, _ := ret[0].([]model.Rating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserRatings indicates an expected call of GetUserRatings.
+func (mr *MockRatingServiceMockRecorder) GetUserRatings(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserRatings", reflect.TypeOf((*MockRatingService)(nil).GetUserRatings), userID)
+}
+
+// InsertRating mocks base method.
+func (m *MockRatingService) InsertRating(userID string, ratingRequest dto.RatingRequest) (model.Rating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertRating", userID, ratingRequest)
+	ret0, _ := ret[0].(model.Rating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertRating indicates an expected call of InsertRating.
+func (mr *MockRatingServiceMockRecorder) InsertRating(userID, ratingRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertRating", reflect.TypeOf((*MockRatingService)(nil).InsertRating), userID, ratingRequest)
+}
+
+// UpdateRating mocks base method.
+func (m *MockRatingService) UpdateRating(userID string, id uint, ratingRequest dto.RatingRequest) (model.Rating, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRating", userID, id, ratingRequest)
+	ret0, _ := ret[0].(model.Rating)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRating indicates an expected call of UpdateRating.
+func (mr *MockRatingServiceMockRecorder) UpdateRating(userID, id, ratingRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRating", reflect.TypeOf((*MockRatingService)(nil).UpdateRating), userID, id, ratingRequest)
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"errors"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	"github.com/avialog/backend/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"time"
+)
+
+var _ = Describe("RatingService", func() {
+	var (
+		ratingService   RatingService
+		ratingRepoCtrl  *gomock.Controller
+		ratingRepoMock  *repository.MockRatingRepository
+		licenceRepoCtrl *gomock.Controller
+		licenceRepoMock *repository.MockLicenceRepository
+		expiresAt       time.Time
+		ratingRequest   dto.RatingRequest
+		mockRating      model.Rating
+	)
+
+	BeforeEach(func() {
+		ratingRepoCtrl = gomock.NewController(GinkgoT())
+		ratingRepoMock = repository.NewMockRatingRepository(ratingRepoCtrl)
+		licenceRepoCtrl = gomock.NewController(GinkgoT())
+		licenceRepoMock = repository.NewMockLicenceRepository(licenceRepoCtrl)
+		ratingService = newRatingService(ratingRepoMock, licenceRepoMock, util.GetValidator())
+
+		expiresAt = time.Date(2024, 6, 30, 0, 0, 0, 0, time.UTC)
+		ratingRequest = dto.RatingRequest{
+			LicenceID: util.Uint(2),
+			Kind:      model.RatingKindClass,
+			Name:      "SEP",
+			ExpiresAt: &expiresAt,
+		}
+		mockRating = model.Rating{
+			UserID:    "1",
+			LicenceID: util.Uint(2),
+			Kind:      model.RatingKindClass,
+			Name:      "SEP",
+			ExpiresAt: &expiresAt,
+		}
+	})
+
+	AfterEach(func() {
+		ratingRepoCtrl.Finish()
+		licenceRepoCtrl.Finish()
+	})
+
+	Describe("InsertRating", func() {
+		Context("when the rating is endorsed on a licence of the user", func() {
+			It("should insert the rating", func() {
+				// given
+				licenceRepoMock.EXPECT().GetByUserIDAndID("1", uint(2)).Return(model.Licence{Model: gorm.Model{ID: 2}}, nil)
+				ratingRepoMock.EXPECT().Create(mockRating).Return(mockRating, nil)
+
+				// when
+				rating, err := ratingService.InsertRating("1", ratingRequest)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(rating).To(Equal(mockRating))
+			})
+		})
+		Context("when the licence does not belong to the user", func() {
+			It("should return bad request error", func() {
+				// given
+				licenceRepoMock.EXPECT().GetByUserIDAndID("1", uint(2)).Return(model.Licence{}, dto.ErrNotFound)
+
+				// when
+				_, err := ratingService.InsertRating("1", ratingRequest)
+
+				// then
+				Expect(errors.Is(err, dto.ErrBadRequest)).To(BeTrue())
+				Expect(err.Error()).To(Equal("bad request: licence 2 not found"))
+			})
+		})
+		Context("when the rating is not endorsed on a licence", func() {
+			It("should insert the rating without checking licences", func() {
+				// given
+				ratingRequest.LicenceID = nil
+				mockRating.LicenceID = nil
+				ratingRepoMock.EXPECT().Create(mockRating).Return(mockRating, nil)
+
+				// when
+				_, err := ratingService.InsertRating("1", ratingRequest)
+
+				// then
+				Expect(err).To(BeNil())
+			})
+		})
+		Context("when the kind is unknown", func() {
+			It("should return bad request error", func() {
+				// given
+				ratingRequest.Kind = "LICENCE"
+
+				// when
+				_, err := ratingService.InsertRating("1", ratingRequest)
+
+				// then
+				Expect(errors.Is(err, dto.ErrBadRequest)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("UpdateRating", func() {
+		Context("when the rating does not exist", func() {
+			It("should return not found error", func() {
+				// given
+				ratingRepoMock.EXPECT().GetByUserIDAndID("1", uint(3)).Return(model.Rating{}, dto.ErrNotFound)
+
+				// when
+				_, err := ratingService.UpdateRating("1", uint(3), ratingRequest)
+
+				// then
+				Expect(errors.Is(err, dto.ErrNotFound)).To(BeTrue())
+			})
+		})
+		Context("when the rating is revalidated", func() {
+			It("should save the new expiry date", func() {
+				// given
+				revalidatedAt := expiresAt.AddDate(2, 0, 0)
+				ratingRequest.ExpiresAt = &revalidatedAt
+				mockRating.ID = 3
+				updatedRating := mockRating
+				updatedRating.ExpiresAt = &revalidatedAt
+				ratingRepoMock.EXPECT().GetByUserIDAndID("1", uint(3)).Return(mockRating, nil)
+				licenceRepoMock.EXPECT().GetByUserIDAndID("1", uint(2)).Return(model.Licence{Model: gorm.Model{ID: 2}}, nil)
+				ratingRepoMock.EXPECT().Save(updatedRating).Return(updatedRating, nil)
+
+				// when
+				rating, err := ratingService.UpdateRating("1", uint(3), ratingRequest)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(rating.ExpiresAt).To(Equal(&revalidatedAt))
+			})
+		})
+	})
+})
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/notification"
+	"github.com/avialog/backend/internal/repository"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultReminderDaysBefore = 30
+	reminderSendTimeout       = 30 * time.Second
+	reminderDateLayout        = "2 January 2006"
+)
+
+//go:generate mockgen -source=reminder.go -destination=reminder_mock.go -package service
+type ReminderService interface {
+	GetPreference(userID string) (model.NotificationPreference, error)
+	UpdatePreference(userID string, preferenceRequest dto.ReminderPreferenceRequest) (model.NotificationPreference, error)
+	SendReminders(ctx context.Context) (dto.ReminderResult, error)
+}
+
+type reminderService struct {
+	preferenceRepository repository.PreferenceRepository
+	reminderRepository   repository.ReminderRepository
+	licenceService       LicenceService
+	medicalService       MedicalService
+	currencyService      CurrencyService
+	notifications        notification.Registry
+	validator            *validator.Validate
+	now                  func() time.Time
+}
+
+// lapse is something of the user that lapses on a known date, like a licence or the passenger currency.
+type lapse struct {
+	item     string
+	title    string
+	body     string
+	lapsesAt time.Time
+	daysLeft int
+	data     map[string]string
+}
+
+func newReminderService(preferenceRepository repository.PreferenceRepository, reminderRepository repository.ReminderRepository,
+	licenceService LicenceService, medicalService MedicalService, currencyService CurrencyService,
+	notifications notification.Registry, validator *validator.Validate, now func() time.Time) ReminderService {
+	return &reminderService{preferenceRepository: preferenceRepository, reminderRepository: reminderRepository,
+		licenceService: licenceService, medicalService: medicalService, currencyService: currencyService,
+		notifications: notifications, validator: validator, now: now}
+}
+
+// GetPreference returns the preference of the user, or the default one with all channels disabled if the user has not
+// chosen any.
+func (r *reminderService) GetPreference(userID string) (model.NotificationPreference, error) {
+	preference, err := r.preferenceRepository.GetByUserID(userID)
+	if errors.Is(err, dto.ErrNotFound) {
+		return model.NotificationPreference{
+			UserID:     userID,
+			DaysBefore: defaultReminderDaysBefore,
+			Licences:   true,
+			Medicals:   true,
+			Currency:   true,
+		}, nil
+	}
+	return preference, err
+}
+
+func (r *reminderService) UpdatePreference(userID string, preferenceRequest dto.ReminderPreferenceRequest) (model.NotificationPreference, error) {
+	preference := model.NotificationPreference{
+		UserID:         userID,
+		DaysBefore:     preferenceRequest.DaysBefore,
+		Licences:       preferenceRequest.Licences,
+		Medicals:       preferenceRequest.Medicals,
+		Currency:       preferenceRequest.Currency,
+		EmailEnabled:   preferenceRequest.EmailEnabled,
+		PushEnabled:    preferenceRequest.PushEnabled,
+		WebhookEnabled: preferenceRequest.WebhookEnabled,
+		DeviceToken:    preferenceRequest.DeviceToken,
+		WebhookURL:     preferenceRequest.WebhookURL,
+	}
+
+	validationError := &dto.ValidationError{}
+	if err := addFieldErrors(r.validator, validationError, "", preference); err != nil {
+		return model.NotificationPreference{}, err
+	}
+	if preference.PushEnabled && (preference.DeviceToken == nil || *preference.DeviceToken == "") {
+		validationError.Add("device_token", dto.CodeRequired)
+	}
+	if preference.WebhookEnabled && (preference.WebhookURL == nil || *preference.WebhookURL == "") {
+		validationError.Add("webhook_url", dto.CodeRequired)
+	}
+	if len(validationError.Errors) > 0 {
+		return model.NotificationPreference{}, fmt.Errorf("%w: %w", dto.ErrBadRequest, validationError)
+	}
+
+	return r.preferenceRepository.Save(preference)
+}
+
+// SendReminders reminds every user with an enabled channel of what lapses within the days they chose. Each lapse is
+// reminded of once, a reminder that could not be sent over any channel is tried again by the next run. Failures of a
+// single user are logged, so they do not keep the other users from being reminded. Once the context is done, the
+// remaining users are left to the next run.
+func (r *reminderService) SendReminders(ctx context.Context) (dto.ReminderResult, error) {
+	preferences, err := r.preferenceRepository.GetEnabled()
+	if err != nil {
+		return dto.ReminderResult{}, err
+	}
+
+	result := dto.ReminderResult{Users: len(preferences)}
+	for _, preference := range preferences {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		lapses, err := r.upcomingLapses(preference)
+		if err != nil {
+			logrus.Errorf("error collecting reminders of user %s: %v", preference.UserID, err)
+			result.Failed++
+			continue
+		}
+
+		recipient := notification.Recipient{
+			UserID:      preference.UserID,
+			Email:       preference.User.Email,
+			DeviceToken: preference.DeviceToken,
+			WebhookURL:  preference.WebhookURL,
+		}
+		for _, lapse := range lapses {
+			sent, err := r.remind(ctx, preference, recipient, lapse)
+			if err != nil {
+				logrus.Errorf("error reminding user %s of %s: %v", preference.UserID, lapse.item, err)
+				result.Failed++
+			} else if sent {
+				result.Sent++
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// remind sends the reminder of the lapse over all channels of the user, unless it has already been sent. It succeeds
+// if at least one channel delivered it.
+func (r *reminderService) remind(ctx context.Context, preference model.NotificationPreference, recipient notification.Recipient, lapse lapse) (bool, error) {
+	reminder := model.Reminder{UserID: preference.UserID, Item: lapse.item, LapsesAt: lapse.lapsesAt}
+	claimed, err := r.reminderRepository.Claim(reminder)
+	if err != nil || !claimed {
+		return false, err
+	}
+
+	message := notification.Message{Title: lapse.title, Body: lapse.body, Data: lapse.data}
+	var errs []error
+	delivered := false
+	for _, name := range preference.Channels() {
+		channel, ok := r.notifications.Get(name)
+		if !ok {
+			errs = append(errs, fmt.Errorf("channel %s is not configured", name))
+			continue
+		}
+
+		sendCtx, cancel := context.WithTimeout(ctx, reminderSendTimeout)
+		err := channel.Send(sendCtx, recipient, message)
+		cancel()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		delivered = true
+	}
+	if delivered {
+		for _, err := range errs {
+			logrus.Warnf("reminder of user %s of %s not delivered over every channel: %v", preference.UserID, lapse.item, err)
+		}
+		return true, nil
+	}
+
+	if err := r.reminderRepository.Release(reminder); err != nil {
+		errs = append(errs, err)
+	}
+	return false, errors.Join(errs...)
+}
+
+// upcomingLapses returns what the user chose to be reminded of and lapses within the chosen days. Medicals only count
+// with the most recent certificate of each rule set, as it supersedes the older ones.
+func (r *reminderService) upcomingLapses(preference model.NotificationPreference) ([]lapse, error) {
+	today := dateOf(r.now())
+	var lapses []lapse
+
+	if preference.Licences {
+		summary, err := r.licenceService.GetExpirySummary(preference.UserID)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range append(summary.ExpiringSoon, summary.Valid...) {
+			if item.ExpiresAt == nil {
+				continue
+			}
+			kind := strings.ToLower(string(item.Type))
+			lapses = append(lapses, lapse{
+				item:     fmt.Sprintf("%s:%d", item.Type, item.ID),
+				title:    fmt.Sprintf("%s expires %s", item.Name, inDays(today, *item.ExpiresAt)),
+				body:     fmt.Sprintf("Your %s %s expires on %s.", kind, item.Name, item.ExpiresAt.Format(reminderDateLayout)),
+				lapsesAt: *item.ExpiresAt,
+				daysLeft: daysBetween(today, *item.ExpiresAt),
+				data:     map[string]string{"type": string(item.Type), "id": strconv.FormatUint(uint64(item.ID), 10)},
+			})
+		}
+	}
+
+	if preference.Medicals {
+		medicals, err := r.medicalService.GetMedicals(preference.UserID)
+		if err != nil {
+			return nil, err
+		}
+		seen := make(map[model.RuleSet]bool)
+		for _, certificate := range medicals.Certificates {
+			if seen[certificate.RuleSet] {
+				continue
+			}
+			seen[certificate.RuleSet] = true
+			for _, privilege := range certificate.Privileges {
+				name := fmt.Sprintf("%s %s medical", certificate.RuleSet, privilege.Privilege)
+				lapses = append(lapses, lapse{
+					item:     fmt.Sprintf("MEDICAL:%d:%s", certificate.ID, privilege.Privilege),
+					title:    fmt.Sprintf("%s expires %s", name, inDays(today, privilege.ValidUntil)),
+					body:     fmt.Sprintf("Your %s expires on %s.", name, privilege.ValidUntil.Format(reminderDateLayout)),
+					lapsesAt: privilege.ValidUntil,
+					daysLeft: privilege.DaysLeft,
+					data: map[string]string{"type": "MEDICAL", "id": strconv.FormatUint(uint64(certificate.ID), 10),
+						"privilege": string(privilege.Privilege)},
+				})
+			}
+		}
+	}
+
+	if preference.Currency {
+		currencies, err := r.currencyService.GetCurrency(preference.UserID)
+		if err != nil {
+			return nil, err
+		}
+		for _, currency := range currencies {
+			category := "all aircraft"
+			if currency.Category != nil {
+				category = string(*currency.Category)
+			}
+			for _, named := range []struct {
+				name   string
+				status dto.CurrencyStatus
+			}{
+				{"passenger", currency.Passenger},
+				{"night passenger", currency.NightPassenger},
+				{"instrument", currency.Instrument},
+			} {
+				name, status := named.name, named.status
+				if !status.Current || status.LapsesAt == nil {
+					continue
+				}
+				lapses = append(lapses, lapse{
+					item:     fmt.Sprintf("CURRENCY:%s:%s", category, name),
+					title:    fmt.Sprintf("%s currency (%s) lapses %s", capitalize(name), category, inDays(today, *status.LapsesAt)),
+					body:     fmt.Sprintf("Your %s currency (%s) lapses on %s.", name, category, status.LapsesAt.Format(reminderDateLayout)),
+					lapsesAt: *status.LapsesAt,
+					daysLeft: daysBetween(today, *status.LapsesAt),
+					data:     map[string]string{"type": "CURRENCY", "category": category, "currency": name},
+				})
+			}
+		}
+	}
+
+	upcoming := make([]lapse, 0, len(lapses))
+	for _, lapse := range lapses {
+		if lapse.daysLeft >= 0 && lapse.daysLeft <= preference.DaysBefore {
+			upcoming = append(upcoming, lapse)
+		}
+	}
+	return upcoming, nil
+}
+
+// daysBetween returns the number of calendar days from the day to the date, in UTC.
+func daysBetween(day, date time.Time) int {
+	return int(dateOf(date).Sub(dateOf(day)).Hours() / 24)
+}
+
+func inDays(today, date time.Time) string {
+	switch days := daysBetween(today, date); days {
+	case 0:
+		return "today"
+	case 1:
+		return "tomorrow"
+	default:
+		return fmt.Sprintf("in %d days", days)
+	}
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
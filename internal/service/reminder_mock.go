@@ -0,0 +1,87 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: reminder.go
+//
+// Generated by this command:
+//
+//	mockgen -source=reminder.go -destination=reminder_mock.go -package service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/avialog/backend/internal/dto"
+	model "github.com/avialog/backend/internal/model"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReminderService is a mock of ReminderService interface.
+type MockReminderService struct {
+	ctrl     *gomock.Controller
+	recorder *MockReminderServiceMockRecorder
+}
+
+// MockReminderServiceMockRecorder is the mock recorder for MockReminderService.
+type MockReminderServiceMockRecorder struct {
+	mock *MockReminderService
+}
+
+// NewMockReminderService creates a new mock instance.
+func NewMockReminderService(ctrl *gomock.Controller) *MockReminderService {
+	mock := &MockReminderService{ctrl: ctrl}
+	mock.recorder = &MockReminderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReminderService) EXPECT() *MockReminderServiceMockRecorder {
+	return m.recorder
+}
+
+// GetPreference mocks base method.
+func (m *MockReminderService) GetPreference(userID string) (model.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreference", userID)
+	ret0, _ := ret[0].(model.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreference indicates an expected call of GetPreference.
+func (mr *MockReminderServiceMockRecorder) GetPreference(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreference", reflect.TypeOf((*MockReminderService)(nil).GetPreference), userID)
+}
+
+// SendReminders mocks base method.
+func (m *MockReminderService) SendReminders(ctx context.Context) (dto.ReminderResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendReminders", ctx)
+	ret0, _ := ret[0].(dto.ReminderResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SendReminders indicates an expected call of SendReminders.
+func (mr *MockReminderServiceMockRecorder) SendReminders(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendReminders", reflect.TypeOf((*MockReminderService)(nil).SendReminders), ctx)
+}
+
+// UpdatePreference mocks base method.
+func (m *MockReminderService) UpdatePreference(userID string, preferenceRequest dto.ReminderPreferenceRequest) (model.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePreference", userID, preferenceRequest)
+	ret0, _ := ret[0].(model.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePreference indicates an expected call of UpdatePreference.
+func (mr *MockReminderServiceMockRecorder) UpdatePreference(userID, preferenceRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreference", reflect.TypeOf((*MockReminderService)(nil).UpdatePreference), userID, preferenceRequest)
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/notification"
+	"github.com/avialog/backend/internal/repository"
+	"github.com/avialog/backend/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"time"
+)
+
+var _ = Describe("ReminderService", func() {
+	var (
+		reminderService    ReminderService
+		preferenceRepoCtrl *gomock.Controller
+		preferenceRepoMock *repository.MockPreferenceRepository
+		reminderRepoCtrl   *gomock.Controller
+		reminderRepoMock   *repository.MockReminderRepository
+		licenceCtrl        *gomock.Controller
+		licenceMock        *MockLicenceService
+		medicalCtrl        *gomock.Controller
+		medicalMock        *MockMedicalService
+		currencyCtrl       *gomock.Controller
+		currencyMock       *MockCurrencyService
+		channelCtrl        *gomock.Controller
+		emailChannel       *notification.MockChannel
+		pushChannel        *notification.MockChannel
+		notifications      notification.Registry
+		now                time.Time
+		deviceToken        string
+		mockPreference     model.NotificationPreference
+	)
+
+	BeforeEach(func() {
+		preferenceRepoCtrl = gomock.NewController(GinkgoT())
+		preferenceRepoMock = repository.NewMockPreferenceRepository(preferenceRepoCtrl)
+		reminderRepoCtrl = gomock.NewController(GinkgoT())
+		reminderRepoMock = repository.NewMockReminderRepository(reminderRepoCtrl)
+		licenceCtrl = gomock.NewController(GinkgoT())
+		licenceMock = NewMockLicenceService(licenceCtrl)
+		medicalCtrl = gomock.NewController(GinkgoT())
+		medicalMock = NewMockMedicalService(medicalCtrl)
+		currencyCtrl = gomock.NewController(GinkgoT())
+		currencyMock = NewMockCurrencyService(currencyCtrl)
+		channelCtrl = gomock.NewController(GinkgoT())
+		emailChannel = notification.NewMockChannel(channelCtrl)
+		pushChannel = notification.NewMockChannel(channelCtrl)
+		notifications = notification.NewRegistry()
+		notifications.Register(model.NotificationChannelEmail, emailChannel)
+		notifications.Register(model.NotificationChannelPush, pushChannel)
+		now = time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+		reminderService = newReminderService(preferenceRepoMock, reminderRepoMock, licenceMock, medicalMock, currencyMock,
+			notifications, util.GetValidator(), func() time.Time { return now })
+
+		deviceToken = "device-token"
+		mockPreference = model.NotificationPreference{
+			UserID:       "1",
+			User:         model.User{ID: "1", Email: "pilot@example.com"},
+			DaysBefore:   30,
+			Licences:     true,
+			EmailEnabled: true,
+			PushEnabled:  true,
+			DeviceToken:  &deviceToken,
+		}
+	})
+
+	AfterEach(func() {
+		preferenceRepoCtrl.Finish()
+		reminderRepoCtrl.Finish()
+		licenceCtrl.Finish()
+		medicalCtrl.Finish()
+		currencyCtrl.Finish()
+		channelCtrl.Finish()
+	})
+
+	Describe("GetPreference", func() {
+		Context("when the user has not chosen any preference", func() {
+			It("should return the defaults with all channels disabled", func() {
+				// given
+				preferenceRepoMock.EXPECT().GetByUserID("1").
+					Return(model.NotificationPreference{}, fmt.Errorf("%w: %v", dto.ErrNotFound, gorm.ErrRecordNotFound))
+
+				// when
+				preference, err := reminderService.GetPreference("1")
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(preference).To(Equal(model.NotificationPreference{UserID: "1", DaysBefore: 30, Licences: true,
+					Medicals: true, Currency: true}))
+			})
+		})
+	})
+
+	Describe("UpdatePreference", func() {
+		var preferenceRequest dto.ReminderPreferenceRequest
+
+		BeforeEach(func() {
+			preferenceRequest = dto.ReminderPreferenceRequest{
+				DaysBefore:   14,
+				Medicals:     true,
+				EmailEnabled: true,
+			}
+		})
+
+		Context("when the preference is valid", func() {
+			It("should save it", func() {
+				// given
+				expectedPreference := model.NotificationPreference{UserID: "1", DaysBefore: 14, Medicals: true,
+					EmailEnabled: true}
+				preferenceRepoMock.EXPECT().Save(expectedPreference).Return(expectedPreference, nil)
+
+				// when
+				preference, err := reminderService.UpdatePreference("1", preferenceRequest)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(preference).To(Equal(expectedPreference))
+			})
+		})
+		Context("when enabled channels have no address", func() {
+			It("should return bad request error", func() {
+				// given
+				webhookURL := "http://hooks.example.com"
+				preferenceRequest.PushEnabled = true
+				preferenceRequest.WebhookEnabled = true
+				preferenceRequest.WebhookURL = &webhookURL
+
+				// when
+				_, err := reminderService.UpdatePreference("1", preferenceRequest)
+
+				// then
+				Expect(errors.Is(err, dto.ErrBadRequest)).To(BeTrue())
+				var validationError *dto.ValidationError
+				Expect(errors.As(err, &validationError)).To(BeTrue())
+				Expect(validationError.Errors).To(Equal([]dto.FieldError{
+					{Field: "webhook_url", Code: dto.CodeInvalidValue},
+					{Field: "device_token", Code: dto.CodeRequired},
+				}))
+			})
+		})
+	})
+
+	Describe("SendReminders", func() {
+		var (
+			licenceExpiresAt time.Time
+			ratingExpiresAt  time.Time
+			licenceReminder  model.Reminder
+		)
+
+		BeforeEach(func() {
+			licenceExpiresAt = time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+			ratingExpiresAt = time.Date(2024, 7, 31, 0, 0, 0, 0, time.UTC)
+			licenceReminder = model.Reminder{UserID: "1", Item: "LICENCE:2", LapsesAt: licenceExpiresAt}
+			preferenceRepoMock.EXPECT().GetEnabled().Return([]model.NotificationPreference{mockPreference}, nil)
+			licenceMock.EXPECT().GetExpirySummary("1").Return(dto.ExpirySummaryResponse{
+				Valid: []dto.ExpiryItem{
+					{Type: dto.ExpiryItemTypeRating, ID: 3, Name: "SEP", ExpiresAt: &ratingExpiresAt, DaysLeft: util.Int(101)},
+				},
+				ExpiringSoon: []dto.ExpiryItem{
+					{Type: dto.ExpiryItemTypeLicence, ID: 2, Name: "CPL PL.FCL.1", ExpiresAt: &licenceExpiresAt, DaysLeft: util.Int(10)},
+				},
+			}, nil)
+		})
+
+		Context("when a licence lapses within the chosen days", func() {
+			It("should remind the user of it over all enabled channels", func() {
+				// given
+				reminderRepoMock.EXPECT().Claim(licenceReminder).Return(true, nil)
+				expectedRecipient := notification.Recipient{UserID: "1", Email: "pilot@example.com", DeviceToken: &deviceToken}
+				expectedMessage := notification.Message{
+					Title: "CPL PL.FCL.1 expires in 11 days",
+					Body:  "Your licence CPL PL.FCL.1 expires on 1 May 2024.",
+					Data:  map[string]string{"type": "LICENCE", "id": "2"},
+				}
+				emailChannel.EXPECT().Send(gomock.Any(), expectedRecipient, expectedMessage).Return(nil)
+				pushChannel.EXPECT().Send(gomock.Any(), expectedRecipient, expectedMessage).
+					Return(errors.New("registration token is not valid"))
+
+				// when
+				result, err := reminderService.SendReminders(context.Background())
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(result).To(Equal(dto.ReminderResult{Users: 1, Sent: 1}))
+			})
+		})
+		Context("when the reminder has already been sent", func() {
+			It("should not send it again", func() {
+				// given
+				reminderRepoMock.EXPECT().Claim(licenceReminder).Return(false, nil)
+
+				// when
+				result, err := reminderService.SendReminders(context.Background())
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(result).To(Equal(dto.ReminderResult{Users: 1}))
+			})
+		})
+		Context("when no channel delivers the reminder", func() {
+			It("should release it to be sent by the next run", func() {
+				// given
+				reminderRepoMock.EXPECT().Claim(licenceReminder).Return(true, nil)
+				emailChannel.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("connection refused"))
+				pushChannel.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("unavailable"))
+				reminderRepoMock.EXPECT().Release(licenceReminder).Return(nil)
+
+				// when
+				result, err := reminderService.SendReminders(context.Background())
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(result).To(Equal(dto.ReminderResult{Users: 1, Failed: 1}))
+			})
+		})
+	})
+
+	Describe("SendReminders", func() {
+		Context("when medical and currency reminders are enabled", func() {
+			It("should remind of the most recent medical and the lapsing currency", func() {
+				// given
+				category := model.AircraftCategoryAirplane
+				passengerLapsesAt := time.Date(2024, 4, 25, 14, 30, 0, 0, time.UTC)
+				mockPreference.Licences = false
+				mockPreference.Medicals = true
+				mockPreference.Currency = true
+				mockPreference.PushEnabled = false
+				preferenceRepoMock.EXPECT().GetEnabled().Return([]model.NotificationPreference{mockPreference}, nil)
+				medicalMock.EXPECT().GetMedicals("1").Return(dto.MedicalsResponse{
+					Certificates: []dto.MedicalResponse{
+						{ID: 4, RuleSet: model.RuleSetEASA, Privileges: []dto.MedicalPrivilegeResponse{
+							{Privilege: model.MedicalPrivilegeClass2, ValidUntil: time.Date(2024, 5, 4, 0, 0, 0, 0, time.UTC), DaysLeft: 14},
+						}},
+						{ID: 3, RuleSet: model.RuleSetEASA, Privileges: []dto.MedicalPrivilegeResponse{
+							{Privilege: model.MedicalPrivilegeClass2, ValidUntil: time.Date(2024, 4, 24, 0, 0, 0, 0, time.UTC), DaysLeft: 4},
+						}},
+					},
+				}, nil)
+				currencyMock.EXPECT().GetCurrency("1").Return([]dto.CurrencyResponse{
+					{
+						Category:   &category,
+						Passenger:  dto.CurrencyStatus{Current: true, LapsesAt: &passengerLapsesAt},
+						Instrument: dto.CurrencyStatus{Current: false},
+					},
+				}, nil)
+				reminderRepoMock.EXPECT().Claim(model.Reminder{UserID: "1", Item: "MEDICAL:4:CLASS_2",
+					LapsesAt: time.Date(2024, 5, 4, 0, 0, 0, 0, time.UTC)}).Return(true, nil)
+				reminderRepoMock.EXPECT().Claim(model.Reminder{UserID: "1", Item: "CURRENCY:AIRPLANE:passenger",
+					LapsesAt: passengerLapsesAt}).Return(true, nil)
+				var titles []string
+				emailChannel.EXPECT().Send(gomock.Any(), gomock.Any(), gomock.Any()).Times(2).
+					DoAndReturn(func(_ any, _ notification.Recipient, message notification.Message) error {
+						titles = append(titles, message.Title)
+						return nil
+					})
+
+				// when
+				result, err := reminderService.SendReminders(context.Background())
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(result).To(Equal(dto.ReminderResult{Users: 1, Sent: 2}))
+				Expect(titles).To(Equal([]string{
+					"EASA CLASS_2 medical expires in 14 days",
+					"Passenger currency (AIRPLANE) lapses in 5 days",
+				}))
+			})
+		})
+		Context("when the run is cancelled", func() {
+			It("should leave the remaining users to the next run", func() {
+				// given
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				preferenceRepoMock.EXPECT().GetEnabled().Return([]model.NotificationPreference{mockPreference}, nil)
+
+				// when
+				result, err := reminderService.SendReminders(ctx)
+
+				// then
+				Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+				Expect(result).To(Equal(dto.ReminderResult{Users: 1}))
+			})
+		})
+	})
+})
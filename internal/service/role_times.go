@@ -0,0 +1,66 @@
+package service
+
+import (
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"time"
+)
+
+// roleTimes tells which time columns are credited with the block time of a flight flown in a role.
+type roleTimes struct {
+	pilotInCommand  bool
+	secondInCommand bool
+	dualReceived    bool
+	dualGiven       bool
+}
+
+// roleTimeAllocation lists time columns of every role in model.AvailableRoles. Student pilot-in-command,
+// pilot-in-command under supervision and examiner time is logged as PIC time, instructors log both dual given and PIC
+// time.
+var roleTimeAllocation = map[model.Role]roleTimes{
+	model.RolePilotInCommand:                 {pilotInCommand: true},
+	model.RoleSecondInCommand:                {secondInCommand: true},
+	model.RoleDual:                           {dualReceived: true},
+	model.RoleStudentPilotInCommand:          {pilotInCommand: true},
+	model.RolePilotInCommandUnderSupervision: {pilotInCommand: true},
+	model.RoleInstructor:                     {pilotInCommand: true, dualGiven: true},
+	model.RoleExaminer:                       {pilotInCommand: true},
+	model.RoleFlightAttendant:                {},
+	model.RoleOther:                          {},
+}
+
+// needsRoleTimes tells whether fillRoleTimes may change the request, the user's setting is loaded only when it does.
+func needsRoleTimes(request dto.LogbookRequest) bool {
+	if request.TotalBlockTime == nil {
+		return true
+	}
+	times := roleTimeAllocation[request.MyRole]
+	return times.pilotInCommand && request.PilotInCommandTime == nil ||
+		times.secondInCommand && request.SecondInCommandTime == nil ||
+		times.dualReceived && request.DualReceivedTime == nil ||
+		times.dualGiven && request.DualGivenTime == nil
+}
+
+// fillRoleTimes sets the block time from takeoff and landing time and credits it to the time columns of the role.
+// Values provided by the client are never overwritten.
+func fillRoleTimes(request *dto.LogbookRequest) {
+	if request.TotalBlockTime == nil {
+		if request.TakeoffTime.IsZero() || request.LandingTime.Before(request.TakeoffTime) {
+			return
+		}
+		blockTime := request.LandingTime.Sub(request.TakeoffTime).Round(time.Minute)
+		request.TotalBlockTime = &blockTime
+	}
+
+	times := roleTimeAllocation[request.MyRole]
+	fill := func(column **time.Duration, credited bool) {
+		if credited && *column == nil {
+			value := *request.TotalBlockTime
+			*column = &value
+		}
+	}
+	fill(&request.PilotInCommandTime, times.pilotInCommand)
+	fill(&request.SecondInCommandTime, times.secondInCommand)
+	fill(&request.DualReceivedTime, times.dualReceived)
+	fill(&request.DualGivenTime, times.dualGiven)
+}
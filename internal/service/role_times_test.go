@@ -0,0 +1,121 @@
+package service
+
+import (
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"time"
+)
+
+var _ = Describe("RoleTimes", func() {
+	var (
+		takeoffTime time.Time
+		request     dto.LogbookRequest
+	)
+
+	BeforeEach(func() {
+		takeoffTime = time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+		request = dto.LogbookRequest{
+			TakeoffTime: takeoffTime,
+			LandingTime: takeoffTime.Add(90 * time.Minute),
+			MyRole:      model.RolePilotInCommand,
+		}
+	})
+
+	Describe("roleTimeAllocation", func() {
+		It("should contain every available role", func() {
+			// then
+			for _, role := range model.AvailableRoles {
+				Expect(roleTimeAllocation).To(HaveKey(role))
+			}
+			Expect(roleTimeAllocation).To(HaveLen(len(model.AvailableRoles)))
+		})
+	})
+
+	Describe("fillRoleTimes", func() {
+		Context("when block time is missing", func() {
+			It("should compute it from takeoff and landing time", func() {
+				// when
+				fillRoleTimes(&request)
+
+				// then
+				Expect(request.TotalBlockTime).To(Equal(util.Duration(90 * time.Minute)))
+				Expect(request.PilotInCommandTime).To(Equal(util.Duration(90 * time.Minute)))
+				Expect(request.SecondInCommandTime).To(BeNil())
+				Expect(request.DualReceivedTime).To(BeNil())
+				Expect(request.DualGivenTime).To(BeNil())
+			})
+		})
+		Context("when the user is an instructor", func() {
+			It("should credit dual given and PIC time", func() {
+				// given
+				request.MyRole = model.RoleInstructor
+				request.TotalBlockTime = util.Duration(time.Hour)
+
+				// when
+				fillRoleTimes(&request)
+
+				// then
+				Expect(request.PilotInCommandTime).To(Equal(util.Duration(time.Hour)))
+				Expect(request.DualGivenTime).To(Equal(util.Duration(time.Hour)))
+				Expect(request.DualReceivedTime).To(BeNil())
+			})
+		})
+		Context("when the user is a student", func() {
+			It("should credit dual received time", func() {
+				// given
+				request.MyRole = model.RoleDual
+
+				// when
+				fillRoleTimes(&request)
+
+				// then
+				Expect(request.DualReceivedTime).To(Equal(util.Duration(90 * time.Minute)))
+				Expect(request.PilotInCommandTime).To(BeNil())
+			})
+		})
+		Context("when the user is a pilot in command under supervision", func() {
+			It("should credit PIC time", func() {
+				// given
+				request.MyRole = model.RolePilotInCommandUnderSupervision
+
+				// when
+				fillRoleTimes(&request)
+
+				// then
+				Expect(request.PilotInCommandTime).To(Equal(util.Duration(90 * time.Minute)))
+			})
+		})
+		Context("when times are provided", func() {
+			It("should not overwrite them", func() {
+				// given
+				request.MyRole = model.RoleSecondInCommand
+				request.TotalBlockTime = util.Duration(time.Hour)
+				request.SecondInCommandTime = util.Duration(30 * time.Minute)
+
+				// when
+				fillRoleTimes(&request)
+
+				// then
+				Expect(request.TotalBlockTime).To(Equal(util.Duration(time.Hour)))
+				Expect(request.SecondInCommandTime).To(Equal(util.Duration(30 * time.Minute)))
+				Expect(needsRoleTimes(request)).To(BeFalse())
+			})
+		})
+		Context("when landing time is before takeoff time", func() {
+			It("should leave block time missing", func() {
+				// given
+				request.LandingTime = takeoffTime.Add(-time.Hour)
+
+				// when
+				fillRoleTimes(&request)
+
+				// then
+				Expect(request.TotalBlockTime).To(BeNil())
+				Expect(request.PilotInCommandTime).To(BeNil())
+			})
+		})
+	})
+})
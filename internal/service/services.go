@@ -3,8 +3,12 @@ package service
 import (
 	authV4 "firebase.google.com/go/v4/auth"
 	"github.com/avialog/backend/internal/config"
+	"github.com/avialog/backend/internal/importer"
+	"github.com/avialog/backend/internal/infrastructure"
+	"github.com/avialog/backend/internal/notification"
 	"github.com/avialog/backend/internal/repository"
 	"github.com/go-playground/validator/v10"
+	"time"
 )
 
 type Services interface {
@@ -13,28 +17,94 @@ type Services interface {
 	User() UserService
 	Logbook() LogbookService
 	Auth() AuthService
+	Currency() CurrencyService
+	Import() ImportService
+	Airport() AirportService
+	Signature() SignatureService
+	History() HistoryService
+	Trash() TrashService
+	Idempotency() IdempotencyService
+	Sync() SyncService
+	Licence() LicenceService
+	Rating() RatingService
+	Medical() MedicalService
+	Reminder() ReminderService
+	Job() JobService
+	Progress() ProgressService
 }
 
 type services struct {
-	contactService  ContactService
-	aircraftService AircraftService
-	userService     UserService
-	logbookService  LogbookService
-	authService     AuthService
+	contactService     ContactService
+	aircraftService    AircraftService
+	userService        UserService
+	logbookService     LogbookService
+	authService        AuthService
+	currencyService    CurrencyService
+	importService      ImportService
+	airportService     AirportService
+	signatureService   SignatureService
+	historyService     HistoryService
+	trashService       TrashService
+	idempotencyService IdempotencyService
+	syncService        SyncService
+	licenceService     LicenceService
+	ratingService      RatingService
+	medicalService     MedicalService
+	reminderService    ReminderService
+	jobService         JobService
+	progressService    ProgressService
 }
 
-func NewServices(repositories repository.Repositories, config config.Config, validator *validator.Validate, authClient *authV4.Client) Services {
+func NewServices(repositories repository.Repositories, config config.Config, validator *validator.Validate, authClient *authV4.Client,
+	httpClient infrastructure.HTTPClient, notifications notification.Registry) Services {
 	contactService := newContactService(repositories.Contact(), config, validator)
 	aircraftService := newAircraftService(repositories.Aircraft(), repositories.Flight(), config, validator)
 	userService := newUserService(repositories.User(), config)
-	logbookService := newLogbookService(repositories.Flight(), repositories.Landing(), repositories.Passenger(), repositories.Aircraft(), config, validator)
+	logbookService := newLogbookService(repositories.Flight(), repositories.Landing(), repositories.Passenger(), repositories.Aircraft(),
+		repositories.User(), repositories.Airport(), repositories.Signature(), repositories.FlightVersion(), repositories.Trash(),
+		httpClient, config, validator)
 	authService := newAuthService(repositories.User(), authClient, authV4.IsIDTokenExpired)
+	currencyService := newCurrencyService(repositories.Flight(), repositories.Landing(), repositories.Aircraft(), config, time.Now)
+	importService := newImportService(repositories.Flight(), repositories.Landing(), repositories.Passenger(), repositories.Aircraft(),
+		repositories.Airport(), repositories.FlightVersion(), importer.NewRegistry(), config, validator)
+	airportService := newAirportService(repositories.Airport())
+	signatureService := newSignatureService(repositories.Signature(), repositories.Flight(), repositories.Contact(),
+		repositories.User(), logbookService, notifications, time.Now)
+	historyService := newHistoryService(repositories.FlightVersion(), repositories.Flight(), repositories.Landing(),
+		repositories.Passenger())
+	trashService := newTrashService(repositories.Trash(), repositories.Flight(), repositories.Aircraft(),
+		repositories.FlightVersion(), config, time.Now)
+	idempotencyService := newIdempotencyService(repositories.IdempotencyKey(), time.Now)
+	syncService := newSyncService(repositories.Sync(), repositories.Flight(), repositories.Landing(),
+		repositories.Passenger(), repositories.Aircraft(), repositories.Contact(), repositories.User(),
+		repositories.Signature(), logbookService, aircraftService, contactService, userService, config, time.Now)
+	licenceService := newLicenceService(repositories.Licence(), repositories.Rating(), config, validator, time.Now)
+	ratingService := newRatingService(repositories.Rating(), repositories.Licence(), validator)
+	medicalService := newMedicalService(repositories.Medical(), repositories.User(), config, validator, time.Now)
+	reminderService := newReminderService(repositories.Preference(), repositories.Reminder(), licenceService,
+		medicalService, currencyService, notifications, validator, time.Now)
+	jobService := newJobService(repositories.JobRun(), time.Now)
+	progressService := newProgressService(repositories.Flight())
 	return &services{
-		contactService:  contactService,
-		aircraftService: aircraftService,
-		userService:     userService,
-		logbookService:  logbookService,
-		authService:     authService,
+		contactService:     contactService,
+		aircraftService:    aircraftService,
+		userService:        userService,
+		logbookService:     logbookService,
+		authService:        authService,
+		currencyService:    currencyService,
+		importService:      importService,
+		airportService:     airportService,
+		signatureService:   signatureService,
+		historyService:     historyService,
+		trashService:       trashService,
+		idempotencyService: idempotencyService,
+		syncService:        syncService,
+		licenceService:     licenceService,
+		ratingService:      ratingService,
+		medicalService:     medicalService,
+		reminderService:    reminderService,
+		jobService:         jobService,
+		progressService:    progressService,
 	}
 }
 
@@ -49,3 +119,31 @@ func (s *services) User() UserService { return s.userService }
 func (s *services) Logbook() LogbookService { return s.logbookService }
 
 func (s *services) Auth() AuthService { return s.authService }
+
+func (s *services) Currency() CurrencyService { return s.currencyService }
+
+func (s *services) Import() ImportService { return s.importService }
+
+func (s *services) Airport() AirportService { return s.airportService }
+
+func (s *services) Signature() SignatureService { return s.signatureService }
+
+func (s *services) History() HistoryService { return s.historyService }
+
+func (s *services) Trash() TrashService { return s.trashService }
+
+func (s *services) Idempotency() IdempotencyService { return s.idempotencyService }
+
+func (s *services) Sync() SyncService { return s.syncService }
+
+func (s *services) Licence() LicenceService { return s.licenceService }
+
+func (s *services) Rating() RatingService { return s.ratingService }
+
+func (s *services) Medical() MedicalService { return s.medicalService }
+
+func (s *services) Reminder() ReminderService { return s.reminderService }
+
+func (s *services) Job() JobService { return s.jobService }
+
+func (s *services) Progress() ProgressService { return s.progressService }
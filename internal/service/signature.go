@@ -0,0 +1,491 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/infrastructure"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/notification"
+	"github.com/avialog/backend/internal/repository"
+	"strings"
+	"time"
+)
+
+const (
+	signatureTokenBytes       = 32
+	signatureTokenSendTimeout = 30 * time.Second
+)
+
+//go:generate mockgen -source=signature.go -destination=signature_mock.go -package service
+type SignatureService interface {
+	RequestSignature(userID string, flightID uint, signatureRequest dto.SignatureRequest) (dto.SignatureResponse, error)
+	GetSignatureRequests(userID string) ([]dto.SignatureResponse, error)
+	GetSignatureRequest(userID string, signatureID uint) (dto.SignatureReviewResponse, error)
+	SignEntry(userID string, signatureID uint, decision dto.SignatureDecisionRequest) (dto.SignatureResponse, error)
+	RejectEntry(userID string, signatureID uint, decision dto.SignatureDecisionRequest) (dto.SignatureResponse, error)
+	GetSignatureRequestByToken(token string) (dto.SignatureReviewResponse, error)
+	SignEntryByToken(token string, decision dto.SignatureDecisionRequest) (dto.SignatureResponse, error)
+	RejectEntryByToken(token string, decision dto.SignatureDecisionRequest) (dto.SignatureResponse, error)
+}
+
+type signatureService struct {
+	signatureRepository repository.SignatureRepository
+	flightRepository    repository.FlightRepository
+	contactRepository   repository.ContactRepository
+	userRepository      repository.UserRepository
+	logbookService      LogbookService
+	notifications       notification.Registry
+	now                 func() time.Time
+}
+
+func newSignatureService(signatureRepository repository.SignatureRepository, flightRepository repository.FlightRepository,
+	contactRepository repository.ContactRepository, userRepository repository.UserRepository,
+	logbookService LogbookService, notifications notification.Registry, now func() time.Time) SignatureService {
+	return &signatureService{signatureRepository: signatureRepository, flightRepository: flightRepository,
+		contactRepository: contactRepository, userRepository: userRepository, logbookService: logbookService,
+		notifications: notifications, now: now}
+}
+
+// RequestSignature asks an instructor to countersign the flight. A contact signs with the token of the request, which
+// is emailed to the contact only, so the pilot cannot sign their own flight. Another user signs the request listed among
+// the requests of the account.
+func (s *signatureService) RequestSignature(userID string, flightID uint, signatureRequest dto.SignatureRequest) (dto.SignatureResponse, error) {
+	flight, err := s.flightRepository.GetByID(flightID)
+	if err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			return dto.SignatureResponse{}, fmt.Errorf("%w: %v", dto.ErrNotFound, "flight not found")
+		}
+		return dto.SignatureResponse{}, err
+	}
+
+	if flight.UserID != userID {
+		return dto.SignatureResponse{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "flight does not belong to user")
+	}
+
+	requester, err := s.userRepository.GetByID(userID)
+	if err != nil {
+		return dto.SignatureResponse{}, err
+	}
+
+	signature := model.Signature{
+		FlightID:      flightID,
+		RequesterID:   userID,
+		RequesterName: userName(requester),
+		Status:        model.SignatureStatusPending,
+	}
+
+	switch {
+	case signatureRequest.ContactID != nil && signatureRequest.InstructorEmail == nil:
+		contact, err := s.contactRepository.GetByUserIDAndID(userID, *signatureRequest.ContactID)
+		if err != nil {
+			if errors.Is(err, dto.ErrNotFound) {
+				return dto.SignatureResponse{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "contact does not belong to user")
+			}
+			return dto.SignatureResponse{}, err
+		}
+
+		if contact.EmailAddress == nil || strings.TrimSpace(*contact.EmailAddress) == "" {
+			return dto.SignatureResponse{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "contact has no email address")
+		}
+
+		token, err := generateSignatureToken()
+		if err != nil {
+			return dto.SignatureResponse{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+		}
+
+		signature.SignerContactID = &contact.ID
+		signature.SignerName = fullName(contact.FirstName, contact.LastName)
+		signature.SignerEmail = contact.EmailAddress
+		signature.Token = &token
+	case signatureRequest.InstructorEmail != nil && signatureRequest.ContactID == nil:
+		instructor, err := s.userRepository.GetByEmail(strings.TrimSpace(*signatureRequest.InstructorEmail))
+		if err != nil {
+			if errors.Is(err, dto.ErrNotFound) {
+				return dto.SignatureResponse{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "instructor not found")
+			}
+			return dto.SignatureResponse{}, err
+		}
+
+		if instructor.ID == userID {
+			return dto.SignatureResponse{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "flight cannot be signed by its pilot")
+		}
+
+		signature.SignerUserID = &instructor.ID
+		signature.SignerName = userName(instructor)
+		signature.SignerEmail = &instructor.Email
+	default:
+		return dto.SignatureResponse{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "either contact_id or instructor_email is required")
+	}
+
+	tx := s.flightRepository.Begin()
+
+	createdSignature, err := s.createSignatureTx(tx, signature)
+	if err != nil {
+		tx.Rollback()
+		return dto.SignatureResponse{}, err
+	}
+
+	// a request the contact never learns about would block further requests, so it is only kept once the email is sent
+	if createdSignature.Token != nil {
+		if err := s.sendSignatureToken(createdSignature, flight); err != nil {
+			tx.Rollback()
+			return dto.SignatureResponse{}, err
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return dto.SignatureResponse{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+	}
+
+	return adaptSignatureResponse(createdSignature), nil
+}
+
+// createSignatureTx stores the request while the flight is locked, so of concurrent requests for the same flight only
+// the first one finds no pending request.
+func (s *signatureService) createSignatureTx(tx infrastructure.Database, signature model.Signature) (model.Signature, error) {
+	if _, err := s.flightRepository.GetByIDForUpdateTx(tx, signature.FlightID); err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			return model.Signature{}, fmt.Errorf("%w: %v", dto.ErrNotFound, "flight not found")
+		}
+		return model.Signature{}, err
+	}
+
+	latest, err := s.signatureRepository.GetLatestByFlightIDTx(tx, signature.FlightID)
+	if err != nil && !errors.Is(err, dto.ErrNotFound) {
+		return model.Signature{}, err
+	}
+	if err == nil {
+		switch latest.Status {
+		case model.SignatureStatusPending:
+			return model.Signature{}, fmt.Errorf("%w: %v", dto.ErrConflict, "signature already requested")
+		case model.SignatureStatusSigned:
+			return model.Signature{}, fmt.Errorf("%w: %v", dto.ErrConflict, "flight is already signed")
+		}
+	}
+
+	return s.signatureRepository.CreateTx(tx, signature)
+}
+
+// sendSignatureToken emails the token of the request to the contact asked to sign.
+func (s *signatureService) sendSignatureToken(signature model.Signature, flight model.Flight) error {
+	channel, ok := s.notifications.Get(model.NotificationChannelEmail)
+	if !ok {
+		return fmt.Errorf("%w: %v", dto.ErrUnprocessable, "emails are disabled, contacts cannot be asked to sign")
+	}
+
+	message := notification.Message{
+		Title: fmt.Sprintf("%s asks you to sign a logbook entry", signature.RequesterName),
+		Body: fmt.Sprintf("%s asks you to countersign the flight from %s to %s on %s.\n\n"+
+			"Review the entry in Avialog with the following signature token, then sign or reject it:\n\n%s",
+			signature.RequesterName, flight.TakeoffAirportCode, flight.LandingAirportCode,
+			flight.TakeoffTime.UTC().Format(time.DateOnly), *signature.Token),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), signatureTokenSendTimeout)
+	defer cancel()
+	if err := channel.Send(ctx, notification.Recipient{Email: *signature.SignerEmail}, message); err != nil {
+		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+	}
+	return nil
+}
+
+// GetSignatureRequests returns the requests waiting for the signature of the user.
+func (s *signatureService) GetSignatureRequests(userID string) ([]dto.SignatureResponse, error) {
+	signatures, err := s.signatureRepository.GetPendingBySignerUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	signatureResponses := make([]dto.SignatureResponse, 0, len(signatures))
+	for _, signature := range signatures {
+		signatureResponses = append(signatureResponses, adaptSignatureResponse(signature))
+	}
+	return signatureResponses, nil
+}
+
+func (s *signatureService) GetSignatureRequest(userID string, signatureID uint) (dto.SignatureReviewResponse, error) {
+	signature, err := s.getSignerSignature(userID, signatureID)
+	if err != nil {
+		return dto.SignatureReviewResponse{}, err
+	}
+	return s.review(signature)
+}
+
+func (s *signatureService) SignEntry(userID string, signatureID uint, decision dto.SignatureDecisionRequest) (dto.SignatureResponse, error) {
+	signature, err := s.getSignerSignature(userID, signatureID)
+	if err != nil {
+		return dto.SignatureResponse{}, err
+	}
+	return s.decide(signature, model.SignatureStatusSigned, decision)
+}
+
+func (s *signatureService) RejectEntry(userID string, signatureID uint, decision dto.SignatureDecisionRequest) (dto.SignatureResponse, error) {
+	signature, err := s.getSignerSignature(userID, signatureID)
+	if err != nil {
+		return dto.SignatureResponse{}, err
+	}
+	return s.decide(signature, model.SignatureStatusRejected, decision)
+}
+
+func (s *signatureService) GetSignatureRequestByToken(token string) (dto.SignatureReviewResponse, error) {
+	signature, err := s.getSignatureByToken(token)
+	if err != nil {
+		return dto.SignatureReviewResponse{}, err
+	}
+	return s.review(signature)
+}
+
+func (s *signatureService) SignEntryByToken(token string, decision dto.SignatureDecisionRequest) (dto.SignatureResponse, error) {
+	signature, err := s.getSignatureByToken(token)
+	if err != nil {
+		return dto.SignatureResponse{}, err
+	}
+	return s.decide(signature, model.SignatureStatusSigned, decision)
+}
+
+func (s *signatureService) RejectEntryByToken(token string, decision dto.SignatureDecisionRequest) (dto.SignatureResponse, error) {
+	signature, err := s.getSignatureByToken(token)
+	if err != nil {
+		return dto.SignatureResponse{}, err
+	}
+	return s.decide(signature, model.SignatureStatusRejected, decision)
+}
+
+// getSignerSignature returns the signature request addressed to the user, requests of other signers are reported as not
+// found to not reveal them.
+func (s *signatureService) getSignerSignature(userID string, signatureID uint) (model.Signature, error) {
+	signature, err := s.signatureRepository.GetByID(signatureID)
+	if err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			return model.Signature{}, fmt.Errorf("%w: %v", dto.ErrNotFound, "signature request not found")
+		}
+		return model.Signature{}, err
+	}
+
+	if signature.SignerUserID == nil || *signature.SignerUserID != userID {
+		return model.Signature{}, fmt.Errorf("%w: %v", dto.ErrNotFound, "signature request not found")
+	}
+	return signature, nil
+}
+
+func (s *signatureService) getSignatureByToken(token string) (model.Signature, error) {
+	signature, err := s.signatureRepository.GetByToken(token)
+	if err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			return model.Signature{}, fmt.Errorf("%w: %v", dto.ErrNotFound, "signature request not found")
+		}
+		return model.Signature{}, err
+	}
+	return signature, nil
+}
+
+// review returns the entry as the instructor sees it, without the personal remarks of the pilot.
+func (s *signatureService) review(signature model.Signature) (dto.SignatureReviewResponse, error) {
+	entry, err := s.logbookService.GetLogbookEntry(signature.RequesterID, signature.FlightID)
+	if err != nil {
+		return dto.SignatureReviewResponse{}, err
+	}
+
+	entry.PersonalRemarks = nil
+	entry.Signature = nil
+	return dto.SignatureReviewResponse{Signature: adaptSignatureResponse(signature), Entry: entry}, nil
+}
+
+// decide signs or rejects a pending request. Signing stores the time and the hash of the fields of the entry as they
+// are at that moment.
+func (s *signatureService) decide(signature model.Signature, status model.SignatureStatus,
+	decision dto.SignatureDecisionRequest) (dto.SignatureResponse, error) {
+	if signature.Status != model.SignatureStatusPending {
+		return dto.SignatureResponse{}, fmt.Errorf("%w: signature request is %v", dto.ErrConflict,
+			strings.ToLower(string(signature.Status)))
+	}
+
+	tx := s.flightRepository.Begin()
+
+	savedSignature, err := s.decideTx(tx, signature.ID, signature.FlightID, status, decision)
+	if err != nil {
+		tx.Rollback()
+		return dto.SignatureResponse{}, err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return dto.SignatureResponse{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+	}
+	return adaptSignatureResponse(savedSignature), nil
+}
+
+// decideTx stores the decision while the flight is locked. Changes of the entry lock the flight as well, so a change
+// either commits before the request is checked and the entry is hashed, or waits and then finds the entry signed.
+func (s *signatureService) decideTx(tx infrastructure.Database, signatureID uint, flightID uint,
+	status model.SignatureStatus, decision dto.SignatureDecisionRequest) (model.Signature, error) {
+	if _, err := s.flightRepository.GetByIDForUpdateTx(tx, flightID); err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			return model.Signature{}, fmt.Errorf("%w: %v", dto.ErrNotFound, "flight not found")
+		}
+		return model.Signature{}, err
+	}
+
+	// an amendment committed since the request was read invalidates it
+	signature, err := s.signatureRepository.GetByIDTx(tx, signatureID)
+	if err != nil {
+		return model.Signature{}, err
+	}
+	if signature.Status != model.SignatureStatusPending {
+		return model.Signature{}, fmt.Errorf("%w: signature request is %v", dto.ErrConflict,
+			strings.ToLower(string(signature.Status)))
+	}
+
+	if status == model.SignatureStatusSigned {
+		// no change of the entry can commit while the flight is locked, so the entry read here is the one signed
+		entry, err := s.logbookService.GetLogbookEntry(signature.RequesterID, signature.FlightID)
+		if err != nil {
+			return model.Signature{}, err
+		}
+
+		fieldsHash, err := hashSignedFields(entry)
+		if err != nil {
+			return model.Signature{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+		}
+
+		signedAt := s.now()
+		signature.SignedAt = &signedAt
+		signature.FieldsHash = &fieldsHash
+	}
+
+	signature.Status = status
+	signature.Remarks = decision.Remarks
+
+	return s.signatureRepository.SaveTx(tx, signature)
+}
+
+// signedFields are the fields of an entry covered by the signature. Identifiers of passengers and landings, which are
+// recreated on every update, and the personal remarks of the pilot are left out.
+type signedFields struct {
+	Aircraft            dto.AircraftSummary  `json:"aircraft"`
+	TakeoffTime         time.Time            `json:"takeoff_time"`
+	TakeoffAirportCode  string               `json:"takeoff_airport_code"`
+	LandingTime         time.Time            `json:"landing_time"`
+	LandingAirportCode  string               `json:"landing_airport_code"`
+	Style               model.Style          `json:"style"`
+	MyRole              model.Role           `json:"my_role"`
+	Remarks             *string              `json:"remarks"`
+	TotalBlockTime      *time.Duration       `json:"total_block_time"`
+	PilotInCommandTime  *time.Duration       `json:"pilot_in_command_time"`
+	SecondInCommandTime *time.Duration       `json:"second_in_command_time"`
+	DualReceivedTime    *time.Duration       `json:"dual_received_time"`
+	DualGivenTime       *time.Duration       `json:"dual_given_time"`
+	MultiPilotTime      *time.Duration       `json:"multi_pilot_time"`
+	NightTime           *time.Duration       `json:"night_time"`
+	IFRTime             *time.Duration       `json:"ifr_time"`
+	IFRActualTime       *time.Duration       `json:"ifr_actual_time"`
+	IFRSimulatedTime    *time.Duration       `json:"ifr_simulated_time"`
+	CrossCountryTime    *time.Duration       `json:"cross_country_time"`
+	SimulatorTime       *time.Duration       `json:"simulator_time"`
+	Holdings            *uint                `json:"holdings"`
+	Passengers          []dto.PassengerEntry `json:"passengers"`
+	Landings            []dto.LandingEntry   `json:"landings"`
+}
+
+// hashSignedFields returns the hex encoded SHA-256 hash of the JSON encoding of the signed fields of the entry.
+func hashSignedFields(entry dto.LogbookResponse) (string, error) {
+	fields := signedFields{
+		Aircraft:            entry.Aircraft,
+		TakeoffTime:         entry.TakeoffTime.UTC(),
+		TakeoffAirportCode:  entry.TakeoffAirportCode,
+		LandingTime:         entry.LandingTime.UTC(),
+		LandingAirportCode:  entry.LandingAirportCode,
+		Style:               entry.Style,
+		MyRole:              entry.MyRole,
+		Remarks:             entry.Remarks,
+		TotalBlockTime:      entry.TotalBlockTime,
+		PilotInCommandTime:  entry.PilotInCommandTime,
+		SecondInCommandTime: entry.SecondInCommandTime,
+		DualReceivedTime:    entry.DualReceivedTime,
+		DualGivenTime:       entry.DualGivenTime,
+		MultiPilotTime:      entry.MultiPilotTime,
+		NightTime:           entry.NightTime,
+		IFRTime:             entry.IFRTime,
+		IFRActualTime:       entry.IFRActualTime,
+		IFRSimulatedTime:    entry.IFRSimulatedTime,
+		CrossCountryTime:    entry.CrossCountryTime,
+		SimulatorTime:       entry.SimulatorTime,
+		Holdings:            entry.Holdings,
+		Passengers:          make([]dto.PassengerEntry, 0, len(entry.Passengers)),
+		Landings:            make([]dto.LandingEntry, 0, len(entry.Landings)),
+	}
+	for _, passenger := range entry.Passengers {
+		fields.Passengers = append(fields.Passengers, dto.PassengerEntry{
+			Role:         passenger.Role,
+			FirstName:    passenger.FirstName,
+			LastName:     passenger.LastName,
+			Company:      passenger.Company,
+			Phone:        passenger.Phone,
+			EmailAddress: passenger.EmailAddress,
+			Note:         passenger.Note,
+		})
+	}
+	for _, landing := range entry.Landings {
+		fields.Landings = append(fields.Landings, dto.LandingEntry{
+			ApproachType: landing.ApproachType,
+			Count:        landing.Count,
+			NightCount:   landing.NightCount,
+			DayCount:     landing.DayCount,
+			AirportCode:  landing.AirportCode,
+		})
+	}
+
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(encoded)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func generateSignatureToken() (string, error) {
+	token := make([]byte, signatureTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(token), nil
+}
+
+func userName(user model.User) string {
+	if user.FirstName == nil {
+		return user.Email
+	}
+	return fullName(*user.FirstName, user.LastName)
+}
+
+func fullName(firstName string, lastName *string) string {
+	if lastName == nil || *lastName == "" {
+		return firstName
+	}
+	return firstName + " " + *lastName
+}
+
+// adaptSignatureResponse leaves out the token, which only the contact asked to sign receives.
+func adaptSignatureResponse(signature model.Signature) dto.SignatureResponse {
+	return dto.SignatureResponse{
+		ID:              signature.ID,
+		FlightID:        signature.FlightID,
+		Status:          signature.Status,
+		RequesterName:   signature.RequesterName,
+		SignerName:      signature.SignerName,
+		SignerEmail:     signature.SignerEmail,
+		SignerUserID:    signature.SignerUserID,
+		SignerContactID: signature.SignerContactID,
+		Remarks:         signature.Remarks,
+		SignedAt:        signature.SignedAt,
+		FieldsHash:      signature.FieldsHash,
+		CreatedAt:       signature.CreatedAt,
+		UpdatedAt:       signature.UpdatedAt,
+	}
+}
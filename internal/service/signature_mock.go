@@ -0,0 +1,160 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: signature.go
+//
+// Generated by this command:
+//
+//	mockgen -source=signature.go -destination=signature_mock.go -package service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	reflect "reflect"
+
+	dto "github.com/avialog/backend/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSignatureService is a mock of SignatureService interface.
+type MockSignatureService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSignatureServiceMockRecorder
+}
+
+// MockSignatureServiceMockRecorder is the mock recorder for MockSignatureService.
+type MockSignatureServiceMockRecorder struct {
+	mock *MockSignatureService
+}
+
+// NewMockSignatureService creates a new mock instance.
+func NewMockSignatureService(ctrl *gomock.Controller) *MockSignatureService {
+	mock := &MockSignatureService{ctrl: ctrl}
+	mock.recorder = &MockSignatureServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSignatureService) EXPECT() *MockSignatureServiceMockRecorder {
+	return m.recorder
+}
+
+// GetSignatureRequest mocks base method.
+func (m *MockSignatureService) GetSignatureRequest(userID string, signatureID uint) (dto.SignatureReviewResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSignatureRequest", userID, signatureID)
+	ret0, _ := ret[0].(dto.SignatureReviewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSignatureRequest indicates an expected call of GetSignatureRequest.
+func (mr *MockSignatureServiceMockRecorder) GetSignatureRequest(userID, signatureID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSignatureRequest", reflect.TypeOf((*MockSignatureService)(nil).GetSignatureRequest), userID, signatureID)
+}
+
+// GetSignatureRequestByToken mocks base method.
+func (m *MockSignatureService) GetSignatureRequestByToken(token string) (dto.SignatureReviewResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSignatureRequestByToken", token)
+	ret0, _ := ret[0].(dto.SignatureReviewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSignatureRequestByToken indicates an expected call of GetSignatureRequestByToken.
+func (mr *MockSignatureServiceMockRecorder) GetSignatureRequestByToken(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSignatureRequestByToken", reflect.TypeOf((*MockSignatureService)(nil).GetSignatureRequestByToken), token)
+}
+
+// GetSignatureRequests mocks base method.
+func (m *MockSignatureService) GetSignatureRequests(userID string) ([]dto.SignatureResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSignatureRequests", userID)
+	ret0, _ := ret[0].([]dto.SignatureResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSignatureRequests indicates an expected call of GetSignatureRequests.
+func (mr *MockSignatureServiceMockRecorder) GetSignatureRequests(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSignatureRequests", reflect.TypeOf((*MockSignatureService)(nil).GetSignatureRequests), userID)
+}
+
+// RejectEntry mocks base method.
+func (m *MockSignatureService) RejectEntry(userID string, signatureID uint, decision dto.SignatureDecisionRequest) (dto.SignatureResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectEntry", userID, signatureID, decision)
+	ret0, _ := ret[0].(dto.SignatureResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectEntry indicates an expected call of RejectEntry.
+func (mr *MockSignatureServiceMockRecorder) RejectEntry(userID, signatureID, decision any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectEntry", reflect.TypeOf((*MockSignatureService)(nil).RejectEntry), userID, signatureID, decision)
+}
+
+// RejectEntryByToken mocks base method.
+func (m *MockSignatureService) RejectEntryByToken(token string, decision dto.SignatureDecisionRequest) (dto.SignatureResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectEntryByToken", token, decision)
+	ret0, _ := ret[0].(dto.SignatureResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectEntryByToken indicates an expected call of RejectEntryByToken.
+func (mr *MockSignatureServiceMockRecorder) RejectEntryByToken(token, decision any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectEntryByToken", reflect.TypeOf((*MockSignatureService)(nil).RejectEntryByToken), token, decision)
+}
+
+// RequestSignature mocks base method.
+func (m *MockSignatureService) RequestSignature(userID string, flightID uint, signatureRequest dto.SignatureRequest) (dto.SignatureResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestSignature", userID, flightID, signatureRequest)
+	ret0, _ := ret[0].(dto.SignatureResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestSignature indicates an expected call of RequestSignature.
+func (mr *MockSignatureServiceMockRecorder) RequestSignature(userID, flightID, signatureRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestSignature", reflect.TypeOf((*MockSignatureService)(nil).RequestSignature), userID, flightID, signatureRequest)
+}
+
+// SignEntry mocks base method.
+func (m *MockSignatureService) SignEntry(userID string, signatureID uint, decision dto.SignatureDecisionRequest) (dto.SignatureResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignEntry", userID, signatureID, decision)
+	ret0, _ := ret[0].(dto.SignatureResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignEntry indicates an expected call of SignEntry.
+func (mr *MockSignatureServiceMockRecorder) SignEntry(userID, signatureID, decision any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignEntry", reflect.TypeOf((*MockSignatureService)(nil).SignEntry), userID, signatureID, decision)
+}
+
+// SignEntryByToken mocks base method.
+func (m *MockSignatureService) SignEntryByToken(token string, decision dto.SignatureDecisionRequest) (dto.SignatureResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignEntryByToken", token, decision)
+	ret0, _ := ret[0].(dto.SignatureResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignEntryByToken indicates an expected call of SignEntryByToken.
+func (mr *MockSignatureServiceMockRecorder) SignEntryByToken(token, decision any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignEntryByToken", reflect.TypeOf((*MockSignatureService)(nil).SignEntryByToken), token, decision)
+}
@@ -0,0 +1,658 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/infrastructure"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/notification"
+	"github.com/avialog/backend/internal/repository"
+	"github.com/avialog/backend/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"time"
+)
+
+var _ = Describe("SignatureService", func() {
+	var (
+		signatureService      SignatureService
+		signatureRepoCtrl     *gomock.Controller
+		signatureRepoMock     *repository.MockSignatureRepository
+		flightRepoCtrl        *gomock.Controller
+		flightRepoMock        *repository.MockFlightRepository
+		contactRepoCtrl       *gomock.Controller
+		contactRepoMock       *repository.MockContactRepository
+		userRepoCtrl          *gomock.Controller
+		userRepoMock          *repository.MockUserRepository
+		logbookServiceCtrl    *gomock.Controller
+		logbookServiceMock    *MockLogbookService
+		databaseCtrl          *gomock.Controller
+		databaseMock          *infrastructure.MockDatabase
+		channelCtrl           *gomock.Controller
+		emailChannel          *notification.MockChannel
+		notifications         notification.Registry
+		fixedTime             time.Time
+		mockFlight            model.Flight
+		mockPilot             model.User
+		mockInstructor        model.User
+		mockContact           model.Contact
+		mockPendingSignature  model.Signature
+		mockContactSignature  model.Signature
+		mockEntry             dto.LogbookResponse
+		signatureDecision     dto.SignatureDecisionRequest
+		expectedReviewedEntry dto.LogbookResponse
+	)
+
+	BeforeEach(func() {
+		signatureRepoCtrl = gomock.NewController(GinkgoT())
+		signatureRepoMock = repository.NewMockSignatureRepository(signatureRepoCtrl)
+		flightRepoCtrl = gomock.NewController(GinkgoT())
+		flightRepoMock = repository.NewMockFlightRepository(flightRepoCtrl)
+		contactRepoCtrl = gomock.NewController(GinkgoT())
+		contactRepoMock = repository.NewMockContactRepository(contactRepoCtrl)
+		userRepoCtrl = gomock.NewController(GinkgoT())
+		userRepoMock = repository.NewMockUserRepository(userRepoCtrl)
+		logbookServiceCtrl = gomock.NewController(GinkgoT())
+		logbookServiceMock = NewMockLogbookService(logbookServiceCtrl)
+		databaseCtrl = gomock.NewController(GinkgoT())
+		databaseMock = infrastructure.NewMockDatabase(databaseCtrl)
+		channelCtrl = gomock.NewController(GinkgoT())
+		emailChannel = notification.NewMockChannel(channelCtrl)
+		notifications = notification.NewRegistry()
+		notifications.Register(model.NotificationChannelEmail, emailChannel)
+		fixedTime = time.Date(2024, 3, 26, 9, 30, 0, 0, time.UTC)
+		signatureService = newSignatureService(signatureRepoMock, flightRepoMock, contactRepoMock, userRepoMock,
+			logbookServiceMock, notifications, func() time.Time { return fixedTime })
+
+		mockFlight = model.Flight{Model: gorm.Model{ID: 3}, UserID: "1"}
+		mockPilot = model.User{ID: "1", FirstName: util.String("Anna"), LastName: util.String("Nowak"), Email: "anna@example.com"}
+		mockInstructor = model.User{ID: "2", FirstName: util.String("Jan"), LastName: util.String("Kowalski"), Email: "jan@example.com"}
+		mockContact = model.Contact{Model: gorm.Model{ID: 7}, UserID: "1", FirstName: "Piotr", EmailAddress: util.String("piotr@example.com")}
+		mockPendingSignature = model.Signature{
+			Model:         gorm.Model{ID: 5},
+			FlightID:      3,
+			RequesterID:   "1",
+			RequesterName: "Anna Nowak",
+			SignerUserID:  util.String("2"),
+			SignerName:    "Jan Kowalski",
+			SignerEmail:   util.String("jan@example.com"),
+			Status:        model.SignatureStatusPending,
+		}
+		mockContactSignature = model.Signature{
+			Model:           gorm.Model{ID: 6},
+			FlightID:        3,
+			RequesterID:     "1",
+			RequesterName:   "Anna Nowak",
+			SignerContactID: util.Uint(7),
+			SignerName:      "Piotr",
+			SignerEmail:     util.String("piotr@example.com"),
+			Token:           util.String("token"),
+			Status:          model.SignatureStatusPending,
+		}
+		mockEntry = dto.LogbookResponse{
+			ID:                 3,
+			AircraftID:         1,
+			Aircraft:           dto.AircraftSummary{ID: 1, RegistrationNumber: "SP-ABC", AircraftModel: "C152"},
+			TakeoffTime:        time.Date(2024, 3, 25, 10, 0, 0, 0, time.UTC),
+			TakeoffAirportCode: "EPKK",
+			LandingTime:        time.Date(2024, 3, 25, 11, 0, 0, 0, time.UTC),
+			LandingAirportCode: "EPWA",
+			Style:              model.StyleY,
+			MyRole:             model.RoleDual,
+			Remarks:            util.String("Circuits"),
+			PersonalRemarks:    util.String("Flare too high"),
+			Passengers:         []dto.PassengerResponse{{ID: 11, Role: model.RoleInstructor, FirstName: "Jan"}},
+			Landings:           []dto.LandingResponse{{ID: 21, ApproachType: model.ApproachTypeVisual, Count: util.Uint(3)}},
+			Signature:          &dto.SignatureResponse{ID: 5, Status: model.SignatureStatusPending},
+		}
+		expectedReviewedEntry = mockEntry
+		expectedReviewedEntry.PersonalRemarks = nil
+		expectedReviewedEntry.Signature = nil
+		signatureDecision = dto.SignatureDecisionRequest{Remarks: util.String("Good landings")}
+	})
+
+	AfterEach(func() {
+		signatureRepoCtrl.Finish()
+		flightRepoCtrl.Finish()
+		contactRepoCtrl.Finish()
+		userRepoCtrl.Finish()
+		logbookServiceCtrl.Finish()
+		databaseCtrl.Finish()
+		channelCtrl.Finish()
+	})
+
+	Describe("RequestSignature", func() {
+		Context("when the instructor is another user", func() {
+			It("Should create a request addressed to the instructor without a token", func() {
+				// given
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlight, nil)
+				userRepoMock.EXPECT().GetByID("1").Return(mockPilot, nil)
+				userRepoMock.EXPECT().GetByEmail("jan@example.com").Return(mockInstructor, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(mockFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(3)).Return(model.Signature{}, dto.ErrNotFound)
+				databaseMock.EXPECT().Commit().Return(&gorm.DB{})
+				signatureRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(func(_ infrastructure.Database, signature model.Signature) (model.Signature, error) {
+					Expect(signature.Token).To(BeNil())
+					signature.ID = 5
+					return signature, nil
+				})
+
+				// when
+				signatureResponse, err := signatureService.RequestSignature("1", uint(3),
+					dto.SignatureRequest{InstructorEmail: util.String(" jan@example.com ")})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(signatureResponse).To(Equal(dto.SignatureResponse{
+					ID:            5,
+					FlightID:      3,
+					Status:        model.SignatureStatusPending,
+					RequesterName: "Anna Nowak",
+					SignerName:    "Jan Kowalski",
+					SignerEmail:   util.String("jan@example.com"),
+					SignerUserID:  util.String("2"),
+				}))
+			})
+		})
+		Context("when the instructor is a contact", func() {
+			It("Should create a request with a token emailed to the contact only", func() {
+				// given
+				var token string
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlight, nil)
+				userRepoMock.EXPECT().GetByID("1").Return(mockPilot, nil)
+				contactRepoMock.EXPECT().GetByUserIDAndID("1", uint(7)).Return(mockContact, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(mockFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(3)).Return(model.Signature{Status: model.SignatureStatusRejected}, nil)
+				signatureRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(func(_ infrastructure.Database, signature model.Signature) (model.Signature, error) {
+					Expect(signature.Token).ToNot(BeNil())
+					Expect(*signature.Token).To(HaveLen(43))
+					token = *signature.Token
+					signature.ID = 6
+					return signature, nil
+				})
+				emailChannel.EXPECT().Send(gomock.Any(), notification.Recipient{Email: "piotr@example.com"}, gomock.Any()).
+					DoAndReturn(func(_ context.Context, _ notification.Recipient, message notification.Message) error {
+						Expect(message.Title).To(Equal("Anna Nowak asks you to sign a logbook entry"))
+						Expect(message.Body).To(ContainSubstring(token))
+						return nil
+					})
+				databaseMock.EXPECT().Commit().Return(&gorm.DB{})
+
+				// when
+				signatureResponse, err := signatureService.RequestSignature("1", uint(3), dto.SignatureRequest{ContactID: util.Uint(7)})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(signatureResponse.SignerContactID).To(Equal(util.Uint(7)))
+				Expect(signatureResponse.SignerName).To(Equal("Piotr"))
+				Expect(signatureResponse.SignerEmail).To(Equal(util.String("piotr@example.com")))
+			})
+		})
+		Context("when the token cannot be emailed to the contact", func() {
+			It("Should not keep the request", func() {
+				// given
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlight, nil)
+				userRepoMock.EXPECT().GetByID("1").Return(mockPilot, nil)
+				contactRepoMock.EXPECT().GetByUserIDAndID("1", uint(7)).Return(mockContact, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(mockFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(3)).Return(model.Signature{}, dto.ErrNotFound)
+				signatureRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(func(_ infrastructure.Database, signature model.Signature) (model.Signature, error) {
+					signature.ID = 6
+					return signature, nil
+				})
+				emailChannel.EXPECT().Send(gomock.Any(), notification.Recipient{Email: "piotr@example.com"}, gomock.Any()).
+					Return(errors.New("connection refused"))
+				databaseMock.EXPECT().Rollback().Return(&gorm.DB{})
+
+				// when
+				_, err := signatureService.RequestSignature("1", uint(3), dto.SignatureRequest{ContactID: util.Uint(7)})
+
+				// then
+				Expect(err).To(MatchError("internal failure: connection refused"))
+			})
+		})
+		Context("when emails are disabled", func() {
+			It("Should return unprocessable without keeping the request", func() {
+				// given
+				signatureService = newSignatureService(signatureRepoMock, flightRepoMock, contactRepoMock, userRepoMock,
+					logbookServiceMock, notification.NewRegistry(), func() time.Time { return fixedTime })
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlight, nil)
+				userRepoMock.EXPECT().GetByID("1").Return(mockPilot, nil)
+				contactRepoMock.EXPECT().GetByUserIDAndID("1", uint(7)).Return(mockContact, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(mockFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(3)).Return(model.Signature{}, dto.ErrNotFound)
+				signatureRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(func(_ infrastructure.Database, signature model.Signature) (model.Signature, error) {
+					signature.ID = 6
+					return signature, nil
+				})
+				databaseMock.EXPECT().Rollback().Return(&gorm.DB{})
+
+				// when
+				_, err := signatureService.RequestSignature("1", uint(3), dto.SignatureRequest{ContactID: util.Uint(7)})
+
+				// then
+				Expect(errors.Is(err, dto.ErrUnprocessable)).To(BeTrue())
+			})
+		})
+		Context("when the contact has no email address", func() {
+			It("Should return a bad request", func() {
+				// given
+				mockContact.EmailAddress = nil
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlight, nil)
+				userRepoMock.EXPECT().GetByID("1").Return(mockPilot, nil)
+				contactRepoMock.EXPECT().GetByUserIDAndID("1", uint(7)).Return(mockContact, nil)
+
+				// when
+				_, err := signatureService.RequestSignature("1", uint(3), dto.SignatureRequest{ContactID: util.Uint(7)})
+
+				// then
+				Expect(err).To(MatchError("bad request: contact has no email address"))
+			})
+		})
+		Context("when the contact does not belong to the user", func() {
+			It("Should return a bad request", func() {
+				// given
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlight, nil)
+				userRepoMock.EXPECT().GetByID("1").Return(mockPilot, nil)
+				contactRepoMock.EXPECT().GetByUserIDAndID("1", uint(7)).Return(model.Contact{}, dto.ErrNotFound)
+
+				// when
+				_, err := signatureService.RequestSignature("1", uint(3), dto.SignatureRequest{ContactID: util.Uint(7)})
+
+				// then
+				Expect(err).To(MatchError("bad request: contact does not belong to user"))
+			})
+		})
+		Context("when the pilot asks for their own signature", func() {
+			It("Should return a bad request", func() {
+				// given
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlight, nil)
+				userRepoMock.EXPECT().GetByID("1").Return(mockPilot, nil)
+				userRepoMock.EXPECT().GetByEmail("anna@example.com").Return(mockPilot, nil)
+
+				// when
+				_, err := signatureService.RequestSignature("1", uint(3), dto.SignatureRequest{InstructorEmail: util.String("anna@example.com")})
+
+				// then
+				Expect(err).To(MatchError("bad request: flight cannot be signed by its pilot"))
+			})
+		})
+		Context("when neither a contact nor an instructor is given", func() {
+			It("Should return a bad request", func() {
+				// given
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlight, nil)
+				userRepoMock.EXPECT().GetByID("1").Return(mockPilot, nil)
+
+				// when
+				_, err := signatureService.RequestSignature("1", uint(3), dto.SignatureRequest{})
+
+				// then
+				Expect(err).To(MatchError("bad request: either contact_id or instructor_email is required"))
+			})
+		})
+		Context("when a request is already pending", func() {
+			It("Should return a conflict", func() {
+				// given
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlight, nil)
+				userRepoMock.EXPECT().GetByID("1").Return(mockPilot, nil)
+				userRepoMock.EXPECT().GetByEmail("jan@example.com").Return(mockInstructor, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(mockFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(3)).Return(mockPendingSignature, nil)
+				databaseMock.EXPECT().Rollback().Return(&gorm.DB{})
+
+				// when
+				_, err := signatureService.RequestSignature("1", uint(3), dto.SignatureRequest{InstructorEmail: util.String("jan@example.com")})
+
+				// then
+				Expect(errors.Is(err, dto.ErrConflict)).To(BeTrue())
+				Expect(err.Error()).To(Equal("conflict: signature already requested"))
+			})
+		})
+		Context("when the flight is already signed", func() {
+			It("Should return a conflict", func() {
+				// given
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlight, nil)
+				userRepoMock.EXPECT().GetByID("1").Return(mockPilot, nil)
+				userRepoMock.EXPECT().GetByEmail("jan@example.com").Return(mockInstructor, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(mockFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightIDTx(databaseMock, uint(3)).Return(model.Signature{Status: model.SignatureStatusSigned}, nil)
+				databaseMock.EXPECT().Rollback().Return(&gorm.DB{})
+
+				// when
+				_, err := signatureService.RequestSignature("1", uint(3), dto.SignatureRequest{InstructorEmail: util.String("jan@example.com")})
+
+				// then
+				Expect(err).To(MatchError("conflict: flight is already signed"))
+			})
+		})
+		Context("when the flight belongs to another user", func() {
+			It("Should return a bad request", func() {
+				// given
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlight, nil)
+
+				// when
+				_, err := signatureService.RequestSignature("2", uint(3), dto.SignatureRequest{InstructorEmail: util.String("jan@example.com")})
+
+				// then
+				Expect(err).To(MatchError("bad request: flight does not belong to user"))
+			})
+		})
+		Context("when the flight does not exist", func() {
+			It("Should return not found", func() {
+				// given
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(model.Flight{}, dto.ErrNotFound)
+
+				// when
+				_, err := signatureService.RequestSignature("1", uint(3), dto.SignatureRequest{InstructorEmail: util.String("jan@example.com")})
+
+				// then
+				Expect(err).To(MatchError("not found: flight not found"))
+			})
+		})
+		Context("when the flight is deleted before it is locked", func() {
+			It("Should return not found", func() {
+				// given
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlight, nil)
+				userRepoMock.EXPECT().GetByID("1").Return(mockPilot, nil)
+				userRepoMock.EXPECT().GetByEmail("jan@example.com").Return(mockInstructor, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(model.Flight{}, dto.ErrNotFound)
+				databaseMock.EXPECT().Rollback().Return(&gorm.DB{})
+
+				// when
+				_, err := signatureService.RequestSignature("1", uint(3), dto.SignatureRequest{InstructorEmail: util.String("jan@example.com")})
+
+				// then
+				Expect(err).To(MatchError("not found: flight not found"))
+			})
+		})
+	})
+
+	Describe("GetSignatureRequests", func() {
+		Context("when the user has pending requests", func() {
+			It("Should return them", func() {
+				// given
+				signatureRepoMock.EXPECT().GetPendingBySignerUserID("2").Return([]model.Signature{mockPendingSignature}, nil)
+
+				// when
+				signatureResponses, err := signatureService.GetSignatureRequests("2")
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(signatureResponses).To(HaveLen(1))
+				Expect(signatureResponses[0].ID).To(Equal(uint(5)))
+				Expect(signatureResponses[0].RequesterName).To(Equal("Anna Nowak"))
+			})
+		})
+		Context("when loading the requests fails", func() {
+			It("Should return an error", func() {
+				// given
+				signatureRepoMock.EXPECT().GetPendingBySignerUserID("2").Return(nil, dto.ErrInternalFailure)
+
+				// when
+				signatureResponses, err := signatureService.GetSignatureRequests("2")
+
+				// then
+				Expect(err).To(Equal(dto.ErrInternalFailure))
+				Expect(signatureResponses).To(BeNil())
+			})
+		})
+	})
+
+	Describe("GetSignatureRequest", func() {
+		Context("when the request is addressed to the user", func() {
+			It("Should return the entry without the personal remarks", func() {
+				// given
+				signatureRepoMock.EXPECT().GetByID(uint(5)).Return(mockPendingSignature, nil)
+				logbookServiceMock.EXPECT().GetLogbookEntry("1", uint(3)).Return(mockEntry, nil)
+
+				// when
+				reviewResponse, err := signatureService.GetSignatureRequest("2", uint(5))
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(reviewResponse.Signature.ID).To(Equal(uint(5)))
+				Expect(reviewResponse.Entry).To(Equal(expectedReviewedEntry))
+			})
+		})
+		Context("when the request is addressed to another user", func() {
+			It("Should return not found", func() {
+				// given
+				signatureRepoMock.EXPECT().GetByID(uint(5)).Return(mockPendingSignature, nil)
+
+				// when
+				_, err := signatureService.GetSignatureRequest("3", uint(5))
+
+				// then
+				Expect(err).To(MatchError("not found: signature request not found"))
+			})
+		})
+	})
+
+	Describe("SignEntry", func() {
+		Context("when the request is pending", func() {
+			It("Should store the signing time and the hash of the entry", func() {
+				// given
+				expectedHash, err := hashSignedFields(mockEntry)
+				Expect(err).To(BeNil())
+				signatureRepoMock.EXPECT().GetByID(uint(5)).Return(mockPendingSignature, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(mockFlight, nil)
+				signatureRepoMock.EXPECT().GetByIDTx(databaseMock, uint(5)).Return(mockPendingSignature, nil)
+				logbookServiceMock.EXPECT().GetLogbookEntry("1", uint(3)).Return(mockEntry, nil)
+				signatureRepoMock.EXPECT().SaveTx(databaseMock, gomock.Any()).DoAndReturn(func(_ infrastructure.Database, signature model.Signature) (model.Signature, error) {
+					return signature, nil
+				})
+				databaseMock.EXPECT().Commit().Return(&gorm.DB{})
+
+				// when
+				signatureResponse, err := signatureService.SignEntry("2", uint(5), signatureDecision)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(signatureResponse.Status).To(Equal(model.SignatureStatusSigned))
+				Expect(signatureResponse.SignedAt).To(Equal(&fixedTime))
+				Expect(signatureResponse.FieldsHash).To(Equal(&expectedHash))
+				Expect(signatureResponse.Remarks).To(Equal(util.String("Good landings")))
+			})
+		})
+		Context("when the request is no longer pending", func() {
+			It("Should return a conflict", func() {
+				// given
+				mockPendingSignature.Status = model.SignatureStatusInvalidated
+				signatureRepoMock.EXPECT().GetByID(uint(5)).Return(mockPendingSignature, nil)
+
+				// when
+				_, err := signatureService.SignEntry("2", uint(5), signatureDecision)
+
+				// then
+				Expect(errors.Is(err, dto.ErrConflict)).To(BeTrue())
+				Expect(err.Error()).To(Equal("conflict: signature request is invalidated"))
+			})
+		})
+		Context("when the entry is amended after the request was read", func() {
+			It("Should roll back and return a conflict without hashing the entry", func() {
+				// given
+				invalidated := mockPendingSignature
+				invalidated.Status = model.SignatureStatusInvalidated
+				signatureRepoMock.EXPECT().GetByID(uint(5)).Return(mockPendingSignature, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(mockFlight, nil)
+				signatureRepoMock.EXPECT().GetByIDTx(databaseMock, uint(5)).Return(invalidated, nil)
+				databaseMock.EXPECT().Rollback().Return(&gorm.DB{})
+
+				// when
+				signatureResponse, err := signatureService.SignEntry("2", uint(5), signatureDecision)
+
+				// then
+				Expect(err).To(MatchError("conflict: signature request is invalidated"))
+				Expect(signatureResponse).To(Equal(dto.SignatureResponse{}))
+			})
+		})
+		Context("when the request does not exist", func() {
+			It("Should return not found", func() {
+				// given
+				signatureRepoMock.EXPECT().GetByID(uint(5)).Return(model.Signature{}, dto.ErrNotFound)
+
+				// when
+				_, err := signatureService.SignEntry("2", uint(5), signatureDecision)
+
+				// then
+				Expect(err).To(MatchError("not found: signature request not found"))
+			})
+		})
+		Context("when saving the signature fails", func() {
+			It("Should return an error", func() {
+				// given
+				signatureRepoMock.EXPECT().GetByID(uint(5)).Return(mockPendingSignature, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(mockFlight, nil)
+				signatureRepoMock.EXPECT().GetByIDTx(databaseMock, uint(5)).Return(mockPendingSignature, nil)
+				logbookServiceMock.EXPECT().GetLogbookEntry("1", uint(3)).Return(mockEntry, nil)
+				signatureRepoMock.EXPECT().SaveTx(databaseMock, gomock.Any()).Return(model.Signature{}, dto.ErrInternalFailure)
+				databaseMock.EXPECT().Rollback().Return(&gorm.DB{})
+
+				// when
+				signatureResponse, err := signatureService.SignEntry("2", uint(5), signatureDecision)
+
+				// then
+				Expect(err).To(Equal(dto.ErrInternalFailure))
+				Expect(signatureResponse).To(Equal(dto.SignatureResponse{}))
+			})
+		})
+	})
+
+	Describe("RejectEntry", func() {
+		Context("when the request is pending", func() {
+			It("Should reject it without a hash", func() {
+				// given
+				signatureRepoMock.EXPECT().GetByID(uint(5)).Return(mockPendingSignature, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(mockFlight, nil)
+				signatureRepoMock.EXPECT().GetByIDTx(databaseMock, uint(5)).Return(mockPendingSignature, nil)
+				signatureRepoMock.EXPECT().SaveTx(databaseMock, gomock.Any()).DoAndReturn(func(_ infrastructure.Database, signature model.Signature) (model.Signature, error) {
+					return signature, nil
+				})
+				databaseMock.EXPECT().Commit().Return(&gorm.DB{})
+
+				// when
+				signatureResponse, err := signatureService.RejectEntry("2", uint(5), dto.SignatureDecisionRequest{})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(signatureResponse.Status).To(Equal(model.SignatureStatusRejected))
+				Expect(signatureResponse.SignedAt).To(BeNil())
+				Expect(signatureResponse.FieldsHash).To(BeNil())
+			})
+		})
+	})
+
+	Describe("SignEntryByToken", func() {
+		Context("when the token matches a pending request", func() {
+			It("Should sign the entry without revealing the token", func() {
+				// given
+				signatureRepoMock.EXPECT().GetByToken("token").Return(mockContactSignature, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightRepoMock.EXPECT().GetByIDForUpdateTx(databaseMock, uint(3)).Return(mockFlight, nil)
+				signatureRepoMock.EXPECT().GetByIDTx(databaseMock, uint(6)).Return(mockContactSignature, nil)
+				logbookServiceMock.EXPECT().GetLogbookEntry("1", uint(3)).Return(mockEntry, nil)
+				signatureRepoMock.EXPECT().SaveTx(databaseMock, gomock.Any()).DoAndReturn(func(_ infrastructure.Database, signature model.Signature) (model.Signature, error) {
+					return signature, nil
+				})
+				databaseMock.EXPECT().Commit().Return(&gorm.DB{})
+
+				// when
+				signatureResponse, err := signatureService.SignEntryByToken("token", signatureDecision)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(signatureResponse.Status).To(Equal(model.SignatureStatusSigned))
+			})
+		})
+		Context("when the token is unknown", func() {
+			It("Should return not found", func() {
+				// given
+				signatureRepoMock.EXPECT().GetByToken("unknown").Return(model.Signature{}, dto.ErrNotFound)
+
+				// when
+				_, err := signatureService.SignEntryByToken("unknown", signatureDecision)
+
+				// then
+				Expect(err).To(MatchError("not found: signature request not found"))
+			})
+		})
+	})
+
+	Describe("GetSignatureRequestByToken", func() {
+		Context("when the token matches a request", func() {
+			It("Should return the entry without the personal remarks", func() {
+				// given
+				signatureRepoMock.EXPECT().GetByToken("token").Return(mockContactSignature, nil)
+				logbookServiceMock.EXPECT().GetLogbookEntry("1", uint(3)).Return(mockEntry, nil)
+
+				// when
+				reviewResponse, err := signatureService.GetSignatureRequestByToken("token")
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(reviewResponse.Entry).To(Equal(expectedReviewedEntry))
+			})
+		})
+	})
+
+	Describe("RejectEntryByToken", func() {
+		Context("when the request was already signed", func() {
+			It("Should return a conflict", func() {
+				// given
+				mockContactSignature.Status = model.SignatureStatusSigned
+				signatureRepoMock.EXPECT().GetByToken("token").Return(mockContactSignature, nil)
+
+				// when
+				_, err := signatureService.RejectEntryByToken("token", signatureDecision)
+
+				// then
+				Expect(err).To(MatchError("conflict: signature request is signed"))
+			})
+		})
+	})
+
+	Describe("hashSignedFields", func() {
+		It("Should ignore identifiers and personal remarks", func() {
+			// given
+			recreated := mockEntry
+			recreated.PersonalRemarks = util.String("Changed")
+			recreated.Passengers = []dto.PassengerResponse{{ID: 12, Role: model.RoleInstructor, FirstName: "Jan"}}
+			recreated.Landings = []dto.LandingResponse{{ID: 22, ApproachType: model.ApproachTypeVisual, Count: util.Uint(3)}}
+
+			// when
+			hash, err := hashSignedFields(mockEntry)
+			recreatedHash, recreatedErr := hashSignedFields(recreated)
+
+			// then
+			Expect(err).To(BeNil())
+			Expect(recreatedErr).To(BeNil())
+			Expect(recreatedHash).To(Equal(hash))
+		})
+		It("Should change with the signed fields", func() {
+			// given
+			changed := mockEntry
+			changed.LandingAirportCode = "EPKT"
+
+			// when
+			hash, _ := hashSignedFields(mockEntry)
+			changedHash, _ := hashSignedFields(changed)
+
+			// then
+			Expect(changedHash).ToNot(Equal(hash))
+		})
+	})
+})
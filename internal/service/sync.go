@@ -0,0 +1,542 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/config"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	"strconv"
+	"time"
+)
+
+const (
+	// syncOverlap is subtracted from the time of a token, so changes of transactions which were still running when the
+	// token was issued are not missed. Clients apply changes idempotently, at worst they receive a change twice.
+	syncOverlap     = time.Minute
+	maxSyncPushSize = 500
+)
+
+//go:generate mockgen -source=sync.go -destination=sync_mock.go -package service
+type SyncService interface {
+	GetChanges(userID string, token *string) (dto.SyncResponse, error)
+	PushChanges(userID string, pushRequest dto.SyncPushRequest) (dto.SyncPushResponse, error)
+}
+
+type syncService struct {
+	syncRepository      repository.SyncRepository
+	flightRepository    repository.FlightRepository
+	landingRepository   repository.LandingRepository
+	passengerRepository repository.PassengerRepository
+	aircraftRepository  repository.AircraftRepository
+	contactRepository   repository.ContactRepository
+	userRepository      repository.UserRepository
+	signatureRepository repository.SignatureRepository
+	logbookService      LogbookService
+	aircraftService     AircraftService
+	contactService      ContactService
+	userService         UserService
+	config              config.Config
+	now                 func() time.Time
+}
+
+func newSyncService(syncRepository repository.SyncRepository, flightRepository repository.FlightRepository,
+	landingRepository repository.LandingRepository, passengerRepository repository.PassengerRepository,
+	aircraftRepository repository.AircraftRepository, contactRepository repository.ContactRepository,
+	userRepository repository.UserRepository, signatureRepository repository.SignatureRepository,
+	logbookService LogbookService, aircraftService AircraftService, contactService ContactService,
+	userService UserService, config config.Config, now func() time.Time) SyncService {
+	return &syncService{syncRepository: syncRepository, flightRepository: flightRepository,
+		landingRepository: landingRepository, passengerRepository: passengerRepository,
+		aircraftRepository: aircraftRepository, contactRepository: contactRepository, userRepository: userRepository,
+		signatureRepository: signatureRepository, logbookService: logbookService, aircraftService: aircraftService,
+		contactService: contactService, userService: userService, config: config, now: now}
+}
+
+// GetChanges returns the items of the user created, updated or deleted since the token was issued, or every item
+// without a token. Deleted items are purged after the retention period of the trash, so a token older than that
+// resets the local copy of the client as well.
+func (s *syncService) GetChanges(userID string, token *string) (dto.SyncResponse, error) {
+	now := s.now()
+
+	var since time.Time
+	reset := true
+	if token != nil {
+		issuedAt, err := decodeSyncToken(*token)
+		if err != nil {
+			return dto.SyncResponse{}, fmt.Errorf("%w: %v", dto.ErrBadRequest, "invalid sync token")
+		}
+		if issuedAt.After(now.Add(-s.config.TrashRetention)) {
+			since = issuedAt.Add(-syncOverlap)
+			reset = false
+		}
+	}
+
+	flights, err := s.syncRepository.GetFlightsChangedSince(userID, since)
+	if err != nil {
+		return dto.SyncResponse{}, err
+	}
+
+	entries, err := loadLogbookEntries(s.aircraftRepository, s.landingRepository, s.passengerRepository,
+		s.signatureRepository, flights)
+	if err != nil {
+		return dto.SyncResponse{}, err
+	}
+
+	aircraft, err := s.syncRepository.GetAircraftChangedSince(userID, since)
+	if err != nil {
+		return dto.SyncResponse{}, err
+	}
+
+	contacts, err := s.syncRepository.GetContactsChangedSince(userID, since)
+	if err != nil {
+		return dto.SyncResponse{}, err
+	}
+
+	syncResponse := dto.SyncResponse{
+		Token:           encodeSyncToken(now),
+		Reset:           reset,
+		Flights:         make([]dto.LogbookResponse, 0, len(flights)),
+		DeletedFlights:  make([]uint, 0),
+		Aircraft:        make([]dto.AircraftResponse, 0, len(aircraft)),
+		DeletedAircraft: make([]uint, 0),
+		Contacts:        make([]dto.ContactResponse, 0, len(contacts)),
+		DeletedContacts: make([]uint, 0),
+	}
+	for _, flight := range flights {
+		syncResponse.Flights = append(syncResponse.Flights, adaptLogbookResponse(flight,
+			entries.aircraftByID[flight.AircraftID], entries.landingsByFlightID[flight.ID],
+			entries.passengersByFlightID[flight.ID], entries.signatureByFlightID[flight.ID]))
+	}
+	for _, a := range aircraft {
+		syncResponse.Aircraft = append(syncResponse.Aircraft, adaptAircraftResponse(a))
+	}
+	for _, contact := range contacts {
+		syncResponse.Contacts = append(syncResponse.Contacts, adaptContactResponse(contact))
+	}
+
+	if !reset {
+		deletedFlights, err := s.syncRepository.GetDeletedFlightIDsSince(userID, since)
+		if err != nil {
+			return dto.SyncResponse{}, err
+		}
+		syncResponse.DeletedFlights = append(syncResponse.DeletedFlights, deletedFlights...)
+
+		deletedAircraft, err := s.syncRepository.GetDeletedAircraftIDsSince(userID, since)
+		if err != nil {
+			return dto.SyncResponse{}, err
+		}
+		syncResponse.DeletedAircraft = append(syncResponse.DeletedAircraft, deletedAircraft...)
+
+		deletedContacts, err := s.syncRepository.GetDeletedContactIDsSince(userID, since)
+		if err != nil {
+			return dto.SyncResponse{}, err
+		}
+		syncResponse.DeletedContacts = append(syncResponse.DeletedContacts, deletedContacts...)
+	}
+
+	user, err := s.userRepository.GetByID(userID)
+	if err != nil {
+		return dto.SyncResponse{}, err
+	}
+	if reset || user.UpdatedAt.After(since) {
+		profile := adaptUserResponse(user)
+		syncResponse.Profile = &profile
+	}
+
+	return syncResponse, nil
+}
+
+// PushChanges applies the changes of the user one by one, aircraft and contacts before flights. A change of an item is
+// applied only if the item did not change on the server since the version the change is based on, otherwise the
+// current server version is returned as a conflict for the client to resolve.
+func (s *syncService) PushChanges(userID string, pushRequest dto.SyncPushRequest) (dto.SyncPushResponse, error) {
+	if len(pushRequest.Flights)+len(pushRequest.Aircraft)+len(pushRequest.Contacts) > maxSyncPushSize {
+		return dto.SyncPushResponse{}, fmt.Errorf("%w: push has more than %d changes", dto.ErrBadRequest,
+			maxSyncPushSize)
+	}
+
+	pushResponse := dto.SyncPushResponse{
+		Flights:  make([]dto.SyncFlightResult, 0, len(pushRequest.Flights)),
+		Aircraft: make([]dto.SyncAircraftResult, 0, len(pushRequest.Aircraft)),
+		Contacts: make([]dto.SyncContactResult, 0, len(pushRequest.Contacts)),
+	}
+	for i, change := range pushRequest.Aircraft {
+		pushResponse.Aircraft = append(pushResponse.Aircraft, s.pushAircraftChange(userID, i, change))
+	}
+	for i, change := range pushRequest.Contacts {
+		pushResponse.Contacts = append(pushResponse.Contacts, s.pushContactChange(userID, i, change))
+	}
+	for i, change := range pushRequest.Flights {
+		pushResponse.Flights = append(pushResponse.Flights, s.pushFlightChange(userID, i, change))
+	}
+	if pushRequest.Profile != nil {
+		profileResult := s.pushProfileChange(userID, *pushRequest.Profile)
+		pushResponse.Profile = &profileResult
+	}
+
+	return pushResponse, nil
+}
+
+func (s *syncService) pushFlightChange(userID string, index int, change dto.SyncFlightChange) dto.SyncFlightResult {
+	result := dto.SyncFlightResult{Index: index, ID: change.ID}
+	if err := checkSyncChange(change.ID, change.BaseUpdatedAt, change.Deleted, change.Entry != nil); err != nil {
+		result.Status, result.Error = dto.SyncStatusFailed, syncErrorMessage(err)
+		return result
+	}
+
+	if change.ID == nil {
+		entry, err := s.logbookService.InsertLogbookEntry(userID, *change.Entry)
+		if err != nil {
+			result.Status, result.Error = dto.SyncStatusFailed, syncErrorMessage(err)
+			return result
+		}
+		result.Status, result.ID, result.Entry = dto.SyncStatusApplied, &entry.ID, &entry
+		return result
+	}
+
+	flight, err := s.flightRepository.GetByID(*change.ID)
+	if err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			result.Status, result.Error = deletedOnServer(change.Deleted, "flight")
+			return result
+		}
+		result.Status, result.Error = dto.SyncStatusFailed, syncErrorMessage(err)
+		return result
+	}
+
+	if flight.UserID != userID {
+		result.Status = dto.SyncStatusFailed
+		result.Error = syncErrorMessage(fmt.Errorf("%w: %v", dto.ErrBadRequest, "flight does not belong to user"))
+		return result
+	}
+
+	signature, err := s.signatureRepository.GetLatestByFlightID(flight.ID)
+	if err != nil && !errors.Is(err, dto.ErrNotFound) {
+		result.Status, result.Error = dto.SyncStatusFailed, syncErrorMessage(err)
+		return result
+	}
+	var latestSignature *model.Signature
+	if err == nil {
+		latestSignature = &signature
+	}
+
+	if !sameVersion(*change.BaseUpdatedAt, logbookEntryVersion(flight, latestSignature)) {
+		return s.flightConflict(userID, result)
+	}
+
+	if change.Deleted {
+		if err := s.logbookService.DeleteLogbookEntry(userID, flight.ID, change.BaseUpdatedAt); err != nil {
+			if errors.Is(err, dto.ErrPreconditionFailed) {
+				return s.flightConflict(userID, result)
+			}
+			result.Status, result.Error = dto.SyncStatusFailed, syncErrorMessage(err)
+			return result
+		}
+		result.Status = dto.SyncStatusApplied
+		return result
+	}
+
+	entry, err := s.logbookService.UpdateLogbookEntry(userID, flight.ID, *change.Entry, change.BaseUpdatedAt)
+	if err != nil {
+		if errors.Is(err, dto.ErrPreconditionFailed) {
+			return s.flightConflict(userID, result)
+		}
+		result.Status, result.Error = dto.SyncStatusFailed, syncErrorMessage(err)
+		return result
+	}
+	result.Status, result.Entry = dto.SyncStatusApplied, &entry
+	return result
+}
+
+// flightConflict reports that the entry changed on the server since the base version, with the entry as it is now.
+func (s *syncService) flightConflict(userID string, result dto.SyncFlightResult) dto.SyncFlightResult {
+	entry, err := s.logbookService.GetLogbookEntry(userID, *result.ID)
+	if err != nil {
+		result.Status, result.Error = dto.SyncStatusFailed, syncErrorMessage(err)
+		return result
+	}
+	result.Status, result.Error, result.Entry = dto.SyncStatusConflict, syncErrorMessage(errChangedOnServer), &entry
+	return result
+}
+
+func (s *syncService) pushAircraftChange(userID string, index int, change dto.SyncAircraftChange) dto.SyncAircraftResult {
+	result := dto.SyncAircraftResult{Index: index, ID: change.ID}
+	if err := checkSyncChange(change.ID, change.BaseUpdatedAt, change.Deleted, change.Aircraft != nil); err != nil {
+		result.Status, result.Error = dto.SyncStatusFailed, syncErrorMessage(err)
+		return result
+	}
+
+	if change.ID == nil {
+		aircraft, err := s.aircraftService.InsertAircraft(userID, *change.Aircraft)
+		if err != nil {
+			result.Status, result.Error = dto.SyncStatusFailed, syncErrorMessage(err)
+			return result
+		}
+		aircraftResponse := adaptAircraftResponse(aircraft)
+		result.Status, result.ID, result.Aircraft = dto.SyncStatusApplied, &aircraft.ID, &aircraftResponse
+		return result
+	}
+
+	aircraft, err := s.aircraftRepository.GetByUserIDAndID(userID, *change.ID)
+	if err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			result.Status, result.Error = deletedOnServer(change.Deleted, "aircraft")
+			return result
+		}
+		result.Status, result.Error = dto.SyncStatusFailed, syncErrorMessage(err)
+		return result
+	}
+
+	if !sameVersion(*change.BaseUpdatedAt, aircraft.UpdatedAt) {
+		return aircraftConflict(aircraft, result)
+	}
+
+	if change.Deleted {
+		if err := s.aircraftService.DeleteAircraft(userID, aircraft.ID, change.BaseUpdatedAt); err != nil {
+			if errors.Is(err, dto.ErrPreconditionFailed) {
+				if aircraft, err = s.aircraftRepository.GetByUserIDAndID(userID, aircraft.ID); err == nil {
+					return aircraftConflict(aircraft, result)
+				}
+			}
+			result.Status, result.Error = dto.SyncStatusFailed, syncErrorMessage(err)
+			return result
+		}
+		result.Status = dto.SyncStatusApplied
+		return result
+	}
+
+	updatedAircraft, err := s.aircraftService.UpdateAircraft(userID, aircraft.ID, *change.Aircraft, change.BaseUpdatedAt)
+	if err != nil {
+		if errors.Is(err, dto.ErrPreconditionFailed) {
+			if aircraft, err = s.aircraftRepository.GetByUserIDAndID(userID, aircraft.ID); err == nil {
+				return aircraftConflict(aircraft, result)
+			}
+		}
+		result.Status, result.Error = dto.SyncStatusFailed, syncErrorMessage(err)
+		return result
+	}
+	aircraftResponse := adaptAircraftResponse(updatedAircraft)
+	result.Status, result.Aircraft = dto.SyncStatusApplied, &aircraftResponse
+	return result
+}
+
+// aircraftConflict reports that the aircraft changed on the server since the base version, with the aircraft as it is
+// now.
+func aircraftConflict(aircraft model.Aircraft, result dto.SyncAircraftResult) dto.SyncAircraftResult {
+	aircraftResponse := adaptAircraftResponse(aircraft)
+	result.Status, result.Error = dto.SyncStatusConflict, syncErrorMessage(errChangedOnServer)
+	result.Aircraft = &aircraftResponse
+	return result
+}
+
+func (s *syncService) pushContactChange(userID string, index int, change dto.SyncContactChange) dto.SyncContactResult {
+	result := dto.SyncContactResult{Index: index, ID: change.ID}
+	if err := checkSyncChange(change.ID, change.BaseUpdatedAt, change.Deleted, change.Contact != nil); err != nil {
+		result.Status, result.Error = dto.SyncStatusFailed, syncErrorMessage(err)
+		return result
+	}
+
+	if change.ID == nil {
+		contact, err := s.contactService.InsertContact(userID, *change.Contact)
+		if err != nil {
+			result.Status, result.Error = dto.SyncStatusFailed, syncErrorMessage(err)
+			return result
+		}
+		contactResponse := adaptContactResponse(contact)
+		result.Status, result.ID, result.Contact = dto.SyncStatusApplied, &contact.ID, &contactResponse
+		return result
+	}
+
+	contact, err := s.contactRepository.GetByUserIDAndID(userID, *change.ID)
+	if err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			result.Status, result.Error = deletedOnServer(change.Deleted, "contact")
+			return result
+		}
+		result.Status, result.Error = dto.SyncStatusFailed, syncErrorMessage(err)
+		return result
+	}
+
+	if !sameVersion(*change.BaseUpdatedAt, contact.UpdatedAt) {
+		return contactConflict(contact, result)
+	}
+
+	if change.Deleted {
+		if err := s.contactService.DeleteContact(userID, contact.ID, change.BaseUpdatedAt); err != nil {
+			if errors.Is(err, dto.ErrPreconditionFailed) {
+				if contact, err = s.contactRepository.GetByUserIDAndID(userID, contact.ID); err == nil {
+					return contactConflict(contact, result)
+				}
+			}
+			result.Status, result.Error = dto.SyncStatusFailed, syncErrorMessage(err)
+			return result
+		}
+		result.Status = dto.SyncStatusApplied
+		return result
+	}
+
+	updatedContact, err := s.contactService.UpdateContact(userID, contact.ID, *change.Contact, change.BaseUpdatedAt)
+	if err != nil {
+		if errors.Is(err, dto.ErrPreconditionFailed) {
+			if contact, err = s.contactRepository.GetByUserIDAndID(userID, contact.ID); err == nil {
+				return contactConflict(contact, result)
+			}
+		}
+		result.Status, result.Error = dto.SyncStatusFailed, syncErrorMessage(err)
+		return result
+	}
+	contactResponse := adaptContactResponse(updatedContact)
+	result.Status, result.Contact = dto.SyncStatusApplied, &contactResponse
+	return result
+}
+
+// contactConflict reports that the contact changed on the server since the base version, with the contact as it is
+// now.
+func contactConflict(contact model.Contact, result dto.SyncContactResult) dto.SyncContactResult {
+	contactResponse := adaptContactResponse(contact)
+	result.Status, result.Error = dto.SyncStatusConflict, syncErrorMessage(errChangedOnServer)
+	result.Contact = &contactResponse
+	return result
+}
+
+func (s *syncService) pushProfileChange(userID string, change dto.SyncProfileChange) dto.SyncProfileResult {
+	var result dto.SyncProfileResult
+	if change.BaseUpdatedAt == nil {
+		result.Status = dto.SyncStatusFailed
+		result.Error = syncErrorMessage(fmt.Errorf("%w: %v", dto.ErrBadRequest, "base_updated_at is required"))
+		return result
+	}
+
+	user, err := s.userRepository.GetByID(userID)
+	if err != nil {
+		result.Status, result.Error = dto.SyncStatusFailed, syncErrorMessage(err)
+		return result
+	}
+
+	if !sameVersion(*change.BaseUpdatedAt, user.UpdatedAt) {
+		return profileConflict(user)
+	}
+
+	updatedUser, err := s.userService.UpdateProfile(userID, change.Profile, change.BaseUpdatedAt)
+	if err != nil {
+		if errors.Is(err, dto.ErrPreconditionFailed) {
+			if user, err = s.userRepository.GetByID(userID); err == nil {
+				return profileConflict(user)
+			}
+		}
+		result.Status, result.Error = dto.SyncStatusFailed, syncErrorMessage(err)
+		return result
+	}
+	profile := adaptUserResponse(updatedUser)
+	result.Status, result.Profile = dto.SyncStatusApplied, &profile
+	return result
+}
+
+// profileConflict reports that the profile changed on the server since the base version, with the profile as it is
+// now.
+func profileConflict(user model.User) dto.SyncProfileResult {
+	profile := adaptUserResponse(user)
+	return dto.SyncProfileResult{Status: dto.SyncStatusConflict, Error: syncErrorMessage(errChangedOnServer), Profile: &profile}
+}
+
+var errChangedOnServer = fmt.Errorf("%w: %v", dto.ErrConflict, "changed on the server since the base version")
+
+// checkSyncChange checks that a new item has data, and that a change of an existing item has the version it is based
+// on and data unless it is deleted.
+func checkSyncChange(id *uint, baseUpdatedAt *time.Time, deleted bool, hasData bool) error {
+	if id == nil {
+		if deleted || !hasData {
+			return fmt.Errorf("%w: %v", dto.ErrBadRequest, "data is required to create")
+		}
+		return nil
+	}
+
+	if baseUpdatedAt == nil {
+		return fmt.Errorf("%w: %v", dto.ErrBadRequest, "base_updated_at is required to change an existing item")
+	}
+	if !deleted && !hasData {
+		return fmt.Errorf("%w: %v", dto.ErrBadRequest, "data is required to update")
+	}
+	return nil
+}
+
+// deletedOnServer returns the result of a change of an item which does not exist on the server. Deleting it again is
+// applied, any other change is a conflict.
+func deletedOnServer(deleted bool, item string) (dto.SyncStatus, *string) {
+	if deleted {
+		return dto.SyncStatusApplied, nil
+	}
+	return dto.SyncStatusConflict, syncErrorMessage(fmt.Errorf("%w: %s was deleted on the server", dto.ErrConflict, item))
+}
+
+func syncErrorMessage(err error) *string {
+	message := err.Error()
+	return &message
+}
+
+// encodeSyncToken returns an opaque token of the time the changes were read at, the client must not rely on its format.
+func encodeSyncToken(issuedAt time.Time) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(issuedAt.UnixNano(), 10)))
+}
+
+func decodeSyncToken(token string) (time.Time, error) {
+	value, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	nanoseconds, err := strconv.ParseInt(string(value), 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(0, nanoseconds).UTC(), nil
+}
+
+func adaptAircraftResponse(aircraft model.Aircraft) dto.AircraftResponse {
+	return dto.AircraftResponse{
+		ID:                 aircraft.ID,
+		RegistrationNumber: aircraft.RegistrationNumber,
+		AircraftModel:      aircraft.AircraftModel,
+		Category:           aircraft.Category,
+		Remarks:            aircraft.Remarks,
+		ImageURL:           aircraft.ImageURL,
+		UpdatedAt:          aircraft.UpdatedAt,
+	}
+}
+
+func adaptContactResponse(contact model.Contact) dto.ContactResponse {
+	return dto.ContactResponse{
+		ID:           contact.ID,
+		AvatarURL:    contact.AvatarURL,
+		FirstName:    contact.FirstName,
+		LastName:     contact.LastName,
+		Company:      contact.Company,
+		Phone:        contact.Phone,
+		EmailAddress: contact.EmailAddress,
+		Note:         contact.Note,
+		UpdatedAt:    contact.UpdatedAt,
+	}
+}
+
+func adaptUserResponse(user model.User) dto.UserResponse {
+	return dto.UserResponse{
+		FirstName:     user.FirstName,
+		LastName:      user.LastName,
+		Email:         user.Email,
+		AvatarURL:     user.AvatarURL,
+		SignatureURL:  user.SignatureURL,
+		Country:       user.Country,
+		Phone:         user.Phone,
+		Street:        user.Street,
+		City:          user.City,
+		Company:       user.Company,
+		Timezone:      user.Timezone,
+		AutoFillTimes: user.AutoFillTimes,
+		DateOfBirth:   user.DateOfBirth,
+		UpdatedAt:     user.UpdatedAt,
+	}
+}
@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: sync.go
+//
+// Generated by this command:
+//
+//	mockgen -source=sync.go -destination=sync_mock.go -package service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	reflect "reflect"
+
+	dto "github.com/avialog/backend/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSyncService is a mock of SyncService interface.
+type MockSyncService struct {
+	ctrl     *gomock.Controller
+	recorder *MockSyncServiceMockRecorder
+}
+
+// MockSyncServiceMockRecorder is the mock recorder for MockSyncService.
+type MockSyncServiceMockRecorder struct {
+	mock *MockSyncService
+}
+
+// NewMockSyncService creates a new mock instance.
+func NewMockSyncService(ctrl *gomock.Controller) *MockSyncService {
+	mock := &MockSyncService{ctrl: ctrl}
+	mock.recorder = &MockSyncServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSyncService) EXPECT() *MockSyncServiceMockRecorder {
+	return m.recorder
+}
+
+// GetChanges mocks base method.
+func (m *MockSyncService) GetChanges(userID string, token *string) (dto.SyncResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChanges", userID, token)
+	ret0, _ := ret[0].(dto.SyncResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChanges indicates an expected call of GetChanges.
+func (mr *MockSyncServiceMockRecorder) GetChanges(userID, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChanges", reflect.TypeOf((*MockSyncService)(nil).GetChanges), userID, token)
+}
+
+// PushChanges mocks base method.
+func (m *MockSyncService) PushChanges(userID string, pushRequest dto.SyncPushRequest) (dto.SyncPushResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PushChanges", userID, pushRequest)
+	ret0, _ := ret[0].(dto.SyncPushResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PushChanges indicates an expected call of PushChanges.
+func (mr *MockSyncServiceMockRecorder) PushChanges(userID, pushRequest any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushChanges", reflect.TypeOf((*MockSyncService)(nil).PushChanges), userID, pushRequest)
+}
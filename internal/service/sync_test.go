@@ -0,0 +1,540 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/config"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	"github.com/avialog/backend/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"time"
+)
+
+var _ = Describe("SyncService", func() {
+	var (
+		syncService         SyncService
+		syncRepoCtrl        *gomock.Controller
+		syncRepoMock        *repository.MockSyncRepository
+		flightRepoCtrl      *gomock.Controller
+		flightRepoMock      *repository.MockFlightRepository
+		landingRepoCtrl     *gomock.Controller
+		landingRepoMock     *repository.MockLandingRepository
+		passengerRepoCtrl   *gomock.Controller
+		passengerRepoMock   *repository.MockPassengerRepository
+		aircraftRepoCtrl    *gomock.Controller
+		aircraftRepoMock    *repository.MockAircraftRepository
+		contactRepoCtrl     *gomock.Controller
+		contactRepoMock     *repository.MockContactRepository
+		userRepoCtrl        *gomock.Controller
+		userRepoMock        *repository.MockUserRepository
+		signatureRepoCtrl   *gomock.Controller
+		signatureRepoMock   *repository.MockSignatureRepository
+		logbookServiceCtrl  *gomock.Controller
+		logbookServiceMock  *MockLogbookService
+		aircraftServiceCtrl *gomock.Controller
+		aircraftServiceMock *MockAircraftService
+		contactServiceCtrl  *gomock.Controller
+		contactServiceMock  *MockContactService
+		userServiceCtrl     *gomock.Controller
+		userServiceMock     *MockUserService
+		now                 time.Time
+		updatedAt           time.Time
+		mockAircraft        model.Aircraft
+		mockFlight          model.Flight
+		mockUser            model.User
+	)
+
+	BeforeEach(func() {
+		syncRepoCtrl = gomock.NewController(GinkgoT())
+		syncRepoMock = repository.NewMockSyncRepository(syncRepoCtrl)
+		flightRepoCtrl = gomock.NewController(GinkgoT())
+		flightRepoMock = repository.NewMockFlightRepository(flightRepoCtrl)
+		landingRepoCtrl = gomock.NewController(GinkgoT())
+		landingRepoMock = repository.NewMockLandingRepository(landingRepoCtrl)
+		passengerRepoCtrl = gomock.NewController(GinkgoT())
+		passengerRepoMock = repository.NewMockPassengerRepository(passengerRepoCtrl)
+		aircraftRepoCtrl = gomock.NewController(GinkgoT())
+		aircraftRepoMock = repository.NewMockAircraftRepository(aircraftRepoCtrl)
+		contactRepoCtrl = gomock.NewController(GinkgoT())
+		contactRepoMock = repository.NewMockContactRepository(contactRepoCtrl)
+		userRepoCtrl = gomock.NewController(GinkgoT())
+		userRepoMock = repository.NewMockUserRepository(userRepoCtrl)
+		signatureRepoCtrl = gomock.NewController(GinkgoT())
+		signatureRepoMock = repository.NewMockSignatureRepository(signatureRepoCtrl)
+		logbookServiceCtrl = gomock.NewController(GinkgoT())
+		logbookServiceMock = NewMockLogbookService(logbookServiceCtrl)
+		aircraftServiceCtrl = gomock.NewController(GinkgoT())
+		aircraftServiceMock = NewMockAircraftService(aircraftServiceCtrl)
+		contactServiceCtrl = gomock.NewController(GinkgoT())
+		contactServiceMock = NewMockContactService(contactServiceCtrl)
+		userServiceCtrl = gomock.NewController(GinkgoT())
+		userServiceMock = NewMockUserService(userServiceCtrl)
+
+		now = time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+		updatedAt = time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC)
+		syncService = newSyncService(syncRepoMock, flightRepoMock, landingRepoMock, passengerRepoMock, aircraftRepoMock,
+			contactRepoMock, userRepoMock, signatureRepoMock, logbookServiceMock, aircraftServiceMock, contactServiceMock,
+			userServiceMock, config.Config{TrashRetention: 30 * 24 * time.Hour}, func() time.Time { return now })
+
+		mockAircraft = model.Aircraft{
+			Model:              gorm.Model{ID: 1, UpdatedAt: updatedAt},
+			UserID:             "1",
+			RegistrationNumber: "SP-ABC",
+			AircraftModel:      "C152",
+		}
+		takeoffTime := time.Date(2024, 4, 18, 10, 0, 0, 0, time.UTC)
+		mockFlight = model.Flight{
+			Model:              gorm.Model{ID: 3, UpdatedAt: updatedAt},
+			UserID:             "1",
+			AircraftID:         1,
+			TakeoffTime:        takeoffTime,
+			TakeoffAirportCode: "EPWA",
+			LandingTime:        takeoffTime.Add(time.Hour),
+			LandingAirportCode: "EPKK",
+			Style:              model.StyleY,
+			MyRole:             model.RolePilotInCommand,
+		}
+		mockUser = model.User{ID: "1", Email: "anna@example.com", UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	})
+
+	AfterEach(func() {
+		syncRepoCtrl.Finish()
+		flightRepoCtrl.Finish()
+		landingRepoCtrl.Finish()
+		passengerRepoCtrl.Finish()
+		aircraftRepoCtrl.Finish()
+		contactRepoCtrl.Finish()
+		userRepoCtrl.Finish()
+		signatureRepoCtrl.Finish()
+		logbookServiceCtrl.Finish()
+		aircraftServiceCtrl.Finish()
+		contactServiceCtrl.Finish()
+		userServiceCtrl.Finish()
+	})
+
+	Describe("GetChanges", func() {
+		Context("when the token is recent", func() {
+			It("should return the changes and deletions since the token minus the overlap", func() {
+				// given
+				issuedAt := time.Date(2024, 4, 19, 6, 0, 0, 0, time.UTC)
+				token := encodeSyncToken(issuedAt)
+				since := issuedAt.Add(-syncOverlap)
+				syncRepoMock.EXPECT().GetFlightsChangedSince("1", since).Return([]model.Flight{mockFlight}, nil)
+				aircraftRepoMock.EXPECT().GetByIDs([]uint{1}).Return([]model.Aircraft{mockAircraft}, nil)
+				landingRepoMock.EXPECT().GetByFlightIDs([]uint{3}).Return(nil, nil)
+				passengerRepoMock.EXPECT().GetByFlightIDs([]uint{3}).Return(nil, nil)
+				signatureRepoMock.EXPECT().GetByFlightIDs([]uint{3}).Return(nil, nil)
+				syncRepoMock.EXPECT().GetAircraftChangedSince("1", since).Return([]model.Aircraft{mockAircraft}, nil)
+				syncRepoMock.EXPECT().GetContactsChangedSince("1", since).Return(nil, nil)
+				syncRepoMock.EXPECT().GetDeletedFlightIDsSince("1", since).Return([]uint{5}, nil)
+				syncRepoMock.EXPECT().GetDeletedAircraftIDsSince("1", since).Return(nil, nil)
+				syncRepoMock.EXPECT().GetDeletedContactIDsSince("1", since).Return([]uint{4}, nil)
+				userRepoMock.EXPECT().GetByID("1").Return(mockUser, nil)
+
+				// when
+				syncResponse, err := syncService.GetChanges("1", &token)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(syncResponse.Token).To(Equal(encodeSyncToken(now)))
+				Expect(syncResponse.Reset).To(BeFalse())
+				Expect(syncResponse.Flights).To(HaveLen(1))
+				Expect(syncResponse.Flights[0].ID).To(Equal(uint(3)))
+				Expect(syncResponse.Flights[0].Aircraft.RegistrationNumber).To(Equal("SP-ABC"))
+				Expect(syncResponse.DeletedFlights).To(Equal([]uint{5}))
+				Expect(syncResponse.Aircraft).To(Equal([]dto.AircraftResponse{{
+					ID:                 1,
+					RegistrationNumber: "SP-ABC",
+					AircraftModel:      "C152",
+					UpdatedAt:          updatedAt,
+				}}))
+				Expect(syncResponse.DeletedAircraft).To(BeEmpty())
+				Expect(syncResponse.Contacts).To(BeEmpty())
+				Expect(syncResponse.DeletedContacts).To(Equal([]uint{4}))
+				Expect(syncResponse.Profile).To(BeNil())
+			})
+		})
+		Context("when there is no token", func() {
+			It("should return every item and the profile with reset set", func() {
+				// given
+				syncRepoMock.EXPECT().GetFlightsChangedSince("1", time.Time{}).Return(nil, nil)
+				aircraftRepoMock.EXPECT().GetByIDs([]uint{}).Return(nil, nil)
+				landingRepoMock.EXPECT().GetByFlightIDs([]uint{}).Return(nil, nil)
+				passengerRepoMock.EXPECT().GetByFlightIDs([]uint{}).Return(nil, nil)
+				signatureRepoMock.EXPECT().GetByFlightIDs([]uint{}).Return(nil, nil)
+				syncRepoMock.EXPECT().GetAircraftChangedSince("1", time.Time{}).Return(nil, nil)
+				syncRepoMock.EXPECT().GetContactsChangedSince("1", time.Time{}).
+					Return([]model.Contact{{Model: gorm.Model{ID: 4, UpdatedAt: updatedAt}, FirstName: "Jan"}}, nil)
+				userRepoMock.EXPECT().GetByID("1").Return(mockUser, nil)
+
+				// when
+				syncResponse, err := syncService.GetChanges("1", nil)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(syncResponse.Reset).To(BeTrue())
+				Expect(syncResponse.Contacts).To(Equal([]dto.ContactResponse{{ID: 4, FirstName: "Jan", UpdatedAt: updatedAt}}))
+				Expect(syncResponse.DeletedFlights).To(BeEmpty())
+				Expect(syncResponse.Profile).ToNot(BeNil())
+				Expect(syncResponse.Profile.Email).To(Equal("anna@example.com"))
+			})
+		})
+		Context("when the token is older than the retention period of the trash", func() {
+			It("should return every item with reset set", func() {
+				// given
+				token := encodeSyncToken(now.Add(-31 * 24 * time.Hour))
+				syncRepoMock.EXPECT().GetFlightsChangedSince("1", time.Time{}).Return(nil, nil)
+				aircraftRepoMock.EXPECT().GetByIDs([]uint{}).Return(nil, nil)
+				landingRepoMock.EXPECT().GetByFlightIDs([]uint{}).Return(nil, nil)
+				passengerRepoMock.EXPECT().GetByFlightIDs([]uint{}).Return(nil, nil)
+				signatureRepoMock.EXPECT().GetByFlightIDs([]uint{}).Return(nil, nil)
+				syncRepoMock.EXPECT().GetAircraftChangedSince("1", time.Time{}).Return(nil, nil)
+				syncRepoMock.EXPECT().GetContactsChangedSince("1", time.Time{}).Return(nil, nil)
+				userRepoMock.EXPECT().GetByID("1").Return(mockUser, nil)
+
+				// when
+				syncResponse, err := syncService.GetChanges("1", &token)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(syncResponse.Reset).To(BeTrue())
+			})
+		})
+		Context("when the token is invalid", func() {
+			It("should return bad request", func() {
+				// given
+				token := "not a token"
+
+				// when
+				_, err := syncService.GetChanges("1", &token)
+
+				// then
+				Expect(errors.Is(err, dto.ErrBadRequest)).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("PushChanges", func() {
+		Context("when an aircraft did not change on the server", func() {
+			It("should apply the change", func() {
+				// given
+				aircraftRequest := dto.AircraftRequest{RegistrationNumber: "SP-ABC", AircraftModel: "C172"}
+				updatedAircraft := mockAircraft
+				updatedAircraft.AircraftModel = "C172"
+				updatedAircraft.UpdatedAt = now
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("1", uint(1)).Return(mockAircraft, nil)
+				aircraftServiceMock.EXPECT().UpdateAircraft("1", uint(1), aircraftRequest, &updatedAt).Return(updatedAircraft, nil)
+
+				// when
+				pushResponse, err := syncService.PushChanges("1", dto.SyncPushRequest{
+					Aircraft: []dto.SyncAircraftChange{{ID: util.Uint(1), BaseUpdatedAt: &updatedAt, Aircraft: &aircraftRequest}},
+				})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(pushResponse.Aircraft).To(Equal([]dto.SyncAircraftResult{{
+					Index:  0,
+					Status: dto.SyncStatusApplied,
+					ID:     util.Uint(1),
+					Aircraft: &dto.AircraftResponse{
+						ID:                 1,
+						RegistrationNumber: "SP-ABC",
+						AircraftModel:      "C172",
+						UpdatedAt:          now,
+					},
+				}}))
+			})
+		})
+		Context("when an aircraft changed on the server since the base version", func() {
+			It("should not apply the change and return the server version", func() {
+				// given
+				aircraftRequest := dto.AircraftRequest{RegistrationNumber: "SP-ABC", AircraftModel: "C172"}
+				baseUpdatedAt := updatedAt.Add(-time.Hour)
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("1", uint(1)).Return(mockAircraft, nil)
+
+				// when
+				pushResponse, err := syncService.PushChanges("1", dto.SyncPushRequest{
+					Aircraft: []dto.SyncAircraftChange{{ID: util.Uint(1), BaseUpdatedAt: &baseUpdatedAt, Aircraft: &aircraftRequest}},
+				})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(pushResponse.Aircraft).To(HaveLen(1))
+				Expect(pushResponse.Aircraft[0].Status).To(Equal(dto.SyncStatusConflict))
+				Expect(pushResponse.Aircraft[0].Aircraft.AircraftModel).To(Equal("C152"))
+				Expect(pushResponse.Aircraft[0].Aircraft.UpdatedAt).To(Equal(updatedAt))
+			})
+		})
+		Context("when an aircraft changes on the server while the change is applied", func() {
+			It("should report a conflict with the server version", func() {
+				// given
+				aircraftRequest := dto.AircraftRequest{RegistrationNumber: "SP-ABC", AircraftModel: "C172"}
+				changedAircraft := mockAircraft
+				changedAircraft.AircraftModel = "PA-28"
+				changedAircraft.UpdatedAt = now
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("1", uint(1)).Return(mockAircraft, nil)
+				aircraftServiceMock.EXPECT().UpdateAircraft("1", uint(1), aircraftRequest, &updatedAt).
+					Return(model.Aircraft{}, fmt.Errorf("%w: %v", dto.ErrPreconditionFailed, "changed by another request"))
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("1", uint(1)).Return(changedAircraft, nil)
+
+				// when
+				pushResponse, err := syncService.PushChanges("1", dto.SyncPushRequest{
+					Aircraft: []dto.SyncAircraftChange{{ID: util.Uint(1), BaseUpdatedAt: &updatedAt, Aircraft: &aircraftRequest}},
+				})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(pushResponse.Aircraft[0].Status).To(Equal(dto.SyncStatusConflict))
+				Expect(pushResponse.Aircraft[0].Aircraft.AircraftModel).To(Equal("PA-28"))
+			})
+		})
+		Context("when a new flight is pushed", func() {
+			It("should insert it and return its ID", func() {
+				// given
+				entry := dto.LogbookRequest{AircraftID: 1, TakeoffAirportCode: "EPWA", LandingAirportCode: "EPKK"}
+				logbookServiceMock.EXPECT().InsertLogbookEntry("1", entry).Return(dto.LogbookResponse{ID: 9}, nil)
+
+				// when
+				pushResponse, err := syncService.PushChanges("1", dto.SyncPushRequest{
+					Flights: []dto.SyncFlightChange{{Entry: &entry}},
+				})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(pushResponse.Flights).To(HaveLen(1))
+				Expect(pushResponse.Flights[0].Status).To(Equal(dto.SyncStatusApplied))
+				Expect(pushResponse.Flights[0].ID).To(Equal(util.Uint(9)))
+			})
+		})
+		Context("when a flight changed on the server since the base version", func() {
+			It("should not apply the change and return the server version", func() {
+				// given
+				entry := dto.LogbookRequest{AircraftID: 1}
+				baseUpdatedAt := updatedAt.Add(-time.Hour)
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).Return(model.Signature{}, dto.ErrNotFound)
+				logbookServiceMock.EXPECT().GetLogbookEntry("1", uint(3)).Return(dto.LogbookResponse{ID: 3, UpdatedAt: updatedAt}, nil)
+
+				// when
+				pushResponse, err := syncService.PushChanges("1", dto.SyncPushRequest{
+					Flights: []dto.SyncFlightChange{{ID: util.Uint(3), BaseUpdatedAt: &baseUpdatedAt, Entry: &entry}},
+				})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(pushResponse.Flights[0].Status).To(Equal(dto.SyncStatusConflict))
+				Expect(pushResponse.Flights[0].Entry).To(Equal(&dto.LogbookResponse{ID: 3, UpdatedAt: updatedAt}))
+			})
+		})
+		Context("when the signature request of a flight changed since the base version", func() {
+			It("should not apply the change and return the server version", func() {
+				// given
+				entry := dto.LogbookRequest{AircraftID: 1}
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).
+					Return(model.Signature{FlightID: 3, Model: gorm.Model{UpdatedAt: now}}, nil)
+				logbookServiceMock.EXPECT().GetLogbookEntry("1", uint(3)).Return(dto.LogbookResponse{ID: 3, UpdatedAt: now}, nil)
+
+				// when
+				pushResponse, err := syncService.PushChanges("1", dto.SyncPushRequest{
+					Flights: []dto.SyncFlightChange{{ID: util.Uint(3), BaseUpdatedAt: &updatedAt, Entry: &entry}},
+				})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(pushResponse.Flights[0].Status).To(Equal(dto.SyncStatusConflict))
+				Expect(pushResponse.Flights[0].Entry).To(Equal(&dto.LogbookResponse{ID: 3, UpdatedAt: now}))
+			})
+		})
+		Context("when a flight changes on the server while the change is applied", func() {
+			It("should report a conflict with the server version", func() {
+				// given
+				entry := dto.LogbookRequest{AircraftID: 1}
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).Return(model.Signature{}, dto.ErrNotFound)
+				logbookServiceMock.EXPECT().UpdateLogbookEntry("1", uint(3), entry, &updatedAt).
+					Return(dto.LogbookResponse{}, fmt.Errorf("%w: %v", dto.ErrPreconditionFailed, "flight changed since the given version"))
+				logbookServiceMock.EXPECT().GetLogbookEntry("1", uint(3)).Return(dto.LogbookResponse{ID: 3, UpdatedAt: now}, nil)
+
+				// when
+				pushResponse, err := syncService.PushChanges("1", dto.SyncPushRequest{
+					Flights: []dto.SyncFlightChange{{ID: util.Uint(3), BaseUpdatedAt: &updatedAt, Entry: &entry}},
+				})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(pushResponse.Flights[0].Status).To(Equal(dto.SyncStatusConflict))
+				Expect(pushResponse.Flights[0].Entry).To(Equal(&dto.LogbookResponse{ID: 3, UpdatedAt: now}))
+			})
+		})
+		Context("when an updated flight was deleted on the server", func() {
+			It("should report a conflict without a server version", func() {
+				// given
+				entry := dto.LogbookRequest{AircraftID: 1}
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(model.Flight{}, dto.ErrNotFound)
+
+				// when
+				pushResponse, err := syncService.PushChanges("1", dto.SyncPushRequest{
+					Flights: []dto.SyncFlightChange{{ID: util.Uint(3), BaseUpdatedAt: &updatedAt, Entry: &entry}},
+				})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(pushResponse.Flights[0].Status).To(Equal(dto.SyncStatusConflict))
+				Expect(pushResponse.Flights[0].Entry).To(BeNil())
+			})
+		})
+		Context("when a flight is deleted and did not change on the server", func() {
+			It("should delete it", func() {
+				// given
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).Return(model.Signature{}, dto.ErrNotFound)
+				logbookServiceMock.EXPECT().DeleteLogbookEntry("1", uint(3), &updatedAt).Return(nil)
+
+				// when
+				pushResponse, err := syncService.PushChanges("1", dto.SyncPushRequest{
+					Flights: []dto.SyncFlightChange{{ID: util.Uint(3), BaseUpdatedAt: &updatedAt, Deleted: true}},
+				})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(pushResponse.Flights[0].Status).To(Equal(dto.SyncStatusApplied))
+				Expect(pushResponse.Flights[0].Entry).To(BeNil())
+			})
+		})
+		Context("when a deleted flight changes on the server while the deletion is applied", func() {
+			It("should report a conflict with the server version", func() {
+				// given
+				flightRepoMock.EXPECT().GetByID(uint(3)).Return(mockFlight, nil)
+				signatureRepoMock.EXPECT().GetLatestByFlightID(uint(3)).Return(model.Signature{}, dto.ErrNotFound)
+				logbookServiceMock.EXPECT().DeleteLogbookEntry("1", uint(3), &updatedAt).
+					Return(fmt.Errorf("%w: %v", dto.ErrPreconditionFailed, "flight changed since the given version"))
+				logbookServiceMock.EXPECT().GetLogbookEntry("1", uint(3)).Return(dto.LogbookResponse{ID: 3, UpdatedAt: now}, nil)
+
+				// when
+				pushResponse, err := syncService.PushChanges("1", dto.SyncPushRequest{
+					Flights: []dto.SyncFlightChange{{ID: util.Uint(3), BaseUpdatedAt: &updatedAt, Deleted: true}},
+				})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(pushResponse.Flights[0].Status).To(Equal(dto.SyncStatusConflict))
+				Expect(pushResponse.Flights[0].Entry).To(Equal(&dto.LogbookResponse{ID: 3, UpdatedAt: now}))
+			})
+		})
+		Context("when a deleted aircraft changes on the server while the deletion is applied", func() {
+			It("should report a conflict with the server version", func() {
+				// given
+				changedAircraft := mockAircraft
+				changedAircraft.AircraftModel = "PA-28"
+				changedAircraft.UpdatedAt = now
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("1", uint(1)).Return(mockAircraft, nil)
+				aircraftServiceMock.EXPECT().DeleteAircraft("1", uint(1), &updatedAt).
+					Return(fmt.Errorf("%w: %v", dto.ErrPreconditionFailed, "changed by another request"))
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("1", uint(1)).Return(changedAircraft, nil)
+
+				// when
+				pushResponse, err := syncService.PushChanges("1", dto.SyncPushRequest{
+					Aircraft: []dto.SyncAircraftChange{{ID: util.Uint(1), BaseUpdatedAt: &updatedAt, Deleted: true}},
+				})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(pushResponse.Aircraft[0].Status).To(Equal(dto.SyncStatusConflict))
+				Expect(pushResponse.Aircraft[0].Aircraft.AircraftModel).To(Equal("PA-28"))
+			})
+		})
+		Context("when a deleted contact changes on the server while the deletion is applied", func() {
+			It("should report a conflict with the server version", func() {
+				// given
+				contact := model.Contact{Model: gorm.Model{ID: 4, UpdatedAt: updatedAt}, UserID: "1", FirstName: "Jan"}
+				changedContact := contact
+				changedContact.FirstName = "Janusz"
+				changedContact.UpdatedAt = now
+				contactRepoMock.EXPECT().GetByUserIDAndID("1", uint(4)).Return(contact, nil)
+				contactServiceMock.EXPECT().DeleteContact("1", uint(4), &updatedAt).
+					Return(fmt.Errorf("%w: %v", dto.ErrPreconditionFailed, "changed by another request"))
+				contactRepoMock.EXPECT().GetByUserIDAndID("1", uint(4)).Return(changedContact, nil)
+
+				// when
+				pushResponse, err := syncService.PushChanges("1", dto.SyncPushRequest{
+					Contacts: []dto.SyncContactChange{{ID: util.Uint(4), BaseUpdatedAt: &updatedAt, Deleted: true}},
+				})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(pushResponse.Contacts[0].Status).To(Equal(dto.SyncStatusConflict))
+				Expect(pushResponse.Contacts[0].Contact.FirstName).To(Equal("Janusz"))
+			})
+		})
+		Context("when a deleted contact was already deleted on the server", func() {
+			It("should report the deletion as applied", func() {
+				// given
+				contactRepoMock.EXPECT().GetByUserIDAndID("1", uint(4)).Return(model.Contact{}, dto.ErrNotFound)
+
+				// when
+				pushResponse, err := syncService.PushChanges("1", dto.SyncPushRequest{
+					Contacts: []dto.SyncContactChange{{ID: util.Uint(4), BaseUpdatedAt: &updatedAt, Deleted: true}},
+				})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(pushResponse.Contacts).To(Equal([]dto.SyncContactResult{{
+					Index:  0,
+					Status: dto.SyncStatusApplied,
+					ID:     util.Uint(4),
+				}}))
+			})
+		})
+		Context("when a change of an existing item has no base version", func() {
+			It("should fail the change", func() {
+				// given
+				contactRequest := dto.ContactRequest{FirstName: "Jan"}
+
+				// when
+				pushResponse, err := syncService.PushChanges("1", dto.SyncPushRequest{
+					Contacts: []dto.SyncContactChange{{ID: util.Uint(4), Contact: &contactRequest}},
+				})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(pushResponse.Contacts[0].Status).To(Equal(dto.SyncStatusFailed))
+				Expect(*pushResponse.Contacts[0].Error).To(Equal("bad request: base_updated_at is required to change an existing item"))
+			})
+		})
+		Context("when the profile changed on the server since the base version", func() {
+			It("should not apply the change and return the server version", func() {
+				// given
+				baseUpdatedAt := mockUser.UpdatedAt.Add(-time.Hour)
+				userRepoMock.EXPECT().GetByID("1").Return(mockUser, nil)
+
+				// when
+				pushResponse, err := syncService.PushChanges("1", dto.SyncPushRequest{
+					Profile: &dto.SyncProfileChange{BaseUpdatedAt: &baseUpdatedAt, Profile: dto.UserRequest{FirstName: util.String("Anna")}},
+				})
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(pushResponse.Profile.Status).To(Equal(dto.SyncStatusConflict))
+				Expect(pushResponse.Profile.Profile.Email).To(Equal("anna@example.com"))
+			})
+		})
+		Context("when the push has too many changes", func() {
+			It("should return bad request", func() {
+				// given
+				changes := make([]dto.SyncFlightChange, maxSyncPushSize+1)
+
+				// when
+				_, err := syncService.PushChanges("1", dto.SyncPushRequest{Flights: changes})
+
+				// then
+				Expect(errors.Is(err, dto.ErrBadRequest)).To(BeTrue())
+			})
+		})
+	})
+})
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/config"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	"time"
+)
+
+//go:generate mockgen -source=trash.go -destination=trash_mock.go -package service
+type TrashService interface {
+	GetTrash(userID string) (dto.TrashResponse, error)
+	RestoreLogbookEntry(userID string, flightID uint) error
+	RestoreAircraft(userID string, aircraftID uint) error
+	RestoreContact(userID string, contactID uint) error
+	PurgeTrash(ctx context.Context) (dto.PurgeResult, error)
+}
+
+type trashService struct {
+	trashRepository         repository.TrashRepository
+	flightRepository        repository.FlightRepository
+	aircraftRepository      repository.AircraftRepository
+	flightVersionRepository repository.FlightVersionRepository
+	config                  config.Config
+	now                     func() time.Time
+}
+
+func newTrashService(trashRepository repository.TrashRepository, flightRepository repository.FlightRepository,
+	aircraftRepository repository.AircraftRepository, flightVersionRepository repository.FlightVersionRepository,
+	config config.Config, now func() time.Time) TrashService {
+	return &trashService{trashRepository: trashRepository, flightRepository: flightRepository,
+		aircraftRepository: aircraftRepository, flightVersionRepository: flightVersionRepository, config: config, now: now}
+}
+
+// GetTrash returns the deleted flights, aircraft and contacts of the user, the most recently deleted first.
+func (t *trashService) GetTrash(userID string) (dto.TrashResponse, error) {
+	flights, err := t.trashRepository.GetDeletedFlightsByUserID(userID)
+	if err != nil {
+		return dto.TrashResponse{}, err
+	}
+
+	aircraft, err := t.trashRepository.GetDeletedAircraftByUserID(userID)
+	if err != nil {
+		return dto.TrashResponse{}, err
+	}
+
+	contacts, err := t.trashRepository.GetDeletedContactsByUserID(userID)
+	if err != nil {
+		return dto.TrashResponse{}, err
+	}
+
+	trashResponse := dto.TrashResponse{
+		Flights:  make([]dto.TrashFlightResponse, 0, len(flights)),
+		Aircraft: make([]dto.TrashAircraftResponse, 0, len(aircraft)),
+		Contacts: make([]dto.TrashContactResponse, 0, len(contacts)),
+	}
+	for _, flight := range flights {
+		trashResponse.Flights = append(trashResponse.Flights, dto.TrashFlightResponse{
+			ID:                 flight.ID,
+			AircraftID:         flight.AircraftID,
+			TakeoffTime:        flight.TakeoffTime,
+			TakeoffAirportCode: flight.TakeoffAirportCode,
+			LandingTime:        flight.LandingTime,
+			LandingAirportCode: flight.LandingAirportCode,
+			DeletedAt:          flight.DeletedAt.Time,
+			PurgeAt:            flight.DeletedAt.Time.Add(t.config.TrashRetention),
+		})
+	}
+	for _, a := range aircraft {
+		trashResponse.Aircraft = append(trashResponse.Aircraft, dto.TrashAircraftResponse{
+			ID:                 a.ID,
+			RegistrationNumber: a.RegistrationNumber,
+			AircraftModel:      a.AircraftModel,
+			DeletedAt:          a.DeletedAt.Time,
+			PurgeAt:            a.DeletedAt.Time.Add(t.config.TrashRetention),
+		})
+	}
+	for _, contact := range contacts {
+		trashResponse.Contacts = append(trashResponse.Contacts, dto.TrashContactResponse{
+			ID:        contact.ID,
+			FirstName: contact.FirstName,
+			LastName:  contact.LastName,
+			Company:   contact.Company,
+			DeletedAt: contact.DeletedAt.Time,
+			PurgeAt:   contact.DeletedAt.Time.Add(t.config.TrashRetention),
+		})
+	}
+
+	return trashResponse, nil
+}
+
+// RestoreLogbookEntry brings back the deleted flight with its landings and passengers and records the restore in the
+// history of the logbook. The aircraft of the flight has to be restored first.
+func (t *trashService) RestoreLogbookEntry(userID string, flightID uint) error {
+	flight, err := t.trashRepository.GetDeletedFlightByUserIDAndID(userID, flightID)
+	if err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			return fmt.Errorf("%w: %v", dto.ErrNotFound, "deleted flight not found")
+		}
+		return err
+	}
+
+	if _, err := t.aircraftRepository.GetByUserIDAndID(userID, flight.AircraftID); err != nil {
+		if errors.Is(err, dto.ErrNotFound) {
+			return fmt.Errorf("%w: %v", dto.ErrBadRequest, "aircraft of the flight is deleted, restore it first")
+		}
+		return err
+	}
+
+	tx := t.flightRepository.Begin()
+
+	if err := t.flightVersionRepository.LockUserTx(tx, userID); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	landings, passengers, err := t.trashRepository.RestoreFlightTx(tx, flight, t.now())
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, dto.ErrNotFound) {
+			return fmt.Errorf("%w: %v", dto.ErrNotFound, "deleted flight not found")
+		}
+		return err
+	}
+
+	if err := recordFlightVersions(t.flightVersionRepository, tx, userID, t.now(), flightChange{
+		flightID: flightID,
+		action:   model.FlightVersionActionRestored,
+		snapshot: newLogbookSnapshot(flight, passengers, landings),
+	}); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return fmt.Errorf("%w: %v", dto.ErrInternalFailure, err)
+	}
+	return nil
+}
+
+func (t *trashService) RestoreAircraft(userID string, aircraftID uint) error {
+	return t.trashRepository.RestoreAircraft(userID, aircraftID, t.now())
+}
+
+func (t *trashService) RestoreContact(userID string, contactID uint) error {
+	return t.trashRepository.RestoreContact(userID, contactID, t.now())
+}
+
+// PurgeTrash permanently removes everything deleted longer ago than the retention period.
+func (t *trashService) PurgeTrash(ctx context.Context) (dto.PurgeResult, error) {
+	return t.trashRepository.PurgeDeletedBefore(ctx, t.now().Add(-t.config.TrashRetention))
+}
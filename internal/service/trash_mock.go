@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: trash.go
+//
+// Generated by this command:
+//
+//	mockgen -source=trash.go -destination=trash_mock.go -package service
+//
+
+// Package service is a generated GoMock package.
+package service
+
+import (
+	context "context"
+	reflect "reflect"
+
+	dto "github.com/avialog/backend/internal/dto"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTrashService is a mock of TrashService interface.
+type MockTrashService struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrashServiceMockRecorder
+}
+
+// MockTrashServiceMockRecorder is the mock recorder for MockTrashService.
+type MockTrashServiceMockRecorder struct {
+	mock *MockTrashService
+}
+
+// NewMockTrashService creates a new mock instance.
+func NewMockTrashService(ctrl *gomock.Controller) *MockTrashService {
+	mock := &MockTrashService{ctrl: ctrl}
+	mock.recorder = &MockTrashServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrashService) EXPECT() *MockTrashServiceMockRecorder {
+	return m.recorder
+}
+
+// GetTrash mocks base method.
+func (m *MockTrashService) GetTrash(userID string) (dto.TrashResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTrash", userID)
+	ret0, _ := ret[0].(dto.TrashResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTrash indicates an expected call of GetTrash.
+func (mr *MockTrashServiceMockRecorder) GetTrash(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTrash", reflect.TypeOf((*MockTrashService)(nil).GetTrash), userID)
+}
+
+// PurgeTrash mocks base method.
+func (m *MockTrashService) PurgeTrash(ctx context.Context) (dto.PurgeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeTrash", ctx)
+	ret0, _ := ret[0].(dto.PurgeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeTrash indicates an expected call of PurgeTrash.
+func (mr *MockTrashServiceMockRecorder) PurgeTrash(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeTrash", reflect.TypeOf((*MockTrashService)(nil).PurgeTrash), ctx)
+}
+
+// RestoreAircraft mocks base method.
+func (m *MockTrashService) RestoreAircraft(userID string, aircraftID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreAircraft", userID, aircraftID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreAircraft indicates an expected call of RestoreAircraft.
+func (mr *MockTrashServiceMockRecorder) RestoreAircraft(userID, aircraftID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreAircraft", reflect.TypeOf((*MockTrashService)(nil).RestoreAircraft), userID, aircraftID)
+}
+
+// RestoreContact mocks base method.
+func (m *MockTrashService) RestoreContact(userID string, contactID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreContact", userID, contactID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreContact indicates an expected call of RestoreContact.
+func (mr *MockTrashServiceMockRecorder) RestoreContact(userID, contactID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreContact", reflect.TypeOf((*MockTrashService)(nil).RestoreContact), userID, contactID)
+}
+
+// RestoreLogbookEntry mocks base method.
+func (m *MockTrashService) RestoreLogbookEntry(userID string, flightID uint) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreLogbookEntry", userID, flightID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreLogbookEntry indicates an expected call of RestoreLogbookEntry.
+func (mr *MockTrashServiceMockRecorder) RestoreLogbookEntry(userID, flightID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreLogbookEntry", reflect.TypeOf((*MockTrashService)(nil).RestoreLogbookEntry), userID, flightID)
+}
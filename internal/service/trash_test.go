@@ -0,0 +1,313 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/avialog/backend/internal/config"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/infrastructure"
+	"github.com/avialog/backend/internal/model"
+	"github.com/avialog/backend/internal/repository"
+	"github.com/avialog/backend/internal/util"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/mock/gomock"
+	"gorm.io/gorm"
+	"time"
+)
+
+var _ = Describe("TrashService", func() {
+	var (
+		trashService          TrashService
+		trashRepoCtrl         *gomock.Controller
+		trashRepoMock         *repository.MockTrashRepository
+		flightRepoCtrl        *gomock.Controller
+		flightRepoMock        *repository.MockFlightRepository
+		aircraftRepoCtrl      *gomock.Controller
+		aircraftRepoMock      *repository.MockAircraftRepository
+		flightVersionRepoCtrl *gomock.Controller
+		flightVersionRepoMock *repository.MockFlightVersionRepository
+		databaseCtrl          *gomock.Controller
+		databaseMock          *infrastructure.MockDatabase
+		now                   time.Time
+		deletedAt             time.Time
+		mockDeletedFlight     model.Flight
+		mockLandings          []model.Landing
+		mockPassengers        []model.Passenger
+	)
+
+	BeforeEach(func() {
+		trashRepoCtrl = gomock.NewController(GinkgoT())
+		trashRepoMock = repository.NewMockTrashRepository(trashRepoCtrl)
+		flightRepoCtrl = gomock.NewController(GinkgoT())
+		flightRepoMock = repository.NewMockFlightRepository(flightRepoCtrl)
+		aircraftRepoCtrl = gomock.NewController(GinkgoT())
+		aircraftRepoMock = repository.NewMockAircraftRepository(aircraftRepoCtrl)
+		flightVersionRepoCtrl = gomock.NewController(GinkgoT())
+		flightVersionRepoMock = repository.NewMockFlightVersionRepository(flightVersionRepoCtrl)
+		databaseCtrl = gomock.NewController(GinkgoT())
+		databaseMock = infrastructure.NewMockDatabase(databaseCtrl)
+
+		now = time.Date(2024, 4, 20, 12, 0, 0, 0, time.UTC)
+		deletedAt = time.Date(2024, 4, 1, 8, 0, 0, 0, time.UTC)
+		trashService = newTrashService(trashRepoMock, flightRepoMock, aircraftRepoMock, flightVersionRepoMock,
+			config.Config{TrashRetention: 30 * 24 * time.Hour}, func() time.Time { return now })
+
+		takeoffTime := time.Date(2024, 3, 25, 10, 0, 0, 0, time.UTC)
+		mockDeletedFlight = model.Flight{
+			Model:              gorm.Model{ID: 3, DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}},
+			UserID:             "1",
+			AircraftID:         1,
+			TakeoffTime:        takeoffTime,
+			TakeoffAirportCode: "EPWA",
+			LandingTime:        takeoffTime.Add(time.Hour),
+			LandingAirportCode: "EPKK",
+			Style:              model.StyleY,
+			MyRole:             model.RolePilotInCommand,
+		}
+		mockLandings = []model.Landing{{Model: gorm.Model{ID: 7}, FlightID: 3, AirportCode: util.String("EPKK"), DayCount: util.Uint(1)}}
+		mockPassengers = []model.Passenger{{Model: gorm.Model{ID: 8}, FlightID: 3, Role: model.RoleInstructor, FirstName: "Jan"}}
+	})
+
+	AfterEach(func() {
+		trashRepoCtrl.Finish()
+		flightRepoCtrl.Finish()
+		aircraftRepoCtrl.Finish()
+		flightVersionRepoCtrl.Finish()
+		databaseCtrl.Finish()
+	})
+
+	Describe("GetTrash", func() {
+		Context("when the user has deleted items", func() {
+			It("should return them with the time they are purged at", func() {
+				// given
+				trashRepoMock.EXPECT().GetDeletedFlightsByUserID("1").Return([]model.Flight{mockDeletedFlight}, nil)
+				trashRepoMock.EXPECT().GetDeletedAircraftByUserID("1").Return([]model.Aircraft{{
+					Model:              gorm.Model{ID: 1, DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}},
+					RegistrationNumber: "SP-ABC",
+					AircraftModel:      "C152",
+				}}, nil)
+				trashRepoMock.EXPECT().GetDeletedContactsByUserID("1").Return([]model.Contact{{
+					Model:     gorm.Model{ID: 4, DeletedAt: gorm.DeletedAt{Time: deletedAt, Valid: true}},
+					FirstName: "Jan",
+					LastName:  util.String("Kowalski"),
+				}}, nil)
+
+				// when
+				trashResponse, err := trashService.GetTrash("1")
+
+				// then
+				Expect(err).To(BeNil())
+				purgeAt := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+				Expect(trashResponse.Flights).To(Equal([]dto.TrashFlightResponse{{
+					ID:                 3,
+					AircraftID:         1,
+					TakeoffTime:        mockDeletedFlight.TakeoffTime,
+					TakeoffAirportCode: "EPWA",
+					LandingTime:        mockDeletedFlight.LandingTime,
+					LandingAirportCode: "EPKK",
+					DeletedAt:          deletedAt,
+					PurgeAt:            purgeAt,
+				}}))
+				Expect(trashResponse.Aircraft).To(Equal([]dto.TrashAircraftResponse{{
+					ID:                 1,
+					RegistrationNumber: "SP-ABC",
+					AircraftModel:      "C152",
+					DeletedAt:          deletedAt,
+					PurgeAt:            purgeAt,
+				}}))
+				Expect(trashResponse.Contacts).To(Equal([]dto.TrashContactResponse{{
+					ID:        4,
+					FirstName: "Jan",
+					LastName:  util.String("Kowalski"),
+					DeletedAt: deletedAt,
+					PurgeAt:   purgeAt,
+				}}))
+			})
+		})
+		Context("when the trash is empty", func() {
+			It("should return empty lists", func() {
+				// given
+				trashRepoMock.EXPECT().GetDeletedFlightsByUserID("1").Return(nil, nil)
+				trashRepoMock.EXPECT().GetDeletedAircraftByUserID("1").Return(nil, nil)
+				trashRepoMock.EXPECT().GetDeletedContactsByUserID("1").Return(nil, nil)
+
+				// when
+				trashResponse, err := trashService.GetTrash("1")
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(trashResponse.Flights).To(BeEmpty())
+				Expect(trashResponse.Flights).ToNot(BeNil())
+				Expect(trashResponse.Aircraft).ToNot(BeNil())
+				Expect(trashResponse.Contacts).ToNot(BeNil())
+			})
+		})
+		Context("when fetching the deleted flights fails", func() {
+			It("should return an error", func() {
+				// given
+				trashRepoMock.EXPECT().GetDeletedFlightsByUserID("1").Return(nil, fmt.Errorf("%w: %v", dto.ErrInternalFailure, "db error"))
+
+				// when
+				_, err := trashService.GetTrash("1")
+
+				// then
+				Expect(err.Error()).To(Equal("internal failure: db error"))
+			})
+		})
+	})
+
+	Describe("RestoreLogbookEntry", func() {
+		Context("when the flight and its aircraft can be restored", func() {
+			It("should restore it and record the restore in the history", func() {
+				// given
+				trashRepoMock.EXPECT().GetDeletedFlightByUserIDAndID("1", uint(3)).Return(mockDeletedFlight, nil)
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("1", uint(1)).Return(model.Aircraft{Model: gorm.Model{ID: 1}}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "1").Return(nil)
+				trashRepoMock.EXPECT().RestoreFlightTx(databaseMock, mockDeletedFlight, now).Return(mockLandings, mockPassengers, nil)
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "1").Return(model.FlightVersion{Sequence: 4, Hash: "abcd"}, nil)
+				flightVersionRepoMock.EXPECT().CreateTx(databaseMock, gomock.Any()).DoAndReturn(func(_ infrastructure.Database, version model.FlightVersion) (model.FlightVersion, error) {
+					Expect(version.Sequence).To(Equal(uint(5)))
+					Expect(version.PreviousHash).To(Equal("abcd"))
+					Expect(version.FlightID).To(Equal(uint(3)))
+					Expect(version.Action).To(Equal(model.FlightVersionActionRestored))
+					Expect(version.RecordedAt).To(Equal(now))
+					Expect(version.Snapshot).To(ContainSubstring(`"airport_code":"EPKK"`))
+					Expect(version.Snapshot).To(ContainSubstring(`"first_name":"Jan"`))
+					return version, nil
+				})
+				databaseMock.EXPECT().Commit().Return(&gorm.DB{Error: nil})
+
+				// when
+				err := trashService.RestoreLogbookEntry("1", uint(3))
+
+				// then
+				Expect(err).To(BeNil())
+			})
+		})
+		Context("when the flight is not in the trash", func() {
+			It("should return not found", func() {
+				// given
+				trashRepoMock.EXPECT().GetDeletedFlightByUserIDAndID("1", uint(3)).Return(model.Flight{}, dto.ErrNotFound)
+
+				// when
+				err := trashService.RestoreLogbookEntry("1", uint(3))
+
+				// then
+				Expect(errors.Is(err, dto.ErrNotFound)).To(BeTrue())
+				Expect(err.Error()).To(Equal("not found: deleted flight not found"))
+			})
+		})
+		Context("when the aircraft of the flight is deleted", func() {
+			It("should return bad request", func() {
+				// given
+				trashRepoMock.EXPECT().GetDeletedFlightByUserIDAndID("1", uint(3)).Return(mockDeletedFlight, nil)
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("1", uint(1)).Return(model.Aircraft{}, dto.ErrNotFound)
+
+				// when
+				err := trashService.RestoreLogbookEntry("1", uint(3))
+
+				// then
+				Expect(errors.Is(err, dto.ErrBadRequest)).To(BeTrue())
+				Expect(err.Error()).To(Equal("bad request: aircraft of the flight is deleted, restore it first"))
+			})
+		})
+		Context("when the flight is restored concurrently", func() {
+			It("should return not found and rollback transaction", func() {
+				// given
+				trashRepoMock.EXPECT().GetDeletedFlightByUserIDAndID("1", uint(3)).Return(mockDeletedFlight, nil)
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("1", uint(1)).Return(model.Aircraft{Model: gorm.Model{ID: 1}}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "1").Return(nil)
+				trashRepoMock.EXPECT().RestoreFlightTx(databaseMock, mockDeletedFlight, now).
+					Return(nil, nil, fmt.Errorf("%w: flight cannot be restored", dto.ErrNotFound))
+				databaseMock.EXPECT().Rollback()
+
+				// when
+				err := trashService.RestoreLogbookEntry("1", uint(3))
+
+				// then
+				Expect(err.Error()).To(Equal("not found: deleted flight not found"))
+			})
+		})
+		Context("when recording the history fails", func() {
+			It("should return an error and rollback transaction", func() {
+				// given
+				trashRepoMock.EXPECT().GetDeletedFlightByUserIDAndID("1", uint(3)).Return(mockDeletedFlight, nil)
+				aircraftRepoMock.EXPECT().GetByUserIDAndID("1", uint(1)).Return(model.Aircraft{Model: gorm.Model{ID: 1}}, nil)
+				flightRepoMock.EXPECT().Begin().Return(databaseMock)
+				flightVersionRepoMock.EXPECT().LockUserTx(databaseMock, "1").Return(nil)
+				trashRepoMock.EXPECT().RestoreFlightTx(databaseMock, mockDeletedFlight, now).Return(mockLandings, mockPassengers, nil)
+				flightVersionRepoMock.EXPECT().GetHeadTx(databaseMock, "1").Return(model.FlightVersion{}, fmt.Errorf("%w: %v", dto.ErrInternalFailure, "db error"))
+				databaseMock.EXPECT().Rollback()
+
+				// when
+				err := trashService.RestoreLogbookEntry("1", uint(3))
+
+				// then
+				Expect(err.Error()).To(Equal("internal failure: db error"))
+			})
+		})
+	})
+
+	Describe("RestoreAircraft", func() {
+		Context("when the aircraft is in the trash", func() {
+			It("should restore it", func() {
+				// given
+				trashRepoMock.EXPECT().RestoreAircraft("1", uint(1), now).Return(nil)
+
+				// when
+				err := trashService.RestoreAircraft("1", uint(1))
+
+				// then
+				Expect(err).To(BeNil())
+			})
+		})
+		Context("when the aircraft is not in the trash", func() {
+			It("should return not found", func() {
+				// given
+				trashRepoMock.EXPECT().RestoreAircraft("1", uint(1), now).Return(fmt.Errorf("%w: %v", dto.ErrNotFound, "deleted aircraft not found"))
+
+				// when
+				err := trashService.RestoreAircraft("1", uint(1))
+
+				// then
+				Expect(err.Error()).To(Equal("not found: deleted aircraft not found"))
+			})
+		})
+	})
+
+	Describe("RestoreContact", func() {
+		Context("when the contact is not in the trash", func() {
+			It("should return not found", func() {
+				// given
+				trashRepoMock.EXPECT().RestoreContact("1", uint(4), now).Return(fmt.Errorf("%w: %v", dto.ErrNotFound, "deleted contact not found"))
+
+				// when
+				err := trashService.RestoreContact("1", uint(4))
+
+				// then
+				Expect(err.Error()).To(Equal("not found: deleted contact not found"))
+			})
+		})
+	})
+
+	Describe("PurgeTrash", func() {
+		Context("when the retention period is 30 days", func() {
+			It("should purge the items deleted more than 30 days ago", func() {
+				// given
+				trashRepoMock.EXPECT().PurgeDeletedBefore(gomock.Any(), time.Date(2024, 3, 21, 12, 0, 0, 0, time.UTC)).
+					Return(dto.PurgeResult{Flights: 2, Aircraft: 1}, nil)
+
+				// when
+				purgeResult, err := trashService.PurgeTrash(context.Background())
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(purgeResult).To(Equal(dto.PurgeResult{Flights: 2, Aircraft: 1}))
+			})
+		})
+	})
+})
@@ -5,12 +5,13 @@ import (
 	"github.com/avialog/backend/internal/dto"
 	"github.com/avialog/backend/internal/model"
 	"github.com/avialog/backend/internal/repository"
+	"time"
 )
 
 //go:generate mockgen -source=user.go -destination=user_mock.go -package service
 type UserService interface {
 	GetUser(id string) (model.User, error)
-	UpdateProfile(id string, userRequest dto.UserRequest) (model.User, error)
+	UpdateProfile(id string, userRequest dto.UserRequest, version *time.Time) (model.User, error)
 }
 
 type userService struct {
@@ -26,11 +27,17 @@ func (u *userService) GetUser(id string) (model.User, error) {
 	return u.userRepository.GetByID(id)
 }
 
-func (u *userService) UpdateProfile(id string, userRequest dto.UserRequest) (model.User, error) {
+// UpdateProfile changes the profile if it is still at the given version, or regardless of its version if none is
+// given.
+func (u *userService) UpdateProfile(id string, userRequest dto.UserRequest, version *time.Time) (model.User, error) {
 	user, err := u.userRepository.GetByID(id)
 	if err != nil {
 		return model.User{}, err
 	}
+
+	if err := checkVersion(version, user.UpdatedAt, "profile"); err != nil {
+		return model.User{}, err
+	}
 	user.FirstName = userRequest.FirstName
 	user.LastName = userRequest.LastName
 	user.AvatarURL = userRequest.AvatarURL
@@ -41,6 +48,14 @@ func (u *userService) UpdateProfile(id string, userRequest dto.UserRequest) (mod
 	user.City = userRequest.City
 	user.Company = userRequest.Company
 	user.Timezone = userRequest.Timezone
+	user.DateOfBirth = userRequest.DateOfBirth
+	if userRequest.AutoFillTimes != nil {
+		user.AutoFillTimes = *userRequest.AutoFillTimes
+	}
 
+	if version != nil {
+		// the version was checked on read, the write fails if the profile changed since
+		return u.userRepository.SaveIfUnchanged(user, user.UpdatedAt)
+	}
 	return u.userRepository.Save(user)
 }
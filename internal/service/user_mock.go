@@ -11,6 +11,7 @@ package service
 
 import (
 	reflect "reflect"
+	time "time"
 
 	dto "github.com/avialog/backend/internal/dto"
 	model "github.com/avialog/backend/internal/model"
@@ -56,16 +57,16 @@ func (mr *MockUserServiceMockRecorder) GetUser(id any) *gomock.Call {
 }
 
 // UpdateProfile mocks base method.
-func (m *MockUserService) UpdateProfile(id string, userRequest dto.UserRequest) (model.User, error) {
+func (m *MockUserService) UpdateProfile(id string, userRequest dto.UserRequest, version *time.Time) (model.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateProfile", id, userRequest)
+	ret := m.ctrl.Call(m, "UpdateProfile", id, userRequest, version)
 	ret0, _ := ret[0].(model.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateProfile indicates an expected call of UpdateProfile.
-func (mr *MockUserServiceMockRecorder) UpdateProfile(id, userRequest any) *gomock.Call {
+func (mr *MockUserServiceMockRecorder) UpdateProfile(id, userRequest, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockUserService)(nil).UpdateProfile), id, userRequest)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockUserService)(nil).UpdateProfile), id, userRequest, version)
 }
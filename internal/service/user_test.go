@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"github.com/avialog/backend/internal/config"
 	"github.com/avialog/backend/internal/dto"
 	"github.com/avialog/backend/internal/model"
@@ -10,6 +11,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
+	"time"
 )
 
 var _ = Describe("UserService", func() {
@@ -95,19 +97,83 @@ var _ = Describe("UserService", func() {
 				userRepoMock.EXPECT().Save(mockUser).Return(mockUser, nil)
 				userRepoMock.EXPECT().GetByID("1").Return(mockUser, nil)
 				// when
-				user, err := userService.UpdateProfile("1", userRequest)
+				user, err := userService.UpdateProfile("1", userRequest, nil)
 
 				// then
 				Expect(err).To(BeNil())
 				Expect(user).To(Equal(mockUser))
 			})
 		})
+		Context("when the profile changed since the given version", func() {
+			It("should return precondition failed error without saving", func() {
+				// given
+				mockUser.UpdatedAt = time.Date(2024, 4, 19, 8, 0, 0, 0, time.UTC)
+				version := time.Date(2024, 4, 18, 8, 0, 0, 0, time.UTC)
+				userRepoMock.EXPECT().GetByID("1").Return(mockUser, nil)
+
+				// when
+				user, err := userService.UpdateProfile("1", userRequest, &version)
+
+				// then
+				Expect(errors.Is(err, dto.ErrPreconditionFailed)).To(BeTrue())
+				Expect(user).To(Equal(model.User{}))
+			})
+		})
+		Context("when another request changes the profile after the version is checked", func() {
+			It("should return precondition failed error", func() {
+				// given
+				version := mockUser.UpdatedAt
+				userRepoMock.EXPECT().GetByID("1").Return(mockUser, nil)
+				userRepoMock.EXPECT().SaveIfUnchanged(gomock.Any(), mockUser.UpdatedAt).
+					Return(model.User{}, fmt.Errorf("%w: %v", dto.ErrPreconditionFailed, "changed by another request"))
+
+				// when
+				user, err := userService.UpdateProfile("1", userRequest, &version)
+
+				// then
+				Expect(errors.Is(err, dto.ErrPreconditionFailed)).To(BeTrue())
+				Expect(user).To(Equal(model.User{}))
+			})
+		})
+		Context("when auto fill of times is disabled", func() {
+			It("should save the setting", func() {
+				// given
+				mockUser.AutoFillTimes = true
+				userRequest.AutoFillTimes = util.Bool(false)
+				updatedUser := mockUser
+				updatedUser.AutoFillTimes = false
+				userRepoMock.EXPECT().GetByID("1").Return(mockUser, nil)
+				userRepoMock.EXPECT().Save(updatedUser).Return(updatedUser, nil)
+
+				// when
+				user, err := userService.UpdateProfile("1", userRequest, nil)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(user.AutoFillTimes).To(BeFalse())
+			})
+		})
+		Context("when auto fill of times is omitted", func() {
+			It("should keep the setting", func() {
+				// given
+				mockUser.AutoFillTimes = true
+				userRepoMock.EXPECT().GetByID("1").Return(mockUser, nil)
+				userRepoMock.EXPECT().Save(mockUser).Return(mockUser, nil)
+
+				// when
+				user, err := userService.UpdateProfile("1", userRequest, nil)
+
+				// then
+				Expect(err).To(BeNil())
+				Expect(user.AutoFillTimes).To(BeTrue())
+			})
+		})
 		Context("when user does not exist", func() {
 			It("should return error", func() {
 				// given
 				userRepoMock.EXPECT().GetByID("1").Return(model.User{}, errors.New("user not found"))
 				// when
-				user, err := userService.UpdateProfile("1", userRequest)
+				user, err := userService.UpdateProfile("1", userRequest, nil)
 
 				// then
 				Expect(err.Error()).To(Equal("user not found"))
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"fmt"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/avialog/backend/internal/model"
+	"time"
+)
+
+// sameVersion compares update times at the precision of the database.
+func sameVersion(baseUpdatedAt, updatedAt time.Time) bool {
+	return baseUpdatedAt.Truncate(time.Microsecond).Equal(updatedAt.Truncate(time.Microsecond))
+}
+
+// checkVersion rejects a change based on a version of the item older than the current one. A change without a version
+// is applied to any version.
+func checkVersion(version *time.Time, updatedAt time.Time, item string) error {
+	if version != nil && !sameVersion(*version, updatedAt) {
+		return fmt.Errorf("%w: %s changed since the given version", dto.ErrPreconditionFailed, item)
+	}
+	return nil
+}
+
+// logbookEntryVersion is the time the entry was last changed, a change of its signature request changes the entry too.
+func logbookEntryVersion(flight model.Flight, signature *model.Signature) time.Time {
+	if signature != nil && signature.UpdatedAt.After(flight.UpdatedAt) {
+		return signature.UpdatedAt
+	}
+	return flight.UpdatedAt
+}
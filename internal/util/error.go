@@ -1,16 +1,30 @@
 package util
 
-import "github.com/gin-gonic/gin"
+import (
+	"errors"
+	"github.com/avialog/backend/internal/dto"
+	"github.com/gin-gonic/gin"
+)
 
 func NewError(ctx *gin.Context, status int, err error) {
 	er := HTTPError{
 		Code:    status,
 		Message: err.Error(),
 	}
+	var validationError *dto.ValidationError
+	if errors.As(err, &validationError) {
+		er.Errors = validationError.Errors
+	}
+	var conflictError *dto.ConflictError
+	if errors.As(err, &conflictError) {
+		er.Conflicts = conflictError.Conflicts
+	}
 	ctx.JSON(status, er)
 }
 
 type HTTPError struct {
-	Code    int    `json:"code" binding:"required"`
-	Message string `json:"message" binding:"required"`
+	Code      int                  `json:"code" binding:"required"`
+	Message   string               `json:"message" binding:"required"`
+	Errors    []dto.FieldError     `json:"errors,omitempty"`
+	Conflicts []dto.FlightConflict `json:"conflicts,omitempty"`
 }
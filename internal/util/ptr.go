@@ -17,3 +17,11 @@ func Uint(u uint) *uint {
 func Int64(i int64) *int64 {
 	return &i
 }
+
+func Int(i int) *int {
+	return &i
+}
+
+func Bool(b bool) *bool {
+	return &b
+}
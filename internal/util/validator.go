@@ -28,6 +28,14 @@ func init() {
 		logrus.Panic(err)
 	}
 
+	err = validate.RegisterValidation("aircraft_category", func(fl validator.FieldLevel) bool {
+		category := fl.Field().String()
+		return slices.Contains(model.AvailableAircraftCategories, model.AircraftCategory(category))
+	})
+	if err != nil {
+		logrus.Panic(err)
+	}
+
 	err = validate.RegisterValidation("style", func(fl validator.FieldLevel) bool {
 		style := fl.Field().String()
 		return slices.Contains(model.AvailableStyles, model.Style(style))
@@ -35,6 +43,38 @@ func init() {
 	if err != nil {
 		logrus.Panic(err)
 	}
+
+	err = validate.RegisterValidation("licence_type", func(fl validator.FieldLevel) bool {
+		licenceType := fl.Field().String()
+		return slices.Contains(model.AvailableLicenceTypes, model.LicenceType(licenceType))
+	})
+	if err != nil {
+		logrus.Panic(err)
+	}
+
+	err = validate.RegisterValidation("rating_kind", func(fl validator.FieldLevel) bool {
+		kind := fl.Field().String()
+		return slices.Contains(model.AvailableRatingKinds, model.RatingKind(kind))
+	})
+	if err != nil {
+		logrus.Panic(err)
+	}
+
+	err = validate.RegisterValidation("rule_set", func(fl validator.FieldLevel) bool {
+		ruleSet := fl.Field().String()
+		return slices.Contains(model.AvailableRuleSets, model.RuleSet(ruleSet))
+	})
+	if err != nil {
+		logrus.Panic(err)
+	}
+
+	err = validate.RegisterValidation("medical_class", func(fl validator.FieldLevel) bool {
+		class := fl.Field().String()
+		return slices.Contains(model.AvailableMedicalClasses, model.MedicalClass(class))
+	})
+	if err != nil {
+		logrus.Panic(err)
+	}
 }
 
 func GetValidator() *validator.Validate {